@@ -170,6 +170,7 @@ func (m *MailDigestAction) Run(ctx context.Context, channels *actions.RunnableCh
 			ContentMarkdown: md,
 			To:              []*mailer.User{user},
 		},
+		InQueue: true,
 	})
 	if err != nil {
 		return input.WithError(err), err
@@ -25,6 +25,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,7 +43,8 @@ const (
 
 // BOLT DAO MANAGEMENT
 var (
-	bucketName = []byte("MailerQueue")
+	bucketName            = []byte("MailerQueue")
+	deadLettersBucketName = []byte("MailerDeadLetters")
 )
 
 // BoltQueue defines a queue for the mails backed by a Bolt DB.
@@ -72,7 +74,10 @@ func NewBoltQueue(fileName string, deleteOnClose ...bool) (*BoltQueue, error) {
 	}
 	bs.db = db
 	e2 := db.Update(func(tx *bolt.Tx) error {
-		_, e := tx.CreateBucketIfNotExists(bucketName)
+		if _, e := tx.CreateBucketIfNotExists(bucketName); e != nil {
+			return e
+		}
+		_, e := tx.CreateBucketIfNotExists(deadLettersBucketName)
 		return e
 	})
 	return bs, e2
@@ -110,14 +115,17 @@ func (b *BoltQueue) Push(email *mailer.Mail) error {
 }
 
 // Consume acquires the lock and send mails that are in the queue by batches,
-// sending at most 100 mails by batch.
+// sending at most 100 mails by batch. Mails waiting for their next retry are skipped,
+// and mails failing more than MaxSendRetries times are moved to the dead letters.
 func (b *BoltQueue) Consume(sendHandler func(email *mailer.Mail) error) error {
 
 	var output error
+	now := timeNow()
 
 	b.db.Update(func(tx *bolt.Tx) error {
 
-		b := tx.Bucket([]byte("MailerQueue"))
+		b := tx.Bucket(bucketName)
+		dead := tx.Bucket(deadLettersBucketName)
 		c := b.Cursor()
 		var errStack []string
 		// Launch by batch
@@ -133,19 +141,31 @@ func (b *BoltQueue) Consume(sendHandler func(email *mailer.Mail) error) error {
 				continue
 			}
 
+			if isDelayed(&em, now) {
+				continue
+			}
+
 			// Stream mail
-			if err = sendHandler(&em); err != nil {
+			if err = sendHandler(&em); err == ErrThrottled {
+				// Keep mail for next batch
+				continue
+			} else if err != nil {
 				tos := getTos(&em)
-				if em.Retries <= MaxSendRetries {
+				if !markFailed(&em, err, now) {
 					// Update number of tries and re-put mail in the queue.
-					em.Retries++
-					em.SendErrors = append(em.SendErrors, err.Error())
 					marsh, _ := json.Marshal(&em)
 					b.Put(k, marsh)
 					errStack = append(errStack, fmt.Sprintf("cannot send email to [%s], cause: %s", tos, err.Error()))
 					continue
-				} else {
-					errStack = append(errStack, fmt.Sprintf("max number of retries reached for recipient [%s], cause: %s", tos, err.Error()))
+				}
+				errStack = append(errStack, fmt.Sprintf("max number of retries reached for recipient [%s], moving to dead letters, cause: %s", tos, err.Error()))
+				if e := putDeadLetter(dead, &em, now); e != nil {
+					// Keep mail in the queue rather than losing it, it is moved after its next failure
+					em.NextRetry = now.Add(RetryBackoff(em.Retries)).Unix()
+					marsh, _ := json.Marshal(&em)
+					b.Put(k, marsh)
+					errStack = append(errStack, e.Error())
+					continue
 				}
 			}
 
@@ -168,6 +188,76 @@ func (b *BoltQueue) Consume(sendHandler func(email *mailer.Mail) error) error {
 	return output
 }
 
+// DeadLetters lists the mails that could not be sent.
+func (b *BoltQueue) DeadLetters() (letters []*mailer.DeadLetter, e error) {
+
+	e = b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(deadLettersBucketName).ForEach(func(k, v []byte) error {
+			letter := &mailer.DeadLetter{}
+			if err := json.Unmarshal(v, letter); err != nil {
+				return err
+			}
+			letters = append(letters, letter)
+			return nil
+		})
+	})
+	return
+}
+
+// Replay pushes dead letters back to the queue with a fresh retry state, or deletes them if purge is true.
+func (b *BoltQueue) Replay(ids []string, purge bool) (count int, e error) {
+
+	e = b.db.Update(func(tx *bolt.Tx) error {
+		queue := tx.Bucket(bucketName)
+		dead := tx.Bucket(deadLettersBucketName)
+		var processed [][]byte
+		c := dead.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			letter := &mailer.DeadLetter{}
+			if err := json.Unmarshal(v, letter); err != nil {
+				return err
+			}
+			if !matchesIds(letter.ID, ids) {
+				continue
+			}
+			if !purge && letter.Mail != nil {
+				resetRetries(letter.Mail)
+				id, _ := queue.NextSequence()
+				buf, err := json.Marshal(letter.Mail)
+				if err != nil {
+					return err
+				}
+				if err := queue.Put(itob(int(id)), buf); err != nil {
+					return err
+				}
+			}
+			processed = append(processed, k)
+		}
+		for _, k := range processed {
+			if err := dead.Delete(k); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return
+}
+
+// putDeadLetter stores a mail in the dead letters bucket.
+func putDeadLetter(bucket *bolt.Bucket, em *mailer.Mail, now time.Time) error {
+	id, _ := bucket.NextSequence()
+	buf, err := json.Marshal(&mailer.DeadLetter{
+		ID:       strconv.FormatUint(id, 10),
+		Mail:     em,
+		FailedAt: now.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return bucket.Put(itob(int(id)), buf)
+}
+
 // itob returns an 8-byte big endian representation of v.
 func itob(v int) []byte {
 	b := make([]byte, 8)
@@ -44,6 +44,11 @@ type Queue interface {
 	Push(email *mailer.Mail) error
 	Consume(func(email *mailer.Mail) error) error
 	Close() error
+	// DeadLetters lists the mails that could not be sent after MaxSendRetries attempts.
+	DeadLetters() ([]*mailer.DeadLetter, error)
+	// Replay pushes dead letters back to the queue, or discards them if purge is true.
+	// All dead letters are processed if ids is empty.
+	Replay(ids []string, purge bool) (int, error)
 }
 
 type Sender interface {
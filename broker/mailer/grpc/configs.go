@@ -168,6 +168,13 @@ var ExposedConfigs = &forms.Form{
 				},
 				Default: "boltdb",
 			},
+			&forms.FormField{
+				Name:        "throttle",
+				Type:        forms.ParamTextarea,
+				Label:       "Mail.Config.Throttle.Label",
+				Description: "Mail.Config.Throttle.Description",
+				Mandatory:   false,
+			},
 		},
 	}},
 }
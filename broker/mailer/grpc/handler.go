@@ -50,6 +50,8 @@ type Handler struct {
 	senderConfig configx.Values
 	queue        mailer.Queue
 	sender       mailer.Sender
+	throttleSpec string
+	throttler    *mailer.DomainThrottler
 }
 
 func NewHandler(serviceCtx context.Context, conf configx.Values) (*Handler, error) {
@@ -178,6 +180,9 @@ func (h *Handler) ConsumeQueue(ctx context.Context, req *proto.ConsumeQueueReque
 			log.Logger(ctx).Error("ConsumeQueue: trying to send empty email")
 			return fmt.Errorf("cannot send empty email")
 		}
+		if h.throttler != nil && !h.throttler.Allow(em) {
+			return mailer.ErrThrottled
+		}
 		counter++
 		return h.sender.Send(em)
 	}
@@ -193,6 +198,33 @@ func (h *Handler) ConsumeQueue(ctx context.Context, req *proto.ConsumeQueueReque
 	return nil
 }
 
+// ListDeadLetters lists mails that could not be sent after all retries
+func (h *Handler) ListDeadLetters(ctx context.Context, req *proto.ListDeadLettersRequest, rsp *proto.ListDeadLettersResponse) error {
+
+	letters, e := h.queue.DeadLetters()
+	if e != nil {
+		return e
+	}
+	rsp.DeadLetters = letters
+	return nil
+}
+
+// ReplayDeadLetters pushes dead letters back to the queue, or purges them
+func (h *Handler) ReplayDeadLetters(ctx context.Context, req *proto.ReplayDeadLettersRequest, rsp *proto.ReplayDeadLettersResponse) error {
+
+	count, e := h.queue.Replay(req.IDs, req.Purge)
+	if e != nil {
+		return e
+	}
+	rsp.Count = int64(count)
+	if req.Purge {
+		log.Auditer(ctx).Info(fmt.Sprintf("Purged %d mails from dead letters", count))
+	} else {
+		log.Auditer(ctx).Info(fmt.Sprintf("Pushed %d mails from dead letters back to the queue", count))
+	}
+	return nil
+}
+
 func (h *Handler) parseConf(conf configx.Values) (queueName string, queueConfig configx.Values, senderName string, senderConfig configx.Values) {
 
 	// Defaults
@@ -242,6 +274,14 @@ func (h *Handler) initFromConf(ctx context.Context, conf configx.Values, check b
 	}
 
 	log.Logger(ctx).Info("Starting mailer with sender '" + senderName + "'")
+	throttleSpec := conf.Val("throttle").String()
+	if throttler, er := mailer.ParseDomainThrottler(throttleSpec); er == nil {
+		h.throttler = throttler
+	} else {
+		log.Logger(ctx).Error("Ignoring invalid throttling configuration", zap.Error(er))
+		h.throttler = nil
+	}
+	h.throttleSpec = throttleSpec
 	h.sender = sender
 	h.queueName = queueName
 	h.queueConfig = queueConfig
@@ -262,7 +302,7 @@ func (h *Handler) checkConfigChange(ctx context.Context, check bool) error {
 	m1, _ := json.Marshal(senderConfig)
 	m2, _ := json.Marshal(h.senderConfig)
 
-	if queueName != h.queueName || senderName != h.senderName || string(m1) != string(m2) {
+	if queueName != h.queueName || senderName != h.senderName || string(m1) != string(m2) || cfg.Val("throttle").String() != h.throttleSpec {
 		log.Logger(ctx).Info("Mailer configuration has changed. Refreshing sender and queue")
 		return h.initFromConf(ctx, cfg, check)
 	}
@@ -20,40 +20,86 @@
 
 package mailer
 
-import "github.com/pydio/cells/common/proto/mailer"
+import (
+	"strconv"
+	"sync"
+
+	"github.com/pydio/cells/common/proto/mailer"
+)
 
 type memQueue struct {
+	sync.Mutex
 	list []*mailer.Mail
+	dead []*mailer.DeadLetter
+	seq  int
 }
 
 func newInMemoryQueue() *memQueue {
-	m := new(memQueue)
-	m.list = make([]*mailer.Mail, 1)
-	return m
+	return &memQueue{}
 }
 
-func (m memQueue) Close() error {
-	m.list = make([]*mailer.Mail, 1)
+func (m *memQueue) Close() error {
+	m.Lock()
+	defer m.Unlock()
+	m.list = nil
+	m.dead = nil
 	return nil
 }
 
-func (m memQueue) Push(email *mailer.Mail) error {
+func (m *memQueue) Push(email *mailer.Mail) error {
+	m.Lock()
+	defer m.Unlock()
 	m.list = append(m.list, email)
 	return nil
 }
 
-func (m memQueue) Consume(mh func(email *mailer.Mail) error) error {
-	var i int = 0
-	for i = range m.list {
-		em := m.list[i]
-		if err := mh(em); err != nil {
-			i++
-			break
+func (m *memQueue) Consume(mh func(email *mailer.Mail) error) error {
+	m.Lock()
+	defer m.Unlock()
+	now := timeNow()
+	var kept []*mailer.Mail
+	for _, em := range m.list {
+		if isDelayed(em, now) {
+			kept = append(kept, em)
+			continue
+		}
+		if err := mh(em); err == ErrThrottled {
+			kept = append(kept, em)
+		} else if err != nil {
+			if !markFailed(em, err, now) {
+				kept = append(kept, em)
+				continue
+			}
+			m.seq++
+			m.dead = append(m.dead, &mailer.DeadLetter{ID: strconv.Itoa(m.seq), Mail: em, FailedAt: now.Unix()})
 		}
 	}
+	m.list = kept
+	return nil
+}
 
-	if i < len(m.list) {
-		m.list = m.list[:i]
+func (m *memQueue) DeadLetters() ([]*mailer.DeadLetter, error) {
+	m.Lock()
+	defer m.Unlock()
+	return append([]*mailer.DeadLetter{}, m.dead...), nil
+}
+
+func (m *memQueue) Replay(ids []string, purge bool) (int, error) {
+	m.Lock()
+	defer m.Unlock()
+	var kept []*mailer.DeadLetter
+	count := 0
+	for _, letter := range m.dead {
+		if !matchesIds(letter.ID, ids) {
+			kept = append(kept, letter)
+			continue
+		}
+		if !purge {
+			resetRetries(letter.Mail)
+			m.list = append(m.list, letter.Mail)
+		}
+		count++
 	}
-	return nil
+	m.dead = kept
+	return count, nil
 }
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package mailer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pydio/cells/common/proto/mailer"
+)
+
+var (
+	// ErrThrottled can be returned by a Consume handler to keep a mail in the queue without counting a failed attempt.
+	ErrThrottled = errors.New("mail throttled")
+
+	// RetryInitialBackoff is the delay before retrying a mail after its first failure.
+	RetryInitialBackoff = 5 * time.Minute
+	// RetryMaxBackoff caps the delay between two attempts.
+	RetryMaxBackoff = 2 * time.Hour
+
+	timeNow = time.Now
+)
+
+// RetryBackoff computes the delay before the next attempt, doubling after each failed retry.
+func RetryBackoff(retries int32) time.Duration {
+	backoff := RetryInitialBackoff
+	for i := int32(1); i < retries; i++ {
+		backoff *= 2
+		if backoff >= RetryMaxBackoff {
+			return RetryMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// isDelayed checks if the mail is waiting for its next retry.
+func isDelayed(em *mailer.Mail, now time.Time) bool {
+	return em.NextRetry > now.Unix()
+}
+
+// markFailed updates the retry state of a mail after a failed attempt and
+// returns true if it has reached the max number of retries.
+func markFailed(em *mailer.Mail, err error, now time.Time) bool {
+	em.Retries++
+	em.SendErrors = append(em.SendErrors, err.Error())
+	if em.Retries > MaxSendRetries {
+		return true
+	}
+	em.NextRetry = now.Add(RetryBackoff(em.Retries)).Unix()
+	return false
+}
+
+// resetRetries clears the retry state of a mail before pushing it back to the queue.
+func resetRetries(em *mailer.Mail) {
+	em.Retries = 0
+	em.NextRetry = 0
+	em.SendErrors = nil
+}
+
+// matchesIds checks if a dead letter should be processed by Replay.
+func matchesIds(id string, ids []string) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
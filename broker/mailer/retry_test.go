@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/x/configx"
+)
+
+// fakeSmtpServer is a minimal SMTP server accepting all mails, or rejecting all connections when down.
+type fakeSmtpServer struct {
+	sync.Mutex
+	listener net.Listener
+	down     bool
+	received []string
+}
+
+func newFakeSmtpServer() (*fakeSmtpServer, error) {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		return nil, e
+	}
+	s := &fakeSmtpServer{listener: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, nil
+}
+
+func (s *fakeSmtpServer) setDown(down bool) {
+	s.Lock()
+	defer s.Unlock()
+	s.down = down
+}
+
+func (s *fakeSmtpServer) messages() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string{}, s.received...)
+}
+
+func (s *fakeSmtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	s.Lock()
+	down := s.down
+	s.Unlock()
+	if down {
+		fmt.Fprint(conn, "421 4.3.2 Service not available\r\n")
+		return
+	}
+	fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "DATA"):
+			fmt.Fprint(conn, "354 Go ahead\r\n")
+			var data []string
+			for {
+				l, e := r.ReadString('\n')
+				if e != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data = append(data, l)
+			}
+			s.Lock()
+			s.received = append(s.received, strings.Join(data, ""))
+			s.Unlock()
+			fmt.Fprint(conn, "250 OK\r\n")
+		case strings.HasPrefix(cmd, "QUIT"):
+			fmt.Fprint(conn, "221 Bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "250 OK\r\n")
+		}
+	}
+}
+
+func testMail(to string) *mailer.Mail {
+	return &mailer.Mail{
+		From:         &mailer.User{Address: "sender@example.com", Name: "Sender"},
+		To:           []*mailer.User{{Address: to, Name: "Recipient"}},
+		Subject:      "Retry test",
+		ContentPlain: "This is a test",
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	Convey("Backoff doubles after each retry and is capped", t, func() {
+		So(RetryBackoff(1), ShouldEqual, RetryInitialBackoff)
+		So(RetryBackoff(2), ShouldEqual, 2*RetryInitialBackoff)
+		So(RetryBackoff(3), ShouldEqual, 4*RetryInitialBackoff)
+		So(RetryBackoff(20), ShouldEqual, RetryMaxBackoff)
+	})
+}
+
+func TestBoltQueueRetries(t *testing.T) {
+
+	Convey("Failed mails are retried with backoff, moved to dead letters and replayed", t, func() {
+
+		server, e := newFakeSmtpServer()
+		So(e, ShouldBeNil)
+		defer server.listener.Close()
+
+		conf := configx.New()
+		conf.Val("host").Set("127.0.0.1")
+		conf.Val("port").Set(server.listener.Addr().(*net.TCPAddr).Port)
+		conf.Val("user").Set("user")
+		conf.Val("clearPass").Set("pass")
+		sender := &Smtp{}
+		So(sender.Configure(context.Background(), conf), ShouldBeNil)
+
+		now := time.Now()
+		timeNow = func() time.Time { return now }
+		defer func() { timeNow = time.Now }()
+
+		queue, e := NewBoltQueue(filepath.Join(os.TempDir(), "bolt-retry-test.db"), true)
+		So(e, ShouldBeNil)
+		defer queue.Close()
+
+		So(queue.Push(testMail("recipient@example.com")), ShouldBeNil)
+
+		// SMTP outage
+		server.setDown(true)
+		calls := 0
+		handler := func(em *mailer.Mail) error {
+			calls++
+			return sender.Send(em)
+		}
+		So(queue.Consume(handler), ShouldNotBeNil)
+		So(calls, ShouldEqual, 1)
+
+		// Mail is delayed until its next retry
+		server.setDown(false)
+		So(queue.Consume(handler), ShouldBeNil)
+		So(calls, ShouldEqual, 1)
+		So(server.messages(), ShouldHaveLength, 0)
+
+		// Fail until max retries is reached
+		server.setDown(true)
+		for i := 0; i < MaxSendRetries; i++ {
+			now = now.Add(RetryMaxBackoff)
+			So(queue.Consume(handler), ShouldNotBeNil)
+		}
+		So(calls, ShouldEqual, MaxSendRetries+1)
+		letters, e := queue.DeadLetters()
+		So(e, ShouldBeNil)
+		So(letters, ShouldHaveLength, 1)
+		So(letters[0].Mail.SendErrors, ShouldHaveLength, MaxSendRetries+1)
+		So(letters[0].FailedAt, ShouldEqual, now.Unix())
+
+		// Queue is empty
+		now = now.Add(RetryMaxBackoff)
+		So(queue.Consume(handler), ShouldBeNil)
+		So(calls, ShouldEqual, MaxSendRetries+1)
+
+		// Replay once server is back
+		server.setDown(false)
+		count, e := queue.Replay(nil, false)
+		So(e, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+		letters, _ = queue.DeadLetters()
+		So(letters, ShouldHaveLength, 0)
+		So(queue.Consume(handler), ShouldBeNil)
+		So(server.messages(), ShouldHaveLength, 1)
+		So(server.messages()[0], ShouldContainSubstring, "Subject: Retry test")
+
+	})
+
+}
+
+func TestMemQueueDeadLetters(t *testing.T) {
+
+	Convey("Memory queue supports retries, throttling and dead letters", t, func() {
+
+		now := time.Now()
+		timeNow = func() time.Time { return now }
+		defer func() { timeNow = time.Now }()
+
+		queue := newInMemoryQueue()
+		So(queue.Push(testMail("one@example.com")), ShouldBeNil)
+		So(queue.Push(testMail("two@example.com")), ShouldBeNil)
+
+		// Throttled mails are kept without counting a retry
+		So(queue.Consume(func(em *mailer.Mail) error { return ErrThrottled }), ShouldBeNil)
+		So(queue.list, ShouldHaveLength, 2)
+		So(queue.list[0].Retries, ShouldEqual, 0)
+
+		for i := 0; i <= MaxSendRetries; i++ {
+			So(queue.Consume(func(em *mailer.Mail) error { return fmt.Errorf("connection refused") }), ShouldBeNil)
+			now = now.Add(RetryMaxBackoff)
+		}
+		So(queue.list, ShouldHaveLength, 0)
+		letters, _ := queue.DeadLetters()
+		So(letters, ShouldHaveLength, 2)
+
+		count, _ := queue.Replay([]string{letters[0].ID}, true)
+		So(count, ShouldEqual, 1)
+		count, _ = queue.Replay(nil, false)
+		So(count, ShouldEqual, 1)
+		letters, _ = queue.DeadLetters()
+		So(letters, ShouldHaveLength, 0)
+
+		var sent []string
+		So(queue.Consume(func(em *mailer.Mail) error {
+			sent = append(sent, em.To[0].Address)
+			return nil
+		}), ShouldBeNil)
+		So(sent, ShouldResemble, []string{"two@example.com"})
+
+	})
+
+}
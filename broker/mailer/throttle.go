@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package mailer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/pydio/cells/common/proto/mailer"
+)
+
+// DomainThrottler limits the number of mails sent per minute to each recipient domain,
+// to respect the quotas of the mail providers.
+type DomainThrottler struct {
+	sync.Mutex
+	// DefaultRate applies to domains that have no specific rate, 0 means unlimited.
+	DefaultRate int
+	// Rates are the maximum number of mails per minute for a given domain.
+	Rates    map[string]int
+	limiters map[string]*rate.Limiter
+}
+
+// ParseDomainThrottler reads a throttling specification made of one "domain=rate" per line,
+// rate being a number of mails per minute. The "*" domain sets the default rate.
+func ParseDomainThrottler(spec string) (*DomainThrottler, error) {
+	t := &DomainThrottler{
+		Rates:    make(map[string]int),
+		limiters: make(map[string]*rate.Limiter),
+	}
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid throttling rule %s, expected domain=rate", line)
+		}
+		r, e := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if e != nil || r < 0 {
+			return nil, fmt.Errorf("invalid rate in throttling rule %s", line)
+		}
+		domain := strings.ToLower(strings.TrimSpace(parts[0]))
+		if domain == "*" {
+			t.DefaultRate = r
+		} else {
+			t.Rates[domain] = r
+		}
+	}
+	return t, nil
+}
+
+// Allow checks if the mail can be sent now to all its recipients, consuming one slot of the quota of each
+// recipient domain if so. No slot is consumed if any of the domains is over quota.
+func (t *DomainThrottler) Allow(em *mailer.Mail) bool {
+	t.Lock()
+	defer t.Unlock()
+	now := timeNow()
+	var reservations []*rate.Reservation
+	for _, domain := range recipientDomains(em) {
+		limiter := t.limiter(domain)
+		if limiter == nil {
+			continue
+		}
+		r := limiter.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+			return false
+		}
+		reservations = append(reservations, r)
+	}
+	return true
+}
+
+// limiter returns the limiter of a domain, or nil if the domain is not limited.
+func (t *DomainThrottler) limiter(domain string) *rate.Limiter {
+	perMinute, ok := t.Rates[domain]
+	if !ok {
+		perMinute = t.DefaultRate
+	}
+	if perMinute == 0 {
+		return nil
+	}
+	limiter, ok := t.limiters[domain]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)
+		t.limiters[domain] = limiter
+	}
+	return limiter
+}
+
+// recipientDomains lists the distinct domains of the To and Cc recipients.
+func recipientDomains(em *mailer.Mail) (domains []string) {
+	seen := make(map[string]bool)
+	for _, u := range append(em.GetTo(), em.GetCc()...) {
+		domain := ""
+		if i := strings.LastIndex(u.GetAddress(), "@"); i > -1 {
+			domain = strings.ToLower(u.GetAddress()[i+1:])
+		}
+		if !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	return
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package mailer
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/mailer"
+)
+
+func TestDomainThrottler(t *testing.T) {
+
+	Convey("Parse throttling rules", t, func() {
+		_, e := ParseDomainThrottler("example.com")
+		So(e, ShouldNotBeNil)
+		_, e = ParseDomainThrottler("example.com=ten")
+		So(e, ShouldNotBeNil)
+		th, e := ParseDomainThrottler("Example.com = 2\n\n*=10\n")
+		So(e, ShouldBeNil)
+		So(th.Rates["example.com"], ShouldEqual, 2)
+		So(th.DefaultRate, ShouldEqual, 10)
+	})
+
+	Convey("Limit mails per recipient domain", t, func() {
+
+		now := time.Now()
+		timeNow = func() time.Time { return now }
+		defer func() { timeNow = time.Now }()
+
+		th, _ := ParseDomainThrottler("example.com=2")
+		So(th.Allow(testMail("one@example.com")), ShouldBeTrue)
+		So(th.Allow(testMail("two@EXAMPLE.com")), ShouldBeTrue)
+		So(th.Allow(testMail("three@example.com")), ShouldBeFalse)
+		// No default rate
+		for i := 0; i < 10; i++ {
+			So(th.Allow(testMail("other@example.org")), ShouldBeTrue)
+		}
+		// One slot every 30s
+		now = now.Add(30 * time.Second)
+		So(th.Allow(testMail("three@example.com")), ShouldBeTrue)
+		So(th.Allow(testMail("four@example.com")), ShouldBeFalse)
+
+	})
+
+	Convey("Limit mails on all recipient domains", t, func() {
+
+		now := time.Now()
+		timeNow = func() time.Time { return now }
+		defer func() { timeNow = time.Now }()
+
+		th, _ := ParseDomainThrottler("example.com=1\nexample.org=2")
+		em := testMail("one@example.org")
+		em.Cc = []*mailer.User{{Address: "one@example.com"}}
+		So(th.Allow(em), ShouldBeTrue)
+		// example.com is over quota, the example.org slot is not consumed
+		So(th.Allow(em), ShouldBeFalse)
+		So(th.Allow(testMail("two@example.org")), ShouldBeTrue)
+		So(th.Allow(testMail("three@example.org")), ShouldBeFalse)
+
+	})
+
+}
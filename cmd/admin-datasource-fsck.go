@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	p "github.com/manifoldco/promptui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/sync"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+var (
+	fsckStorage     bool
+	fsckApply       bool
+	fsckAuto        bool
+	fsckInteractive bool
+	fsckFixes       []string
+)
+
+var dsFsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check and repair the index of a datasource",
+	Long: `
+DESCRIPTION
+
+  Report inconsistencies of a datasource index: orphan nodes, duplicate paths and broken materialized paths.
+  With --storage, the index is also compared with the object storage.
+
+  By default, the command runs as a dry-run and only reports issues. Fixes are applied with --apply, either
+  selected per issue with --fix, or the default fix of all issues with --auto. Use --interactive to choose
+  the fixes one by one.
+
+  Available fixes are:
+   - reparent[:/folder] : move an orphan node under a folder, defaults to the datasource root
+   - delete[:uuid1,uuid2] : delete a node, or the given duplicates (defaults to all duplicates but the first one)
+   - rehash : recompute the level and hashes of a node from its materialized path
+
+EXAMPLES
+
+  1. Report issues, including storage
+  $ ` + os.Args[0] + ` admin datasource fsck pydiods1 --storage
+
+  2. Apply default fixes
+  $ ` + os.Args[0] + ` admin datasource fsck pydiods1 --auto --apply
+
+  3. Apply selected fixes
+  $ ` + os.Args[0] + ` admin datasource fsck pydiods1 --apply --fix orphan-xxxx=reparent:/recovered --fix mpath-yyyy=rehash
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		req := &sync.FsckRequest{CheckStorage: fsckStorage}
+		for _, f := range fsckFixes {
+			fix, e := parseFsckFix(f)
+			if e != nil {
+				cmd.Println(e.Error())
+				return
+			}
+			req.Fixes = append(req.Fixes, fix)
+		}
+		cli := sync.NewIndexFsckClient(common.ServiceGrpcNamespace_+common.ServiceDataSync_+args[0], defaults.NewClient())
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		defer cancel()
+		ctx = context2.WithUserNameMetadata(ctx, common.PydioSystemUsername)
+
+		resp, e := cli.Fsck(ctx, req)
+		if e != nil {
+			cmd.Println("Fsck failed: " + e.Error())
+			return
+		}
+		if len(resp.Issues) == 0 {
+			cmd.Println("No issues found")
+			return
+		}
+		renderFsckIssues(cmd, resp.Issues)
+
+		if fsckInteractive {
+			req.Fixes = promptFsckFixes(resp.Issues)
+			if len(req.Fixes) == 0 {
+				return
+			}
+			fsckApply = true
+		} else if !fsckAuto && len(req.Fixes) == 0 {
+			return
+		}
+		if !fsckApply {
+			cmd.Println("Dry-run: no fixes were applied, use --apply to apply them")
+			return
+		}
+		req.Apply = true
+		req.AutoFix = fsckAuto
+		if resp, e = cli.Fsck(ctx, req); e != nil {
+			cmd.Println("Fsck failed: " + e.Error())
+			return
+		}
+		renderFsckIssues(cmd, resp.Issues)
+	},
+}
+
+// parseFsckFix parses fixes in the form issueId=type[:argument]
+func parseFsckFix(s string) (*sync.FsckFix, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid fix %s, expecting issueId=fix", s)
+	}
+	fix := &sync.FsckFix{IssueId: parts[0]}
+	action := strings.SplitN(parts[1], ":", 2)
+	switch strings.ToLower(action[0]) {
+	case "reparent":
+		fix.Type = sync.FsckFixType_Reparent
+		if len(action) > 1 {
+			fix.ReparentPath = action[1]
+		}
+	case "delete":
+		fix.Type = sync.FsckFixType_Delete
+		if len(action) > 1 {
+			fix.Uuids = strings.Split(action[1], ",")
+		}
+	case "rehash":
+		fix.Type = sync.FsckFixType_Rehash
+	default:
+		return nil, fmt.Errorf("unknown fix %s, expecting reparent, delete or rehash", action[0])
+	}
+	return fix, nil
+}
+
+func renderFsckIssues(cmd *cobra.Command, issues []*sync.FsckIssue) {
+	table := tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader([]string{"Id", "Type", "Name", "Path", "MPath", "Fixes", "Applied"})
+	for _, i := range issues {
+		var fixes []string
+		for _, f := range i.Fixes {
+			fixes = append(fixes, strings.ToLower(f.String()))
+		}
+		applied := ""
+		if i.Error != "" {
+			applied = "Error: " + i.Error
+		} else if i.Applied != sync.FsckFixType_NoFix {
+			applied = strings.ToLower(i.Applied.String())
+		}
+		table.Append([]string{i.Id, i.Type.String(), i.Name, i.Path, i.MPath, strings.Join(fixes, ", "), applied})
+	}
+	table.Render()
+}
+
+// promptFsckFixes asks the fix to apply on each issue
+func promptFsckFixes(issues []*sync.FsckIssue) (fixes []*sync.FsckFix) {
+	for _, i := range issues {
+		if len(i.Fixes) == 0 {
+			continue
+		}
+		items := []string{"skip"}
+		for _, f := range i.Fixes {
+			items = append(items, strings.ToLower(f.String()))
+		}
+		s := p.Select{Label: fmt.Sprintf("%s (%s %s)", i.Id, i.Type.String(), i.Name), Items: items}
+		idx, _, e := s.Run()
+		if e != nil {
+			return nil
+		}
+		if idx == 0 {
+			continue
+		}
+		fix := &sync.FsckFix{IssueId: i.Id, Type: i.Fixes[idx-1]}
+		if fix.Type == sync.FsckFixType_Reparent {
+			folder := p.Prompt{Label: "Target folder", Default: "/"}
+			if fix.ReparentPath, e = folder.Run(); e != nil {
+				return nil
+			}
+		}
+		fixes = append(fixes, fix)
+	}
+	if len(fixes) > 0 {
+		confirm := p.Prompt{Label: fmt.Sprintf("Apply %d fix(es)", len(fixes)), IsConfirm: true}
+		if _, e := confirm.Run(); e != nil {
+			return nil
+		}
+	}
+	return
+}
+
+func init() {
+	dsFsckCmd.Flags().BoolVar(&fsckStorage, "storage", false, "Compare the index with the object storage")
+	dsFsckCmd.Flags().BoolVar(&fsckApply, "apply", false, "Apply fixes, otherwise only report what would be done")
+	dsFsckCmd.Flags().BoolVar(&fsckAuto, "auto", false, "Select the default fix for all issues")
+	dsFsckCmd.Flags().BoolVarP(&fsckInteractive, "interactive", "i", false, "Interactively select the fixes to apply")
+	dsFsckCmd.Flags().StringArrayVar(&fsckFixes, "fix", []string{}, "Fix to apply, in the form issueId=reparent[:/folder], issueId=delete[:uuids] or issueId=rehash")
+	DataSourceCmd.AddCommand(dsFsckCmd)
+}
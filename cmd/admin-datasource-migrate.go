@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pborman/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+var (
+	migrateTargetFile     string
+	migrateNoCutover      bool
+	migrateNoStores       bool
+	migrateVersionsBucket string
+	migrateThumbsBucket   string
+	migrateMaxPasses      int
+)
+
+var dsMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move a datasource to another storage",
+	Long: `
+DESCRIPTION
+
+  Start a job copying all objects of a datasource to a new storage, then switching the datasource
+  to this storage. The datasource stays online during the copy: successive passes copy the objects
+  modified in the meantime, until a pass has nothing left to copy. Nodes UUIDs are kept, and the
+  versions and thumbnails buckets are migrated as well if they are hosted by this datasource.
+
+  The target storage is described by a JSON file using the datasource format, for instance:
+
+  {"StorageType":"S3", "ObjectsBucket":"new-bucket", "ApiKey":"key", "ApiSecret":"secret",
+   "StorageConfiguration":{"customEndpoint":"https://s3.example.com"}}
+
+  {"StorageType":"LOCAL", "StorageConfiguration":{"folder":"/mnt/storage/pydiods1"}}
+
+  Use --no-cutover to only copy and verify the data: running the command again later copies the last
+  modifications and performs the cutover. The previous configuration is kept in the job output.
+
+EXAMPLE
+
+  $ ` + os.Args[0] + ` admin datasource migrate pydiods1 --target-file s3.json --versions-bucket new-versions
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if migrateTargetFile == "" {
+			cmd.Println("Please provide the target storage with --target-file")
+			return
+		}
+		data, e := ioutil.ReadFile(migrateTargetFile)
+		if e != nil {
+			cmd.Println("Cannot read target file: " + e.Error())
+			return
+		}
+		var target object.DataSource
+		if e := jsonpb.UnmarshalString(string(data), &target); e != nil {
+			cmd.Println("Invalid target file: " + e.Error())
+			return
+		}
+		params := map[string]string{
+			"dataSource":     args[0],
+			"target":         string(data),
+			"versionsBucket": migrateVersionsBucket,
+			"thumbsBucket":   migrateThumbsBucket,
+			"maxPasses":      fmt.Sprintf("%d", migrateMaxPasses),
+		}
+		if migrateNoCutover {
+			params["cutover"] = "false"
+		}
+		if migrateNoStores {
+			params["stores"] = "false"
+		}
+		job := &jobs.Job{
+			ID:             "migrate-datasource-" + uuid.New(),
+			Owner:          common.PydioSystemUsername,
+			Label:          "Migrate datasource " + args[0] + " to " + target.StorageType.String() + " storage",
+			MaxConcurrency: 1,
+			AutoStart:      true,
+			Actions:        []*jobs.Action{{ID: "actions.datasource.migrate", Parameters: params}},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		ctx = context2.WithUserNameMetadata(ctx, common.PydioSystemUsername)
+		cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+		if _, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: job}); e != nil {
+			cmd.Println("Cannot start migration: " + e.Error())
+			return
+		}
+		cmd.Println("Migration started by job " + job.ID + ", follow its progress in the scheduler")
+	},
+}
+
+func init() {
+	dsMigrateCmd.Flags().StringVar(&migrateTargetFile, "target-file", "", "JSON file describing the target storage")
+	dsMigrateCmd.Flags().BoolVar(&migrateNoCutover, "no-cutover", false, "Only copy and verify the data, without switching the datasource")
+	dsMigrateCmd.Flags().BoolVar(&migrateNoStores, "no-stores", false, "Do not migrate the versions and thumbnails buckets")
+	dsMigrateCmd.Flags().StringVar(&migrateVersionsBucket, "versions-bucket", "", "Name of the versions bucket on a S3 target, defaults to the current name")
+	dsMigrateCmd.Flags().StringVar(&migrateThumbsBucket, "thumbs-bucket", "", "Name of the thumbnails bucket on a S3 target, defaults to the current name")
+	dsMigrateCmd.Flags().IntVar(&migrateMaxPasses, "max-passes", 3, "Maximum number of copy passes per bucket before giving up on the cutover")
+	DataSourceCmd.AddCommand(dsMigrateCmd)
+}
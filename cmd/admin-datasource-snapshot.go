@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pborman/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/proto/tree"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+var (
+	snapshotName        string
+	snapshotKeep        int
+	snapshotCron        string
+	snapshotFrom        string
+	snapshotTo          string
+	snapshotPath        string
+	snapshotDeleteNewer bool
+	snapshotDryRun      bool
+)
+
+var dsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage snapshots of a datasource index",
+	Long: `
+DESCRIPTION
+
+  Snapshots capture the whole index of a datasource, along with the encryption keys of its files.
+  They can be browsed read-only, compared together or with the current index, and used to restore
+  a folder to its previous state. Files contents are restored from their versions, so the datasource
+  should be versioned for a restoration to be complete.
+
+EXAMPLES
+
+  1. Take a snapshot now, keeping only the 7 most recent ones
+  $ ` + os.Args[0] + ` admin datasource snapshot create pydiods1 --keep 7
+
+  2. Take a snapshot every night
+  $ ` + os.Args[0] + ` admin datasource snapshot create pydiods1 --keep 7 --cron "0 2 * * *"
+
+  3. Show what changed since a snapshot
+  $ ` + os.Args[0] + ` admin datasource snapshot diff pydiods1 --from 2019-06-01T02-00-00
+
+  4. Restore a folder
+  $ ` + os.Args[0] + ` admin datasource snapshot restore pydiods1 2019-06-01T02-00-00 --path /folder
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var dsSnapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a snapshot, or schedule the creation of snapshots with --cron",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dsName := args[0]
+		if snapshotCron != "" {
+			params := map[string]string{"dataSource": dsName, "keep": fmt.Sprintf("%d", snapshotKeep)}
+			job := &jobs.Job{
+				ID:             "snapshot-" + dsName,
+				Owner:          common.PydioSystemUsername,
+				Label:          "Snapshot datasource " + dsName,
+				MaxConcurrency: 1,
+				Schedule:       &jobs.Schedule{Cron: snapshotCron},
+				Actions:        []*jobs.Action{{ID: "actions.snapshot.create", Parameters: params}},
+			}
+			if e := putSnapshotJob(job); e != nil {
+				cmd.Println("Cannot schedule snapshots: " + e.Error())
+				return
+			}
+			cmd.Println("Snapshots scheduled by job " + job.ID)
+			return
+		}
+		ctx, cancel := snapshotContext()
+		defer cancel()
+		resp, e := snapshotsClient(dsName).CreateSnapshot(ctx, &sync.CreateSnapshotRequest{Name: snapshotName, Keep: int32(snapshotKeep)})
+		if e != nil {
+			cmd.Println("Cannot create snapshot: " + e.Error())
+			return
+		}
+		renderSnapshots(cmd, []*sync.IndexSnapshot{resp.Snapshot})
+		if len(resp.Pruned) > 0 {
+			cmd.Println("Pruned snapshots: " + strings.Join(resp.Pruned, ", "))
+		}
+	},
+}
+
+var dsSnapshotListCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List snapshots of a datasource",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := snapshotContext()
+		defer cancel()
+		resp, e := snapshotsClient(args[0]).ListSnapshots(ctx, &sync.ListSnapshotsRequest{})
+		if e != nil {
+			cmd.Println("Cannot list snapshots: " + e.Error())
+			return
+		}
+		renderSnapshots(cmd, resp.Snapshots)
+	},
+}
+
+var dsSnapshotDeleteCmd = &cobra.Command{
+	Use:   "rm",
+	Short: "Delete a snapshot",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := snapshotContext()
+		defer cancel()
+		if _, e := snapshotsClient(args[0]).DeleteSnapshot(ctx, &sync.DeleteSnapshotRequest{Name: args[1]}); e != nil {
+			cmd.Println("Cannot delete snapshot: " + e.Error())
+			return
+		}
+		cmd.Println("Snapshot " + args[1] + " deleted")
+	},
+}
+
+var dsSnapshotDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "List changes between two snapshots, or between a snapshot and the current index",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := snapshotContext()
+		defer cancel()
+		resp, e := snapshotsClient(args[0]).DiffSnapshots(ctx, &sync.DiffSnapshotsRequest{From: snapshotFrom, To: snapshotTo, Path: snapshotPath})
+		if e != nil {
+			cmd.Println("Cannot compute diff: " + e.Error())
+			return
+		}
+		if len(resp.Changes) == 0 {
+			cmd.Println("No changes")
+			return
+		}
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Type", "Path", "From", "Uuid"})
+		for _, c := range resp.Changes {
+			table.Append([]string{c.Type, c.Path, c.MoveFrom, c.GetNode().GetUuid()})
+		}
+		table.Render()
+	},
+}
+
+var dsSnapshotRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a folder of a datasource to a snapshot",
+	Long: `
+DESCRIPTION
+
+  Start a job restoring a folder (the whole datasource by default) to the state captured by a snapshot.
+  Files created after the snapshot are kept unless --delete-newer is set. Use --dry-run to only log the
+  changes in the job logs.
+`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		params := map[string]string{
+			"dataSource": args[0],
+			"snapshot":   args[1],
+			"path":       snapshotPath,
+		}
+		if snapshotDeleteNewer {
+			params["deleteNewer"] = "true"
+		}
+		if snapshotDryRun {
+			params["dryRun"] = "true"
+		}
+		job := &jobs.Job{
+			ID:             "restore-snapshot-" + uuid.New(),
+			Owner:          common.PydioSystemUsername,
+			Label:          "Restore datasource " + args[0] + " to snapshot " + args[1],
+			MaxConcurrency: 1,
+			AutoStart:      true,
+			Actions:        []*jobs.Action{{ID: "actions.snapshot.restore", Parameters: params}},
+		}
+		if e := putSnapshotJob(job); e != nil {
+			cmd.Println("Cannot start restoration: " + e.Error())
+			return
+		}
+		cmd.Println("Restoration started by job " + job.ID + ", follow its progress in the scheduler")
+	},
+}
+
+var dsSnapshotBrowseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Publish the snapshots of a datasource as a read-only virtual node",
+	Long: `
+DESCRIPTION
+
+  Create a template path pointing to the snapshots folder of a datasource. The template path can
+  then be used as root of a workspace to browse the snapshots contents.
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dsName := args[0]
+		id := "snapshots-" + dsName
+		vNode := &tree.Node{
+			Uuid: id,
+			Path: id,
+			Type: tree.NodeType_COLLECTION,
+			MetaStore: map[string]string{
+				"name":       id,
+				"resolution": dsName + "/.snapshots",
+			},
+		}
+		data, _ := (&jsonpb.Marshaler{}).MarshalToString(vNode)
+		ctx, cancel := snapshotContext()
+		defer cancel()
+		cli := docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+		if _, e := cli.PutDocument(ctx, &docstore.PutDocumentRequest{
+			StoreID:    common.DocStoreIdVirtualNodes,
+			DocumentID: id,
+			Document:   &docstore.Document{ID: id, Owner: common.PydioSystemUsername, Data: data},
+		}); e != nil {
+			cmd.Println("Cannot create template path: " + e.Error())
+			return
+		}
+		cmd.Println("Template path " + id + " created, use it as a workspace root to browse snapshots")
+	},
+}
+
+func snapshotsClient(dsName string) sync.IndexSnapshotsClient {
+	return sync.NewIndexSnapshotsClient(common.ServiceGrpcNamespace_+common.ServiceDataIndex_+dsName, defaults.NewClient())
+}
+
+func snapshotContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	return context2.WithUserNameMetadata(ctx, common.PydioSystemUsername), cancel
+}
+
+func putSnapshotJob(job *jobs.Job) error {
+	ctx, cancel := snapshotContext()
+	defer cancel()
+	cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+	_, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: job})
+	return e
+}
+
+func renderSnapshots(cmd *cobra.Command, snapshots []*sync.IndexSnapshot) {
+	table := tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader([]string{"Name", "Created", "Nodes", "Size", "Keys"})
+	for _, s := range snapshots {
+		created := time.Unix(int64(s.CreatedAt), 0).Format(time.RFC3339)
+		table.Append([]string{s.Name, created, fmt.Sprintf("%d", s.Nodes), fmt.Sprintf("%d", s.Size), fmt.Sprintf("%d", s.Keys)})
+	}
+	table.Render()
+}
+
+func init() {
+	dsSnapshotCreateCmd.Flags().StringVar(&snapshotName, "name", "", "Name of the snapshot, generated from the current date if empty")
+	dsSnapshotCreateCmd.Flags().IntVar(&snapshotKeep, "keep", 0, "Delete the oldest snapshots to keep at most this number of snapshots")
+	dsSnapshotCreateCmd.Flags().StringVar(&snapshotCron, "cron", "", "Schedule the creation of snapshots with a cron expression instead of creating one now")
+	dsSnapshotDiffCmd.Flags().StringVar(&snapshotFrom, "from", "", "Initial snapshot, the current index if empty")
+	dsSnapshotDiffCmd.Flags().StringVar(&snapshotTo, "to", "", "Target snapshot, the current index if empty")
+	dsSnapshotDiffCmd.Flags().StringVar(&snapshotPath, "path", "", "Restrict the diff to a folder")
+	dsSnapshotRestoreCmd.Flags().StringVar(&snapshotPath, "path", "/", "Folder to restore")
+	dsSnapshotRestoreCmd.Flags().BoolVar(&snapshotDeleteNewer, "delete-newer", false, "Delete files and folders created after the snapshot")
+	dsSnapshotRestoreCmd.Flags().BoolVar(&snapshotDryRun, "dry-run", false, "Only log the changes that would be applied")
+
+	dsSnapshotCmd.AddCommand(dsSnapshotCreateCmd, dsSnapshotListCmd, dsSnapshotDeleteCmd, dsSnapshotDiffCmd, dsSnapshotRestoreCmd, dsSnapshotBrowseCmd)
+	DataSourceCmd.AddCommand(dsSnapshotCmd)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/jobs/bundle"
+)
+
+var (
+	jobsExportIDs    []string
+	jobsExportFormat string
+	jobsExportFile   string
+)
+
+var jobsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export jobs definitions to a portable bundle",
+	Long: `
+DESCRIPTION
+
+  Export one or more jobs definitions (actions, selectors, filters and parameters) as a versioned
+  document that can be imported in another instance. Running tasks are not exported.
+  If no job ID is passed, all custom jobs are exported.
+
+EXAMPLES
+
+  1. Export all custom jobs in YAML
+  $ ` + os.Args[0] + ` admin jobs export --format=yaml --file=jobs.yaml
+
+  2. Export two jobs in JSON to the standard output
+  $ ` + os.Args[0] + ` admin jobs export --id=JOB_ID_1 --id=JOB_ID_2
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if jobsExportFormat != bundle.FormatJSON && jobsExportFormat != bundle.FormatYAML {
+			cmd.Println("Unsupported format " + jobsExportFormat)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+		jj, err := bundle.Load(ctx, cli, jobsExportIDs)
+		if err != nil {
+			cmd.Println("Cannot load jobs: " + err.Error())
+			return
+		}
+		content, err := bundle.Export(jj, jobsExportFormat)
+		if err != nil {
+			cmd.Println("Cannot export jobs: " + err.Error())
+			return
+		}
+		if jobsExportFile == "" {
+			cmd.Println(string(content))
+			return
+		}
+		if err := ioutil.WriteFile(jobsExportFile, content, 0644); err != nil {
+			cmd.Println("Cannot write file: " + err.Error())
+			return
+		}
+		cmd.Printf("Exported %d job(s) to %s\n", len(jj), jobsExportFile)
+	},
+}
+
+func init() {
+	jobsExportCmd.Flags().StringArrayVar(&jobsExportIDs, "id", []string{}, "ID of the job to export (can be repeated)")
+	jobsExportCmd.Flags().StringVar(&jobsExportFormat, "format", bundle.FormatJSON, "Output format (json|yaml)")
+	jobsExportCmd.Flags().StringVarP(&jobsExportFile, "file", "f", "", "Write to this file instead of standard output")
+	JobsCmd.AddCommand(jobsExportCmd)
+}
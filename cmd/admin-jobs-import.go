@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/scheduler/jobs/bundle"
+)
+
+var (
+	jobsImportFile   string
+	jobsImportOwners []string
+	jobsImportDryRun bool
+	jobsImportDiff   bool
+)
+
+var jobsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import jobs definitions from a bundle",
+	Long: `
+DESCRIPTION
+
+  Import jobs definitions from a bundle created with the export command, in JSON or YAML format.
+  Jobs are validated against the actions registered in this instance: invalid jobs are skipped.
+  Existing jobs with the same ID are replaced.
+
+  Use --owner to replace the owners of the imported jobs, and --dry-run to display the changes
+  without saving anything.
+
+EXAMPLES
+
+  1. Preview changes
+  $ ` + os.Args[0] + ` admin jobs import --file=jobs.yaml --dry-run --diff
+
+  2. Import jobs, replacing owner "admin" by "ops" and all other owners by "pydio.system.user"
+  $ ` + os.Args[0] + ` admin jobs import --file=jobs.yaml --owner=admin:ops --owner=*:pydio.system.user
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsImportFile == "" {
+			return fmt.Errorf("please provide a bundle file")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := ioutil.ReadFile(jobsImportFile)
+		if err != nil {
+			cmd.Println("Cannot read file: " + err.Error())
+			return
+		}
+		b, err := bundle.Parse(content)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+		mapping := make(map[string]string, len(jobsImportOwners))
+		for _, o := range jobsImportOwners {
+			parts := strings.SplitN(o, ":", 2)
+			if len(parts) != 2 {
+				cmd.Println("Invalid owner mapping " + o + ", use ORIGINAL:NEW")
+				return
+			}
+			mapping[parts[0]] = parts[1]
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+		results, err := bundle.Import(ctx, cli, b, bundle.ImportOptions{OwnersMapping: mapping, DryRun: jobsImportDryRun})
+		if err != nil {
+			cmd.Println("Cannot import jobs: " + err.Error())
+			return
+		}
+
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Id", "Label", "Status", "Error"})
+		for _, r := range results {
+			table.Append([]string{r.JobID, r.Label, r.Status.String(), r.Error})
+		}
+		table.Render()
+		if jobsImportDiff {
+			for _, r := range results {
+				if r.Diff != "" {
+					cmd.Printf("\n### %s (%s)\n%s", r.JobID, r.Label, r.Diff)
+				}
+			}
+		}
+		if jobsImportDryRun {
+			cmd.Println("Dry run: nothing was saved")
+		}
+		for _, r := range results {
+			if r.Status == rest.ImportJobStatus_Invalid {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	jobsImportCmd.Flags().StringVarP(&jobsImportFile, "file", "f", "", "Bundle file to import (JSON or YAML)")
+	jobsImportCmd.Flags().StringArrayVar(&jobsImportOwners, "owner", []string{}, "Replace owners, as ORIGINAL:NEW (use * as ORIGINAL to replace all other owners)")
+	jobsImportCmd.Flags().BoolVar(&jobsImportDryRun, "dry-run", false, "Compute changes without saving anything")
+	jobsImportCmd.Flags().BoolVar(&jobsImportDiff, "diff", false, "Display a diff for each created or updated job")
+	JobsCmd.AddCommand(jobsImportCmd)
+}
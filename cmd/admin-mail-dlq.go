@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/mailer"
+)
+
+var mailDlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "List emails that could not be sent",
+	Long: `
+DESCRIPTION
+
+  List the emails moved to the dead letters after failing all their retries, with the last error.
+  Use the replay command to push them back to the queue.
+
+EXAMPLE
+
+  $ ` + os.Args[0] + ` admin mail dlq
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cli := mailer.NewMailerServiceClient(common.ServiceGrpcNamespace_+common.ServiceMailer, defaults.NewClient())
+		resp, err := cli.ListDeadLetters(ctx, &mailer.ListDeadLettersRequest{})
+		if err != nil {
+			cmd.Println("Cannot list dead letters: " + err.Error())
+			return
+		}
+		if len(resp.DeadLetters) == 0 {
+			cmd.Println("No dead letters")
+			return
+		}
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Id", "Failed At", "To", "Subject", "Retries", "Last Error"})
+		for _, l := range resp.DeadLetters {
+			var tos []string
+			for _, to := range l.GetMail().GetTo() {
+				tos = append(tos, to.Address)
+			}
+			var lastError string
+			if errs := l.GetMail().GetSendErrors(); len(errs) > 0 {
+				lastError = errs[len(errs)-1]
+			}
+			table.Append([]string{
+				l.ID,
+				time.Unix(l.FailedAt, 0).Format(time.RFC3339),
+				strings.Join(tos, ", "),
+				l.GetMail().GetSubject(),
+				fmt.Sprintf("%d", l.GetMail().GetRetries()),
+				lastError,
+			})
+		}
+		table.Render()
+	},
+}
+
+func init() {
+	MailCmd.AddCommand(mailDlqCmd)
+}
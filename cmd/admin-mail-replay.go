@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/mailer"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+var (
+	mailReplayIds   []string
+	mailReplayPurge bool
+)
+
+var mailReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Push dead letters back to the mailer queue",
+	Long: `
+DESCRIPTION
+
+  Push emails from the dead letters back to the queue, with a fresh retry counter. They are sent
+  at the next queue flush. Use --purge to discard them instead.
+
+EXAMPLES
+
+  1. Replay all dead letters
+  $ ` + os.Args[0] + ` admin mail replay
+
+  2. Replay specific dead letters
+  $ ` + os.Args[0] + ` admin mail replay --id=3 --id=4
+
+  3. Discard all dead letters
+  $ ` + os.Args[0] + ` admin mail replay --purge
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		ctx = context2.WithUserNameMetadata(ctx, common.PydioSystemUsername)
+		cli := mailer.NewMailerServiceClient(common.ServiceGrpcNamespace_+common.ServiceMailer, defaults.NewClient())
+		resp, err := cli.ReplayDeadLetters(ctx, &mailer.ReplayDeadLettersRequest{IDs: mailReplayIds, Purge: mailReplayPurge})
+		if err != nil {
+			cmd.Println("Cannot process dead letters: " + err.Error())
+			return
+		}
+		if mailReplayPurge {
+			cmd.Printf("Discarded %d dead letter(s)\n", resp.Count)
+		} else {
+			cmd.Printf("Pushed %d dead letter(s) back to the queue\n", resp.Count)
+		}
+	},
+}
+
+func init() {
+	mailReplayCmd.Flags().StringArrayVar(&mailReplayIds, "id", []string{}, "Dead letter ID to process (all if not set)")
+	mailReplayCmd.Flags().BoolVar(&mailReplayPurge, "purge", false, "Discard dead letters instead of replaying them")
+	MailCmd.AddCommand(mailReplayCmd)
+}
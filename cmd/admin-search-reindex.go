@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/sync"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+var (
+	searchEngine string
+	searchUrl    string
+	searchIndex  string
+)
+
+var searchReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the search index, optionally migrating to another engine",
+	Long: `
+DESCRIPTION
+
+  Rebuild the search index from the tree. If an engine is passed, the search service configuration
+  is updated and all nodes are indexed in the new engine. Searches are served by the current engine
+  until the new one is fully indexed.
+
+  Supported engines are "bleve" (embedded, default) and "opensearch" (OpenSearch or Elasticsearch server).
+
+EXAMPLES
+
+  1. Rebuild the current index
+  $ ` + os.Args[0] + ` admin search reindex
+
+  2. Migrate to an OpenSearch server
+  $ ` + os.Args[0] + ` admin search reindex --engine=opensearch --url=http://localhost:9200 --index=cells-search
+
+  3. Migrate back to the embedded engine
+  $ ` + os.Args[0] + ` admin search reindex --engine=bleve
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceName := common.ServiceGrpcNamespace_ + common.ServiceSearch
+		if searchEngine != "" {
+			if searchEngine != "bleve" && searchEngine != "opensearch" {
+				cmd.Println("Unsupported engine " + searchEngine)
+				return
+			}
+			if searchEngine == "opensearch" && searchUrl == "" && config.Get("services", serviceName, "opensearchUrl").String() == "" {
+				cmd.Println("Please provide the url of the OpenSearch server")
+				return
+			}
+			config.Set(searchEngine, "services", serviceName, "engine")
+			if searchUrl != "" {
+				config.Set(searchUrl, "services", serviceName, "opensearchUrl")
+			}
+			if searchIndex != "" {
+				config.Set(searchIndex, "services", serviceName, "opensearchIndex")
+			}
+			if err := config.Save("cli", fmt.Sprintf("Switch search engine to %s", searchEngine)); err != nil {
+				cmd.Println("Cannot save configuration: " + err.Error())
+				return
+			}
+		}
+		client := sync.NewSyncEndpointClient(serviceName, defaults.NewClient())
+		c, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		c = context2.WithUserNameMetadata(c, common.PydioSystemUsername)
+		if _, err := client.TriggerResync(c, &sync.ResyncRequest{Path: "/"}); err != nil {
+			cmd.Println("Reindex Failed: " + err.Error())
+			return
+		}
+		cmd.Println("Reindexation started, check the search service logs for progress.")
+	},
+}
+
+func init() {
+	searchReindexCmd.Flags().StringVar(&searchEngine, "engine", "", "Switch to this engine before reindexing (bleve|opensearch)")
+	searchReindexCmd.Flags().StringVar(&searchUrl, "url", "", "OpenSearch server URL")
+	searchReindexCmd.Flags().StringVar(&searchIndex, "index", "", "OpenSearch index name")
+	SearchCmd.AddCommand(searchReindexCmd)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/mfa"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+var (
+	userProvisionMFALogin string
+)
+
+var userProvisionMFACmd = &cobra.Command{
+	Use:   "provision-mfa",
+	Short: "Provision second authentication factor",
+	Long: fmt.Sprintf(`
+DESCRIPTION
+
+  Generate a new TOTP secret for a user who is required to use a second authentication factor
+  and cannot log in without it. Hand the secret (or the otpauth URI, as a QR code) to the user
+  through a trusted channel: it is activated at their first login with a valid code.
+
+EXAMPLE
+
+  $ %s user provision-mfa -u LOGIN
+
+`, os.Args[0]),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userProvisionMFALogin == "" {
+			return fmt.Errorf("Missing arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+
+		users, err := searchUser(context.Background(), client, userProvisionMFALogin)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return fmt.Errorf("cannot find user %s", userProvisionMFALogin)
+		}
+		user := users[0]
+		if mfa.Enrolled(user) {
+			return fmt.Errorf("user %s already has a second authentication factor, reset it first", user.Login)
+		}
+		manager := mfa.NewManager()
+		manager.UserClient = client
+		secret, uri, err := manager.Provision(context.Background(), user)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Second authentication factor provisioned for user %s\n", user.Login)
+		fmt.Printf("Secret: %s\n", secret)
+		fmt.Printf("URI: %s\n", uri)
+		return nil
+	},
+}
+
+func init() {
+	userProvisionMFACmd.Flags().StringVarP(&userProvisionMFALogin, "username", "u", "", "Login of the user")
+	UserCmd.AddCommand(userProvisionMFACmd)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
+)
+
+var (
+	recoverKeyLogin    string
+	recoverKeyRecovery string
+	recoverKeyPwd      string
+)
+
+var userRecoverKeyCmd = &cobra.Command{
+	Use:   "recover-key",
+	Short: "Recover a user keyring",
+	Long: fmt.Sprintf(`
+DESCRIPTION
+
+  Recover the passphrase protected keyring of a user, used by datasources encrypted in USER_PWD mode.
+  A recovery key is given to the user when the keyring is created at first login. Use it when the user
+  password was lost or reset by an admin: the keyring is then protected with the new password, which
+  must be the user login password, and a new recovery key is generated.
+
+EXAMPLE
+
+  $ %s user recover-key -u 'USER_LOGIN' -k 'RECOVERY_KEY' -p 'NEW_PASSWORD'
+`,
+		os.Args[0],
+	),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if recoverKeyLogin == "" {
+			return fmt.Errorf("Missing arguments")
+		}
+		if recoverKeyRecovery == "" {
+			p := promptui.Prompt{
+				Label: "Provide the recovery key",
+				Validate: func(s string) error {
+					if s == "" {
+						return fmt.Errorf("cannot use empty recovery key")
+					}
+					return nil
+				},
+			}
+			var e error
+			if recoverKeyRecovery, e = p.Run(); e != nil {
+				return e
+			}
+		}
+		if recoverKeyPwd == "" {
+			p := promptui.Prompt{
+				Label: "Provide the user password",
+				Validate: func(s string) error {
+					if s == "" {
+						return fmt.Errorf("cannot use empty password")
+					}
+					return nil
+				},
+				Mask: '*',
+			}
+			var e error
+			if recoverKeyPwd, e = p.Run(); e != nil {
+				return e
+			}
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		client := encryption.NewUserKeyStoreClient(common.ServiceGrpcNamespace_+common.ServiceUserKey, defaults.NewClient())
+		resp, err := client.RecoverUserKey(context.Background(), &encryption.RecoverUserKeyRequest{
+			UserLogin:   recoverKeyLogin,
+			RecoveryKey: recoverKeyRecovery,
+			NewPassword: recoverKeyPwd,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Keyring of user %s was successfully recovered.\nPlease give the user this new recovery key: %s\n", recoverKeyLogin, resp.RecoveryKey)
+	},
+}
+
+func init() {
+	userRecoverKeyCmd.Flags().StringVarP(&recoverKeyLogin, "username", "u", "", "Login of the user")
+	userRecoverKeyCmd.Flags().StringVarP(&recoverKeyRecovery, "recovery-key", "k", "", "Recovery key given at keyring creation")
+	userRecoverKeyCmd.Flags().StringVarP(&recoverKeyPwd, "password", "p", "", "New keyring password")
+
+	UserCmd.AddCommand(userRecoverKeyCmd)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/mfa"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+var (
+	userResetMFALogin string
+)
+
+var userResetMFACmd = &cobra.Command{
+	Use:   "reset-mfa",
+	Short: "Reset second authentication factor",
+	Long: fmt.Sprintf(`
+DESCRIPTION
+
+  Remove the second authentication factor (TOTP secret and recovery codes) of a user.
+  This may be handy if a user lost both their device and their recovery codes. If policies
+  require a second factor, a new one must be provisioned with the provision-mfa command.
+
+EXAMPLE
+
+  $ %s user reset-mfa -u LOGIN
+
+`, os.Args[0]),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userResetMFALogin == "" {
+			return fmt.Errorf("Missing arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+
+		users, err := searchUser(context.Background(), client, userResetMFALogin)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return fmt.Errorf("cannot find user %s", userResetMFALogin)
+		}
+		user := users[0]
+		if user.Attributes == nil {
+			return nil
+		}
+		mfa.ResetUser(user)
+		user.Password = ""
+		if _, err := client.CreateUser(context.Background(), &idm.CreateUserRequest{User: user}); err != nil {
+			return err
+		}
+		fmt.Printf("Second authentication factor removed for user %s\n", user.Login)
+		return nil
+	},
+}
+
+func init() {
+	userResetMFACmd.Flags().StringVarP(&userResetMFALogin, "username", "u", "", "Login of the user")
+	UserCmd.AddCommand(userResetMFACmd)
+}
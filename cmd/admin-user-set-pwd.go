@@ -31,6 +31,7 @@ import (
 	"github.com/pydio/cells/common"
 	defaults "github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/idm/key"
 	"github.com/spf13/cobra"
 )
 
@@ -47,6 +48,7 @@ DESCRIPTION
 
   Set a user password. 
   Directly use --password (or -p) to provide a new password, or leave empty to be prompted.
+  If the user has a passphrase protected keyring, it must then be recovered with the "user recover-key" command.
 
 EXAMPLE
 
@@ -107,6 +109,9 @@ EXAMPLE
 				log.Println(err)
 			} else {
 				fmt.Printf("user %s password was successfully updated\n", user.Login)
+				if e := key.RequireUserKeyRecovery(context.Background(), user.Login); e != nil {
+					fmt.Printf("could not flag keyring of [%s] for recovery: %s\n", user.Login, e.Error())
+				}
 			}
 		}
 	},
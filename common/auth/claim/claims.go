@@ -31,6 +31,8 @@ import (
 
 const (
 	ContextKey contextKey = "pydio-claims"
+	// MetadataKey is the metadata key passing the JSON-encoded claims along with grpc queries
+	MetadataKey = "x-pydio-claims"
 )
 
 type contextKey string
@@ -52,6 +54,8 @@ type Claims struct {
 	GroupPath      string      `json:"groupPath" mapstructure:"groupPath"`
 	ProvidesScopes bool        `json:"providesScopes" mapstructure:"providesScopes"`
 	Scopes         []string    `json:"scopes" mapstructure:"scopes"`
+	// Attributes of the user, checked by policies conditions
+	Attributes map[string]string `json:"attributes,omitempty" mapstructure:"attributes"`
 }
 
 // Decode Subject field of the claims
@@ -171,6 +171,18 @@ type CallbackConnector interface {
 	HandleCallback(s Scopes, r *http.Request) (identity Identity, err error)
 }
 
+// LogoutConnector is an interface implemented by connectors which propagate logouts
+// to and from the upstream identity provider.
+type LogoutConnector interface {
+	// LogoutURL returns the URL ending the upstream session, using the ConnectorData
+	// of the identity. An empty string means the provider does not support it.
+	LogoutURL(connectorData []byte, postLogoutRedirectURL string) (string, error)
+
+	// HandleLogout verifies a logout notification sent by the provider and returns
+	// the ID of the user whose sessions must be revoked.
+	HandleLogout(r *http.Request) (userID string, err error)
+}
+
 // SAMLConnector represents SAML connectors which implement the HTTP POST binding.
 //  RelayState is handled by the server.
 //
@@ -23,8 +23,10 @@ package auth
 import (
 	"bytes"
 	"crypto/md5"
+	crand "crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -33,9 +35,23 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/pbkdf2"
 )
 
+const (
+	HashAlgorithmArgon2id = "argon2id"
+	HashAlgorithmBcrypt   = "bcrypt"
+	HashAlgorithmPBKDF2   = "pbkdf2"
+
+	argon2idPrefix  = "$argon2id$"
+	argon2SaltSize  = 16
+	argon2KeyLength = 32
+)
+
+// PydioPW hashes and checks users passwords. Hashes are created with the configured Algorithm,
+// any supported format is accepted when checking a password.
 type PydioPW struct {
 	PBKDF2_HASH_ALGORITHM string
 	PBKDF2_ITERATIONS     int
@@ -46,6 +62,15 @@ type PydioPW struct {
 	HASH_ITERATION_INDEX  int
 	HASH_SALT_INDEX       int
 	HASH_PBKDF2_INDEX     int
+
+	// Algorithm used by CreateHash, one of the HashAlgorithm constants. Defaults to PBKDF2 with the parameters above.
+	Algorithm string
+	// Argon2id cost parameters, Argon2Memory is expressed in KiB
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+	// BcryptCost is the cost of bcrypt hashes
+	BcryptCost int
 }
 
 func (p PydioPW) pbkdf2CreateHash(password []byte, salt []byte, iter, totalSize int, algo string) (hash []byte, err error) {
@@ -78,7 +103,33 @@ func (p PydioPW) checkPasswordDBKDF2(password string, storePassword []byte, salt
 	return false, fmt.Errorf("Password does not match")
 }
 
+func (p PydioPW) checkPasswordArgon2id(password string, hashedPw string) (bool, error) {
+	iter, memory, threads, salt, key, err := parseArgon2id(hashedPw)
+	if err != nil {
+		return false, err
+	}
+	pwd := argon2.IDKey([]byte(password), salt, iter, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(pwd, key) == 1 {
+		return true, nil
+	}
+	return false, fmt.Errorf("Password does not match")
+}
+
+func (p PydioPW) checkPasswordBcrypt(password string, hashedPw string) (bool, error) {
+	if err := bcrypt.CompareHashAndPassword([]byte(hashedPw), []byte(password)); err != nil {
+		return false, fmt.Errorf("Password does not match")
+	}
+	return true, nil
+}
+
+// CheckDBKDF2PydioPwd checks a password against a stored hash, whatever its format.
 func (p PydioPW) CheckDBKDF2PydioPwd(password string, hashedPw string, legacySalt ...bool) (bool, error) {
+	if strings.HasPrefix(hashedPw, argon2idPrefix) {
+		return p.checkPasswordArgon2id(password, hashedPw)
+	}
+	if isBcrypt(hashedPw) {
+		return p.checkPasswordBcrypt(password, hashedPw)
+	}
 	arrPwd := strings.Split(hashedPw, ":")
 	if len(arrPwd) < p.HASH_SECTIONS {
 		// MD5 password
@@ -110,12 +161,83 @@ func (p PydioPW) CheckDBKDF2PydioPwd(password string, hashedPw string, legacySal
 	return false, fmt.Errorf("Password format invalid")
 }
 
+// CreateHash hashes a password with the configured algorithm.
 func (p PydioPW) CreateHash(password string) (base64Pw string) {
+	switch p.Algorithm {
+	case HashAlgorithmArgon2id:
+		salt := make([]byte, argon2SaltSize)
+		if _, err := crand.Read(salt); err != nil {
+			break
+		}
+		key := argon2.IDKey([]byte(password), salt, p.Argon2Time, p.Argon2Memory, p.Argon2Threads, argon2KeyLength)
+		return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, p.Argon2Memory, p.Argon2Time, p.Argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+	case HashAlgorithmBcrypt:
+		if hashed, err := bcrypt.GenerateFromPassword([]byte(password), p.BcryptCost); err == nil {
+			return string(hashed)
+		}
+	}
+	return p.createPBKDF2Hash(password)
+}
+
+// NeedsRehash tells whether a stored hash was created with another algorithm or other parameters
+// than the configured ones. It must only be called once the password is checked.
+func (p PydioPW) NeedsRehash(hashedPw string) bool {
+	switch p.Algorithm {
+	case HashAlgorithmArgon2id:
+		iter, memory, threads, _, _, err := parseArgon2id(hashedPw)
+		return err != nil || iter != p.Argon2Time || memory != p.Argon2Memory || threads != p.Argon2Threads
+	case HashAlgorithmBcrypt:
+		cost, err := bcrypt.Cost([]byte(hashedPw))
+		return err != nil || cost != p.BcryptCost
+	}
+	arrPwd := strings.Split(hashedPw, ":")
+	if len(arrPwd) != p.HASH_SECTIONS {
+		return true
+	}
+	iter, _ := strconv.Atoi(arrPwd[p.HASH_ITERATION_INDEX])
+	return arrPwd[p.HASH_ALGORITHM_INDEX] != strings.ToLower(p.PBKDF2_HASH_ALGORITHM) || iter != p.PBKDF2_ITERATIONS
+}
+
+func (p PydioPW) createPBKDF2Hash(password string) string {
 	salt := randStringBytes(p.PBKDF2_SALT_BYTE_SIZE)
 	hashedPw, _ := p.pbkdf2CreateHash([]byte(password), salt, p.PBKDF2_ITERATIONS, p.PBKDF2_HASH_BYTE_SIZE, p.PBKDF2_HASH_ALGORITHM)
 	return strings.ToLower(p.PBKDF2_HASH_ALGORITHM) + ":" + strconv.Itoa(p.PBKDF2_ITERATIONS) + ":" + base64.StdEncoding.EncodeToString(salt) + ":" + base64.StdEncoding.EncodeToString(hashedPw)
 }
 
+func isBcrypt(hashedPw string) bool {
+	return strings.HasPrefix(hashedPw, "$2a$") || strings.HasPrefix(hashedPw, "$2b$") || strings.HasPrefix(hashedPw, "$2y$")
+}
+
+// parseArgon2id reads a hash in the PHC string format: $argon2id$v=19$m=65536,t=3,p=2$salt$key
+func parseArgon2id(hashedPw string) (iter, memory uint32, threads uint8, salt, key []byte, err error) {
+	parts := strings.Split(hashedPw, "$")
+	if len(parts) != 6 || parts[1] != HashAlgorithmArgon2id {
+		err = fmt.Errorf("Password format invalid")
+		return
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return
+	} else if version != argon2.Version {
+		err = fmt.Errorf("Argon2 version not supported")
+		return
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iter, &threads); err != nil {
+		return
+	} else if iter == 0 || threads == 0 {
+		err = fmt.Errorf("Password format invalid")
+		return
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err == nil && len(key) == 0 {
+		err = fmt.Errorf("Password format invalid")
+	}
+	return
+}
+
 // TODO
 // Use stronger random []byte
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
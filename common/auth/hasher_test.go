@@ -101,3 +101,67 @@ func TestCheckPasswordMd5(t *testing.T) {
 	})
 
 }
+
+func TestModernPasswordHashes(t *testing.T) {
+
+	hasher := PydioPW{
+		PBKDF2_HASH_ALGORITHM: "sha256",
+		PBKDF2_ITERATIONS:     1000,
+		PBKDF2_SALT_BYTE_SIZE: 32,
+		PBKDF2_HASH_BYTE_SIZE: 24,
+		HASH_SECTIONS:         4,
+		HASH_ALGORITHM_INDEX:  0,
+		HASH_ITERATION_INDEX:  1,
+		HASH_SALT_INDEX:       2,
+		HASH_PBKDF2_INDEX:     3,
+		Argon2Time:            1,
+		Argon2Memory:          1024,
+		Argon2Threads:         1,
+		BcryptCost:            4,
+	}
+
+	Convey("Test Argon2id Password", t, func() {
+		hasher.Algorithm = HashAlgorithmArgon2id
+		hash := hasher.CreateHash("P@ssw0rd")
+		So(hash, ShouldStartWith, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+		ok, err := hasher.CheckDBKDF2PydioPwd("P@ssw0rd", hash)
+		So(ok, ShouldBeTrue)
+		So(err, ShouldBeNil)
+		ok, _ = hasher.CheckDBKDF2PydioPwd("password", hash)
+		So(ok, ShouldBeFalse)
+		ok, _ = hasher.CheckDBKDF2PydioPwd("P@ssw0rd", "$argon2id$v=19$m=1024,t=1,p=0$c2FsdA$")
+		So(ok, ShouldBeFalse)
+
+		So(hasher.NeedsRehash(hash), ShouldBeFalse)
+		So(hasher.NeedsRehash(testPw), ShouldBeTrue)
+		So(hasher.NeedsRehash(md5pw), ShouldBeTrue)
+		hasher.Argon2Memory = 2048
+		So(hasher.NeedsRehash(hash), ShouldBeTrue)
+		hasher.Argon2Memory = 1024
+	})
+
+	Convey("Test Bcrypt Password", t, func() {
+		hasher.Algorithm = HashAlgorithmBcrypt
+		hash := hasher.CreateHash("P@ssw0rd")
+		So(hash, ShouldStartWith, "$2a$04$")
+
+		ok, err := hasher.CheckDBKDF2PydioPwd("P@ssw0rd", hash)
+		So(ok, ShouldBeTrue)
+		So(err, ShouldBeNil)
+		ok, _ = hasher.CheckDBKDF2PydioPwd("password", hash)
+		So(ok, ShouldBeFalse)
+
+		So(hasher.NeedsRehash(hash), ShouldBeFalse)
+		hasher.BcryptCost = 5
+		So(hasher.NeedsRehash(hash), ShouldBeTrue)
+	})
+
+	Convey("Test PBKDF2 Rehash", t, func() {
+		hasher.Algorithm = HashAlgorithmPBKDF2
+		So(hasher.NeedsRehash(hasher.CreateHash("P@ssw0rd")), ShouldBeFalse)
+		So(hasher.NeedsRehash(md5pw), ShouldBeTrue)
+		hasher.PBKDF2_ITERATIONS = 10000
+		So(hasher.NeedsRehash(testPw), ShouldBeTrue)
+	})
+}
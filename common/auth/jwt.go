@@ -228,6 +228,7 @@ func (j *JWTVerifier) loadClaims(ctx context.Context, token IDToken, claims *cla
 	claims.Profile = profile
 	claims.Roles = strings.Join(roles, ",")
 	claims.GroupPath = user.GroupPath
+	claims.Attributes = permissions.PolicyAttributesFromUser(user)
 
 	return nil
 }
@@ -36,10 +36,16 @@ import (
 
 	"github.com/pydio/cells/common/auth/claim"
 	"github.com/pydio/cells/common/auth/hydra"
+	"github.com/pydio/cells/common/auth/mfa"
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/log"
 )
 
+// verifySecondFactor is called once the password of a user is validated, before issuing any token.
+var verifySecondFactor = func(ctx context.Context, identity Identity) error {
+	return mfa.NewManager().Verify(ctx, identity.UserID)
+}
+
 type oryprovider struct {
 	oauth2Provider fosite.OAuth2Provider
 }
@@ -97,10 +103,14 @@ func (p *oryprovider) LoginChallengeCode(ctx context.Context, claims claim.Claim
 	}
 
 	// Accepting consent
-	if _, err := hydra.AcceptConsent(ctx, consent.Challenge, login.GetRequestedScope(), login.GetRequestedAudience(), map[string]string{}, map[string]string{
+	idToken := map[string]string{
 		"name":  claims.Name,
 		"email": claims.Email,
-	}); err != nil {
+	}
+	if claims.AuthSource != "" {
+		idToken["authSource"] = claims.AuthSource
+	}
+	if _, err := hydra.AcceptConsent(ctx, consent.Challenge, login.GetRequestedScope(), login.GetRequestedAudience(), map[string]string{}, idToken); err != nil {
 		log.Logger(ctx).Error("Failed to accept consent ", zap.Error(err))
 		return "", err
 	}
@@ -182,6 +192,10 @@ func (p *oryprovider) PasswordCredentialsCode(ctx context.Context, userName stri
 		return "", err
 	}
 
+	if err := verifySecondFactor(ctx, identity); err != nil {
+		return "", err
+	}
+
 	// Searching login challenge
 	login, err := hydra.GetLogin(ctx, challenge)
 	if err != nil {
@@ -294,6 +308,10 @@ func (p *oryprovider) PasswordCredentialsToken(ctx context.Context, userName str
 		return nil, err
 	}
 
+	if err := verifySecondFactor(ctx, identity); err != nil {
+		return nil, err
+	}
+
 	// Searching login challenge
 	login, err := hydra.GetLogin(ctx, challenge)
 	if err != nil {
@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"gopkg.in/ldap.v2"
+)
+
+var (
+	requestTimeout  = 30 * time.Second
+	errUserNotFound = fmt.Errorf("user not found in directory")
+)
+
+// directory wraps a connection to the directory.
+type directory struct {
+	conf *Config
+	conn *ldap.Conn
+}
+
+// dial opens a connection to the server and binds with the service account.
+func dial(conf *Config) (*directory, error) {
+	tlsConfig, e := conf.tlsConfig()
+	if e != nil {
+		return nil, e
+	}
+	var conn *ldap.Conn
+	if conf.Connection == ConnectionSSL {
+		conn, e = ldap.DialTLS("tcp", conf.Host, tlsConfig)
+	} else {
+		conn, e = ldap.Dial("tcp", conf.Host)
+	}
+	if e != nil {
+		return nil, e
+	}
+	conn.SetTimeout(requestTimeout)
+	if conf.Connection == ConnectionStartTLS {
+		if e := conn.StartTLS(tlsConfig); e != nil {
+			conn.Close()
+			return nil, e
+		}
+	}
+	cl := &directory{conf: conf, conn: conn}
+	if e := cl.bindService(); e != nil {
+		conn.Close()
+		return nil, e
+	}
+	return cl, nil
+}
+
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	host, _, e := net.SplitHostPort(c.Host)
+	if e != nil {
+		host = c.Host
+	}
+	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: c.SkipVerifyCertificate}
+	if c.RootCA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.RootCA)) {
+			return nil, fmt.Errorf("ldap connector: cannot parse root CA")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// bindService binds with the service account, or stays anonymous if there is none.
+func (cl *directory) bindService() error {
+	if cl.conf.BindDN == "" {
+		return nil
+	}
+	return cl.conn.Bind(cl.conf.BindDN, cl.conf.BindPassword)
+}
+
+// bindUser checks the credentials of a user. It returns false if the password does not match.
+func (cl *directory) bindUser(dn, password string) (bool, error) {
+	// Empty passwords would perform an unauthenticated bind, which always succeeds
+	if password == "" {
+		return false, nil
+	}
+	if e := cl.conn.Bind(dn, password); e != nil {
+		if ldap.IsErrorWithCode(e, ldap.LDAPResultInvalidCredentials) {
+			return false, nil
+		}
+		return false, e
+	}
+	// Back to the service account for subsequent searches
+	return true, cl.bindService()
+}
+
+// search runs a filter on all base DNs, using paged results if configured. Missing base DNs are skipped
+// if missingOK is set, otherwise they are reported as an error.
+func (cl *directory) search(dns []string, scope string, filter string, missingOK bool) ([]*ldap.Entry, error) {
+	ldapScope := ldap.ScopeWholeSubtree
+	if scope == "one" {
+		ldapScope = ldap.ScopeSingleLevel
+	}
+	var entries []*ldap.Entry
+	for _, dn := range dns {
+		req := ldap.NewSearchRequest(dn, ldapScope, ldap.NeverDerefAliases, 0, 0, false, filter, nil, nil)
+		var res *ldap.SearchResult
+		var e error
+		if cl.conf.PageSize > 0 {
+			res, e = cl.conn.SearchWithPaging(req, cl.conf.PageSize)
+		} else {
+			res, e = cl.conn.Search(req)
+		}
+		if e != nil {
+			if missingOK && ldap.IsErrorWithCode(e, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return nil, fmt.Errorf("cannot search %s: %s", dn, e.Error())
+		}
+		entries = append(entries, res.Entries...)
+	}
+	return entries, nil
+}
+
+// users lists all users entries.
+func (cl *directory) users() ([]*ldap.Entry, error) {
+	return cl.search(cl.conf.User.DNs, cl.conf.User.Scope, cl.conf.User.Filter, false)
+}
+
+// groups lists all groups entries.
+func (cl *directory) groups() ([]*ldap.Entry, error) {
+	if len(cl.conf.Group.DNs) == 0 {
+		return nil, nil
+	}
+	return cl.search(cl.conf.Group.DNs, cl.conf.Group.Scope, cl.conf.Group.Filter, false)
+}
+
+// findUser finds the entry of a user by login.
+func (cl *directory) findUser(login string) (*ldap.Entry, error) {
+	filter := fmt.Sprintf("(&%s(%s=%s))", cl.conf.User.Filter, cl.conf.User.IDAttribute, ldap.EscapeFilter(login))
+	entries, e := cl.search(cl.conf.User.DNs, cl.conf.User.Scope, filter, true)
+	if e != nil {
+		return nil, e
+	}
+	switch len(entries) {
+	case 0:
+		return nil, errUserNotFound
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, fmt.Errorf("login %s matches %d entries in directory", login, len(entries))
+	}
+}
+
+// userGroups finds the groups of a single user.
+func (cl *directory) userGroups(user *ldap.Entry) ([]*ldap.Entry, error) {
+	if len(cl.conf.Group.DNs) == 0 {
+		return nil, nil
+	}
+	member := cl.conf.Group.MemberAttribute
+	filter := fmt.Sprintf("(&%s(|(%s=%s)(%s=%s)))", cl.conf.Group.Filter,
+		member, ldap.EscapeFilter(user.DN),
+		member, ldap.EscapeFilter(value(user, cl.conf.User.IDAttribute)),
+	)
+	return cl.search(cl.conf.Group.DNs, cl.conf.Group.Scope, filter, true)
+}
+
+func (cl *directory) Close() {
+	cl.conn.Close()
+}
+
+// values reads the values of an attribute, attribute names being case insensitive.
+func values(entry *ldap.Entry, attribute string) []string {
+	if strings.EqualFold(attribute, "dn") {
+		return []string{entry.DN}
+	}
+	for _, a := range entry.Attributes {
+		if strings.EqualFold(a.Name, attribute) {
+			return a.Values
+		}
+	}
+	return nil
+}
+
+// value reads the first value of an attribute.
+func value(entry *ldap.Entry, attribute string) string {
+	if vv := values(entry, attribute); len(vv) > 0 {
+		return vv[0]
+	}
+	return ""
+}
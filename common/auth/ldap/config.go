@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package ldap provides an LDAP / Active Directory connector. Users are authenticated by binding on the directory
+// with their own credentials, and users and groups are synchronized into the internal identity services, either
+// at login time or periodically by a scheduler job.
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/config"
+)
+
+const (
+	// ConnectorType is the type of LDAP connectors in the oauth service configuration
+	ConnectorType = "ldap"
+
+	ConnectionNormal   = "normal"
+	ConnectionSSL      = "ssl"
+	ConnectionStartTLS = "starttls"
+
+	// RightAttributeRoles is the reserved right attribute of mapping rules assigning roles to users
+	RightAttributeRoles = "Roles"
+	// RightAttributeGroupPath is the reserved right attribute of mapping rules placing users in a group
+	RightAttributeGroupPath = "GroupPath"
+)
+
+// Config is the configuration of an LDAP connector.
+type Config struct {
+	// Host of the server, as host:port
+	Host string `json:"host"`
+	// Connection is one of normal, ssl or starttls
+	Connection string `json:"connection"`
+	// SkipVerifyCertificate disables the verification of the server certificate
+	SkipVerifyCertificate bool `json:"skipVerifyCertificate"`
+	// RootCA is a PEM encoded certificate used to verify the server certificate
+	RootCA string `json:"rootCA"`
+
+	// BindDN and BindPassword are the credentials of the service account used to search the directory
+	BindDN       string `json:"bindDN"`
+	BindPassword string `json:"bindPassword"`
+
+	// PageSize is the number of entries per page when listing the directory, 0 to disable paging
+	PageSize uint32 `json:"pageSize"`
+
+	User  UserQuery  `json:"user"`
+	Group GroupQuery `json:"group"`
+
+	// MappingRules maps directory attributes on users attributes, roles and group path
+	MappingRules []auth.MappingRule `json:"mappingRules"`
+
+	// GroupPath is the group where users are created, defaults to the root
+	GroupPath string `json:"groupPath"`
+	// Profile given to created users, defaults to standard
+	Profile string `json:"profile"`
+
+	// SyncInterval is the ISO8601 duration between two synchronizations (e.g. PT1H), empty to disable them
+	SyncInterval string `json:"syncInterval"`
+	// MaxDisabledRatio is the share of the users of this source that one synchronization may disable, 0.5 by
+	// default. Synchronizations disabling more users are aborted, as they are most likely misconfigured.
+	MaxDisabledRatio float64 `json:"maxDisabledRatio"`
+}
+
+// UserQuery describes how users are searched in the directory.
+type UserQuery struct {
+	// DNs are the base DNs where users are searched
+	DNs []string `json:"dns"`
+	// Filter selects users entries, e.g. (objectClass=inetOrgPerson)
+	Filter string `json:"filter"`
+	// IDAttribute is the attribute used as login, uid by default (sAMAccountName for Active Directory)
+	IDAttribute string `json:"idAttribute"`
+	// Scope of the search: sub (default) or one
+	Scope string `json:"scope"`
+}
+
+// GroupQuery describes how groups are searched in the directory. Groups are synchronized as roles.
+type GroupQuery struct {
+	// DNs are the base DNs where groups are searched, groups are not synchronized if empty
+	DNs []string `json:"dns"`
+	// Filter selects groups entries, e.g. (objectClass=groupOfNames)
+	Filter string `json:"filter"`
+	// IDAttribute identifies groups, cn by default
+	IDAttribute string `json:"idAttribute"`
+	// DisplayAttribute is used as role label, defaults to IDAttribute
+	DisplayAttribute string `json:"displayAttribute"`
+	// MemberAttribute lists the members DNs (or ids), member by default
+	MemberAttribute string `json:"memberAttribute"`
+	// Scope of the search: sub (default) or one
+	Scope string `json:"scope"`
+}
+
+// Validate checks mandatory values and sets defaults.
+func (c *Config) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("ldap connector: missing host")
+	}
+	if len(c.User.DNs) == 0 {
+		return fmt.Errorf("ldap connector: missing user search DNs")
+	}
+	switch c.Connection {
+	case "":
+		c.Connection = ConnectionNormal
+	case ConnectionNormal, ConnectionSSL, ConnectionStartTLS:
+	default:
+		return fmt.Errorf("ldap connector: unknown connection type %s", c.Connection)
+	}
+	if c.User.Filter == "" {
+		c.User.Filter = "(objectClass=*)"
+	}
+	if c.User.IDAttribute == "" {
+		c.User.IDAttribute = "uid"
+	}
+	if c.Group.Filter == "" {
+		c.Group.Filter = "(objectClass=*)"
+	}
+	if c.Group.IDAttribute == "" {
+		c.Group.IDAttribute = "cn"
+	}
+	if c.Group.DisplayAttribute == "" {
+		c.Group.DisplayAttribute = c.Group.IDAttribute
+	}
+	if c.Group.MemberAttribute == "" {
+		c.Group.MemberAttribute = "member"
+	}
+	if c.GroupPath == "" {
+		c.GroupPath = "/"
+	} else {
+		c.GroupPath = "/" + strings.Trim(c.GroupPath, "/")
+	}
+	if c.Profile == "" {
+		c.Profile = common.PydioProfileStandard
+	}
+	if c.MaxDisabledRatio <= 0 {
+		c.MaxDisabledRatio = 0.5
+	}
+	return nil
+}
+
+// ConfigFromMessage reads a configuration passed to the connector opener as a struct message.
+func ConfigFromMessage(msg proto.Message) (*Config, error) {
+	st, ok := msg.(*structpb.Struct)
+	if !ok || st == nil {
+		return nil, fmt.Errorf("ldap connector: missing configuration")
+	}
+	data, e := (&jsonpb.Marshaler{}).MarshalToString(st)
+	if e != nil {
+		return nil, e
+	}
+	c := &Config{}
+	if e := json.Unmarshal([]byte(data), c); e != nil {
+		return nil, e
+	}
+	return c, c.Validate()
+}
+
+// Connector is an LDAP connector declared in the oauth service configuration.
+type Connector struct {
+	ID     string
+	Name   string
+	Type   string
+	Config *Config
+}
+
+// ListConnectors lists the LDAP connectors declared in the oauth service configuration.
+func ListConnectors() ([]*Connector, error) {
+	var connectors, ldapConnectors []*Connector
+	if e := config.Get("services", common.ServiceWebNamespace_+common.ServiceOAuth, "connectors").Scan(&connectors); e != nil {
+		return nil, e
+	}
+	for _, c := range connectors {
+		if c.Type != ConnectorType || c.Config == nil {
+			continue
+		}
+		if e := c.Config.Validate(); e != nil {
+			return nil, e
+		}
+		ldapConnectors = append(ldapConnectors, c)
+	}
+	return ldapConnectors, nil
+}
+
+// LoadConfig finds the configuration of a connector in the oauth service configuration.
+func LoadConfig(id string) (*Config, error) {
+	connectors, e := ListConnectors()
+	if e != nil {
+		return nil, e
+	}
+	for _, c := range connectors {
+		if c.ID == id {
+			return c.Config, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find ldap connector %s", id)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"context"
+
+	dlog "github.com/dexidp/dex/pkg/log"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+var (
+	_ auth.PasswordConnector = (*connector)(nil)
+)
+
+func init() {
+	auth.RegisterConnectorType(ConnectorType, func(data proto.Message) (auth.Opener, error) {
+		c, e := ConfigFromMessage(data)
+		if e != nil {
+			return nil, e
+		}
+		return &opener{conf: c}, nil
+	})
+}
+
+type opener struct {
+	conf *Config
+}
+
+func (o *opener) Open(id string, _ dlog.Logger) (auth.Connector, error) {
+	return &connector{syncer: NewSyncer(id, o.conf)}, nil
+}
+
+// connector authenticates users by binding on the directory with their credentials. Successful logins
+// synchronize the user and its groups, so that users do not have to wait for the next scheduled synchronization.
+type connector struct {
+	syncer *Syncer
+}
+
+func (c *connector) Prompt() string {
+	return "Username"
+}
+
+func (c *connector) Login(ctx context.Context, s auth.Scopes, username, password string) (auth.Identity, bool, error) {
+	cl, e := dial(c.syncer.Config)
+	if e != nil {
+		return auth.Identity{}, false, e
+	}
+	defer cl.Close()
+
+	entry, e := cl.findUser(username)
+	if e != nil {
+		return auth.Identity{}, false, e
+	}
+	if valid, e := cl.bindUser(entry.DN, password); e != nil || !valid {
+		return auth.Identity{}, false, e
+	}
+
+	user, roles, e := c.syncer.syncEntry(ctx, cl, entry)
+	if e != nil {
+		return auth.Identity{}, false, e
+	}
+	groups := []string{}
+	for _, r := range roles {
+		groups = append(groups, r.Label)
+	}
+	return auth.Identity{
+		UserID:        user.GetUuid(),
+		Username:      user.GetLogin(),
+		Email:         user.GetAttributes()[idm.UserAttrEmail],
+		EmailVerified: true,
+		Groups:        groups,
+	}, true, nil
+}
@@ -0,0 +1,353 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/micro/go-micro/client"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+type usersStream struct {
+	idm.UserService_SearchUserClient
+	users []*idm.User
+}
+
+func (s *usersStream) Recv() (*idm.SearchUserResponse, error) {
+	if len(s.users) == 0 {
+		return nil, io.EOF
+	}
+	u := s.users[0]
+	s.users = s.users[1:]
+	return &idm.SearchUserResponse{User: u}, nil
+}
+
+func (s *usersStream) Close() error {
+	return nil
+}
+
+// usersMock stores users by login and mimics the roles returned by the users service
+type usersMock struct {
+	idm.UserServiceClient
+	users map[string]*idm.User
+}
+
+func (m *usersMock) CreateUser(ctx context.Context, in *idm.CreateUserRequest, opts ...client.CallOption) (*idm.CreateUserResponse, error) {
+	u := proto.Clone(in.User).(*idm.User)
+	if u.Uuid == "" {
+		u.Uuid = "uuid-" + u.Login
+	}
+	var roles []*idm.Role
+	for _, r := range u.Roles {
+		if !r.UserRole {
+			roles = append(roles, &idm.Role{Uuid: r.Uuid, Label: r.Uuid})
+		}
+	}
+	u.Roles = append(roles, &idm.Role{Uuid: u.Uuid, UserRole: true})
+	m.users[u.Login] = u
+	return &idm.CreateUserResponse{User: u}, nil
+}
+
+func (m *usersMock) SearchUser(ctx context.Context, in *idm.SearchUserRequest, opts ...client.CallOption) (idm.UserService_SearchUserClient, error) {
+	q := &idm.UserSingleQuery{}
+	ptypes.UnmarshalAny(in.Query.SubQueries[0], q)
+	s := &usersStream{}
+	for _, u := range m.users {
+		if (q.Login != "" && u.Login == q.Login) || (q.AttributeName != "" && u.Attributes[q.AttributeName] == q.AttributeValue) {
+			s.users = append(s.users, proto.Clone(u).(*idm.User))
+		}
+	}
+	return s, nil
+}
+
+type rolesStream struct {
+	idm.RoleService_SearchRoleClient
+	roles []*idm.Role
+}
+
+func (s *rolesStream) Recv() (*idm.SearchRoleResponse, error) {
+	if len(s.roles) == 0 {
+		return nil, io.EOF
+	}
+	r := s.roles[0]
+	s.roles = s.roles[1:]
+	return &idm.SearchRoleResponse{Role: r}, nil
+}
+
+func (s *rolesStream) Close() error {
+	return nil
+}
+
+type rolesMock struct {
+	idm.RoleServiceClient
+	roles map[string]*idm.Role
+}
+
+func (m *rolesMock) CreateRole(ctx context.Context, in *idm.CreateRoleRequest, opts ...client.CallOption) (*idm.CreateRoleResponse, error) {
+	m.roles[in.Role.Uuid] = in.Role
+	return &idm.CreateRoleResponse{Role: in.Role}, nil
+}
+
+func (m *rolesMock) SearchRole(ctx context.Context, in *idm.SearchRoleRequest, opts ...client.CallOption) (idm.RoleService_SearchRoleClient, error) {
+	q := &idm.RoleSingleQuery{}
+	ptypes.UnmarshalAny(in.Query.SubQueries[0], q)
+	s := &rolesStream{}
+	for _, uuid := range q.Uuid {
+		if r, ok := m.roles[uuid]; ok {
+			s.roles = append(s.roles, r)
+		}
+	}
+	return s, nil
+}
+
+func testDirectory() (*testServer, error) {
+	s, e := newTestServer()
+	if e != nil {
+		return nil, e
+	}
+	s.Add("cn=admin,dc=example,dc=com", "admin-pass")
+	s.Add("uid=alice,ou=people,dc=example,dc=com", "alice-pass",
+		"objectClass", "inetOrgPerson",
+		"uid", "alice",
+		"mail", "alice@example.com",
+		"displayName", "Alice",
+		"memberOf", "cn=admins,ou=groups,dc=example,dc=com",
+		"departmentNumber", "sales",
+	)
+	s.Add("uid=bob,ou=people,dc=example,dc=com", "bob-pass",
+		"objectClass", "inetOrgPerson",
+		"uid", "bob",
+		"mail", "bob@example.com",
+		"displayName", "Bob",
+	)
+	s.Add("cn=devs,ou=groups,dc=example,dc=com", "",
+		"objectClass", "groupOfNames",
+		"cn", "devs",
+		"description", "Developers",
+		"member", "uid=alice,ou=people,dc=example,dc=com",
+		"member", "uid=bob,ou=people,dc=example,dc=com",
+	)
+	return s, nil
+}
+
+func testConfig(host string) *Config {
+	c := &Config{
+		Host:         host,
+		BindDN:       "cn=admin,dc=example,dc=com",
+		BindPassword: "admin-pass",
+		PageSize:     1,
+		User:         UserQuery{DNs: []string{"ou=people,dc=example,dc=com"}, Filter: "(objectClass=inetOrgPerson)"},
+		Group:        GroupQuery{DNs: []string{"ou=groups,dc=example,dc=com"}, Filter: "(objectClass=groupOfNames)", DisplayAttribute: "description"},
+		GroupPath:    "/ldap",
+		MappingRules: []auth.MappingRule{
+			{LeftAttribute: "mail", RightAttribute: "email"},
+			{LeftAttribute: "displayName", RightAttribute: "displayName"},
+			{LeftAttribute: "memberOf", RightAttribute: RightAttributeRoles},
+			{LeftAttribute: "departmentNumber", RightAttribute: RightAttributeGroupPath, RuleString: "sales,support"},
+		},
+	}
+	c.Validate()
+	return c
+}
+
+func TestConfig(t *testing.T) {
+	Convey("Test configuration", t, func() {
+		So((&Config{}).Validate(), ShouldNotBeNil)
+		So((&Config{Host: "localhost:389"}).Validate(), ShouldNotBeNil)
+		So((&Config{Host: "localhost:389", User: UserQuery{DNs: []string{"dc=com"}}, Connection: "other"}).Validate(), ShouldNotBeNil)
+
+		st := &structpb.Struct{}
+		e := jsonpb.UnmarshalString(`{"host":"localhost:636","connection":"ssl","user":{"dns":["ou=people,dc=com"],"idAttribute":"sAMAccountName"},"groupPath":"ad/"}`, st)
+		So(e, ShouldBeNil)
+		c, e := ConfigFromMessage(st)
+		So(e, ShouldBeNil)
+		So(c.Connection, ShouldEqual, ConnectionSSL)
+		So(c.User.IDAttribute, ShouldEqual, "sAMAccountName")
+		So(c.Group.MemberAttribute, ShouldEqual, "member")
+		So(c.GroupPath, ShouldEqual, "/ad")
+		So(c.Profile, ShouldEqual, "standard")
+		_, e = ConfigFromMessage(nil)
+		So(e, ShouldNotBeNil)
+	})
+}
+
+func TestSync(t *testing.T) {
+	Convey("Test users and groups synchronization", t, func() {
+		server, e := testDirectory()
+		So(e, ShouldBeNil)
+		defer server.Close()
+		ctx := context.Background()
+		users := &usersMock{users: map[string]*idm.User{}}
+		roles := &rolesMock{roles: map[string]*idm.Role{}}
+		syncer := &Syncer{Source: "ldap1", Config: testConfig(server.Addr()), UserClient: users, RoleClient: roles}
+
+		stats, e := syncer.Sync(ctx)
+		So(e, ShouldBeNil)
+		So(stats.Users, ShouldEqual, 2)
+		So(stats.Created, ShouldEqual, 2)
+		So(stats.Roles, ShouldEqual, 2)
+		// Two pages of users and one page of groups
+		So(server.searches, ShouldEqual, 3)
+		So(roles.roles, ShouldContainKey, "ldap1_admins")
+		So(roles.roles["ldap1_devs"].Label, ShouldEqual, "Developers")
+		So(roles.roles, ShouldContainKey, "uuid-alice")
+
+		alice := users.users["alice"]
+		So(alice.GroupPath, ShouldEqual, "/ldap/sales")
+		So(alice.Attributes[idm.UserAttrEmail], ShouldEqual, "alice@example.com")
+		So(alice.Attributes[idm.UserAttrAuthSource], ShouldEqual, "ldap1")
+		So(alice.Attributes[idm.UserAttrProfile], ShouldEqual, "standard")
+		So(roleUuids(alice), ShouldResemble, []string{"ldap1_admins", "ldap1_devs"})
+		So(users.users["bob"].GroupPath, ShouldEqual, "/ldap")
+
+		stats, e = syncer.Sync(ctx)
+		So(e, ShouldBeNil)
+		So(stats.Created+stats.Updated+stats.Roles, ShouldEqual, 0)
+
+		Convey("Updates keep roles assigned in Cells", func() {
+			users.users["alice"].Roles = append(users.users["alice"].Roles, &idm.Role{Uuid: "manual"})
+			server.Remove("uid=alice,ou=people,dc=example,dc=com")
+			server.Add("uid=alice,ou=people,dc=example,dc=com", "alice-pass", "objectClass", "inetOrgPerson", "uid", "alice", "displayName", "Alice Smith")
+			stats, e := syncer.Sync(ctx)
+			So(e, ShouldBeNil)
+			So(stats.Updated, ShouldEqual, 1)
+			alice := users.users["alice"]
+			So(alice.Attributes[idm.UserAttrDisplayName], ShouldEqual, "Alice Smith")
+			So(alice.GroupPath, ShouldEqual, "/ldap")
+			So(roleUuids(alice), ShouldResemble, []string{"ldap1_devs", "manual"})
+		})
+
+		Convey("Users removed from the directory are disabled, and enabled when back", func() {
+			server.Remove("uid=bob,ou=people,dc=example,dc=com")
+			stats, e := syncer.Sync(ctx)
+			So(e, ShouldBeNil)
+			So(stats.Disabled, ShouldEqual, 1)
+			So(users.users["bob"].Attributes["locks"], ShouldEqual, `["logout"]`)
+			stats, e = syncer.Sync(ctx)
+			So(e, ShouldBeNil)
+			So(stats.Disabled, ShouldEqual, 0)
+
+			server.Add("uid=bob,ou=people,dc=example,dc=com", "bob-pass", "objectClass", "inetOrgPerson", "uid", "bob")
+			stats, e = syncer.Sync(ctx)
+			So(e, ShouldBeNil)
+			So(stats.Enabled, ShouldEqual, 1)
+			So(users.users["bob"].Attributes, ShouldNotContainKey, "locks")
+			So(users.users["bob"].Attributes, ShouldNotContainKey, attrDisabled)
+		})
+
+		Convey("Missing base DNs are reported and users are not disabled", func() {
+			syncer.Config.User.DNs = []string{"ou=renamed,dc=example,dc=com"}
+			_, e := syncer.Sync(ctx)
+			So(e, ShouldNotBeNil)
+			So(users.users["alice"].Attributes, ShouldNotContainKey, attrDisabled)
+			So(users.users["bob"].Attributes, ShouldNotContainKey, attrDisabled)
+		})
+
+		Convey("Users are not disabled when the directory is empty or too many are removed", func() {
+			server.Add("ou=people,dc=example,dc=com", "", "objectClass", "organizationalUnit")
+			server.Remove("uid=alice,ou=people,dc=example,dc=com")
+			server.Remove("uid=bob,ou=people,dc=example,dc=com")
+			stats, e := syncer.Sync(ctx)
+			So(e, ShouldNotBeNil)
+			So(stats.Disabled, ShouldEqual, 0)
+			So(users.users["alice"].Attributes, ShouldNotContainKey, attrDisabled)
+
+			server.Add("uid=bob,ou=people,dc=example,dc=com", "bob-pass", "objectClass", "inetOrgPerson", "uid", "bob")
+			syncer.Config.MaxDisabledRatio = 0.4
+			stats, e = syncer.Sync(ctx)
+			So(e, ShouldNotBeNil)
+			So(stats.Disabled, ShouldEqual, 0)
+			So(users.users["alice"].Attributes, ShouldNotContainKey, attrDisabled)
+		})
+
+		Convey("Logins used by another source are not overwritten", func() {
+			users.users["carol"] = &idm.User{Uuid: "carol", Login: "carol", Attributes: map[string]string{}}
+			server.Add("uid=carol,ou=people,dc=example,dc=com", "", "objectClass", "inetOrgPerson", "uid", "carol")
+			stats, e := syncer.Sync(ctx)
+			So(e, ShouldBeNil)
+			So(stats.Errors, ShouldEqual, 1)
+			So(users.users["carol"].Attributes, ShouldNotContainKey, idm.UserAttrAuthSource)
+		})
+	})
+}
+
+func TestLogin(t *testing.T) {
+	Convey("Test login through the connector", t, func() {
+		server, e := testDirectory()
+		So(e, ShouldBeNil)
+		defer server.Close()
+		ctx := context.Background()
+		users := &usersMock{users: map[string]*idm.User{}}
+
+		st := &structpb.Struct{}
+		So(jsonpb.UnmarshalString(`{"host":"`+server.Addr()+`","connection":"starttls","skipVerifyCertificate":true,`+
+			`"bindDN":"cn=admin,dc=example,dc=com","bindPassword":"admin-pass",`+
+			`"user":{"dns":["ou=people,dc=example,dc=com"]},"group":{"dns":["ou=groups,dc=example,dc=com"]}}`, st), ShouldBeNil)
+		auth.RegisterConnector("ldap1", "Directory", ConnectorType, st)
+		var conn *connector
+		for _, c := range auth.GetConnectors() {
+			if c.ID() == "ldap1" {
+				conn, _ = c.Conn().(*connector)
+			}
+		}
+		So(conn, ShouldNotBeNil)
+		conn.syncer.UserClient = users
+		conn.syncer.RoleClient = &rolesMock{roles: map[string]*idm.Role{}}
+
+		identity, valid, e := conn.Login(ctx, auth.Scopes{}, "alice", "alice-pass")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeTrue)
+		So(identity.UserID, ShouldEqual, "uuid-alice")
+		So(identity.Username, ShouldEqual, "alice")
+		So(identity.Groups, ShouldResemble, []string{"devs"})
+		So(users.users, ShouldContainKey, "alice")
+
+		_, valid, e = conn.Login(ctx, auth.Scopes{}, "alice", "wrong")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeFalse)
+		_, valid, e = conn.Login(ctx, auth.Scopes{}, "alice", "")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeFalse)
+		_, _, e = conn.Login(ctx, auth.Scopes{}, "nobody", "pass")
+		So(e, ShouldNotBeNil)
+		_, _, e = conn.Login(ctx, auth.Scopes{}, "*", "pass")
+		So(e, ShouldNotBeNil)
+
+		Convey("Server certificate is verified", func() {
+			conf := *conn.syncer.Config
+			conf.SkipVerifyCertificate = false
+			_, e := dial(&conf)
+			So(e, ShouldNotBeNil)
+			So(strings.ToLower(e.Error()), ShouldContainSubstring, "certificate")
+		})
+	})
+}
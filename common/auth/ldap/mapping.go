@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/ldap.v2"
+
+	"github.com/pydio/cells/common/auth"
+)
+
+// mappedRole is a role computed from directory values.
+type mappedRole struct {
+	Uuid  string
+	Label string
+}
+
+// mappedUser holds the values of a directory user, transformed by the mapping rules.
+type mappedUser struct {
+	Login      string
+	DN         string
+	Attributes map[string]string
+	Roles      []mappedRole
+	GroupPath  string
+}
+
+// roleUuid builds the UUID of a role as AuthSourceName_Prefix_RoleID.
+func roleUuid(source, prefix, id string) string {
+	if prefix != "" {
+		return source + "_" + prefix + "_" + id
+	}
+	return source + "_" + id
+}
+
+// mapUser applies the mapping rules on a user entry.
+func (c *Config) mapUser(source string, entry *ldap.Entry) *mappedUser {
+	m := &mappedUser{
+		Login:      value(entry, c.User.IDAttribute),
+		DN:         entry.DN,
+		Attributes: make(map[string]string),
+		GroupPath:  c.GroupPath,
+	}
+	for _, rule := range c.MappingRules {
+		vv := applyRule(rule, values(entry, rule.LeftAttribute))
+		if len(vv) == 0 {
+			continue
+		}
+		switch rule.RightAttribute {
+		case RightAttributeRoles:
+			for _, v := range vv {
+				m.Roles = append(m.Roles, mappedRole{Uuid: roleUuid(source, rule.RolePrefix, v), Label: v})
+			}
+		case RightAttributeGroupPath:
+			m.GroupPath = path.Join(c.GroupPath, strings.Trim(vv[0], "/"))
+		default:
+			m.Attributes[rule.RightAttribute] = strings.Join(vv, ",")
+		}
+	}
+	return m
+}
+
+// mapGroup computes the role of a group entry.
+func (c *Config) mapGroup(source string, entry *ldap.Entry) mappedRole {
+	id := value(entry, c.Group.IDAttribute)
+	label := value(entry, c.Group.DisplayAttribute)
+	if label == "" {
+		label = id
+	}
+	return mappedRole{Uuid: roleUuid(source, "", id), Label: label}
+}
+
+// applyRule cleans the values and filters them with the rule string. Roles and group paths are often read
+// from DN values (like memberOf), they are converted to their first RDN value.
+func applyRule(rule auth.MappingRule, vv []string) []string {
+	vv = rule.SanitizeValues(vv)
+	vv = rule.RemoveLdapEscape(vv)
+	if rule.RightAttribute == RightAttributeRoles || rule.RightAttribute == RightAttributeGroupPath {
+		vv = rule.ConvertDNtoName(vv)
+	}
+	if strings.HasPrefix(rule.RuleString, "preg:") {
+		vv = rule.FilterPreg(rule.RuleString, vv)
+	} else if rule.RuleString != "" {
+		vv = rule.FilterList(rule.SanitizeValues(strings.Split(rule.RuleString, ",")), vv)
+	}
+	return vv
+}
@@ -0,0 +1,330 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/asn1-ber.v1"
+	"gopkg.in/ldap.v2"
+)
+
+// testServer is a minimal in-process LDAP server supporting simple binds, searches with paging and StartTLS.
+type testServer struct {
+	sync.Mutex
+	listener net.Listener
+	tls      *tls.Config
+	entries  []*ldap.Entry
+	// passwords indexed by DN
+	passwords map[string]string
+	// searches counts the search requests, pages included
+	searches int
+}
+
+func newTestServer() (*testServer, error) {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		return nil, e
+	}
+	cert, e := selfSignedCertificate()
+	if e != nil {
+		return nil, e
+	}
+	s := &testServer{
+		listener:  l,
+		tls:       &tls.Config{Certificates: []tls.Certificate{cert}},
+		passwords: make(map[string]string),
+	}
+	go func() {
+		for {
+			conn, e := l.Accept()
+			if e != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, nil
+}
+
+func (s *testServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) Close() {
+	s.listener.Close()
+}
+
+// Add registers an entry with attributes given as name, value pairs. Repeated names add values.
+func (s *testServer) Add(dn string, password string, attributes ...string) {
+	s.Lock()
+	defer s.Unlock()
+	values := make(map[string][]string)
+	var names []string
+	for i := 0; i+1 < len(attributes); i += 2 {
+		if _, ok := values[attributes[i]]; !ok {
+			names = append(names, attributes[i])
+		}
+		values[attributes[i]] = append(values[attributes[i]], attributes[i+1])
+	}
+	entry := &ldap.Entry{DN: dn}
+	for _, n := range names {
+		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{Name: n, Values: values[n]})
+	}
+	s.entries = append(s.entries, entry)
+	if password != "" {
+		s.passwords[strings.ToLower(dn)] = password
+	}
+}
+
+// Remove deletes an entry.
+func (s *testServer) Remove(dn string) {
+	s.Lock()
+	defer s.Unlock()
+	for i, e := range s.entries {
+		if strings.EqualFold(e.DN, dn) {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		p, e := ber.ReadPacket(conn)
+		if e != nil || len(p.Children) < 2 {
+			return
+		}
+		id := p.Children[0].Value.(int64)
+		op := p.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			code := ldap.LDAPResultSuccess
+			s.Lock()
+			if expected, ok := s.passwords[strings.ToLower(dn)]; dn != "" && (!ok || expected != password) {
+				code = ldap.LDAPResultInvalidCredentials
+			}
+			s.Unlock()
+			conn.Write(response(id, ldap.ApplicationBindResponse, code, nil).Bytes())
+		case ldap.ApplicationSearchRequest:
+			for _, r := range s.search(id, op, p) {
+				conn.Write(r.Bytes())
+			}
+		case ldap.ApplicationExtendedRequest:
+			conn.Write(response(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess, nil).Bytes())
+			tlsConn := tls.Server(conn, s.tls)
+			if e := tlsConn.Handshake(); e != nil {
+				return
+			}
+			conn = tlsConn
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *testServer) search(id int64, op *ber.Packet, p *ber.Packet) []*ber.Packet {
+	s.Lock()
+	defer s.Unlock()
+	s.searches++
+	base := strings.ToLower(op.Children[0].Value.(string))
+	scope := op.Children[1].Value.(int64)
+	var matches []*ldap.Entry
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.DN)
+		if !strings.HasSuffix(dn, ","+base) && dn != base {
+			continue
+		}
+		if scope == ldap.ScopeSingleLevel && strings.Count(dn, ",") != strings.Count(base, ",")+1 {
+			continue
+		}
+		if matchFilter(e, op.Children[6]) {
+			matches = append(matches, e)
+		}
+	}
+	if len(matches) == 0 && base != "" {
+		// Unknown base DNs are reported as missing objects
+		found := false
+		for _, e := range s.entries {
+			if strings.HasSuffix(strings.ToLower(e.DN), base) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return []*ber.Packet{response(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject, nil)}
+		}
+	}
+
+	var paging *ldap.ControlPaging
+	if len(p.Children) > 2 {
+		for _, c := range p.Children[2].Children {
+			if ctrl, ok := ldap.DecodeControl(c).(*ldap.ControlPaging); ok {
+				paging = ctrl
+			}
+		}
+	}
+	var responses []*ber.Packet
+	var controls *ber.Packet
+	if paging != nil && paging.PagingSize > 0 {
+		offset, _ := strconv.Atoi(string(paging.Cookie))
+		end := offset + int(paging.PagingSize)
+		next := ""
+		if end < len(matches) {
+			next = strconv.Itoa(end)
+		} else {
+			end = len(matches)
+		}
+		matches = matches[offset:end]
+		pagingResponse := ldap.NewControlPaging(paging.PagingSize)
+		pagingResponse.SetCookie([]byte(next))
+		controls = ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+		controls.AppendChild(pagingResponse.Encode())
+	} else if paging != nil {
+		// Paging abandon
+		matches = nil
+	}
+	for _, e := range matches {
+		responses = append(responses, entryPacket(id, e))
+	}
+	return append(responses, response(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess, controls))
+}
+
+func matchFilter(e *ldap.Entry, f *ber.Packet) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matchFilter(e, c) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if matchFilter(e, c) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchFilter(e, f.Children[0])
+	case ldap.FilterPresent:
+		return strings.EqualFold(f.Data.String(), "objectClass") || len(values(e, f.Data.String())) > 0
+	case ldap.FilterEqualityMatch:
+		expected := f.Children[1].Value.(string)
+		for _, v := range values(e, f.Children[0].Value.(string)) {
+			if strings.EqualFold(v, expected) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterSubstrings:
+		for _, v := range values(e, f.Children[0].Value.(string)) {
+			v = strings.ToLower(v)
+			ok := true
+			for _, sub := range f.Children[1].Children {
+				part := strings.ToLower(sub.Data.String())
+				switch sub.Tag {
+				case ldap.FilterSubstringsInitial:
+					ok = ok && strings.HasPrefix(v, part)
+				case ldap.FilterSubstringsFinal:
+					ok = ok && strings.HasSuffix(v, part)
+				default:
+					ok = ok && strings.Contains(v, part)
+				}
+			}
+			if ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func response(id int64, tag ber.Tag, code int, controls *ber.Packet) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	p.AppendChild(op)
+	if controls != nil {
+		p.AppendChild(controls)
+	}
+	return p
+}
+
+func entryPacket(id int64, e *ldap.Entry) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "DN"))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for _, a := range e.Attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, a.Name, "Name"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range a.Values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attributes.AppendChild(attr)
+	}
+	op.AppendChild(attributes)
+	p.AppendChild(op)
+	return p
+}
+
+func selfSignedCertificate() (tls.Certificate, error) {
+	key, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		return tls.Certificate{}, e
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, e := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if e != nil {
+		return tls.Certificate{}, e
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,444 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+	"gopkg.in/ldap.v2"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+const (
+	// SyncActionName is the name of the scheduler action synchronizing a directory
+	SyncActionName = "actions.idm.ldap-sync"
+
+	// attrDisabled marks users locked because they were removed from the directory
+	attrDisabled = idm.UserAttrPrivatePrefix + "ldap_disabled"
+	lockLogout   = "logout"
+)
+
+// Syncer synchronizes the users of a directory into the users service, and their groups into the roles service.
+// Users are created under the configured group path with an AuthSource attribute set to the connector ID, groups
+// become roles assigned to their members.
+type Syncer struct {
+	// Source is the ID of the connector
+	Source string
+	Config *Config
+
+	UserClient idm.UserServiceClient
+	RoleClient idm.RoleServiceClient
+}
+
+// SyncStats counts the changes applied by a synchronization.
+type SyncStats struct {
+	Users    int
+	Created  int
+	Updated  int
+	Disabled int
+	Enabled  int
+	Roles    int
+	Errors   int
+}
+
+func (s *SyncStats) String() string {
+	return fmt.Sprintf("%d users found in directory: %d created, %d updated, %d disabled, %d enabled, %d roles created or updated, %d errors",
+		s.Users, s.Created, s.Updated, s.Disabled, s.Enabled, s.Roles, s.Errors)
+}
+
+type storeStatus int
+
+const (
+	statusUnchanged storeStatus = iota
+	statusCreated
+	statusUpdated
+	statusEnabled
+)
+
+// NewSyncer creates a Syncer using the default users and roles services.
+func NewSyncer(source string, conf *Config) *Syncer {
+	return &Syncer{
+		Source:     source,
+		Config:     conf,
+		UserClient: idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient()),
+		RoleClient: idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient()),
+	}
+}
+
+// Sync lists all users and groups of the directory, creates or updates the corresponding users and roles,
+// and disables users of this source that are not in the directory anymore. Users are not disabled if the
+// directory returned no users, or if more than the configured MaxDisabledRatio would be disabled.
+func (s *Syncer) Sync(ctx context.Context) (*SyncStats, error) {
+	cl, e := dial(s.Config)
+	if e != nil {
+		return nil, e
+	}
+	defer cl.Close()
+	entries, e := cl.users()
+	if e != nil {
+		return nil, e
+	}
+	groups, e := cl.groups()
+	if e != nil {
+		return nil, e
+	}
+
+	// Members are referenced either by DN or by id
+	members := make(map[string][]mappedRole)
+	var roles []mappedRole
+	for _, g := range groups {
+		r := s.Config.mapGroup(s.Source, g)
+		roles = append(roles, r)
+		for _, m := range values(g, s.Config.Group.MemberAttribute) {
+			key := strings.ToLower(m)
+			members[key] = append(members[key], r)
+		}
+	}
+	stats := &SyncStats{}
+	upstream := make(map[string]bool)
+	var users []*mappedUser
+	for _, entry := range entries {
+		m := s.Config.mapUser(s.Source, entry)
+		if m.Login == "" {
+			continue
+		}
+		m.Roles = append(m.Roles, members[strings.ToLower(entry.DN)]...)
+		m.Roles = append(m.Roles, members[strings.ToLower(m.Login)]...)
+		roles = append(roles, m.Roles...)
+		upstream[m.Login] = true
+		users = append(users, m)
+	}
+	stats.Users = len(users)
+
+	if stats.Roles, e = s.ensureRoles(ctx, roles); e != nil {
+		return nil, e
+	}
+	for _, m := range users {
+		_, status, e := s.storeUser(ctx, m)
+		if e != nil {
+			log.Logger(ctx).Error("Cannot synchronize user "+m.Login, zap.Error(e))
+			stats.Errors++
+			continue
+		}
+		switch status {
+		case statusCreated:
+			stats.Created++
+		case statusUpdated:
+			stats.Updated++
+		case statusEnabled:
+			stats.Enabled++
+		}
+	}
+
+	existing, e := s.searchUsers(ctx, &idm.UserSingleQuery{AttributeName: idm.UserAttrAuthSource, AttributeValue: s.Source})
+	if e != nil {
+		return stats, e
+	}
+	var active int
+	var removed []*idm.User
+	for _, u := range existing {
+		if u.Attributes[attrDisabled] != "" {
+			continue
+		}
+		active++
+		if !upstream[u.Login] {
+			removed = append(removed, u)
+		}
+	}
+	if len(removed) > 0 && len(users) == 0 {
+		return stats, fmt.Errorf("directory returned no users, %d users of this source are not disabled", len(removed))
+	}
+	if float64(len(removed)) > s.Config.MaxDisabledRatio*float64(active) {
+		return stats, fmt.Errorf("synchronization would disable %d users out of %d, more than the allowed ratio of %.2f: check the directory configuration", len(removed), active, s.Config.MaxDisabledRatio)
+	}
+	for _, u := range removed {
+		if e := s.disable(ctx, u); e != nil {
+			log.Logger(ctx).Error("Cannot disable user "+u.Login, zap.Error(e))
+			stats.Errors++
+			continue
+		}
+		log.Logger(ctx).Info("Disabled user " + u.Login + ", removed from directory")
+		stats.Disabled++
+	}
+	return stats, nil
+}
+
+// syncEntry synchronizes a single user entry and its groups, using an opened connection.
+func (s *Syncer) syncEntry(ctx context.Context, cl *directory, entry *ldap.Entry) (*idm.User, []mappedRole, error) {
+	m := s.Config.mapUser(s.Source, entry)
+	groups, e := cl.userGroups(entry)
+	if e != nil {
+		return nil, nil, e
+	}
+	for _, g := range groups {
+		m.Roles = append(m.Roles, s.Config.mapGroup(s.Source, g))
+	}
+	if _, e := s.ensureRoles(ctx, m.Roles); e != nil {
+		return nil, nil, e
+	}
+	u, _, e := s.storeUser(ctx, m)
+	return u, m.Roles, e
+}
+
+// storeUser creates or updates a user. Roles assigned manually to the user are kept.
+func (s *Syncer) storeUser(ctx context.Context, m *mappedUser) (*idm.User, storeStatus, error) {
+	existing, e := s.searchUsers(ctx, &idm.UserSingleQuery{Login: m.Login, NodeType: idm.NodeType_USER})
+	if e != nil {
+		return nil, statusUnchanged, e
+	}
+	if len(existing) == 0 {
+		u := &idm.User{
+			Login:      m.Login,
+			GroupPath:  m.GroupPath,
+			Attributes: map[string]string{idm.UserAttrProfile: s.Config.Profile},
+			Roles:      s.roles(m.Roles),
+		}
+		for k, v := range m.Attributes {
+			u.Attributes[k] = v
+		}
+		u.Attributes[idm.UserAttrAuthSource] = s.Source
+		resp, e := s.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+		if e != nil {
+			return nil, statusUnchanged, e
+		}
+		created := resp.GetUser()
+		_, e = s.RoleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:     created.Uuid,
+			Label:    "User " + created.Login,
+			UserRole: true,
+			Policies: service.NewResourcePoliciesBuilder().
+				WithProfileRead(common.PydioProfileStandard).
+				WithUserWrite(created.Login).
+				WithProfileWrite(common.PydioProfileAdmin).
+				Policies(),
+		}})
+		return created, statusCreated, e
+	}
+
+	current := existing[0]
+	if current.Attributes[idm.UserAttrAuthSource] != s.Source {
+		return nil, statusUnchanged, fmt.Errorf("login %s is already used by another authentication source", m.Login)
+	}
+	u := proto.Clone(current).(*idm.User)
+	u.Password = ""
+	u.GroupPath = m.GroupPath
+	for k, v := range m.Attributes {
+		u.Attributes[k] = v
+	}
+	u.Roles = nil
+	for _, r := range current.Roles {
+		if !r.UserRole && !r.GroupRole && !strings.HasPrefix(r.Uuid, s.Source+"_") {
+			u.Roles = append(u.Roles, r)
+		}
+	}
+	u.Roles = append(u.Roles, s.roles(m.Roles)...)
+	status := statusUpdated
+	if u.Attributes[attrDisabled] != "" {
+		delete(u.Attributes, attrDisabled)
+		setLock(u, lockLogout, false)
+		status = statusEnabled
+	} else if !userChanged(current, u) {
+		return current, statusUnchanged, nil
+	}
+	resp, e := s.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+	if e != nil {
+		return nil, statusUnchanged, e
+	}
+	return resp.GetUser(), status, nil
+}
+
+// disable locks a user removed from the directory.
+func (s *Syncer) disable(ctx context.Context, user *idm.User) error {
+	u := proto.Clone(user).(*idm.User)
+	u.Password = ""
+	u.Attributes[attrDisabled] = "true"
+	setLock(u, lockLogout, true)
+	_, e := s.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+	return e
+}
+
+// ensureRoles creates missing roles and updates their labels. It returns the number of created or updated roles.
+func (s *Syncer) ensureRoles(ctx context.Context, roles []mappedRole) (int, error) {
+	labels := make(map[string]string)
+	var uuids []string
+	for _, r := range roles {
+		if _, ok := labels[r.Uuid]; !ok {
+			uuids = append(uuids, r.Uuid)
+		}
+		labels[r.Uuid] = r.Label
+	}
+	if len(uuids) == 0 {
+		return 0, nil
+	}
+	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: uuids})
+	streamer, e := s.RoleClient.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return 0, e
+	}
+	existing := make(map[string]string)
+	defer streamer.Close()
+	for {
+		resp, e := streamer.Recv()
+		if e == io.EOF || resp == nil {
+			break
+		} else if e != nil {
+			return 0, e
+		}
+		existing[resp.GetRole().GetUuid()] = resp.GetRole().GetLabel()
+	}
+	var count int
+	for _, uuid := range uuids {
+		if l, ok := existing[uuid]; ok && l == labels[uuid] {
+			continue
+		}
+		if _, e := s.RoleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:  uuid,
+			Label: labels[uuid],
+			Policies: service.NewResourcePoliciesBuilder().
+				WithProfileRead(common.PydioProfileStandard).
+				WithProfileWrite(common.PydioProfileAdmin).
+				Policies(),
+		}}); e != nil {
+			return count, e
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (s *Syncer) searchUsers(ctx context.Context, query *idm.UserSingleQuery) ([]*idm.User, error) {
+	q, _ := ptypes.MarshalAny(query)
+	streamer, e := s.UserClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer streamer.Close()
+	var users []*idm.User
+	for {
+		resp, e := streamer.Recv()
+		if e == io.EOF || resp == nil {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		if !resp.GetUser().GetIsGroup() {
+			users = append(users, resp.GetUser())
+		}
+	}
+	return users, nil
+}
+
+// roles builds the roles of a user, without duplicates.
+func (s *Syncer) roles(mapped []mappedRole) []*idm.Role {
+	var roles []*idm.Role
+	seen := make(map[string]bool)
+	for _, r := range mapped {
+		if !seen[r.Uuid] {
+			roles = append(roles, &idm.Role{Uuid: r.Uuid, Label: r.Label})
+			seen[r.Uuid] = true
+		}
+	}
+	return roles
+}
+
+// userChanged compares the values managed by the synchronization.
+func userChanged(a, b *idm.User) bool {
+	if a.GroupPath != b.GroupPath || len(a.Attributes) != len(b.Attributes) {
+		return true
+	}
+	for k, v := range a.Attributes {
+		if b.Attributes[k] != v {
+			return true
+		}
+	}
+	return strings.Join(roleUuids(a), ",") != strings.Join(roleUuids(b), ",")
+}
+
+func roleUuids(u *idm.User) []string {
+	var uuids []string
+	for _, r := range u.Roles {
+		if !r.UserRole && !r.GroupRole {
+			uuids = append(uuids, r.Uuid)
+		}
+	}
+	sort.Strings(uuids)
+	return uuids
+}
+
+// setLock adds or removes a lock in the locks attribute of a user.
+func setLock(u *idm.User, lock string, set bool) {
+	var locks, newLocks []string
+	if l, ok := u.Attributes["locks"]; ok {
+		json.Unmarshal([]byte(l), &locks)
+	}
+	for _, l := range locks {
+		if l != lock {
+			newLocks = append(newLocks, l)
+		}
+	}
+	if set {
+		newLocks = append(newLocks, lock)
+	}
+	if len(newLocks) == 0 {
+		delete(u.Attributes, "locks")
+		return
+	}
+	data, _ := json.Marshal(newLocks)
+	u.Attributes["locks"] = string(data)
+}
+
+// SyncJob builds the job periodically synchronizing the directory of a connector.
+func SyncJob(id, name string, conf *Config) *jobs.Job {
+	return &jobs.Job{
+		ID:             SyncJobID(id),
+		Owner:          common.PydioSystemUsername,
+		Label:          "Synchronize users and groups from " + name,
+		MaxConcurrency: 1,
+		AutoStart:      false,
+		Schedule: &jobs.Schedule{
+			Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:00/" + conf.SyncInterval,
+		},
+		Actions: []*jobs.Action{{
+			ID:         SyncActionName,
+			Parameters: map[string]string{"connector": id},
+		}},
+	}
+}
+
+// SyncJobID is the ID of the synchronization job of a connector.
+func SyncJobID(id string) string {
+	return "ldap-sync-" + id
+}
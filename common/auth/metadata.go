@@ -36,7 +36,7 @@ import (
 const (
 	rolesMaxLength     = 6 * 1024
 	rolesRequireReload = "__RolesRequireReload__"
-	claimsContextKey   = "x-pydio-claims"
+	claimsContextKey   = claim.MetadataKey
 )
 
 // ContextFromClaims feeds context with correct Keys and Metadata for a given Claims
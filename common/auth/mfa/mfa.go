@@ -0,0 +1,311 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package mfa implements time-based one-time passwords (TOTP) as a second factor for password logins, with
+// single-use recovery codes. Secrets are stored in private attributes of the users.
+//
+// Users enroll through the frontend enroll endpoint, from an authenticated session. Administrators require a second
+// factor for users, roles or profiles by allowing them the "mfa" action on the "oidc" resource in a policy: such
+// users cannot log in until they are enrolled, either from a session opened before the policy applied or with a
+// secret provisioned by an administrator (see Provision). Personal access tokens and logins through external
+// providers are not concerned.
+package mfa
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/errors"
+	"github.com/ory/ladon"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	context2 "github.com/pydio/cells/common/utils/context"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+const (
+	// EnrollType is the type of the frontend enroll requests managing the second factor
+	EnrollType = "totp"
+	// Issuer is the name displayed in authenticator applications
+	Issuer = "Pydio Cells"
+	// PolicyAction is the action of "oidc" policies requiring a second factor
+	PolicyAction = "mfa"
+
+	// MetaCode is the metadata key passing the code typed by the user along with the password
+	MetaCode = "X-Pydio-Mfa-Code"
+
+	AttrSecret        = idm.UserAttrPrivatePrefix + "mfa_totp_secret"
+	AttrPending       = idm.UserAttrPrivatePrefix + "mfa_totp_pending"
+	AttrCounter       = idm.UserAttrPrivatePrefix + "mfa_totp_counter"
+	AttrRecoveryCodes = idm.UserAttrPrivatePrefix + "mfa_recovery_codes"
+
+	ErrRequired = "mfa.required"
+	ErrInvalid  = "mfa.invalid"
+	ErrEnroll   = "mfa.enroll"
+)
+
+// WithCode passes the second factor typed by the user to the login services.
+func WithCode(ctx context.Context, code string) context.Context {
+	if code == "" {
+		return ctx
+	}
+	return context2.WithAdditionalMetadata(ctx, map[string]string{MetaCode: code})
+}
+
+// CodeFromContext reads the second factor passed along with the password.
+func CodeFromContext(ctx context.Context) string {
+	code, _ := context2.CanonicalMeta(ctx, MetaCode)
+	return code
+}
+
+// Enrolled tells whether a user has an active second factor.
+func Enrolled(user *idm.User) bool {
+	return user.GetAttributes()[AttrSecret] != ""
+}
+
+// Required checks the "oidc" policies for the "mfa" action. It fails closed: a second factor is required if
+// policies cannot be loaded.
+func Required(ctx context.Context, user *idm.User) bool {
+	checker, e := permissions.CachedPoliciesChecker(ctx, "oidc")
+	if e != nil || checker == nil {
+		log.Logger(ctx).Warn("Cannot load policies to check second factor requirement, requiring it", zap.Error(e))
+		return true
+	}
+	policyContext := make(map[string]string)
+	permissions.PolicyContextFromMetadata(policyContext, ctx)
+	permissions.PolicyContextFromUser(policyContext, user)
+	reqCtx := ladon.Context{}
+	for k, v := range policyContext {
+		reqCtx[k] = v
+	}
+	var required bool
+	for _, subject := range permissions.PolicyRequestSubjectsFromUser(user) {
+		e := checker.IsAllowed(&ladon.Request{Resource: "oidc", Subject: subject, Action: PolicyAction, Context: reqCtx})
+		if e == ladon.ErrRequestForcefullyDenied {
+			return false
+		} else if e == nil {
+			required = true
+		}
+	}
+	return required
+}
+
+// Manager verifies second factors at login and manages the enrollment of users.
+type Manager struct {
+	UserClient idm.UserServiceClient
+	// Required tells whether a user must use a second factor, see the Required function
+	Required func(ctx context.Context, user *idm.User) bool
+	// Now is the clock used to compute codes
+	Now func() time.Time
+}
+
+// NewManager creates a Manager using the default users service and policies.
+func NewManager() *Manager {
+	return &Manager{
+		UserClient: idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient()),
+		Required:   Required,
+		Now:        time.Now,
+	}
+}
+
+// Verify is called once the password of a user is validated, before issuing tokens. It checks the code passed
+// in the context. A password alone never discloses a secret: users required to use a second factor without having
+// one get an ErrEnroll error, unless a secret is pending for them, in which case they are enrolled when they log
+// in with a valid code for this secret.
+func (m *Manager) Verify(ctx context.Context, userID string) error {
+	user, e := m.LoadUser(ctx, userID)
+	if e != nil {
+		return e
+	}
+	code := CodeFromContext(ctx)
+	if Enrolled(user) {
+		if code == "" {
+			return errors.New(ErrRequired, "A second authentication factor is required", http.StatusUnauthorized)
+		}
+		if !m.checkCode(user, code) {
+			return errors.New(ErrInvalid, "Invalid authentication code", http.StatusUnauthorized)
+		}
+		return m.save(ctx, user)
+	}
+	if !m.Required(ctx, user) {
+		return nil
+	}
+	pending := user.Attributes[AttrPending]
+	if pending == "" {
+		return errors.New(ErrEnroll, "A second authentication factor is required for this account, please ask an administrator to provision it", http.StatusUnauthorized)
+	}
+	if code == "" {
+		return errors.New(ErrRequired, "A second authentication factor is required", http.StatusUnauthorized)
+	}
+	counter, ok := ValidateTOTP(pending, code, m.Now())
+	if !ok {
+		return errors.New(ErrInvalid, "Invalid authentication code", http.StatusUnauthorized)
+	}
+	activate(user, pending, counter)
+	log.Auditer(ctx).Info("User "+user.Login+" enrolled a second authentication factor at login", user.ZapLogin())
+	return m.save(ctx, user)
+}
+
+// Setup generates the secret a user has to confirm to enroll. An existing pending secret is kept, as it may
+// already be registered in an application.
+func (m *Manager) Setup(ctx context.Context, user *idm.User) (string, string, error) {
+	secret := user.GetAttributes()[AttrPending]
+	if secret == "" {
+		var e error
+		if secret, e = GenerateSecret(); e != nil {
+			return "", "", e
+		}
+		if user.Attributes == nil {
+			user.Attributes = make(map[string]string)
+		}
+		user.Attributes[AttrPending] = secret
+		if e := m.save(ctx, user); e != nil {
+			return "", "", e
+		}
+	}
+	return secret, KeyURI(Issuer, user.Login, secret), nil
+}
+
+// Provision replaces the pending secret of a user with a new one. Administrators hand it to users who are required
+// to use a second factor and cannot log in yet: it is activated at their first login with a valid code.
+func (m *Manager) Provision(ctx context.Context, user *idm.User) (string, string, error) {
+	delete(user.Attributes, AttrPending)
+	return m.Setup(ctx, user)
+}
+
+// Confirm activates the pending secret of a user with a first code, and returns new recovery codes.
+func (m *Manager) Confirm(ctx context.Context, user *idm.User, code string) ([]string, error) {
+	pending := user.GetAttributes()[AttrPending]
+	if pending == "" {
+		return nil, errors.BadRequest(ErrEnroll, "No pending enrollment, call setup first")
+	}
+	counter, ok := ValidateTOTP(pending, code, m.Now())
+	if !ok {
+		return nil, errors.New(ErrInvalid, "Invalid authentication code", http.StatusUnauthorized)
+	}
+	activate(user, pending, counter)
+	return m.RecoveryCodes(ctx, user)
+}
+
+// RecoveryCodes replaces the recovery codes of a user.
+func (m *Manager) RecoveryCodes(ctx context.Context, user *idm.User) ([]string, error) {
+	codes, stored, e := GenerateRecoveryCodes()
+	if e != nil {
+		return nil, e
+	}
+	user.Attributes[AttrRecoveryCodes] = stored
+	return codes, m.save(ctx, user)
+}
+
+// Disable removes the second factor of a user after checking a last code. It is refused if policies require one.
+func (m *Manager) Disable(ctx context.Context, user *idm.User, code string) error {
+	if !Enrolled(user) {
+		return nil
+	}
+	if m.Required(ctx, user) {
+		return errors.Forbidden(ErrRequired, "A second authentication factor is required for this account")
+	}
+	if !m.checkCode(user, code) {
+		return errors.New(ErrInvalid, "Invalid authentication code", http.StatusUnauthorized)
+	}
+	ResetUser(user)
+	return m.save(ctx, user)
+}
+
+// CheckCode validates a code for an enrolled user, and records it as used.
+func (m *Manager) CheckCode(ctx context.Context, user *idm.User, code string) error {
+	if !Enrolled(user) || !m.checkCode(user, code) {
+		return errors.New(ErrInvalid, "Invalid authentication code", http.StatusUnauthorized)
+	}
+	return m.save(ctx, user)
+}
+
+// ResetUser removes all second factor attributes of a user.
+func ResetUser(user *idm.User) {
+	for _, a := range []string{AttrSecret, AttrPending, AttrCounter, AttrRecoveryCodes} {
+		delete(user.Attributes, a)
+	}
+}
+
+// checkCode accepts a TOTP code that was not used yet, or a recovery code that is then removed.
+func (m *Manager) checkCode(user *idm.User, code string) bool {
+	if counter, ok := ValidateTOTP(user.Attributes[AttrSecret], code, m.Now()); ok {
+		last, _ := strconv.ParseUint(user.Attributes[AttrCounter], 10, 64)
+		if counter <= last {
+			return false
+		}
+		user.Attributes[AttrCounter] = strconv.FormatUint(counter, 10)
+		return true
+	}
+	if remaining, ok := UseRecoveryCode(user.Attributes[AttrRecoveryCodes], code); ok {
+		user.Attributes[AttrRecoveryCodes] = remaining
+		return true
+	}
+	return false
+}
+
+func activate(user *idm.User, secret string, counter uint64) {
+	user.Attributes[AttrSecret] = secret
+	user.Attributes[AttrCounter] = strconv.FormatUint(counter, 10)
+	delete(user.Attributes, AttrPending)
+}
+
+// LoadUser reads a user from the users service, bypassing caches.
+func (m *Manager) LoadUser(ctx context.Context, userID string) (*idm.User, error) {
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Uuid: userID})
+	streamer, e := m.UserClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer streamer.Close()
+	for {
+		resp, e := streamer.Recv()
+		if e == io.EOF || resp == nil {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		if u := resp.GetUser(); !u.GetIsGroup() {
+			if u.Attributes == nil {
+				u.Attributes = make(map[string]string)
+			}
+			return u, nil
+		}
+	}
+	return nil, errors.NotFound(common.ServiceUser, "cannot find user %s", userID)
+}
+
+func (m *Manager) save(ctx context.Context, user *idm.User) error {
+	u := proto.Clone(user).(*idm.User)
+	u.Password = ""
+	_, e := m.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+	return e
+}
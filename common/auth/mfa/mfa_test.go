@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package mfa
+
+import (
+	"context"
+	"encoding/base32"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+type usersStream struct {
+	idm.UserService_SearchUserClient
+	users []*idm.User
+}
+
+func (s *usersStream) Recv() (*idm.SearchUserResponse, error) {
+	if len(s.users) == 0 {
+		return nil, io.EOF
+	}
+	u := s.users[0]
+	s.users = s.users[1:]
+	return &idm.SearchUserResponse{User: u}, nil
+}
+
+func (s *usersStream) Close() error {
+	return nil
+}
+
+// usersMock stores users by uuid
+type usersMock struct {
+	idm.UserServiceClient
+	users map[string]*idm.User
+}
+
+func (m *usersMock) CreateUser(ctx context.Context, in *idm.CreateUserRequest, opts ...client.CallOption) (*idm.CreateUserResponse, error) {
+	m.users[in.User.Uuid] = proto.Clone(in.User).(*idm.User)
+	return &idm.CreateUserResponse{User: in.User}, nil
+}
+
+func (m *usersMock) SearchUser(ctx context.Context, in *idm.SearchUserRequest, opts ...client.CallOption) (idm.UserService_SearchUserClient, error) {
+	q := &idm.UserSingleQuery{}
+	ptypes.UnmarshalAny(in.Query.SubQueries[0], q)
+	s := &usersStream{}
+	if u, ok := m.users[q.Uuid]; ok {
+		s.users = append(s.users, proto.Clone(u).(*idm.User))
+	}
+	return s, nil
+}
+
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTP(t *testing.T) {
+
+	Convey("Test RFC 6238 vectors", t, func() {
+		for ts, expected := range map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924"} {
+			code, e := TOTPCode(rfcSecret, time.Unix(ts, 0))
+			So(e, ShouldBeNil)
+			So(code, ShouldEqual, expected)
+		}
+	})
+
+	Convey("Test validation window", t, func() {
+		now := time.Unix(1234567890, 0)
+		code, _ := TOTPCode(rfcSecret, now)
+		counter, ok := ValidateTOTP(rfcSecret, code, now.Add(30*time.Second))
+		So(ok, ShouldBeTrue)
+		So(counter, ShouldEqual, 1234567890/30)
+		_, ok = ValidateTOTP(rfcSecret, code, now.Add(2*time.Minute))
+		So(ok, ShouldBeFalse)
+		_, ok = ValidateTOTP(rfcSecret, "", now)
+		So(ok, ShouldBeFalse)
+		_, ok = ValidateTOTP("", code, now)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Test secret and key URI", t, func() {
+		secret, e := GenerateSecret()
+		So(e, ShouldBeNil)
+		So(secret, ShouldHaveLength, 32)
+		uri := KeyURI(Issuer, "john", secret)
+		So(uri, ShouldStartWith, "otpauth://totp/")
+		So(uri, ShouldContainSubstring, "secret="+secret)
+	})
+}
+
+func TestRecoveryCodes(t *testing.T) {
+
+	Convey("Test recovery codes are single use", t, func() {
+		codes, stored, e := GenerateRecoveryCodes()
+		So(e, ShouldBeNil)
+		So(codes, ShouldHaveLength, 10)
+		So(CountRecoveryCodes(stored), ShouldEqual, 10)
+		So(stored, ShouldNotContainSubstring, codes[0])
+
+		remaining, ok := UseRecoveryCode(stored, strings.ToUpper(strings.Replace(codes[3], "-", "", 1)))
+		So(ok, ShouldBeTrue)
+		So(CountRecoveryCodes(remaining), ShouldEqual, 9)
+
+		_, ok = UseRecoveryCode(remaining, codes[3])
+		So(ok, ShouldBeFalse)
+		_, ok = UseRecoveryCode("", codes[0])
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestManager(t *testing.T) {
+
+	now := time.Unix(1234567890, 0)
+	newManager := func(required bool, users ...*idm.User) (*Manager, *usersMock) {
+		mock := &usersMock{users: make(map[string]*idm.User)}
+		for _, u := range users {
+			mock.users[u.Uuid] = u
+		}
+		return &Manager{
+			UserClient: mock,
+			Required:   func(context.Context, *idm.User) bool { return required },
+			Now:        func() time.Time { return now },
+		}, mock
+	}
+	enrolled := func() *idm.User {
+		_, stored, _ := GenerateRecoveryCodes()
+		return &idm.User{Uuid: "u1", Login: "john", Attributes: map[string]string{AttrSecret: rfcSecret, AttrRecoveryCodes: stored}}
+	}
+	code, _ := TOTPCode(rfcSecret, now)
+
+	Convey("Test users without second factor", t, func() {
+		m, _ := newManager(false, &idm.User{Uuid: "u1", Login: "john"})
+		So(m.Verify(context.Background(), "u1"), ShouldBeNil)
+	})
+
+	Convey("Test enrolled users", t, func() {
+		m, mock := newManager(false, enrolled())
+		e := m.Verify(context.Background(), "u1")
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrRequired)
+
+		e = m.Verify(WithCode(context.Background(), "000000"), "u1")
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrInvalid)
+
+		So(m.Verify(WithCode(context.Background(), code), "u1"), ShouldBeNil)
+		So(mock.users["u1"].Attributes[AttrCounter], ShouldEqual, "41152263")
+
+		// Codes cannot be replayed
+		e = m.Verify(WithCode(context.Background(), code), "u1")
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrInvalid)
+	})
+
+	Convey("Test recovery codes at login", t, func() {
+		m, mock := newManager(false, enrolled())
+		user, _ := m.LoadUser(context.Background(), "u1")
+		codes, e := m.RecoveryCodes(context.Background(), user)
+		So(e, ShouldBeNil)
+		So(m.Verify(WithCode(context.Background(), codes[0]), "u1"), ShouldBeNil)
+		So(CountRecoveryCodes(mock.users["u1"].Attributes[AttrRecoveryCodes]), ShouldEqual, 9)
+		e = m.Verify(WithCode(context.Background(), codes[0]), "u1")
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrInvalid)
+	})
+
+	Convey("Test enrollment at login when required", t, func() {
+		m, mock := newManager(true, &idm.User{Uuid: "u1", Login: "john"})
+		e := m.Verify(context.Background(), "u1")
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrEnroll)
+		// The password alone does not issue a secret
+		So(mock.users["u1"].Attributes[AttrPending], ShouldBeEmpty)
+
+		user, _ := m.LoadUser(context.Background(), "u1")
+		first, _, e := m.Provision(context.Background(), user)
+		So(e, ShouldBeNil)
+		pending, uri, e := m.Provision(context.Background(), user)
+		So(e, ShouldBeNil)
+		So(pending, ShouldNotEqual, first)
+		So(uri, ShouldContainSubstring, pending)
+
+		e = m.Verify(context.Background(), "u1")
+		parsed := errors.Parse(e.Error())
+		So(parsed.Id, ShouldEqual, ErrRequired)
+		So(parsed.Detail, ShouldNotContainSubstring, pending)
+
+		c, _ := TOTPCode(pending, now)
+		So(m.Verify(WithCode(context.Background(), c), "u1"), ShouldBeNil)
+		So(Enrolled(mock.users["u1"]), ShouldBeTrue)
+		So(mock.users["u1"].Attributes[AttrPending], ShouldBeEmpty)
+	})
+
+	Convey("Test setup, confirm and disable", t, func() {
+		m, mock := newManager(false, &idm.User{Uuid: "u1", Login: "john"})
+		user, _ := m.LoadUser(context.Background(), "u1")
+		secret, uri, e := m.Setup(context.Background(), user)
+		So(e, ShouldBeNil)
+		So(uri, ShouldContainSubstring, secret)
+
+		_, e = m.Confirm(context.Background(), user, "000000")
+		So(e, ShouldNotBeNil)
+		c, _ := TOTPCode(secret, now)
+		codes, e := m.Confirm(context.Background(), user, c)
+		So(e, ShouldBeNil)
+		So(codes, ShouldHaveLength, 10)
+		So(Enrolled(mock.users["u1"]), ShouldBeTrue)
+
+		// Code was consumed by the confirmation
+		So(m.Disable(context.Background(), user, c), ShouldNotBeNil)
+		So(m.Disable(context.Background(), user, codes[0]), ShouldBeNil)
+		So(Enrolled(mock.users["u1"]), ShouldBeFalse)
+		So(mock.users["u1"].Attributes[AttrRecoveryCodes], ShouldBeEmpty)
+	})
+
+	Convey("Test disable is refused when required", t, func() {
+		m, _ := newManager(true, enrolled())
+		user, _ := m.LoadUser(context.Background(), "u1")
+		e := m.Disable(context.Background(), user, code)
+		So(errors.Parse(e.Error()).Id, ShouldEqual, ErrRequired)
+	})
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	recoveryCodesCount  = 10
+	recoveryCodesLength = 10
+	// recoveryAlphabet has 32 characters without ambiguous ones (l, o, 0, 1), so that random bytes map evenly
+	recoveryAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// GenerateRecoveryCodes creates single-use recovery codes. Codes are returned in clear to be displayed once,
+// and as a JSON list of hashes to be stored.
+func GenerateRecoveryCodes() ([]string, string, error) {
+	var codes, hashes []string
+	for i := 0; i < recoveryCodesCount; i++ {
+		b := make([]byte, recoveryCodesLength)
+		if _, e := rand.Read(b); e != nil {
+			return nil, "", e
+		}
+		for j := range b {
+			b[j] = recoveryAlphabet[int(b[j])%len(recoveryAlphabet)]
+		}
+		code := string(b[:recoveryCodesLength/2]) + "-" + string(b[recoveryCodesLength/2:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	data, _ := json.Marshal(hashes)
+	return codes, string(data), nil
+}
+
+// UseRecoveryCode looks for a code in the stored hashes. If found, it returns the remaining hashes.
+func UseRecoveryCode(stored, code string) (string, bool) {
+	var hashes, remaining []string
+	if stored == "" || json.Unmarshal([]byte(stored), &hashes) != nil {
+		return stored, false
+	}
+	h := hashRecoveryCode(code)
+	var found bool
+	for _, s := range hashes {
+		if !found && subtle.ConstantTimeCompare([]byte(s), []byte(h)) == 1 {
+			found = true
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	if !found {
+		return stored, false
+	}
+	data, _ := json.Marshal(remaining)
+	return string(data), true
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes.
+func CountRecoveryCodes(stored string) int {
+	var hashes []string
+	json.Unmarshal([]byte(stored), &hashes)
+	return len(hashes)
+}
+
+// hashRecoveryCode normalizes codes, so that they can be typed without dash and in any case.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
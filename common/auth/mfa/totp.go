@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the validity of a code, in seconds
+	totpPeriod = 30
+	// totpSkew is the number of periods accepted before and after the current one, to cope with clock drifts
+	totpSkew   = 1
+	totpDigits = 6
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret creates a random TOTP secret, base32 encoded as expected by authenticator applications.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, e := rand.Read(b); e != nil {
+		return "", e
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// KeyURI builds the otpauth:// URI of a secret, usually displayed as a QR code.
+func KeyURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", totpDigits))
+	v.Set("period", fmt.Sprintf("%d", totpPeriod))
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// TOTPCode computes the code of a secret at a given time (RFC 6238).
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return hotp(secret, uint64(t.Unix()/totpPeriod))
+}
+
+// ValidateTOTP checks a code against the periods around the given time. It returns the period (counter) that
+// matched, so that callers can refuse a code that was already used.
+func ValidateTOTP(secret, code string, t time.Time) (uint64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	counter := uint64(t.Unix() / totpPeriod)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		c := counter + uint64(i)
+		expected, e := hotp(secret, c)
+		if e != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// hotp computes an HMAC-SHA1 based one-time password (RFC 4226).
+func hotp(secret string, counter uint64) (string, error) {
+	key, e := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if e != nil {
+		return "", e
+	}
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000), nil
+}
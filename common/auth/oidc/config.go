@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package oidc provides a connector delegating authentication to an external OpenID Connect provider (Keycloak,
+// Azure AD, Google...). Users are redirected to the provider with the authorization code flow, and are created or
+// updated in the internal identity services when they come back.
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+)
+
+const (
+	// ConnectorType is the type of OpenID Connect connectors in the oauth service configuration
+	ConnectorType = "oidc"
+
+	// RightAttributeRoles is the reserved right attribute of mapping rules assigning roles to users
+	RightAttributeRoles = "Roles"
+	// RightAttributeGroupPath is the reserved right attribute of mapping rules placing users in a group
+	RightAttributeGroupPath = "GroupPath"
+)
+
+// Config is the configuration of an OpenID Connect connector.
+type Config struct {
+	// Issuer is the URL of the provider, used for discovery
+	Issuer string `json:"issuer"`
+	// ClientID and ClientSecret are the credentials of the client registered on the provider
+	ClientID     string `json:"clientID"`
+	ClientSecret string `json:"clientSecret"`
+	// RedirectURI overrides the callback URL computed from the request, it must be registered on the provider
+	RedirectURI string `json:"redirectURI"`
+	// Scopes requested to the provider, openid, profile and email by default
+	Scopes []string `json:"scopes"`
+
+	// LoginClaim is the claim used as login, preferred_username by default
+	LoginClaim string `json:"loginClaim"`
+	// EmailClaim and NameClaim are copied to the email and displayName attributes, email and name by default
+	EmailClaim string `json:"emailClaim"`
+	NameClaim  string `json:"nameClaim"`
+
+	// MappingRules maps claims on users attributes, roles and group path. Nested claims are read with a dotted
+	// path, e.g. realm_access.roles
+	MappingRules []auth.MappingRule `json:"mappingRules"`
+
+	// GroupPath is the group where users are created, defaults to the root
+	GroupPath string `json:"groupPath"`
+	// Profile given to created users, defaults to standard
+	Profile string `json:"profile"`
+
+	// PostLogoutRedirectURI is where the provider sends users back after logging out, defaults to the site root
+	PostLogoutRedirectURI string `json:"postLogoutRedirectURI"`
+}
+
+// Validate checks mandatory values and sets defaults.
+func (c *Config) Validate() error {
+	if c.Issuer == "" {
+		return fmt.Errorf("oidc connector: missing issuer")
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oidc connector: missing client ID")
+	}
+	if len(c.Scopes) == 0 {
+		c.Scopes = []string{"openid", "profile", "email"}
+	} else if !contains(c.Scopes, "openid") {
+		c.Scopes = append([]string{"openid"}, c.Scopes...)
+	}
+	if c.LoginClaim == "" {
+		c.LoginClaim = "preferred_username"
+	}
+	if c.EmailClaim == "" {
+		c.EmailClaim = "email"
+	}
+	if c.NameClaim == "" {
+		c.NameClaim = "name"
+	}
+	if c.GroupPath == "" {
+		c.GroupPath = "/"
+	} else {
+		c.GroupPath = "/" + strings.Trim(c.GroupPath, "/")
+	}
+	if c.Profile == "" {
+		c.Profile = common.PydioProfileStandard
+	}
+	return nil
+}
+
+// ConfigFromMessage reads a configuration passed to the connector opener as a struct message.
+func ConfigFromMessage(msg proto.Message) (*Config, error) {
+	st, ok := msg.(*structpb.Struct)
+	if !ok || st == nil {
+		return nil, fmt.Errorf("oidc connector: missing configuration")
+	}
+	data, e := (&jsonpb.Marshaler{}).MarshalToString(st)
+	if e != nil {
+		return nil, e
+	}
+	c := &Config{}
+	if e := json.Unmarshal([]byte(data), c); e != nil {
+		return nil, e
+	}
+	return c, c.Validate()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc"
+	dlog "github.com/dexidp/dex/pkg/log"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/oauth2"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	// logoutTokenMaxAge bounds the age of logout tokens that do not carry an expiry
+	logoutTokenMaxAge = 10 * time.Minute
+)
+
+var (
+	_ auth.CallbackConnector = (*connector)(nil)
+	_ auth.LogoutConnector   = (*connector)(nil)
+)
+
+func init() {
+	auth.RegisterConnectorType(ConnectorType, func(data proto.Message) (auth.Opener, error) {
+		c, e := ConfigFromMessage(data)
+		if e != nil {
+			return nil, e
+		}
+		return &opener{conf: c}, nil
+	})
+}
+
+type opener struct {
+	conf *Config
+}
+
+func (o *opener) Open(id string, _ dlog.Logger) (auth.Connector, error) {
+	return &connector{conf: o.conf, provisioner: NewProvisioner(id, o.conf)}, nil
+}
+
+// connector implements the authorization code flow against an OpenID Connect provider. The provider metadata is
+// discovered on first use, so that an unreachable provider does not prevent the service from starting.
+type connector struct {
+	conf        *Config
+	provisioner *Provisioner
+
+	sync.Mutex
+	provider   *gooidc.Provider
+	endSession string
+}
+
+// connectorData is returned with the identity and passed back to LogoutURL.
+type connectorData struct {
+	IDToken string `json:"idToken"`
+}
+
+func (c *connector) getProvider() (*gooidc.Provider, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.provider != nil {
+		return c.provider, nil
+	}
+	p, e := gooidc.NewProvider(context.Background(), c.conf.Issuer)
+	if e != nil {
+		return nil, e
+	}
+	var discovery struct {
+		EndSession string `json:"end_session_endpoint"`
+	}
+	if e := p.Claims(&discovery); e != nil {
+		return nil, e
+	}
+	c.provider = p
+	c.endSession = discovery.EndSession
+	return p, nil
+}
+
+func (c *connector) oauth2Config(p *gooidc.Provider, callbackURL string) *oauth2.Config {
+	if c.conf.RedirectURI != "" {
+		callbackURL = c.conf.RedirectURI
+	}
+	return &oauth2.Config{
+		ClientID:     c.conf.ClientID,
+		ClientSecret: c.conf.ClientSecret,
+		Endpoint:     p.Endpoint(),
+		RedirectURL:  callbackURL,
+		Scopes:       c.conf.Scopes,
+	}
+}
+
+// LoginURL redirects to the provider authorization endpoint. The state is also used as nonce.
+func (c *connector) LoginURL(s auth.Scopes, callbackURL, state string) (string, error) {
+	p, e := c.getProvider()
+	if e != nil {
+		return "", e
+	}
+	return c.oauth2Config(p, callbackURL).AuthCodeURL(state, gooidc.Nonce(state)), nil
+}
+
+// HandleCallback exchanges the code for an ID token, verifies it and provisions the corresponding user.
+func (c *connector) HandleCallback(s auth.Scopes, r *http.Request) (auth.Identity, error) {
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		return auth.Identity{}, fmt.Errorf("oidc connector: provider returned %s: %s", e, q.Get("error_description"))
+	}
+	p, e := c.getProvider()
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	ctx := r.Context()
+	token, e := c.oauth2Config(p, callbackURL(r)).Exchange(ctx, q.Get("code"))
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok {
+		return auth.Identity{}, fmt.Errorf("oidc connector: no id_token in token response")
+	}
+	idToken, e := p.Verifier(&gooidc.Config{ClientID: c.conf.ClientID, SkipNonceCheck: true}).Verify(ctx, raw)
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	if idToken.Nonce != q.Get("state") {
+		return auth.Identity{}, fmt.Errorf("oidc connector: invalid nonce in id_token")
+	}
+	claims := make(map[string]interface{})
+	if e := idToken.Claims(&claims); e != nil {
+		return auth.Identity{}, e
+	}
+	m, e := c.conf.mapClaims(c.provisioner.Source, claims)
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	user, e := c.provisioner.Provision(ctx, m)
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	groups := []string{}
+	for _, r := range m.Roles {
+		groups = append(groups, r.Label)
+	}
+	verified, _ := claims["email_verified"].(bool)
+	data, _ := json.Marshal(&connectorData{IDToken: raw})
+	return auth.Identity{
+		UserID:        user.GetUuid(),
+		Username:      user.GetLogin(),
+		Email:         user.GetAttributes()[idm.UserAttrEmail],
+		EmailVerified: verified,
+		Claims:        claims,
+		Groups:        groups,
+		ConnectorData: data,
+	}, nil
+}
+
+// LogoutURL builds the RP-initiated logout URL of the provider, if it publishes an end_session_endpoint.
+func (c *connector) LogoutURL(data []byte, postLogoutRedirectURL string) (string, error) {
+	if _, e := c.getProvider(); e != nil {
+		return "", e
+	}
+	if c.endSession == "" {
+		return "", nil
+	}
+	u, e := url.Parse(c.endSession)
+	if e != nil {
+		return "", e
+	}
+	v := u.Query()
+	d := &connectorData{}
+	if len(data) > 0 {
+		json.Unmarshal(data, d)
+	}
+	if d.IDToken != "" {
+		v.Set("id_token_hint", d.IDToken)
+	}
+	v.Set("client_id", c.conf.ClientID)
+	if c.conf.PostLogoutRedirectURI != "" {
+		postLogoutRedirectURL = c.conf.PostLogoutRedirectURI
+	}
+	if postLogoutRedirectURL != "" {
+		v.Set("post_logout_redirect_uri", postLogoutRedirectURL)
+	}
+	u.RawQuery = v.Encode()
+	return u.String(), nil
+}
+
+// HandleLogout verifies a back-channel logout token posted by the provider. Tokens identifying the session
+// with a sid claim only are not supported, as upstream sessions are not tracked.
+func (c *connector) HandleLogout(r *http.Request) (string, error) {
+	if e := r.ParseForm(); e != nil {
+		return "", e
+	}
+	raw := r.PostForm.Get("logout_token")
+	if raw == "" {
+		return "", fmt.Errorf("oidc connector: missing logout_token")
+	}
+	p, e := c.getProvider()
+	if e != nil {
+		return "", e
+	}
+	ctx := r.Context()
+	token, e := p.Verifier(&gooidc.Config{ClientID: c.conf.ClientID, SkipExpiryCheck: true, SkipNonceCheck: true}).Verify(ctx, raw)
+	if e != nil {
+		return "", e
+	}
+	var claims struct {
+		Events map[string]interface{} `json:"events"`
+		Nonce  string                 `json:"nonce"`
+	}
+	if e := token.Claims(&claims); e != nil {
+		return "", e
+	}
+	if _, ok := claims.Events[backChannelLogoutEvent]; !ok || claims.Nonce != "" {
+		return "", fmt.Errorf("oidc connector: invalid logout_token")
+	}
+	now := time.Now()
+	if (!token.Expiry.IsZero() && token.Expiry.Before(now)) || token.IssuedAt.Before(now.Add(-logoutTokenMaxAge)) {
+		return "", fmt.Errorf("oidc connector: logout_token is expired")
+	}
+	if token.Subject == "" {
+		return "", fmt.Errorf("oidc connector: logout_token without sub claim is not supported")
+	}
+	user, e := c.provisioner.FindBySubject(ctx, token.Subject)
+	if e != nil {
+		return "", e
+	}
+	return user.GetUuid(), nil
+}
+
+// callbackURL rebuilds the URL the provider redirected to, without its query, as it must be sent again
+// when exchanging the code.
+func callbackURL(r *http.Request) string {
+	u := *r.URL
+	u.RawQuery = ""
+	if u.Host == "" {
+		u.Host = r.Host
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+			u.Scheme = "https"
+		}
+	}
+	return u.String()
+}
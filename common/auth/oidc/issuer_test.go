@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	testClientID     = "cells"
+	testClientSecret = "cells-secret"
+)
+
+// testIssuer is a minimal OpenID Connect provider: it serves the discovery document and the keys, authorizes
+// any request for the configured user claims and exchanges codes for signed ID tokens.
+type testIssuer struct {
+	*httptest.Server
+	key jose.JSONWebKey
+
+	sync.Mutex
+	// Claims are the claims of the next authenticated user
+	Claims map[string]interface{}
+	codes  map[string]url.Values
+}
+
+func newTestIssuer() (*testIssuer, error) {
+	priv, e := rsa.GenerateKey(rand.Reader, 2048)
+	if e != nil {
+		return nil, e
+	}
+	i := &testIssuer{
+		key:   jose.JSONWebKey{Key: priv, KeyID: "test-key", Algorithm: string(jose.RS256), Use: "sig"},
+		codes: make(map[string]url.Values),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/keys", i.keys)
+	mux.HandleFunc("/auth", i.authorize)
+	mux.HandleFunc("/token", i.token)
+	i.Server = httptest.NewServer(mux)
+	return i, nil
+}
+
+func (i *testIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/auth",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/keys",
+		"end_session_endpoint":   i.URL + "/logout?ui_locales=en",
+	})
+}
+
+func (i *testIssuer) keys(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{i.key.Public()}})
+}
+
+// authorize immediately redirects to the client with a code
+func (i *testIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != testClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	i.Lock()
+	code := "code-" + q.Get("state")
+	i.codes[code] = q
+	i.Unlock()
+	http.Redirect(w, r, q.Get("redirect_uri")+"?code="+code+"&state="+url.QueryEscape(q.Get("state")), http.StatusFound)
+}
+
+func (i *testIssuer) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	i.Lock()
+	auth, found := i.codes[r.PostForm.Get("code")]
+	delete(i.codes, r.PostForm.Get("code"))
+	claims := make(map[string]interface{})
+	for k, v := range i.Claims {
+		claims[k] = v
+	}
+	i.Unlock()
+	if id != testClientID || secret != testClientSecret || !found || auth.Get("redirect_uri") != r.PostForm.Get("redirect_uri") {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	now := time.Now()
+	claims["iss"] = i.URL
+	claims["aud"] = testClientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Hour).Unix()
+	if n := auth.Get("nonce"); n != "" {
+		claims["nonce"] = n
+	}
+	idToken, e := i.Sign(claims)
+	if e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "access-" + r.PostForm.Get("code"),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// Sign builds a JWT with the issuer key
+func (i *testIssuer) Sign(claims map[string]interface{}) (string, error) {
+	signer, e := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: i.key}, nil)
+	if e != nil {
+		return "", e
+	}
+	payload, e := json.Marshal(claims)
+	if e != nil {
+		return "", e
+	}
+	jws, e := signer.Sign(payload)
+	if e != nil {
+		return "", e
+	}
+	return jws.CompactSerialize()
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oidc
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// mappedRole is a role computed from claims values.
+type mappedRole struct {
+	Uuid  string
+	Label string
+}
+
+// mappedUser holds the values of the ID token claims, transformed by the mapping rules.
+type mappedUser struct {
+	Login      string
+	Subject    string
+	Attributes map[string]string
+	Roles      []mappedRole
+	GroupPath  string
+}
+
+// roleUuid builds the UUID of a role as AuthSourceName_Prefix_RoleID.
+func roleUuid(source, prefix, id string) string {
+	if prefix != "" {
+		return source + "_" + prefix + "_" + id
+	}
+	return source + "_" + id
+}
+
+// mapClaims applies the mapping rules on the claims of an ID token. The login falls back to the email when the
+// login claim is missing, as some providers (Azure AD, Google) do not send a preferred_username by default. This
+// fallback requires the email to be verified by the provider.
+func (c *Config) mapClaims(source string, claims map[string]interface{}) (*mappedUser, error) {
+	m := &mappedUser{
+		Login:      first(claimValues(claims, c.LoginClaim)),
+		Subject:    first(claimValues(claims, "sub")),
+		Attributes: make(map[string]string),
+		GroupPath:  c.GroupPath,
+	}
+	if m.Login == "" && first(claimValues(claims, "email_verified")) == "true" {
+		m.Login = first(claimValues(claims, c.EmailClaim))
+	}
+	if m.Login == "" || m.Subject == "" {
+		return nil, fmt.Errorf("oidc connector: ID token has no %s or sub claim", c.LoginClaim)
+	}
+	if v := first(claimValues(claims, c.EmailClaim)); v != "" {
+		m.Attributes[idm.UserAttrEmail] = v
+	}
+	if v := first(claimValues(claims, c.NameClaim)); v != "" {
+		m.Attributes[idm.UserAttrDisplayName] = v
+	}
+	for _, rule := range c.MappingRules {
+		vv := applyRule(rule, claimValues(claims, rule.LeftAttribute))
+		if len(vv) == 0 {
+			continue
+		}
+		switch rule.RightAttribute {
+		case RightAttributeRoles:
+			for _, v := range vv {
+				// Group paths (e.g. Keycloak /team/dev) are flattened to keep role UUIDs usable in URLs
+				id := strings.Replace(strings.Trim(v, "/"), "/", "_", -1)
+				m.Roles = append(m.Roles, mappedRole{Uuid: roleUuid(source, rule.RolePrefix, id), Label: v})
+			}
+		case RightAttributeGroupPath:
+			m.GroupPath = path.Join(c.GroupPath, strings.Trim(vv[0], "/"))
+		default:
+			m.Attributes[rule.RightAttribute] = strings.Join(vv, ",")
+		}
+	}
+	return m, nil
+}
+
+// claimValues reads a claim as a list of strings. Nested claims are looked up with a dotted path when there is
+// no top-level claim with that name.
+func claimValues(claims map[string]interface{}, name string) []string {
+	v, ok := claims[name]
+	if !ok {
+		var current interface{} = claims
+		for _, part := range strings.Split(name, ".") {
+			obj, isObj := current.(map[string]interface{})
+			if !isObj {
+				return nil
+			}
+			if current, ok = obj[part]; !ok {
+				return nil
+			}
+		}
+		v = current
+	}
+	switch t := v.(type) {
+	case nil, map[string]interface{}:
+		return nil
+	case string:
+		return []string{t}
+	case []interface{}:
+		var vv []string
+		for _, i := range t {
+			if s := claimValues(map[string]interface{}{"v": i}, "v"); len(s) > 0 {
+				vv = append(vv, s...)
+			}
+		}
+		return vv
+	default:
+		return []string{fmt.Sprint(t)}
+	}
+}
+
+// applyRule cleans the values and filters them with the rule string.
+func applyRule(rule auth.MappingRule, vv []string) []string {
+	vv = rule.SanitizeValues(vv)
+	if strings.HasPrefix(rule.RuleString, "preg:") {
+		vv = rule.FilterPreg(rule.RuleString, vv)
+	} else if rule.RuleString != "" {
+		vv = rule.FilterList(rule.SanitizeValues(strings.Split(rule.RuleString, ",")), vv)
+	}
+	return vv
+}
+
+func first(vv []string) string {
+	if len(vv) > 0 {
+		return vv[0]
+	}
+	return ""
+}
@@ -0,0 +1,420 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/micro/go-micro/client"
+	"github.com/pborman/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const testCallback = "https://cells.example.com/oidc/connectors/keycloak/callback"
+
+type usersStream struct {
+	idm.UserService_SearchUserClient
+	users []*idm.User
+}
+
+func (s *usersStream) Recv() (*idm.SearchUserResponse, error) {
+	if len(s.users) == 0 {
+		return nil, io.EOF
+	}
+	u := s.users[0]
+	s.users = s.users[1:]
+	return &idm.SearchUserResponse{User: u}, nil
+}
+
+func (s *usersStream) Close() error {
+	return nil
+}
+
+// usersMock stores users by login and mimics the roles returned by the users service
+type usersMock struct {
+	idm.UserServiceClient
+	users map[string]*idm.User
+}
+
+func (m *usersMock) CreateUser(ctx context.Context, in *idm.CreateUserRequest, opts ...client.CallOption) (*idm.CreateUserResponse, error) {
+	u := proto.Clone(in.User).(*idm.User)
+	if u.Uuid == "" {
+		u.Uuid = "uuid-" + u.Login
+	}
+	var roles []*idm.Role
+	for _, r := range u.Roles {
+		if !r.UserRole {
+			roles = append(roles, &idm.Role{Uuid: r.Uuid, Label: r.Uuid})
+		}
+	}
+	u.Roles = append(roles, &idm.Role{Uuid: u.Uuid, UserRole: true})
+	m.users[u.Login] = u
+	return &idm.CreateUserResponse{User: u}, nil
+}
+
+func (m *usersMock) SearchUser(ctx context.Context, in *idm.SearchUserRequest, opts ...client.CallOption) (idm.UserService_SearchUserClient, error) {
+	q := &idm.UserSingleQuery{}
+	ptypes.UnmarshalAny(in.Query.SubQueries[0], q)
+	s := &usersStream{}
+	for _, u := range m.users {
+		if (q.Login != "" && u.Login == q.Login) || (q.AttributeName != "" && u.Attributes[q.AttributeName] == q.AttributeValue) {
+			s.users = append(s.users, proto.Clone(u).(*idm.User))
+		}
+	}
+	return s, nil
+}
+
+type rolesStream struct {
+	idm.RoleService_SearchRoleClient
+	roles []*idm.Role
+}
+
+func (s *rolesStream) Recv() (*idm.SearchRoleResponse, error) {
+	if len(s.roles) == 0 {
+		return nil, io.EOF
+	}
+	r := s.roles[0]
+	s.roles = s.roles[1:]
+	return &idm.SearchRoleResponse{Role: r}, nil
+}
+
+func (s *rolesStream) Close() error {
+	return nil
+}
+
+type rolesMock struct {
+	idm.RoleServiceClient
+	roles map[string]*idm.Role
+}
+
+func (m *rolesMock) CreateRole(ctx context.Context, in *idm.CreateRoleRequest, opts ...client.CallOption) (*idm.CreateRoleResponse, error) {
+	m.roles[in.Role.Uuid] = in.Role
+	return &idm.CreateRoleResponse{Role: in.Role}, nil
+}
+
+func (m *rolesMock) SearchRole(ctx context.Context, in *idm.SearchRoleRequest, opts ...client.CallOption) (idm.RoleService_SearchRoleClient, error) {
+	q := &idm.RoleSingleQuery{}
+	ptypes.UnmarshalAny(in.Query.SubQueries[0], q)
+	s := &rolesStream{}
+	for _, uuid := range q.Uuid {
+		if r, ok := m.roles[uuid]; ok {
+			s.roles = append(s.roles, r)
+		}
+	}
+	return s, nil
+}
+
+func testConfig(issuer string) *Config {
+	c := &Config{
+		Issuer:       issuer,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		GroupPath:    "/oidc",
+		MappingRules: []auth.MappingRule{
+			{LeftAttribute: "realm_access.roles", RightAttribute: RightAttributeRoles, RuleString: "preg:^cells-"},
+			{LeftAttribute: "groups", RightAttribute: RightAttributeRoles, RolePrefix: "group"},
+			{LeftAttribute: "department", RightAttribute: RightAttributeGroupPath, RuleString: "sales,support"},
+			{LeftAttribute: "phone_number", RightAttribute: "phone"},
+		},
+	}
+	c.Validate()
+	return c
+}
+
+func testClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":                "f81d4fae-7dec",
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"name":               "Alice",
+		"realm_access":       map[string]interface{}{"roles": []interface{}{"cells-admins", "offline_access"}},
+		"groups":             []interface{}{"/staff/dev"},
+		"department":         "sales",
+		"phone_number":       12345,
+	}
+}
+
+// login runs the authorization code flow against the issuer, with the given state
+func login(c *connector, state string) (auth.Identity, error) {
+	loginURL, e := c.LoginURL(auth.Scopes{}, testCallback, state)
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	cl := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, e := cl.Get(loginURL)
+	if e != nil {
+		return auth.Identity{}, e
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return auth.Identity{}, fmt.Errorf("unexpected authorization status %d", resp.StatusCode)
+	}
+	return c.HandleCallback(auth.Scopes{}, httptest.NewRequest(http.MethodGet, resp.Header.Get("Location"), nil))
+}
+
+func logoutRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/oidc/connectors/keycloak/backchannel-logout", strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestConfig(t *testing.T) {
+	Convey("Test configuration", t, func() {
+		So((&Config{}).Validate(), ShouldNotBeNil)
+		So((&Config{Issuer: "https://accounts.google.com"}).Validate(), ShouldNotBeNil)
+
+		c := &Config{Issuer: "https://accounts.google.com", ClientID: "id", GroupPath: "google/"}
+		So(c.Validate(), ShouldBeNil)
+		So(c.Scopes, ShouldResemble, []string{"openid", "profile", "email"})
+		So(c.LoginClaim, ShouldEqual, "preferred_username")
+		So(c.GroupPath, ShouldEqual, "/google")
+		So(c.Profile, ShouldEqual, "standard")
+
+		c = &Config{Issuer: "https://login.microsoftonline.com/tenant/v2.0", ClientID: "id", Scopes: []string{"email"}}
+		So(c.Validate(), ShouldBeNil)
+		So(c.Scopes, ShouldResemble, []string{"openid", "email"})
+
+		st := &structpb.Struct{}
+		So(jsonpb.UnmarshalString(`{"issuer":"https://sso.example.com/realms/cells","clientID":"cells","loginClaim":"email","mappingRules":[{"LeftAttribute":"groups","RightAttribute":"Roles"}]}`, st), ShouldBeNil)
+		conf, e := ConfigFromMessage(st)
+		So(e, ShouldBeNil)
+		So(conf.LoginClaim, ShouldEqual, "email")
+		So(conf.MappingRules, ShouldHaveLength, 1)
+		So(conf.MappingRules[0].RightAttribute, ShouldEqual, RightAttributeRoles)
+
+		_, e = ConfigFromMessage(nil)
+		So(e, ShouldNotBeNil)
+	})
+}
+
+func TestMapping(t *testing.T) {
+	Convey("Test claims mapping", t, func() {
+		c := testConfig("https://sso.example.com")
+		m, e := c.mapClaims("keycloak", testClaims())
+		So(e, ShouldBeNil)
+		So(m.Login, ShouldEqual, "alice")
+		So(m.Subject, ShouldEqual, "f81d4fae-7dec")
+		So(m.GroupPath, ShouldEqual, "/oidc/sales")
+		So(m.Attributes, ShouldResemble, map[string]string{"email": "alice@example.com", "displayName": "Alice", "phone": "12345"})
+		So(m.Roles, ShouldResemble, []mappedRole{
+			{Uuid: "keycloak_cells-admins", Label: "cells-admins"},
+			{Uuid: "keycloak_group_staff_dev", Label: "/staff/dev"},
+		})
+
+		// Login falls back to the email, only if it is verified
+		claims := testClaims()
+		delete(claims, "preferred_username")
+		claims["department"] = "marketing"
+		m, e = c.mapClaims("keycloak", claims)
+		So(e, ShouldBeNil)
+		So(m.Login, ShouldEqual, "alice@example.com")
+		So(m.GroupPath, ShouldEqual, "/oidc")
+
+		claims["email_verified"] = false
+		_, e = c.mapClaims("keycloak", claims)
+		So(e, ShouldNotBeNil)
+		claims["email_verified"] = true
+
+		delete(claims, "email")
+		_, e = c.mapClaims("keycloak", claims)
+		So(e, ShouldNotBeNil)
+
+		So(claimValues(map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{"x", nil, true}}}, "a.b"), ShouldResemble, []string{"x", "true"})
+		So(claimValues(map[string]interface{}{"a.b": "flat"}, "a.b"), ShouldResemble, []string{"flat"})
+		So(claimValues(map[string]interface{}{"a": "x"}, "a.b"), ShouldBeNil)
+	})
+}
+
+func TestConnector(t *testing.T) {
+	Convey("Test authorization code flow", t, func() {
+		issuer, e := newTestIssuer()
+		So(e, ShouldBeNil)
+		defer issuer.Close()
+		issuer.Claims = testClaims()
+
+		users := &usersMock{users: make(map[string]*idm.User)}
+		roles := &rolesMock{roles: make(map[string]*idm.Role)}
+		o := &opener{conf: testConfig(issuer.URL)}
+		conn, e := o.Open("keycloak", nil)
+		So(e, ShouldBeNil)
+		c := conn.(*connector)
+		c.provisioner.UserClient = users
+		c.provisioner.RoleClient = roles
+
+		loginURL, e := c.LoginURL(auth.Scopes{}, testCallback, "state-1")
+		So(e, ShouldBeNil)
+		u, _ := url.Parse(loginURL)
+		So(u.Query().Get("redirect_uri"), ShouldEqual, testCallback)
+		So(u.Query().Get("nonce"), ShouldEqual, "state-1")
+		So(u.Query().Get("scope"), ShouldEqual, "openid profile email")
+
+		identity, e := login(c, uuid.New())
+		So(e, ShouldBeNil)
+		So(identity.UserID, ShouldEqual, "uuid-alice")
+		So(identity.Username, ShouldEqual, "alice")
+		So(identity.Email, ShouldEqual, "alice@example.com")
+		So(identity.EmailVerified, ShouldBeTrue)
+		So(identity.Groups, ShouldResemble, []string{"cells-admins", "/staff/dev"})
+		So(identity.ConnectorData, ShouldNotBeEmpty)
+
+		alice := users.users["alice"]
+		So(alice, ShouldNotBeNil)
+		So(alice.GroupPath, ShouldEqual, "/oidc/sales")
+		So(alice.Attributes[idm.UserAttrAuthSource], ShouldEqual, "keycloak")
+		So(alice.Attributes[attrSubject], ShouldEqual, "f81d4fae-7dec")
+		So(alice.Attributes["phone"], ShouldEqual, "12345")
+		So(roleUuids(alice), ShouldEqual, "keycloak_cells-admins,keycloak_group_staff_dev")
+		So(roles.roles, ShouldContainKey, "uuid-alice")
+		So(roles.roles["keycloak_group_staff_dev"].Label, ShouldEqual, "/staff/dev")
+
+		Convey("Next logins update the user and keep manual roles", func() {
+			users.users["alice"].Roles = append(users.users["alice"].Roles, &idm.Role{Uuid: "manual"})
+			issuer.Claims["groups"] = []interface{}{}
+			issuer.Claims["name"] = "Alice Liddell"
+			_, e := login(c, uuid.New())
+			So(e, ShouldBeNil)
+			So(users.users["alice"].Attributes["displayName"], ShouldEqual, "Alice Liddell")
+			So(roleUuids(users.users["alice"]), ShouldEqual, "keycloak_cells-admins,manual")
+		})
+
+		Convey("Locked users are refused", func() {
+			users.users["alice"].Attributes["locks"] = `["logout"]`
+			_, e := login(c, uuid.New())
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("Users are found by subject", func() {
+			issuer.Claims["preferred_username"] = "alice.renamed"
+			identity, e := login(c, uuid.New())
+			So(e, ShouldBeNil)
+			So(identity.UserID, ShouldEqual, "uuid-alice")
+			So(users.users, ShouldNotContainKey, "alice.renamed")
+		})
+
+		Convey("Another account of the provider with the same login is refused", func() {
+			issuer.Claims["sub"] = "other-subject"
+			_, e := login(c, uuid.New())
+			So(e, ShouldNotBeNil)
+			So(users.users["alice"].Attributes[attrSubject], ShouldEqual, "f81d4fae-7dec")
+		})
+
+		Convey("Logins of other sources are refused", func() {
+			users.users["bob"] = &idm.User{Uuid: "uuid-bob", Login: "bob", Attributes: map[string]string{idm.UserAttrAuthSource: "pydio"}}
+			issuer.Claims["preferred_username"] = "bob"
+			issuer.Claims["sub"] = "bob-subject"
+			_, e := login(c, uuid.New())
+			So(e, ShouldNotBeNil)
+			So(users.users["bob"].Attributes[attrSubject], ShouldBeEmpty)
+		})
+
+		Convey("Tokens must carry the state as nonce", func() {
+			cl := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}}
+			loginURL, _ := c.LoginURL(auth.Scopes{}, testCallback, "state-2")
+			resp, e := cl.Get(strings.Replace(loginURL, "nonce=state-2", "nonce=other", 1))
+			So(e, ShouldBeNil)
+			resp.Body.Close()
+			_, e = c.HandleCallback(auth.Scopes{}, httptest.NewRequest(http.MethodGet, resp.Header.Get("Location"), nil))
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("Provider errors are reported", func() {
+			_, e := c.HandleCallback(auth.Scopes{}, httptest.NewRequest(http.MethodGet, testCallback+"?error=access_denied&state=s", nil))
+			So(e, ShouldNotBeNil)
+			So(e.Error(), ShouldContainSubstring, "access_denied")
+		})
+
+		Convey("Logout URL uses the end session endpoint", func() {
+			logoutURL, e := c.LogoutURL(identity.ConnectorData, "https://cells.example.com/")
+			So(e, ShouldBeNil)
+			u, _ := url.Parse(logoutURL)
+			So(u.Path, ShouldEqual, "/logout")
+			So(u.Query().Get("ui_locales"), ShouldEqual, "en")
+			So(u.Query().Get("id_token_hint"), ShouldNotBeEmpty)
+			So(u.Query().Get("client_id"), ShouldEqual, testClientID)
+			So(u.Query().Get("post_logout_redirect_uri"), ShouldEqual, "https://cells.example.com/")
+
+			c.conf.PostLogoutRedirectURI = "https://www.example.com/bye"
+			logoutURL, e = c.LogoutURL(nil, "https://cells.example.com/")
+			So(e, ShouldBeNil)
+			u, _ = url.Parse(logoutURL)
+			So(u.Query().Get("id_token_hint"), ShouldBeEmpty)
+			So(u.Query().Get("post_logout_redirect_uri"), ShouldEqual, "https://www.example.com/bye")
+		})
+
+		Convey("Back-channel logout tokens are verified", func() {
+			claims := func() map[string]interface{} {
+				return map[string]interface{}{
+					"iss":    issuer.URL,
+					"aud":    testClientID,
+					"iat":    time.Now().Unix(),
+					"jti":    uuid.New(),
+					"sub":    "f81d4fae-7dec",
+					"sid":    "session",
+					"events": map[string]interface{}{backChannelLogoutEvent: map[string]interface{}{}},
+				}
+			}
+			token, _ := issuer.Sign(claims())
+			userID, e := c.HandleLogout(logoutRequest(token))
+			So(e, ShouldBeNil)
+			So(userID, ShouldEqual, "uuid-alice")
+
+			_, e = c.HandleLogout(logoutRequest(""))
+			So(e, ShouldNotBeNil)
+
+			invalid := []func(map[string]interface{}){
+				func(cl map[string]interface{}) { delete(cl, "events") },
+				func(cl map[string]interface{}) { cl["nonce"] = "n" },
+				func(cl map[string]interface{}) { cl["aud"] = "other" },
+				func(cl map[string]interface{}) { cl["iat"] = time.Now().Add(-time.Hour).Unix() },
+				func(cl map[string]interface{}) { cl["exp"] = time.Now().Add(-time.Minute).Unix() },
+				func(cl map[string]interface{}) { delete(cl, "sub") },
+				func(cl map[string]interface{}) { cl["sub"] = "unknown" },
+			}
+			for _, change := range invalid {
+				cl := claims()
+				change(cl)
+				token, _ := issuer.Sign(cl)
+				_, e := c.HandleLogout(logoutRequest(token))
+				So(e, ShouldNotBeNil)
+			}
+		})
+	})
+}
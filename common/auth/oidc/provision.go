@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+const (
+	// attrSubject stores the subject of the user at the provider, to find users from back-channel logout tokens
+	attrSubject = idm.UserAttrPrivatePrefix + "oidc_subject"
+)
+
+// Provisioner creates users the first time they log in through a provider, and updates their attributes and roles
+// on next logins. Users are created with an AuthSource attribute set to the connector ID; roles assigned manually
+// to them are kept.
+type Provisioner struct {
+	// Source is the ID of the connector
+	Source string
+	Config *Config
+
+	UserClient idm.UserServiceClient
+	RoleClient idm.RoleServiceClient
+}
+
+// NewProvisioner creates a Provisioner using the default users and roles services.
+func NewProvisioner(source string, conf *Config) *Provisioner {
+	return &Provisioner{
+		Source:     source,
+		Config:     conf,
+		UserClient: idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient()),
+		RoleClient: idm.NewRoleServiceClient(common.ServiceGrpcNamespace_+common.ServiceRole, defaults.NewClient()),
+	}
+}
+
+// Provision creates or updates the user matching the claims of an ID token. Users are identified by their subject
+// at the provider: an existing user with the same login is only used if it has no subject yet, a login bound to
+// another subject is refused.
+func (p *Provisioner) Provision(ctx context.Context, m *mappedUser) (*idm.User, error) {
+	if e := p.ensureRoles(ctx, m.Roles); e != nil {
+		return nil, e
+	}
+	existing, e := p.usersBySubject(ctx, m.Subject)
+	if e != nil {
+		return nil, e
+	}
+	if len(existing) == 0 {
+		if existing, e = p.searchUsers(ctx, &idm.UserSingleQuery{Login: m.Login, NodeType: idm.NodeType_USER}); e != nil {
+			return nil, e
+		}
+		if len(existing) > 0 {
+			if s := existing[0].Attributes[attrSubject]; s != "" && s != m.Subject {
+				return nil, fmt.Errorf("login %s is already used by another account of the provider", m.Login)
+			}
+		}
+	}
+	if len(existing) == 0 {
+		u := &idm.User{
+			Login:      m.Login,
+			GroupPath:  m.GroupPath,
+			Attributes: map[string]string{idm.UserAttrProfile: p.Config.Profile},
+			Roles:      p.roles(m.Roles),
+		}
+		for k, v := range m.Attributes {
+			u.Attributes[k] = v
+		}
+		u.Attributes[idm.UserAttrAuthSource] = p.Source
+		u.Attributes[attrSubject] = m.Subject
+		resp, e := p.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+		if e != nil {
+			return nil, e
+		}
+		created := resp.GetUser()
+		_, e = p.RoleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:     created.Uuid,
+			Label:    "User " + created.Login,
+			UserRole: true,
+			Policies: service.NewResourcePoliciesBuilder().
+				WithProfileRead(common.PydioProfileStandard).
+				WithUserWrite(created.Login).
+				WithProfileWrite(common.PydioProfileAdmin).
+				Policies(),
+		}})
+		return created, e
+	}
+
+	current := existing[0]
+	if current.Attributes[idm.UserAttrAuthSource] != p.Source {
+		return nil, fmt.Errorf("login %s is already used by another authentication source", m.Login)
+	}
+	if permissions.IsUserLocked(current) {
+		return nil, fmt.Errorf("user %s is locked", current.Login)
+	}
+	u := proto.Clone(current).(*idm.User)
+	u.Password = ""
+	u.GroupPath = m.GroupPath
+	for k, v := range m.Attributes {
+		u.Attributes[k] = v
+	}
+	u.Attributes[attrSubject] = m.Subject
+	u.Roles = nil
+	for _, r := range current.Roles {
+		if !r.UserRole && !r.GroupRole && !strings.HasPrefix(r.Uuid, p.Source+"_") {
+			u.Roles = append(u.Roles, r)
+		}
+	}
+	u.Roles = append(u.Roles, p.roles(m.Roles)...)
+	if !userChanged(current, u) {
+		return current, nil
+	}
+	resp, e := p.UserClient.CreateUser(ctx, &idm.CreateUserRequest{User: u})
+	if e != nil {
+		return nil, e
+	}
+	return resp.GetUser(), nil
+}
+
+// FindBySubject finds the user of this source having the given subject at the provider.
+func (p *Provisioner) FindBySubject(ctx context.Context, subject string) (*idm.User, error) {
+	users, e := p.usersBySubject(ctx, subject)
+	if e != nil {
+		return nil, e
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("cannot find user with subject %s", subject)
+	}
+	return users[0], nil
+}
+
+// usersBySubject lists the users of this source having the given subject at the provider.
+func (p *Provisioner) usersBySubject(ctx context.Context, subject string) ([]*idm.User, error) {
+	users, e := p.searchUsers(ctx, &idm.UserSingleQuery{AttributeName: attrSubject, AttributeValue: subject, NodeType: idm.NodeType_USER})
+	if e != nil {
+		return nil, e
+	}
+	var filtered []*idm.User
+	for _, u := range users {
+		if u.Attributes[idm.UserAttrAuthSource] == p.Source {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered, nil
+}
+
+// ensureRoles creates missing roles and updates their labels.
+func (p *Provisioner) ensureRoles(ctx context.Context, roles []mappedRole) error {
+	labels := make(map[string]string)
+	var uuids []string
+	for _, r := range roles {
+		if _, ok := labels[r.Uuid]; !ok {
+			uuids = append(uuids, r.Uuid)
+		}
+		labels[r.Uuid] = r.Label
+	}
+	if len(uuids) == 0 {
+		return nil
+	}
+	q, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: uuids})
+	streamer, e := p.RoleClient.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return e
+	}
+	existing := make(map[string]string)
+	defer streamer.Close()
+	for {
+		resp, e := streamer.Recv()
+		if e == io.EOF || resp == nil {
+			break
+		} else if e != nil {
+			return e
+		}
+		existing[resp.GetRole().GetUuid()] = resp.GetRole().GetLabel()
+	}
+	for _, uuid := range uuids {
+		if l, ok := existing[uuid]; ok && l == labels[uuid] {
+			continue
+		}
+		if _, e := p.RoleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:  uuid,
+			Label: labels[uuid],
+			Policies: service.NewResourcePoliciesBuilder().
+				WithProfileRead(common.PydioProfileStandard).
+				WithProfileWrite(common.PydioProfileAdmin).
+				Policies(),
+		}}); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *Provisioner) searchUsers(ctx context.Context, query *idm.UserSingleQuery) ([]*idm.User, error) {
+	q, _ := ptypes.MarshalAny(query)
+	streamer, e := p.UserClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer streamer.Close()
+	var users []*idm.User
+	for {
+		resp, e := streamer.Recv()
+		if e == io.EOF || resp == nil {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		if !resp.GetUser().GetIsGroup() {
+			users = append(users, resp.GetUser())
+		}
+	}
+	return users, nil
+}
+
+// roles builds the roles of a user, without duplicates.
+func (p *Provisioner) roles(mapped []mappedRole) []*idm.Role {
+	var roles []*idm.Role
+	seen := make(map[string]bool)
+	for _, r := range mapped {
+		if !seen[r.Uuid] {
+			roles = append(roles, &idm.Role{Uuid: r.Uuid, Label: r.Label})
+			seen[r.Uuid] = true
+		}
+	}
+	return roles
+}
+
+// userChanged compares the values managed by the provisioning.
+func userChanged(a, b *idm.User) bool {
+	if a.GroupPath != b.GroupPath || len(a.Attributes) != len(b.Attributes) {
+		return true
+	}
+	for k, v := range a.Attributes {
+		if b.Attributes[k] != v {
+			return true
+		}
+	}
+	return roleUuids(a) != roleUuids(b)
+}
+
+func roleUuids(u *idm.User) string {
+	var uuids []string
+	for _, r := range u.Roles {
+		if !r.UserRole && !r.GroupRole {
+			uuids = append(uuids, r.Uuid)
+		}
+	}
+	sort.Strings(uuids)
+	return strings.Join(uuids, ",")
+}
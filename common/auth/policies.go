@@ -24,6 +24,7 @@ func checkOIDCPolicies(ctx context.Context, user *idm.User) error {
 	subjects := permissions.PolicyRequestSubjectsFromUser(user)
 	policyContext := make(map[string]string)
 	permissions.PolicyContextFromMetadata(policyContext, ctx)
+	permissions.PolicyContextFromUser(policyContext, user)
 
 	checker, err := permissions.CachedPoliciesChecker(ctx, "oidc")
 	if err != nil {
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package crypto
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// UserKeySize is the size of the keys of a user key pair
+	UserKeySize = 32
+)
+
+// GenerateUserKeyPair creates a new X25519 key pair used to wrap node keys for a given user.
+func GenerateUserKeyPair() (public []byte, private []byte, err error) {
+	private, err = RandomBytes(UserKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	public, err = UserPublicKey(private)
+	return
+}
+
+// UserPublicKey computes the public key corresponding to a user private key.
+func UserPublicKey(private []byte) ([]byte, error) {
+	if len(private) != UserKeySize {
+		return nil, fmt.Errorf("invalid private key size")
+	}
+	var pub, priv [UserKeySize]byte
+	copy(priv[:], private)
+	curve25519.ScalarBaseMult(&pub, &priv)
+	return pub[:], nil
+}
+
+// WrapKey encrypts a key so that it can only be unwrapped with the private key corresponding to
+// the recipient public key. An ephemeral key pair is used to derive a shared secret, its public part
+// is prepended to the sealed data.
+func WrapKey(recipientPublic []byte, key []byte) ([]byte, error) {
+	if len(recipientPublic) != UserKeySize {
+		return nil, fmt.Errorf("invalid public key size")
+	}
+	ephemeralPublic, ephemeralPrivate, err := GenerateUserKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	wrappingKey, err := deriveWrappingKey(ephemeralPrivate, recipientPublic, ephemeralPublic, recipientPublic)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := Seal(wrappingKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeralPublic, sealed...), nil
+}
+
+// UnwrapKey decrypts a key previously wrapped with the public key corresponding to this private key.
+func UnwrapKey(private []byte, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < UserKeySize+12 {
+		return nil, fmt.Errorf("invalid wrapped key")
+	}
+	public, err := UserPublicKey(private)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPublic := wrapped[:UserKeySize]
+	sealed := wrapped[UserKeySize:]
+	wrappingKey, err := deriveWrappingKey(private, ephemeralPublic, ephemeralPublic, public)
+	if err != nil {
+		return nil, err
+	}
+	return Open(wrappingKey, sealed[:12], sealed[12:])
+}
+
+func deriveWrappingKey(private, peerPublic, ephemeralPublic, recipientPublic []byte) ([]byte, error) {
+	var shared, priv, pub [UserKeySize]byte
+	copy(priv[:], private)
+	copy(pub[:], peerPublic)
+	curve25519.ScalarMult(&shared, &priv, &pub)
+	salt := append(append([]byte{}, ephemeralPublic...), recipientPublic...)
+	wrappingKey := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared[:], salt, []byte("pydio-node-key")), wrappingKey); err != nil {
+		return nil, err
+	}
+	return wrappingKey, nil
+}
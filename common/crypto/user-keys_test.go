@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package crypto
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUserKeys(t *testing.T) {
+
+	Convey("Wrap and unwrap a node key", t, func() {
+		public, private, err := GenerateUserKeyPair()
+		So(err, ShouldBeNil)
+		So(public, ShouldHaveLength, UserKeySize)
+		So(private, ShouldHaveLength, UserKeySize)
+
+		nodeKey, _ := RandomBytes(32)
+		wrapped, err := WrapKey(public, nodeKey)
+		So(err, ShouldBeNil)
+		So(wrapped, ShouldNotResemble, nodeKey)
+
+		unwrapped, err := UnwrapKey(private, wrapped)
+		So(err, ShouldBeNil)
+		So(unwrapped, ShouldResemble, nodeKey)
+	})
+
+	Convey("Another private key cannot unwrap", t, func() {
+		public, _, _ := GenerateUserKeyPair()
+		_, otherPrivate, _ := GenerateUserKeyPair()
+		nodeKey, _ := RandomBytes(32)
+		wrapped, err := WrapKey(public, nodeKey)
+		So(err, ShouldBeNil)
+		_, err = UnwrapKey(otherPrivate, wrapped)
+		So(err, ShouldNotBeNil)
+		_, err = UnwrapKey(otherPrivate, wrapped[:10])
+		So(err, ShouldNotBeNil)
+	})
+
+}
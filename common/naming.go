@@ -209,6 +209,8 @@ const (
 	DocStoreIdVersioningPolicies = "versioningPolicies"
 	DocStoreIdShares             = "share"
 	DocStoreIdResetPassKeys      = "resetPasswordKeys"
+	DocStoreIdDavLocks           = "davLocks"
+	DocStoreIdWopiLocks          = "wopiLocks"
 )
 
 // Define constants for Loggging configuration
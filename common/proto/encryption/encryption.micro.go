@@ -24,8 +24,20 @@ It has these top-level messages:
 	AdminExportKeyResponse
 	AdminImportKeyRequest
 	AdminImportKeyResponse
+	AdminRotateKeyRequest
+	AdminRotateKeyResponse
 	AdminCreateKeyRequest
 	AdminCreateKeyResponse
+	UnlockUserKeyRequest
+	UnlockUserKeyResponse
+	LockUserKeyRequest
+	LockUserKeyResponse
+	ChangeUserKeyPasswordRequest
+	ChangeUserKeyPasswordResponse
+	RecoverUserKeyRequest
+	RecoverUserKeyResponse
+	RequireUserKeyRecoveryRequest
+	RequireUserKeyRecoveryResponse
 	NodeKey
 	Node
 	NodeInfo
@@ -88,6 +100,11 @@ type UserKeyStoreClient interface {
 	AdminDeleteKey(ctx context.Context, in *AdminDeleteKeyRequest, opts ...client.CallOption) (*AdminDeleteKeyResponse, error)
 	AdminExportKey(ctx context.Context, in *AdminExportKeyRequest, opts ...client.CallOption) (*AdminExportKeyResponse, error)
 	AdminImportKey(ctx context.Context, in *AdminImportKeyRequest, opts ...client.CallOption) (*AdminImportKeyResponse, error)
+	UnlockUserKey(ctx context.Context, in *UnlockUserKeyRequest, opts ...client.CallOption) (*UnlockUserKeyResponse, error)
+	LockUserKey(ctx context.Context, in *LockUserKeyRequest, opts ...client.CallOption) (*LockUserKeyResponse, error)
+	ChangeUserKeyPassword(ctx context.Context, in *ChangeUserKeyPasswordRequest, opts ...client.CallOption) (*ChangeUserKeyPasswordResponse, error)
+	RecoverUserKey(ctx context.Context, in *RecoverUserKeyRequest, opts ...client.CallOption) (*RecoverUserKeyResponse, error)
+	RequireUserKeyRecovery(ctx context.Context, in *RequireUserKeyRecoveryRequest, opts ...client.CallOption) (*RequireUserKeyRecoveryResponse, error)
 }
 
 type userKeyStoreClient struct {
@@ -178,6 +195,56 @@ func (c *userKeyStoreClient) AdminImportKey(ctx context.Context, in *AdminImport
 	return out, nil
 }
 
+func (c *userKeyStoreClient) UnlockUserKey(ctx context.Context, in *UnlockUserKeyRequest, opts ...client.CallOption) (*UnlockUserKeyResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "UserKeyStore.UnlockUserKey", in)
+	out := new(UnlockUserKeyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userKeyStoreClient) LockUserKey(ctx context.Context, in *LockUserKeyRequest, opts ...client.CallOption) (*LockUserKeyResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "UserKeyStore.LockUserKey", in)
+	out := new(LockUserKeyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userKeyStoreClient) ChangeUserKeyPassword(ctx context.Context, in *ChangeUserKeyPasswordRequest, opts ...client.CallOption) (*ChangeUserKeyPasswordResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "UserKeyStore.ChangeUserKeyPassword", in)
+	out := new(ChangeUserKeyPasswordResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userKeyStoreClient) RecoverUserKey(ctx context.Context, in *RecoverUserKeyRequest, opts ...client.CallOption) (*RecoverUserKeyResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "UserKeyStore.RecoverUserKey", in)
+	out := new(RecoverUserKeyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userKeyStoreClient) RequireUserKeyRecovery(ctx context.Context, in *RequireUserKeyRecoveryRequest, opts ...client.CallOption) (*RequireUserKeyRecoveryResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "UserKeyStore.RequireUserKeyRecovery", in)
+	out := new(RequireUserKeyRecoveryResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for UserKeyStore service
 
 type UserKeyStoreHandler interface {
@@ -188,6 +255,11 @@ type UserKeyStoreHandler interface {
 	AdminDeleteKey(context.Context, *AdminDeleteKeyRequest, *AdminDeleteKeyResponse) error
 	AdminExportKey(context.Context, *AdminExportKeyRequest, *AdminExportKeyResponse) error
 	AdminImportKey(context.Context, *AdminImportKeyRequest, *AdminImportKeyResponse) error
+	UnlockUserKey(context.Context, *UnlockUserKeyRequest, *UnlockUserKeyResponse) error
+	LockUserKey(context.Context, *LockUserKeyRequest, *LockUserKeyResponse) error
+	ChangeUserKeyPassword(context.Context, *ChangeUserKeyPasswordRequest, *ChangeUserKeyPasswordResponse) error
+	RecoverUserKey(context.Context, *RecoverUserKeyRequest, *RecoverUserKeyResponse) error
+	RequireUserKeyRecovery(context.Context, *RequireUserKeyRecoveryRequest, *RequireUserKeyRecoveryResponse) error
 }
 
 func RegisterUserKeyStoreHandler(s server.Server, hdlr UserKeyStoreHandler, opts ...server.HandlerOption) {
@@ -226,6 +298,26 @@ func (h *UserKeyStore) AdminImportKey(ctx context.Context, in *AdminImportKeyReq
 	return h.UserKeyStoreHandler.AdminImportKey(ctx, in, out)
 }
 
+func (h *UserKeyStore) UnlockUserKey(ctx context.Context, in *UnlockUserKeyRequest, out *UnlockUserKeyResponse) error {
+	return h.UserKeyStoreHandler.UnlockUserKey(ctx, in, out)
+}
+
+func (h *UserKeyStore) LockUserKey(ctx context.Context, in *LockUserKeyRequest, out *LockUserKeyResponse) error {
+	return h.UserKeyStoreHandler.LockUserKey(ctx, in, out)
+}
+
+func (h *UserKeyStore) ChangeUserKeyPassword(ctx context.Context, in *ChangeUserKeyPasswordRequest, out *ChangeUserKeyPasswordResponse) error {
+	return h.UserKeyStoreHandler.ChangeUserKeyPassword(ctx, in, out)
+}
+
+func (h *UserKeyStore) RecoverUserKey(ctx context.Context, in *RecoverUserKeyRequest, out *RecoverUserKeyResponse) error {
+	return h.UserKeyStoreHandler.RecoverUserKey(ctx, in, out)
+}
+
+func (h *UserKeyStore) RequireUserKeyRecovery(ctx context.Context, in *RequireUserKeyRecoveryRequest, out *RequireUserKeyRecoveryResponse) error {
+	return h.UserKeyStoreHandler.RequireUserKeyRecovery(ctx, in, out)
+}
+
 // Client API for NodeKeyManager service
 
 type NodeKeyManagerClient interface {
@@ -233,6 +325,7 @@ type NodeKeyManagerClient interface {
 	GetNodePlainSize(ctx context.Context, in *GetNodePlainSizeRequest, opts ...client.CallOption) (*GetNodePlainSizeResponse, error)
 	SetNodeInfo(ctx context.Context, opts ...client.CallOption) (NodeKeyManager_SetNodeInfoClient, error)
 	CopyNodeInfo(ctx context.Context, in *CopyNodeInfoRequest, opts ...client.CallOption) (*CopyNodeInfoResponse, error)
+	SetNodeKey(ctx context.Context, in *SetNodeKeyRequest, opts ...client.CallOption) (*SetNodeKeyResponse, error)
 	DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...client.CallOption) (*DeleteNodeResponse, error)
 	DeleteNodeKey(ctx context.Context, in *DeleteNodeKeyRequest, opts ...client.CallOption) (*DeleteNodeKeyResponse, error)
 	DeleteNodeSharedKey(ctx context.Context, in *DeleteNodeSharedKeyRequest, opts ...client.CallOption) (*DeleteNodeSharedKeyResponse, error)
@@ -322,6 +415,16 @@ func (c *nodeKeyManagerClient) CopyNodeInfo(ctx context.Context, in *CopyNodeInf
 	return out, nil
 }
 
+func (c *nodeKeyManagerClient) SetNodeKey(ctx context.Context, in *SetNodeKeyRequest, opts ...client.CallOption) (*SetNodeKeyResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "NodeKeyManager.SetNodeKey", in)
+	out := new(SetNodeKeyResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeKeyManagerClient) DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...client.CallOption) (*DeleteNodeResponse, error) {
 	req := c.c.NewRequest(c.serviceName, "NodeKeyManager.DeleteNode", in)
 	out := new(DeleteNodeResponse)
@@ -359,6 +462,7 @@ type NodeKeyManagerHandler interface {
 	GetNodePlainSize(context.Context, *GetNodePlainSizeRequest, *GetNodePlainSizeResponse) error
 	SetNodeInfo(context.Context, NodeKeyManager_SetNodeInfoStream) error
 	CopyNodeInfo(context.Context, *CopyNodeInfoRequest, *CopyNodeInfoResponse) error
+	SetNodeKey(context.Context, *SetNodeKeyRequest, *SetNodeKeyResponse) error
 	DeleteNode(context.Context, *DeleteNodeRequest, *DeleteNodeResponse) error
 	DeleteNodeKey(context.Context, *DeleteNodeKeyRequest, *DeleteNodeKeyResponse) error
 	DeleteNodeSharedKey(context.Context, *DeleteNodeSharedKeyRequest, *DeleteNodeSharedKeyResponse) error
@@ -419,6 +523,10 @@ func (h *NodeKeyManager) CopyNodeInfo(ctx context.Context, in *CopyNodeInfoReque
 	return h.NodeKeyManagerHandler.CopyNodeInfo(ctx, in, out)
 }
 
+func (h *NodeKeyManager) SetNodeKey(ctx context.Context, in *SetNodeKeyRequest, out *SetNodeKeyResponse) error {
+	return h.NodeKeyManagerHandler.SetNodeKey(ctx, in, out)
+}
+
 func (h *NodeKeyManager) DeleteNode(ctx context.Context, in *DeleteNodeRequest, out *DeleteNodeResponse) error {
 	return h.NodeKeyManagerHandler.DeleteNode(ctx, in, out)
 }
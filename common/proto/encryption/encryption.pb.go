@@ -24,8 +24,20 @@ It has these top-level messages:
 	AdminExportKeyResponse
 	AdminImportKeyRequest
 	AdminImportKeyResponse
+	AdminRotateKeyRequest
+	AdminRotateKeyResponse
 	AdminCreateKeyRequest
 	AdminCreateKeyResponse
+	UnlockUserKeyRequest
+	UnlockUserKeyResponse
+	LockUserKeyRequest
+	LockUserKeyResponse
+	ChangeUserKeyPasswordRequest
+	ChangeUserKeyPasswordResponse
+	RecoverUserKeyRequest
+	RecoverUserKeyResponse
+	RequireUserKeyRecoveryRequest
+	RequireUserKeyRecoveryResponse
 	NodeKey
 	Node
 	NodeInfo
@@ -122,6 +134,12 @@ func (m *Import) GetDate() int32 {
 type KeyInfo struct {
 	Exports []*Export `protobuf:"bytes,1,rep,name=Exports" json:"Exports,omitempty"`
 	Imports []*Import `protobuf:"bytes,2,rep,name=Imports" json:"Imports,omitempty"`
+	// Public part of a user keyring
+	PublicKey string `protobuf:"bytes,3,opt,name=PublicKey" json:"PublicKey,omitempty"`
+	// User keyring private key sealed with its recovery key
+	RecoveryContent string `protobuf:"bytes,4,opt,name=RecoveryContent" json:"RecoveryContent,omitempty"`
+	// Set when the user password was reset by an admin: the keyring must be recovered with its recovery key
+	RecoveryRequired bool `protobuf:"varint,5,opt,name=RecoveryRequired" json:"RecoveryRequired,omitempty"`
 }
 
 func (m *KeyInfo) Reset()                    { *m = KeyInfo{} }
@@ -143,6 +161,27 @@ func (m *KeyInfo) GetImports() []*Import {
 	return nil
 }
 
+func (m *KeyInfo) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *KeyInfo) GetRecoveryContent() string {
+	if m != nil {
+		return m.RecoveryContent
+	}
+	return ""
+}
+
+func (m *KeyInfo) GetRecoveryRequired() bool {
+	if m != nil {
+		return m.RecoveryRequired
+	}
+	return false
+}
+
 type Key struct {
 	// Key owner
 	Owner string `protobuf:"bytes,1,opt,name=Owner" json:"Owner,omitempty"`
@@ -251,6 +290,8 @@ type GetKeyRequest struct {
 	Owner       string `protobuf:"bytes,1,opt,name=Owner" json:"Owner,omitempty"`
 	KeyID       string `protobuf:"bytes,2,opt,name=KeyID" json:"KeyID,omitempty"`
 	StrPassword string `protobuf:"bytes,3,opt,name=StrPassword" json:"StrPassword,omitempty"`
+	// Only load the public part of a user keyring
+	PublicOnly bool `protobuf:"varint,4,opt,name=PublicOnly" json:"PublicOnly,omitempty"`
 }
 
 func (m *GetKeyRequest) Reset()                    { *m = GetKeyRequest{} }
@@ -279,6 +320,13 @@ func (m *GetKeyRequest) GetStrPassword() string {
 	return ""
 }
 
+func (m *GetKeyRequest) GetPublicOnly() bool {
+	if m != nil {
+		return m.PublicOnly
+	}
+	return false
+}
+
 type GetKeyResponse struct {
 	Key *Key `protobuf:"bytes,1,opt,name=Key" json:"Key,omitempty"`
 }
@@ -445,6 +493,67 @@ func (m *AdminImportKeyResponse) GetSuccess() bool {
 	return false
 }
 
+type AdminRotateKeyRequest struct {
+	// Name of the datasource whose node keys are re-wrapped
+	DataSource string `protobuf:"bytes,1,opt,name=DataSource" json:"DataSource,omitempty"`
+	// Id of the new master key. Can be empty when resuming a rotation in progress
+	NewKeyID string `protobuf:"bytes,2,opt,name=NewKeyID" json:"NewKeyID,omitempty"`
+	// Number of node keys processed per batch
+	BatchSize int32 `protobuf:"varint,3,opt,name=BatchSize" json:"BatchSize,omitempty"`
+	// Only verify that every node of the datasource can be decrypted
+	VerifyOnly bool `protobuf:"varint,4,opt,name=VerifyOnly" json:"VerifyOnly,omitempty"`
+}
+
+func (m *AdminRotateKeyRequest) Reset()                    { *m = AdminRotateKeyRequest{} }
+func (m *AdminRotateKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*AdminRotateKeyRequest) ProtoMessage()               {}
+func (*AdminRotateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *AdminRotateKeyRequest) GetDataSource() string {
+	if m != nil {
+		return m.DataSource
+	}
+	return ""
+}
+
+func (m *AdminRotateKeyRequest) GetNewKeyID() string {
+	if m != nil {
+		return m.NewKeyID
+	}
+	return ""
+}
+
+func (m *AdminRotateKeyRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *AdminRotateKeyRequest) GetVerifyOnly() bool {
+	if m != nil {
+		return m.VerifyOnly
+	}
+	return false
+}
+
+type AdminRotateKeyResponse struct {
+	// Id of the job performing the rotation
+	JobUuid string `protobuf:"bytes,1,opt,name=JobUuid" json:"JobUuid,omitempty"`
+}
+
+func (m *AdminRotateKeyResponse) Reset()                    { *m = AdminRotateKeyResponse{} }
+func (m *AdminRotateKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*AdminRotateKeyResponse) ProtoMessage()               {}
+func (*AdminRotateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *AdminRotateKeyResponse) GetJobUuid() string {
+	if m != nil {
+		return m.JobUuid
+	}
+	return ""
+}
+
 type AdminCreateKeyRequest struct {
 	// Create a key with this ID
 	KeyID string `protobuf:"bytes,1,opt,name=KeyID" json:"KeyID,omitempty"`
@@ -455,7 +564,7 @@ type AdminCreateKeyRequest struct {
 func (m *AdminCreateKeyRequest) Reset()                    { *m = AdminCreateKeyRequest{} }
 func (m *AdminCreateKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*AdminCreateKeyRequest) ProtoMessage()               {}
-func (*AdminCreateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*AdminCreateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *AdminCreateKeyRequest) GetKeyID() string {
 	if m != nil {
@@ -478,7 +587,7 @@ type AdminCreateKeyResponse struct {
 func (m *AdminCreateKeyResponse) Reset()                    { *m = AdminCreateKeyResponse{} }
 func (m *AdminCreateKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*AdminCreateKeyResponse) ProtoMessage()               {}
-func (*AdminCreateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*AdminCreateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AdminCreateKeyResponse) GetSuccess() bool {
 	if m != nil {
@@ -487,6 +596,255 @@ func (m *AdminCreateKeyResponse) GetSuccess() bool {
 	return false
 }
 
+type UnlockUserKeyRequest struct {
+	// Login of the keyring owner
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	// Passphrase protecting the keyring
+	StrPassword string `protobuf:"bytes,2,opt,name=StrPassword" json:"StrPassword,omitempty"`
+	// Time during which the keyring stays unlocked, in seconds
+	TTL int32 `protobuf:"varint,3,opt,name=TTL" json:"TTL,omitempty"`
+}
+
+func (m *UnlockUserKeyRequest) Reset()                    { *m = UnlockUserKeyRequest{} }
+func (m *UnlockUserKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnlockUserKeyRequest) ProtoMessage()               {}
+func (*UnlockUserKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *UnlockUserKeyRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *UnlockUserKeyRequest) GetStrPassword() string {
+	if m != nil {
+		return m.StrPassword
+	}
+	return ""
+}
+
+func (m *UnlockUserKeyRequest) GetTTL() int32 {
+	if m != nil {
+		return m.TTL
+	}
+	return 0
+}
+
+type UnlockUserKeyResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+	// Whether the keyring was created by this call
+	Created bool `protobuf:"varint,2,opt,name=Created" json:"Created,omitempty"`
+	// Recovery key of a newly created keyring, returned only once
+	RecoveryKey string `protobuf:"bytes,3,opt,name=RecoveryKey" json:"RecoveryKey,omitempty"`
+	// Whether the keyring must be recovered with its recovery key before it can be unlocked
+	RecoveryRequired bool `protobuf:"varint,4,opt,name=RecoveryRequired" json:"RecoveryRequired,omitempty"`
+}
+
+func (m *UnlockUserKeyResponse) Reset()                    { *m = UnlockUserKeyResponse{} }
+func (m *UnlockUserKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*UnlockUserKeyResponse) ProtoMessage()               {}
+func (*UnlockUserKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *UnlockUserKeyResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *UnlockUserKeyResponse) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *UnlockUserKeyResponse) GetRecoveryKey() string {
+	if m != nil {
+		return m.RecoveryKey
+	}
+	return ""
+}
+
+func (m *UnlockUserKeyResponse) GetRecoveryRequired() bool {
+	if m != nil {
+		return m.RecoveryRequired
+	}
+	return false
+}
+
+type LockUserKeyRequest struct {
+	// Login of the keyring owner
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+}
+
+func (m *LockUserKeyRequest) Reset()                    { *m = LockUserKeyRequest{} }
+func (m *LockUserKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*LockUserKeyRequest) ProtoMessage()               {}
+func (*LockUserKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *LockUserKeyRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+type LockUserKeyResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *LockUserKeyResponse) Reset()                    { *m = LockUserKeyResponse{} }
+func (m *LockUserKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*LockUserKeyResponse) ProtoMessage()               {}
+func (*LockUserKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *LockUserKeyResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type ChangeUserKeyPasswordRequest struct {
+	// Login of the keyring owner
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	// Current passphrase
+	OldPassword string `protobuf:"bytes,2,opt,name=OldPassword" json:"OldPassword,omitempty"`
+	// New passphrase
+	NewPassword string `protobuf:"bytes,3,opt,name=NewPassword" json:"NewPassword,omitempty"`
+}
+
+func (m *ChangeUserKeyPasswordRequest) Reset()                    { *m = ChangeUserKeyPasswordRequest{} }
+func (m *ChangeUserKeyPasswordRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChangeUserKeyPasswordRequest) ProtoMessage()               {}
+func (*ChangeUserKeyPasswordRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ChangeUserKeyPasswordRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *ChangeUserKeyPasswordRequest) GetOldPassword() string {
+	if m != nil {
+		return m.OldPassword
+	}
+	return ""
+}
+
+func (m *ChangeUserKeyPasswordRequest) GetNewPassword() string {
+	if m != nil {
+		return m.NewPassword
+	}
+	return ""
+}
+
+type ChangeUserKeyPasswordResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *ChangeUserKeyPasswordResponse) Reset()                    { *m = ChangeUserKeyPasswordResponse{} }
+func (m *ChangeUserKeyPasswordResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChangeUserKeyPasswordResponse) ProtoMessage()               {}
+func (*ChangeUserKeyPasswordResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ChangeUserKeyPasswordResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type RecoverUserKeyRequest struct {
+	// Login of the keyring owner
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	// Recovery key delivered when the keyring was created
+	RecoveryKey string `protobuf:"bytes,2,opt,name=RecoveryKey" json:"RecoveryKey,omitempty"`
+	// New passphrase
+	NewPassword string `protobuf:"bytes,3,opt,name=NewPassword" json:"NewPassword,omitempty"`
+}
+
+func (m *RecoverUserKeyRequest) Reset()                    { *m = RecoverUserKeyRequest{} }
+func (m *RecoverUserKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*RecoverUserKeyRequest) ProtoMessage()               {}
+func (*RecoverUserKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RecoverUserKeyRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *RecoverUserKeyRequest) GetRecoveryKey() string {
+	if m != nil {
+		return m.RecoveryKey
+	}
+	return ""
+}
+
+func (m *RecoverUserKeyRequest) GetNewPassword() string {
+	if m != nil {
+		return m.NewPassword
+	}
+	return ""
+}
+
+type RecoverUserKeyResponse struct {
+	// New recovery key, the previous one cannot be used anymore
+	RecoveryKey string `protobuf:"bytes,1,opt,name=RecoveryKey" json:"RecoveryKey,omitempty"`
+}
+
+func (m *RecoverUserKeyResponse) Reset()                    { *m = RecoverUserKeyResponse{} }
+func (m *RecoverUserKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*RecoverUserKeyResponse) ProtoMessage()               {}
+func (*RecoverUserKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *RecoverUserKeyResponse) GetRecoveryKey() string {
+	if m != nil {
+		return m.RecoveryKey
+	}
+	return ""
+}
+
+type RequireUserKeyRecoveryRequest struct {
+	// Login of the keyring owner
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+}
+
+func (m *RequireUserKeyRecoveryRequest) Reset()                    { *m = RequireUserKeyRecoveryRequest{} }
+func (m *RequireUserKeyRecoveryRequest) String() string            { return proto.CompactTextString(m) }
+func (*RequireUserKeyRecoveryRequest) ProtoMessage()               {}
+func (*RequireUserKeyRecoveryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *RequireUserKeyRecoveryRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+type RequireUserKeyRecoveryResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *RequireUserKeyRecoveryResponse) Reset()         { *m = RequireUserKeyRecoveryResponse{} }
+func (m *RequireUserKeyRecoveryResponse) String() string { return proto.CompactTextString(m) }
+func (*RequireUserKeyRecoveryResponse) ProtoMessage()    {}
+func (*RequireUserKeyRecoveryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{29}
+}
+
+func (m *RequireUserKeyRecoveryResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
 type NodeKey struct {
 	NodeId  string `protobuf:"bytes,1,opt,name=NodeId" json:"NodeId,omitempty"`
 	UserId  string `protobuf:"bytes,2,opt,name=UserId" json:"UserId,omitempty"`
@@ -497,7 +855,7 @@ type NodeKey struct {
 func (m *NodeKey) Reset()                    { *m = NodeKey{} }
 func (m *NodeKey) String() string            { return proto.CompactTextString(m) }
 func (*NodeKey) ProtoMessage()               {}
-func (*NodeKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*NodeKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *NodeKey) GetNodeId() string {
 	if m != nil {
@@ -535,7 +893,7 @@ type Node struct {
 func (m *Node) Reset()                    { *m = Node{} }
 func (m *Node) String() string            { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()               {}
-func (*Node) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Node) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *Node) GetNodeId() string {
 	if m != nil {
@@ -560,7 +918,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *NodeInfo) GetNode() *Node {
 	if m != nil {
@@ -595,7 +953,7 @@ type Block struct {
 func (m *Block) Reset()                    { *m = Block{} }
 func (m *Block) String() string            { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()               {}
-func (*Block) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Block) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *Block) GetOwnerId() string {
 	if m != nil {
@@ -651,7 +1009,7 @@ type RangedBlock struct {
 func (m *RangedBlock) Reset()                    { *m = RangedBlock{} }
 func (m *RangedBlock) String() string            { return proto.CompactTextString(m) }
 func (*RangedBlock) ProtoMessage()               {}
-func (*RangedBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*RangedBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *RangedBlock) GetOwnerId() string {
 	if m != nil {
@@ -706,7 +1064,7 @@ type GetNodeInfoRequest struct {
 func (m *GetNodeInfoRequest) Reset()                    { *m = GetNodeInfoRequest{} }
 func (m *GetNodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeInfoRequest) ProtoMessage()               {}
-func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *GetNodeInfoRequest) GetUserId() string {
 	if m != nil {
@@ -754,7 +1112,7 @@ type GetNodeInfoResponse struct {
 func (m *GetNodeInfoResponse) Reset()                    { *m = GetNodeInfoResponse{} }
 func (m *GetNodeInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeInfoResponse) ProtoMessage()               {}
-func (*GetNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*GetNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *GetNodeInfoResponse) GetNodeInfo() *NodeInfo {
 	if m != nil {
@@ -799,7 +1157,7 @@ type GetNodePlainSizeRequest struct {
 func (m *GetNodePlainSizeRequest) Reset()                    { *m = GetNodePlainSizeRequest{} }
 func (m *GetNodePlainSizeRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetNodePlainSizeRequest) ProtoMessage()               {}
-func (*GetNodePlainSizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*GetNodePlainSizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *GetNodePlainSizeRequest) GetUserId() string {
 	if m != nil {
@@ -822,7 +1180,7 @@ type GetNodePlainSizeResponse struct {
 func (m *GetNodePlainSizeResponse) Reset()                    { *m = GetNodePlainSizeResponse{} }
 func (m *GetNodePlainSizeResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetNodePlainSizeResponse) ProtoMessage()               {}
-func (*GetNodePlainSizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*GetNodePlainSizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *GetNodePlainSizeResponse) GetSize() int64 {
 	if m != nil {
@@ -840,7 +1198,7 @@ type SetNodeInfoRequest struct {
 func (m *SetNodeInfoRequest) Reset()                    { *m = SetNodeInfoRequest{} }
 func (m *SetNodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeInfoRequest) ProtoMessage()               {}
-func (*SetNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*SetNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *SetNodeInfoRequest) GetAction() string {
 	if m != nil {
@@ -872,7 +1230,7 @@ type SetNodeInfoResponse struct {
 func (m *SetNodeInfoResponse) Reset()                    { *m = SetNodeInfoResponse{} }
 func (m *SetNodeInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeInfoResponse) ProtoMessage()               {}
-func (*SetNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*SetNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *SetNodeInfoResponse) GetErrorText() string {
 	if m != nil {
@@ -902,7 +1260,7 @@ type DeleteNodeRequest struct {
 func (m *DeleteNodeRequest) Reset()                    { *m = DeleteNodeRequest{} }
 func (m *DeleteNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeRequest) ProtoMessage()               {}
-func (*DeleteNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*DeleteNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *DeleteNodeRequest) GetNodeId() string {
 	if m != nil {
@@ -917,7 +1275,7 @@ type DeleteNodeResponse struct {
 func (m *DeleteNodeResponse) Reset()                    { *m = DeleteNodeResponse{} }
 func (m *DeleteNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeResponse) ProtoMessage()               {}
-func (*DeleteNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*DeleteNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 type DeleteNodeKeyRequest struct {
 	UserId string `protobuf:"bytes,1,opt,name=UserId" json:"UserId,omitempty"`
@@ -927,7 +1285,7 @@ type DeleteNodeKeyRequest struct {
 func (m *DeleteNodeKeyRequest) Reset()                    { *m = DeleteNodeKeyRequest{} }
 func (m *DeleteNodeKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeKeyRequest) ProtoMessage()               {}
-func (*DeleteNodeKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*DeleteNodeKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *DeleteNodeKeyRequest) GetUserId() string {
 	if m != nil {
@@ -949,7 +1307,7 @@ type DeleteNodeKeyResponse struct {
 func (m *DeleteNodeKeyResponse) Reset()                    { *m = DeleteNodeKeyResponse{} }
 func (m *DeleteNodeKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeKeyResponse) ProtoMessage()               {}
-func (*DeleteNodeKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*DeleteNodeKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 type DeleteNodeSharedKeyRequest struct {
 	UserId  string `protobuf:"bytes,1,opt,name=UserId" json:"UserId,omitempty"`
@@ -960,7 +1318,7 @@ type DeleteNodeSharedKeyRequest struct {
 func (m *DeleteNodeSharedKeyRequest) Reset()                    { *m = DeleteNodeSharedKeyRequest{} }
 func (m *DeleteNodeSharedKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeSharedKeyRequest) ProtoMessage()               {}
-func (*DeleteNodeSharedKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*DeleteNodeSharedKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *DeleteNodeSharedKeyRequest) GetUserId() string {
 	if m != nil {
@@ -989,7 +1347,7 @@ type DeleteNodeSharedKeyResponse struct {
 func (m *DeleteNodeSharedKeyResponse) Reset()                    { *m = DeleteNodeSharedKeyResponse{} }
 func (m *DeleteNodeSharedKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeSharedKeyResponse) ProtoMessage()               {}
-func (*DeleteNodeSharedKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*DeleteNodeSharedKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 type SetNodeKeyRequest struct {
 	NodeKey *NodeKey `protobuf:"bytes,1,opt,name=NodeKey" json:"NodeKey,omitempty"`
@@ -998,7 +1356,7 @@ type SetNodeKeyRequest struct {
 func (m *SetNodeKeyRequest) Reset()                    { *m = SetNodeKeyRequest{} }
 func (m *SetNodeKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeKeyRequest) ProtoMessage()               {}
-func (*SetNodeKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*SetNodeKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *SetNodeKeyRequest) GetNodeKey() *NodeKey {
 	if m != nil {
@@ -1013,7 +1371,7 @@ type SetNodeKeyResponse struct {
 func (m *SetNodeKeyResponse) Reset()                    { *m = SetNodeKeyResponse{} }
 func (m *SetNodeKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeKeyResponse) ProtoMessage()               {}
-func (*SetNodeKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*SetNodeKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 type SetNodeBlockRequest struct {
 	NodeUuid string `protobuf:"bytes,1,opt,name=NodeUuid" json:"NodeUuid,omitempty"`
@@ -1023,7 +1381,7 @@ type SetNodeBlockRequest struct {
 func (m *SetNodeBlockRequest) Reset()                    { *m = SetNodeBlockRequest{} }
 func (m *SetNodeBlockRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeBlockRequest) ProtoMessage()               {}
-func (*SetNodeBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*SetNodeBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *SetNodeBlockRequest) GetNodeUuid() string {
 	if m != nil {
@@ -1045,7 +1403,7 @@ type SetNodeBlockResponse struct {
 func (m *SetNodeBlockResponse) Reset()                    { *m = SetNodeBlockResponse{} }
 func (m *SetNodeBlockResponse) String() string            { return proto.CompactTextString(m) }
 func (*SetNodeBlockResponse) ProtoMessage()               {}
-func (*SetNodeBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*SetNodeBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 type CopyNodeInfoRequest struct {
 	NodeUuid     string `protobuf:"bytes,1,opt,name=NodeUuid" json:"NodeUuid,omitempty"`
@@ -1055,7 +1413,7 @@ type CopyNodeInfoRequest struct {
 func (m *CopyNodeInfoRequest) Reset()                    { *m = CopyNodeInfoRequest{} }
 func (m *CopyNodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*CopyNodeInfoRequest) ProtoMessage()               {}
-func (*CopyNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*CopyNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *CopyNodeInfoRequest) GetNodeUuid() string {
 	if m != nil {
@@ -1077,7 +1435,7 @@ type CopyNodeInfoResponse struct {
 func (m *CopyNodeInfoResponse) Reset()                    { *m = CopyNodeInfoResponse{} }
 func (m *CopyNodeInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*CopyNodeInfoResponse) ProtoMessage()               {}
-func (*CopyNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*CopyNodeInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func init() {
 	proto.RegisterType((*Export)(nil), "encryption.Export")
@@ -1096,8 +1454,20 @@ func init() {
 	proto.RegisterType((*AdminExportKeyResponse)(nil), "encryption.AdminExportKeyResponse")
 	proto.RegisterType((*AdminImportKeyRequest)(nil), "encryption.AdminImportKeyRequest")
 	proto.RegisterType((*AdminImportKeyResponse)(nil), "encryption.AdminImportKeyResponse")
+	proto.RegisterType((*AdminRotateKeyRequest)(nil), "encryption.AdminRotateKeyRequest")
+	proto.RegisterType((*AdminRotateKeyResponse)(nil), "encryption.AdminRotateKeyResponse")
 	proto.RegisterType((*AdminCreateKeyRequest)(nil), "encryption.AdminCreateKeyRequest")
 	proto.RegisterType((*AdminCreateKeyResponse)(nil), "encryption.AdminCreateKeyResponse")
+	proto.RegisterType((*UnlockUserKeyRequest)(nil), "encryption.UnlockUserKeyRequest")
+	proto.RegisterType((*UnlockUserKeyResponse)(nil), "encryption.UnlockUserKeyResponse")
+	proto.RegisterType((*LockUserKeyRequest)(nil), "encryption.LockUserKeyRequest")
+	proto.RegisterType((*LockUserKeyResponse)(nil), "encryption.LockUserKeyResponse")
+	proto.RegisterType((*ChangeUserKeyPasswordRequest)(nil), "encryption.ChangeUserKeyPasswordRequest")
+	proto.RegisterType((*ChangeUserKeyPasswordResponse)(nil), "encryption.ChangeUserKeyPasswordResponse")
+	proto.RegisterType((*RecoverUserKeyRequest)(nil), "encryption.RecoverUserKeyRequest")
+	proto.RegisterType((*RecoverUserKeyResponse)(nil), "encryption.RecoverUserKeyResponse")
+	proto.RegisterType((*RequireUserKeyRecoveryRequest)(nil), "encryption.RequireUserKeyRecoveryRequest")
+	proto.RegisterType((*RequireUserKeyRecoveryResponse)(nil), "encryption.RequireUserKeyRecoveryResponse")
 	proto.RegisterType((*NodeKey)(nil), "encryption.NodeKey")
 	proto.RegisterType((*Node)(nil), "encryption.Node")
 	proto.RegisterType((*NodeInfo)(nil), "encryption.NodeInfo")
@@ -1126,90 +1496,113 @@ func init() {
 func init() { proto.RegisterFile("encryption.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1349 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x6e, 0xdb, 0x46,
-	0x13, 0x36, 0x75, 0xb2, 0x35, 0xb2, 0x1c, 0x7b, 0xad, 0x38, 0xfa, 0xf9, 0x3b, 0x8e, 0xb2, 0x09,
-	0x62, 0xa1, 0x4d, 0x8c, 0x42, 0x01, 0x7a, 0x93, 0x34, 0x80, 0x4f, 0x4d, 0x05, 0xb9, 0xb6, 0xbb,
-	0xb4, 0x5b, 0xb4, 0x40, 0x2e, 0x18, 0x71, 0x6d, 0x0b, 0xb5, 0x49, 0x99, 0xa4, 0x1b, 0xab, 0x4f,
-	0xd0, 0x27, 0x29, 0xda, 0xcb, 0xbe, 0x4d, 0xdf, 0xa2, 0x97, 0xbd, 0x2d, 0xf6, 0x40, 0x72, 0x49,
-	0xae, 0x44, 0xf7, 0x70, 0xc7, 0x99, 0x9d, 0xfd, 0xe6, 0x9b, 0xd9, 0x99, 0xdd, 0x91, 0x60, 0x99,
-	0xba, 0x43, 0x7f, 0x32, 0x0e, 0x47, 0x9e, 0xbb, 0x35, 0xf6, 0xbd, 0xd0, 0x43, 0x90, 0x68, 0xf0,
-	0x73, 0xa8, 0xed, 0xdf, 0x8e, 0x3d, 0x3f, 0x44, 0x4b, 0x50, 0xda, 0x99, 0xb4, 0x8d, 0x8e, 0xd1,
-	0xad, 0x93, 0xd2, 0xce, 0x04, 0x21, 0xa8, 0xec, 0xd9, 0x21, 0x6d, 0x97, 0x3a, 0x46, 0xb7, 0x4a,
-	0xf8, 0x37, 0xb3, 0xee, 0x5f, 0xcd, 0xb4, 0x2e, 0x2b, 0xd6, 0x14, 0xe6, 0x07, 0x74, 0xd2, 0x77,
-	0xcf, 0x3c, 0xf4, 0x1c, 0xe6, 0x85, 0x9b, 0xa0, 0x6d, 0x74, 0xca, 0xdd, 0x46, 0x0f, 0x6d, 0x29,
-	0xb4, 0xc4, 0x12, 0x89, 0x4c, 0x98, 0xb5, 0x70, 0x13, 0xb4, 0x4b, 0x79, 0x6b, 0xb1, 0x44, 0x22,
-	0x13, 0xfc, 0x8b, 0x01, 0xe5, 0x01, 0x9d, 0xa0, 0x16, 0x54, 0x8f, 0x3e, 0xb8, 0xd4, 0x97, 0xac,
-	0x84, 0xc0, 0x88, 0xf6, 0xf7, 0x78, 0x10, 0x75, 0x52, 0xea, 0xef, 0x31, 0xab, 0x03, 0xfb, 0x3d,
-	0xbd, 0xe4, 0x4c, 0xeb, 0x44, 0x08, 0xa8, 0x0d, 0xf3, 0xbb, 0x9e, 0x1b, 0x52, 0x37, 0x6c, 0x57,
-	0xb8, 0x3e, 0x12, 0x11, 0x86, 0xc5, 0x5d, 0x9f, 0xda, 0xcc, 0x33, 0x0f, 0xb0, 0xca, 0x03, 0x4c,
-	0xe9, 0xd0, 0x26, 0x54, 0x58, 0x94, 0xed, 0x5a, 0xc7, 0xe8, 0x36, 0x7a, 0xab, 0x2a, 0x59, 0x99,
-	0x00, 0xc2, 0x0d, 0xf0, 0x09, 0x34, 0xb7, 0x1d, 0x67, 0x40, 0x27, 0x84, 0x5e, 0xdf, 0xd0, 0x20,
-	0x44, 0x8f, 0x39, 0x75, 0xce, 0xb8, 0xd1, 0xbb, 0x97, 0xd9, 0x48, 0x78, 0x58, 0x1d, 0x68, 0x58,
-	0xa1, 0x7f, 0x6c, 0x07, 0xc1, 0x07, 0xcf, 0x77, 0x64, 0x24, 0xaa, 0x0a, 0x7f, 0x04, 0x4b, 0x11,
-	0x6a, 0x30, 0xf6, 0xdc, 0x80, 0xb2, 0x70, 0xac, 0x9b, 0xe1, 0x90, 0x06, 0x01, 0x87, 0x5e, 0x20,
-	0x91, 0x88, 0xdf, 0x41, 0xf3, 0x2d, 0x0d, 0x15, 0x06, 0xfa, 0xac, 0xb5, 0xa0, 0xca, 0x98, 0x47,
-	0x89, 0x13, 0x42, 0x96, 0x4a, 0x39, 0x4f, 0xe5, 0x25, 0x2c, 0x45, 0xf0, 0x92, 0x4a, 0x71, 0x84,
-	0x78, 0x0d, 0x5a, 0xdb, 0xce, 0xd5, 0xc8, 0x3d, 0x18, 0x05, 0x6c, 0x6b, 0x20, 0xa9, 0xe1, 0xd7,
-	0x70, 0x3f, 0xa3, 0x97, 0x98, 0x4f, 0xa0, 0xc2, 0x64, 0x59, 0x4a, 0x39, 0x50, 0xbe, 0x88, 0x5f,
-	0xc8, 0xdd, 0x7b, 0xf4, 0x92, 0x86, 0x34, 0x1d, 0xb1, 0x88, 0xcd, 0x50, 0x62, 0xc3, 0x3d, 0x58,
-	0xcb, 0x9a, 0x17, 0x26, 0xf3, 0x48, 0xba, 0x10, 0x75, 0x5b, 0xe4, 0xe2, 0x0e, 0x27, 0xf9, 0x4a,
-	0x92, 0x50, 0x00, 0xef, 0x9e, 0xc6, 0x5b, 0xc9, 0x46, 0xf4, 0xc5, 0x7f, 0x5c, 0x64, 0xc8, 0x84,
-	0x85, 0xa3, 0x1f, 0xa8, 0xef, 0x8f, 0x1c, 0xd1, 0xe4, 0x0b, 0x24, 0x96, 0xe3, 0xdc, 0x29, 0x9e,
-	0x0b, 0x73, 0xb7, 0x2b, 0xd9, 0xf2, 0x46, 0x2a, 0x3c, 0x9e, 0xa4, 0x6d, 0x4b, 0x4a, 0xdb, 0xc6,
-	0x8e, 0x15, 0x90, 0x42, 0xc7, 0x57, 0x30, 0x7f, 0xe8, 0x39, 0xcc, 0x18, 0xad, 0x41, 0x8d, 0x7d,
-	0xf6, 0x1d, 0xe9, 0x4b, 0x4a, 0x4c, 0x7f, 0x1a, 0x50, 0xbf, 0x1f, 0x25, 0x42, 0x4a, 0x0c, 0x94,
-	0xb7, 0x47, 0x3f, 0xaa, 0xfd, 0x48, 0x64, 0x2b, 0x03, 0x3a, 0xd9, 0xb3, 0x43, 0x9b, 0x5f, 0x02,
-	0x8b, 0x24, 0x12, 0xf1, 0xa7, 0x50, 0x61, 0xa8, 0xb3, 0x7c, 0x1d, 0xd0, 0x73, 0x7b, 0x38, 0xe1,
-	0xbe, 0x16, 0x88, 0x94, 0xf0, 0x4f, 0x06, 0x2c, 0x70, 0x13, 0x76, 0x7d, 0x3e, 0x15, 0x20, 0xf2,
-	0x08, 0x97, 0xd5, 0x23, 0x64, 0x7a, 0x22, 0x5c, 0xbc, 0x88, 0x23, 0xe3, 0x58, 0x99, 0x9b, 0x48,
-	0x2e, 0x91, 0x38, 0xfa, 0x4d, 0xa8, 0xee, 0x5c, 0x7a, 0xc3, 0xef, 0x79, 0x2c, 0x8d, 0xde, 0x8a,
-	0x6a, 0xcc, 0x17, 0x88, 0x58, 0xc7, 0xbf, 0x1a, 0xd2, 0x52, 0x4d, 0x80, 0x91, 0x4e, 0xc0, 0x1a,
-	0xd4, 0x8e, 0x6d, 0x3f, 0x94, 0x29, 0x6b, 0x12, 0x29, 0xb1, 0xb2, 0x39, 0xf6, 0x82, 0x11, 0x03,
-	0xe5, 0x7e, 0x9a, 0x24, 0x96, 0xd1, 0x06, 0xc0, 0x17, 0xd4, 0x76, 0xa8, 0x6f, 0x8d, 0x7e, 0xa4,
-	0xfc, 0xde, 0x6d, 0x12, 0x45, 0x83, 0xd6, 0xa1, 0xce, 0xdd, 0xf2, 0xe5, 0x2a, 0x5f, 0x4e, 0x14,
-	0xac, 0x22, 0x0e, 0x3d, 0x77, 0x48, 0x65, 0xc2, 0x85, 0x80, 0x7f, 0x33, 0xa0, 0x41, 0x6c, 0xf7,
-	0x9c, 0x3a, 0xff, 0x82, 0xb1, 0x45, 0xaf, 0xad, 0xd0, 0xf6, 0xc3, 0x88, 0x71, 0x24, 0xb3, 0x3d,
-	0x16, 0xbd, 0xde, 0x77, 0x1d, 0xc9, 0x56, 0x4a, 0x99, 0x48, 0xaa, 0xb3, 0x23, 0xa9, 0x65, 0x22,
-	0xc1, 0x3f, 0x1b, 0x80, 0xde, 0xd2, 0x30, 0x3a, 0xed, 0xa8, 0x11, 0x92, 0x2a, 0x34, 0x52, 0x55,
-	0x98, 0x54, 0x52, 0x29, 0x55, 0x49, 0xeb, 0x50, 0xff, 0x66, 0x14, 0x5e, 0xf0, 0xe8, 0x65, 0x8b,
-	0x26, 0x0a, 0xd6, 0xe1, 0xc7, 0x97, 0xf6, 0xc8, 0x3d, 0x3a, 0x3b, 0x0b, 0xa8, 0x78, 0xe5, 0xca,
-	0x44, 0x55, 0xc5, 0x16, 0x07, 0xd4, 0x3d, 0x0f, 0x2f, 0x78, 0x14, 0x91, 0x85, 0x50, 0xe1, 0x3f,
-	0x0d, 0x58, 0x4d, 0x11, 0x95, 0xcd, 0xf6, 0x49, 0x52, 0xaa, 0xb2, 0x44, 0x5b, 0xd9, 0xca, 0xe3,
-	0xf6, 0x49, 0x41, 0xbf, 0x01, 0x93, 0xa5, 0xc7, 0x1a, 0x8c, 0xc6, 0x63, 0xea, 0x70, 0x1f, 0x3b,
-	0x93, 0x90, 0x06, 0xbb, 0xde, 0x8d, 0x1b, 0xf2, 0xb8, 0xca, 0x64, 0x86, 0x45, 0x41, 0xac, 0x5d,
-	0xb8, 0xb7, 0x2f, 0xdc, 0x53, 0x27, 0x15, 0x6f, 0x56, 0x8d, 0x9e, 0xc1, 0x52, 0xac, 0x12, 0xbe,
-	0x45, 0xd8, 0x19, 0x2d, 0xee, 0xc3, 0x03, 0x19, 0x38, 0x67, 0xc2, 0x8e, 0xed, 0x1f, 0x1e, 0x13,
-	0xde, 0x82, 0x76, 0x1e, 0x4a, 0x26, 0x12, 0x41, 0x85, 0x97, 0x88, 0xc1, 0x49, 0xf0, 0x6f, 0x36,
-	0xde, 0x20, 0x4b, 0x5b, 0x1d, 0xdb, 0x43, 0xde, 0x56, 0xd2, 0xad, 0x90, 0xd0, 0x67, 0x00, 0xd2,
-	0x3a, 0xb9, 0x07, 0x1e, 0xaa, 0xa7, 0x91, 0xac, 0x4a, 0x28, 0xa2, 0x6c, 0x40, 0xaf, 0x58, 0xf5,
-	0x87, 0xea, 0xbd, 0xf0, 0x48, 0xb3, 0x59, 0x5c, 0x0f, 0x72, 0x7b, 0xbc, 0x81, 0x35, 0xdf, 0xaa,
-	0xa5, 0xa9, 0x8f, 0x75, 0xa8, 0xef, 0xfb, 0xbe, 0xe7, 0x9f, 0xd0, 0xdb, 0x50, 0xd2, 0x4d, 0x14,
-	0xe8, 0x8d, 0x86, 0xf1, 0xc6, 0x34, 0xc6, 0x02, 0x31, 0x45, 0xf9, 0x75, 0x8e, 0x72, 0x67, 0x3a,
-	0x65, 0xb9, 0x3f, 0xe1, 0xfc, 0x31, 0xac, 0x88, 0x27, 0x9f, 0x5f, 0xa4, 0x49, 0x72, 0x75, 0x97,
-	0x35, 0x6e, 0x01, 0x52, 0x8d, 0x05, 0x18, 0xfe, 0x1c, 0x5a, 0x89, 0x56, 0x79, 0xc9, 0xfe, 0x6e,
-	0x65, 0x3c, 0x80, 0xfb, 0x19, 0x1c, 0xe9, 0xe0, 0x0c, 0xcc, 0x64, 0xc1, 0xba, 0xb0, 0x7d, 0xea,
-	0xdc, 0xc1, 0x8d, 0x72, 0xf5, 0x95, 0x72, 0x57, 0x9f, 0x24, 0x50, 0x4e, 0x11, 0x78, 0x08, 0xff,
-	0xd7, 0xfa, 0x91, 0x34, 0x76, 0x60, 0x25, 0x57, 0x3c, 0xea, 0xa3, 0x63, 0x14, 0x3f, 0x3a, 0x2c,
-	0x83, 0xf9, 0xe3, 0xc4, 0xdf, 0xc5, 0x75, 0xa3, 0x56, 0x16, 0xbb, 0x8a, 0x99, 0xee, 0xf4, 0x66,
-	0x14, 0xc5, 0x16, 0xcb, 0xc9, 0xeb, 0x55, 0x2a, 0x78, 0xbd, 0xd6, 0xa0, 0xa5, 0x2b, 0x01, 0x7c,
-	0x0a, 0xab, 0xbb, 0xde, 0x78, 0x92, 0xed, 0xab, 0x59, 0x3e, 0x31, 0x2c, 0xb2, 0x6f, 0xb6, 0x8d,
-	0xaf, 0x8b, 0xb4, 0xa6, 0x74, 0xcc, 0x5d, 0x1a, 0x56, 0xb8, 0xeb, 0xfd, 0x5e, 0x81, 0x45, 0x76,
-	0x30, 0x03, 0x3a, 0xb1, 0x42, 0xcf, 0xa7, 0x68, 0x1b, 0x6a, 0x62, 0x6a, 0x47, 0xff, 0x53, 0xb9,
-	0xa7, 0x7e, 0x1f, 0x98, 0xa6, 0x6e, 0x49, 0x06, 0x30, 0xc7, 0x20, 0xc4, 0xb4, 0x9d, 0x86, 0x48,
-	0x0d, 0xf8, 0x69, 0x88, 0xf4, 0x70, 0x8e, 0xe7, 0xd0, 0xd7, 0xec, 0x17, 0x89, 0x32, 0x63, 0xa3,
-	0x4e, 0xda, 0x63, 0x7e, 0x2c, 0x37, 0x1f, 0xcf, 0xb0, 0x88, 0x71, 0xbf, 0x65, 0xbf, 0x49, 0xd4,
-	0xc9, 0x0c, 0xe5, 0xb7, 0x65, 0x47, 0x3f, 0x13, 0xcf, 0x32, 0xc9, 0x41, 0xc7, 0x93, 0xba, 0x06,
-	0x3a, 0x3b, 0xf4, 0x6b, 0xa0, 0x73, 0x83, 0xbe, 0x02, 0x1d, 0xcf, 0xdf, 0x1a, 0xe8, 0xec, 0xb0,
-	0xaf, 0x81, 0xce, 0x8d, 0xef, 0x0a, 0x74, 0x3c, 0x23, 0x6b, 0xa0, 0xb3, 0x93, 0xbb, 0x06, 0x3a,
-	0x37, 0x62, 0xe3, 0xb9, 0xde, 0x1f, 0x15, 0x58, 0x92, 0x1d, 0xf5, 0xa5, 0xed, 0xda, 0xe7, 0xd4,
-	0x47, 0x87, 0xd0, 0x50, 0x1e, 0x6a, 0xb4, 0x91, 0xa9, 0x81, 0x4c, 0xd1, 0x9b, 0x8f, 0xa6, 0xae,
-	0xcb, 0x1b, 0xfc, 0x1d, 0x2c, 0x67, 0x1f, 0x2d, 0xf4, 0x44, 0xb3, 0x29, 0xfb, 0x3a, 0x9a, 0x4f,
-	0x67, 0x1b, 0x49, 0xf8, 0x63, 0x68, 0x58, 0xd3, 0xe8, 0x5a, 0x05, 0x74, 0x35, 0x0f, 0x4e, 0xd7,
-	0x40, 0x5f, 0xc1, 0xa2, 0xda, 0x86, 0x28, 0xb5, 0x45, 0xd3, 0xf7, 0x66, 0x67, 0xba, 0x81, 0x24,
-	0x39, 0x00, 0x48, 0x6e, 0x47, 0x94, 0x7a, 0x53, 0x73, 0x2f, 0x88, 0xb9, 0x31, 0x6d, 0x59, 0x82,
-	0x9d, 0x40, 0x33, 0x75, 0xd7, 0xa7, 0xfb, 0x4e, 0xf7, 0x9c, 0xa4, 0xfb, 0x4e, 0xfb, 0x50, 0xa0,
-	0x33, 0x58, 0xd5, 0x5c, 0xe0, 0xe8, 0x99, 0x7e, 0x67, 0xf6, 0x25, 0x31, 0x37, 0x0b, 0xed, 0x84,
-	0x9f, 0xf7, 0x35, 0xfe, 0x47, 0xd2, 0xcb, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x22, 0x60, 0x66,
-	0x48, 0x5c, 0x12, 0x00, 0x00,
+	// 1726 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcb, 0x6f, 0xdb, 0x46,
+	0x13, 0xff, 0xa8, 0x87, 0x2d, 0x8f, 0x6c, 0xc7, 0x59, 0xcb, 0x8a, 0x3e, 0x7e, 0xb6, 0x23, 0x6f,
+	0x82, 0x44, 0xc9, 0x97, 0xa4, 0x85, 0x02, 0x14, 0x68, 0x5e, 0x80, 0x5f, 0x4d, 0x55, 0xab, 0xb6,
+	0x4b, 0xd9, 0x29, 0x5a, 0xa0, 0x07, 0x5a, 0x5c, 0xdb, 0x44, 0x6d, 0xd2, 0x26, 0xe9, 0x38, 0x2a,
+	0x50, 0xa0, 0xc7, 0x5e, 0x7b, 0xec, 0x3f, 0x50, 0xb4, 0xc7, 0xde, 0x7b, 0xe8, 0xbf, 0xd1, 0xbf,
+	0xa4, 0xd7, 0x62, 0x1f, 0x24, 0x77, 0x49, 0x8a, 0xb4, 0xdb, 0xde, 0x38, 0xb3, 0xf3, 0xf8, 0xcd,
+	0xec, 0xec, 0xec, 0x8e, 0x04, 0x73, 0xc4, 0x19, 0x7a, 0xa3, 0xb3, 0xc0, 0x76, 0x9d, 0x27, 0x67,
+	0x9e, 0x1b, 0xb8, 0x08, 0x62, 0x0e, 0x7e, 0x04, 0x13, 0x9b, 0xef, 0xce, 0x5c, 0x2f, 0x40, 0xb3,
+	0x50, 0x5a, 0x1b, 0xb5, 0xb4, 0xb6, 0xd6, 0x99, 0x32, 0x4a, 0x6b, 0x23, 0x84, 0xa0, 0xb2, 0x61,
+	0x06, 0xa4, 0x55, 0x6a, 0x6b, 0x9d, 0xaa, 0xc1, 0xbe, 0xa9, 0x74, 0xef, 0x34, 0x57, 0xba, 0x2c,
+	0x49, 0xff, 0xa1, 0xc1, 0xe4, 0x16, 0x19, 0xf5, 0x9c, 0x43, 0x17, 0x3d, 0x82, 0x49, 0xee, 0xc7,
+	0x6f, 0x69, 0xed, 0x72, 0xa7, 0xde, 0x45, 0x4f, 0x24, 0x5c, 0x7c, 0xc9, 0x08, 0x45, 0xa8, 0x34,
+	0xf7, 0xe3, 0xb7, 0x4a, 0x69, 0x69, 0xbe, 0x64, 0x84, 0x22, 0x68, 0x11, 0xa6, 0x76, 0x2f, 0x0e,
+	0x4e, 0xec, 0xe1, 0x16, 0x19, 0x31, 0x00, 0x53, 0x46, 0xcc, 0x40, 0x1d, 0xb8, 0x61, 0x90, 0xa1,
+	0xfb, 0x96, 0x78, 0xa3, 0x75, 0xd7, 0x09, 0x88, 0x13, 0xb4, 0x2a, 0x4c, 0x26, 0xc9, 0x46, 0x0f,
+	0x61, 0x2e, 0x64, 0x19, 0xe4, 0xfc, 0xc2, 0xf6, 0x88, 0xd5, 0xaa, 0xb6, 0xb5, 0x4e, 0xcd, 0x48,
+	0xf1, 0xf1, 0xcf, 0x1a, 0x94, 0xa9, 0xf5, 0x06, 0x54, 0x77, 0x2e, 0x1d, 0xe2, 0x89, 0x54, 0x70,
+	0x82, 0x66, 0xa7, 0xb7, 0xc1, 0x32, 0x37, 0x65, 0x94, 0x7a, 0x1b, 0x54, 0xaa, 0x6f, 0x1e, 0x90,
+	0x13, 0x81, 0x8e, 0x13, 0xa8, 0x05, 0x93, 0x2a, 0xa2, 0x90, 0x44, 0x18, 0xa6, 0xd7, 0x3d, 0x62,
+	0xd2, 0x68, 0x59, 0x56, 0xab, 0x2c, 0xab, 0x0a, 0x0f, 0xdd, 0x87, 0x0a, 0xcd, 0x6c, 0x6b, 0xa2,
+	0xad, 0x75, 0xea, 0xdd, 0x79, 0x39, 0x41, 0x22, 0xe9, 0x06, 0x13, 0xc0, 0x7b, 0x30, 0xb3, 0x6a,
+	0x59, 0x5b, 0x84, 0x81, 0x27, 0x7e, 0x80, 0x56, 0x18, 0x74, 0x86, 0xb8, 0xde, 0xbd, 0x91, 0x50,
+	0x34, 0x58, 0x58, 0x6d, 0xa8, 0x0f, 0x02, 0x6f, 0xd7, 0xf4, 0xfd, 0x4b, 0xd7, 0xb3, 0x44, 0x24,
+	0x32, 0x0b, 0x3f, 0x84, 0xd9, 0xd0, 0xaa, 0x7f, 0xe6, 0x3a, 0x3e, 0xa1, 0xe1, 0x0c, 0x2e, 0x86,
+	0x43, 0xe2, 0xfb, 0xcc, 0x74, 0xcd, 0x08, 0x49, 0xfc, 0x2d, 0xcc, 0xbc, 0x26, 0x81, 0x84, 0x20,
+	0x3b, 0x6b, 0x0d, 0xa8, 0x52, 0xe4, 0x61, 0xe2, 0x38, 0x91, 0x84, 0x52, 0x4e, 0x41, 0x41, 0xcb,
+	0x00, 0x7c, 0xbb, 0x77, 0x9c, 0x93, 0x11, 0x4b, 0x65, 0xcd, 0x90, 0x38, 0xf8, 0x29, 0xcc, 0x86,
+	0xee, 0x05, 0xd4, 0xe2, 0x0c, 0xe0, 0x26, 0x34, 0x56, 0xad, 0x53, 0xdb, 0xe9, 0xdb, 0x3e, 0x55,
+	0xf5, 0x05, 0x74, 0xfc, 0x02, 0x16, 0x12, 0x7c, 0x61, 0xf3, 0x0e, 0x54, 0x28, 0x2d, 0xca, 0x3b,
+	0x65, 0x94, 0x2d, 0xe2, 0xc7, 0x42, 0x7b, 0x83, 0x9c, 0x90, 0x80, 0xa8, 0x19, 0xe1, 0xb1, 0x6b,
+	0x52, 0xec, 0xb8, 0x0b, 0xcd, 0xa4, 0x78, 0x61, 0xb2, 0x77, 0x84, 0x0b, 0x7e, 0x96, 0x8a, 0x5c,
+	0x5c, 0x61, 0xa7, 0x9f, 0x43, 0x33, 0x69, 0xf0, 0xea, 0x69, 0x7c, 0x27, 0xd0, 0xf0, 0xb3, 0xfa,
+	0x2f, 0x17, 0x21, 0xd2, 0xa1, 0xb6, 0xf3, 0x96, 0x78, 0x9e, 0x6d, 0xf1, 0xce, 0x53, 0x33, 0x22,
+	0x3a, 0xca, 0x5d, 0xef, 0x34, 0x09, 0x7b, 0x7c, 0xee, 0x7e, 0xd0, 0x04, 0x5c, 0xc3, 0x0d, 0x4c,
+	0x65, 0x7f, 0x96, 0x01, 0x36, 0xcc, 0xc0, 0x1c, 0xb8, 0x17, 0xde, 0x90, 0x88, 0x0c, 0x4a, 0x1c,
+	0x8a, 0x64, 0x9b, 0x5c, 0xca, 0xe5, 0x1b, 0xd1, 0xb4, 0x3f, 0xad, 0x99, 0xc1, 0xf0, 0x78, 0x60,
+	0x7f, 0x13, 0x36, 0xc8, 0x98, 0x41, 0x2d, 0xbf, 0x21, 0x9e, 0x7d, 0x38, 0x92, 0xab, 0x37, 0xe6,
+	0x44, 0x71, 0x48, 0x90, 0xe2, 0x38, 0x3e, 0x71, 0x0f, 0xf6, 0x2f, 0x6c, 0x4b, 0x00, 0x0a, 0x49,
+	0xbc, 0x2e, 0xc2, 0x60, 0x0d, 0xa3, 0xb0, 0xcc, 0xe2, 0xf6, 0x54, 0x92, 0xda, 0x53, 0xe4, 0x58,
+	0x32, 0x52, 0x98, 0xc0, 0x63, 0x68, 0xec, 0x3b, 0x27, 0xee, 0xf0, 0xeb, 0x7d, 0x9f, 0x78, 0x92,
+	0xdf, 0x45, 0x98, 0xa2, 0x9c, 0xbe, 0x7b, 0x64, 0x3b, 0xc2, 0x77, 0xcc, 0xb8, 0xc2, 0x46, 0xcf,
+	0x41, 0x79, 0x6f, 0xaf, 0x2f, 0x92, 0x47, 0x3f, 0xf1, 0x8f, 0x1a, 0x2c, 0x24, 0x5c, 0x15, 0xa1,
+	0x63, 0x0d, 0x97, 0x05, 0xc3, 0x7d, 0xd4, 0x8c, 0x90, 0xa4, 0x08, 0xc2, 0x16, 0x1f, 0x5f, 0x22,
+	0x32, 0x2b, 0xf3, 0x72, 0xa8, 0x8c, 0xb9, 0x1c, 0xba, 0x80, 0xfa, 0xd7, 0xcc, 0x01, 0x7e, 0x0f,
+	0xe6, 0xfb, 0xd7, 0x09, 0x06, 0x7f, 0xa7, 0xc1, 0xe2, 0xfa, 0xb1, 0xe9, 0x1c, 0x11, 0xa1, 0x13,
+	0x26, 0xeb, 0xca, 0x39, 0xdf, 0x39, 0xb1, 0x92, 0x39, 0x97, 0x58, 0x54, 0x62, 0x9b, 0x5c, 0x26,
+	0x1b, 0xaf, 0xc4, 0xc2, 0x1f, 0xc2, 0xd2, 0x18, 0x04, 0x85, 0xe8, 0x47, 0xb0, 0x20, 0xd2, 0x76,
+	0xdd, 0x4a, 0x91, 0xf7, 0xa9, 0x94, 0xde, 0xa7, 0x62, 0xd4, 0xcf, 0xa0, 0x99, 0x74, 0x2d, 0xe0,
+	0x26, 0xac, 0x6b, 0x29, 0xeb, 0xf8, 0x25, 0x2c, 0x89, 0x5d, 0x8e, 0x74, 0xe3, 0xbd, 0x2f, 0xde,
+	0xe4, 0x67, 0xb0, 0x3c, 0x4e, 0xbd, 0x30, 0x63, 0xa7, 0x30, 0xb9, 0xed, 0x5a, 0xf4, 0x1c, 0xa2,
+	0x26, 0x4c, 0xd0, 0xcf, 0x5e, 0x78, 0xee, 0x05, 0x45, 0xf9, 0xd4, 0x6e, 0x2f, 0xdc, 0x4e, 0x41,
+	0x51, 0xa3, 0xec, 0x86, 0xed, 0x85, 0xf9, 0x08, 0x49, 0xba, 0xb2, 0x45, 0x46, 0xb4, 0x8f, 0xb1,
+	0x77, 0xc4, 0xb4, 0x11, 0x92, 0xf8, 0x03, 0xa8, 0x50, 0xab, 0x79, 0xbe, 0xfa, 0xe4, 0xc8, 0x1c,
+	0x8e, 0xc4, 0x51, 0x12, 0x14, 0xfe, 0x5e, 0x83, 0x1a, 0x13, 0xa1, 0xaf, 0xbe, 0xbb, 0xdc, 0x88,
+	0xe8, 0xf2, 0x73, 0x72, 0x97, 0xa7, 0x7c, 0x83, 0xbb, 0x78, 0x1c, 0x45, 0xd6, 0x2a, 0xa5, 0x1f,
+	0x33, 0x62, 0xc9, 0x88, 0xa2, 0xbf, 0x0f, 0xd5, 0x35, 0x7a, 0xee, 0x59, 0x2c, 0xf5, 0xee, 0x4d,
+	0x59, 0x98, 0x2d, 0x18, 0x7c, 0x1d, 0xff, 0xa2, 0x09, 0x49, 0x39, 0x01, 0x9a, 0x9a, 0x80, 0x26,
+	0x4c, 0xec, 0x9a, 0x5e, 0x20, 0x52, 0x36, 0x63, 0x08, 0x8a, 0xf6, 0xf3, 0x5d, 0xd7, 0xb7, 0xa9,
+	0x51, 0xe6, 0x67, 0xc6, 0x88, 0x68, 0xda, 0xb1, 0x3f, 0x26, 0xa6, 0x45, 0x3c, 0xd6, 0xd0, 0x2b,
+	0x6c, 0x55, 0xe2, 0xb0, 0x7e, 0x4f, 0xdd, 0xb2, 0xe5, 0x2a, 0x5b, 0x8e, 0x19, 0xb4, 0xd9, 0x6e,
+	0xbb, 0xce, 0x90, 0x88, 0x84, 0x73, 0x02, 0xff, 0xaa, 0x41, 0xdd, 0xa0, 0x47, 0xc9, 0xfa, 0x07,
+	0x88, 0x07, 0xe4, 0x7c, 0x10, 0x98, 0x5e, 0x10, 0x22, 0x0e, 0x69, 0xaa, 0x33, 0x20, 0xe7, 0x9b,
+	0x8e, 0x25, 0xd0, 0x0a, 0x2a, 0x11, 0x49, 0x35, 0x3f, 0x92, 0x89, 0x44, 0x24, 0xf8, 0x27, 0x0d,
+	0xd0, 0x6b, 0x12, 0x84, 0xbb, 0x1d, 0x1e, 0x81, 0xb8, 0x0a, 0x35, 0xa5, 0x0a, 0xe3, 0x4a, 0x2a,
+	0x29, 0x95, 0xb4, 0x08, 0x53, 0x9f, 0xdb, 0xc1, 0x31, 0x8b, 0x5e, 0xdc, 0xe2, 0x31, 0x83, 0x9e,
+	0xc9, 0xdd, 0x13, 0xd3, 0x76, 0x76, 0x0e, 0x0f, 0x7d, 0xc2, 0x1f, 0xca, 0x65, 0x43, 0x66, 0x45,
+	0x12, 0x7d, 0xe2, 0x1c, 0x05, 0xc7, 0xad, 0xaa, 0x24, 0xc1, 0x59, 0xf8, 0x4f, 0x0d, 0xe6, 0x15,
+	0xa0, 0xe2, 0xb0, 0xbd, 0x1f, 0x97, 0xaa, 0x28, 0xd1, 0x46, 0xb2, 0xf2, 0x98, 0x7c, 0x5c, 0xd0,
+	0xaf, 0x40, 0xa7, 0xe9, 0x19, 0x6c, 0xd9, 0x67, 0x67, 0xc4, 0x62, 0x3e, 0xd6, 0x46, 0x01, 0xf1,
+	0xd7, 0xdd, 0x0b, 0x27, 0x60, 0x71, 0x95, 0x8d, 0x1c, 0x89, 0x82, 0x58, 0x3b, 0x70, 0x63, 0x93,
+	0xbb, 0x27, 0x96, 0x12, 0x6f, 0x92, 0x8d, 0xee, 0xc1, 0x6c, 0xc4, 0xe2, 0xbe, 0x79, 0xd8, 0x09,
+	0x2e, 0xee, 0xc1, 0x2d, 0x11, 0x38, 0x43, 0x42, 0xb7, 0xed, 0x6f, 0x6e, 0x13, 0x7e, 0x02, 0xad,
+	0xb4, 0x29, 0x91, 0x48, 0x04, 0x15, 0x56, 0x22, 0x1a, 0x03, 0xc1, 0xbe, 0xe9, 0x84, 0x84, 0x06,
+	0x99, 0xd5, 0xb1, 0x3a, 0x64, 0xc7, 0x4a, 0xb8, 0xe5, 0x14, 0x7a, 0x09, 0x20, 0xa4, 0xe3, 0x3e,
+	0xb0, 0x24, 0xef, 0x46, 0xbc, 0x2a, 0x4c, 0x19, 0x92, 0x02, 0x7a, 0x4e, 0xab, 0x3f, 0x90, 0xfb,
+	0xc2, 0xed, 0x0c, 0x65, 0xb6, 0x1e, 0xaa, 0x47, 0x0a, 0xf4, 0xf0, 0xcd, 0x0f, 0x32, 0xea, 0x63,
+	0x11, 0xa6, 0x36, 0x3d, 0xcf, 0xf5, 0xf6, 0xc8, 0xbb, 0x20, 0x6c, 0xe6, 0x11, 0x03, 0xbd, 0xca,
+	0x40, 0xbc, 0x3c, 0x0e, 0x31, 0xb7, 0xa8, 0x40, 0x7e, 0x91, 0x82, 0xdc, 0x1e, 0x0f, 0x59, 0xe8,
+	0xc7, 0x98, 0xff, 0x0f, 0x37, 0xf9, 0x54, 0x40, 0x85, 0xa4, 0xe4, 0x66, 0x35, 0x6b, 0xdc, 0x00,
+	0x24, 0x0b, 0x73, 0x63, 0xf8, 0x23, 0x68, 0xc4, 0x5c, 0xe9, 0x0a, 0xbe, 0x6e, 0x65, 0xdc, 0x82,
+	0x85, 0x84, 0x1d, 0xe1, 0xe0, 0x10, 0xf4, 0x78, 0x61, 0x70, 0x6c, 0x7a, 0xc4, 0xba, 0x82, 0x1b,
+	0xa9, 0xf5, 0x95, 0x52, 0xad, 0x4f, 0x00, 0x28, 0x2b, 0x00, 0x96, 0xe0, 0x7f, 0x99, 0x7e, 0x04,
+	0x8c, 0x35, 0xb8, 0x99, 0x2a, 0x1e, 0xf9, 0xd2, 0xd1, 0x8a, 0x2f, 0x1d, 0x9a, 0xc1, 0xf4, 0x76,
+	0xe2, 0x2f, 0xa3, 0xba, 0x91, 0x2b, 0x8b, 0x0d, 0x03, 0xae, 0x45, 0xa4, 0x97, 0x79, 0x44, 0xc7,
+	0xb7, 0x57, 0xa9, 0xe0, 0xf6, 0x6a, 0x42, 0x23, 0xab, 0x04, 0xf0, 0x3e, 0xcc, 0xaf, 0xbb, 0x67,
+	0xa3, 0xe4, 0xb9, 0xca, 0xf3, 0x89, 0x61, 0x9a, 0x7e, 0x53, 0x35, 0xb6, 0xce, 0xd3, 0xaa, 0xf0,
+	0xa8, 0x3b, 0xd5, 0x2c, 0x77, 0xd7, 0xfd, 0xbd, 0x06, 0xd3, 0xe2, 0xb1, 0x32, 0x08, 0x5c, 0x8f,
+	0xa0, 0x55, 0x98, 0xe0, 0x83, 0x3f, 0xfa, 0xaf, 0x8c, 0x5d, 0xf9, 0x89, 0x41, 0xd7, 0xb3, 0x96,
+	0x44, 0x00, 0xff, 0xa1, 0x26, 0xf8, 0x40, 0xae, 0x9a, 0x50, 0x7e, 0x23, 0xd0, 0xf5, 0xac, 0xa5,
+	0xc8, 0xc4, 0x1b, 0x98, 0x51, 0xc6, 0x70, 0xd4, 0x56, 0x3d, 0xa6, 0x27, 0x77, 0x7d, 0x25, 0x47,
+	0x22, 0xb2, 0xfb, 0x05, 0xcc, 0xaa, 0x43, 0x0f, 0x4a, 0xab, 0x25, 0xa7, 0x2a, 0x1d, 0xe7, 0x89,
+	0xa4, 0x4c, 0x47, 0xc3, 0x7c, 0x86, 0xe9, 0xe4, 0xef, 0x02, 0x3a, 0xce, 0x13, 0x49, 0x99, 0x8e,
+	0x46, 0xf4, 0x0c, 0xd3, 0xc9, 0xdf, 0x03, 0x74, 0x9c, 0x27, 0x92, 0x32, 0xdd, 0x3b, 0x1d, 0x6f,
+	0xba, 0x77, 0x5a, 0x68, 0x3a, 0x35, 0x85, 0xf3, 0x3d, 0x54, 0x26, 0x38, 0x75, 0x0f, 0xb3, 0xe6,
+	0x48, 0x7d, 0x25, 0x47, 0x22, 0xb2, 0xbb, 0x0b, 0x75, 0x69, 0x94, 0x42, 0x4a, 0x4f, 0x4e, 0xcf,
+	0x65, 0xfa, 0xed, 0xb1, 0xeb, 0x91, 0x45, 0x07, 0x16, 0x32, 0x07, 0x1d, 0xd4, 0x91, 0x75, 0xf3,
+	0xa6, 0x31, 0xfd, 0xc1, 0x15, 0x24, 0xe5, 0xa4, 0xab, 0x23, 0x8a, 0x9a, 0xf4, 0xcc, 0xc9, 0x49,
+	0xc7, 0x79, 0x22, 0x91, 0xe9, 0x73, 0x68, 0x66, 0x8f, 0x20, 0xe8, 0x81, 0xaa, 0x9f, 0x33, 0xe5,
+	0xe8, 0x0f, 0xaf, 0x22, 0x1a, 0xba, 0xec, 0xfe, 0x56, 0x85, 0x59, 0xd1, 0x39, 0x3f, 0x35, 0x1d,
+	0xf3, 0x88, 0x78, 0x68, 0x1b, 0xea, 0xd2, 0x83, 0x4c, 0xdd, 0xa2, 0xf4, 0x93, 0x52, 0xbf, 0x3d,
+	0x76, 0x5d, 0xdc, 0xd4, 0x5f, 0xc1, 0x5c, 0xf2, 0x71, 0x82, 0xee, 0x64, 0x28, 0x25, 0x5f, 0x41,
+	0xfa, 0xdd, 0x7c, 0x21, 0x61, 0x7e, 0x17, 0xea, 0x83, 0x71, 0x70, 0x07, 0x05, 0x70, 0x33, 0x1e,
+	0x16, 0x1d, 0x0d, 0x7d, 0x06, 0xd3, 0x72, 0xbb, 0x45, 0x8a, 0x4a, 0x46, 0x7f, 0xd7, 0xdb, 0xe3,
+	0x05, 0x04, 0xc8, 0x2d, 0xf9, 0x3d, 0x82, 0xf2, 0xdf, 0x4e, 0x7a, 0xc1, 0x43, 0x85, 0x1a, 0x8b,
+	0xaf, 0x54, 0xd5, 0x58, 0xea, 0xd9, 0xa1, 0x2f, 0x8f, 0x5b, 0x16, 0xc6, 0xf6, 0x60, 0x46, 0x79,
+	0x20, 0xa8, 0x07, 0x3d, 0xeb, 0x0d, 0xa2, 0xaf, 0xe4, 0x48, 0x08, 0xab, 0x87, 0x30, 0x9f, 0x71,
+	0xeb, 0xa3, 0x7b, 0xd9, 0x9a, 0xc9, 0xe7, 0x87, 0x7e, 0xbf, 0x50, 0x8e, 0xfb, 0x39, 0x98, 0x60,
+	0xff, 0x9a, 0x3c, 0xfd, 0x6b, 0x00, 0x82, 0xd1, 0x87, 0x2a, 0x49, 0x19, 0x00, 0x00,
 }
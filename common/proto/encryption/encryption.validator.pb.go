@@ -107,12 +107,48 @@ func (this *AdminImportKeyRequest) Validate() error {
 func (this *AdminImportKeyResponse) Validate() error {
 	return nil
 }
+func (this *AdminRotateKeyRequest) Validate() error {
+	return nil
+}
+func (this *AdminRotateKeyResponse) Validate() error {
+	return nil
+}
 func (this *AdminCreateKeyRequest) Validate() error {
 	return nil
 }
 func (this *AdminCreateKeyResponse) Validate() error {
 	return nil
 }
+func (this *UnlockUserKeyRequest) Validate() error {
+	return nil
+}
+func (this *UnlockUserKeyResponse) Validate() error {
+	return nil
+}
+func (this *LockUserKeyRequest) Validate() error {
+	return nil
+}
+func (this *LockUserKeyResponse) Validate() error {
+	return nil
+}
+func (this *ChangeUserKeyPasswordRequest) Validate() error {
+	return nil
+}
+func (this *ChangeUserKeyPasswordResponse) Validate() error {
+	return nil
+}
+func (this *RecoverUserKeyRequest) Validate() error {
+	return nil
+}
+func (this *RecoverUserKeyResponse) Validate() error {
+	return nil
+}
+func (this *RequireUserKeyRecoveryRequest) Validate() error {
+	return nil
+}
+func (this *RequireUserKeyRecoveryResponse) Validate() error {
+	return nil
+}
 func (this *NodeKey) Validate() error {
 	return nil
 }
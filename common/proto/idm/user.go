@@ -52,6 +52,29 @@ func (u *User) WithPublicData(ctx context.Context, policiesContextEditable bool)
 	return user
 }
 
+// PolicyAttributes returns the attributes listed in names, that are passed along in the user claims to be checked
+// by policies conditions. Private attributes and parameters are never returned, and attributes are dropped once
+// their total size would exceed maxSize.
+func (u *User) PolicyAttributes(names []string, maxSize int) map[string]string {
+	attributes := make(map[string]string)
+	var size int
+	for _, k := range names {
+		if strings.HasPrefix(k, UserAttrPrivatePrefix) || strings.HasPrefix(k, "parameter:") {
+			continue
+		}
+		v, ok := u.GetAttributes()[k]
+		if !ok {
+			continue
+		}
+		if size+len(k)+len(v) > maxSize {
+			break
+		}
+		size += len(k) + len(v)
+		attributes[k] = v
+	}
+	return attributes
+}
+
 func isPublicAttribute(att string) (public bool) {
 
 	publicAttributes := []string{
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUserPolicyAttributes(t *testing.T) {
+
+	Convey("Only listed public attributes are returned, within the size limit", t, func() {
+		u := &User{Attributes: map[string]string{
+			"department":          "legal",
+			"country":             "fr",
+			"office":              "paris",
+			UserAttrPassHashed:    "true",
+			"parameter:core.conf": "value",
+		}}
+
+		So(u.PolicyAttributes(nil, 1024), ShouldBeEmpty)
+		So(u.PolicyAttributes([]string{"department", "unknown", UserAttrPassHashed, "parameter:core.conf"}, 1024), ShouldResemble, map[string]string{"department": "legal"})
+		So(u.PolicyAttributes([]string{"department", "country", "office"}, len("departmentlegalcountryfr")), ShouldResemble, map[string]string{"department": "legal", "country": "fr"})
+	})
+}
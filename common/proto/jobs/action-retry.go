@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+)
+
+const (
+	defaultRetryBackoff    = 1 * time.Second
+	defaultRetryMultiplier = 2
+)
+
+// GetTimeoutDuration parses the Timeout field. It returns 0 if the timeout is empty or invalid.
+func (a *Action) GetTimeoutDuration() time.Duration {
+	if a.Timeout == "" {
+		return 0
+	}
+	d, e := time.ParseDuration(a.Timeout)
+	if e != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Attempts returns the maximum number of runs allowed by this policy, at least 1.
+// It is safe to call on a nil policy.
+func (p *ActionRetryPolicy) Attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return int(p.MaxAttempts)
+}
+
+// Backoff computes the delay to wait after the given failed attempt (starting at 1).
+func (p *ActionRetryPolicy) Backoff(attempt int) time.Duration {
+	delay := defaultRetryBackoff
+	if d, e := time.ParseDuration(p.InitialBackoff); e == nil && d >= 0 {
+		delay = d
+	}
+	multiplier := float64(p.BackoffMultiplier)
+	if multiplier <= 0 {
+		multiplier = defaultRetryMultiplier
+	}
+	backoff := time.Duration(float64(delay) * math.Pow(multiplier, float64(attempt-1)))
+	if max, e := time.ParseDuration(p.MaxBackoff); e == nil && max > 0 && (backoff > max || backoff < 0) {
+		backoff = max
+	}
+	return backoff
+}
+
+// IsRetryable checks if an error can be retried. If neither RetryableCodes nor RetryableErrors are set,
+// all errors are considered retryable. Timeouts are retried only if RetryOnTimeout is set.
+func (p *ActionRetryPolicy) IsRetryable(err error, timeout bool) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	if timeout {
+		return p.RetryOnTimeout
+	}
+	if len(p.RetryableCodes) == 0 && len(p.RetryableErrors) == 0 {
+		return true
+	}
+	parsed := errors.Parse(err.Error())
+	for _, c := range p.RetryableCodes {
+		if parsed.Code == c {
+			return true
+		}
+	}
+	for _, r := range p.RetryableErrors {
+		if reg, e := regexp.Compile(r); e == nil && reg.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
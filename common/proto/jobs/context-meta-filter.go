@@ -56,6 +56,11 @@ func (m *ContextMetaFilter) filterPolicyQueries(ctx context.Context, input Actio
 			}
 		}
 	}
+	claimsContext := make(map[string]string)
+	permissions.PolicyContextFromClaims(claimsContext, ctx)
+	for k, v := range claimsContext {
+		policyContext[k] = v
+	}
 	warden := &ladon.Ladon{Manager: memory.NewMemoryManager()}
 	for _, q := range m.Query.SubQueries {
 		var c ContextMetaSingleQuery
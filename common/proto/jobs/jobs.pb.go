@@ -16,6 +16,7 @@ It has these top-level messages:
 	ContextMetaSingleQuery
 	Schedule
 	Action
+	ActionRetryPolicy
 	Job
 	JobParameter
 	JobChangeEvent
@@ -508,6 +509,11 @@ type Schedule struct {
 	Iso8601Schedule string `protobuf:"bytes,1,opt,name=Iso8601Schedule" json:"Iso8601Schedule,omitempty"`
 	// Minimum time between two runs
 	Iso8601MinDelta string `protobuf:"bytes,3,opt,name=Iso8601MinDelta" json:"Iso8601MinDelta,omitempty"`
+	// Cron expression (minute hour day-of-month month day-of-week), for instance "0 2 * * MON-FRI"
+	// or "0 2 * * SUN#1". Takes precedence over Iso8601Schedule if set.
+	Cron string `protobuf:"bytes,4,opt,name=Cron" json:"Cron,omitempty"`
+	// IANA Time zone used to evaluate the Cron expression, for instance "Europe/Paris" (defaults to server time zone)
+	Timezone string `protobuf:"bytes,5,opt,name=Timezone" json:"Timezone,omitempty"`
 }
 
 func (m *Schedule) Reset()                    { *m = Schedule{} }
@@ -529,6 +535,20 @@ func (m *Schedule) GetIso8601MinDelta() string {
 	return ""
 }
 
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *Schedule) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
 type Action struct {
 	// String Identifier for specific action
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -540,6 +560,11 @@ type Action struct {
 	Bypass bool `protobuf:"varint,15,opt,name=Bypass" json:"Bypass,omitempty"`
 	// Stop full chain now : do not carry on executing next actions
 	BreakAfter bool `protobuf:"varint,16,opt,name=BreakAfter" json:"BreakAfter,omitempty"`
+	// Retry the action if it fails
+	RetryPolicy *ActionRetryPolicy `protobuf:"bytes,17,opt,name=RetryPolicy" json:"RetryPolicy,omitempty"`
+	// Hard timeout for running this action, expressed as a golang duration (e.g. "30s", "10m").
+	// Each attempt is cancelled and flagged as failed if it exceeds this duration.
+	Timeout string `protobuf:"bytes,18,opt,name=Timeout" json:"Timeout,omitempty"`
 	// Nodes Selector
 	NodesSelector *NodesSelector `protobuf:"bytes,2,opt,name=NodesSelector" json:"NodesSelector,omitempty"`
 	// Users Selector (deprecated in favor of IdmSelector)
@@ -608,6 +633,20 @@ func (m *Action) GetBreakAfter() bool {
 	return false
 }
 
+func (m *Action) GetRetryPolicy() *ActionRetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *Action) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
 func (m *Action) GetNodesSelector() *NodesSelector {
 	if m != nil {
 		return m.NodesSelector
@@ -685,6 +724,78 @@ func (m *Action) GetFailedFilterActions() []*Action {
 	return nil
 }
 
+// ActionRetryPolicy describes how a failed action should be retried
+type ActionRetryPolicy struct {
+	// Maximum number of attempts, including the first run. Values lower than 2 disable retry.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=MaxAttempts" json:"MaxAttempts,omitempty"`
+	// Delay before the first retry, expressed as a golang duration (default "1s")
+	InitialBackoff string `protobuf:"bytes,2,opt,name=InitialBackoff" json:"InitialBackoff,omitempty"`
+	// Upper limit for the delay between two attempts, expressed as a golang duration
+	MaxBackoff string `protobuf:"bytes,3,opt,name=MaxBackoff" json:"MaxBackoff,omitempty"`
+	// Factor applied to the delay after each attempt (default 2)
+	BackoffMultiplier float32 `protobuf:"fixed32,4,opt,name=BackoffMultiplier" json:"BackoffMultiplier,omitempty"`
+	// Only retry errors whose code is part of this list (e.g. 408, 500, 503)
+	RetryableCodes []int32 `protobuf:"varint,5,rep,packed,name=RetryableCodes" json:"RetryableCodes,omitempty"`
+	// Only retry errors whose message matches one of these regular expressions
+	RetryableErrors []string `protobuf:"bytes,6,rep,name=RetryableErrors" json:"RetryableErrors,omitempty"`
+	// Also retry when the action timeout is reached
+	RetryOnTimeout bool `protobuf:"varint,7,opt,name=RetryOnTimeout" json:"RetryOnTimeout,omitempty"`
+}
+
+func (m *ActionRetryPolicy) Reset()                    { *m = ActionRetryPolicy{} }
+func (m *ActionRetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*ActionRetryPolicy) ProtoMessage()               {}
+func (*ActionRetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ActionRetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *ActionRetryPolicy) GetInitialBackoff() string {
+	if m != nil {
+		return m.InitialBackoff
+	}
+	return ""
+}
+
+func (m *ActionRetryPolicy) GetMaxBackoff() string {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return ""
+}
+
+func (m *ActionRetryPolicy) GetBackoffMultiplier() float32 {
+	if m != nil {
+		return m.BackoffMultiplier
+	}
+	return 0
+}
+
+func (m *ActionRetryPolicy) GetRetryableCodes() []int32 {
+	if m != nil {
+		return m.RetryableCodes
+	}
+	return nil
+}
+
+func (m *ActionRetryPolicy) GetRetryableErrors() []string {
+	if m != nil {
+		return m.RetryableErrors
+	}
+	return nil
+}
+
+func (m *ActionRetryPolicy) GetRetryOnTimeout() bool {
+	if m != nil {
+		return m.RetryOnTimeout
+	}
+	return false
+}
+
 type Job struct {
 	// Unique ID for this Job
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -731,7 +842,7 @@ type Job struct {
 func (m *Job) Reset()                    { *m = Job{} }
 func (m *Job) String() string            { return proto.CompactTextString(m) }
 func (*Job) ProtoMessage()               {}
-func (*Job) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*Job) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *Job) GetID() string {
 	if m != nil {
@@ -884,7 +995,7 @@ type JobParameter struct {
 func (m *JobParameter) Reset()                    { *m = JobParameter{} }
 func (m *JobParameter) String() string            { return proto.CompactTextString(m) }
 func (*JobParameter) ProtoMessage()               {}
-func (*JobParameter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*JobParameter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *JobParameter) GetName() string {
 	if m != nil {
@@ -937,7 +1048,7 @@ type JobChangeEvent struct {
 func (m *JobChangeEvent) Reset()                    { *m = JobChangeEvent{} }
 func (m *JobChangeEvent) String() string            { return proto.CompactTextString(m) }
 func (*JobChangeEvent) ProtoMessage()               {}
-func (*JobChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*JobChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *JobChangeEvent) GetJobUpdated() *Job {
 	if m != nil {
@@ -961,7 +1072,7 @@ type TaskChangeEvent struct {
 func (m *TaskChangeEvent) Reset()                    { *m = TaskChangeEvent{} }
 func (m *TaskChangeEvent) String() string            { return proto.CompactTextString(m) }
 func (*TaskChangeEvent) ProtoMessage()               {}
-func (*TaskChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*TaskChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *TaskChangeEvent) GetTaskUpdated() *Task {
 	if m != nil {
@@ -984,7 +1095,7 @@ type PutJobRequest struct {
 func (m *PutJobRequest) Reset()                    { *m = PutJobRequest{} }
 func (m *PutJobRequest) String() string            { return proto.CompactTextString(m) }
 func (*PutJobRequest) ProtoMessage()               {}
-func (*PutJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*PutJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *PutJobRequest) GetJob() *Job {
 	if m != nil {
@@ -1000,7 +1111,7 @@ type PutJobResponse struct {
 func (m *PutJobResponse) Reset()                    { *m = PutJobResponse{} }
 func (m *PutJobResponse) String() string            { return proto.CompactTextString(m) }
 func (*PutJobResponse) ProtoMessage()               {}
-func (*PutJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*PutJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *PutJobResponse) GetJob() *Job {
 	if m != nil {
@@ -1017,7 +1128,7 @@ type GetJobRequest struct {
 func (m *GetJobRequest) Reset()                    { *m = GetJobRequest{} }
 func (m *GetJobRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetJobRequest) ProtoMessage()               {}
-func (*GetJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*GetJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *GetJobRequest) GetJobID() string {
 	if m != nil {
@@ -1040,7 +1151,7 @@ type GetJobResponse struct {
 func (m *GetJobResponse) Reset()                    { *m = GetJobResponse{} }
 func (m *GetJobResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetJobResponse) ProtoMessage()               {}
-func (*GetJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*GetJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *GetJobResponse) GetJob() *Job {
 	if m != nil {
@@ -1057,7 +1168,7 @@ type DeleteJobRequest struct {
 func (m *DeleteJobRequest) Reset()                    { *m = DeleteJobRequest{} }
 func (m *DeleteJobRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteJobRequest) ProtoMessage()               {}
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *DeleteJobRequest) GetJobID() string {
 	if m != nil {
@@ -1081,7 +1192,7 @@ type DeleteJobResponse struct {
 func (m *DeleteJobResponse) Reset()                    { *m = DeleteJobResponse{} }
 func (m *DeleteJobResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteJobResponse) ProtoMessage()               {}
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *DeleteJobResponse) GetSuccess() bool {
 	if m != nil {
@@ -1117,7 +1228,7 @@ type ListJobsRequest struct {
 func (m *ListJobsRequest) Reset()                    { *m = ListJobsRequest{} }
 func (m *ListJobsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListJobsRequest) ProtoMessage()               {}
-func (*ListJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ListJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *ListJobsRequest) GetOwner() string {
 	if m != nil {
@@ -1175,7 +1286,7 @@ type ListJobsResponse struct {
 func (m *ListJobsResponse) Reset()                    { *m = ListJobsResponse{} }
 func (m *ListJobsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListJobsResponse) ProtoMessage()               {}
-func (*ListJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*ListJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *ListJobsResponse) GetJob() *Job {
 	if m != nil {
@@ -1194,7 +1305,7 @@ type ListTasksRequest struct {
 func (m *ListTasksRequest) Reset()                    { *m = ListTasksRequest{} }
 func (m *ListTasksRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListTasksRequest) ProtoMessage()               {}
-func (*ListTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*ListTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ListTasksRequest) GetJobID() string {
 	if m != nil {
@@ -1217,7 +1328,7 @@ type ListTasksResponse struct {
 func (m *ListTasksResponse) Reset()                    { *m = ListTasksResponse{} }
 func (m *ListTasksResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListTasksResponse) ProtoMessage()               {}
-func (*ListTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*ListTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ListTasksResponse) GetTask() *Task {
 	if m != nil {
@@ -1233,7 +1344,7 @@ type PutTaskRequest struct {
 func (m *PutTaskRequest) Reset()                    { *m = PutTaskRequest{} }
 func (m *PutTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*PutTaskRequest) ProtoMessage()               {}
-func (*PutTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*PutTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *PutTaskRequest) GetTask() *Task {
 	if m != nil {
@@ -1249,7 +1360,7 @@ type PutTaskResponse struct {
 func (m *PutTaskResponse) Reset()                    { *m = PutTaskResponse{} }
 func (m *PutTaskResponse) String() string            { return proto.CompactTextString(m) }
 func (*PutTaskResponse) ProtoMessage()               {}
-func (*PutTaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*PutTaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *PutTaskResponse) GetTask() *Task {
 	if m != nil {
@@ -1272,7 +1383,7 @@ type DeleteTasksRequest struct {
 func (m *DeleteTasksRequest) Reset()                    { *m = DeleteTasksRequest{} }
 func (m *DeleteTasksRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteTasksRequest) ProtoMessage()               {}
-func (*DeleteTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*DeleteTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *DeleteTasksRequest) GetJobId() string {
 	if m != nil {
@@ -1309,7 +1420,7 @@ type DeleteTasksResponse struct {
 func (m *DeleteTasksResponse) Reset()                    { *m = DeleteTasksResponse{} }
 func (m *DeleteTasksResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteTasksResponse) ProtoMessage()               {}
-func (*DeleteTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*DeleteTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *DeleteTasksResponse) GetDeleted() []string {
 	if m != nil {
@@ -1325,7 +1436,7 @@ type DetectStuckTasksRequest struct {
 func (m *DetectStuckTasksRequest) Reset()                    { *m = DetectStuckTasksRequest{} }
 func (m *DetectStuckTasksRequest) String() string            { return proto.CompactTextString(m) }
 func (*DetectStuckTasksRequest) ProtoMessage()               {}
-func (*DetectStuckTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*DetectStuckTasksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *DetectStuckTasksRequest) GetSince() int32 {
 	if m != nil {
@@ -1341,7 +1452,7 @@ type DetectStuckTasksResponse struct {
 func (m *DetectStuckTasksResponse) Reset()                    { *m = DetectStuckTasksResponse{} }
 func (m *DetectStuckTasksResponse) String() string            { return proto.CompactTextString(m) }
 func (*DetectStuckTasksResponse) ProtoMessage()               {}
-func (*DetectStuckTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*DetectStuckTasksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *DetectStuckTasksResponse) GetFixedTaskIds() []string {
 	if m != nil {
@@ -1373,7 +1484,7 @@ type Task struct {
 func (m *Task) Reset()                    { *m = Task{} }
 func (m *Task) String() string            { return proto.CompactTextString(m) }
 func (*Task) ProtoMessage()               {}
-func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *Task) GetID() string {
 	if m != nil {
@@ -1476,7 +1587,7 @@ type CtrlCommand struct {
 func (m *CtrlCommand) Reset()                    { *m = CtrlCommand{} }
 func (m *CtrlCommand) String() string            { return proto.CompactTextString(m) }
 func (*CtrlCommand) ProtoMessage()               {}
-func (*CtrlCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*CtrlCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *CtrlCommand) GetCmd() Command {
 	if m != nil {
@@ -1521,7 +1632,7 @@ type CtrlCommandResponse struct {
 func (m *CtrlCommandResponse) Reset()                    { *m = CtrlCommandResponse{} }
 func (m *CtrlCommandResponse) String() string            { return proto.CompactTextString(m) }
 func (*CtrlCommandResponse) ProtoMessage()               {}
-func (*CtrlCommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*CtrlCommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *CtrlCommandResponse) GetMsg() string {
 	if m != nil {
@@ -1534,12 +1645,14 @@ type ActionLog struct {
 	Action        *Action        `protobuf:"bytes,1,opt,name=Action" json:"Action,omitempty"`
 	InputMessage  *ActionMessage `protobuf:"bytes,2,opt,name=InputMessage" json:"InputMessage,omitempty"`
 	OutputMessage *ActionMessage `protobuf:"bytes,3,opt,name=OutputMessage" json:"OutputMessage,omitempty"`
+	// Attempt number, starting at 1, when the action has a RetryPolicy
+	Attempt int32 `protobuf:"varint,4,opt,name=Attempt" json:"Attempt,omitempty"`
 }
 
 func (m *ActionLog) Reset()                    { *m = ActionLog{} }
 func (m *ActionLog) String() string            { return proto.CompactTextString(m) }
 func (*ActionLog) ProtoMessage()               {}
-func (*ActionLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ActionLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *ActionLog) GetAction() *Action {
 	if m != nil {
@@ -1562,6 +1675,13 @@ func (m *ActionLog) GetOutputMessage() *ActionMessage {
 	return nil
 }
 
+func (m *ActionLog) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
 // Simple Event sent by the timer service to trigger a JobID at a given time
 // or to trigger a run now, with optional parameters
 type JobTriggerEvent struct {
@@ -1580,7 +1700,7 @@ type JobTriggerEvent struct {
 func (m *JobTriggerEvent) Reset()                    { *m = JobTriggerEvent{} }
 func (m *JobTriggerEvent) String() string            { return proto.CompactTextString(m) }
 func (*JobTriggerEvent) ProtoMessage()               {}
-func (*JobTriggerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*JobTriggerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *JobTriggerEvent) GetJobID() string {
 	if m != nil {
@@ -1639,7 +1759,7 @@ type ActionOutput struct {
 func (m *ActionOutput) Reset()                    { *m = ActionOutput{} }
 func (m *ActionOutput) String() string            { return proto.CompactTextString(m) }
 func (*ActionOutput) ProtoMessage()               {}
-func (*ActionOutput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*ActionOutput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *ActionOutput) GetSuccess() bool {
 	if m != nil {
@@ -1723,7 +1843,7 @@ type ActionOutputSingleQuery struct {
 func (m *ActionOutputSingleQuery) Reset()                    { *m = ActionOutputSingleQuery{} }
 func (m *ActionOutputSingleQuery) String() string            { return proto.CompactTextString(m) }
 func (*ActionOutputSingleQuery) ProtoMessage()               {}
-func (*ActionOutputSingleQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*ActionOutputSingleQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *ActionOutputSingleQuery) GetIsSuccess() bool {
 	if m != nil {
@@ -1841,7 +1961,7 @@ type ActionMessage struct {
 func (m *ActionMessage) Reset()                    { *m = ActionMessage{} }
 func (m *ActionMessage) String() string            { return proto.CompactTextString(m) }
 func (*ActionMessage) ProtoMessage()               {}
-func (*ActionMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*ActionMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *ActionMessage) GetEvent() *google_protobuf.Any {
 	if m != nil {
@@ -1908,6 +2028,7 @@ func init() {
 	proto.RegisterType((*ContextMetaSingleQuery)(nil), "jobs.ContextMetaSingleQuery")
 	proto.RegisterType((*Schedule)(nil), "jobs.Schedule")
 	proto.RegisterType((*Action)(nil), "jobs.Action")
+	proto.RegisterType((*ActionRetryPolicy)(nil), "jobs.ActionRetryPolicy")
 	proto.RegisterType((*Job)(nil), "jobs.Job")
 	proto.RegisterType((*JobParameter)(nil), "jobs.JobParameter")
 	proto.RegisterType((*JobChangeEvent)(nil), "jobs.JobChangeEvent")
@@ -1945,169 +2066,178 @@ func init() {
 func init() { proto.RegisterFile("jobs.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2609 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x4b, 0x6f, 0x1b, 0xc9,
-	0xf1, 0x37, 0x1f, 0xc3, 0x47, 0x51, 0x12, 0x47, 0x6d, 0xad, 0xcd, 0xd5, 0xfa, 0xbf, 0x2b, 0x0c,
-	0x8c, 0xfd, 0x6b, 0x85, 0x0d, 0x65, 0xcb, 0xbb, 0x89, 0x1d, 0xac, 0x17, 0x2b, 0x53, 0xf6, 0x9a,
-	0x8a, 0x5e, 0xdb, 0xb4, 0x93, 0x43, 0x82, 0x00, 0x43, 0x4e, 0x9b, 0x1a, 0x7b, 0xd8, 0xcd, 0xcc,
-	0xf4, 0xd8, 0x66, 0x72, 0xcd, 0x31, 0xdf, 0x20, 0xb7, 0x00, 0xb9, 0x06, 0xc8, 0x35, 0xc7, 0xdc,
-	0x72, 0xc9, 0x21, 0x40, 0xf2, 0x0d, 0xf2, 0x01, 0xf2, 0x09, 0x12, 0xf4, 0x63, 0x66, 0x7a, 0x86,
-	0x0f, 0xcb, 0x40, 0x90, 0x83, 0x84, 0xe9, 0x5f, 0x55, 0x75, 0x57, 0x75, 0x57, 0x55, 0x57, 0x35,
-	0x01, 0x5e, 0xb2, 0x61, 0xd4, 0x9d, 0x86, 0x8c, 0x33, 0x54, 0x15, 0xdf, 0xdb, 0x1f, 0x8e, 0x19,
-	0x1b, 0x07, 0x64, 0x5f, 0x62, 0xc3, 0xf8, 0xc5, 0xbe, 0x4b, 0x67, 0x8a, 0x61, 0xfb, 0xfe, 0xd8,
-	0xe7, 0x97, 0xf1, 0xb0, 0x3b, 0x62, 0x93, 0xfd, 0xe9, 0xcc, 0xf3, 0xd9, 0xfe, 0x88, 0x04, 0x41,
-	0xb4, 0x3f, 0x62, 0x93, 0x09, 0xa3, 0xfb, 0x11, 0x09, 0x5f, 0xfb, 0x23, 0x2d, 0xa9, 0x41, 0x2d,
-	0x79, 0x6f, 0xb5, 0xa4, 0x92, 0xe0, 0x21, 0x21, 0xf2, 0x9f, 0x16, 0xba, 0x7b, 0x15, 0x21, 0xdf,
-	0x9b, 0x88, 0x3f, 0x2d, 0x72, 0x78, 0x15, 0x11, 0x77, 0xc4, 0xfd, 0xd7, 0x3e, 0x9f, 0xa5, 0x1f,
-	0x11, 0x0f, 0x89, 0xab, 0xa7, 0x70, 0xfe, 0x58, 0x82, 0xf5, 0x33, 0xe6, 0x91, 0x68, 0x40, 0x02,
-	0x32, 0xe2, 0x2c, 0x44, 0x36, 0x54, 0x0e, 0x83, 0xa0, 0x53, 0xda, 0x29, 0xed, 0x36, 0xb0, 0xf8,
-	0x44, 0x37, 0xa0, 0x76, 0xe1, 0xf2, 0x4b, 0x12, 0x75, 0xca, 0x3b, 0x95, 0xdd, 0x26, 0xd6, 0x23,
-	0x74, 0x1b, 0xac, 0xef, 0x62, 0x12, 0xce, 0x3a, 0xd5, 0x9d, 0xd2, 0x6e, 0xeb, 0x60, 0xa3, 0xab,
-	0xb7, 0xa4, 0x2b, 0x51, 0xac, 0x88, 0xa8, 0x03, 0xf5, 0x1e, 0x0b, 0xc4, 0xe4, 0x1d, 0x4b, 0xce,
-	0x99, 0x0c, 0xd1, 0x16, 0x58, 0x27, 0xee, 0x90, 0x04, 0x9d, 0xda, 0x4e, 0x69, 0xb7, 0x89, 0xd5,
-	0x00, 0xed, 0x40, 0xeb, 0x88, 0x44, 0xa3, 0xd0, 0x9f, 0x72, 0x9f, 0xd1, 0x4e, 0x5d, 0xd2, 0x4c,
-	0xc8, 0xf9, 0x73, 0x09, 0x5a, 0x7d, 0x6f, 0x92, 0x6a, 0xfc, 0x19, 0x54, 0x9f, 0xcd, 0xa6, 0x44,
-	0xaa, 0xbc, 0x71, 0xf0, 0x41, 0x57, 0x1e, 0xb2, 0xc1, 0x20, 0x88, 0x58, 0xb2, 0x24, 0xc6, 0x95,
-	0x33, 0xe3, 0x52, 0x23, 0x2a, 0x57, 0x34, 0xa2, 0xba, 0xc4, 0x08, 0x6b, 0x85, 0x11, 0xb5, 0x79,
-	0x23, 0xfe, 0x54, 0x82, 0xf5, 0xe7, 0x11, 0x09, 0x57, 0x6d, 0xfc, 0x27, 0x60, 0x49, 0x16, 0xb9,
-	0xef, 0xad, 0x83, 0x66, 0x57, 0x1c, 0xbd, 0x40, 0xb0, 0xc2, 0xdf, 0x5f, 0xf9, 0xff, 0xd2, 0x09,
-	0x84, 0x80, 0x0e, 0x47, 0xe2, 0xeb, 0x3c, 0xe6, 0xd3, 0x98, 0x3f, 0xf1, 0x03, 0x4e, 0xc2, 0x4c,
-	0x9b, 0xd2, 0x2a, 0x6d, 0xd2, 0x35, 0xcb, 0x2b, 0xd6, 0xac, 0xcc, 0xaf, 0xf9, 0xbb, 0x12, 0x6c,
-	0xf6, 0x18, 0xe5, 0xe4, 0x2d, 0x3f, 0x25, 0xdc, 0xd5, 0x6b, 0xee, 0xe7, 0xce, 0xfe, 0x23, 0x75,
-	0xf6, 0x73, 0x6c, 0x86, 0x07, 0xa4, 0x4a, 0x96, 0xaf, 0xa4, 0x64, 0x65, 0x85, 0x92, 0xd5, 0x79,
-	0x25, 0x5f, 0xc2, 0x0d, 0x63, 0xf1, 0x81, 0x4f, 0xc7, 0x01, 0x51, 0x33, 0xde, 0x82, 0xe6, 0x13,
-	0x9f, 0x04, 0xde, 0x99, 0x3b, 0x51, 0xda, 0x36, 0x71, 0x06, 0xa0, 0x03, 0x68, 0xf6, 0x18, 0xf5,
-	0x7c, 0x39, 0xaf, 0xd2, 0x6c, 0x4b, 0x9e, 0xf6, 0x05, 0x0b, 0xfc, 0xd1, 0x2c, 0xa5, 0xe1, 0x8c,
-	0xcd, 0xf9, 0x39, 0x34, 0x06, 0xa3, 0x4b, 0xe2, 0xc5, 0x01, 0x41, 0xbb, 0xd0, 0xee, 0x47, 0xec,
-	0xfe, 0xf7, 0xef, 0xdc, 0x4d, 0x20, 0xbd, 0x46, 0x11, 0x36, 0x38, 0x4f, 0x7d, 0x7a, 0x44, 0x02,
-	0xee, 0x6a, 0x1b, 0x8b, 0xb0, 0xf3, 0x8f, 0x1a, 0xd4, 0xd4, 0x29, 0xa3, 0x0d, 0x28, 0xf7, 0x8f,
-	0xf4, 0x8c, 0xe5, 0xfe, 0x51, 0xb6, 0x3d, 0xeb, 0x2b, 0xb6, 0x67, 0x63, 0x6e, 0x7b, 0x44, 0x26,
-	0x79, 0x34, 0x9b, 0xba, 0x51, 0xd4, 0x69, 0x4b, 0x47, 0xd4, 0x23, 0xf4, 0x31, 0xc0, 0xa3, 0x90,
-	0xb8, 0xaf, 0x0e, 0x5f, 0x70, 0x12, 0x76, 0x6c, 0x49, 0x33, 0x10, 0xf4, 0xa0, 0x90, 0xa4, 0xf4,
-	0x16, 0x5d, 0x57, 0xc7, 0x9d, 0x23, 0xe1, 0x42, 0x3a, 0x7b, 0x50, 0x08, 0x33, 0x1d, 0x2a, 0x5a,
-	0x34, 0x47, 0xc2, 0x85, 0x80, 0xfc, 0x12, 0x5a, 0x72, 0x2e, 0xe5, 0x43, 0x3a, 0xcb, 0x2d, 0x5c,
-	0xd3, 0xe4, 0x13, 0x62, 0x72, 0x1e, 0x2d, 0x66, 0x2d, 0x5f, 0xcf, 0xe4, 0x43, 0xf7, 0x72, 0x49,
-	0xad, 0xd3, 0x94, 0x62, 0x9b, 0x73, 0xc9, 0x0c, 0xe7, 0x52, 0xdf, 0x3e, 0x34, 0xfb, 0xde, 0x44,
-	0xaf, 0x04, 0xcb, 0x44, 0x32, 0x1e, 0xf4, 0x74, 0x51, 0xe4, 0xca, 0xf0, 0x6f, 0x1d, 0x74, 0x94,
-	0xe4, 0x3c, 0x1d, 0x2f, 0x8a, 0xf6, 0xc7, 0x0b, 0xc2, 0xb1, 0xd3, 0x92, 0x13, 0xdd, 0x5c, 0x12,
-	0x86, 0x78, 0x41, 0x00, 0x7f, 0x05, 0x70, 0xe1, 0x86, 0xee, 0x84, 0x70, 0x91, 0xe8, 0xea, 0x32,
-	0xd1, 0xdd, 0x32, 0x15, 0xe9, 0x66, 0xe4, 0xc7, 0x94, 0x87, 0x33, 0x6c, 0xf0, 0xa3, 0x2f, 0x60,
-	0xa3, 0x77, 0xe9, 0xfa, 0x94, 0x78, 0x8a, 0x39, 0xea, 0x34, 0xe4, 0x0c, 0x6b, 0xe6, 0x0c, 0xb8,
-	0xc0, 0x83, 0xbe, 0x86, 0xeb, 0x4f, 0x5c, 0x3f, 0x20, 0x9e, 0xd2, 0x21, 0x11, 0x5d, 0x5b, 0x20,
-	0xba, 0x88, 0x71, 0xfb, 0x21, 0xb4, 0x0b, 0x4a, 0x89, 0xe4, 0xfd, 0x8a, 0xcc, 0x74, 0x88, 0x88,
-	0x4f, 0x11, 0x23, 0xaf, 0xdd, 0x20, 0x26, 0x49, 0x9e, 0x93, 0x83, 0x1f, 0x96, 0xef, 0x97, 0x9c,
-	0x7f, 0x5a, 0x50, 0x39, 0x66, 0xc3, 0xe5, 0x51, 0x95, 0xcb, 0x8c, 0x5b, 0x60, 0x9d, 0xbf, 0xa1,
-	0x24, 0x4c, 0x52, 0x91, 0x1c, 0xa0, 0x6d, 0x68, 0xf4, 0xa9, 0xbc, 0xd1, 0x89, 0xbe, 0x91, 0xd2,
-	0xb1, 0x88, 0xb2, 0x5e, 0x1c, 0x71, 0x36, 0xe9, 0x6c, 0xa9, 0x28, 0x53, 0x23, 0x91, 0x82, 0x4e,
-	0x5c, 0x3a, 0x8e, 0xdd, 0x31, 0x89, 0x3a, 0x20, 0xaf, 0xf2, 0x0c, 0x10, 0x31, 0xf8, 0xf8, 0x35,
-	0xa1, 0x5c, 0xe4, 0xa3, 0xa8, 0x63, 0x49, 0xb2, 0x81, 0xa0, 0xbd, 0x2c, 0xdd, 0x68, 0x7f, 0xd9,
-	0x50, 0x3b, 0x95, 0xa0, 0x38, 0x4b, 0x47, 0xb7, 0xa0, 0x79, 0x18, 0x73, 0x36, 0xe0, 0x6e, 0xc8,
-	0xe5, 0xfd, 0xd1, 0xc0, 0x19, 0x90, 0x50, 0x7b, 0x01, 0x71, 0xa9, 0xf4, 0x18, 0x4d, 0x95, 0x00,
-	0xfa, 0x14, 0xea, 0xab, 0xce, 0x32, 0x21, 0xa2, 0x4f, 0x61, 0xe3, 0xd4, 0x7d, 0xdb, 0x63, 0x74,
-	0x14, 0x87, 0x21, 0xa1, 0xa3, 0x99, 0x0c, 0x19, 0x0b, 0x17, 0x50, 0xf4, 0x39, 0x6c, 0x3e, 0x73,
-	0xa3, 0x57, 0xd1, 0xc0, 0x0f, 0x08, 0xe5, 0xcf, 0xa7, 0x9e, 0xcb, 0x49, 0x67, 0x4d, 0xae, 0x3a,
-	0x4f, 0x40, 0x3b, 0x60, 0x49, 0xb0, 0xb3, 0x21, 0xd7, 0x06, 0xb5, 0xb6, 0x80, 0xb0, 0x22, 0xa0,
-	0x87, 0xd0, 0x16, 0xd1, 0x2e, 0x77, 0x46, 0x7b, 0x7d, 0x7b, 0x79, 0x66, 0x28, 0xf2, 0x0a, 0x71,
-	0x11, 0xf5, 0xa6, 0xb8, 0xbd, 0x3c, 0x43, 0x14, 0x79, 0xf3, 0x01, 0xbf, 0x79, 0x85, 0x80, 0x5f,
-	0x18, 0xa6, 0xe8, 0xbd, 0xc3, 0xf4, 0x20, 0x17, 0xa6, 0xd7, 0xe5, 0xe6, 0x20, 0x25, 0x7f, 0xcc,
-	0x86, 0x29, 0xc9, 0x0c, 0x4e, 0xe7, 0x0f, 0x25, 0x58, 0x33, 0x89, 0x08, 0x41, 0xd5, 0xb8, 0xfe,
-	0xe4, 0x77, 0xf1, 0xd2, 0x28, 0xcf, 0x5f, 0x1a, 0x5b, 0x60, 0xfd, 0x58, 0x06, 0x92, 0xba, 0x6f,
-	0xd5, 0x40, 0x38, 0xd1, 0xa9, 0x4b, 0x3d, 0x97, 0x33, 0x5d, 0xfe, 0x34, 0x70, 0x06, 0x88, 0x95,
-	0x64, 0x59, 0xa0, 0x8a, 0x32, 0x75, 0xf3, 0xef, 0x40, 0xeb, 0x38, 0x62, 0xb4, 0x77, 0xc9, 0xfc,
-	0x11, 0x89, 0x92, 0x9a, 0xcc, 0x80, 0x9c, 0x9f, 0xc2, 0xc6, 0x31, 0x1b, 0xf6, 0x2e, 0x5d, 0x3a,
-	0x56, 0x67, 0x86, 0x3e, 0x03, 0x38, 0x66, 0x43, 0xe5, 0x1b, 0x9e, 0xae, 0x6b, 0x9a, 0xa9, 0xd9,
-	0xd8, 0x20, 0x8a, 0xf8, 0x11, 0x10, 0x99, 0xb0, 0xd7, 0xc4, 0xd3, 0x76, 0x18, 0x88, 0xf3, 0x33,
-	0x68, 0x0b, 0x07, 0x32, 0x67, 0xff, 0x1c, 0x5a, 0x02, 0xca, 0x4f, 0x6f, 0xba, 0x9c, 0x49, 0x46,
-	0x1f, 0xc9, 0xac, 0xa1, 0xaf, 0x3e, 0x43, 0x09, 0x81, 0x3a, 0x9f, 0xc3, 0xfa, 0x45, 0xcc, 0xe5,
-	0x72, 0xbf, 0x88, 0x49, 0xc4, 0x13, 0xee, 0xd2, 0x42, 0xee, 0xef, 0xc1, 0x46, 0xc2, 0x1d, 0x4d,
-	0x19, 0x8d, 0xc8, 0x6a, 0xf6, 0xe7, 0xb0, 0xfe, 0x2d, 0x31, 0x27, 0xdf, 0x02, 0xeb, 0x98, 0x0d,
-	0xd3, 0xe4, 0xa5, 0x06, 0xa8, 0x0b, 0xcd, 0x13, 0xe6, 0x7a, 0x2a, 0x7e, 0xca, 0xb2, 0x20, 0xb3,
-	0x33, 0x63, 0x06, 0xdc, 0xe5, 0x71, 0x84, 0x33, 0x16, 0xa1, 0x45, 0x32, 0xed, 0x55, 0xb4, 0x38,
-	0x03, 0xfb, 0x88, 0x04, 0x84, 0x93, 0x77, 0x2a, 0x72, 0x1b, 0xd6, 0x65, 0x2e, 0x71, 0x87, 0x81,
-	0x60, 0x8e, 0x74, 0xbd, 0x9f, 0x07, 0x9d, 0x73, 0xd8, 0x34, 0xe6, 0xd3, 0x1a, 0x74, 0xa0, 0x3e,
-	0x88, 0x47, 0x23, 0x12, 0x45, 0xba, 0x10, 0x4f, 0x86, 0xca, 0x51, 0x05, 0x7b, 0x8f, 0xc5, 0x94,
-	0xcb, 0x29, 0x2d, 0x6c, 0x42, 0xce, 0xbf, 0x4a, 0xd0, 0x3e, 0xf1, 0x23, 0x61, 0x51, 0x64, 0x28,
-	0xa8, 0xb2, 0x77, 0xc9, 0xcc, 0xde, 0x49, 0xae, 0x8d, 0xce, 0x69, 0x30, 0xd3, 0xda, 0x19, 0x88,
-	0xa0, 0x3f, 0xf3, 0x27, 0x24, 0x54, 0x74, 0xe5, 0xdd, 0x06, 0x92, 0xdf, 0xe9, 0xea, 0x3b, 0x77,
-	0x5a, 0xdc, 0x08, 0x72, 0x67, 0x92, 0xbc, 0xae, 0x47, 0xc2, 0x26, 0xc9, 0x70, 0xfe, 0xe2, 0x45,
-	0x44, 0xb8, 0x0c, 0x09, 0x0b, 0x9b, 0x90, 0xd4, 0x44, 0x0c, 0x4f, 0xfc, 0x89, 0xaf, 0x52, 0xb9,
-	0x85, 0x0d, 0xc4, 0xd9, 0x07, 0x3b, 0x33, 0xf9, 0x2a, 0xa7, 0x88, 0x95, 0x80, 0x9c, 0x62, 0xf5,
-	0x29, 0xee, 0x42, 0x4d, 0x59, 0xb2, 0xd4, 0x97, 0x34, 0xdd, 0xb9, 0x07, 0x9b, 0xc6, 0x9c, 0x5a,
-	0x8b, 0x8f, 0xa1, 0x2a, 0x80, 0x05, 0x51, 0x25, 0x71, 0xe7, 0x8e, 0x8c, 0x01, 0x09, 0x68, 0x35,
-	0xde, 0x25, 0x71, 0x17, 0xda, 0xa9, 0xc4, 0x15, 0x17, 0xf9, 0x4d, 0x09, 0x90, 0x72, 0x91, 0x45,
-	0x06, 0x7b, 0xa6, 0xc1, 0x9e, 0x38, 0x25, 0xc1, 0xd5, 0x3f, 0x4a, 0xfa, 0x6c, 0x35, 0x32, 0x36,
-	0xa2, 0xb2, 0x53, 0x59, 0xb5, 0x11, 0xe2, 0xb4, 0x2e, 0xc2, 0x98, 0x12, 0x75, 0x5a, 0x55, 0x75,
-	0x5a, 0x19, 0xe2, 0xec, 0xc3, 0xf5, 0x9c, 0x36, 0x99, 0xd3, 0x2b, 0x58, 0x28, 0x24, 0x56, 0x4e,
-	0x86, 0xce, 0x3e, 0xdc, 0x3c, 0x22, 0x9c, 0x8c, 0xf8, 0x80, 0xc7, 0xa3, 0x57, 0x45, 0x1b, 0x06,
-	0x3e, 0x1d, 0xa9, 0x6c, 0x6e, 0x61, 0x35, 0x70, 0xbe, 0x86, 0xce, 0xbc, 0x80, 0x5e, 0xc6, 0x81,
-	0xb5, 0x27, 0xfe, 0x5b, 0x22, 0x7d, 0xb2, 0xef, 0x45, 0x7a, 0xad, 0x1c, 0xe6, 0xfc, 0xbb, 0xac,
-	0x76, 0x74, 0x51, 0x71, 0xa4, 0x7c, 0xa4, 0xbc, 0xd8, 0x47, 0x2a, 0xab, 0x7d, 0x44, 0xe4, 0x04,
-	0xf5, 0x75, 0x4a, 0xa2, 0xc8, 0x1d, 0x27, 0xb7, 0x49, 0x1e, 0x14, 0x2a, 0x3e, 0x0b, 0xfd, 0xf1,
-	0x98, 0x84, 0x2a, 0x6a, 0xd5, 0xfd, 0x91, 0xc3, 0xc4, 0xcd, 0x23, 0xeb, 0x18, 0x11, 0x8f, 0x3a,
-	0x64, 0x32, 0x40, 0xec, 0xe5, 0x63, 0xea, 0x49, 0x9a, 0x8a, 0x96, 0x64, 0x28, 0xdb, 0x70, 0x97,
-	0x0e, 0x38, 0x9b, 0x76, 0x1a, 0xba, 0x0d, 0x57, 0x43, 0x51, 0xcc, 0xf5, 0x5c, 0x7a, 0xe1, 0xc6,
-	0x11, 0x91, 0x45, 0x4c, 0x03, 0xa7, 0x63, 0x11, 0xa2, 0x4f, 0xdd, 0xe8, 0x22, 0x64, 0xe3, 0x50,
-	0x24, 0x25, 0x90, 0x64, 0x13, 0x12, 0xd2, 0x29, 0x59, 0x54, 0x53, 0x65, 0x9c, 0x8e, 0xd1, 0x5d,
-	0x68, 0xe9, 0x7a, 0xe9, 0x84, 0x8d, 0x93, 0x0a, 0xb7, 0x6d, 0x16, 0x54, 0x27, 0x6c, 0x8c, 0x4d,
-	0x1e, 0xe7, 0xd7, 0x65, 0x68, 0xf5, 0x78, 0x18, 0xf4, 0xd8, 0x64, 0xe2, 0x52, 0x0f, 0x7d, 0x02,
-	0x95, 0xde, 0xc4, 0xd3, 0x0d, 0xf6, 0x7a, 0x52, 0x32, 0x48, 0x1a, 0x16, 0x94, 0xcc, 0x99, 0xcb,
-	0x8b, 0x9c, 0xd9, 0xd3, 0x75, 0xab, 0x1e, 0x89, 0x5d, 0x90, 0xdb, 0xd8, 0xf7, 0xf4, 0x09, 0x24,
-	0x43, 0x74, 0x0c, 0xeb, 0x38, 0xa6, 0x46, 0x95, 0x61, 0x49, 0x6d, 0x6f, 0xeb, 0x25, 0x33, 0x95,
-	0xba, 0x39, 0x36, 0xd5, 0x14, 0xe4, 0x45, 0xb7, 0xbf, 0x01, 0x34, 0xcf, 0xf4, 0x5e, 0x45, 0xfa,
-	0xff, 0xc3, 0x75, 0x63, 0xc9, 0xd4, 0x87, 0x6d, 0xa8, 0x9c, 0x46, 0xe3, 0x64, 0x8a, 0xd3, 0x68,
-	0xec, 0xfc, 0xbe, 0x04, 0xcd, 0x74, 0x2b, 0xd1, 0xed, 0xa4, 0x67, 0xd6, 0x29, 0x21, 0x5f, 0xbc,
-	0x26, 0xfd, 0xf4, 0x0f, 0x60, 0xad, 0x4f, 0xa7, 0x31, 0x4f, 0x7c, 0x31, 0xd7, 0xce, 0x2a, 0x1e,
-	0x4d, 0xc2, 0x39, 0x46, 0xd1, 0xcd, 0xaa, 0x26, 0x2c, 0x91, 0xac, 0x2c, 0x97, 0xcc, 0x73, 0x3a,
-	0xbf, 0x2d, 0x43, 0xfb, 0x98, 0x0d, 0xb5, 0x2b, 0xab, 0x02, 0x64, 0x71, 0xe2, 0x35, 0x2b, 0xfd,
-	0xf2, 0x3b, 0x2a, 0xfd, 0x1b, 0x50, 0xc3, 0x31, 0x3d, 0x63, 0x6f, 0xf4, 0x2d, 0xa5, 0x47, 0x22,
-	0x48, 0x70, 0x4c, 0xb5, 0x07, 0xa8, 0x83, 0xce, 0x00, 0x74, 0xb6, 0xf8, 0xa8, 0x77, 0xd3, 0xbb,
-	0xc2, 0xd4, 0xf2, 0x7f, 0x72, 0xdc, 0x7f, 0x2d, 0xc1, 0x9a, 0xd9, 0xe4, 0xae, 0x28, 0x04, 0x3a,
-	0x50, 0xc7, 0xee, 0x9b, 0x47, 0xcc, 0x53, 0x37, 0xf7, 0x1a, 0x4e, 0x86, 0x22, 0xfd, 0x0e, 0x78,
-	0xe8, 0xd3, 0xb1, 0x24, 0x2a, 0xbf, 0x37, 0x10, 0x11, 0xa9, 0xa2, 0xdc, 0x94, 0xd4, 0xaa, 0x14,
-	0x4d, 0xc7, 0x22, 0xce, 0x1f, 0x87, 0x21, 0x0b, 0x15, 0xbb, 0x4e, 0x3c, 0x26, 0x24, 0xd6, 0xed,
-	0x8f, 0x29, 0x0b, 0x89, 0x27, 0xb3, 0x4e, 0x03, 0x27, 0x43, 0x59, 0xed, 0x66, 0x09, 0x47, 0x7e,
-	0x3b, 0x7f, 0xa9, 0xc2, 0x4d, 0xd3, 0xa0, 0xc2, 0x5b, 0x54, 0x3f, 0xca, 0x5b, 0x97, 0x01, 0x68,
-	0x0f, 0xec, 0x4c, 0x67, 0x4c, 0xc6, 0xe4, 0xed, 0x54, 0xef, 0xd7, 0x1c, 0x8e, 0xbe, 0x82, 0x0f,
-	0x33, 0x6c, 0xe0, 0xff, 0x92, 0x7c, 0x1b, 0x12, 0x97, 0x93, 0xf0, 0xd9, 0xa5, 0xab, 0x1e, 0xf1,
-	0x2c, 0xbc, 0x9c, 0x61, 0x5e, 0x7a, 0x30, 0x71, 0x83, 0x40, 0x4b, 0x57, 0x17, 0x49, 0x1b, 0x0c,
-	0xa2, 0x01, 0x4c, 0x76, 0x4f, 0x6b, 0xa9, 0x36, 0xad, 0x80, 0x9a, 0x7c, 0x4f, 0xdd, 0xe8, 0x47,
-	0x64, 0xa6, 0x4b, 0xff, 0x02, 0x8a, 0xee, 0xc3, 0xcd, 0x04, 0x29, 0x5a, 0xa2, 0x36, 0x76, 0x19,
-	0xb9, 0x28, 0x69, 0x5a, 0xd1, 0x98, 0x97, 0x34, 0x6d, 0xd0, 0xe5, 0x95, 0x38, 0xb1, 0x6f, 0xb9,
-	0x6e, 0x60, 0x0d, 0xc4, 0xa4, 0x9f, 0x70, 0x99, 0xfc, 0x0d, 0xfa, 0x89, 0xe8, 0x20, 0x36, 0x0d,
-	0x17, 0xd1, 0xdb, 0xd0, 0x92, 0xe6, 0xcd, 0x13, 0xc4, 0x1d, 0xf8, 0x24, 0x24, 0x24, 0x7b, 0x69,
-	0x54, 0xcf, 0x77, 0x79, 0x50, 0x84, 0xcd, 0x19, 0xe3, 0xba, 0x45, 0x16, 0x9f, 0xce, 0xdf, 0xcb,
-	0xb0, 0x9e, 0xcb, 0x2d, 0x68, 0x0f, 0x2c, 0x19, 0x9b, 0x3a, 0xcb, 0x6d, 0x75, 0xd5, 0xcf, 0x28,
-	0xdd, 0xe4, 0x67, 0x94, 0xee, 0x21, 0x9d, 0x61, 0xc5, 0x22, 0x5a, 0x6a, 0xd9, 0x13, 0xeb, 0x57,
-	0x6c, 0xe8, 0xca, 0x1f, 0x3d, 0x04, 0x84, 0x15, 0x21, 0x7b, 0xe7, 0xae, 0x2c, 0x79, 0xe7, 0xfe,
-	0x04, 0x2c, 0xcc, 0x02, 0xd9, 0xb4, 0x65, 0x0c, 0x02, 0xc1, 0x0a, 0x47, 0x5d, 0x80, 0x9f, 0xb0,
-	0xf0, 0x55, 0x34, 0x75, 0x45, 0x6b, 0xa7, 0x5e, 0x91, 0x36, 0x24, 0x57, 0x0a, 0x63, 0x83, 0x03,
-	0xdd, 0x82, 0xea, 0xe1, 0x28, 0x48, 0x5e, 0x18, 0x1a, 0x92, 0xf3, 0xb0, 0x77, 0x82, 0x25, 0x8a,
-	0xee, 0x00, 0x1c, 0xaa, 0x1f, 0x4b, 0x7c, 0x22, 0xea, 0x6b, 0xc1, 0x63, 0x77, 0x93, 0xdf, 0x4f,
-	0xba, 0xe7, 0xc3, 0x97, 0x64, 0xc4, 0xb1, 0xc1, 0x83, 0xbe, 0x80, 0x96, 0x0a, 0x33, 0xf9, 0xd2,
-	0xa4, 0xd3, 0x19, 0x9a, 0x7f, 0x4f, 0xc3, 0x26, 0xdb, 0xde, 0x43, 0x68, 0x17, 0x7e, 0xa6, 0x40,
-	0x0d, 0xa8, 0x0a, 0x93, 0xed, 0x6b, 0xe2, 0x4b, 0xd8, 0x66, 0x97, 0xd0, 0x3a, 0x34, 0x53, 0xd5,
-	0xed, 0x32, 0xaa, 0x43, 0xe5, 0x70, 0x14, 0xd8, 0x95, 0xbd, 0x07, 0xf0, 0xc1, 0xc2, 0x97, 0x6e,
-	0xd4, 0x86, 0x96, 0xae, 0xd2, 0x04, 0xc1, 0xbe, 0x26, 0x00, 0xcd, 0x29, 0x27, 0x2f, 0xed, 0xfd,
-	0x4a, 0xf9, 0x95, 0xae, 0x8d, 0x5a, 0x50, 0x7f, 0x4e, 0x5f, 0x51, 0xf6, 0x86, 0xaa, 0x75, 0xfb,
-	0x9e, 0x5c, 0xb7, 0x05, 0x75, 0x1c, 0x53, 0xea, 0xd3, 0xb1, 0x5d, 0x46, 0x6b, 0xd0, 0x78, 0xe2,
-	0x53, 0x3f, 0xba, 0x24, 0x9e, 0x5d, 0x11, 0x13, 0xf6, 0x29, 0x27, 0x61, 0x18, 0x4f, 0x39, 0xf1,
-	0xec, 0x2a, 0x02, 0xa8, 0xc9, 0x7a, 0xc5, 0xb3, 0x2d, 0xa9, 0x20, 0x9d, 0xd9, 0x35, 0xd4, 0x04,
-	0x4b, 0x3a, 0xa1, 0x5d, 0x17, 0xf4, 0xef, 0x62, 0x12, 0x13, 0xcf, 0x6e, 0xec, 0x8d, 0xa1, 0x9e,
-	0x14, 0x17, 0x0d, 0xa8, 0x9e, 0x31, 0x4a, 0xec, 0x6b, 0x82, 0x57, 0x4e, 0x60, 0x97, 0x04, 0x2f,
-	0x26, 0x51, 0x3c, 0x11, 0xc6, 0x36, 0xa0, 0x2a, 0x4a, 0x24, 0xbb, 0x22, 0x50, 0x55, 0x95, 0xda,
-	0x55, 0xad, 0xd9, 0x39, 0x1d, 0x11, 0xdb, 0x12, 0x9a, 0x25, 0x4f, 0x5f, 0x76, 0x4d, 0xb0, 0x1d,
-	0xaa, 0xef, 0xfa, 0xc1, 0xdf, 0xaa, 0xb2, 0x27, 0x1f, 0xa8, 0x17, 0x7e, 0xf4, 0x25, 0xd4, 0x54,
-	0xd7, 0x8b, 0xf4, 0x7d, 0x99, 0xeb, 0x98, 0xb7, 0xb7, 0xf2, 0xa0, 0xba, 0xf0, 0x9d, 0x6b, 0x42,
-	0x4c, 0xb5, 0xa9, 0x89, 0x58, 0xae, 0x17, 0x4e, 0xc4, 0xf2, 0x9d, 0xac, 0x73, 0x0d, 0x7d, 0x0d,
-	0xcd, 0xb4, 0xbd, 0x44, 0x37, 0x14, 0x53, 0xb1, 0x7f, 0xdd, 0xbe, 0x39, 0x87, 0xa7, 0xf2, 0x0f,
-	0xa1, 0x91, 0x74, 0x56, 0x48, 0xff, 0xa6, 0x55, 0x68, 0x2e, 0xb7, 0x6f, 0x14, 0xe1, 0x44, 0xf8,
-	0x4e, 0x09, 0xdd, 0x87, 0xba, 0x6e, 0x56, 0x50, 0x66, 0x98, 0xd1, 0xed, 0x6c, 0x7f, 0x50, 0x40,
-	0xd3, 0x85, 0x1f, 0xc9, 0xa7, 0x04, 0xe5, 0x1e, 0x21, 0x71, 0x27, 0xef, 0x29, 0xbf, 0x5b, 0xba,
-	0x53, 0x42, 0xdf, 0x40, 0x33, 0xed, 0xc8, 0x90, 0xa1, 0xa6, 0xd9, 0x41, 0x24, 0xc6, 0xcf, 0xb5,
-	0x6e, 0x52, 0xff, 0xa3, 0xa4, 0xdd, 0x56, 0x73, 0x74, 0xcc, 0x8d, 0xca, 0xcd, 0xf2, 0xe1, 0x02,
-	0x4a, 0x6a, 0xcb, 0x77, 0x60, 0x17, 0xdb, 0x11, 0xf4, 0x7f, 0x89, 0xc0, 0xc2, 0xbe, 0x66, 0xfb,
-	0xe3, 0x65, 0x64, 0x35, 0xe9, 0xc1, 0x53, 0xd5, 0x33, 0x27, 0x4e, 0xf5, 0x40, 0x38, 0x33, 0xe5,
-	0x21, 0x0b, 0xd0, 0xe6, 0x5c, 0xa5, 0x9a, 0x28, 0xb7, 0xa0, 0x92, 0x74, 0xae, 0x0d, 0x6b, 0x32,
-	0x5b, 0xde, 0xfb, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xec, 0x9c, 0x1d, 0x48, 0x96, 0x1e, 0x00,
-	0x00,
+	// 2767 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcd, 0x6e, 0x1b, 0xc9,
+	0xf1, 0x37, 0xbf, 0x44, 0xb2, 0xa8, 0x8f, 0x51, 0x5b, 0x6b, 0x8f, 0xb5, 0xfb, 0xdf, 0x15, 0x06,
+	0xc6, 0xfe, 0xb5, 0xc2, 0x86, 0xb2, 0xe5, 0xdd, 0xc4, 0x0e, 0xd6, 0x8b, 0x95, 0x29, 0x7f, 0x50,
+	0xd1, 0xd7, 0x36, 0xed, 0xe4, 0x90, 0x5c, 0x86, 0x9c, 0x36, 0x35, 0xd6, 0x70, 0x9a, 0x99, 0xe9,
+	0xb1, 0xcd, 0xcd, 0x35, 0xc7, 0x20, 0x2f, 0x90, 0x5b, 0x80, 0x9c, 0x02, 0x04, 0xc8, 0x35, 0x97,
+	0x00, 0xb9, 0xe5, 0x92, 0x43, 0x80, 0x3c, 0x42, 0x1e, 0x20, 0x4f, 0x90, 0xa0, 0xba, 0x7b, 0x66,
+	0x7a, 0x48, 0x8a, 0x96, 0x81, 0x20, 0x07, 0x12, 0xd3, 0xbf, 0xaa, 0xea, 0xae, 0xaa, 0xae, 0xae,
+	0xae, 0x9a, 0x01, 0x78, 0xc5, 0xfb, 0x71, 0x7b, 0x1c, 0x71, 0xc1, 0x49, 0x15, 0x9f, 0x37, 0x6f,
+	0x0d, 0x39, 0x1f, 0x06, 0x6c, 0x57, 0x62, 0xfd, 0xe4, 0xe5, 0xae, 0x1b, 0x4e, 0x14, 0xc3, 0xe6,
+	0xfd, 0xa1, 0x2f, 0xce, 0x93, 0x7e, 0x7b, 0xc0, 0x47, 0xbb, 0xe3, 0x89, 0xe7, 0xf3, 0xdd, 0x01,
+	0x0b, 0x82, 0x78, 0x77, 0xc0, 0x47, 0x23, 0x1e, 0xee, 0xc6, 0x2c, 0x7a, 0xed, 0x0f, 0xb4, 0xa4,
+	0x06, 0xb5, 0xe4, 0xbd, 0xc5, 0x92, 0x4a, 0x42, 0x44, 0x8c, 0xc9, 0x3f, 0x2d, 0x74, 0xf7, 0x2a,
+	0x42, 0xbe, 0x37, 0xc2, 0x9f, 0x16, 0xd9, 0xbf, 0x8a, 0x88, 0x3b, 0x10, 0xfe, 0x6b, 0x5f, 0x4c,
+	0xb2, 0x87, 0x58, 0x44, 0xcc, 0xd5, 0x53, 0x38, 0x7f, 0x2c, 0xc1, 0xca, 0x09, 0xf7, 0x58, 0xdc,
+	0x63, 0x01, 0x1b, 0x08, 0x1e, 0x11, 0x0b, 0x2a, 0xfb, 0x41, 0x60, 0x97, 0xb6, 0x4a, 0xdb, 0x0d,
+	0x8a, 0x8f, 0xe4, 0x06, 0x2c, 0x9d, 0xb9, 0xe2, 0x9c, 0xc5, 0x76, 0x79, 0xab, 0xb2, 0xdd, 0xa4,
+	0x7a, 0x44, 0x6e, 0x43, 0xed, 0xdb, 0x84, 0x45, 0x13, 0xbb, 0xba, 0x55, 0xda, 0x6e, 0xed, 0xad,
+	0xb6, 0xb5, 0x4b, 0xda, 0x12, 0xa5, 0x8a, 0x48, 0x6c, 0xa8, 0x77, 0x78, 0x80, 0x93, 0xdb, 0x35,
+	0x39, 0x67, 0x3a, 0x24, 0x1b, 0x50, 0x3b, 0x72, 0xfb, 0x2c, 0xb0, 0x97, 0xb6, 0x4a, 0xdb, 0x4d,
+	0xaa, 0x06, 0x64, 0x0b, 0x5a, 0x07, 0x2c, 0x1e, 0x44, 0xfe, 0x58, 0xf8, 0x3c, 0xb4, 0xeb, 0x92,
+	0x66, 0x42, 0xce, 0x5f, 0x4a, 0xd0, 0xea, 0x7a, 0xa3, 0x4c, 0xe3, 0xcf, 0xa0, 0xfa, 0x7c, 0x32,
+	0x66, 0x52, 0xe5, 0xd5, 0xbd, 0x0f, 0xda, 0x72, 0x93, 0x0d, 0x06, 0x24, 0x52, 0xc9, 0x92, 0x1a,
+	0x57, 0xce, 0x8d, 0xcb, 0x8c, 0xa8, 0x5c, 0xd1, 0x88, 0xea, 0x25, 0x46, 0xd4, 0x16, 0x18, 0xb1,
+	0x34, 0x6b, 0xc4, 0x9f, 0x4a, 0xb0, 0xf2, 0x22, 0x66, 0xd1, 0x22, 0xc7, 0x7f, 0x02, 0x35, 0xc9,
+	0x22, 0xfd, 0xde, 0xda, 0x6b, 0xb6, 0x71, 0xeb, 0x11, 0xa1, 0x0a, 0x7f, 0x7f, 0xe5, 0xff, 0x4b,
+	0x3b, 0x10, 0x01, 0xd9, 0x1f, 0xe0, 0xd3, 0x69, 0x22, 0xc6, 0x89, 0x78, 0xe2, 0x07, 0x82, 0x45,
+	0xb9, 0x36, 0xa5, 0x45, 0xda, 0x64, 0x6b, 0x96, 0x17, 0xac, 0x59, 0x99, 0x5d, 0xf3, 0xb7, 0x25,
+	0x58, 0xef, 0xf0, 0x50, 0xb0, 0xb7, 0xe2, 0x98, 0x09, 0x57, 0xaf, 0xb9, 0x5b, 0xd8, 0xfb, 0x0f,
+	0xd5, 0xde, 0xcf, 0xb0, 0x19, 0x11, 0x90, 0x29, 0x59, 0xbe, 0x92, 0x92, 0x95, 0x05, 0x4a, 0x56,
+	0x67, 0x95, 0x7c, 0x05, 0x37, 0x8c, 0xc5, 0x7b, 0x7e, 0x38, 0x0c, 0x98, 0x9a, 0xf1, 0x23, 0x68,
+	0x3e, 0xf1, 0x59, 0xe0, 0x9d, 0xb8, 0x23, 0xa5, 0x6d, 0x93, 0xe6, 0x00, 0xd9, 0x83, 0x66, 0x87,
+	0x87, 0x9e, 0x2f, 0xe7, 0x55, 0x9a, 0x6d, 0xc8, 0xdd, 0x3e, 0xe3, 0x81, 0x3f, 0x98, 0x64, 0x34,
+	0x9a, 0xb3, 0x39, 0xbf, 0x2e, 0x41, 0xa3, 0x37, 0x38, 0x67, 0x5e, 0x12, 0x30, 0xb2, 0x0d, 0x6b,
+	0xdd, 0x98, 0xdf, 0xff, 0xfe, 0x9d, 0xbb, 0x29, 0xa4, 0x17, 0x99, 0x86, 0x0d, 0xce, 0x63, 0x3f,
+	0x3c, 0x60, 0x81, 0x70, 0xb5, 0x91, 0xd3, 0x30, 0x21, 0x50, 0xed, 0x44, 0x99, 0x9d, 0xf2, 0x99,
+	0x6c, 0x42, 0xe3, 0xb9, 0x3f, 0x62, 0xdf, 0xf1, 0x90, 0xe9, 0x88, 0xcf, 0xc6, 0xce, 0xef, 0xeb,
+	0xb0, 0xa4, 0xc2, 0x82, 0xac, 0x42, 0xb9, 0x7b, 0xa0, 0x35, 0x28, 0x77, 0x0f, 0x72, 0x7f, 0xae,
+	0x2c, 0xf0, 0xe7, 0xea, 0x8c, 0x3f, 0x31, 0xf5, 0x3c, 0x9a, 0x8c, 0xdd, 0x38, 0xb6, 0xd7, 0x64,
+	0xe4, 0xea, 0x11, 0xf9, 0x18, 0xe0, 0x51, 0xc4, 0xdc, 0x8b, 0xfd, 0x97, 0x82, 0x45, 0xb6, 0x25,
+	0x69, 0x06, 0x42, 0x1e, 0x40, 0x8b, 0x32, 0x11, 0x4d, 0x94, 0xfb, 0xec, 0x75, 0xe9, 0xd1, 0x9b,
+	0x2a, 0x3a, 0x94, 0x8a, 0x06, 0x99, 0x9a, 0xbc, 0x78, 0x5a, 0xd0, 0x22, 0x9e, 0x08, 0x9b, 0x48,
+	0x85, 0xd2, 0x21, 0x79, 0x30, 0x95, 0x2a, 0xf5, 0x46, 0x5d, 0x57, 0xd3, 0x16, 0x48, 0xb4, 0xc8,
+	0x89, 0xa2, 0x85, 0xc3, 0x6e, 0x57, 0x4c, 0xd1, 0x02, 0x89, 0x16, 0x39, 0xc9, 0x97, 0xd0, 0x92,
+	0x73, 0xa9, 0x48, 0xb6, 0xab, 0xa6, 0x60, 0x71, 0x4d, 0x93, 0x0f, 0xc5, 0xe4, 0x3c, 0x5a, 0xac,
+	0x76, 0xf9, 0x7a, 0x26, 0x1f, 0xb9, 0x57, 0x48, 0xad, 0x76, 0x53, 0x8a, 0xad, 0xcf, 0xa4, 0x54,
+	0x6a, 0x72, 0x91, 0x5d, 0x68, 0x76, 0xbd, 0x91, 0x5e, 0x09, 0x2e, 0x13, 0xc9, 0x79, 0xc8, 0xb3,
+	0x79, 0xf9, 0x43, 0x26, 0xa1, 0xd6, 0x9e, 0x6d, 0xee, 0x92, 0x49, 0xa7, 0x73, 0x64, 0xc8, 0xe3,
+	0x39, 0x49, 0xc1, 0x6e, 0x99, 0xdb, 0x3d, 0x43, 0xa6, 0xb3, 0x12, 0xe4, 0x2b, 0x80, 0x33, 0x37,
+	0x72, 0x47, 0x4c, 0x60, 0xba, 0xad, 0xcb, 0x74, 0xfb, 0x91, 0xa9, 0x48, 0x3b, 0x27, 0x3f, 0x0e,
+	0x45, 0x34, 0xa1, 0x06, 0x3f, 0xf9, 0x02, 0x56, 0x3b, 0xe7, 0xae, 0x1f, 0x32, 0x4f, 0x31, 0xc7,
+	0x76, 0x43, 0xce, 0xb0, 0x5c, 0x08, 0xb8, 0x29, 0x1e, 0xf2, 0x35, 0x5c, 0x7f, 0xe2, 0xfa, 0x01,
+	0xf3, 0x94, 0x0e, 0xa9, 0xe8, 0xf2, 0x1c, 0xd1, 0x79, 0x8c, 0x9b, 0x0f, 0x61, 0x6d, 0x4a, 0x29,
+	0xbc, 0x42, 0x2e, 0xd8, 0x44, 0x9f, 0x3b, 0x7c, 0xc4, 0x83, 0xf7, 0xda, 0x0d, 0x12, 0x96, 0x66,
+	0x5b, 0x39, 0xf8, 0x61, 0xf9, 0x7e, 0xc9, 0xf9, 0x5d, 0x19, 0xd6, 0x67, 0x8e, 0x02, 0x1e, 0xc9,
+	0x63, 0xf7, 0xed, 0xbe, 0x10, 0x6c, 0x34, 0x16, 0xb1, 0x9c, 0xa9, 0x46, 0x4d, 0x88, 0x7c, 0x0a,
+	0xab, 0xdd, 0xd0, 0x17, 0xbe, 0x1b, 0x3c, 0x72, 0x07, 0x17, 0xfc, 0xe5, 0x4b, 0x3d, 0xf5, 0x14,
+	0x8a, 0x47, 0xf4, 0xd8, 0x7d, 0x9b, 0xf2, 0xa8, 0x14, 0x63, 0x20, 0xe4, 0x73, 0x58, 0xd7, 0x8f,
+	0xc7, 0x49, 0x20, 0xfc, 0x71, 0xe0, 0xeb, 0xe8, 0x2e, 0xd3, 0x59, 0x02, 0xae, 0x2a, 0xd5, 0x74,
+	0xfb, 0x01, 0xeb, 0x60, 0x98, 0xdb, 0xb5, 0xad, 0xca, 0x76, 0x8d, 0x4e, 0xa1, 0x98, 0xdd, 0x32,
+	0xe4, 0x71, 0x14, 0xf1, 0x28, 0xb6, 0x97, 0x64, 0xd1, 0x32, 0x0d, 0x67, 0x33, 0x9e, 0x86, 0xe9,
+	0x71, 0xaf, 0xcb, 0x34, 0x32, 0x85, 0x3a, 0xff, 0xac, 0x41, 0xe5, 0x90, 0xf7, 0x2f, 0x4f, 0x69,
+	0x85, 0x7b, 0x6c, 0x03, 0x6a, 0xa7, 0x6f, 0x42, 0x16, 0xa5, 0x17, 0x87, 0x1c, 0x60, 0xd6, 0xec,
+	0x86, 0xb2, 0xfe, 0x62, 0xba, 0x7e, 0xc8, 0xc6, 0x98, 0xe2, 0x3a, 0x49, 0x2c, 0xf8, 0xc8, 0xde,
+	0x50, 0x29, 0x4e, 0x8d, 0xf0, 0xc2, 0x38, 0x72, 0xc3, 0x61, 0xe2, 0x0e, 0x59, 0x6c, 0x83, 0xb4,
+	0x21, 0x07, 0xd0, 0xbb, 0x8f, 0x5f, 0xb3, 0x50, 0xe0, 0xed, 0xa1, 0x7c, 0xd1, 0xa4, 0x06, 0x42,
+	0x76, 0xf2, 0xbb, 0x41, 0x9f, 0xab, 0x55, 0x15, 0x51, 0x29, 0x4a, 0x33, 0x3a, 0xae, 0xb4, 0x9f,
+	0x08, 0xde, 0x13, 0x6e, 0x94, 0x3a, 0x21, 0x07, 0x52, 0x6a, 0x27, 0x60, 0x6e, 0x68, 0xb7, 0x72,
+	0xaa, 0x04, 0xc8, 0xa7, 0x50, 0x5f, 0x14, 0xf3, 0x29, 0x11, 0xbd, 0x7d, 0xec, 0xbe, 0xed, 0xf0,
+	0x70, 0x90, 0x44, 0x11, 0x0b, 0x07, 0x13, 0x99, 0x5a, 0x6a, 0x74, 0x0a, 0xc5, 0xa8, 0x78, 0xee,
+	0xc6, 0x17, 0x71, 0xcf, 0x0f, 0x58, 0x28, 0x5e, 0x8c, 0x3d, 0x57, 0x30, 0x7b, 0x59, 0xae, 0x3a,
+	0x4b, 0x20, 0x5b, 0x50, 0x93, 0xa0, 0xbd, 0x2a, 0xd7, 0x06, 0xb5, 0x36, 0x42, 0x54, 0x11, 0xc8,
+	0x43, 0x58, 0xc3, 0xac, 0x28, 0x3d, 0xa3, 0xb3, 0xc3, 0xda, 0xe5, 0x19, 0x74, 0x9a, 0x17, 0xc5,
+	0x31, 0x3b, 0x9a, 0xe2, 0xd6, 0xe5, 0x99, 0x74, 0x9a, 0xb7, 0x98, 0x18, 0xd7, 0xaf, 0x90, 0x18,
+	0xe7, 0xa6, 0x33, 0xf2, 0xde, 0xe9, 0x6c, 0xaf, 0x90, 0xce, 0xae, 0x4b, 0xe7, 0x10, 0x25, 0x7f,
+	0xc8, 0xfb, 0x19, 0xc9, 0x4c, 0x62, 0xce, 0x1f, 0x4a, 0xb0, 0x6c, 0x12, 0xf1, 0xfa, 0x37, 0x8a,
+	0x15, 0xf9, 0x3c, 0x7d, 0x63, 0x97, 0x67, 0x6f, 0xec, 0x0d, 0xa8, 0xfd, 0x58, 0x26, 0x1c, 0x55,
+	0x35, 0xa8, 0x01, 0x06, 0xd1, 0xb1, 0x1b, 0x7a, 0xae, 0xe0, 0xba, 0x58, 0x6d, 0xd0, 0x1c, 0xc0,
+	0x95, 0x64, 0x11, 0xa7, 0x0a, 0x0a, 0xf9, 0x8c, 0x2b, 0x1d, 0xc6, 0x3c, 0xec, 0x9c, 0x73, 0x7f,
+	0xc0, 0xe2, 0xb4, 0x82, 0x36, 0x20, 0xe7, 0xa7, 0xb0, 0x7a, 0xc8, 0xfb, 0x9d, 0x73, 0x37, 0x1c,
+	0xaa, 0x3d, 0x23, 0x9f, 0x01, 0x1c, 0xf2, 0xbe, 0x8a, 0x0d, 0x4f, 0x57, 0xa1, 0xcd, 0xcc, 0x6c,
+	0x6a, 0x10, 0xf1, 0xfc, 0x20, 0xc4, 0x46, 0xfc, 0x35, 0xf3, 0xb4, 0x1d, 0x06, 0xe2, 0xfc, 0x0c,
+	0xd6, 0x30, 0x80, 0xcc, 0xd9, 0x3f, 0x87, 0x16, 0x42, 0xc5, 0xe9, 0xcd, 0x90, 0x33, 0xc9, 0xe4,
+	0x43, 0x99, 0x35, 0xec, 0xf2, 0xb4, 0x12, 0x88, 0x3a, 0x9f, 0xc3, 0xca, 0x59, 0x22, 0xe4, 0x72,
+	0x3f, 0x4f, 0x58, 0x2c, 0x52, 0xee, 0xd2, 0x5c, 0xee, 0xef, 0xc1, 0x6a, 0xca, 0x1d, 0x8f, 0x79,
+	0x18, 0xb3, 0xc5, 0xec, 0x2f, 0x60, 0xe5, 0x29, 0x33, 0x27, 0xdf, 0x80, 0xda, 0x21, 0xef, 0x67,
+	0xc9, 0x4b, 0x0d, 0x48, 0x1b, 0x9a, 0x47, 0xdc, 0xf5, 0xd4, 0xf9, 0x29, 0xcb, 0xf2, 0xd9, 0xca,
+	0x8d, 0xe9, 0x09, 0x57, 0x24, 0x31, 0xcd, 0x59, 0x50, 0x8b, 0xa7, 0xec, 0xea, 0x5a, 0x9c, 0x80,
+	0x75, 0xc0, 0x02, 0x26, 0xd8, 0x3b, 0x15, 0xb9, 0x0d, 0x2b, 0x32, 0x97, 0x60, 0x6e, 0x3e, 0xe4,
+	0xfd, 0x58, 0x77, 0x67, 0x45, 0xd0, 0x39, 0x85, 0x75, 0x63, 0x3e, 0xad, 0x81, 0x0d, 0xf5, 0x5e,
+	0x32, 0x18, 0xb0, 0x38, 0xd6, 0x6d, 0x53, 0x3a, 0x54, 0x81, 0x8a, 0xec, 0x1d, 0x9e, 0x84, 0x42,
+	0x4e, 0x59, 0xa3, 0x26, 0xe4, 0xfc, 0xab, 0x04, 0x6b, 0x47, 0x7e, 0x8c, 0x16, 0xc5, 0x86, 0x82,
+	0x2a, 0x7b, 0x97, 0xcc, 0xec, 0x9d, 0xe6, 0xda, 0xf8, 0x34, 0x0c, 0x26, 0x5a, 0x3b, 0x03, 0x41,
+	0x3a, 0x5e, 0x16, 0x91, 0xa2, 0xab, 0xe8, 0x36, 0x90, 0xa2, 0xa7, 0xab, 0xef, 0xf4, 0x34, 0xde,
+	0x08, 0xd2, 0x33, 0x69, 0x5e, 0xd7, 0x23, 0xb4, 0x49, 0x32, 0x9c, 0xbe, 0x7c, 0x19, 0x33, 0x21,
+	0x8f, 0x44, 0x8d, 0x9a, 0x90, 0xd4, 0x04, 0x87, 0x47, 0xfe, 0xc8, 0x57, 0xa9, 0xbc, 0x46, 0x0d,
+	0xc4, 0xd9, 0x05, 0x2b, 0x37, 0xf9, 0x2a, 0xbb, 0x48, 0x95, 0x80, 0x9c, 0x62, 0xf1, 0x2e, 0x6e,
+	0xc3, 0x92, 0xb2, 0xe4, 0xd2, 0x58, 0xd2, 0x74, 0xe7, 0x1e, 0xac, 0x1b, 0x73, 0x6a, 0x2d, 0x3e,
+	0x86, 0x2a, 0x02, 0x73, 0x4e, 0x95, 0xc4, 0x9d, 0x3b, 0xf2, 0x0c, 0x48, 0x40, 0xab, 0xf1, 0x2e,
+	0x89, 0xbb, 0xb0, 0x96, 0x49, 0x5c, 0x71, 0x91, 0x5f, 0x95, 0x80, 0xa8, 0x10, 0x99, 0x67, 0xb0,
+	0x67, 0x1a, 0xec, 0xe1, 0x2e, 0x21, 0x57, 0xf7, 0x20, 0x7d, 0x2b, 0xa2, 0x46, 0x86, 0x23, 0x2a,
+	0x5b, 0x95, 0x45, 0x8e, 0xc0, 0xdd, 0x3a, 0x8b, 0x92, 0x90, 0xa9, 0xdd, 0xaa, 0xaa, 0xdd, 0xca,
+	0x11, 0x67, 0x17, 0xae, 0x17, 0xb4, 0xc9, 0x83, 0x5e, 0xc1, 0xa8, 0x10, 0xae, 0x9c, 0x0e, 0x9d,
+	0x5d, 0xb8, 0x79, 0xc0, 0x04, 0x1b, 0x88, 0x9e, 0x48, 0x06, 0x17, 0xd3, 0x36, 0xf4, 0xfc, 0x70,
+	0xc0, 0x74, 0x45, 0xa7, 0x06, 0xce, 0xd7, 0x60, 0xcf, 0x0a, 0xe8, 0x65, 0x1c, 0x58, 0x7e, 0xe2,
+	0xbf, 0x65, 0x32, 0x26, 0xbb, 0x5e, 0xac, 0xd7, 0x2a, 0x60, 0xce, 0xbf, 0xcb, 0xca, 0xa3, 0xf3,
+	0x8a, 0x23, 0x15, 0x23, 0xe5, 0xf9, 0x31, 0x52, 0x59, 0x1c, 0x23, 0x98, 0x13, 0xd4, 0xd3, 0x31,
+	0x8b, 0x63, 0x77, 0x98, 0xde, 0x26, 0x45, 0x10, 0x55, 0x7c, 0x1e, 0xf9, 0xc3, 0x21, 0x8b, 0xd4,
+	0xa9, 0x55, 0xf7, 0x47, 0x01, 0xc3, 0x9b, 0x47, 0xd6, 0x31, 0x78, 0x1e, 0xf5, 0x91, 0xc9, 0x01,
+	0xf4, 0xe5, 0xe3, 0xd0, 0x93, 0x34, 0x75, 0x5a, 0xd2, 0x21, 0x52, 0x3a, 0x6e, 0xd8, 0x13, 0x7c,
+	0x6c, 0x37, 0xf4, 0x4b, 0x13, 0x35, 0xc4, 0x62, 0xae, 0xe3, 0x86, 0x67, 0x6e, 0x12, 0x33, 0x59,
+	0xc4, 0x34, 0x68, 0x36, 0xc6, 0x23, 0xfa, 0xcc, 0x8d, 0xcf, 0x22, 0x3e, 0x8c, 0x30, 0x29, 0x81,
+	0x24, 0x9b, 0x10, 0x4a, 0x67, 0xe4, 0x96, 0xac, 0x76, 0xb3, 0x31, 0xb9, 0x0b, 0x2d, 0x5d, 0x2f,
+	0x1d, 0xf1, 0x61, 0xda, 0x09, 0xac, 0x99, 0x05, 0xd5, 0x11, 0x1f, 0x52, 0x93, 0xc7, 0xf9, 0x65,
+	0x19, 0x5a, 0x1d, 0x11, 0x05, 0x1d, 0x3e, 0x1a, 0xb9, 0xa1, 0x47, 0x3e, 0x81, 0x4a, 0x67, 0xe4,
+	0xe9, 0xd7, 0x21, 0x2b, 0x69, 0xc9, 0x20, 0x69, 0x14, 0x29, 0x79, 0x30, 0x97, 0xe7, 0x05, 0xb3,
+	0xa7, 0xeb, 0x56, 0x3d, 0x42, 0x2f, 0x48, 0x37, 0x76, 0x3d, 0xbd, 0x03, 0xe9, 0x90, 0x1c, 0xc2,
+	0x0a, 0x4d, 0x42, 0xa3, 0xca, 0xa8, 0x49, 0x6d, 0x6f, 0xeb, 0x25, 0x73, 0x95, 0xda, 0x05, 0x36,
+	0xd5, 0x3c, 0x15, 0x45, 0x37, 0xbf, 0x01, 0x32, 0xcb, 0xf4, 0x5e, 0xcd, 0xcc, 0xff, 0xc3, 0x75,
+	0x63, 0xc9, 0x2c, 0x86, 0x2d, 0xa8, 0x1c, 0xc7, 0xc3, 0x74, 0x8a, 0xe3, 0x78, 0xe8, 0xfc, 0xb9,
+	0x04, 0xcd, 0xcc, 0x95, 0xe4, 0x76, 0xfa, 0xc2, 0x42, 0xa7, 0x84, 0x62, 0xf1, 0xaa, 0x69, 0xe4,
+	0x07, 0xb0, 0xdc, 0x0d, 0xc7, 0x89, 0x48, 0x63, 0xb1, 0xd0, 0xf6, 0x2b, 0x1e, 0x4d, 0xa2, 0x05,
+	0x46, 0xec, 0xfa, 0x55, 0xb3, 0x9a, 0x4a, 0x56, 0x2e, 0x97, 0x2c, 0x72, 0xa2, 0xe3, 0x75, 0xc7,
+	0xa5, 0x13, 0x43, 0x3a, 0x74, 0x7e, 0x53, 0x86, 0xb5, 0x43, 0xde, 0xd7, 0x41, 0xae, 0x4a, 0x93,
+	0xf9, 0x29, 0xd9, 0xec, 0x01, 0xca, 0xef, 0xe8, 0x01, 0x6e, 0xc0, 0x12, 0x4d, 0xc2, 0x13, 0xfe,
+	0x46, 0xdf, 0x5f, 0x7a, 0x84, 0xc7, 0x87, 0x26, 0xa1, 0x8e, 0x0d, 0x15, 0x02, 0x39, 0x40, 0x4e,
+	0xe6, 0x07, 0xc1, 0x76, 0x76, 0x8b, 0x98, 0x5a, 0xfe, 0x4f, 0x02, 0xe1, 0x6f, 0x25, 0x58, 0x36,
+	0x5f, 0x13, 0x2c, 0x28, 0x11, 0x6c, 0xa8, 0x53, 0xf7, 0xcd, 0x23, 0xee, 0xa9, 0x3b, 0x7d, 0x99,
+	0xa6, 0x43, 0x4c, 0xcc, 0x3d, 0x11, 0xf9, 0xe1, 0x50, 0x12, 0x75, 0xeb, 0x9a, 0x23, 0x78, 0x86,
+	0xb1, 0x10, 0x95, 0xd4, 0xaa, 0x14, 0xcd, 0xc6, 0x98, 0x01, 0x64, 0x83, 0xa9, 0xd8, 0x75, 0x4a,
+	0x32, 0x21, 0x5c, 0xb7, 0x3b, 0x0c, 0x79, 0xc4, 0x3c, 0x99, 0x8f, 0x1a, 0x34, 0x1d, 0xca, 0x3a,
+	0x38, 0x4f, 0x45, 0xf2, 0xd9, 0xf9, 0x6b, 0x15, 0x6e, 0x9a, 0x06, 0x4d, 0xbd, 0x53, 0xec, 0xc6,
+	0x45, 0xeb, 0x72, 0x80, 0xec, 0x80, 0x95, 0xeb, 0x4c, 0xd9, 0x90, 0xbd, 0x1d, 0x6b, 0x7f, 0xcd,
+	0xe0, 0xe4, 0x2b, 0xb8, 0x95, 0x63, 0x3d, 0xff, 0x3b, 0xf6, 0x34, 0x62, 0x2e, 0xbe, 0x38, 0x3d,
+	0x77, 0xd5, 0xcb, 0xd8, 0x1a, 0xbd, 0x9c, 0x61, 0x56, 0xba, 0x37, 0x72, 0x83, 0x40, 0x4b, 0x57,
+	0xe7, 0x49, 0x1b, 0x0c, 0xd8, 0x1a, 0xa6, 0xde, 0xd3, 0x5a, 0x2a, 0xa7, 0x4d, 0xa1, 0x26, 0xdf,
+	0x33, 0x37, 0xfe, 0x11, 0x9b, 0xe8, 0xa6, 0x60, 0x0a, 0x25, 0xf7, 0xe1, 0x66, 0x8a, 0x4c, 0x5b,
+	0xa2, 0x1c, 0x7b, 0x19, 0x79, 0x5a, 0xd2, 0xb4, 0xa2, 0x31, 0x2b, 0x69, 0xda, 0xa0, 0x0b, 0x2f,
+	0xdc, 0xb1, 0xa7, 0x42, 0xb7, 0xb6, 0x06, 0x62, 0xd2, 0x8f, 0x84, 0x0d, 0x45, 0xfa, 0x11, 0xf6,
+	0x16, 0xeb, 0x46, 0x88, 0x68, 0x37, 0xb4, 0xa4, 0x79, 0xb3, 0x04, 0xbc, 0x1d, 0x9f, 0x44, 0x8c,
+	0xe5, 0x6f, 0x8c, 0xd5, 0x5b, 0xd5, 0x22, 0x88, 0xc7, 0xe6, 0x84, 0x0b, 0xdd, 0x3c, 0xe3, 0xa3,
+	0xf3, 0x8f, 0x32, 0xac, 0x14, 0xb2, 0x0e, 0xd9, 0x81, 0x9a, 0x3c, 0x9b, 0x3a, 0xff, 0x6d, 0xb4,
+	0xd5, 0xe7, 0xb0, 0x76, 0xfa, 0x39, 0xac, 0xbd, 0x1f, 0x4e, 0xa8, 0x62, 0xc1, 0x66, 0x5b, 0x76,
+	0xcb, 0xfa, 0x6b, 0x04, 0xb4, 0xe5, 0xc7, 0x2b, 0x84, 0xa8, 0x22, 0xe4, 0xdf, 0x2b, 0x2a, 0x97,
+	0x7c, 0xaf, 0xf8, 0x04, 0x6a, 0x94, 0x07, 0x4c, 0xbd, 0x93, 0x49, 0x19, 0x10, 0xa1, 0x0a, 0x27,
+	0x6d, 0x80, 0x9f, 0xf0, 0xe8, 0x22, 0x1e, 0xbb, 0x03, 0x96, 0xbe, 0x87, 0x5b, 0x95, 0x5c, 0x19,
+	0x4c, 0x0d, 0x0e, 0xf2, 0x11, 0x54, 0xf7, 0x07, 0x41, 0xfa, 0xee, 0xa1, 0x21, 0x39, 0xf7, 0x3b,
+	0x47, 0x54, 0xa2, 0xe4, 0x0e, 0xc0, 0xbe, 0xfa, 0xe8, 0xe5, 0x33, 0xac, 0xbc, 0x91, 0xc7, 0x6a,
+	0xa7, 0xdf, 0xc1, 0xda, 0xa7, 0xfd, 0x57, 0x6c, 0x20, 0xa8, 0xc1, 0x43, 0xbe, 0x80, 0x96, 0x3a,
+	0x66, 0xf2, 0x5d, 0x9d, 0x5d, 0x33, 0x3b, 0x67, 0xf3, 0x14, 0x52, 0x93, 0x6d, 0xe7, 0x21, 0xac,
+	0x4d, 0x7d, 0x6e, 0x22, 0x0d, 0xa8, 0xa2, 0xc9, 0xd6, 0x35, 0x7c, 0x42, 0xdb, 0xac, 0x12, 0x59,
+	0x81, 0x66, 0xa6, 0xba, 0x55, 0x26, 0x75, 0xa8, 0xec, 0x0f, 0x02, 0xab, 0xb2, 0xf3, 0x00, 0x3e,
+	0x98, 0xfb, 0xc5, 0x82, 0xac, 0x41, 0x4b, 0xd7, 0x6f, 0x48, 0xb0, 0xae, 0x21, 0xa0, 0x39, 0xe5,
+	0xe4, 0xa5, 0x9d, 0x5f, 0xa8, 0xb8, 0xd2, 0x55, 0x53, 0x0b, 0xea, 0x2f, 0xc2, 0x8b, 0x90, 0xbf,
+	0x09, 0xd5, 0xba, 0x5d, 0x4f, 0xae, 0xdb, 0x82, 0x3a, 0x4d, 0xc2, 0xd0, 0x0f, 0x87, 0x56, 0x99,
+	0x2c, 0x43, 0xe3, 0x89, 0x1f, 0xfa, 0xf1, 0x39, 0xf3, 0xac, 0x0a, 0x4e, 0xd8, 0x0d, 0x05, 0x8b,
+	0xa2, 0x64, 0x2c, 0x98, 0x67, 0x55, 0x09, 0xe0, 0xb7, 0xbe, 0x24, 0x66, 0x9e, 0x55, 0x93, 0x0a,
+	0x86, 0x13, 0x6b, 0x89, 0x34, 0xa1, 0x26, 0x83, 0xd0, 0xaa, 0x23, 0xfd, 0xdb, 0x84, 0x25, 0xcc,
+	0xb3, 0x1a, 0x3b, 0x43, 0xa8, 0xeb, 0x0b, 0x17, 0x17, 0x3b, 0xe1, 0x21, 0xb3, 0xae, 0x21, 0xaf,
+	0x9c, 0xc0, 0x2a, 0x21, 0x2f, 0x65, 0x71, 0x32, 0x42, 0x63, 0x1b, 0x50, 0xc5, 0xe2, 0xc9, 0xaa,
+	0x20, 0xaa, 0xea, 0x55, 0xab, 0xaa, 0x35, 0x3b, 0x0d, 0x07, 0xcc, 0xaa, 0xa1, 0x66, 0xe9, 0x4b,
+	0x31, 0x6b, 0x09, 0xd9, 0xf6, 0xd5, 0x73, 0x7d, 0xef, 0xef, 0x55, 0xd9, 0xad, 0xf7, 0xd4, 0x97,
+	0x1a, 0xf2, 0x25, 0x2c, 0xa9, 0x7e, 0x98, 0xe8, 0x9b, 0xb4, 0xd0, 0x4b, 0x6f, 0x6e, 0x14, 0x41,
+	0x55, 0x0a, 0x38, 0xd7, 0x50, 0xec, 0x29, 0x33, 0xc5, 0x9e, 0xb2, 0x39, 0x62, 0xc5, 0x1e, 0xd7,
+	0xb9, 0x46, 0xbe, 0x86, 0x66, 0xd6, 0x78, 0x92, 0x1b, 0x8a, 0x69, 0xba, 0xb3, 0xdd, 0xbc, 0x39,
+	0x83, 0x67, 0xf2, 0x0f, 0xa1, 0x91, 0xf6, 0x5c, 0x44, 0x7f, 0x9b, 0x9c, 0x6a, 0x3b, 0x37, 0x6f,
+	0x4c, 0xc3, 0xa9, 0xf0, 0x9d, 0x12, 0xb9, 0x0f, 0x75, 0xdd, 0xc6, 0x90, 0xdc, 0x30, 0xa3, 0x0f,
+	0xda, 0xfc, 0x60, 0x0a, 0xcd, 0x16, 0x7e, 0x04, 0x2b, 0x1a, 0xec, 0xc9, 0x2f, 0xbe, 0xef, 0x29,
+	0xbf, 0x5d, 0xba, 0x53, 0x22, 0xdf, 0x40, 0x33, 0xeb, 0xd5, 0x88, 0xa1, 0xa6, 0xd9, 0x5b, 0x6c,
+	0xde, 0x9c, 0xc1, 0x0d, 0xfd, 0x0f, 0xd2, 0x46, 0x5c, 0xcd, 0x61, 0x9b, 0x8e, 0x2a, 0xcc, 0x72,
+	0x6b, 0x0e, 0x25, 0xb3, 0xe5, 0x5b, 0xb0, 0xa6, 0x1b, 0x15, 0xf2, 0x7f, 0xa9, 0xc0, 0xdc, 0x8e,
+	0x67, 0xf3, 0xe3, 0xcb, 0xc8, 0x6a, 0xd2, 0xbd, 0x67, 0xaa, 0x9b, 0x4e, 0x83, 0xea, 0x01, 0x06,
+	0x73, 0x28, 0x22, 0x1e, 0x90, 0xf5, 0x99, 0x1a, 0x76, 0xf3, 0xd6, 0x0c, 0x94, 0x2b, 0xd7, 0x5f,
+	0x92, 0xd9, 0xf2, 0xde, 0x7f, 0x06, 0x00, 0x23, 0x57, 0x9d, 0x8b, 0x5e, 0x20, 0x00, 0x00,
 }
@@ -77,6 +77,11 @@ func (this *Schedule) Validate() error {
 	return nil
 }
 func (this *Action) Validate() error {
+	if this.RetryPolicy != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.RetryPolicy); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("RetryPolicy", err)
+		}
+	}
 	if this.NodesSelector != nil {
 		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.NodesSelector); err != nil {
 			return github_com_mwitkow_go_proto_validators.FieldError("NodesSelector", err)
@@ -134,6 +139,9 @@ func (this *Action) Validate() error {
 	}
 	return nil
 }
+func (this *ActionRetryPolicy) Validate() error {
+	return nil
+}
 func (this *Job) Validate() error {
 	if this.Schedule != nil {
 		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Schedule); err != nil {
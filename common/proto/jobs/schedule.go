@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"github.com/pydio/cells/common/utils/schedule"
+)
+
+// TickerSchedule builds a schedule.TickerSchedule from the Cron expression if it is set,
+// or from the Iso8601Schedule otherwise.
+func (m *Schedule) TickerSchedule() (*schedule.TickerSchedule, error) {
+	if m.Cron != "" {
+		return schedule.NewTickerScheduleFromCron(m.Cron, m.Timezone)
+	}
+	return schedule.NewTickerScheduleFromISO(m.Iso8601Schedule)
+}
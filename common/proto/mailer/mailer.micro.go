@@ -10,10 +10,15 @@ It is generated from these files:
 It has these top-level messages:
 	User
 	Mail
+	DeadLetter
 	SendMailRequest
 	SendMailResponse
 	ConsumeQueueRequest
 	ConsumeQueueResponse
+	ListDeadLettersRequest
+	ListDeadLettersResponse
+	ReplayDeadLettersRequest
+	ReplayDeadLettersResponse
 */
 package mailer
 
@@ -48,6 +53,8 @@ var _ server.Option
 type MailerServiceClient interface {
 	SendMail(ctx context.Context, in *SendMailRequest, opts ...client.CallOption) (*SendMailResponse, error)
 	ConsumeQueue(ctx context.Context, in *ConsumeQueueRequest, opts ...client.CallOption) (*ConsumeQueueResponse, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...client.CallOption) (*ListDeadLettersResponse, error)
+	ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersRequest, opts ...client.CallOption) (*ReplayDeadLettersResponse, error)
 }
 
 type mailerServiceClient struct {
@@ -88,11 +95,33 @@ func (c *mailerServiceClient) ConsumeQueue(ctx context.Context, in *ConsumeQueue
 	return out, nil
 }
 
+func (c *mailerServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...client.CallOption) (*ListDeadLettersResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "MailerService.ListDeadLetters", in)
+	out := new(ListDeadLettersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailerServiceClient) ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersRequest, opts ...client.CallOption) (*ReplayDeadLettersResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "MailerService.ReplayDeadLetters", in)
+	out := new(ReplayDeadLettersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for MailerService service
 
 type MailerServiceHandler interface {
 	SendMail(context.Context, *SendMailRequest, *SendMailResponse) error
 	ConsumeQueue(context.Context, *ConsumeQueueRequest, *ConsumeQueueResponse) error
+	ListDeadLetters(context.Context, *ListDeadLettersRequest, *ListDeadLettersResponse) error
+	ReplayDeadLetters(context.Context, *ReplayDeadLettersRequest, *ReplayDeadLettersResponse) error
 }
 
 func RegisterMailerServiceHandler(s server.Server, hdlr MailerServiceHandler, opts ...server.HandlerOption) {
@@ -110,3 +139,11 @@ func (h *MailerService) SendMail(ctx context.Context, in *SendMailRequest, out *
 func (h *MailerService) ConsumeQueue(ctx context.Context, in *ConsumeQueueRequest, out *ConsumeQueueResponse) error {
 	return h.MailerServiceHandler.ConsumeQueue(ctx, in, out)
 }
+
+func (h *MailerService) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, out *ListDeadLettersResponse) error {
+	return h.MailerServiceHandler.ListDeadLetters(ctx, in, out)
+}
+
+func (h *MailerService) ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersRequest, out *ReplayDeadLettersResponse) error {
+	return h.MailerServiceHandler.ReplayDeadLetters(ctx, in, out)
+}
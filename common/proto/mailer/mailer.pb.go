@@ -10,10 +10,15 @@ It is generated from these files:
 It has these top-level messages:
 	User
 	Mail
+	DeadLetter
 	SendMailRequest
 	SendMailResponse
 	ConsumeQueueRequest
 	ConsumeQueueResponse
+	ListDeadLettersRequest
+	ListDeadLettersResponse
+	ReplayDeadLettersRequest
+	ReplayDeadLettersResponse
 */
 package mailer
 
@@ -105,6 +110,8 @@ type Mail struct {
 	SendErrors []string `protobuf:"bytes,16,rep,name=sendErrors" json:"sendErrors,omitempty"`
 	// User object used to compute the Sender header
 	Sender *User `protobuf:"bytes,17,opt,name=Sender" json:"Sender,omitempty"`
+	// Unix timestamp before which the mail should not be retried (used internally)
+	NextRetry int64 `protobuf:"varint,18,opt,name=NextRetry" json:"NextRetry,omitempty"`
 }
 
 func (m *Mail) Reset()                    { *m = Mail{} }
@@ -224,6 +231,48 @@ func (m *Mail) GetSender() *User {
 	return nil
 }
 
+func (m *Mail) GetNextRetry() int64 {
+	if m != nil {
+		return m.NextRetry
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	// Identifier of the dead letter in the queue
+	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	// Mail that could not be sent
+	Mail *Mail `protobuf:"bytes,2,opt,name=Mail" json:"Mail,omitempty"`
+	// Unix timestamp of the last failed attempt
+	FailedAt int64 `protobuf:"varint,3,opt,name=FailedAt" json:"FailedAt,omitempty"`
+}
+
+func (m *DeadLetter) Reset()                    { *m = DeadLetter{} }
+func (m *DeadLetter) String() string            { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()               {}
+func (*DeadLetter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *DeadLetter) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetMail() *Mail {
+	if m != nil {
+		return m.Mail
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetFailedAt() int64 {
+	if m != nil {
+		return m.FailedAt
+	}
+	return 0
+}
+
 type SendMailRequest struct {
 	// Complete mail object to send
 	Mail *Mail `protobuf:"bytes,1,opt,name=Mail" json:"Mail,omitempty"`
@@ -234,7 +283,7 @@ type SendMailRequest struct {
 func (m *SendMailRequest) Reset()                    { *m = SendMailRequest{} }
 func (m *SendMailRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendMailRequest) ProtoMessage()               {}
-func (*SendMailRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*SendMailRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *SendMailRequest) GetMail() *Mail {
 	if m != nil {
@@ -257,7 +306,7 @@ type SendMailResponse struct {
 func (m *SendMailResponse) Reset()                    { *m = SendMailResponse{} }
 func (m *SendMailResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendMailResponse) ProtoMessage()               {}
-func (*SendMailResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*SendMailResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *SendMailResponse) GetSuccess() bool {
 	if m != nil {
@@ -273,7 +322,7 @@ type ConsumeQueueRequest struct {
 func (m *ConsumeQueueRequest) Reset()                    { *m = ConsumeQueueRequest{} }
 func (m *ConsumeQueueRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConsumeQueueRequest) ProtoMessage()               {}
-func (*ConsumeQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*ConsumeQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *ConsumeQueueRequest) GetMaxEmails() int64 {
 	if m != nil {
@@ -290,7 +339,7 @@ type ConsumeQueueResponse struct {
 func (m *ConsumeQueueResponse) Reset()                    { *m = ConsumeQueueResponse{} }
 func (m *ConsumeQueueResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConsumeQueueResponse) ProtoMessage()               {}
-func (*ConsumeQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ConsumeQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *ConsumeQueueResponse) GetMessage() string {
 	if m != nil {
@@ -306,53 +355,134 @@ func (m *ConsumeQueueResponse) GetEmailsSent() int64 {
 	return 0
 }
 
+type ListDeadLettersRequest struct {
+}
+
+func (m *ListDeadLettersRequest) Reset()                    { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()               {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type ListDeadLettersResponse struct {
+	DeadLetters []*DeadLetter `protobuf:"bytes,1,rep,name=DeadLetters" json:"DeadLetters,omitempty"`
+}
+
+func (m *ListDeadLettersResponse) Reset()                    { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()               {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+type ReplayDeadLettersRequest struct {
+	// Dead letters to process, all of them if empty
+	IDs []string `protobuf:"bytes,1,rep,name=IDs" json:"IDs,omitempty"`
+	// Discard the dead letters instead of pushing them back to the queue
+	Purge bool `protobuf:"varint,2,opt,name=Purge" json:"Purge,omitempty"`
+}
+
+func (m *ReplayDeadLettersRequest) Reset()                    { *m = ReplayDeadLettersRequest{} }
+func (m *ReplayDeadLettersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReplayDeadLettersRequest) ProtoMessage()               {}
+func (*ReplayDeadLettersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ReplayDeadLettersRequest) GetIDs() []string {
+	if m != nil {
+		return m.IDs
+	}
+	return nil
+}
+
+func (m *ReplayDeadLettersRequest) GetPurge() bool {
+	if m != nil {
+		return m.Purge
+	}
+	return false
+}
+
+type ReplayDeadLettersResponse struct {
+	Count int64 `protobuf:"varint,1,opt,name=Count" json:"Count,omitempty"`
+}
+
+func (m *ReplayDeadLettersResponse) Reset()                    { *m = ReplayDeadLettersResponse{} }
+func (m *ReplayDeadLettersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReplayDeadLettersResponse) ProtoMessage()               {}
+func (*ReplayDeadLettersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ReplayDeadLettersResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*User)(nil), "mailer.User")
 	proto.RegisterType((*Mail)(nil), "mailer.Mail")
+	proto.RegisterType((*DeadLetter)(nil), "mailer.DeadLetter")
 	proto.RegisterType((*SendMailRequest)(nil), "mailer.SendMailRequest")
 	proto.RegisterType((*SendMailResponse)(nil), "mailer.SendMailResponse")
 	proto.RegisterType((*ConsumeQueueRequest)(nil), "mailer.ConsumeQueueRequest")
 	proto.RegisterType((*ConsumeQueueResponse)(nil), "mailer.ConsumeQueueResponse")
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "mailer.ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "mailer.ListDeadLettersResponse")
+	proto.RegisterType((*ReplayDeadLettersRequest)(nil), "mailer.ReplayDeadLettersRequest")
+	proto.RegisterType((*ReplayDeadLettersResponse)(nil), "mailer.ReplayDeadLettersResponse")
 }
 
 func init() { proto.RegisterFile("mailer.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 570 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xcd, 0x4e, 0xdb, 0x40,
-	0x10, 0xae, 0x63, 0x13, 0xc2, 0x10, 0x4a, 0xd8, 0x22, 0x75, 0x95, 0x46, 0xc8, 0xb2, 0x7a, 0xc8,
-	0xa1, 0xca, 0x01, 0x2e, 0x55, 0x2f, 0x88, 0x86, 0x54, 0x8d, 0x5a, 0x57, 0xd4, 0x09, 0x0f, 0xb0,
-	0xd8, 0x23, 0x70, 0xb1, 0xd7, 0xe9, 0xee, 0x9a, 0xc2, 0xc3, 0xf4, 0x29, 0xfb, 0x02, 0xd5, 0xee,
-	0xda, 0x89, 0xf3, 0x73, 0xdb, 0xef, 0x9b, 0x99, 0x6f, 0x67, 0xc6, 0xdf, 0x1a, 0xba, 0x39, 0x4b,
-	0x33, 0x14, 0xa3, 0x85, 0x28, 0x54, 0x41, 0xda, 0x16, 0x05, 0x09, 0x78, 0xb7, 0x12, 0x05, 0x21,
-	0xe0, 0xdd, 0x96, 0x69, 0x42, 0x1d, 0xdf, 0x19, 0x1e, 0x44, 0xe6, 0x4c, 0x28, 0xec, 0x5f, 0x25,
-	0x89, 0x40, 0x29, 0x69, 0xcb, 0xd0, 0x35, 0xd4, 0xd9, 0x3f, 0x58, 0x8e, 0xd4, 0xb5, 0xd9, 0xfa,
-	0x4c, 0xfa, 0xd0, 0xf9, 0xce, 0xf8, 0x7d, 0xc9, 0xee, 0x91, 0x7a, 0x86, 0x5f, 0xe2, 0xe0, 0x9f,
-	0x07, 0x5e, 0xc8, 0xd2, 0x8c, 0xf8, 0xe0, 0x7d, 0x11, 0x45, 0x6e, 0xae, 0x39, 0x3c, 0xef, 0x8e,
-	0xaa, 0x9e, 0x74, 0x0b, 0x91, 0x89, 0x90, 0x01, 0xb4, 0xe6, 0x05, 0x75, 0x7d, 0x77, 0x2b, 0xde,
-	0x9a, 0x17, 0x3a, 0x3a, 0x8e, 0xa9, 0xb7, 0x2b, 0x3a, 0x8e, 0x75, 0x0b, 0xd7, 0x4c, 0xe1, 0x0c,
-	0xb9, 0xa2, 0x7b, 0xbe, 0x33, 0x74, 0xa3, 0x25, 0xd6, 0xc3, 0xcc, 0xca, 0xbb, 0x5f, 0x18, 0x2b,
-	0xda, 0xb6, 0xc3, 0x54, 0x90, 0x04, 0xd0, 0x1d, 0x17, 0x5c, 0x21, 0x57, 0x37, 0x19, 0x4b, 0x39,
-	0xdd, 0x37, 0xe1, 0x35, 0x8e, 0xf8, 0x70, 0x58, 0xe1, 0xaf, 0x2a, 0xcf, 0x68, 0xc7, 0xa4, 0x34,
-	0x29, 0x32, 0x84, 0xe3, 0x0a, 0x86, 0x4c, 0x3c, 0x26, 0xc5, 0x1f, 0x4e, 0x0f, 0x4c, 0xd6, 0x26,
-	0xad, 0xb5, 0xae, 0x94, 0x62, 0xf1, 0x43, 0x8e, 0x5c, 0x49, 0x0a, 0xbe, 0xab, 0xb5, 0x1a, 0x14,
-	0x39, 0x03, 0x98, 0x3f, 0x08, 0x64, 0x89, 0xf9, 0x24, 0x87, 0x46, 0xa6, 0xc1, 0x68, 0x05, 0x8b,
-	0xa6, 0x3c, 0xc1, 0x67, 0xda, 0xb5, 0xdd, 0x34, 0x28, 0xa3, 0x80, 0xf9, 0x22, 0x63, 0x0a, 0xa7,
-	0x09, 0x3d, 0xaa, 0x14, 0x96, 0x0c, 0xf9, 0x0c, 0xdd, 0x1a, 0x5d, 0x33, 0xc5, 0xe8, 0x6b, 0xb3,
-	0xd1, 0xb3, 0x7a, 0xa3, 0xfa, 0x5b, 0x8d, 0x9a, 0x09, 0x13, 0xae, 0xc4, 0x4b, 0xb4, 0x56, 0xa3,
-	0x37, 0x1a, 0xa1, 0x12, 0x29, 0x4a, 0x7a, 0xec, 0x3b, 0xc3, 0xbd, 0xa8, 0x86, 0xfa, 0x76, 0x89,
-	0x3c, 0x99, 0x08, 0x51, 0x08, 0x49, 0x7b, 0x66, 0xc0, 0x06, 0x43, 0xde, 0x43, 0x7b, 0x86, 0x3c,
-	0x41, 0x41, 0x4f, 0x76, 0xf8, 0xa0, 0x8a, 0xf5, 0x2f, 0xe1, 0x64, 0xab, 0x05, 0xd2, 0x03, 0xf7,
-	0x11, 0x5f, 0x2a, 0x9b, 0xea, 0x23, 0x39, 0x85, 0xbd, 0x27, 0x96, 0x95, 0x58, 0x79, 0xd4, 0x82,
-	0x4f, 0xad, 0x8f, 0x4e, 0x10, 0xc2, 0xb1, 0x96, 0xd2, 0xc3, 0x44, 0xf8, 0xbb, 0x44, 0xa9, 0xb4,
-	0xff, 0x34, 0xdc, 0xf4, 0x9f, 0x49, 0xb1, 0x0e, 0xa5, 0xb0, 0x3f, 0xe5, 0x3f, 0x4b, 0xac, 0x04,
-	0x3b, 0x51, 0x0d, 0x83, 0x0f, 0xd0, 0x5b, 0xc9, 0xc9, 0x45, 0xc1, 0x25, 0x5a, 0x57, 0xc5, 0xb1,
-	0x7e, 0x22, 0x8e, 0xcd, 0xae, 0x60, 0x70, 0x01, 0x6f, 0xc6, 0x05, 0x97, 0x65, 0x8e, 0xa6, 0xba,
-	0x6e, 0x60, 0x00, 0x07, 0x21, 0x7b, 0x9e, 0xe8, 0x7b, 0x6d, 0x89, 0x1b, 0xad, 0x88, 0xe0, 0x06,
-	0x4e, 0xd7, 0x8b, 0x56, 0xd7, 0x84, 0x28, 0xa5, 0x7e, 0x5a, 0x76, 0xf2, 0x1a, 0xea, 0x55, 0xdb,
-	0x5a, 0x63, 0xfa, 0x96, 0x11, 0x6c, 0x30, 0xe7, 0x7f, 0x1d, 0x38, 0x0a, 0xcd, 0x90, 0x33, 0x14,
-	0x4f, 0x69, 0x8c, 0xe4, 0x12, 0x3a, 0xf5, 0x18, 0xe4, 0x6d, 0xbd, 0x80, 0x8d, 0x3d, 0xf5, 0xe9,
-	0x76, 0xc0, 0xb6, 0x12, 0xbc, 0x22, 0xdf, 0xcc, 0x7b, 0x59, 0x36, 0x49, 0xde, 0xd5, 0xb9, 0x3b,
-	0xe6, 0xed, 0x0f, 0x76, 0x07, 0x6b, 0xb1, 0xbb, 0xb6, 0xf9, 0x1d, 0x5d, 0xfc, 0x0f, 0x00, 0x00,
-	0xff, 0xff, 0x89, 0x9a, 0x79, 0xa3, 0x9e, 0x04, 0x00, 0x00,
+	// 735 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x4d, 0x4f, 0xe3, 0x48,
+	0x10, 0x5d, 0xdb, 0x49, 0x48, 0x2a, 0x81, 0x40, 0x2f, 0x5a, 0x7a, 0xb3, 0x11, 0xeb, 0xb5, 0xf6,
+	0x90, 0xc3, 0x0a, 0x69, 0x61, 0x0f, 0xab, 0xb9, 0x20, 0x48, 0x40, 0x13, 0x0d, 0x61, 0x18, 0x27,
+	0x5c, 0xb8, 0x35, 0x71, 0x09, 0x32, 0x24, 0x76, 0xa6, 0xbb, 0xcd, 0x90, 0x9f, 0x31, 0xbf, 0x6c,
+	0xfe, 0xd2, 0xa8, 0x3f, 0xec, 0x38, 0x24, 0xcc, 0xcd, 0xef, 0x55, 0xf5, 0xab, 0xd7, 0x55, 0xd5,
+	0x32, 0x34, 0x66, 0x6c, 0x32, 0x45, 0x7e, 0x34, 0xe7, 0x89, 0x4c, 0x48, 0xc5, 0xa0, 0x20, 0x82,
+	0xd2, 0xad, 0x40, 0x4e, 0x08, 0x94, 0x6e, 0xd3, 0x49, 0x44, 0x1d, 0xdf, 0xe9, 0xd4, 0x42, 0xfd,
+	0x4d, 0x28, 0x6c, 0x9d, 0x45, 0x11, 0x47, 0x21, 0xa8, 0xab, 0xe9, 0x0c, 0xaa, 0xec, 0x6b, 0x36,
+	0x43, 0xea, 0x99, 0x6c, 0xf5, 0x4d, 0x5a, 0x50, 0xbd, 0x62, 0xf1, 0x43, 0xca, 0x1e, 0x90, 0x96,
+	0x34, 0x9f, 0xe3, 0xe0, 0x5b, 0x19, 0x4a, 0x03, 0x36, 0x99, 0x12, 0x1f, 0x4a, 0x97, 0x3c, 0x99,
+	0xe9, 0x32, 0xf5, 0xe3, 0xc6, 0x91, 0xf5, 0xa4, 0x2c, 0x84, 0x3a, 0x42, 0xda, 0xe0, 0x8e, 0x12,
+	0xea, 0xf9, 0xde, 0x5a, 0xdc, 0x1d, 0x25, 0x2a, 0xda, 0x1d, 0xd3, 0xd2, 0xa6, 0x68, 0x77, 0xac,
+	0x2c, 0xf4, 0x98, 0xc4, 0x21, 0xc6, 0x92, 0x96, 0x7d, 0xa7, 0xe3, 0x85, 0x39, 0x56, 0x97, 0x19,
+	0xa6, 0xf7, 0x9f, 0x71, 0x2c, 0x69, 0xc5, 0x5c, 0xc6, 0x42, 0x12, 0x40, 0xa3, 0x9b, 0xc4, 0x12,
+	0x63, 0x79, 0x33, 0x65, 0x93, 0x98, 0x6e, 0xe9, 0xf0, 0x0a, 0x47, 0x7c, 0xa8, 0x5b, 0xfc, 0x5e,
+	0xce, 0xa6, 0xb4, 0xaa, 0x53, 0x8a, 0x14, 0xe9, 0x40, 0xd3, 0xc2, 0x01, 0xe3, 0x4f, 0x51, 0xf2,
+	0x35, 0xa6, 0x35, 0x9d, 0xf5, 0x9a, 0x56, 0x5a, 0x67, 0x52, 0xb2, 0xf1, 0xe3, 0x0c, 0x63, 0x29,
+	0x28, 0xf8, 0x9e, 0xd2, 0x2a, 0x50, 0xe4, 0x10, 0x60, 0xf4, 0xc8, 0x91, 0x45, 0x7a, 0x24, 0x75,
+	0x2d, 0x53, 0x60, 0x94, 0x82, 0x41, 0xfd, 0x38, 0xc2, 0x17, 0xda, 0x30, 0x6e, 0x0a, 0x94, 0x56,
+	0xc0, 0xd9, 0x7c, 0xca, 0x24, 0xf6, 0x23, 0xba, 0x6d, 0x15, 0x72, 0x86, 0x9c, 0x43, 0x23, 0x43,
+	0x3d, 0x26, 0x19, 0xdd, 0xd1, 0x1d, 0x3d, 0xcc, 0x3a, 0xaa, 0x66, 0x75, 0x54, 0x4c, 0xb8, 0x88,
+	0x25, 0x5f, 0x84, 0x2b, 0x67, 0x54, 0x47, 0x43, 0x94, 0x7c, 0x82, 0x82, 0x36, 0x7d, 0xa7, 0x53,
+	0x0e, 0x33, 0xa8, 0xaa, 0x0b, 0x8c, 0xa3, 0x0b, 0xce, 0x13, 0x2e, 0xe8, 0xae, 0xbe, 0x60, 0x81,
+	0x21, 0x7f, 0x43, 0x65, 0x88, 0x71, 0x84, 0x9c, 0xee, 0x6d, 0xd8, 0x03, 0x1b, 0x23, 0x6d, 0xa8,
+	0x5d, 0xe3, 0x8b, 0x54, 0xa2, 0x0b, 0x4a, 0xf4, 0x38, 0x97, 0x44, 0xeb, 0x14, 0xf6, 0xd6, 0x0c,
+	0x92, 0x5d, 0xf0, 0x9e, 0x70, 0x61, 0x97, 0x58, 0x7d, 0x92, 0x7d, 0x28, 0x3f, 0xb3, 0x69, 0x8a,
+	0x76, 0x83, 0x0d, 0x78, 0xe7, 0xfe, 0xef, 0x04, 0x77, 0x00, 0x3d, 0x64, 0xd1, 0x15, 0x4a, 0x89,
+	0x9c, 0xec, 0x80, 0xdb, 0xef, 0xd9, 0x83, 0x6e, 0xbf, 0xa7, 0x16, 0x55, 0x35, 0x81, 0xba, 0xab,
+	0x06, 0x15, 0x17, 0xea, 0x88, 0x5a, 0xb6, 0x4b, 0x45, 0x46, 0x67, 0x52, 0xbf, 0x03, 0x2f, 0xcc,
+	0x71, 0x30, 0x80, 0xa6, 0xba, 0x84, 0xce, 0xc6, 0x2f, 0x29, 0x0a, 0x99, 0x0b, 0x3a, 0x6f, 0x0a,
+	0x52, 0xd8, 0xea, 0xc7, 0x9f, 0x52, 0xb4, 0x66, 0xab, 0x61, 0x06, 0x83, 0x7f, 0x60, 0x77, 0x29,
+	0x27, 0xe6, 0x49, 0x2c, 0xd0, 0xec, 0xf3, 0x78, 0xac, 0x1e, 0xa7, 0x63, 0xb2, 0x2d, 0x0c, 0x4e,
+	0xe0, 0xd7, 0x6e, 0x12, 0x8b, 0x74, 0x86, 0xfa, 0x74, 0x66, 0xa0, 0x0d, 0xb5, 0x01, 0x7b, 0xb9,
+	0x50, 0x75, 0xcd, 0x11, 0x2f, 0x5c, 0x12, 0xc1, 0x0d, 0xec, 0xaf, 0x1e, 0x5a, 0x96, 0x19, 0xa0,
+	0x10, 0xea, 0x51, 0x9b, 0xe6, 0x64, 0x50, 0x0d, 0xd9, 0x9c, 0xd5, 0xcf, 0xcd, 0xd5, 0x82, 0x05,
+	0x26, 0xa0, 0xf0, 0xdb, 0xd5, 0x44, 0xc8, 0x65, 0x8f, 0x85, 0x75, 0x12, 0x7c, 0x84, 0x83, 0xb5,
+	0x88, 0x2d, 0xf7, 0x1f, 0xd4, 0x0b, 0x34, 0x75, 0xf4, 0x5a, 0x92, 0xac, 0x59, 0xcb, 0x50, 0x58,
+	0x4c, 0x0b, 0xce, 0x81, 0x86, 0x38, 0x9f, 0xb2, 0xc5, 0x7a, 0x31, 0xb5, 0x12, 0xfd, 0x9e, 0x51,
+	0xaa, 0x85, 0xea, 0x53, 0xad, 0xc4, 0x4d, 0xca, 0x1f, 0xb2, 0x2e, 0x1b, 0x10, 0xfc, 0x0b, 0xbf,
+	0x6f, 0xd0, 0xb0, 0xb6, 0xf6, 0xa1, 0xdc, 0x4d, 0xd2, 0x58, 0xda, 0xbe, 0x19, 0x70, 0xfc, 0xdd,
+	0x85, 0xed, 0x81, 0x76, 0x36, 0x44, 0xfe, 0x3c, 0x19, 0x23, 0x39, 0x85, 0x6a, 0x36, 0x28, 0x72,
+	0x90, 0xb9, 0x7e, 0xb5, 0x09, 0x2d, 0xba, 0x1e, 0x30, 0x65, 0x82, 0x5f, 0xc8, 0x07, 0x68, 0x14,
+	0xc7, 0x40, 0xfe, 0xc8, 0x72, 0x37, 0x4c, 0xb4, 0xd5, 0xde, 0x1c, 0xcc, 0xc5, 0x46, 0xd0, 0x7c,
+	0xd5, 0x67, 0x92, 0xbf, 0xf0, 0xcd, 0xa3, 0x69, 0xfd, 0xf9, 0x66, 0x3c, 0x57, 0xbd, 0x83, 0xbd,
+	0xb5, 0x46, 0x11, 0x3f, 0x3b, 0xf7, 0xd6, 0x1c, 0x5a, 0x7f, 0xfd, 0x24, 0x23, 0xd3, 0xbe, 0xaf,
+	0xe8, 0x9f, 0xd3, 0xc9, 0x8f, 0x01, 0x00, 0x39, 0x7e, 0x3f, 0x61, 0xac, 0x06, 0x00, 0x00,
 }
@@ -44,6 +44,14 @@ func (this *Mail) Validate() error {
 	}
 	return nil
 }
+func (this *DeadLetter) Validate() error {
+	if this.Mail != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Mail); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Mail", err)
+		}
+	}
+	return nil
+}
 func (this *SendMailRequest) Validate() error {
 	if this.Mail != nil {
 		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Mail); err != nil {
@@ -61,3 +69,22 @@ func (this *ConsumeQueueRequest) Validate() error {
 func (this *ConsumeQueueResponse) Validate() error {
 	return nil
 }
+func (this *ListDeadLettersRequest) Validate() error {
+	return nil
+}
+func (this *ListDeadLettersResponse) Validate() error {
+	for _, item := range this.DeadLetters {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("DeadLetters", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *ReplayDeadLettersRequest) Validate() error {
+	return nil
+}
+func (this *ReplayDeadLettersResponse) Validate() error {
+	return nil
+}
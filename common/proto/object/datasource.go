@@ -28,6 +28,10 @@ import (
 	"github.com/pydio/minio-go"
 )
 
+// StorageKeyWritesFrozen is the StorageConfiguration key set to "true" while the datasource refuses all writes,
+// e.g. during the final pass of a storage migration.
+const StorageKeyWritesFrozen = "writesFrozen"
+
 // Builds the url used for clients
 func (d *DataSource) BuildUrl() string {
 	return fmt.Sprintf("%s:%d", d.ObjectsHost, d.ObjectsPort)
@@ -39,6 +43,11 @@ func (d *DataSource) CreateClient() (*minio.Core, error) {
 
 }
 
+// WritesFrozen checks if the datasource currently refuses all writes
+func (d *DataSource) WritesFrozen() bool {
+	return d.GetStorageConfiguration()[StorageKeyWritesFrozen] == "true"
+}
+
 // Builds the url used for clients
 func (d *MinioConfig) BuildUrl() string {
 	return fmt.Sprintf("%s:%d", d.RunningHost, d.RunningPort)
@@ -201,7 +201,7 @@ type DataSource struct {
 	ApiSecret string `protobuf:"bytes,17,opt,name=ApiSecret" json:"ApiSecret,omitempty"`
 	// Peer address of the data source
 	PeerAddress string `protobuf:"bytes,19,opt,name=PeerAddress" json:"PeerAddress,omitempty"`
-	// Not implemented, whether to watch for underlying changes on the FS
+	// Whether to watch for changes performed directly on the underlying storage
 	Watch bool `protobuf:"varint,6,opt,name=Watch" json:"Watch,omitempty"`
 	// Type of encryption applied before sending data to storage
 	EncryptionMode EncryptionMode `protobuf:"varint,7,opt,name=EncryptionMode,enum=object.EncryptionMode" json:"EncryptionMode,omitempty"`
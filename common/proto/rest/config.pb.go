@@ -787,78 +787,78 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 1165 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x4f, 0x1b, 0x47,
-	0x14, 0x96, 0x01, 0x63, 0xfb, 0xd8, 0x10, 0xbc, 0x25, 0xb0, 0x71, 0x54, 0x84, 0x56, 0x55, 0x85,
-	0x48, 0x63, 0x54, 0x27, 0x4d, 0x2f, 0x8a, 0x14, 0x81, 0x0d, 0xaa, 0x25, 0x02, 0xd6, 0xda, 0xea,
-	0xfb, 0x78, 0x77, 0x6a, 0xa6, 0xcc, 0xee, 0x6c, 0x67, 0x66, 0x51, 0xfc, 0xde, 0x7f, 0xd3, 0xf7,
-	0xbe, 0xf5, 0x07, 0xf4, 0x5f, 0x55, 0x73, 0xd9, 0xf5, 0xd8, 0x26, 0x2a, 0x52, 0x1f, 0xc0, 0x73,
-	0xbe, 0x73, 0x99, 0x73, 0xf9, 0xe6, 0xd8, 0xd0, 0x8a, 0x58, 0xfa, 0x2b, 0x99, 0x75, 0x33, 0xce,
-	0x24, 0xf3, 0xb6, 0x38, 0x16, 0xb2, 0xf3, 0x66, 0x46, 0xe4, 0x5d, 0x3e, 0xed, 0x46, 0x2c, 0x39,
-	0xcb, 0xe6, 0x31, 0x61, 0x67, 0x11, 0xa6, 0x54, 0x9c, 0x45, 0x2c, 0x49, 0x58, 0x7a, 0xa6, 0x4d,
-	0xcf, 0x24, 0xc7, 0x58, 0xff, 0x33, 0xae, 0x9d, 0xef, 0x9f, 0xe2, 0xc4, 0xa6, 0xbf, 0xe1, 0x48,
-	0xda, 0x0f, 0xeb, 0xf8, 0xed, 0x53, 0x1c, 0x23, 0x49, 0xd5, 0x9f, 0x75, 0xf9, 0xf1, 0x29, 0x2e,
-	0x24, 0x15, 0x12, 0x51, 0x5a, 0x7c, 0x1a, 0xd7, 0xe0, 0x03, 0xec, 0xf4, 0x75, 0xc5, 0x39, 0x47,
-	0x92, 0xb0, 0xd4, 0xeb, 0x40, 0xfd, 0x2a, 0xa7, 0x74, 0x84, 0xe4, 0x9d, 0x5f, 0x39, 0xae, 0x9c,
-	0x34, 0xc2, 0x52, 0xf6, 0x3c, 0xd8, 0x1a, 0x20, 0x89, 0xfc, 0x0d, 0x8d, 0xeb, 0x73, 0x70, 0x08,
-	0xcf, 0xaf, 0x89, 0x90, 0xea, 0x3c, 0x66, 0x39, 0x8f, 0x70, 0x88, 0x7f, 0xcf, 0xb1, 0x90, 0xc1,
-	0x14, 0xf6, 0x17, 0x60, 0x9f, 0x51, 0x8a, 0x23, 0x7d, 0xc1, 0x5b, 0x68, 0x2e, 0x70, 0xe1, 0x57,
-	0x8e, 0x37, 0x4f, 0x9a, 0x3d, 0xaf, 0x6b, 0x7b, 0xe0, 0xc4, 0x71, 0xcd, 0xbc, 0x7d, 0xa8, 0x4e,
-	0x98, 0x44, 0x54, 0xdf, 0x5d, 0x0d, 0x8d, 0x10, 0xbc, 0x05, 0x7f, 0x80, 0x29, 0x96, 0xd8, 0xbd,
-	0x5e, 0x64, 0x2c, 0x15, 0xd8, 0xf3, 0xa1, 0x36, 0xce, 0xa3, 0x08, 0x0b, 0xa1, 0xeb, 0xa8, 0x87,
-	0x85, 0x18, 0xbc, 0x84, 0x17, 0x2a, 0xe5, 0x11, 0xc6, 0x5c, 0x9c, 0xc7, 0x31, 0xc7, 0x42, 0x60,
-	0x51, 0xa4, 0x7d, 0x01, 0x9d, 0xc7, 0x94, 0x36, 0xe8, 0x57, 0xb0, 0xa3, 0x34, 0xa5, 0x42, 0xa7,
-	0xdf, 0x08, 0x97, 0xc1, 0xe0, 0x06, 0x0e, 0x8a, 0x18, 0x57, 0x8c, 0xc6, 0x98, 0x17, 0xd1, 0xbd,
-	0x63, 0x68, 0x3a, 0xa6, 0xb6, 0xc1, 0x2e, 0xa4, 0x7a, 0xac, 0x7b, 0x6f, 0x7b, 0xac, 0xce, 0xc1,
-	0x2d, 0x1c, 0xf6, 0x39, 0x46, 0x12, 0x2f, 0x22, 0xfe, 0xbf, 0x80, 0x13, 0xf0, 0xd7, 0x03, 0xfe,
-	0x57, 0xdf, 0xbc, 0x23, 0xd8, 0xba, 0x61, 0x31, 0xd6, 0x91, 0x9a, 0x3d, 0xe8, 0x6a, 0xb6, 0x2b,
-	0x24, 0xd4, 0x78, 0x90, 0x9b, 0xbe, 0x8e, 0x25, 0xe3, 0x68, 0x86, 0x2f, 0xf2, 0xe8, 0x1e, 0xcb,
-	0xb2, 0xf2, 0x1e, 0xc0, 0x62, 0x48, 0x3a, 0xf2, 0xe3, 0x53, 0x77, 0xac, 0x54, 0xb7, 0xcb, 0x28,
-	0x33, 0xfc, 0x29, 0xb3, 0x35, 0x2c, 0x83, 0xc1, 0x3f, 0x15, 0xa8, 0x8d, 0x38, 0xd3, 0x29, 0xee,
-	0xc2, 0xc6, 0x70, 0x60, 0xbb, 0xb0, 0x31, 0x1c, 0x28, 0x36, 0x8f, 0x10, 0xc7, 0xa9, 0x1c, 0x0e,
-	0xac, 0x73, 0x29, 0xab, 0xd6, 0x7d, 0xc4, 0x92, 0x93, 0x48, 0x8c, 0x18, 0x97, 0xfe, 0xa6, 0x26,
-	0x96, 0x0b, 0x79, 0x07, 0xb0, 0xad, 0x1a, 0x34, 0x8c, 0xfd, 0x2d, 0xed, 0x6b, 0xa5, 0xd5, 0xa6,
-	0x57, 0xd7, 0x9b, 0xde, 0x81, 0xfa, 0x58, 0x22, 0x2e, 0x27, 0x68, 0xe6, 0x6f, 0x9b, 0x7b, 0x0b,
-	0x59, 0xeb, 0x30, 0x7f, 0x20, 0x8a, 0xfd, 0x35, 0x4d, 0x9f, 0x52, 0x0e, 0x46, 0xb0, 0xaf, 0x99,
-	0x63, 0xca, 0x29, 0x59, 0xe9, 0x64, 0x52, 0x59, 0xcd, 0xc4, 0xfa, 0xde, 0xa0, 0x04, 0xdb, 0x12,
-	0x5d, 0x28, 0x18, 0x98, 0xf7, 0xe9, 0x44, 0xb4, 0x73, 0x7e, 0x05, 0x8d, 0x12, 0xb4, 0xaf, 0x70,
-	0xa7, 0xab, 0xf6, 0x5d, 0xd7, 0xc2, 0xe1, 0x42, 0x1f, 0x7c, 0x09, 0x2f, 0x55, 0x94, 0x5f, 0x30,
-	0x17, 0x84, 0xa5, 0x24, 0x9d, 0x8d, 0x18, 0x25, 0xd1, 0xbc, 0x78, 0x34, 0x23, 0xe8, 0xac, 0xaa,
-	0x9c, 0x17, 0xdf, 0x83, 0xba, 0xc6, 0x48, 0x79, 0xd1, 0x81, 0xe1, 0xce, 0x5a, 0xb8, 0xd2, 0x2e,
-	0x78, 0x01, 0x87, 0xfa, 0x42, 0xc2, 0x65, 0x8e, 0xa8, 0xa2, 0x57, 0xf9, 0x42, 0xaf, 0xc1, 0xd3,
-	0x34, 0x33, 0x45, 0x16, 0x1d, 0x7a, 0x07, 0xad, 0xb1, 0x44, 0x32, 0x17, 0x57, 0x84, 0x4a, 0xcc,
-	0x75, 0x9f, 0x76, 0x7b, 0x5e, 0x57, 0x6d, 0x49, 0x6b, 0x6a, 0xf4, 0xe1, 0x92, 0x5d, 0x30, 0x86,
-	0xb6, 0x55, 0x3b, 0x19, 0x9f, 0x38, 0x23, 0x32, 0x19, 0xb7, 0xdc, 0x40, 0x8b, 0x81, 0x7d, 0x66,
-	0x2f, 0xfd, 0x51, 0x81, 0xe7, 0x7d, 0x96, 0x4a, 0xce, 0xe8, 0x4a, 0x9a, 0x2b, 0x03, 0xab, 0xac,
-	0x0d, 0x4c, 0xd1, 0x43, 0x95, 0xeb, 0xcc, 0xb3, 0x94, 0xbd, 0xd7, 0x50, 0xeb, 0xb3, 0x24, 0x41,
-	0x69, 0xac, 0xe9, 0xba, 0xdb, 0xfb, 0xc2, 0x4d, 0xcb, 0xaa, 0xc2, 0xc2, 0x26, 0x78, 0x07, 0x7b,
-	0x03, 0x22, 0x22, 0xf6, 0x80, 0x79, 0x31, 0x2a, 0x2f, 0x80, 0xd6, 0x65, 0x1a, 0x67, 0x8c, 0xa4,
-	0x72, 0x32, 0xcf, 0x8a, 0x0c, 0x96, 0xb0, 0xe0, 0xef, 0x0d, 0x68, 0x3b, 0x8e, 0x96, 0x30, 0x8a,
-	0xf5, 0x28, 0xba, 0x47, 0x33, 0xec, 0x38, 0xba, 0x90, 0x8a, 0x6d, 0xc5, 0x6b, 0x34, 0xc5, 0xd4,
-	0xa6, 0xbf, 0x84, 0xa9, 0xf5, 0x62, 0xc7, 0xae, 0x4b, 0x68, 0x84, 0x85, 0xe8, 0x1d, 0x01, 0x5c,
-	0xe4, 0x84, 0xc6, 0x63, 0x89, 0x92, 0x4c, 0xbf, 0xb8, 0x6a, 0xe8, 0x20, 0x66, 0x1b, 0x10, 0x1a,
-	0x87, 0xf8, 0x81, 0x68, 0xff, 0x6a, 0xb1, 0x0d, 0x1c, 0xd0, 0x1b, 0x40, 0xa3, 0xa8, 0x45, 0xf8,
-	0xdb, 0x7a, 0x76, 0x5f, 0x1b, 0x5a, 0xaf, 0x55, 0xd4, 0x2d, 0x0d, 0x2f, 0x53, 0xc9, 0xe7, 0xe1,
-	0xc2, 0xb1, 0xf3, 0x1e, 0x76, 0x97, 0x95, 0xde, 0x1e, 0x6c, 0xde, 0xe3, 0xb9, 0xad, 0x5a, 0x1d,
-	0xd5, 0xe8, 0x1f, 0x10, 0xcd, 0x8b, 0x29, 0x19, 0xe1, 0xa7, 0x8d, 0x1f, 0x2a, 0xc1, 0x77, 0xd0,
-	0x36, 0x5f, 0xaa, 0x57, 0x8c, 0x27, 0x4f, 0x9e, 0x7c, 0xd0, 0x86, 0x67, 0xb7, 0x19, 0x4e, 0xcf,
-	0x33, 0x52, 0x64, 0x18, 0xfc, 0xb9, 0x09, 0xed, 0x73, 0xcd, 0xc9, 0x01, 0x16, 0x11, 0x27, 0x99,
-	0xa6, 0xa7, 0x07, 0x5b, 0x4e, 0x0c, 0x7d, 0x56, 0xd8, 0x30, 0x62, 0x69, 0xb1, 0xe6, 0xd5, 0x59,
-	0x65, 0x68, 0x06, 0x61, 0x3a, 0x6d, 0x04, 0x95, 0x88, 0x13, 0xcc, 0xae, 0x36, 0x17, 0xf2, 0x4e,
-	0xe0, 0xd9, 0x38, 0x4f, 0x12, 0xc4, 0xe7, 0x13, 0x9c, 0x64, 0x14, 0x49, 0x6c, 0x7b, 0xbd, 0x0a,
-	0xab, 0x69, 0xfe, 0x8c, 0x84, 0x2a, 0x53, 0xaf, 0xb9, 0x7a, 0x58, 0x88, 0x6a, 0x9a, 0xea, 0xf3,
-	0x23, 0x8b, 0x73, 0x8a, 0xfd, 0xa6, 0x76, 0x77, 0x10, 0x75, 0xc7, 0x42, 0x1a, 0x71, 0x96, 0x09,
-	0xbf, 0x65, 0xee, 0x58, 0x81, 0xd5, 0x83, 0xe8, 0x23, 0x89, 0x67, 0x8c, 0xcf, 0xfd, 0x9a, 0x79,
-	0x10, 0x85, 0xac, 0xaa, 0x9e, 0x90, 0x54, 0xfa, 0x75, 0x53, 0xb5, 0x3a, 0x7b, 0xa7, 0xb0, 0x37,
-	0x4c, 0xb3, 0x5c, 0xba, 0x45, 0x36, 0xb4, 0x7e, 0x0d, 0xf7, 0xbe, 0x81, 0xf6, 0x6d, 0x2e, 0x57,
-	0x8c, 0x41, 0x1b, 0xaf, 0x2b, 0x54, 0x4d, 0x43, 0x31, 0x4c, 0x25, 0xe6, 0x29, 0xa2, 0xfe, 0x8e,
-	0x2e, 0xd8, 0x41, 0xd4, 0xd2, 0x1a, 0x47, 0x77, 0x58, 0xa5, 0xce, 0xcd, 0xd4, 0xca, 0xa5, 0xf5,
-	0x57, 0x05, 0xfc, 0x75, 0x9d, 0x7d, 0x59, 0x97, 0x50, 0xb3, 0x90, 0xdd, 0x36, 0xaf, 0x0c, 0x63,
-	0x3f, 0xe7, 0xd0, 0xb5, 0xb2, 0xa1, 0x6d, 0xe1, 0xdb, 0x19, 0x43, 0xcb, 0x55, 0x3c, 0x42, 0xd9,
-	0xd7, 0x2e, 0x65, 0x9b, 0xbd, 0x43, 0x73, 0xcd, 0x1a, 0xc1, 0x5c, 0x2e, 0xbf, 0x87, 0xce, 0x4a,
-	0x1a, 0x2e, 0xa9, 0x8f, 0x00, 0x0c, 0xe8, 0xf0, 0xd1, 0x41, 0xd4, 0xf7, 0xc6, 0xa3, 0xde, 0x96,
-	0xde, 0xa7, 0xb0, 0xa7, 0x57, 0x39, 0x91, 0x4b, 0x5f, 0x75, 0xce, 0x0a, 0x6f, 0x84, 0x56, 0x0a,
-	0x3e, 0x40, 0xdb, 0xb1, 0xb5, 0x9d, 0x3b, 0x85, 0xaa, 0x06, 0x6c, 0xdf, 0xf6, 0xbb, 0xc5, 0xaf,
-	0xdb, 0x11, 0x67, 0x9f, 0xe6, 0xe6, 0x11, 0x86, 0xc6, 0x64, 0xba, 0xad, 0x7f, 0xf1, 0xbe, 0xf9,
-	0x37, 0x00, 0x00, 0xff, 0xff, 0x86, 0xb5, 0x92, 0xb3, 0xe3, 0x0b, 0x00, 0x00,
+	// 1162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0x1a, 0x47,
+	0x14, 0x16, 0xb6, 0x31, 0x70, 0xc0, 0x8e, 0xd9, 0x3a, 0xf1, 0x86, 0xa8, 0x51, 0xb4, 0xaa, 0x2a,
+	0x2b, 0x69, 0xb0, 0x4a, 0xd2, 0xf4, 0xa2, 0x48, 0x51, 0x02, 0xb6, 0x8a, 0xe4, 0xd8, 0x68, 0x41,
+	0x7d, 0x1f, 0x2f, 0xa7, 0x78, 0xea, 0x61, 0x67, 0x3b, 0x33, 0x6b, 0x85, 0xf7, 0xfe, 0x9b, 0xbe,
+	0xf7, 0xad, 0x3f, 0xa0, 0xff, 0xaa, 0x9a, 0xcb, 0x2e, 0x03, 0x38, 0xaa, 0xa5, 0x3e, 0xd8, 0xcc,
+	0xf9, 0xce, 0x65, 0xce, 0xe5, 0x9b, 0x03, 0xd0, 0x4a, 0x78, 0xfa, 0x2b, 0x9d, 0x75, 0x33, 0xc1,
+	0x15, 0x0f, 0x76, 0x04, 0x4a, 0xd5, 0x79, 0x35, 0xa3, 0xea, 0x3a, 0xbf, 0xea, 0x26, 0x7c, 0x7e,
+	0x92, 0x2d, 0xa6, 0x94, 0x9f, 0x24, 0xc8, 0x98, 0x3c, 0x49, 0xf8, 0x7c, 0xce, 0xd3, 0x13, 0x63,
+	0x7a, 0xa2, 0x04, 0xa2, 0xf9, 0x67, 0x5d, 0x3b, 0xdf, 0xdf, 0xc7, 0x89, 0x5f, 0xfd, 0x86, 0x89,
+	0x72, 0x1f, 0xce, 0xf1, 0xdb, 0xfb, 0x38, 0x26, 0x8a, 0xe9, 0x3f, 0xe7, 0xf2, 0xe3, 0x7d, 0x5c,
+	0x68, 0x2a, 0x15, 0x61, 0xac, 0xf8, 0xb4, 0xae, 0xd1, 0x3b, 0xd8, 0xeb, 0x9b, 0x8a, 0x73, 0x41,
+	0x14, 0xe5, 0x69, 0xd0, 0x81, 0xfa, 0x59, 0xce, 0xd8, 0x88, 0xa8, 0xeb, 0xb0, 0xf2, 0xac, 0x72,
+	0xdc, 0x88, 0x4b, 0x39, 0x08, 0x60, 0x67, 0x40, 0x14, 0x09, 0xb7, 0x0c, 0x6e, 0xce, 0xd1, 0x11,
+	0x3c, 0x3c, 0xa7, 0x52, 0xe9, 0xf3, 0x98, 0xe7, 0x22, 0xc1, 0x18, 0x7f, 0xcf, 0x51, 0xaa, 0xe8,
+	0x0a, 0x0e, 0x97, 0x60, 0x9f, 0x33, 0x86, 0x89, 0xb9, 0xe0, 0x35, 0x34, 0x97, 0xb8, 0x0c, 0x2b,
+	0xcf, 0xb6, 0x8f, 0x9b, 0xbd, 0xa0, 0xeb, 0x7a, 0xe0, 0xc5, 0xf1, 0xcd, 0x82, 0x43, 0xa8, 0x4e,
+	0xb8, 0x22, 0xcc, 0xdc, 0x5d, 0x8d, 0xad, 0x10, 0xbd, 0x86, 0x70, 0x80, 0x0c, 0x15, 0xfa, 0xd7,
+	0xcb, 0x8c, 0xa7, 0x12, 0x83, 0x10, 0x6a, 0xe3, 0x3c, 0x49, 0x50, 0x4a, 0x53, 0x47, 0x3d, 0x2e,
+	0xc4, 0xe8, 0x09, 0x3c, 0xd6, 0x29, 0x8f, 0x10, 0x85, 0x7c, 0x3f, 0x9d, 0x0a, 0x94, 0x12, 0x65,
+	0x91, 0xf6, 0x07, 0xe8, 0xdc, 0xa5, 0x74, 0x41, 0xbf, 0x82, 0x3d, 0xad, 0x29, 0x15, 0x26, 0xfd,
+	0x46, 0xbc, 0x0a, 0x46, 0x17, 0xf0, 0xa8, 0x88, 0x71, 0xc6, 0xd9, 0x14, 0x45, 0x11, 0x3d, 0x78,
+	0x06, 0x4d, 0xcf, 0xd4, 0x35, 0xd8, 0x87, 0x74, 0x8f, 0x4d, 0xef, 0x5d, 0x8f, 0xf5, 0x39, 0xba,
+	0x84, 0xa3, 0xbe, 0x40, 0xa2, 0x70, 0x19, 0xf1, 0xff, 0x05, 0x9c, 0x40, 0xb8, 0x19, 0xf0, 0xbf,
+	0xfa, 0x16, 0x3c, 0x85, 0x9d, 0x0b, 0x3e, 0x45, 0x13, 0xa9, 0xd9, 0x83, 0xae, 0x61, 0xbb, 0x46,
+	0x62, 0x83, 0x47, 0xb9, 0xed, 0xeb, 0x58, 0x71, 0x41, 0x66, 0xf8, 0x21, 0x4f, 0x6e, 0x50, 0x95,
+	0x95, 0xf7, 0x00, 0x96, 0x43, 0x32, 0x91, 0xef, 0x9e, 0xba, 0x67, 0xa5, 0xbb, 0x5d, 0x46, 0x99,
+	0xe1, 0xa7, 0xcc, 0xd5, 0xb0, 0x0a, 0x46, 0xff, 0x54, 0xa0, 0x36, 0x12, 0xdc, 0xa4, 0xb8, 0x0f,
+	0x5b, 0xc3, 0x81, 0xeb, 0xc2, 0xd6, 0x70, 0xa0, 0xd9, 0x3c, 0x22, 0x02, 0x53, 0x35, 0x1c, 0x38,
+	0xe7, 0x52, 0xd6, 0xad, 0xfb, 0x88, 0x4a, 0xd0, 0x44, 0x8e, 0xb8, 0x50, 0xe1, 0xb6, 0x21, 0x96,
+	0x0f, 0x05, 0x8f, 0x60, 0x57, 0x37, 0x68, 0x38, 0x0d, 0x77, 0x8c, 0xaf, 0x93, 0xd6, 0x9b, 0x5e,
+	0xdd, 0x6c, 0x7a, 0x07, 0xea, 0x63, 0x45, 0x84, 0x9a, 0x90, 0x59, 0xb8, 0x6b, 0xef, 0x2d, 0x64,
+	0xa3, 0x43, 0x71, 0x4b, 0x35, 0xfb, 0x6b, 0x86, 0x3e, 0xa5, 0x1c, 0x8d, 0xe0, 0xd0, 0x30, 0xc7,
+	0x96, 0x53, 0xb2, 0xd2, 0xcb, 0xa4, 0xb2, 0x9e, 0x89, 0xf3, 0xbd, 0x20, 0x73, 0x74, 0x25, 0xfa,
+	0x50, 0x34, 0x80, 0x87, 0x6b, 0x11, 0xdd, 0x9c, 0x5f, 0x40, 0xa3, 0x04, 0xdd, 0x2b, 0xdc, 0xeb,
+	0x0a, 0x94, 0xaa, 0xeb, 0xe0, 0x78, 0xa9, 0x8f, 0xbe, 0x84, 0x27, 0x3a, 0xca, 0x2f, 0x28, 0x24,
+	0xe5, 0x29, 0x4d, 0x67, 0x23, 0xce, 0x68, 0xb2, 0x28, 0x1e, 0xcd, 0x08, 0x3a, 0xeb, 0x2a, 0xef,
+	0xc5, 0xf7, 0xa0, 0x6e, 0x30, 0x5a, 0x5e, 0xf4, 0xc8, 0x72, 0x67, 0x23, 0x5c, 0x69, 0x17, 0x3d,
+	0x86, 0x23, 0x73, 0x21, 0x15, 0x2a, 0x27, 0x4c, 0xd3, 0xab, 0x7c, 0xa1, 0xe7, 0x10, 0x18, 0x9a,
+	0xd9, 0x22, 0x8b, 0x0e, 0xbd, 0x81, 0xd6, 0x58, 0x11, 0x95, 0xcb, 0x33, 0xca, 0x14, 0x0a, 0xd3,
+	0xa7, 0xfd, 0x5e, 0xd0, 0xd5, 0x5b, 0xd2, 0x99, 0x5a, 0x7d, 0xbc, 0x62, 0x17, 0x8d, 0xa1, 0xed,
+	0xd4, 0x5e, 0xc6, 0xc7, 0xde, 0x88, 0x6c, 0xc6, 0x2d, 0x3f, 0xd0, 0x72, 0x60, 0x9f, 0xd9, 0x4b,
+	0x7f, 0x54, 0xe0, 0x61, 0x9f, 0xa7, 0x4a, 0x70, 0xb6, 0x96, 0xe6, 0xda, 0xc0, 0x2a, 0x1b, 0x03,
+	0xd3, 0xf4, 0xd0, 0xe5, 0x7a, 0xf3, 0x2c, 0xe5, 0xe0, 0x25, 0xd4, 0xfa, 0x7c, 0x3e, 0x27, 0xe9,
+	0xd4, 0xd0, 0x75, 0xbf, 0xf7, 0x85, 0x9f, 0x96, 0x53, 0xc5, 0x85, 0x4d, 0xf4, 0x06, 0x0e, 0x06,
+	0x54, 0x26, 0xfc, 0x16, 0x45, 0x31, 0xaa, 0x20, 0x82, 0xd6, 0x69, 0x3a, 0xcd, 0x38, 0x4d, 0xd5,
+	0x64, 0x91, 0x15, 0x19, 0xac, 0x60, 0xd1, 0xdf, 0x5b, 0xd0, 0xf6, 0x1c, 0x1d, 0x61, 0x34, 0xeb,
+	0x49, 0x72, 0x43, 0x66, 0xe8, 0x39, 0xfa, 0x90, 0x8e, 0xed, 0xc4, 0x73, 0x72, 0x85, 0xcc, 0xa5,
+	0xbf, 0x82, 0xe9, 0xf5, 0xe2, 0xc6, 0x6e, 0x4a, 0x68, 0xc4, 0x85, 0x18, 0x3c, 0x05, 0xf8, 0x90,
+	0x53, 0x36, 0x1d, 0x2b, 0x32, 0xcf, 0xcc, 0x8b, 0xab, 0xc6, 0x1e, 0x62, 0xb7, 0x01, 0x65, 0xd3,
+	0x18, 0x6f, 0xa9, 0xf1, 0xaf, 0x16, 0xdb, 0xc0, 0x03, 0x83, 0x01, 0x34, 0x8a, 0x5a, 0x64, 0xb8,
+	0x6b, 0x66, 0xf7, 0xb5, 0xa5, 0xf5, 0x46, 0x45, 0xdd, 0xd2, 0xf0, 0x34, 0x55, 0x62, 0x11, 0x2f,
+	0x1d, 0x3b, 0x6f, 0x61, 0x7f, 0x55, 0x19, 0x1c, 0xc0, 0xf6, 0x0d, 0x2e, 0x5c, 0xd5, 0xfa, 0xa8,
+	0x47, 0x7f, 0x4b, 0x58, 0x5e, 0x4c, 0xc9, 0x0a, 0x3f, 0x6d, 0xfd, 0x50, 0x89, 0xbe, 0x83, 0xb6,
+	0xfd, 0x52, 0x3d, 0xe3, 0x62, 0x7e, 0xef, 0xc9, 0x47, 0x6d, 0x78, 0x70, 0x99, 0x61, 0xfa, 0x3e,
+	0xa3, 0x45, 0x86, 0xd1, 0x9f, 0xdb, 0xd0, 0x7e, 0x6f, 0x38, 0x39, 0x40, 0x99, 0x08, 0x9a, 0xe9,
+	0xa3, 0x5e, 0xe9, 0x5e, 0x0c, 0x73, 0xd6, 0xd8, 0x30, 0xe1, 0x69, 0xb1, 0xe6, 0xf5, 0x59, 0x67,
+	0x68, 0x07, 0x61, 0x3b, 0x6d, 0x05, 0x9d, 0x88, 0x17, 0xcc, 0xad, 0x36, 0x1f, 0x0a, 0x8e, 0xe1,
+	0xc1, 0x38, 0x9f, 0xcf, 0x89, 0x58, 0x4c, 0x70, 0x9e, 0x31, 0xa2, 0xd0, 0xf5, 0x7a, 0x1d, 0xd6,
+	0xd3, 0xfc, 0x99, 0x48, 0x5d, 0xa6, 0x59, 0x73, 0xf5, 0xb8, 0x10, 0xf5, 0x34, 0xf5, 0xe7, 0x47,
+	0x3e, 0xcd, 0x19, 0x86, 0x4d, 0xe3, 0xee, 0x21, 0xfa, 0x8e, 0xa5, 0x34, 0x12, 0x3c, 0x93, 0x61,
+	0xcb, 0xde, 0xb1, 0x06, 0xeb, 0x07, 0xd1, 0x27, 0x0a, 0x67, 0x5c, 0x2c, 0xc2, 0x9a, 0x7d, 0x10,
+	0x85, 0xac, 0xab, 0x9e, 0xd0, 0x54, 0x85, 0x75, 0x5b, 0xb5, 0x3e, 0x07, 0xcf, 0xe1, 0x60, 0x98,
+	0x66, 0xb9, 0xf2, 0x8b, 0x6c, 0x18, 0xfd, 0x06, 0x1e, 0x7c, 0x03, 0xed, 0xcb, 0x5c, 0xad, 0x19,
+	0x83, 0x31, 0xde, 0x54, 0xe8, 0x9a, 0x86, 0x72, 0x98, 0x2a, 0x14, 0x29, 0x61, 0xe1, 0x9e, 0x29,
+	0xd8, 0x43, 0xf4, 0xd2, 0x1a, 0x27, 0xd7, 0xa8, 0x53, 0x17, 0x76, 0x6a, 0xe5, 0xd2, 0xfa, 0xab,
+	0x02, 0xe1, 0xa6, 0xce, 0xbd, 0xac, 0x53, 0xa8, 0x39, 0xc8, 0x6d, 0x9b, 0x17, 0x96, 0xb1, 0x9f,
+	0x73, 0xe8, 0x3a, 0xd9, 0xd2, 0xb6, 0xf0, 0xed, 0x8c, 0xa1, 0xe5, 0x2b, 0xee, 0xa0, 0xec, 0x4b,
+	0x9f, 0xb2, 0xcd, 0xde, 0x91, 0xbd, 0x66, 0x83, 0x60, 0x3e, 0x97, 0xdf, 0x42, 0x67, 0x2d, 0x0d,
+	0x9f, 0xd4, 0x4f, 0x01, 0x2c, 0xe8, 0xf1, 0xd1, 0x43, 0xf4, 0xf7, 0xc6, 0x9d, 0xde, 0x8e, 0xde,
+	0xcf, 0xe1, 0xc0, 0xac, 0x72, 0xaa, 0x56, 0xbe, 0xea, 0xbc, 0x15, 0xde, 0x88, 0x9d, 0x14, 0xbd,
+	0x83, 0xb6, 0x67, 0xeb, 0x3a, 0xf7, 0x1c, 0xaa, 0x06, 0x70, 0x7d, 0x3b, 0xec, 0x16, 0xbf, 0x6e,
+	0x47, 0x82, 0x7f, 0x5a, 0xd8, 0x47, 0x18, 0x5b, 0x93, 0xab, 0x5d, 0xf3, 0x8b, 0xf7, 0xd5, 0xbf,
+	0x03, 0x00, 0x86, 0xb5, 0x92, 0xb3, 0xe3, 0x0b, 0x00, 0x00,
 }
@@ -13,6 +13,33 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type ImportJobStatus int32
+
+const (
+	ImportJobStatus_Create    ImportJobStatus = 0
+	ImportJobStatus_Update    ImportJobStatus = 1
+	ImportJobStatus_Unchanged ImportJobStatus = 2
+	ImportJobStatus_Invalid   ImportJobStatus = 3
+)
+
+var ImportJobStatus_name = map[int32]string{
+	0: "Create",
+	1: "Update",
+	2: "Unchanged",
+	3: "Invalid",
+}
+var ImportJobStatus_value = map[string]int32{
+	"Create":    0,
+	"Update":    1,
+	"Unchanged": 2,
+	"Invalid":   3,
+}
+
+func (x ImportJobStatus) String() string {
+	return proto.EnumName(ImportJobStatus_name, int32(x))
+}
+func (ImportJobStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{0} }
+
 type UserJobRequest struct {
 	// Name of the job to create in the user space
 	JobName string `protobuf:"bytes,1,opt,name=JobName" json:"JobName,omitempty"`
@@ -71,28 +98,296 @@ func (m *UserJobsCollection) GetJobs() []*jobs.Job {
 	return nil
 }
 
+type SchedulePreviewRequest struct {
+	// Schedule to evaluate
+	Schedule *jobs.Schedule `protobuf:"bytes,1,opt,name=Schedule" json:"Schedule,omitempty"`
+	// Number of occurrences to compute (default 5)
+	Count int32 `protobuf:"varint,2,opt,name=Count" json:"Count,omitempty"`
+}
+
+func (m *SchedulePreviewRequest) Reset()                    { *m = SchedulePreviewRequest{} }
+func (m *SchedulePreviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*SchedulePreviewRequest) ProtoMessage()               {}
+func (*SchedulePreviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{3} }
+
+func (m *SchedulePreviewRequest) GetSchedule() *jobs.Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func (m *SchedulePreviewRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type SchedulePreviewResponse struct {
+	// Next run times, as unix timestamps
+	NextRuns []int32 `protobuf:"varint,1,rep,packed,name=NextRuns" json:"NextRuns,omitempty"`
+}
+
+func (m *SchedulePreviewResponse) Reset()                    { *m = SchedulePreviewResponse{} }
+func (m *SchedulePreviewResponse) String() string            { return proto.CompactTextString(m) }
+func (*SchedulePreviewResponse) ProtoMessage()               {}
+func (*SchedulePreviewResponse) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{4} }
+
+func (m *SchedulePreviewResponse) GetNextRuns() []int32 {
+	if m != nil {
+		return m.NextRuns
+	}
+	return nil
+}
+
+// JobsBundle is a portable and versioned document containing jobs definitions
+type JobsBundle struct {
+	// Format version of this bundle
+	Version string `protobuf:"bytes,1,opt,name=Version" json:"Version,omitempty"`
+	// Export date, as unix timestamp
+	Created int32 `protobuf:"varint,2,opt,name=Created" json:"Created,omitempty"`
+	// Exported jobs, without their tasks
+	Jobs []*jobs.Job `protobuf:"bytes,3,rep,name=Jobs" json:"Jobs,omitempty"`
+}
+
+func (m *JobsBundle) Reset()                    { *m = JobsBundle{} }
+func (m *JobsBundle) String() string            { return proto.CompactTextString(m) }
+func (*JobsBundle) ProtoMessage()               {}
+func (*JobsBundle) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{5} }
+
+func (m *JobsBundle) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *JobsBundle) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *JobsBundle) GetJobs() []*jobs.Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type ExportJobsRequest struct {
+	// Jobs to export. If empty, all custom jobs are exported
+	JobIDs []string `protobuf:"bytes,1,rep,name=JobIDs" json:"JobIDs,omitempty"`
+	// Output format, either "json" (default) or "yaml"
+	Format string `protobuf:"bytes,2,opt,name=Format" json:"Format,omitempty"`
+}
+
+func (m *ExportJobsRequest) Reset()                    { *m = ExportJobsRequest{} }
+func (m *ExportJobsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportJobsRequest) ProtoMessage()               {}
+func (*ExportJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{6} }
+
+func (m *ExportJobsRequest) GetJobIDs() []string {
+	if m != nil {
+		return m.JobIDs
+	}
+	return nil
+}
+
+func (m *ExportJobsRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type ExportJobsResponse struct {
+	// Serialized JobsBundle
+	Content string `protobuf:"bytes,1,opt,name=Content" json:"Content,omitempty"`
+	// Format of the content
+	Format string `protobuf:"bytes,2,opt,name=Format" json:"Format,omitempty"`
+}
+
+func (m *ExportJobsResponse) Reset()                    { *m = ExportJobsResponse{} }
+func (m *ExportJobsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportJobsResponse) ProtoMessage()               {}
+func (*ExportJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{7} }
+
+func (m *ExportJobsResponse) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ExportJobsResponse) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type ImportJobsRequest struct {
+	// Serialized JobsBundle, in JSON or YAML format
+	Content string `protobuf:"bytes,1,opt,name=Content" json:"Content,omitempty"`
+	// Replace owners of imported jobs: keys are original owners, values are new owners.
+	// Use "*" as key to replace all other owners.
+	OwnersMapping map[string]string `protobuf:"bytes,2,rep,name=OwnersMapping" json:"OwnersMapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Only compute changes, do not save anything
+	DryRun bool `protobuf:"varint,3,opt,name=DryRun" json:"DryRun,omitempty"`
+}
+
+func (m *ImportJobsRequest) Reset()                    { *m = ImportJobsRequest{} }
+func (m *ImportJobsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportJobsRequest) ProtoMessage()               {}
+func (*ImportJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{8} }
+
+func (m *ImportJobsRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ImportJobsRequest) GetOwnersMapping() map[string]string {
+	if m != nil {
+		return m.OwnersMapping
+	}
+	return nil
+}
+
+func (m *ImportJobsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportJobResult struct {
+	// Imported job ID
+	JobID string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	// Imported job Label
+	Label string `protobuf:"bytes,2,opt,name=Label" json:"Label,omitempty"`
+	// Status of the import
+	Status ImportJobStatus `protobuf:"varint,3,opt,name=Status,enum=rest.ImportJobStatus" json:"Status,omitempty"`
+	// Unified diff between the existing job and the imported one
+	Diff string `protobuf:"bytes,4,opt,name=Diff" json:"Diff,omitempty"`
+	// Validation or saving error
+	Error string `protobuf:"bytes,5,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *ImportJobResult) Reset()                    { *m = ImportJobResult{} }
+func (m *ImportJobResult) String() string            { return proto.CompactTextString(m) }
+func (*ImportJobResult) ProtoMessage()               {}
+func (*ImportJobResult) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{9} }
+
+func (m *ImportJobResult) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *ImportJobResult) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ImportJobResult) GetStatus() ImportJobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ImportJobStatus_Create
+}
+
+func (m *ImportJobResult) GetDiff() string {
+	if m != nil {
+		return m.Diff
+	}
+	return ""
+}
+
+func (m *ImportJobResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ImportJobsResponse struct {
+	Results []*ImportJobResult `protobuf:"bytes,1,rep,name=Results" json:"Results,omitempty"`
+}
+
+func (m *ImportJobsResponse) Reset()                    { *m = ImportJobsResponse{} }
+func (m *ImportJobsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportJobsResponse) ProtoMessage()               {}
+func (*ImportJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{10} }
+
+func (m *ImportJobsResponse) GetResults() []*ImportJobResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*UserJobRequest)(nil), "rest.UserJobRequest")
 	proto.RegisterType((*UserJobResponse)(nil), "rest.UserJobResponse")
 	proto.RegisterType((*UserJobsCollection)(nil), "rest.UserJobsCollection")
+	proto.RegisterType((*SchedulePreviewRequest)(nil), "rest.SchedulePreviewRequest")
+	proto.RegisterType((*SchedulePreviewResponse)(nil), "rest.SchedulePreviewResponse")
+	proto.RegisterType((*JobsBundle)(nil), "rest.JobsBundle")
+	proto.RegisterType((*ExportJobsRequest)(nil), "rest.ExportJobsRequest")
+	proto.RegisterType((*ExportJobsResponse)(nil), "rest.ExportJobsResponse")
+	proto.RegisterType((*ImportJobsRequest)(nil), "rest.ImportJobsRequest")
+	proto.RegisterType((*ImportJobResult)(nil), "rest.ImportJobResult")
+	proto.RegisterType((*ImportJobsResponse)(nil), "rest.ImportJobsResponse")
+	proto.RegisterEnum("rest.ImportJobStatus", ImportJobStatus_name, ImportJobStatus_value)
 }
 
 func init() { proto.RegisterFile("scheduler.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 209 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x8e, 0xc1, 0x4a, 0xc5, 0x30,
-	0x10, 0x45, 0xa9, 0x3e, 0x94, 0x17, 0xa1, 0x85, 0xac, 0x82, 0x20, 0x94, 0x2e, 0xa4, 0x20, 0x34,
-	0x60, 0x3f, 0xc1, 0x5d, 0x17, 0x22, 0x85, 0x7e, 0x40, 0x93, 0x0e, 0x36, 0x92, 0x64, 0x6a, 0x26,
-	0x59, 0xf8, 0xf7, 0xd2, 0x58, 0x45, 0xde, 0x66, 0xe0, 0x5e, 0xce, 0x1c, 0x2e, 0xab, 0x48, 0xaf,
-	0xb0, 0x24, 0x0b, 0xa1, 0xdb, 0x02, 0x46, 0xe4, 0xa7, 0x00, 0x14, 0xef, 0xfb, 0x77, 0x13, 0xd7,
-	0xa4, 0x3a, 0x8d, 0x4e, 0x6e, 0x5f, 0x8b, 0x41, 0xa9, 0xc1, 0x5a, 0x92, 0x1a, 0x9d, 0x43, 0x2f,
-	0x33, 0x2a, 0x3f, 0x50, 0x51, 0x3e, 0x3f, 0xaf, 0xcd, 0xc8, 0xca, 0x89, 0x20, 0x0c, 0xa8, 0x46,
-	0xf8, 0x4c, 0x40, 0x91, 0x0b, 0x76, 0x3b, 0xa0, 0x7a, 0x9d, 0x1d, 0x88, 0xa2, 0x2e, 0xda, 0xf3,
-	0xf8, 0x1b, 0xf9, 0x23, 0x2b, 0x07, 0x42, 0xff, 0x36, 0x87, 0xd9, 0x41, 0x84, 0x40, 0xe2, 0x2a,
-	0x03, 0x17, 0x6d, 0xf3, 0xc4, 0xaa, 0x3f, 0x27, 0x6d, 0xe8, 0x09, 0x0e, 0xe9, 0x94, 0xcc, 0xf2,
-	0x4f, 0xba, 0xc7, 0xa6, 0x67, 0xfc, 0x80, 0xe9, 0x05, 0xad, 0x05, 0x1d, 0x0d, 0x7a, 0xfe, 0xc0,
-	0x4e, 0x7b, 0x23, 0x8a, 0xfa, 0xba, 0xbd, 0x7b, 0x3e, 0x77, 0x79, 0xf1, 0x2e, 0xcc, 0xb5, 0xba,
-	0xc9, 0xe3, 0xfb, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x59, 0xb2, 0xcf, 0x09, 0x0a, 0x01, 0x00,
-	0x00,
+	// 581 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x5d, 0x6b, 0xdb, 0x3e,
+	0x14, 0xc6, 0xff, 0xce, 0x5b, 0x9b, 0x53, 0x9a, 0xa6, 0xe2, 0xbf, 0xce, 0x14, 0x06, 0xc5, 0x17,
+	0xa3, 0x74, 0xcc, 0x86, 0x96, 0xc1, 0xd8, 0xd5, 0x58, 0x9a, 0x42, 0xc2, 0xd6, 0x15, 0x95, 0xec,
+	0x62, 0x37, 0xc3, 0x2f, 0xa7, 0xad, 0x37, 0x5b, 0xf2, 0x24, 0x39, 0x6d, 0xbe, 0xc8, 0xbe, 0xdb,
+	0xbe, 0xcd, 0xd0, 0x8b, 0xb3, 0x34, 0x59, 0x6f, 0x82, 0x9e, 0x73, 0x8e, 0x7e, 0x7a, 0x1e, 0xcb,
+	0x31, 0xec, 0xc9, 0xf4, 0x0e, 0xb3, 0xba, 0x40, 0x11, 0x56, 0x82, 0x2b, 0x4e, 0x3a, 0x02, 0xa5,
+	0x3a, 0x3c, 0xbb, 0xcd, 0xd5, 0x5d, 0x9d, 0x84, 0x29, 0x2f, 0xa3, 0x6a, 0x91, 0xe5, 0x3c, 0x4a,
+	0xb1, 0x28, 0x64, 0x94, 0xf2, 0xb2, 0xe4, 0x2c, 0x32, 0xa3, 0xd1, 0x77, 0x9e, 0x48, 0xf3, 0x63,
+	0xb7, 0x06, 0x14, 0x06, 0x33, 0x89, 0x62, 0xca, 0x13, 0x8a, 0x3f, 0x6b, 0x94, 0x8a, 0xf8, 0xb0,
+	0x35, 0xe5, 0xc9, 0x65, 0x5c, 0xa2, 0xef, 0x1d, 0x79, 0xc7, 0x7d, 0xda, 0x48, 0xf2, 0x12, 0x06,
+	0x53, 0xc9, 0xd9, 0x55, 0x2c, 0xe2, 0x12, 0x15, 0x0a, 0xe9, 0xb7, 0xcc, 0xc0, 0x5a, 0x35, 0x78,
+	0x05, 0x7b, 0x4b, 0xa6, 0xac, 0x38, 0x93, 0xe8, 0xa0, 0xb3, 0x3a, 0xcf, 0x56, 0xa0, 0x5a, 0x06,
+	0x67, 0x40, 0xdc, 0xb0, 0x1c, 0xf1, 0xa2, 0xc0, 0x54, 0xe5, 0x9c, 0x91, 0x17, 0xd0, 0xd1, 0x15,
+	0xdf, 0x3b, 0x6a, 0x1f, 0xef, 0x9c, 0xf6, 0x43, 0xe3, 0x58, 0x03, 0x4d, 0x39, 0xf8, 0x0a, 0x07,
+	0xd7, 0xee, 0x19, 0x5c, 0x09, 0x9c, 0xe7, 0x78, 0xdf, 0xb8, 0x3f, 0x81, 0xed, 0xa6, 0x63, 0x4e,
+	0xda, 0x39, 0x1d, 0xd8, 0xcd, 0x4d, 0x95, 0x2e, 0xfb, 0xe4, 0x7f, 0xe8, 0x8e, 0x78, 0xcd, 0x94,
+	0x89, 0xd1, 0xa5, 0x56, 0x04, 0x6f, 0xe0, 0xf9, 0x06, 0xdb, 0xa5, 0x38, 0x84, 0xed, 0x4b, 0x7c,
+	0x50, 0xb4, 0x66, 0xd6, 0x59, 0x97, 0x2e, 0x75, 0xf0, 0x0d, 0x40, 0x5b, 0xfb, 0x50, 0xb3, 0xac,
+	0x30, 0x79, 0xbf, 0xa0, 0x90, 0x39, 0x67, 0x4d, 0x5e, 0x27, 0x75, 0x67, 0x24, 0x30, 0x56, 0x98,
+	0xb9, 0x63, 0x1b, 0xb9, 0xcc, 0xdc, 0xfe, 0x77, 0xe6, 0x11, 0xec, 0x8f, 0x1f, 0x2a, 0x2e, 0x94,
+	0x56, 0x4d, 0xdc, 0x03, 0xe8, 0x4d, 0x79, 0x32, 0x39, 0xb7, 0x7e, 0xfa, 0xd4, 0x29, 0x5d, 0xbf,
+	0xe0, 0xa2, 0x8c, 0x95, 0xbb, 0x22, 0xa7, 0x82, 0x0b, 0x20, 0xab, 0x90, 0xbf, 0xb7, 0x33, 0xe2,
+	0x4c, 0x21, 0x53, 0x8d, 0x5b, 0x27, 0x9f, 0xe4, 0xfc, 0xf6, 0x60, 0x7f, 0x52, 0xae, 0xbb, 0x79,
+	0x9a, 0x73, 0x05, 0xbb, 0x9f, 0xef, 0x19, 0x0a, 0xf9, 0x29, 0xae, 0xaa, 0x9c, 0xdd, 0xfa, 0x2d,
+	0x13, 0xf2, 0x24, 0xd4, 0x6f, 0x6e, 0xb8, 0x41, 0x0a, 0x1f, 0x0d, 0x8f, 0x99, 0x12, 0x0b, 0xfa,
+	0x18, 0xa0, 0x9d, 0x9d, 0x8b, 0x05, 0xad, 0x99, 0xdf, 0x3e, 0xf2, 0x8e, 0xb7, 0xa9, 0x53, 0x87,
+	0xef, 0x81, 0x6c, 0x6e, 0x26, 0x43, 0x68, 0xff, 0xc0, 0x85, 0x73, 0xa5, 0x97, 0xfa, 0xf2, 0xe7,
+	0x71, 0x51, 0xa3, 0x0b, 0x66, 0xc5, 0xbb, 0xd6, 0x5b, 0x2f, 0xf8, 0xe5, 0xc1, 0xde, 0xd2, 0x11,
+	0x45, 0x59, 0x17, 0x4a, 0x4f, 0x9b, 0x27, 0xeb, 0x08, 0x56, 0xe8, 0xea, 0xc7, 0x38, 0xc1, 0xa2,
+	0x61, 0x18, 0x41, 0x5e, 0x43, 0xef, 0x5a, 0xc5, 0xaa, 0x96, 0xc6, 0xd9, 0xe0, 0xf4, 0xd9, 0x5a,
+	0x48, 0xdb, 0xa4, 0x6e, 0x88, 0x10, 0xe8, 0x9c, 0xe7, 0x37, 0x37, 0x7e, 0xc7, 0x30, 0xcc, 0x5a,
+	0x83, 0xc7, 0x42, 0x70, 0xe1, 0x77, 0x2d, 0xd8, 0x88, 0x60, 0x0c, 0x64, 0x52, 0x6e, 0x5c, 0x5e,
+	0x04, 0x5b, 0xd6, 0x64, 0xf3, 0x6f, 0x59, 0x3f, 0xcf, 0x76, 0x69, 0x33, 0x75, 0x32, 0x5e, 0x89,
+	0xe7, 0x3c, 0x00, 0xf4, 0xec, 0x5b, 0x38, 0xfc, 0x4f, 0xaf, 0x67, 0x55, 0xa6, 0xd7, 0x1e, 0xd9,
+	0x85, 0xfe, 0x8c, 0xa5, 0x77, 0x31, 0xbb, 0xc5, 0x6c, 0xd8, 0x22, 0x3b, 0xb0, 0x35, 0x61, 0xf3,
+	0xb8, 0xc8, 0xb3, 0x61, 0x3b, 0xe9, 0x99, 0x0f, 0xc8, 0xd9, 0x9f, 0x01, 0x00, 0x17, 0xab, 0x99,
+	0x8b, 0x8e, 0x04, 0x00, 0x00,
 }
@@ -30,3 +30,47 @@ func (this *UserJobsCollection) Validate() error {
 	}
 	return nil
 }
+func (this *SchedulePreviewRequest) Validate() error {
+	if this.Schedule != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Schedule); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Schedule", err)
+		}
+	}
+	return nil
+}
+func (this *SchedulePreviewResponse) Validate() error {
+	return nil
+}
+func (this *JobsBundle) Validate() error {
+	for _, item := range this.Jobs {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Jobs", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *ExportJobsRequest) Validate() error {
+	return nil
+}
+func (this *ExportJobsResponse) Validate() error {
+	return nil
+}
+func (this *ImportJobsRequest) Validate() error {
+	// Validation of proto3 map<> fields is unsupported.
+	return nil
+}
+func (this *ImportJobResult) Validate() error {
+	return nil
+}
+func (this *ImportJobsResponse) Validate() error {
+	for _, item := range this.Results {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Results", err)
+			}
+		}
+	}
+	return nil
+}
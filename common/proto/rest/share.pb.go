@@ -247,7 +247,7 @@ type ShareLink struct {
 	UserLogin string `protobuf:"bytes,7,opt,name=UserLogin" json:"UserLogin,omitempty"`
 	// Whether a password is required or not to access the link
 	PasswordRequired bool `protobuf:"varint,8,opt,name=PasswordRequired" json:"PasswordRequired,omitempty"`
-	// Timestamp of start date for enabling the share (not implemented yet)
+	// Timestamp of start date for enabling the share
 	AccessStart int64 `protobuf:"varint,9,opt,name=AccessStart" json:"AccessStart,omitempty"`
 	// Timestamp after which the share is disabled
 	AccessEnd int64 `protobuf:"varint,10,opt,name=AccessEnd" json:"AccessEnd,omitempty"`
@@ -269,6 +269,8 @@ type ShareLink struct {
 	Policies []*service.ResourcePolicy `protobuf:"bytes,18,rep,name=Policies" json:"Policies,omitempty"`
 	// Whether policies are currently editable or not
 	PoliciesContextEditable bool `protobuf:"varint,19,opt,name=PoliciesContextEditable" json:"PoliciesContextEditable,omitempty"`
+	// Whether the link is scheduled and not active yet (AccessStart is in the future)
+	Pending bool `protobuf:"varint,20,opt,name=Pending" json:"Pending,omitempty"`
 }
 
 func (m *ShareLink) Reset()                    { *m = ShareLink{} }
@@ -409,6 +411,13 @@ func (m *ShareLink) GetPoliciesContextEditable() bool {
 	return false
 }
 
+func (m *ShareLink) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
 // Request for creating a Cell
 type PutCellRequest struct {
 	// Content of the Cell (Room is legacy name)
@@ -815,84 +824,84 @@ func init() {
 func init() { proto.RegisterFile("share.proto", fileDescriptor9) }
 
 var fileDescriptor9 = []byte{
-	// 1250 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0xdb, 0x46,
-	0x13, 0x8d, 0x7e, 0xa8, 0x9f, 0x51, 0x2c, 0x2b, 0xeb, 0x7c, 0x09, 0x3f, 0xa5, 0x49, 0x04, 0x26,
-	0x68, 0x95, 0xa0, 0xa1, 0x5a, 0xbb, 0x28, 0x82, 0xf6, 0x4a, 0x91, 0xdd, 0x34, 0xad, 0xea, 0x18,
-	0x6b, 0xab, 0x40, 0x7a, 0x47, 0x93, 0x1b, 0x87, 0x0d, 0xc9, 0x55, 0xb9, 0x4b, 0x2b, 0x7a, 0x85,
-	0x3e, 0x48, 0x2f, 0xfa, 0x28, 0x7d, 0x89, 0x02, 0xbd, 0x2a, 0x8a, 0x02, 0xbd, 0xe9, 0x03, 0x14,
-	0x3b, 0x4b, 0x8a, 0x94, 0xac, 0x28, 0x46, 0xd1, 0x0b, 0x1b, 0x3b, 0x67, 0x66, 0x76, 0x77, 0xce,
-	0xce, 0x19, 0x11, 0x5a, 0xe2, 0x95, 0x13, 0x33, 0x7b, 0x1a, 0x73, 0xc9, 0x49, 0x35, 0x66, 0x42,
-	0x76, 0x1f, 0x9f, 0xf9, 0xf2, 0x55, 0x72, 0x6a, 0xbb, 0x3c, 0x1c, 0x4c, 0xe7, 0x9e, 0xcf, 0x07,
-	0x2e, 0x0b, 0x02, 0x31, 0x70, 0x79, 0x18, 0xf2, 0x68, 0x20, 0x58, 0x7c, 0xee, 0xbb, 0x6c, 0x80,
-	0x29, 0x29, 0xa8, 0xf3, 0xbb, 0x1f, 0x6f, 0xce, 0xd4, 0x19, 0xbe, 0x17, 0xaa, 0xbf, 0x34, 0x65,
-	0xef, 0x32, 0x29, 0x32, 0x66, 0x0c, 0xff, 0xa5, 0x49, 0x9f, 0x16, 0x92, 0xc2, 0x99, 0x2f, 0x5f,
-	0xf3, 0xd9, 0xe0, 0x8c, 0x3f, 0x42, 0xe7, 0xa3, 0x73, 0x27, 0xf0, 0x3d, 0x47, 0xf2, 0x58, 0x0c,
-	0x16, 0x4b, 0x9d, 0x67, 0xfd, 0x52, 0x82, 0xfa, 0x88, 0x05, 0xc1, 0xd0, 0x0d, 0xc8, 0x0d, 0xa8,
-	0x51, 0x1e, 0xb0, 0x67, 0x9e, 0x59, 0xea, 0x95, 0xfa, 0x4d, 0x9a, 0x5a, 0xa4, 0x0f, 0xf5, 0xa1,
-	0x2b, 0x7d, 0x1e, 0x09, 0xb3, 0xdc, 0xab, 0xf4, 0x5b, 0xbb, 0x6d, 0x5b, 0xdd, 0x76, 0x38, 0x1a,
-	0x6b, 0x98, 0x66, 0x6e, 0x72, 0x07, 0xe0, 0x99, 0x98, 0x08, 0x16, 0xab, 0x4c, 0xb3, 0xd2, 0x2b,
-	0xf5, 0x1b, 0xb4, 0x80, 0x90, 0xdb, 0x50, 0x55, 0x6b, 0xb3, 0xda, 0x2b, 0xf5, 0x5b, 0xbb, 0x4d,
-	0xdc, 0x06, 0x9d, 0x08, 0x93, 0xbb, 0x60, 0x3c, 0x8d, 0x79, 0x32, 0x35, 0x8d, 0x55, 0xbf, 0xc6,
-	0x55, 0x3e, 0xee, 0x5c, 0x2b, 0xf8, 0x15, 0x40, 0x11, 0xb6, 0xfe, 0x2a, 0x43, 0x55, 0x15, 0x43,
-	0x08, 0x54, 0x27, 0x89, 0x9f, 0xd5, 0x81, 0x6b, 0x72, 0x1b, 0x8c, 0xb1, 0x73, 0xca, 0x02, 0xb3,
-	0xac, 0xc0, 0x27, 0xf5, 0xdf, 0x7e, 0xbd, 0x5b, 0x79, 0xf3, 0x77, 0x85, 0x6a, 0x94, 0x3c, 0x80,
-	0xd6, 0x3e, 0x13, 0x6e, 0xec, 0x4f, 0x55, 0x29, 0x78, 0xf7, 0x2c, 0xe8, 0xf7, 0x3a, 0x2d, 0xfa,
-	0x48, 0x1f, 0x9a, 0x94, 0x73, 0x79, 0xc8, 0x3d, 0x26, 0xcc, 0x2a, 0x32, 0x02, 0x36, 0xbe, 0x85,
-	0x82, 0x68, 0xee, 0x24, 0x7d, 0xa8, 0x0e, 0x47, 0x63, 0x61, 0x1a, 0x18, 0x74, 0xdd, 0x56, 0xcd,
-	0x64, 0xab, 0x1b, 0x2a, 0xf2, 0xc4, 0x41, 0x24, 0xe3, 0x39, 0xc5, 0x08, 0xb2, 0x07, 0x8d, 0x23,
-	0x1e, 0xf8, 0xae, 0xcf, 0x84, 0x59, 0xc3, 0xe8, 0x9b, 0x76, 0xda, 0x56, 0x36, 0x65, 0x82, 0x27,
-	0xb1, 0xcb, 0x30, 0x60, 0x4e, 0x17, 0x81, 0xe4, 0x31, 0xdc, 0xcc, 0xd6, 0x23, 0x1e, 0x49, 0xf6,
-	0x46, 0x1e, 0x78, 0xbe, 0x74, 0x4e, 0x03, 0x66, 0xd6, 0x91, 0xfb, 0xb7, 0xb9, 0xbb, 0x5f, 0x40,
-	0x73, 0x71, 0x03, 0xd2, 0x81, 0xca, 0x6b, 0x36, 0x4f, 0xc9, 0x52, 0x4b, 0x72, 0x0f, 0x8c, 0x73,
-	0x27, 0x48, 0x18, 0x72, 0xd5, 0xda, 0xdd, 0xca, 0x2f, 0x3e, 0x74, 0x03, 0xaa, 0x7d, 0x9f, 0x95,
-	0x1f, 0x97, 0xac, 0x09, 0xec, 0x1c, 0x2b, 0xb5, 0x8c, 0xfd, 0xe8, 0xf5, 0x89, 0x13, 0x9f, 0x31,
-	0x89, 0x0f, 0x69, 0x42, 0x7d, 0xdf, 0x17, 0xd3, 0xc0, 0xc9, 0x76, 0xcd, 0x4c, 0x72, 0x1f, 0xb6,
-	0xf6, 0xf9, 0x2c, 0x0a, 0xb8, 0xe3, 0x8d, 0x78, 0x12, 0x49, 0x3c, 0xc1, 0xa0, 0xcb, 0xa0, 0xf5,
-	0x67, 0x0d, 0x9a, 0x8b, 0x7d, 0xd7, 0xbe, 0x66, 0x17, 0x1a, 0xca, 0xf7, 0xa5, 0x23, 0x5e, 0xe9,
-	0x07, 0xa5, 0x0b, 0x5b, 0x9d, 0xae, 0xd6, 0x93, 0x38, 0xd0, 0xcf, 0x48, 0x33, 0x33, 0xef, 0x81,
-	0xea, 0x65, 0x7a, 0xc0, 0xd8, 0xd0, 0x03, 0x5d, 0x68, 0xa8, 0x4a, 0xf1, 0x5e, 0x35, 0x7d, 0x7e,
-	0x66, 0x93, 0xf7, 0xa0, 0xa9, 0xd6, 0x63, 0x7e, 0xe6, 0x47, 0xf8, 0x10, 0x4d, 0x9a, 0x03, 0xe4,
-	0x21, 0x74, 0x8e, 0x1c, 0x21, 0x66, 0x3c, 0xf6, 0x28, 0xfb, 0x21, 0xf1, 0x63, 0xe6, 0x99, 0x0d,
-	0x7c, 0xad, 0x0b, 0x38, 0xe9, 0x41, 0x6b, 0xe8, 0xba, 0x4c, 0x88, 0x63, 0xe9, 0xc4, 0xd2, 0x6c,
-	0xf6, 0x4a, 0xfd, 0x0a, 0x2d, 0x42, 0xea, 0x2c, 0x6d, 0x1e, 0x44, 0x9e, 0x09, 0xe8, 0xcf, 0x01,
-	0x62, 0xc1, 0xd5, 0x6f, 0x9c, 0x37, 0x19, 0xb7, 0xc2, 0x6c, 0x61, 0xc0, 0x12, 0xa6, 0xee, 0x33,
-	0x4a, 0xe2, 0x98, 0x45, 0x32, 0x8f, 0xbb, 0x8a, 0x71, 0x17, 0x70, 0x15, 0xfb, 0xad, 0xcf, 0x66,
-	0x27, 0x2c, 0x9c, 0x06, 0x8e, 0x64, 0x87, 0x4e, 0xc8, 0xcc, 0x2d, 0x2c, 0xf0, 0x02, 0x4e, 0x9e,
-	0x40, 0x2b, 0xef, 0x08, 0x61, 0xb6, 0xb1, 0xa9, 0x7b, 0xba, 0x93, 0x16, 0x6f, 0x6b, 0x17, 0x42,
-	0xb4, 0x1c, 0x8a, 0x49, 0xe4, 0x13, 0xf8, 0x1f, 0x65, 0x42, 0xc6, 0xbe, 0x2b, 0x4f, 0x78, 0x71,
-	0xb7, 0x6d, 0x24, 0x6c, 0xbd, 0x73, 0x59, 0x9f, 0x9d, 0x4d, 0xfa, 0xfc, 0x1c, 0x5a, 0x47, 0x2c,
-	0x0e, 0x7d, 0x21, 0x70, 0xba, 0x5d, 0xeb, 0x55, 0xfa, 0xed, 0xdd, 0xff, 0xaf, 0xdc, 0x51, 0xd3,
-	0x79, 0x32, 0x9f, 0x32, 0x5a, 0x8c, 0x5e, 0x92, 0x2c, 0xf9, 0x0f, 0x24, 0xbb, 0xb3, 0x59, 0xb2,
-	0x2f, 0xa0, 0xb3, 0x4a, 0xd6, 0x1a, 0xe5, 0x0e, 0x96, 0x95, 0xbb, 0x5a, 0x4b, 0xbe, 0x43, 0x51,
-	0xc5, 0xdf, 0x41, 0xfb, 0x28, 0x91, 0x4a, 0xde, 0xaa, 0xf3, 0x98, 0x90, 0xe4, 0x8e, 0x1a, 0xb4,
-	0x3c, 0xc4, 0x9d, 0x15, 0x7b, 0x0b, 0xfd, 0x53, 0xc4, 0x49, 0x1f, 0xb6, 0x47, 0x31, 0x73, 0x24,
-	0x3b, 0x08, 0xa7, 0x72, 0xae, 0x08, 0xc5, 0x03, 0x1b, 0x74, 0x15, 0xb6, 0xee, 0x43, 0xfb, 0x29,
-	0x5b, 0xda, 0x7b, 0x8d, 0x9c, 0xad, 0x0f, 0xe0, 0xda, 0x3e, 0x0b, 0x98, 0x64, 0xef, 0x0a, 0xb4,
-	0x81, 0x14, 0x03, 0xc5, 0x94, 0x47, 0x82, 0x29, 0xc5, 0x1f, 0x27, 0xf8, 0x4c, 0x18, 0xdc, 0xa0,
-	0x99, 0x69, 0x3d, 0x80, 0x9d, 0xa7, 0x4c, 0x2e, 0xea, 0xdf, 0xb4, 0xf5, 0x1f, 0x25, 0xd8, 0x39,
-	0x4a, 0x2e, 0xc6, 0x3e, 0x2a, 0xcc, 0xa2, 0x94, 0x90, 0xed, 0x15, 0x5a, 0x69, 0x61, 0x5a, 0xf5,
-	0x61, 0x3b, 0xd3, 0xf1, 0x41, 0xa4, 0x5e, 0xce, 0xcb, 0xa8, 0x59, 0x81, 0xc9, 0xfb, 0xd0, 0xd6,
-	0x6c, 0x65, 0x8e, 0x74, 0x5c, 0xad, 0xa0, 0x2a, 0x6e, 0x32, 0xf5, 0x8a, 0x71, 0x55, 0x1d, 0xb7,
-	0x8c, 0x2a, 0x75, 0x6a, 0x64, 0x94, 0x08, 0xc9, 0x43, 0x9c, 0x8d, 0x86, 0x56, 0xe7, 0x2a, 0x6e,
-	0x7d, 0x08, 0x37, 0x34, 0x8f, 0x97, 0xa2, 0x66, 0x0f, 0x6e, 0x5e, 0x88, 0x7e, 0x27, 0xf5, 0x3f,
-	0x96, 0xa1, 0x3b, 0xf6, 0x85, 0x26, 0xd4, 0xcb, 0x14, 0x21, 0xb2, 0x73, 0xc6, 0x29, 0xad, 0x4a,
-	0x58, 0x98, 0xda, 0xde, 0xb5, 0x35, 0xad, 0x6f, 0x4f, 0xca, 0x5d, 0x28, 0xc7, 0x7c, 0x03, 0x7d,
-	0x8d, 0xd3, 0xef, 0x99, 0x2b, 0xb3, 0x99, 0x9f, 0x9a, 0x8a, 0xbd, 0xe7, 0xb3, 0x88, 0x79, 0x4f,
-	0xe6, 0x59, 0x40, 0x15, 0xef, 0xb9, 0x82, 0xaa, 0xaf, 0x9f, 0xe7, 0x2f, 0x5f, 0x0a, 0x26, 0x91,
-	0x33, 0x83, 0xa6, 0x16, 0xb9, 0x0e, 0xc6, 0xd8, 0x0f, 0x7d, 0x89, 0x63, 0xde, 0xa0, 0xda, 0xb0,
-	0x6c, 0xd8, 0x5a, 0xba, 0x0b, 0xa9, 0x43, 0x65, 0x78, 0xf8, 0xa2, 0x73, 0x85, 0x34, 0xc1, 0x18,
-	0x3f, 0x3b, 0xfc, 0xfa, 0xb8, 0x53, 0x52, 0xcb, 0xd1, 0xc1, 0x78, 0x7c, 0xdc, 0x29, 0x5b, 0x3f,
-	0x97, 0xe1, 0xd6, 0xda, 0xba, 0x52, 0x1a, 0x0f, 0xa1, 0xb9, 0x00, 0xcd, 0x12, 0x4e, 0x93, 0x8f,
-	0x36, 0xb0, 0xa1, 0xb3, 0xec, 0x65, 0x9c, 0xe6, 0x5b, 0x14, 0xaa, 0x29, 0xaf, 0xaf, 0xa6, 0x52,
-	0xa8, 0x46, 0xa1, 0x27, 0x5c, 0x3a, 0xfa, 0x77, 0xd1, 0xa0, 0xda, 0xe8, 0xce, 0xa0, 0xbd, 0x7c,
-	0x80, 0x1a, 0x0b, 0x6a, 0x70, 0x2e, 0xc6, 0x42, 0x3e, 0x54, 0x11, 0x27, 0xf7, 0xa0, 0x8a, 0x2a,
-	0x29, 0xaf, 0x57, 0x09, 0x3a, 0x49, 0x0f, 0x0c, 0x25, 0x5e, 0x61, 0x56, 0xd2, 0xd1, 0x9c, 0x0f,
-	0x17, 0xed, 0xb0, 0x18, 0x74, 0x75, 0xc3, 0x62, 0x6a, 0x36, 0x10, 0x37, 0x34, 0xe8, 0xd2, 0x2c,
-	0x2e, 0x5f, 0x72, 0x16, 0x5b, 0x3f, 0x95, 0xe0, 0xd6, 0xda, 0x73, 0xde, 0xd5, 0xda, 0xff, 0xea,
-	0xb8, 0x4d, 0xa3, 0xbf, 0xb2, 0x71, 0xf4, 0x3f, 0xfc, 0xaa, 0xf0, 0x95, 0x95, 0xff, 0x1a, 0x91,
-	0xab, 0xd0, 0x38, 0xe4, 0xda, 0xee, 0x5c, 0x21, 0x2d, 0xa8, 0x1f, 0xc5, 0xec, 0xdc, 0x67, 0xb3,
-	0x4e, 0x49, 0xb9, 0xb2, 0x5f, 0xed, 0x4e, 0x99, 0x00, 0xd4, 0x26, 0x53, 0x5c, 0x57, 0x4e, 0x6b,
-	0xf8, 0xdd, 0xbf, 0xf7, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xa2, 0x0f, 0xc2, 0xe6, 0x0c,
-	0x00, 0x00,
+	// 1258 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xd1, 0x72, 0xdb, 0x44,
+	0x17, 0xae, 0x2d, 0x2b, 0xb6, 0x8f, 0x1b, 0xd7, 0xdd, 0xf4, 0x6f, 0xf5, 0xbb, 0xb4, 0xf5, 0xa8,
+	0x1d, 0x70, 0x3b, 0x54, 0x86, 0x84, 0x61, 0x3a, 0x70, 0xe5, 0x3a, 0xa1, 0x14, 0x4c, 0xea, 0xd9,
+	0xc4, 0xcc, 0x94, 0x3b, 0x45, 0xda, 0xa6, 0xa2, 0xb2, 0xd6, 0x68, 0x57, 0x71, 0xfd, 0x02, 0x5c,
+	0xf0, 0x20, 0x5c, 0xf0, 0x28, 0xbc, 0x04, 0x33, 0x5c, 0x31, 0xdc, 0x70, 0xc3, 0x03, 0x30, 0x7b,
+	0x56, 0xb2, 0x64, 0xc7, 0x75, 0x32, 0x0c, 0x17, 0xc9, 0xec, 0xf9, 0xce, 0x77, 0x76, 0xf7, 0x7c,
+	0x7b, 0xce, 0x51, 0x02, 0x0d, 0xf1, 0xda, 0x8d, 0x99, 0x33, 0x8d, 0xb9, 0xe4, 0xa4, 0x12, 0x33,
+	0x21, 0xdb, 0x4f, 0x4e, 0x03, 0xf9, 0x3a, 0x39, 0x71, 0x3c, 0x3e, 0xe9, 0x4d, 0xe7, 0x7e, 0xc0,
+	0x7b, 0x1e, 0x0b, 0x43, 0xd1, 0xf3, 0xf8, 0x64, 0xc2, 0xa3, 0x9e, 0x60, 0xf1, 0x59, 0xe0, 0xb1,
+	0x1e, 0x86, 0xa4, 0xa0, 0x8e, 0x6f, 0x7f, 0xbc, 0x39, 0x52, 0x47, 0x04, 0xfe, 0x44, 0xfd, 0xa4,
+	0x21, 0x7b, 0x97, 0x09, 0x91, 0x31, 0x63, 0xf8, 0x2b, 0x0d, 0xfa, 0xb4, 0x10, 0x34, 0x99, 0x05,
+	0xf2, 0x0d, 0x9f, 0xf5, 0x4e, 0xf9, 0x63, 0x74, 0x3e, 0x3e, 0x73, 0xc3, 0xc0, 0x77, 0x25, 0x8f,
+	0x45, 0x6f, 0xb1, 0xd4, 0x71, 0xf6, 0xaf, 0x25, 0xa8, 0x0e, 0x58, 0x18, 0xf6, 0xbd, 0x90, 0xdc,
+	0x84, 0x2d, 0xca, 0x43, 0xf6, 0xdc, 0xb7, 0x4a, 0x9d, 0x52, 0xb7, 0x4e, 0x53, 0x8b, 0x74, 0xa1,
+	0xda, 0xf7, 0x64, 0xc0, 0x23, 0x61, 0x95, 0x3b, 0x46, 0xb7, 0xb1, 0xdb, 0x74, 0xd4, 0x6d, 0xfb,
+	0x83, 0xa1, 0x86, 0x69, 0xe6, 0x26, 0x77, 0x01, 0x9e, 0x8b, 0xb1, 0x60, 0xb1, 0x8a, 0xb4, 0x8c,
+	0x4e, 0xa9, 0x5b, 0xa3, 0x05, 0x84, 0xdc, 0x81, 0x8a, 0x5a, 0x5b, 0x95, 0x4e, 0xa9, 0xdb, 0xd8,
+	0xad, 0xe3, 0x36, 0xe8, 0x44, 0x98, 0xdc, 0x03, 0xf3, 0x59, 0xcc, 0x93, 0xa9, 0x65, 0xae, 0xfa,
+	0x35, 0xae, 0xe2, 0x71, 0xe7, 0xad, 0x82, 0x5f, 0x01, 0x14, 0x61, 0xfb, 0xaf, 0x32, 0x54, 0x54,
+	0x32, 0x84, 0x40, 0x65, 0x9c, 0x04, 0x59, 0x1e, 0xb8, 0x26, 0x77, 0xc0, 0x1c, 0xba, 0x27, 0x2c,
+	0xb4, 0xca, 0x0a, 0x7c, 0x5a, 0xfd, 0xfd, 0xb7, 0x7b, 0xc6, 0xdb, 0xbf, 0x0d, 0xaa, 0x51, 0xf2,
+	0x10, 0x1a, 0xfb, 0x4c, 0x78, 0x71, 0x30, 0x55, 0xa9, 0x58, 0x46, 0x81, 0xf4, 0x47, 0x95, 0x16,
+	0x7d, 0xa4, 0x0b, 0x75, 0xca, 0xb9, 0x3c, 0xe4, 0x3e, 0x13, 0x56, 0x05, 0x15, 0x01, 0x07, 0xdf,
+	0x42, 0x41, 0x34, 0x77, 0x92, 0x2e, 0x54, 0xfa, 0x83, 0xa1, 0xb0, 0x4c, 0x24, 0xdd, 0x70, 0x54,
+	0x31, 0x39, 0xea, 0x86, 0x4a, 0x3c, 0x71, 0x10, 0xc9, 0x78, 0x4e, 0x91, 0x41, 0xf6, 0xa0, 0x36,
+	0xe2, 0x61, 0xe0, 0x05, 0x4c, 0x58, 0x5b, 0xc8, 0xbe, 0xe5, 0xa4, 0x65, 0xe5, 0x50, 0x26, 0x78,
+	0x12, 0x7b, 0x0c, 0x09, 0x73, 0xba, 0x20, 0x92, 0x27, 0x70, 0x2b, 0x5b, 0x0f, 0x78, 0x24, 0xd9,
+	0x5b, 0x79, 0xe0, 0x07, 0xd2, 0x3d, 0x09, 0x99, 0x55, 0x45, 0xed, 0xdf, 0xe5, 0x6e, 0x7f, 0x01,
+	0xf5, 0xc5, 0x0d, 0x48, 0x0b, 0x8c, 0x37, 0x6c, 0x9e, 0x8a, 0xa5, 0x96, 0xe4, 0x3e, 0x98, 0x67,
+	0x6e, 0x98, 0x30, 0xd4, 0xaa, 0xb1, 0xbb, 0x9d, 0x5f, 0xbc, 0xef, 0x85, 0x54, 0xfb, 0x3e, 0x2b,
+	0x3f, 0x29, 0xd9, 0x63, 0xd8, 0x39, 0x52, 0xdd, 0x32, 0x0c, 0xa2, 0x37, 0xc7, 0x6e, 0x7c, 0xca,
+	0x24, 0x3e, 0xa4, 0x05, 0xd5, 0xfd, 0x40, 0x4c, 0x43, 0x37, 0xdb, 0x35, 0x33, 0xc9, 0x03, 0xd8,
+	0xde, 0xe7, 0xb3, 0x28, 0xe4, 0xae, 0x3f, 0xe0, 0x49, 0x24, 0xf1, 0x04, 0x93, 0x2e, 0x83, 0xf6,
+	0x8f, 0x55, 0xa8, 0x2f, 0xf6, 0x5d, 0xfb, 0x9a, 0x6d, 0xa8, 0x29, 0xdf, 0x97, 0xae, 0x78, 0xad,
+	0x1f, 0x94, 0x2e, 0x6c, 0x75, 0xba, 0x5a, 0x8f, 0xe3, 0x50, 0x3f, 0x23, 0xcd, 0xcc, 0xbc, 0x06,
+	0x2a, 0x97, 0xa9, 0x01, 0x73, 0x43, 0x0d, 0xb4, 0xa1, 0xa6, 0x32, 0xc5, 0x7b, 0x6d, 0xe9, 0xf3,
+	0x33, 0x9b, 0xbc, 0x07, 0x75, 0xb5, 0x1e, 0xf2, 0xd3, 0x20, 0xc2, 0x87, 0xa8, 0xd3, 0x1c, 0x20,
+	0x8f, 0xa0, 0x35, 0x72, 0x85, 0x98, 0xf1, 0xd8, 0xa7, 0xec, 0x87, 0x24, 0x88, 0x99, 0x6f, 0xd5,
+	0xf0, 0xb5, 0xce, 0xe1, 0xa4, 0x03, 0x8d, 0xbe, 0xe7, 0x31, 0x21, 0x8e, 0xa4, 0x1b, 0x4b, 0xab,
+	0xde, 0x29, 0x75, 0x0d, 0x5a, 0x84, 0xd4, 0x59, 0xda, 0x3c, 0x88, 0x7c, 0x0b, 0xd0, 0x9f, 0x03,
+	0xc4, 0x86, 0xab, 0xdf, 0xb8, 0x6f, 0x33, 0x6d, 0x85, 0xd5, 0x40, 0xc2, 0x12, 0xa6, 0xee, 0x33,
+	0x48, 0xe2, 0x98, 0x45, 0x32, 0xe7, 0x5d, 0x45, 0xde, 0x39, 0x5c, 0x71, 0xbf, 0x0d, 0xd8, 0xec,
+	0x98, 0x4d, 0xa6, 0xa1, 0x2b, 0xd9, 0xa1, 0x3b, 0x61, 0xd6, 0x36, 0x26, 0x78, 0x0e, 0x27, 0x4f,
+	0xa1, 0x91, 0x57, 0x84, 0xb0, 0x9a, 0x58, 0xd4, 0x1d, 0x5d, 0x49, 0x8b, 0xb7, 0x75, 0x0a, 0x14,
+	0xdd, 0x0e, 0xc5, 0x20, 0xf2, 0x09, 0xfc, 0x8f, 0x32, 0x21, 0xe3, 0xc0, 0x93, 0xc7, 0xbc, 0xb8,
+	0xdb, 0x35, 0x14, 0x6c, 0xbd, 0x73, 0xb9, 0x3f, 0x5b, 0x9b, 0xfa, 0xf3, 0x73, 0x68, 0x8c, 0x58,
+	0x3c, 0x09, 0x84, 0xc0, 0xe9, 0x76, 0xbd, 0x63, 0x74, 0x9b, 0xbb, 0xff, 0x5f, 0xb9, 0xa3, 0x96,
+	0xf3, 0x78, 0x3e, 0x65, 0xb4, 0xc8, 0x5e, 0x6a, 0x59, 0xf2, 0x1f, 0xb4, 0xec, 0xce, 0xc6, 0x96,
+	0x55, 0x55, 0x3d, 0x62, 0x91, 0x1f, 0x44, 0xa7, 0xd6, 0x0d, 0x64, 0x66, 0x66, 0xfb, 0x25, 0xb4,
+	0x56, 0x65, 0x5c, 0xd3, 0xd3, 0xbd, 0xe5, 0x9e, 0x5e, 0xcd, 0x32, 0xdf, 0xa1, 0xd8, 0xdf, 0xdf,
+	0x41, 0x73, 0x94, 0x48, 0xd5, 0xf8, 0xaa, 0x26, 0x99, 0x90, 0xe4, 0xae, 0x1a, 0xc1, 0x7c, 0x82,
+	0x3b, 0x2b, 0x5d, 0x17, 0x93, 0x81, 0x22, 0x4e, 0xba, 0x70, 0x6d, 0x10, 0x33, 0x57, 0xb2, 0x83,
+	0xc9, 0x54, 0xce, 0x95, 0xd4, 0x78, 0x60, 0x8d, 0xae, 0xc2, 0xf6, 0x03, 0x68, 0x3e, 0x63, 0x4b,
+	0x7b, 0xaf, 0x69, 0x74, 0xfb, 0x03, 0xb8, 0xbe, 0xcf, 0x42, 0x26, 0xd9, 0x45, 0x44, 0x07, 0x48,
+	0x91, 0x28, 0xa6, 0x3c, 0x12, 0xa8, 0xda, 0x51, 0x82, 0x0f, 0x88, 0xe4, 0x1a, 0xcd, 0x4c, 0xfb,
+	0x21, 0xec, 0x3c, 0x63, 0x72, 0x91, 0xff, 0xa6, 0xad, 0xff, 0x2c, 0xc1, 0xce, 0x28, 0x39, 0xcf,
+	0x7d, 0x5c, 0x98, 0x52, 0xa9, 0x20, 0xd7, 0x56, 0x64, 0xa5, 0x39, 0x43, 0x49, 0x93, 0x75, 0xf8,
+	0x41, 0xa4, 0xde, 0xd4, 0xcf, 0xa4, 0x59, 0x81, 0xc9, 0xfb, 0xd0, 0xd4, 0x6a, 0x65, 0x8e, 0x74,
+	0x90, 0xad, 0xa0, 0x8a, 0x37, 0x9e, 0xfa, 0x45, 0x5e, 0x45, 0xf3, 0x96, 0x51, 0xd5, 0xb7, 0x1a,
+	0x19, 0x24, 0x42, 0xf2, 0x09, 0x4e, 0x4d, 0x53, 0xf7, 0xed, 0x2a, 0x6e, 0x7f, 0x08, 0x37, 0xb5,
+	0x8e, 0x97, 0x92, 0x66, 0x0f, 0x6e, 0x9d, 0x63, 0x5f, 0x28, 0xfd, 0x4f, 0x65, 0x68, 0x0f, 0x03,
+	0xa1, 0x05, 0xf5, 0xb3, 0x5e, 0x11, 0xd9, 0x39, 0xc3, 0x54, 0x56, 0xd5, 0x72, 0x18, 0xda, 0xdc,
+	0x75, 0xb4, 0xac, 0xef, 0x0e, 0xca, 0x5d, 0xd8, 0xa8, 0xf9, 0x06, 0xfa, 0x1a, 0x27, 0xdf, 0x33,
+	0x4f, 0x66, 0x5f, 0x83, 0xd4, 0x54, 0xea, 0xbd, 0x98, 0x45, 0xcc, 0x7f, 0x3a, 0xcf, 0x08, 0x15,
+	0xbc, 0xe7, 0x0a, 0xaa, 0xfe, 0x2e, 0x7a, 0xf1, 0xea, 0x95, 0x60, 0x12, 0x35, 0x33, 0x69, 0x6a,
+	0x91, 0x1b, 0x60, 0x0e, 0x83, 0x49, 0x20, 0xf1, 0x03, 0x60, 0x52, 0x6d, 0xd8, 0x0e, 0x6c, 0x2f,
+	0xdd, 0x85, 0x54, 0xc1, 0xe8, 0x1f, 0xbe, 0x6c, 0x5d, 0x21, 0x75, 0x30, 0x87, 0xcf, 0x0f, 0xbf,
+	0x3e, 0x6a, 0x95, 0xd4, 0x72, 0x70, 0x30, 0x1c, 0x1e, 0xb5, 0xca, 0xf6, 0x2f, 0x65, 0xb8, 0xbd,
+	0x36, 0xaf, 0x54, 0xc6, 0x43, 0xa8, 0x2f, 0x40, 0xab, 0x84, 0x73, 0xe6, 0xa3, 0x0d, 0x6a, 0xe8,
+	0x28, 0x67, 0x19, 0xa7, 0xf9, 0x16, 0x85, 0x6c, 0xca, 0xeb, 0xb3, 0x31, 0x0a, 0xd9, 0x28, 0xf4,
+	0x98, 0x4b, 0x57, 0x7f, 0x31, 0x4d, 0xaa, 0x8d, 0xf6, 0x0c, 0x9a, 0xcb, 0x07, 0xa8, 0xb1, 0xa0,
+	0x46, 0xea, 0x62, 0x2c, 0xe4, 0xe3, 0x16, 0x71, 0x72, 0x1f, 0x2a, 0xd8, 0x25, 0xe5, 0xf5, 0x5d,
+	0x82, 0x4e, 0xd2, 0x01, 0x53, 0x35, 0xaf, 0xb0, 0x8c, 0x74, 0x68, 0xe7, 0xc3, 0x45, 0x3b, 0x6c,
+	0x06, 0x6d, 0x5d, 0xb0, 0x18, 0x9a, 0x8d, 0xca, 0x0d, 0x05, 0xba, 0x34, 0xa5, 0xcb, 0x97, 0x9c,
+	0xd2, 0xf6, 0xcf, 0x25, 0xb8, 0xbd, 0xf6, 0x9c, 0x8b, 0x4a, 0xfb, 0x5f, 0x1d, 0xb7, 0xe9, 0xa3,
+	0x60, 0x6c, 0xfc, 0x28, 0x3c, 0xfa, 0x0a, 0x76, 0xd6, 0x7c, 0xa7, 0xc8, 0x55, 0xa8, 0x1d, 0x72,
+	0x6d, 0xb7, 0xae, 0x90, 0x06, 0x54, 0x47, 0x31, 0x3b, 0x0b, 0xd8, 0xac, 0x55, 0x52, 0xae, 0xec,
+	0x7b, 0xde, 0x2a, 0x13, 0x80, 0xad, 0xf1, 0x14, 0xd7, 0xc6, 0xc9, 0x16, 0xfe, 0x47, 0xb0, 0xf7,
+	0xcf, 0x00, 0x00, 0x0f, 0xe0, 0x3c, 0x00, 0x0d, 0x00, 0x00,
 }
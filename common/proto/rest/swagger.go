@@ -529,7 +529,7 @@ var SwaggerJson = `{
           },
           {
             "name": "Watch",
-            "description": "Not implemented, whether to watch for underlying changes on the FS.",
+            "description": "Whether to watch for changes performed directly on the underlying storage.",
             "in": "query",
             "required": false,
             "type": "boolean",
@@ -851,6 +851,33 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/config/encryption/rotate": {
+      "post": {
+        "summary": "Re-wrap all node keys of a datasource with a new master key, in a background job",
+        "operationId": "RotateEncryptionKey",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/encryptionAdminRotateKeyResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/encryptionAdminRotateKeyRequest"
+            }
+          }
+        ],
+        "tags": [
+          "ConfigService"
+        ]
+      }
+    },
     "/config/peers": {
       "get": {
         "summary": "List all detected peers (servers on which the app is running)",
@@ -1568,6 +1595,87 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/jobs/export": {
+      "post": {
+        "summary": "Export one or more jobs definitions as a portable bundle",
+        "operationId": "ExportJobs",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restExportJobsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restExportJobsRequest"
+            }
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
+    "/jobs/import": {
+      "post": {
+        "summary": "Import jobs definitions from a bundle, or compute changes if DryRun is set",
+        "operationId": "ImportJobs",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restImportJobsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restImportJobsRequest"
+            }
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
+    "/jobs/schedule/preview": {
+      "post": {
+        "summary": "Compute the next run times of a given schedule",
+        "operationId": "PreviewSchedule",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restSchedulePreviewResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restSchedulePreviewRequest"
+            }
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
     "/jobs/tasks/delete": {
       "post": {
         "summary": "Send a control command to clean tasks on a given job",
@@ -3779,6 +3887,38 @@ var SwaggerJson = `{
         }
       }
     },
+    "encryptionAdminRotateKeyRequest": {
+      "type": "object",
+      "properties": {
+        "DataSource": {
+          "type": "string",
+          "title": "Name of the datasource whose node keys are re-wrapped"
+        },
+        "NewKeyID": {
+          "type": "string",
+          "title": "Id of the new master key. Can be empty when resuming a rotation in progress"
+        },
+        "BatchSize": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Number of node keys processed per batch"
+        },
+        "VerifyOnly": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Only verify that every node of the datasource can be decrypted"
+        }
+      }
+    },
+    "encryptionAdminRotateKeyResponse": {
+      "type": "object",
+      "properties": {
+        "JobUuid": {
+          "type": "string",
+          "title": "Id of the job performing the rotation"
+        }
+      }
+    },
     "encryptionExport": {
       "type": "object",
       "properties": {
@@ -4843,6 +4983,14 @@ var SwaggerJson = `{
           "format": "boolean",
           "title": "Stop full chain now : do not carry on executing next actions"
         },
+        "RetryPolicy": {
+          "$ref": "#/definitions/jobsActionRetryPolicy",
+          "title": "Retry the action if it fails"
+        },
+        "Timeout": {
+          "type": "string",
+          "description": "Hard timeout for running this action, expressed as a golang duration (e.g. \"30s\", \"10m\").\nEach attempt is cancelled and flagged as failed if it exceeds this duration."
+        },
         "NodesSelector": {
           "$ref": "#/definitions/jobsNodesSelector",
           "title": "Nodes Selector"
@@ -4909,6 +5057,11 @@ var SwaggerJson = `{
         },
         "OutputMessage": {
           "$ref": "#/definitions/jobsActionMessage"
+        },
+        "Attempt": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Attempt number, starting at 1, when the action has a RetryPolicy"
         }
       }
     },
@@ -5028,6 +5181,50 @@ var SwaggerJson = `{
       },
       "title": "ActionOutputFilter can be used to filter last message output"
     },
+    "jobsActionRetryPolicy": {
+      "type": "object",
+      "properties": {
+        "MaxAttempts": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Maximum number of attempts, including the first run. Values lower than 2 disable retry."
+        },
+        "InitialBackoff": {
+          "type": "string",
+          "title": "Delay before the first retry, expressed as a golang duration (default \"1s\")"
+        },
+        "MaxBackoff": {
+          "type": "string",
+          "title": "Upper limit for the delay between two attempts, expressed as a golang duration"
+        },
+        "BackoffMultiplier": {
+          "type": "number",
+          "format": "float",
+          "title": "Factor applied to the delay after each attempt (default 2)"
+        },
+        "RetryableCodes": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int32"
+          },
+          "title": "Only retry errors whose code is part of this list (e.g. 408, 500, 503)"
+        },
+        "RetryableErrors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Only retry errors whose message matches one of these regular expressions"
+        },
+        "RetryOnTimeout": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Also retry when the action timeout is reached"
+        }
+      },
+      "title": "ActionRetryPolicy describes how a failed action should be retried"
+    },
     "jobsCommand": {
       "type": "string",
       "enum": [
@@ -5409,6 +5606,14 @@ var SwaggerJson = `{
         "Iso8601MinDelta": {
           "type": "string",
           "title": "Minimum time between two runs"
+        },
+        "Cron": {
+          "type": "string",
+          "description": "Cron expression (minute hour day-of-month month day-of-week), for instance \"0 2 * * MON-FRI\"\nor \"0 2 * * SUN#1\". Takes precedence over Iso8601Schedule if set."
+        },
+        "Timezone": {
+          "type": "string",
+          "title": "IANA Time zone used to evaluate the Cron expression, for instance \"Europe/Paris\" (defaults to server time zone)"
         }
       }
     },
@@ -5809,7 +6014,7 @@ var SwaggerJson = `{
         "Watch": {
           "type": "boolean",
           "format": "boolean",
-          "title": "Not implemented, whether to watch for underlying changes on the FS"
+          "title": "Whether to watch for changes performed directly on the underlying storage"
         },
         "EncryptionMode": {
           "$ref": "#/definitions/objectEncryptionMode",
@@ -6353,6 +6558,35 @@ var SwaggerJson = `{
         }
       }
     },
+    "restExportJobsRequest": {
+      "type": "object",
+      "properties": {
+        "JobIDs": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Jobs to export. If empty, all custom jobs are exported"
+        },
+        "Format": {
+          "type": "string",
+          "title": "Output format, either \"json\" (default) or \"yaml\""
+        }
+      }
+    },
+    "restExportJobsResponse": {
+      "type": "object",
+      "properties": {
+        "Content": {
+          "type": "string",
+          "title": "Serialized JobsBundle"
+        },
+        "Format": {
+          "type": "string",
+          "title": "Format of the content"
+        }
+      }
+    },
     "restFrontBinaryRequest": {
       "type": "object",
       "properties": {
@@ -6520,6 +6754,95 @@ var SwaggerJson = `{
         }
       }
     },
+    "restImportJobResult": {
+      "type": "object",
+      "properties": {
+        "JobID": {
+          "type": "string",
+          "title": "Imported job ID"
+        },
+        "Label": {
+          "type": "string",
+          "title": "Imported job Label"
+        },
+        "Status": {
+          "$ref": "#/definitions/restImportJobStatus",
+          "title": "Status of the import"
+        },
+        "Diff": {
+          "type": "string",
+          "title": "Unified diff between the existing job and the imported one"
+        },
+        "Error": {
+          "type": "string",
+          "title": "Validation or saving error"
+        }
+      }
+    },
+    "restImportJobStatus": {
+      "type": "string",
+      "enum": [
+        "Create",
+        "Update",
+        "Unchanged",
+        "Invalid"
+      ],
+      "default": "Create"
+    },
+    "restImportJobsRequest": {
+      "type": "object",
+      "properties": {
+        "Content": {
+          "type": "string",
+          "title": "Serialized JobsBundle, in JSON or YAML format"
+        },
+        "OwnersMapping": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "description": "Replace owners of imported jobs: keys are original owners, values are new owners.\nUse \"*\" as key to replace all other owners."
+        },
+        "DryRun": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Only compute changes, do not save anything"
+        }
+      }
+    },
+    "restImportJobsResponse": {
+      "type": "object",
+      "properties": {
+        "Results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restImportJobResult"
+          }
+        }
+      }
+    },
+    "restJobsBundle": {
+      "type": "object",
+      "properties": {
+        "Version": {
+          "type": "string",
+          "title": "Format version of this bundle"
+        },
+        "Created": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Export date, as unix timestamp"
+        },
+        "Jobs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsJob"
+          },
+          "title": "Exported jobs, without their tasks"
+        }
+      },
+      "title": "JobsBundle is a portable and versioned document containing jobs definitions"
+    },
     "restListPeerFoldersRequest": {
       "type": "object",
       "properties": {
@@ -7017,6 +7340,33 @@ var SwaggerJson = `{
       },
       "title": "Roles Collection"
     },
+    "restSchedulePreviewRequest": {
+      "type": "object",
+      "properties": {
+        "Schedule": {
+          "$ref": "#/definitions/jobsSchedule",
+          "title": "Schedule to evaluate"
+        },
+        "Count": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Number of occurrences to compute (default 5)"
+        }
+      }
+    },
+    "restSchedulePreviewResponse": {
+      "type": "object",
+      "properties": {
+        "NextRuns": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int32"
+          },
+          "title": "Next run times, as unix timestamps"
+        }
+      }
+    },
     "restSchedulerActionFormResponse": {
       "type": "object"
     },
@@ -7385,7 +7735,7 @@ var SwaggerJson = `{
         "AccessStart": {
           "type": "string",
           "format": "int64",
-          "title": "Timestamp of start date for enabling the share (not implemented yet)"
+          "title": "Timestamp of start date for enabling the share"
         },
         "AccessEnd": {
           "type": "string",
@@ -7443,6 +7793,11 @@ var SwaggerJson = `{
           "type": "boolean",
           "format": "boolean",
           "title": "Whether policies are currently editable or not"
+        },
+        "Pending": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Whether the link is scheduled and not active yet (AccessStart is in the future)"
         }
       },
       "title": "Model for representing a public link"
@@ -8237,9 +8592,68 @@ var SwaggerJson = `{
           "items": {
             "$ref": "#/definitions/treeVersioningKeepPeriod"
           }
+        },
+        "Rules": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/treeVersioningPolicyRule"
+          },
+          "title": "Rules are evaluated in order, the first matching rule overrides the policy KeepPeriods"
         }
       }
     },
+    "treeVersioningPolicyRule": {
+      "type": "object",
+      "properties": {
+        "Name": {
+          "type": "string"
+        },
+        "PathPrefixes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Path prefixes, relative to the datasource root"
+        },
+        "Extensions": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "File extensions, without leading dot"
+        },
+        "MimeTypes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Mime types computed from the extension, a trailing wildcard is supported (e.g. image/*)"
+        },
+        "MinSize": {
+          "type": "string",
+          "format": "int64",
+          "title": "Minimum size in bytes"
+        },
+        "MaxSize": {
+          "type": "string",
+          "format": "int64",
+          "title": "Maximum size in bytes"
+        },
+        "Ignore": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Do not create any version for matching nodes"
+        },
+        "KeepPeriods": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/treeVersioningKeepPeriod"
+          },
+          "title": "Keep periods applied to matching nodes"
+        }
+      },
+      "description": "VersioningPolicyRule applies specific retention to the nodes matching all its criteria.\nEmpty criteria are ignored."
+    },
     "treeWorkspaceRelativePath": {
       "type": "object",
       "properties": {
@@ -30,6 +30,20 @@ It is generated from these files:
 It has these top-level messages:
 	ResyncRequest
 	ResyncResponse
+	FsckIssue
+	FsckFix
+	FsckRequest
+	FsckResponse
+	IndexSnapshot
+	CreateSnapshotRequest
+	CreateSnapshotResponse
+	ListSnapshotsRequest
+	ListSnapshotsResponse
+	DeleteSnapshotRequest
+	DeleteSnapshotResponse
+	SnapshotChange
+	DiffSnapshotsRequest
+	DiffSnapshotsResponse
 */
 package sync
 
@@ -37,6 +51,8 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/pydio/cells/common/proto/jobs"
+import _ "github.com/pydio/cells/common/proto/tree"
+import _ "github.com/pydio/cells/common/proto/encryption"
 
 import (
 	client "github.com/micro/go-micro/client"
@@ -111,3 +127,155 @@ type SyncEndpoint struct {
 func (h *SyncEndpoint) TriggerResync(ctx context.Context, in *ResyncRequest, out *ResyncResponse) error {
 	return h.SyncEndpointHandler.TriggerResync(ctx, in, out)
 }
+
+// Client API for IndexFsck service
+
+type IndexFsckClient interface {
+	Fsck(ctx context.Context, in *FsckRequest, opts ...client.CallOption) (*FsckResponse, error)
+}
+
+type indexFsckClient struct {
+	c           client.Client
+	serviceName string
+}
+
+func NewIndexFsckClient(serviceName string, c client.Client) IndexFsckClient {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(serviceName) == 0 {
+		serviceName = "sync"
+	}
+	return &indexFsckClient{
+		c:           c,
+		serviceName: serviceName,
+	}
+}
+
+func (c *indexFsckClient) Fsck(ctx context.Context, in *FsckRequest, opts ...client.CallOption) (*FsckResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "IndexFsck.Fsck", in)
+	out := new(FsckResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for IndexFsck service
+
+type IndexFsckHandler interface {
+	Fsck(context.Context, *FsckRequest, *FsckResponse) error
+}
+
+func RegisterIndexFsckHandler(s server.Server, hdlr IndexFsckHandler, opts ...server.HandlerOption) {
+	s.Handle(s.NewHandler(&IndexFsck{hdlr}, opts...))
+}
+
+type IndexFsck struct {
+	IndexFsckHandler
+}
+
+func (h *IndexFsck) Fsck(ctx context.Context, in *FsckRequest, out *FsckResponse) error {
+	return h.IndexFsckHandler.Fsck(ctx, in, out)
+}
+
+// Client API for IndexSnapshots service
+
+type IndexSnapshotsClient interface {
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...client.CallOption) (*CreateSnapshotResponse, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...client.CallOption) (*ListSnapshotsResponse, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...client.CallOption) (*DeleteSnapshotResponse, error)
+	DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...client.CallOption) (*DiffSnapshotsResponse, error)
+}
+
+type indexSnapshotsClient struct {
+	c           client.Client
+	serviceName string
+}
+
+func NewIndexSnapshotsClient(serviceName string, c client.Client) IndexSnapshotsClient {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(serviceName) == 0 {
+		serviceName = "sync"
+	}
+	return &indexSnapshotsClient{
+		c:           c,
+		serviceName: serviceName,
+	}
+}
+
+func (c *indexSnapshotsClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...client.CallOption) (*CreateSnapshotResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "IndexSnapshots.CreateSnapshot", in)
+	out := new(CreateSnapshotResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexSnapshotsClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...client.CallOption) (*ListSnapshotsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "IndexSnapshots.ListSnapshots", in)
+	out := new(ListSnapshotsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexSnapshotsClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...client.CallOption) (*DeleteSnapshotResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "IndexSnapshots.DeleteSnapshot", in)
+	out := new(DeleteSnapshotResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexSnapshotsClient) DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...client.CallOption) (*DiffSnapshotsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "IndexSnapshots.DiffSnapshots", in)
+	out := new(DiffSnapshotsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for IndexSnapshots service
+
+type IndexSnapshotsHandler interface {
+	CreateSnapshot(context.Context, *CreateSnapshotRequest, *CreateSnapshotResponse) error
+	ListSnapshots(context.Context, *ListSnapshotsRequest, *ListSnapshotsResponse) error
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest, *DeleteSnapshotResponse) error
+	DiffSnapshots(context.Context, *DiffSnapshotsRequest, *DiffSnapshotsResponse) error
+}
+
+func RegisterIndexSnapshotsHandler(s server.Server, hdlr IndexSnapshotsHandler, opts ...server.HandlerOption) {
+	s.Handle(s.NewHandler(&IndexSnapshots{hdlr}, opts...))
+}
+
+type IndexSnapshots struct {
+	IndexSnapshotsHandler
+}
+
+func (h *IndexSnapshots) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, out *CreateSnapshotResponse) error {
+	return h.IndexSnapshotsHandler.CreateSnapshot(ctx, in, out)
+}
+
+func (h *IndexSnapshots) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, out *ListSnapshotsResponse) error {
+	return h.IndexSnapshotsHandler.ListSnapshots(ctx, in, out)
+}
+
+func (h *IndexSnapshots) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, out *DeleteSnapshotResponse) error {
+	return h.IndexSnapshotsHandler.DeleteSnapshot(ctx, in, out)
+}
+
+func (h *IndexSnapshots) DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, out *DiffSnapshotsResponse) error {
+	return h.IndexSnapshotsHandler.DiffSnapshots(ctx, in, out)
+}
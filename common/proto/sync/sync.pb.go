@@ -1,23 +1,3 @@
-/*
- * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
- * This file is part of Pydio Cells.
- *
- * Pydio Cells is free software: you can redistribute it and/or modify
- * it under the terms of the GNU Affero General Public License as published by
- * the Free Software Foundation, either version 3 of the License, or
- * (at your option) any later version.
- *
- * Pydio Cells is distributed in the hope that it will be useful,
- * but WITHOUT ANY WARRANTY; without even the implied warranty of
- * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
- * GNU Affero General Public License for more details.
- *
- * You should have received a copy of the GNU Affero General Public License
- * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
- *
- * The latest code can be found at <https://pydio.com>.
- */
-
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // source: sync.proto
 
@@ -30,6 +10,20 @@ It is generated from these files:
 It has these top-level messages:
 	ResyncRequest
 	ResyncResponse
+	FsckIssue
+	FsckFix
+	FsckRequest
+	FsckResponse
+	IndexSnapshot
+	CreateSnapshotRequest
+	CreateSnapshotResponse
+	ListSnapshotsRequest
+	ListSnapshotsResponse
+	DeleteSnapshotRequest
+	DeleteSnapshotResponse
+	SnapshotChange
+	DiffSnapshotsRequest
+	DiffSnapshotsResponse
 */
 package sync
 
@@ -37,6 +31,8 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import jobs "github.com/pydio/cells/common/proto/jobs"
+import tree "github.com/pydio/cells/common/proto/tree"
+import encryption "github.com/pydio/cells/common/proto/encryption"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -49,6 +45,63 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type FsckIssueType int32
+
+const (
+	FsckIssueType_Orphan           FsckIssueType = 0
+	FsckIssueType_DuplicatePath    FsckIssueType = 1
+	FsckIssueType_BrokenMPath      FsckIssueType = 2
+	FsckIssueType_MissingInStorage FsckIssueType = 3
+	FsckIssueType_MissingInIndex   FsckIssueType = 4
+)
+
+var FsckIssueType_name = map[int32]string{
+	0: "Orphan",
+	1: "DuplicatePath",
+	2: "BrokenMPath",
+	3: "MissingInStorage",
+	4: "MissingInIndex",
+}
+var FsckIssueType_value = map[string]int32{
+	"Orphan":           0,
+	"DuplicatePath":    1,
+	"BrokenMPath":      2,
+	"MissingInStorage": 3,
+	"MissingInIndex":   4,
+}
+
+func (x FsckIssueType) String() string {
+	return proto.EnumName(FsckIssueType_name, int32(x))
+}
+func (FsckIssueType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type FsckFixType int32
+
+const (
+	FsckFixType_NoFix    FsckFixType = 0
+	FsckFixType_Reparent FsckFixType = 1
+	FsckFixType_Delete   FsckFixType = 2
+	FsckFixType_Rehash   FsckFixType = 3
+)
+
+var FsckFixType_name = map[int32]string{
+	0: "NoFix",
+	1: "Reparent",
+	2: "Delete",
+	3: "Rehash",
+}
+var FsckFixType_value = map[string]int32{
+	"NoFix":    0,
+	"Reparent": 1,
+	"Delete":   2,
+	"Rehash":   3,
+}
+
+func (x FsckFixType) String() string {
+	return proto.EnumName(FsckFixType_name, int32(x))
+}
+func (FsckFixType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
 type ResyncRequest struct {
 	Path   string     `protobuf:"bytes,1,opt,name=Path" json:"Path,omitempty"`
 	DryRun bool       `protobuf:"varint,2,opt,name=DryRun" json:"DryRun,omitempty"`
@@ -113,29 +166,553 @@ func (m *ResyncResponse) GetTask() *jobs.Task {
 	return nil
 }
 
+// FsckIssue is an inconsistency detected in the index
+type FsckIssue struct {
+	// Id identifies the issue across successive runs
+	Id    string        `protobuf:"bytes,1,opt,name=Id" json:"Id,omitempty"`
+	Type  FsckIssueType `protobuf:"varint,2,opt,name=Type,enum=sync.FsckIssueType" json:"Type,omitempty"`
+	Uuids []string      `protobuf:"bytes,3,rep,name=Uuids" json:"Uuids,omitempty"`
+	Name  string        `protobuf:"bytes,4,opt,name=Name" json:"Name,omitempty"`
+	MPath string        `protobuf:"bytes,5,opt,name=MPath" json:"MPath,omitempty"`
+	Path  string        `protobuf:"bytes,6,opt,name=Path" json:"Path,omitempty"`
+	Leaf  bool          `protobuf:"varint,7,opt,name=Leaf" json:"Leaf,omitempty"`
+	// Fixes lists the fixes that can be applied, the first one being the default
+	Fixes   []FsckFixType `protobuf:"varint,8,rep,packed,name=Fixes,enum=sync.FsckFixType" json:"Fixes,omitempty"`
+	Applied FsckFixType   `protobuf:"varint,9,opt,name=Applied,enum=sync.FsckFixType" json:"Applied,omitempty"`
+	Error   string        `protobuf:"bytes,10,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *FsckIssue) Reset()                    { *m = FsckIssue{} }
+func (m *FsckIssue) String() string            { return proto.CompactTextString(m) }
+func (*FsckIssue) ProtoMessage()               {}
+func (*FsckIssue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *FsckIssue) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetType() FsckIssueType {
+	if m != nil {
+		return m.Type
+	}
+	return FsckIssueType_Orphan
+}
+
+func (m *FsckIssue) GetUuids() []string {
+	if m != nil {
+		return m.Uuids
+	}
+	return nil
+}
+
+func (m *FsckIssue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetMPath() string {
+	if m != nil {
+		return m.MPath
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetLeaf() bool {
+	if m != nil {
+		return m.Leaf
+	}
+	return false
+}
+
+func (m *FsckIssue) GetFixes() []FsckFixType {
+	if m != nil {
+		return m.Fixes
+	}
+	return nil
+}
+
+func (m *FsckIssue) GetApplied() FsckFixType {
+	if m != nil {
+		return m.Applied
+	}
+	return FsckFixType_NoFix
+}
+
+func (m *FsckIssue) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// FsckFix selects a fix for a given issue
+type FsckFix struct {
+	IssueId string      `protobuf:"bytes,1,opt,name=IssueId" json:"IssueId,omitempty"`
+	Type    FsckFixType `protobuf:"varint,2,opt,name=Type,enum=sync.FsckFixType" json:"Type,omitempty"`
+	// Uuids to delete, defaults to all duplicates but the first one
+	Uuids []string `protobuf:"bytes,3,rep,name=Uuids" json:"Uuids,omitempty"`
+	// ReparentPath is the folder where orphans are moved, defaults to the root
+	ReparentPath string `protobuf:"bytes,4,opt,name=ReparentPath" json:"ReparentPath,omitempty"`
+}
+
+func (m *FsckFix) Reset()                    { *m = FsckFix{} }
+func (m *FsckFix) String() string            { return proto.CompactTextString(m) }
+func (*FsckFix) ProtoMessage()               {}
+func (*FsckFix) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *FsckFix) GetIssueId() string {
+	if m != nil {
+		return m.IssueId
+	}
+	return ""
+}
+
+func (m *FsckFix) GetType() FsckFixType {
+	if m != nil {
+		return m.Type
+	}
+	return FsckFixType_NoFix
+}
+
+func (m *FsckFix) GetUuids() []string {
+	if m != nil {
+		return m.Uuids
+	}
+	return nil
+}
+
+func (m *FsckFix) GetReparentPath() string {
+	if m != nil {
+		return m.ReparentPath
+	}
+	return ""
+}
+
+type FsckRequest struct {
+	// Apply fixes, otherwise only report issues
+	Apply bool `protobuf:"varint,1,opt,name=Apply" json:"Apply,omitempty"`
+	// AutoFix applies default fixes on issues that have no explicit fix
+	AutoFix      bool       `protobuf:"varint,2,opt,name=AutoFix" json:"AutoFix,omitempty"`
+	Fixes        []*FsckFix `protobuf:"bytes,3,rep,name=Fixes" json:"Fixes,omitempty"`
+	CheckStorage bool       `protobuf:"varint,4,opt,name=CheckStorage" json:"CheckStorage,omitempty"`
+}
+
+func (m *FsckRequest) Reset()                    { *m = FsckRequest{} }
+func (m *FsckRequest) String() string            { return proto.CompactTextString(m) }
+func (*FsckRequest) ProtoMessage()               {}
+func (*FsckRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *FsckRequest) GetApply() bool {
+	if m != nil {
+		return m.Apply
+	}
+	return false
+}
+
+func (m *FsckRequest) GetAutoFix() bool {
+	if m != nil {
+		return m.AutoFix
+	}
+	return false
+}
+
+func (m *FsckRequest) GetFixes() []*FsckFix {
+	if m != nil {
+		return m.Fixes
+	}
+	return nil
+}
+
+func (m *FsckRequest) GetCheckStorage() bool {
+	if m != nil {
+		return m.CheckStorage
+	}
+	return false
+}
+
+type FsckResponse struct {
+	Issues []*FsckIssue `protobuf:"bytes,1,rep,name=Issues" json:"Issues,omitempty"`
+}
+
+func (m *FsckResponse) Reset()                    { *m = FsckResponse{} }
+func (m *FsckResponse) String() string            { return proto.CompactTextString(m) }
+func (*FsckResponse) ProtoMessage()               {}
+func (*FsckResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *FsckResponse) GetIssues() []*FsckIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+// IndexSnapshot describes a named snapshot of a datasource index
+type IndexSnapshot struct {
+	Name      string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	CreatedAt int32  `protobuf:"varint,2,opt,name=CreatedAt" json:"CreatedAt,omitempty"`
+	Nodes     int64  `protobuf:"varint,3,opt,name=Nodes" json:"Nodes,omitempty"`
+	Size      int64  `protobuf:"varint,4,opt,name=Size" json:"Size,omitempty"`
+	// Keys is the number of encryption node keys captured with the snapshot
+	Keys int64 `protobuf:"varint,5,opt,name=Keys" json:"Keys,omitempty"`
+}
+
+func (m *IndexSnapshot) Reset()                    { *m = IndexSnapshot{} }
+func (m *IndexSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*IndexSnapshot) ProtoMessage()               {}
+func (*IndexSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *IndexSnapshot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IndexSnapshot) GetCreatedAt() int32 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *IndexSnapshot) GetNodes() int64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *IndexSnapshot) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *IndexSnapshot) GetKeys() int64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+type CreateSnapshotRequest struct {
+	// Name of the snapshot, generated from the current date if empty
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	// Keep prunes the oldest snapshots to keep at most this number of snapshots, if greater than 0
+	Keep int32 `protobuf:"varint,2,opt,name=Keep" json:"Keep,omitempty"`
+}
+
+func (m *CreateSnapshotRequest) Reset()                    { *m = CreateSnapshotRequest{} }
+func (m *CreateSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateSnapshotRequest) ProtoMessage()               {}
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *CreateSnapshotRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateSnapshotRequest) GetKeep() int32 {
+	if m != nil {
+		return m.Keep
+	}
+	return 0
+}
+
+type CreateSnapshotResponse struct {
+	Snapshot *IndexSnapshot `protobuf:"bytes,1,opt,name=Snapshot" json:"Snapshot,omitempty"`
+	Pruned   []string       `protobuf:"bytes,2,rep,name=Pruned" json:"Pruned,omitempty"`
+}
+
+func (m *CreateSnapshotResponse) Reset()                    { *m = CreateSnapshotResponse{} }
+func (m *CreateSnapshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateSnapshotResponse) ProtoMessage()               {}
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CreateSnapshotResponse) GetSnapshot() *IndexSnapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *CreateSnapshotResponse) GetPruned() []string {
+	if m != nil {
+		return m.Pruned
+	}
+	return nil
+}
+
+type ListSnapshotsRequest struct {
+}
+
+func (m *ListSnapshotsRequest) Reset()                    { *m = ListSnapshotsRequest{} }
+func (m *ListSnapshotsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsRequest) ProtoMessage()               {}
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type ListSnapshotsResponse struct {
+	Snapshots []*IndexSnapshot `protobuf:"bytes,1,rep,name=Snapshots" json:"Snapshots,omitempty"`
+}
+
+func (m *ListSnapshotsResponse) Reset()                    { *m = ListSnapshotsResponse{} }
+func (m *ListSnapshotsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsResponse) ProtoMessage()               {}
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ListSnapshotsResponse) GetSnapshots() []*IndexSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type DeleteSnapshotRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+}
+
+func (m *DeleteSnapshotRequest) Reset()                    { *m = DeleteSnapshotRequest{} }
+func (m *DeleteSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSnapshotRequest) ProtoMessage()               {}
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *DeleteSnapshotRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteSnapshotResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *DeleteSnapshotResponse) Reset()                    { *m = DeleteSnapshotResponse{} }
+func (m *DeleteSnapshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSnapshotResponse) ProtoMessage()               {}
+func (*DeleteSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *DeleteSnapshotResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+// SnapshotChange is an operation transforming one state of the index into another
+type SnapshotChange struct {
+	// Type is the name of the sync operation (CreateFile, UpdateFile, CreateFolder, MoveFile, MoveFolder, Delete)
+	Type string `protobuf:"bytes,1,opt,name=Type" json:"Type,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=Path" json:"Path,omitempty"`
+	// MoveFrom is the original path of moved nodes
+	MoveFrom string     `protobuf:"bytes,3,opt,name=MoveFrom" json:"MoveFrom,omitempty"`
+	Node     *tree.Node `protobuf:"bytes,4,opt,name=Node" json:"Node,omitempty"`
+	// NodeKey is the encryption key of the node in the target state, if requested and available
+	NodeKey *encryption.NodeKey `protobuf:"bytes,5,opt,name=NodeKey" json:"NodeKey,omitempty"`
+}
+
+func (m *SnapshotChange) Reset()                    { *m = SnapshotChange{} }
+func (m *SnapshotChange) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotChange) ProtoMessage()               {}
+func (*SnapshotChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *SnapshotChange) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SnapshotChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SnapshotChange) GetMoveFrom() string {
+	if m != nil {
+		return m.MoveFrom
+	}
+	return ""
+}
+
+func (m *SnapshotChange) GetNode() *tree.Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *SnapshotChange) GetNodeKey() *encryption.NodeKey {
+	if m != nil {
+		return m.NodeKey
+	}
+	return nil
+}
+
+type DiffSnapshotsRequest struct {
+	// From is the initial snapshot, the current index if empty
+	From string `protobuf:"bytes,1,opt,name=From" json:"From,omitempty"`
+	// To is the target snapshot, the current index if empty
+	To string `protobuf:"bytes,2,opt,name=To" json:"To,omitempty"`
+	// Path restricts the diff to a subtree
+	Path string `protobuf:"bytes,3,opt,name=Path" json:"Path,omitempty"`
+	// WithKeys attaches the encryption keys of created and updated files
+	WithKeys bool `protobuf:"varint,4,opt,name=WithKeys" json:"WithKeys,omitempty"`
+}
+
+func (m *DiffSnapshotsRequest) Reset()                    { *m = DiffSnapshotsRequest{} }
+func (m *DiffSnapshotsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiffSnapshotsRequest) ProtoMessage()               {}
+func (*DiffSnapshotsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *DiffSnapshotsRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *DiffSnapshotsRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *DiffSnapshotsRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DiffSnapshotsRequest) GetWithKeys() bool {
+	if m != nil {
+		return m.WithKeys
+	}
+	return false
+}
+
+type DiffSnapshotsResponse struct {
+	Changes []*SnapshotChange `protobuf:"bytes,1,rep,name=Changes" json:"Changes,omitempty"`
+}
+
+func (m *DiffSnapshotsResponse) Reset()                    { *m = DiffSnapshotsResponse{} }
+func (m *DiffSnapshotsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiffSnapshotsResponse) ProtoMessage()               {}
+func (*DiffSnapshotsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *DiffSnapshotsResponse) GetChanges() []*SnapshotChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ResyncRequest)(nil), "sync.ResyncRequest")
 	proto.RegisterType((*ResyncResponse)(nil), "sync.ResyncResponse")
+	proto.RegisterType((*FsckIssue)(nil), "sync.FsckIssue")
+	proto.RegisterType((*FsckFix)(nil), "sync.FsckFix")
+	proto.RegisterType((*FsckRequest)(nil), "sync.FsckRequest")
+	proto.RegisterType((*FsckResponse)(nil), "sync.FsckResponse")
+	proto.RegisterType((*IndexSnapshot)(nil), "sync.IndexSnapshot")
+	proto.RegisterType((*CreateSnapshotRequest)(nil), "sync.CreateSnapshotRequest")
+	proto.RegisterType((*CreateSnapshotResponse)(nil), "sync.CreateSnapshotResponse")
+	proto.RegisterType((*ListSnapshotsRequest)(nil), "sync.ListSnapshotsRequest")
+	proto.RegisterType((*ListSnapshotsResponse)(nil), "sync.ListSnapshotsResponse")
+	proto.RegisterType((*DeleteSnapshotRequest)(nil), "sync.DeleteSnapshotRequest")
+	proto.RegisterType((*DeleteSnapshotResponse)(nil), "sync.DeleteSnapshotResponse")
+	proto.RegisterType((*SnapshotChange)(nil), "sync.SnapshotChange")
+	proto.RegisterType((*DiffSnapshotsRequest)(nil), "sync.DiffSnapshotsRequest")
+	proto.RegisterType((*DiffSnapshotsResponse)(nil), "sync.DiffSnapshotsResponse")
+	proto.RegisterEnum("sync.FsckIssueType", FsckIssueType_name, FsckIssueType_value)
+	proto.RegisterEnum("sync.FsckFixType", FsckFixType_name, FsckFixType_value)
 }
 
 func init() { proto.RegisterFile("sync.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 252 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x09, 0x44, 0x25, 0x3d, 0x28, 0x83, 0x41, 0x28, 0xca, 0x80, 0xa2, 0x4c, 0x99, 0x12,
-	0xa9, 0x48, 0x4c, 0x8c, 0x65, 0x41, 0x0c, 0xc8, 0xed, 0xc6, 0xd4, 0xb8, 0x4e, 0x6a, 0x50, 0x7c,
-	0xc1, 0xe7, 0x20, 0xe5, 0xdf, 0xa3, 0x5c, 0x28, 0x52, 0xa7, 0x2e, 0xd6, 0xbd, 0x27, 0xfb, 0x7d,
-	0xbe, 0x07, 0x40, 0x83, 0x55, 0x45, 0xe7, 0xd0, 0xa3, 0x08, 0xc7, 0x39, 0x79, 0x6a, 0x8c, 0xdf,
-	0xf7, 0x55, 0xa1, 0xb0, 0x2d, 0xbb, 0x61, 0x67, 0xb0, 0x24, 0xed, 0x7e, 0x8c, 0xd2, 0x54, 0x2a,
-	0x6c, 0x5b, 0xb4, 0x25, 0xdf, 0x2e, 0x3f, 0xb1, 0x22, 0x3e, 0xa6, 0xd7, 0xd9, 0x07, 0x2c, 0xa4,
-	0x1e, 0x13, 0xa4, 0xfe, 0xee, 0x35, 0x79, 0x21, 0x20, 0x7c, 0xdf, 0xfa, 0x7d, 0x1c, 0xa4, 0x41,
-	0x3e, 0x97, 0x3c, 0x8b, 0x7b, 0x98, 0xad, 0xdc, 0x20, 0x7b, 0x1b, 0x9f, 0xa7, 0x41, 0x1e, 0xc9,
-	0x3f, 0x25, 0x1e, 0x20, 0xdc, 0x6c, 0xe9, 0x2b, 0xbe, 0x48, 0x83, 0xfc, 0x6a, 0x09, 0x05, 0xe7,
-	0x8e, 0x8e, 0x64, 0x3f, 0xab, 0xe1, 0xe6, 0x10, 0x4e, 0x1d, 0x5a, 0xd2, 0x22, 0x86, 0xcb, 0x75,
-	0xaf, 0x94, 0x26, 0x62, 0x40, 0x24, 0x0f, 0x52, 0x24, 0x10, 0xbd, 0x12, 0xda, 0x95, 0xa9, 0x6b,
-	0xa6, 0xcc, 0xe5, 0xbf, 0x3e, 0xc5, 0x59, 0xbe, 0xc1, 0xf5, 0x7a, 0xb0, 0xea, 0xc5, 0xee, 0x3a,
-	0x34, 0xd6, 0x8b, 0x67, 0x58, 0x6c, 0x9c, 0x69, 0x1a, 0xed, 0x26, 0xbc, 0xb8, 0x2d, 0xb8, 0xb0,
-	0xa3, 0x4d, 0x93, 0xbb, 0x63, 0x73, 0xfa, 0x61, 0x76, 0x56, 0xcd, 0xb8, 0x99, 0xc7, 0xdf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xdb, 0xa4, 0xc8, 0xff, 0x65, 0x01, 0x00, 0x00,
+	// 1020 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x5f, 0x4f, 0xe3, 0x46,
+	0x10, 0xc7, 0x4e, 0x20, 0xf1, 0x84, 0xf8, 0x7c, 0xdb, 0x80, 0xac, 0x80, 0xaa, 0xc8, 0x55, 0x45,
+	0x74, 0xa7, 0x12, 0x35, 0xf7, 0xd0, 0x97, 0x53, 0x4f, 0x14, 0x2e, 0x15, 0x1c, 0x5c, 0x4f, 0x0b,
+	0x55, 0x1f, 0xfa, 0x64, 0xec, 0x25, 0xf1, 0x01, 0x5e, 0xd7, 0xeb, 0x54, 0xa4, 0x2f, 0x7d, 0xa9,
+	0xfa, 0x19, 0xfa, 0xde, 0xef, 0xd2, 0xcf, 0x55, 0xed, 0xec, 0xae, 0xb1, 0x53, 0xab, 0xc7, 0x4b,
+	0x32, 0x33, 0x3b, 0x3b, 0xf3, 0xdb, 0xdf, 0xfc, 0x49, 0x00, 0xc4, 0x2a, 0x8d, 0x0e, 0xb3, 0x9c,
+	0x17, 0x9c, 0xb4, 0xa5, 0x3c, 0x7c, 0x35, 0x4f, 0x8a, 0xc5, 0xf2, 0xfa, 0x30, 0xe2, 0xf7, 0x93,
+	0x6c, 0x15, 0x27, 0x7c, 0x12, 0xb1, 0xbb, 0x3b, 0x31, 0x89, 0xf8, 0xfd, 0x3d, 0x4f, 0x27, 0xe8,
+	0x3a, 0xf9, 0xc8, 0xaf, 0x05, 0x7e, 0xa8, 0xab, 0x4f, 0xbb, 0x54, 0xe4, 0x8c, 0xe1, 0x87, 0xbe,
+	0xf4, 0xe6, 0x29, 0x97, 0x58, 0x1a, 0xe5, 0xab, 0xac, 0x48, 0x78, 0x5a, 0x11, 0x55, 0x80, 0xe0,
+	0x67, 0xe8, 0x53, 0x26, 0x41, 0x53, 0xf6, 0xcb, 0x92, 0x89, 0x82, 0x10, 0x68, 0x7f, 0x08, 0x8b,
+	0x85, 0x6f, 0x8d, 0xac, 0xb1, 0x43, 0x51, 0x26, 0xbb, 0xb0, 0x75, 0x92, 0xaf, 0xe8, 0x32, 0xf5,
+	0xed, 0x91, 0x35, 0xee, 0x52, 0xad, 0x91, 0xcf, 0xa1, 0x7d, 0x15, 0x8a, 0x5b, 0xbf, 0x35, 0xb2,
+	0xc6, 0xbd, 0x29, 0x1c, 0xe2, 0x6b, 0xa4, 0x85, 0xa2, 0x3d, 0xb8, 0x01, 0xd7, 0x04, 0x17, 0x19,
+	0x4f, 0x05, 0x23, 0x3e, 0x74, 0x2e, 0x97, 0x51, 0xc4, 0x84, 0xc0, 0x04, 0x5d, 0x6a, 0x54, 0x32,
+	0x84, 0xee, 0x99, 0xe0, 0xe9, 0x49, 0x72, 0x73, 0x83, 0x59, 0x1c, 0x5a, 0xea, 0x9f, 0xcc, 0xf3,
+	0x97, 0x0d, 0xce, 0x4c, 0x44, 0xb7, 0xa7, 0x42, 0x2c, 0x19, 0x71, 0xc1, 0x3e, 0x8d, 0x35, 0x7e,
+	0xfb, 0x34, 0x26, 0x07, 0xd0, 0xbe, 0x5a, 0x65, 0x0c, 0xa3, 0xba, 0xd3, 0xcf, 0x0e, 0xb1, 0x5c,
+	0xa5, 0xbb, 0x3c, 0xa2, 0xe8, 0x40, 0x06, 0xb0, 0xf9, 0xe3, 0x32, 0x89, 0x85, 0xdf, 0x1a, 0xb5,
+	0xc6, 0x0e, 0x55, 0x8a, 0x24, 0xe4, 0x7d, 0x78, 0xcf, 0xfc, 0xb6, 0x22, 0x44, 0xca, 0xd2, 0xf3,
+	0x02, 0x59, 0xda, 0x44, 0xa3, 0x52, 0x4a, 0xea, 0xb6, 0x2a, 0xd4, 0x11, 0x68, 0x9f, 0xb3, 0xf0,
+	0xc6, 0xef, 0xe0, 0x6b, 0x51, 0x26, 0x07, 0xb0, 0x39, 0x4b, 0x1e, 0x98, 0xf0, 0xbb, 0xa3, 0xd6,
+	0xd8, 0x9d, 0x3e, 0x7f, 0x44, 0x34, 0x4b, 0x1e, 0x10, 0x8f, 0x3a, 0x27, 0x2f, 0xa1, 0x73, 0x94,
+	0x65, 0x77, 0x09, 0x8b, 0x7d, 0x67, 0x64, 0x35, 0xbb, 0x1a, 0x0f, 0x89, 0xe9, 0x6d, 0x9e, 0xf3,
+	0xdc, 0x07, 0x85, 0x09, 0x95, 0xe0, 0x0f, 0x0b, 0x3a, 0xda, 0x5d, 0x92, 0x8f, 0x4f, 0x2e, 0xd9,
+	0x31, 0x2a, 0xf9, 0xb2, 0x46, 0x51, 0x43, 0x96, 0xff, 0x23, 0x28, 0x80, 0x6d, 0xca, 0xb2, 0x30,
+	0x67, 0x69, 0x81, 0xcf, 0x57, 0x44, 0xd5, 0x6c, 0xc1, 0x9f, 0x16, 0xf4, 0x64, 0x3c, 0xd3, 0x65,
+	0x03, 0xd8, 0x94, 0xb8, 0x57, 0xba, 0x0b, 0x94, 0x22, 0x01, 0x1e, 0x2d, 0x0b, 0x3e, 0x4b, 0x1e,
+	0x74, 0xa3, 0x19, 0x95, 0x7c, 0x61, 0x28, 0x93, 0x99, 0x7b, 0xd3, 0x7e, 0x0d, 0xa1, 0xa1, 0x2b,
+	0x80, 0xed, 0xe3, 0x05, 0x8b, 0x6e, 0x2f, 0x0b, 0x9e, 0x87, 0x73, 0x55, 0xb1, 0x2e, 0xad, 0xd9,
+	0x82, 0x6f, 0x60, 0x5b, 0xe1, 0xd0, 0x0d, 0x79, 0x00, 0x5b, 0x48, 0x82, 0xec, 0x47, 0x19, 0xf9,
+	0xd9, 0x5a, 0x7b, 0x50, 0x7d, 0x1c, 0xfc, 0x0e, 0xfd, 0xd3, 0x34, 0x66, 0x0f, 0x97, 0x69, 0x98,
+	0x89, 0x05, 0x2f, 0xca, 0xbe, 0xb0, 0x2a, 0x7d, 0xb1, 0x0f, 0xce, 0x71, 0xce, 0xc2, 0x82, 0xc5,
+	0x47, 0x05, 0x3e, 0x61, 0x93, 0x3e, 0x1a, 0xe4, 0xa3, 0xdf, 0xf3, 0x18, 0x1f, 0x61, 0x8d, 0x5b,
+	0x54, 0x29, 0x32, 0xce, 0x65, 0xf2, 0x9b, 0x42, 0xdb, 0xa2, 0x28, 0x4b, 0xdb, 0x3b, 0xb6, 0x12,
+	0xd8, 0x5e, 0x2d, 0x8a, 0x72, 0xf0, 0x06, 0x76, 0x54, 0x28, 0x83, 0xa0, 0x32, 0xb1, 0xff, 0x01,
+	0x82, 0x01, 0x58, 0xa6, 0x31, 0xa0, 0x1c, 0x84, 0xb0, 0xbb, 0x1e, 0x40, 0x93, 0x30, 0x81, 0xae,
+	0xb1, 0x61, 0x94, 0x9e, 0x99, 0x92, 0xda, 0x8b, 0x69, 0xe9, 0x24, 0x17, 0xc2, 0x87, 0x7c, 0x99,
+	0xb2, 0xd8, 0xb7, 0xb1, 0x13, 0xb4, 0x16, 0xec, 0xc2, 0xe0, 0x3c, 0x11, 0x85, 0xf1, 0x13, 0x1a,
+	0x62, 0x70, 0x06, 0x3b, 0x6b, 0x76, 0x9d, 0xf9, 0x6b, 0x70, 0x4a, 0xa3, 0xae, 0x40, 0x63, 0xea,
+	0x47, 0xaf, 0xe0, 0x25, 0xec, 0x9c, 0xb0, 0x3b, 0xf6, 0x24, 0x1e, 0x82, 0x29, 0xec, 0xae, 0x3b,
+	0x7f, 0x6a, 0x13, 0x05, 0x7f, 0x5b, 0xe0, 0x1a, 0xf7, 0xe3, 0x45, 0x98, 0xce, 0x91, 0x4e, 0x9c,
+	0x0f, 0x1d, 0x5a, 0xca, 0xe5, 0xb4, 0xdb, 0x95, 0x69, 0x1f, 0x42, 0xf7, 0x82, 0xff, 0xca, 0x66,
+	0x39, 0xbf, 0xc7, 0x22, 0x3b, 0xb4, 0xd4, 0xe5, 0x12, 0x93, 0x05, 0xf7, 0xdb, 0x7a, 0x89, 0xe1,
+	0x16, 0x97, 0x16, 0x8a, 0x76, 0xf2, 0x15, 0x74, 0xe4, 0xf7, 0x3b, 0xb6, 0xc2, 0xb2, 0x4b, 0x22,
+	0x2a, 0xdb, 0x5a, 0x1f, 0x51, 0xe3, 0x13, 0x7c, 0x84, 0x81, 0xdc, 0x8d, 0xeb, 0x54, 0x4b, 0x58,
+	0x98, 0x5e, 0x43, 0xc5, 0xd4, 0x2e, 0xd8, 0x57, 0x5c, 0x03, 0xb5, 0xaf, 0x78, 0x09, 0xbd, 0x55,
+	0x87, 0xfe, 0x53, 0x52, 0x2c, 0xb0, 0xed, 0xd4, 0xe0, 0x94, 0x7a, 0xf0, 0x3d, 0xec, 0xac, 0xe5,
+	0xd2, 0x24, 0x1e, 0x42, 0x47, 0x31, 0x64, 0x8a, 0x37, 0x50, 0xc5, 0xab, 0xd3, 0x47, 0x8d, 0xd3,
+	0x8b, 0x04, 0xfa, 0xb5, 0xc5, 0x4b, 0x00, 0xb6, 0x7e, 0xc8, 0xb3, 0x45, 0x98, 0x7a, 0x1b, 0xe4,
+	0x39, 0xf4, 0x4f, 0x96, 0xd9, 0x5d, 0x12, 0x85, 0x05, 0x93, 0x90, 0x3c, 0x8b, 0x3c, 0x83, 0xde,
+	0x77, 0x39, 0xbf, 0x65, 0x29, 0x2e, 0x58, 0xcf, 0x26, 0x03, 0xf0, 0x2e, 0x12, 0x21, 0x92, 0x74,
+	0x7e, 0x9a, 0xea, 0x91, 0xf6, 0x5a, 0x84, 0x80, 0x5b, 0x5a, 0xb1, 0x6f, 0xbc, 0xf6, 0x8b, 0x6f,
+	0xa1, 0x57, 0x59, 0x60, 0xc4, 0x91, 0xb3, 0x37, 0x4b, 0x1e, 0xbc, 0x0d, 0xb2, 0x0d, 0x5d, 0xb3,
+	0x9b, 0x3c, 0x4b, 0x22, 0x50, 0x1d, 0xe2, 0xd9, 0x52, 0xa6, 0x6c, 0x11, 0x8a, 0x85, 0xd7, 0x9a,
+	0x9e, 0xc3, 0xf6, 0xe5, 0x2a, 0x8d, 0xde, 0xa6, 0x71, 0xc6, 0x93, 0xb4, 0x20, 0xaf, 0xa1, 0x7f,
+	0x95, 0x27, 0xf3, 0x39, 0xcb, 0xd5, 0x4f, 0x1a, 0xd1, 0x7d, 0x5a, 0xfb, 0xf5, 0x1c, 0x0e, 0xea,
+	0x46, 0x45, 0x53, 0xb0, 0x31, 0x7d, 0x0d, 0x0e, 0x02, 0x93, 0x90, 0xc8, 0x04, 0xda, 0xf8, 0x5d,
+	0xd9, 0xb3, 0xe6, 0x3e, 0xa9, 0x9a, 0xca, 0xdb, 0xff, 0xd8, 0xe0, 0xd6, 0xe6, 0x41, 0x90, 0x0b,
+	0x70, 0xeb, 0xc3, 0x4c, 0xf6, 0xd4, 0xd5, 0xc6, 0x1d, 0x31, 0xdc, 0x6f, 0x3e, 0x34, 0x19, 0xc8,
+	0x19, 0xf4, 0x6b, 0x03, 0x4a, 0x86, 0xea, 0x42, 0xd3, 0x34, 0x0f, 0xf7, 0x1a, 0xcf, 0xca, 0x58,
+	0x17, 0xe0, 0xd6, 0x67, 0xce, 0x40, 0x6b, 0x1c, 0xdb, 0xe1, 0x7e, 0xf3, 0x61, 0x15, 0x5a, 0xad,
+	0xf9, 0x0c, 0xb4, 0xa6, 0xee, 0x1f, 0xee, 0x35, 0x9e, 0x99, 0x58, 0xd7, 0x5b, 0xf8, 0xa7, 0xe7,
+	0xd5, 0xbf, 0x03, 0x00, 0xb9, 0x99, 0x91, 0x09, 0xb3, 0x09, 0x00, 0x00,
 }
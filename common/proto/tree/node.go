@@ -270,6 +270,9 @@ func (policy *VersioningPolicy) MarshalLogObject(encoder zapcore.ObjectEncoder)
 	if len(policy.KeepPeriods) > 0 {
 		encoder.AddReflected("Periods", policy.KeepPeriods)
 	}
+	if len(policy.Rules) > 0 {
+		encoder.AddReflected("Rules", policy.Rules)
+	}
 	return nil
 }
 
@@ -44,6 +44,7 @@ It has these top-level messages:
 	PruneVersionsResponse
 	VersioningPolicy
 	VersioningKeepPeriod
+	VersioningPolicyRule
 	Node
 	WorkspaceRelativePath
 	ChangeLog
@@ -44,6 +44,7 @@ It has these top-level messages:
 	PruneVersionsResponse
 	VersioningPolicy
 	VersioningKeepPeriod
+	VersioningPolicyRule
 	Node
 	WorkspaceRelativePath
 	ChangeLog
@@ -139,7 +140,7 @@ func (x NodeChangeEvent_EventType) String() string {
 	return proto.EnumName(NodeChangeEvent_EventType_name, int32(x))
 }
 func (NodeChangeEvent_EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{43, 0}
+	return fileDescriptor0, []int{44, 0}
 }
 
 type SyncChange_Type int32
@@ -170,7 +171,7 @@ var SyncChange_Type_value = map[string]int32{
 func (x SyncChange_Type) String() string {
 	return proto.EnumName(SyncChange_Type_name, int32(x))
 }
-func (SyncChange_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{47, 0} }
+func (SyncChange_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{48, 0} }
 
 // Request / Responses Messages
 type ReadNodeRequest struct {
@@ -1214,6 +1215,8 @@ type VersioningPolicy struct {
 	MaxSizePerFile           int64                   `protobuf:"varint,7,opt,name=MaxSizePerFile" json:"MaxSizePerFile,omitempty"`
 	IgnoreFilesGreaterThan   int64                   `protobuf:"varint,8,opt,name=IgnoreFilesGreaterThan" json:"IgnoreFilesGreaterThan,omitempty"`
 	KeepPeriods              []*VersioningKeepPeriod `protobuf:"bytes,9,rep,name=KeepPeriods" json:"KeepPeriods,omitempty"`
+	// Rules are evaluated in order, the first matching rule overrides the policy KeepPeriods
+	Rules []*VersioningPolicyRule `protobuf:"bytes,10,rep,name=Rules" json:"Rules,omitempty"`
 }
 
 func (m *VersioningPolicy) Reset()                    { *m = VersioningPolicy{} }
@@ -1284,6 +1287,13 @@ func (m *VersioningPolicy) GetKeepPeriods() []*VersioningKeepPeriod {
 	return nil
 }
 
+func (m *VersioningPolicy) GetRules() []*VersioningPolicyRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 type VersioningKeepPeriod struct {
 	IntervalStart string `protobuf:"bytes,1,opt,name=IntervalStart" json:"IntervalStart,omitempty"`
 	MaxNumber     int32  `protobuf:"varint,3,opt,name=MaxNumber" json:"MaxNumber,omitempty"`
@@ -1308,6 +1318,87 @@ func (m *VersioningKeepPeriod) GetMaxNumber() int32 {
 	return 0
 }
 
+// VersioningPolicyRule applies specific retention to the nodes matching all its criteria.
+// Empty criteria are ignored.
+type VersioningPolicyRule struct {
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	// Path prefixes, relative to the datasource root
+	PathPrefixes []string `protobuf:"bytes,2,rep,name=PathPrefixes" json:"PathPrefixes,omitempty"`
+	// File extensions, without leading dot
+	Extensions []string `protobuf:"bytes,3,rep,name=Extensions" json:"Extensions,omitempty"`
+	// Mime types computed from the extension, a trailing wildcard is supported (e.g. image/*)
+	MimeTypes []string `protobuf:"bytes,4,rep,name=MimeTypes" json:"MimeTypes,omitempty"`
+	// Minimum size in bytes
+	MinSize int64 `protobuf:"varint,5,opt,name=MinSize" json:"MinSize,omitempty"`
+	// Maximum size in bytes
+	MaxSize int64 `protobuf:"varint,6,opt,name=MaxSize" json:"MaxSize,omitempty"`
+	// Do not create any version for matching nodes
+	Ignore bool `protobuf:"varint,7,opt,name=Ignore" json:"Ignore,omitempty"`
+	// Keep periods applied to matching nodes
+	KeepPeriods []*VersioningKeepPeriod `protobuf:"bytes,8,rep,name=KeepPeriods" json:"KeepPeriods,omitempty"`
+}
+
+func (m *VersioningPolicyRule) Reset()                    { *m = VersioningPolicyRule{} }
+func (m *VersioningPolicyRule) String() string            { return proto.CompactTextString(m) }
+func (*VersioningPolicyRule) ProtoMessage()               {}
+func (*VersioningPolicyRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *VersioningPolicyRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VersioningPolicyRule) GetPathPrefixes() []string {
+	if m != nil {
+		return m.PathPrefixes
+	}
+	return nil
+}
+
+func (m *VersioningPolicyRule) GetExtensions() []string {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+func (m *VersioningPolicyRule) GetMimeTypes() []string {
+	if m != nil {
+		return m.MimeTypes
+	}
+	return nil
+}
+
+func (m *VersioningPolicyRule) GetMinSize() int64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *VersioningPolicyRule) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *VersioningPolicyRule) GetIgnore() bool {
+	if m != nil {
+		return m.Ignore
+	}
+	return false
+}
+
+func (m *VersioningPolicyRule) GetKeepPeriods() []*VersioningKeepPeriod {
+	if m != nil {
+		return m.KeepPeriods
+	}
+	return nil
+}
+
 type Node struct {
 	// ------------------------------------
 	// Core identification of the node
@@ -1336,7 +1427,7 @@ type Node struct {
 func (m *Node) Reset()                    { *m = Node{} }
 func (m *Node) String() string            { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()               {}
-func (*Node) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*Node) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *Node) GetUuid() string {
 	if m != nil {
@@ -1426,7 +1517,7 @@ type WorkspaceRelativePath struct {
 func (m *WorkspaceRelativePath) Reset()                    { *m = WorkspaceRelativePath{} }
 func (m *WorkspaceRelativePath) String() string            { return proto.CompactTextString(m) }
 func (*WorkspaceRelativePath) ProtoMessage()               {}
-func (*WorkspaceRelativePath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*WorkspaceRelativePath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *WorkspaceRelativePath) GetWsUuid() string {
 	if m != nil {
@@ -1483,7 +1574,7 @@ type ChangeLog struct {
 func (m *ChangeLog) Reset()                    { *m = ChangeLog{} }
 func (m *ChangeLog) String() string            { return proto.CompactTextString(m) }
 func (*ChangeLog) ProtoMessage()               {}
-func (*ChangeLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*ChangeLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *ChangeLog) GetUuid() string {
 	if m != nil {
@@ -1573,7 +1664,7 @@ type Query struct {
 func (m *Query) Reset()                    { *m = Query{} }
 func (m *Query) String() string            { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()               {}
-func (*Query) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*Query) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *Query) GetPaths() []string {
 	if m != nil {
@@ -1712,7 +1803,7 @@ type GeoQuery struct {
 func (m *GeoQuery) Reset()                    { *m = GeoQuery{} }
 func (m *GeoQuery) String() string            { return proto.CompactTextString(m) }
 func (*GeoQuery) ProtoMessage()               {}
-func (*GeoQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*GeoQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *GeoQuery) GetCenter() *GeoPoint {
 	if m != nil {
@@ -1750,7 +1841,7 @@ type GeoPoint struct {
 func (m *GeoPoint) Reset()                    { *m = GeoPoint{} }
 func (m *GeoPoint) String() string            { return proto.CompactTextString(m) }
 func (*GeoPoint) ProtoMessage()               {}
-func (*GeoPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*GeoPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 func (m *GeoPoint) GetLat() float64 {
 	if m != nil {
@@ -1773,7 +1864,7 @@ type StreamChangesRequest struct {
 func (m *StreamChangesRequest) Reset()                    { *m = StreamChangesRequest{} }
 func (m *StreamChangesRequest) String() string            { return proto.CompactTextString(m) }
 func (*StreamChangesRequest) ProtoMessage()               {}
-func (*StreamChangesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*StreamChangesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *StreamChangesRequest) GetRootPath() string {
 	if m != nil {
@@ -1794,7 +1885,7 @@ type NodeChangeEvent struct {
 func (m *NodeChangeEvent) Reset()                    { *m = NodeChangeEvent{} }
 func (m *NodeChangeEvent) String() string            { return proto.CompactTextString(m) }
 func (*NodeChangeEvent) ProtoMessage()               {}
-func (*NodeChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*NodeChangeEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *NodeChangeEvent) GetType() NodeChangeEvent_EventType {
 	if m != nil {
@@ -1848,7 +1939,7 @@ type IndexEvent struct {
 func (m *IndexEvent) Reset()                    { *m = IndexEvent{} }
 func (m *IndexEvent) String() string            { return proto.CompactTextString(m) }
 func (*IndexEvent) ProtoMessage()               {}
-func (*IndexEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*IndexEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *IndexEvent) GetErrorDetected() bool {
 	if m != nil {
@@ -1888,7 +1979,7 @@ type GetEncryptionKeyRequest struct {
 func (m *GetEncryptionKeyRequest) Reset()                    { *m = GetEncryptionKeyRequest{} }
 func (m *GetEncryptionKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetEncryptionKeyRequest) ProtoMessage()               {}
-func (*GetEncryptionKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*GetEncryptionKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *GetEncryptionKeyRequest) GetUser() string {
 	if m != nil {
@@ -1925,7 +2016,7 @@ type GetEncryptionKeyResponse struct {
 func (m *GetEncryptionKeyResponse) Reset()                    { *m = GetEncryptionKeyResponse{} }
 func (m *GetEncryptionKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetEncryptionKeyResponse) ProtoMessage()               {}
-func (*GetEncryptionKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*GetEncryptionKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *GetEncryptionKeyResponse) GetKey() []byte {
 	if m != nil {
@@ -1946,7 +2037,7 @@ type SyncChange struct {
 func (m *SyncChange) Reset()                    { *m = SyncChange{} }
 func (m *SyncChange) String() string            { return proto.CompactTextString(m) }
 func (*SyncChange) ProtoMessage()               {}
-func (*SyncChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*SyncChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *SyncChange) GetSeq() uint64 {
 	if m != nil {
@@ -2001,7 +2092,7 @@ type SyncChangeNode struct {
 func (m *SyncChangeNode) Reset()                    { *m = SyncChangeNode{} }
 func (m *SyncChangeNode) String() string            { return proto.CompactTextString(m) }
 func (*SyncChangeNode) ProtoMessage()               {}
-func (*SyncChangeNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*SyncChangeNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *SyncChangeNode) GetBytesize() int64 {
 	if m != nil {
@@ -2046,7 +2137,7 @@ type PutSyncChangeResponse struct {
 func (m *PutSyncChangeResponse) Reset()                    { *m = PutSyncChangeResponse{} }
 func (m *PutSyncChangeResponse) String() string            { return proto.CompactTextString(m) }
 func (*PutSyncChangeResponse) ProtoMessage()               {}
-func (*PutSyncChangeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*PutSyncChangeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *PutSyncChangeResponse) GetSuccess() bool {
 	if m != nil {
@@ -2072,7 +2163,7 @@ type SearchSyncChangeRequest struct {
 func (m *SearchSyncChangeRequest) Reset()                    { *m = SearchSyncChangeRequest{} }
 func (m *SearchSyncChangeRequest) String() string            { return proto.CompactTextString(m) }
 func (*SearchSyncChangeRequest) ProtoMessage()               {}
-func (*SearchSyncChangeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*SearchSyncChangeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *SearchSyncChangeRequest) GetSeq() uint64 {
 	if m != nil {
@@ -2139,6 +2230,7 @@ func init() {
 	proto.RegisterType((*PruneVersionsResponse)(nil), "tree.PruneVersionsResponse")
 	proto.RegisterType((*VersioningPolicy)(nil), "tree.VersioningPolicy")
 	proto.RegisterType((*VersioningKeepPeriod)(nil), "tree.VersioningKeepPeriod")
+	proto.RegisterType((*VersioningPolicyRule)(nil), "tree.VersioningPolicyRule")
 	proto.RegisterType((*Node)(nil), "tree.Node")
 	proto.RegisterType((*WorkspaceRelativePath)(nil), "tree.WorkspaceRelativePath")
 	proto.RegisterType((*ChangeLog)(nil), "tree.ChangeLog")
@@ -2162,185 +2254,190 @@ func init() {
 func init() { proto.RegisterFile("tree.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2879 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x1a, 0xdb, 0x6e, 0x23, 0x49,
-	0x35, 0xed, 0x76, 0x1c, 0xfb, 0xe4, 0xd6, 0xa9, 0x38, 0x33, 0x5e, 0xef, 0x85, 0xa1, 0x59, 0x2d,
-	0xd9, 0x65, 0x15, 0xed, 0x66, 0x58, 0xf6, 0x8a, 0x58, 0x8f, 0xed, 0xcc, 0x84, 0x49, 0x62, 0xd3,
-	0x76, 0x36, 0x02, 0x09, 0x2d, 0x3d, 0x76, 0xc5, 0x69, 0xc6, 0xee, 0xf6, 0x54, 0x97, 0xb3, 0x31,
-	0x2f, 0x30, 0x2f, 0xbc, 0x21, 0xa4, 0x95, 0xf8, 0x00, 0x84, 0xc4, 0x03, 0x3f, 0xc0, 0x23, 0x2f,
-	0x3c, 0xf1, 0x03, 0xfc, 0x02, 0xbf, 0x80, 0x78, 0x41, 0xa7, 0x2e, 0x7d, 0x71, 0x77, 0x76, 0x26,
-	0x33, 0xfb, 0x12, 0xd5, 0xb9, 0xf4, 0xa9, 0x73, 0xa9, 0x73, 0xa9, 0x72, 0x00, 0x38, 0xa3, 0x74,
-	0x6f, 0xca, 0x02, 0x1e, 0x90, 0x22, 0xae, 0xed, 0xbf, 0x18, 0xb0, 0xe9, 0x50, 0x77, 0x78, 0x12,
-	0x0c, 0xa9, 0x43, 0x9f, 0xcc, 0x68, 0xc8, 0xc9, 0x1b, 0x50, 0x44, 0xb0, 0x66, 0xdc, 0x31, 0x76,
-	0x57, 0xf7, 0x61, 0x4f, 0x7c, 0x24, 0x18, 0x04, 0x9e, 0xdc, 0x81, 0xd5, 0x33, 0x8f, 0x5f, 0x34,
-	0x83, 0xc9, 0xc4, 0xe3, 0x61, 0xad, 0x70, 0xc7, 0xd8, 0x2d, 0x3b, 0x49, 0x14, 0x79, 0x17, 0xb6,
-	0x10, 0x6c, 0x5f, 0x71, 0xea, 0x0f, 0xe9, 0xb0, 0xc7, 0x5d, 0x1e, 0xd6, 0x4c, 0xc1, 0x97, 0x25,
-	0xa0, 0xbc, 0xce, 0xa3, 0x5f, 0xd3, 0x01, 0x97, 0x7c, 0x45, 0x29, 0x2f, 0x81, 0xb2, 0x8f, 0xc0,
-	0x8a, 0x95, 0x0c, 0xa7, 0x81, 0x1f, 0x52, 0x52, 0x83, 0x95, 0xde, 0x6c, 0x30, 0xa0, 0x61, 0x28,
-	0x14, 0x2d, 0x3b, 0x1a, 0x8c, 0xf4, 0x2f, 0xe4, 0xeb, 0x6f, 0x7f, 0x5d, 0x00, 0xeb, 0xc8, 0x0b,
-	0x39, 0x02, 0xe1, 0xf3, 0x1a, 0xfd, 0x1a, 0x54, 0x1c, 0x3a, 0x98, 0xb1, 0xd0, 0xbb, 0xa4, 0xca,
-	0xe4, 0x18, 0x81, 0xd4, 0x86, 0x3f, 0xa0, 0x21, 0x0f, 0x98, 0x36, 0x34, 0x46, 0x10, 0x1b, 0xd6,
-	0xd0, 0xea, 0x2f, 0x28, 0x0b, 0xbd, 0xc0, 0x0f, 0x6b, 0x2b, 0x82, 0x21, 0x85, 0x5b, 0x74, 0x6a,
-	0x39, 0xeb, 0xd4, 0x2a, 0x2c, 0x1f, 0x79, 0x13, 0x8f, 0x0b, 0x07, 0x99, 0x8e, 0x04, 0xc8, 0x2d,
-	0x28, 0x75, 0xce, 0xcf, 0x43, 0xca, 0x6b, 0xcb, 0x02, 0xad, 0x20, 0xb2, 0x07, 0x70, 0xe0, 0x8d,
-	0x39, 0x65, 0xfd, 0xf9, 0x94, 0xd6, 0x4a, 0x77, 0x8c, 0xdd, 0x8d, 0xfd, 0x8d, 0xd8, 0x2a, 0xc4,
-	0x3a, 0x09, 0x0e, 0xfb, 0x2e, 0x6c, 0x25, 0x7c, 0xa2, 0x7c, 0xfc, 0x0c, 0xa7, 0xd8, 0xff, 0x34,
-	0xa0, 0x76, 0xc6, 0xdc, 0xe9, 0xd4, 0xf3, 0x47, 0x3d, 0xce, 0xa8, 0x3b, 0xa1, 0x2c, 0xfa, 0xf8,
-	0x7e, 0x8e, 0x44, 0x25, 0xe9, 0xb6, 0x94, 0x94, 0x21, 0x3f, 0x58, 0x72, 0x72, 0xb4, 0x68, 0xc0,
-	0x26, 0x22, 0x9a, 0x17, 0xae, 0x3f, 0xa2, 0xed, 0x4b, 0xea, 0x73, 0x15, 0xda, 0x9d, 0x58, 0xa1,
-	0x04, 0xf1, 0xc1, 0x92, 0xb3, 0xc8, 0x8f, 0xbe, 0x6b, 0x33, 0x16, 0x30, 0x11, 0x9b, 0x8a, 0x23,
-	0x81, 0x7b, 0x25, 0x28, 0xb6, 0x5c, 0xee, 0xda, 0x7f, 0x36, 0x60, 0xab, 0xc9, 0xa8, 0xcb, 0xe9,
-	0x4d, 0xd2, 0xe0, 0x2d, 0xd8, 0x38, 0x9d, 0x0e, 0x5d, 0x4e, 0x0f, 0xcf, 0xdb, 0x57, 0x5e, 0x18,
-	0x65, 0xc2, 0x02, 0x16, 0x93, 0xe1, 0xd0, 0x1f, 0xd2, 0x2b, 0x97, 0x7b, 0x81, 0xdf, 0xa3, 0x21,
-	0xc6, 0x5b, 0xe9, 0x91, 0x25, 0x60, 0x3c, 0x7b, 0xde, 0x18, 0x6d, 0x94, 0x79, 0xa0, 0x20, 0xfb,
-	0x04, 0x48, 0x52, 0xc5, 0x97, 0x4e, 0x82, 0x3f, 0x19, 0xb0, 0x25, 0x15, 0x5d, 0xb0, 0xf9, 0x80,
-	0x05, 0x93, 0x3c, 0x9b, 0x11, 0x4f, 0xea, 0x50, 0xe8, 0x07, 0x39, 0x32, 0x0b, 0xfd, 0xe0, 0xdb,
-	0xb3, 0x33, 0xa9, 0xd6, 0x4b, 0xdb, 0x39, 0x87, 0xad, 0x16, 0x1d, 0xd3, 0x9b, 0x85, 0x36, 0xd7,
-	0x94, 0xc2, 0xb3, 0x4d, 0x31, 0x53, 0xa6, 0xec, 0x01, 0x49, 0x6e, 0xfd, 0x2c, 0x53, 0xec, 0xff,
-	0x1a, 0x39, 0xdb, 0x12, 0x02, 0xc5, 0xd3, 0x99, 0x37, 0x14, 0xcc, 0x15, 0x47, 0xac, 0xb1, 0x58,
-	0xb4, 0x68, 0x38, 0x60, 0xde, 0x94, 0xc7, 0x9a, 0x25, 0x51, 0xe4, 0x2d, 0x28, 0x3b, 0x41, 0x20,
-	0x12, 0x49, 0x68, 0x95, 0xb6, 0x32, 0xa2, 0x91, 0x8f, 0xe0, 0x76, 0xfb, 0x6a, 0x4a, 0x07, 0x9c,
-	0x0e, 0x3b, 0x53, 0xca, 0xc4, 0xce, 0x61, 0x33, 0x98, 0xf9, 0xba, 0xcc, 0x5c, 0x47, 0x26, 0x3f,
-	0x84, 0x9d, 0xe6, 0x8c, 0x31, 0xea, 0xf3, 0x88, 0x22, 0xbf, 0x93, 0x75, 0x28, 0x9f, 0x98, 0xf0,
-	0x55, 0x29, 0xe5, 0xab, 0x27, 0xb0, 0x1d, 0x9b, 0x1e, 0x7d, 0x83, 0x86, 0x2a, 0x3f, 0x24, 0x7c,
-	0x90, 0x44, 0x3d, 0x87, 0x2b, 0x6e, 0x41, 0xa9, 0x39, 0x63, 0xa1, 0x4a, 0x7e, 0xd3, 0x51, 0x90,
-	0x7d, 0x1f, 0x48, 0x67, 0x4a, 0xb5, 0x9f, 0xf5, 0xd1, 0x78, 0x1f, 0x56, 0x74, 0xc0, 0x53, 0xb5,
-	0x2a, 0x13, 0x18, 0x47, 0xf3, 0xd9, 0x0f, 0x60, 0x3b, 0x25, 0x48, 0x05, 0xfa, 0xc5, 0x24, 0x1d,
-	0x8c, 0x67, 0xe1, 0xc5, 0xcb, 0xeb, 0x74, 0x08, 0xd5, 0xb4, 0xa4, 0x97, 0x52, 0xaa, 0x39, 0x0e,
-	0x42, 0xfa, 0xad, 0x28, 0x95, 0x96, 0xf4, 0xe2, 0x4a, 0xed, 0x83, 0x75, 0xe6, 0xf2, 0xc1, 0xc5,
-	0x0d, 0xb2, 0x1a, 0x5b, 0x5c, 0xe2, 0x9b, 0xe7, 0x6c, 0x71, 0x1c, 0xd6, 0x7b, 0xd4, 0x65, 0x83,
-	0x0b, 0xbd, 0xcb, 0x77, 0x61, 0xf9, 0x67, 0x33, 0xca, 0xe6, 0xea, 0x8b, 0x55, 0xf9, 0x85, 0x40,
-	0x39, 0x92, 0x82, 0x29, 0xdb, 0xf3, 0x7e, 0x23, 0x6b, 0xd2, 0xb2, 0x23, 0xd6, 0x88, 0x13, 0x95,
-	0xd5, 0x94, 0x38, 0x51, 0x4d, 0x6b, 0xb0, 0xd2, 0xa2, 0xdc, 0xf5, 0xc6, 0x7a, 0xe8, 0xd1, 0xa0,
-	0xfd, 0x77, 0x03, 0x0f, 0x3e, 0x6e, 0x7b, 0xe0, 0x0e, 0x28, 0xc7, 0xf9, 0xe2, 0xc0, 0xa3, 0xe3,
-	0xe1, 0x89, 0x3b, 0xa1, 0x2a, 0x0b, 0x62, 0x84, 0x98, 0x0c, 0xdc, 0x47, 0x74, 0xac, 0x4e, 0xbf,
-	0x04, 0x10, 0x2b, 0x13, 0x52, 0x6e, 0x29, 0x01, 0xd4, 0xa3, 0x4f, 0xd9, 0x44, 0x6c, 0x58, 0x71,
-	0xc4, 0x9a, 0x58, 0x60, 0x1e, 0x7b, 0xbe, 0x4a, 0x5c, 0x5c, 0x0a, 0x8c, 0x7b, 0x25, 0x72, 0x14,
-	0x31, 0xee, 0x15, 0x4a, 0xeb, 0x71, 0x97, 0x71, 0x31, 0xbc, 0x2c, 0x3b, 0x12, 0x40, 0xbe, 0xb6,
-	0x3f, 0x14, 0xd3, 0xca, 0xb2, 0x83, 0x4b, 0xfb, 0xe7, 0xb0, 0xa1, 0xfd, 0xf5, 0x7c, 0x1e, 0x26,
-	0xdf, 0x87, 0x65, 0x61, 0xa4, 0x2a, 0xe1, 0x5b, 0x92, 0x21, 0x61, 0xbd, 0x23, 0xe9, 0xf6, 0x13,
-	0xa8, 0xca, 0x16, 0xa8, 0x86, 0xa6, 0xe7, 0xad, 0xe6, 0x1f, 0xc3, 0x5a, 0x9f, 0x79, 0xa3, 0x11,
-	0x65, 0xcf, 0x1e, 0x1e, 0x9c, 0x14, 0xab, 0x7d, 0x0f, 0x76, 0x16, 0xb6, 0x54, 0x46, 0xbd, 0x0d,
-	0x2b, 0x0a, 0xa5, 0xb6, 0xdd, 0x94, 0xe2, 0xa4, 0xa8, 0xa3, 0x60, 0xe4, 0x68, 0xba, 0xfd, 0x01,
-	0x6c, 0xe3, 0x4c, 0xa3, 0x27, 0xbd, 0xe7, 0x3d, 0xad, 0x0d, 0xa8, 0xa6, 0x3f, 0xbb, 0xf9, 0xce,
-	0x0e, 0x90, 0x07, 0xd4, 0x1d, 0xde, 0xd0, 0x5d, 0xaf, 0x41, 0x45, 0x7d, 0x71, 0x38, 0x54, 0x27,
-	0x2a, 0x46, 0xd8, 0x9f, 0xc3, 0x76, 0x4a, 0xe6, 0xcd, 0xb5, 0xfa, 0x15, 0x6c, 0xf7, 0x78, 0xc0,
-	0x6e, 0x1a, 0xc5, 0xc4, 0x0e, 0x85, 0x67, 0xec, 0x30, 0x82, 0x6a, 0x7a, 0x87, 0x67, 0x4e, 0x11,
-	0x1f, 0xc0, 0x7a, 0x97, 0xcd, 0x7c, 0x1a, 0x8d, 0xe8, 0x85, 0x3b, 0x66, 0xde, 0x16, 0x69, 0x2e,
-	0x7b, 0x0c, 0xd5, 0x14, 0x42, 0xdb, 0xf2, 0x0e, 0xc0, 0xa9, 0xef, 0x3d, 0x99, 0xd1, 0x6b, 0x2c,
-	0x4a, 0x50, 0xc9, 0x2e, 0x6c, 0x36, 0xc6, 0x63, 0x39, 0x28, 0x88, 0x1b, 0x8e, 0x9e, 0x23, 0x17,
-	0xd1, 0x76, 0x03, 0x76, 0x16, 0x76, 0x53, 0x76, 0xed, 0xc2, 0xa6, 0x62, 0x8c, 0xf4, 0x37, 0xee,
-	0x98, 0xbb, 0x15, 0x67, 0x11, 0x6d, 0x7f, 0x6d, 0x82, 0xa5, 0x00, 0xcf, 0x1f, 0x75, 0x83, 0xb1,
-	0x37, 0x98, 0xe7, 0x4e, 0x18, 0x04, 0x8a, 0xa2, 0xd6, 0xc8, 0xf8, 0x8b, 0xf5, 0x62, 0xab, 0x35,
-	0xb3, 0xad, 0xf6, 0x47, 0x70, 0x4b, 0x6f, 0x85, 0x83, 0x75, 0x2f, 0x98, 0xb1, 0x01, 0x15, 0x72,
-	0x64, 0xb9, 0xb9, 0x86, 0x4a, 0x3e, 0x81, 0x5a, 0x96, 0x72, 0x6f, 0x36, 0x78, 0xac, 0xae, 0x35,
-	0x15, 0xe7, 0x5a, 0x3a, 0x5e, 0xae, 0x8e, 0xdd, 0xab, 0x7e, 0xc0, 0xdd, 0xb1, 0x28, 0xba, 0xb2,
-	0x66, 0xa5, 0x70, 0x38, 0xaa, 0x1f, 0xbb, 0x57, 0xb8, 0xec, 0x52, 0x76, 0xe0, 0x8d, 0xa9, 0xa8,
-	0x62, 0xa6, 0xb3, 0x80, 0x45, 0xfd, 0x0f, 0x47, 0x7e, 0xc0, 0x28, 0x42, 0xe1, 0x7d, 0x91, 0xf9,
-	0xac, 0x7f, 0xe1, 0xfa, 0xa2, 0xc2, 0x99, 0xce, 0x35, 0x54, 0xf2, 0x19, 0xac, 0x3e, 0xa4, 0x74,
-	0xda, 0xa5, 0xcc, 0x0b, 0x86, 0x61, 0xad, 0x22, 0x0e, 0x4f, 0x5d, 0x06, 0x3c, 0x76, 0x77, 0xcc,
-	0xe2, 0x24, 0xd9, 0xed, 0x5f, 0x40, 0x35, 0x8f, 0x89, 0xbc, 0x09, 0xeb, 0x87, 0x3e, 0xa7, 0xec,
-	0xd2, 0x1d, 0xcb, 0xd2, 0x2b, 0x03, 0x94, 0x46, 0x62, 0xba, 0x1e, 0xbb, 0x57, 0x27, 0xb3, 0xc9,
-	0x23, 0xca, 0x54, 0xa9, 0x8f, 0x11, 0xf6, 0x53, 0x53, 0xa6, 0xd5, 0x75, 0x41, 0xee, 0xba, 0xfc,
-	0x42, 0x07, 0x19, 0xd7, 0xc4, 0x86, 0xa2, 0xb8, 0x31, 0x9a, 0xb9, 0x37, 0x46, 0x41, 0x8b, 0xfa,
-	0x9b, 0x9c, 0x10, 0x65, 0x7f, 0xab, 0xc2, 0xf2, 0x71, 0xdf, 0x9b, 0x50, 0xd5, 0x45, 0x24, 0x80,
-	0x9c, 0xc7, 0x98, 0x02, 0x25, 0xd9, 0xf5, 0x8e, 0x95, 0x26, 0x6d, 0xee, 0x8e, 0x44, 0x08, 0x2a,
-	0x8e, 0x58, 0x63, 0x72, 0xeb, 0x9b, 0x6f, 0x25, 0x3f, 0xf3, 0x34, 0x9d, 0x7c, 0x08, 0x95, 0x63,
-	0xca, 0x5d, 0x91, 0xe0, 0xb5, 0xb2, 0x60, 0x7e, 0x25, 0xd6, 0x72, 0x2f, 0xa2, 0xb5, 0x7d, 0xce,
-	0xe6, 0x4e, 0xcc, 0x4b, 0x3e, 0x86, 0x4a, 0x63, 0x3a, 0xa5, 0x2e, 0x0b, 0x0f, 0xfd, 0x1a, 0x88,
-	0x0f, 0x5f, 0x95, 0x1f, 0x9e, 0x05, 0xec, 0x71, 0x38, 0x75, 0x07, 0xd4, 0xa1, 0x63, 0x97, 0x7b,
-	0x97, 0x14, 0x3d, 0xe1, 0xc4, 0xdc, 0xf5, 0xcf, 0x60, 0x23, 0x2d, 0x17, 0x1b, 0xdf, 0x63, 0x3a,
-	0x57, 0xde, 0xc4, 0x25, 0x3a, 0xe0, 0xd2, 0x1d, 0xcf, 0x74, 0xca, 0x48, 0xe0, 0x93, 0xc2, 0x47,
-	0x86, 0xfd, 0x47, 0x03, 0x76, 0x72, 0xb7, 0xc0, 0xd1, 0xf4, 0x2c, 0x4c, 0x84, 0x45, 0x41, 0x58,
-	0xa8, 0xce, 0xc2, 0x64, 0x4b, 0xd7, 0x60, 0x14, 0x32, 0x33, 0x11, 0x32, 0x21, 0xa5, 0x37, 0x9e,
-	0x8d, 0x54, 0x96, 0x29, 0x48, 0x4a, 0xe9, 0x0d, 0x82, 0x29, 0x55, 0x49, 0xa4, 0x41, 0xfb, 0x5f,
-	0x06, 0x54, 0x22, 0xd7, 0xbe, 0xe0, 0x0d, 0x23, 0x0a, 0xb8, 0xb9, 0x10, 0xf0, 0xcc, 0xd1, 0x20,
-	0xf2, 0x9a, 0x2d, 0x94, 0x58, 0x73, 0xc4, 0x1a, 0x4f, 0x6d, 0xe7, 0x2b, 0x9f, 0x32, 0xb1, 0x71,
-	0x49, 0x36, 0x99, 0x08, 0x41, 0x7e, 0x00, 0xcb, 0xb2, 0x55, 0xaf, 0x7c, 0x53, 0xab, 0x96, 0x3c,
-	0xf6, 0xd3, 0xa2, 0x9a, 0xc8, 0x50, 0x25, 0x74, 0x48, 0x58, 0x5b, 0x17, 0xd5, 0x4f, 0x02, 0xe4,
-	0x0d, 0x00, 0x5c, 0x74, 0x19, 0x3d, 0xf7, 0xae, 0x54, 0x61, 0x4c, 0x60, 0xd0, 0x4d, 0xc7, 0x9e,
-	0x1f, 0x0d, 0x6c, 0xa6, 0xa3, 0x41, 0x41, 0x91, 0x05, 0x42, 0x19, 0xa9, 0x41, 0xf5, 0x4d, 0xcb,
-	0xe5, 0xda, 0x52, 0x0d, 0xaa, 0x6f, 0x04, 0x65, 0x39, 0xfa, 0x46, 0x50, 0x6c, 0x58, 0x6b, 0xcd,
-	0xe4, 0xbd, 0x46, 0x90, 0x2d, 0x61, 0x75, 0x0a, 0x17, 0x65, 0x5f, 0xe9, 0x1b, 0xb2, 0xaf, 0x0e,
-	0x65, 0x2c, 0x40, 0xa2, 0xac, 0xca, 0x1c, 0x8a, 0x60, 0xdc, 0xbd, 0x19, 0xf8, 0x1c, 0x5d, 0x57,
-	0x96, 0x21, 0x57, 0x20, 0x5e, 0x69, 0x35, 0x57, 0x87, 0x69, 0x9e, 0x2d, 0x79, 0xa5, 0xcd, 0x10,
-	0xd0, 0x67, 0x07, 0x8c, 0xd2, 0x1e, 0x67, 0x9e, 0x3f, 0xaa, 0x55, 0x04, 0x5b, 0x02, 0x83, 0xe1,
-	0x13, 0x6f, 0x78, 0xa2, 0x1d, 0x83, 0x0c, 0x5f, 0x84, 0x20, 0xef, 0x40, 0xf9, 0x3e, 0x0d, 0xe4,
-	0x94, 0xbc, 0x2a, 0x22, 0xa8, 0x2c, 0xd1, 0x58, 0x27, 0xa2, 0xa3, 0x24, 0x8c, 0x45, 0x8b, 0x4e,
-	0xf9, 0x45, 0x6d, 0x4d, 0x96, 0xaf, 0x08, 0x81, 0x11, 0x3d, 0x3d, 0x3d, 0x6c, 0x85, 0xb5, 0x4d,
-	0x19, 0x51, 0x01, 0x60, 0xf2, 0x9d, 0x04, 0xbc, 0xb6, 0x21, 0xda, 0x24, 0x2e, 0xed, 0xbf, 0x19,
-	0xf1, 0x96, 0xe4, 0x2d, 0x28, 0x35, 0x29, 0xd6, 0x48, 0xd5, 0x79, 0xe3, 0xcd, 0xbb, 0x81, 0xe7,
-	0x73, 0x47, 0x51, 0xd1, 0x91, 0x2d, 0x2f, 0xe4, 0xae, 0x3f, 0xd0, 0x49, 0x1b, 0xc1, 0x64, 0x17,
-	0x56, 0xfa, 0xc1, 0xf4, 0x88, 0x9e, 0x73, 0x75, 0x7d, 0x5e, 0x14, 0xa2, 0xc9, 0xe4, 0x3d, 0x58,
-	0xbd, 0x17, 0x70, 0x1e, 0x4c, 0x1c, 0x6f, 0x74, 0x21, 0x6f, 0xcd, 0x59, 0xee, 0x24, 0x8b, 0xbd,
-	0x27, 0x74, 0x15, 0x04, 0x34, 0xe5, 0xc8, 0x95, 0x95, 0xdd, 0x70, 0x70, 0x29, 0x30, 0x2a, 0xe3,
-	0x10, 0x23, 0xee, 0x3a, 0x55, 0xf9, 0xb8, 0x26, 0x0f, 0x7f, 0x34, 0x65, 0xd4, 0xe5, 0x1d, 0x5f,
-	0xd4, 0x03, 0x99, 0xbb, 0x11, 0x6c, 0xff, 0xc3, 0xcc, 0x3c, 0x9a, 0x91, 0xbb, 0xea, 0x70, 0x19,
-	0xe2, 0x70, 0x7d, 0x27, 0x37, 0xa9, 0xf6, 0xc4, 0xdf, 0xc4, 0x69, 0xb3, 0xa1, 0x24, 0xdb, 0x6d,
-	0xce, 0x0b, 0x8b, 0xa2, 0x20, 0x4f, 0xdf, 0x65, 0x23, 0xca, 0x73, 0x9e, 0x1a, 0x14, 0x85, 0xfc,
-	0x04, 0xca, 0x58, 0x42, 0x87, 0x58, 0x08, 0x4a, 0xa2, 0xf8, 0x7e, 0x2f, 0x5f, 0x01, 0xcd, 0x25,
-	0xeb, 0x77, 0xf4, 0xd1, 0x75, 0x0f, 0x46, 0x78, 0x54, 0x3b, 0x53, 0xee, 0x4d, 0xbc, 0x90, 0x7b,
-	0x03, 0x91, 0x73, 0x65, 0x27, 0x81, 0xa9, 0x7f, 0x0a, 0xeb, 0x29, 0x91, 0x37, 0x2a, 0xdd, 0x73,
-	0xa8, 0x44, 0x0e, 0x21, 0x00, 0xa5, 0xa6, 0xd3, 0x6e, 0xf4, 0xdb, 0xd6, 0x12, 0x29, 0x43, 0xd1,
-	0x69, 0x37, 0x5a, 0x96, 0x41, 0x36, 0x61, 0xf5, 0xb4, 0xdb, 0x6a, 0xf4, 0xdb, 0x5f, 0x76, 0x1b,
-	0xfd, 0x07, 0x56, 0x81, 0x10, 0xd8, 0x50, 0x88, 0x66, 0xe7, 0xa4, 0xdf, 0x3e, 0xe9, 0x5b, 0x66,
-	0x82, 0xe9, 0xb8, 0xdd, 0x6f, 0x58, 0x45, 0x52, 0x05, 0x4b, 0x21, 0x4e, 0x7b, 0x6d, 0x47, 0x62,
-	0x4b, 0xb8, 0x43, 0xab, 0x7d, 0xd4, 0xee, 0xb7, 0xad, 0x65, 0xfb, 0xaf, 0x06, 0x80, 0xb8, 0x00,
-	0xcb, 0xe0, 0xbd, 0x09, 0xeb, 0xe2, 0xd1, 0xb2, 0x45, 0xb9, 0x78, 0x8e, 0x51, 0x13, 0x6c, 0x1a,
-	0x89, 0x83, 0xce, 0xc2, 0xe0, 0x25, 0x4d, 0x5a, 0xc0, 0x8a, 0xfc, 0xc5, 0x0f, 0x13, 0xbd, 0x24,
-	0x46, 0x60, 0xad, 0x50, 0xf7, 0xec, 0x83, 0x80, 0x0d, 0xa8, 0xb8, 0xb3, 0xab, 0xde, 0x92, 0x25,
-	0xd8, 0x4f, 0x0d, 0xb8, 0x7d, 0x9f, 0xf2, 0xb6, 0x3f, 0x60, 0x73, 0xd1, 0x1a, 0x1e, 0xd2, 0xb9,
-	0x3e, 0xa2, 0xd8, 0x5a, 0x42, 0x95, 0x88, 0xd8, 0x5a, 0x42, 0x99, 0x76, 0x5d, 0x37, 0x0c, 0xbf,
-	0x0a, 0x98, 0xbe, 0x5e, 0x44, 0x70, 0x74, 0x09, 0x30, 0xaf, 0xb9, 0x04, 0xdc, 0x82, 0x92, 0xbc,
-	0x8f, 0xa9, 0x40, 0x2b, 0xc8, 0x7e, 0x17, 0x6a, 0x59, 0x15, 0xd4, 0x74, 0x6c, 0x81, 0xf9, 0x50,
-	0xc5, 0x7b, 0xcd, 0xc1, 0xa5, 0xfd, 0xbb, 0x02, 0x40, 0x6f, 0xee, 0x0f, 0xe4, 0xb1, 0x43, 0x86,
-	0x90, 0x3e, 0x11, 0x0c, 0x45, 0x07, 0x97, 0xe4, 0x36, 0x94, 0xfc, 0x60, 0x48, 0xa3, 0xfb, 0xcf,
-	0x0a, 0x42, 0x5f, 0x7a, 0x43, 0xf2, 0x36, 0x14, 0x79, 0x3c, 0x1d, 0xa9, 0xbe, 0x14, 0x8b, 0xda,
-	0x93, 0x89, 0x83, 0x2c, 0xa8, 0x6a, 0x28, 0x13, 0x47, 0x75, 0x65, 0x09, 0x21, 0x9e, 0xcb, 0x64,
-	0x91, 0x4d, 0x59, 0x41, 0x64, 0x17, 0x8a, 0xbe, 0x1e, 0x95, 0x56, 0xf7, 0xab, 0x8b, 0xa2, 0xa5,
-	0x13, 0x90, 0xc3, 0xbe, 0x27, 0xf3, 0x98, 0xac, 0xc2, 0xca, 0xcc, 0x7f, 0xec, 0x07, 0x5f, 0xf9,
-	0xd6, 0x12, 0x1e, 0x9d, 0x81, 0xf0, 0x85, 0x65, 0xe0, 0x7a, 0x28, 0x06, 0x7f, 0xab, 0x80, 0x07,
-	0x75, 0xea, 0xf2, 0x0b, 0xcb, 0x44, 0xf6, 0x81, 0x2c, 0xef, 0x56, 0x11, 0x4f, 0xd7, 0x46, 0x5a,
-	0x38, 0xc6, 0xe5, 0xd1, 0x9c, 0xd3, 0x10, 0xdb, 0x9d, 0x21, 0x5a, 0x57, 0x04, 0xa3, 0x8b, 0x26,
-	0xc3, 0x0f, 0x94, 0x37, 0x70, 0x89, 0x39, 0x33, 0xe1, 0x89, 0xf6, 0x2f, 0x00, 0xf2, 0x2a, 0x94,
-	0x51, 0x45, 0x71, 0xac, 0xa4, 0xd9, 0x15, 0xe1, 0x3a, 0x54, 0x81, 0xdc, 0x85, 0x2a, 0xa3, 0xd3,
-	0x20, 0xf4, 0x78, 0xc0, 0xe6, 0x87, 0x43, 0xea, 0x73, 0xef, 0xdc, 0xa3, 0x4c, 0xf9, 0x61, 0x27,
-	0xa6, 0x7d, 0xe9, 0x45, 0x44, 0xbb, 0x09, 0x3b, 0xdd, 0x19, 0x8f, 0x55, 0x4d, 0x5e, 0xe6, 0xc2,
-	0xf4, 0x65, 0x4e, 0x81, 0x42, 0xd9, 0x70, 0x14, 0x29, 0x1b, 0x8e, 0xec, 0xdf, 0xc2, 0x6d, 0xf9,
-	0x9e, 0x90, 0x94, 0x23, 0x4f, 0x68, 0x36, 0xf8, 0x35, 0x58, 0x39, 0x1f, 0xbb, 0x9c, 0x53, 0x5f,
-	0x5d, 0xc4, 0x34, 0x88, 0xa1, 0x9b, 0xca, 0x29, 0x42, 0xa6, 0x8c, 0x82, 0x70, 0x58, 0x1a, 0xbb,
-	0x21, 0xef, 0xd1, 0x27, 0x1d, 0x7f, 0x3c, 0xd7, 0x3f, 0x60, 0x25, 0x50, 0xef, 0xbc, 0x0f, 0x65,
-	0xdd, 0xc5, 0x31, 0x0e, 0xa7, 0x27, 0x0f, 0x4f, 0x3a, 0x67, 0x27, 0xb2, 0x8e, 0x1c, 0xb5, 0x1b,
-	0x07, 0x96, 0x41, 0x36, 0x00, 0x9a, 0x9d, 0xa3, 0xa3, 0x76, 0xb3, 0x7f, 0xd8, 0x39, 0xb1, 0x0a,
-	0xfb, 0x7f, 0x30, 0x60, 0x0d, 0xbf, 0xe9, 0xb2, 0xe0, 0xd2, 0x1b, 0x52, 0x46, 0x3e, 0x85, 0xb2,
-	0xfe, 0x11, 0x8c, 0xa8, 0x93, 0xb7, 0xf0, 0xcb, 0x5d, 0xfd, 0xd6, 0x22, 0x5a, 0xfa, 0xca, 0x5e,
-	0x22, 0x9f, 0x43, 0x25, 0xfa, 0x61, 0x85, 0xdc, 0xca, 0xfc, 0xfc, 0x22, 0x3f, 0xbf, 0xee, 0x67,
-	0x19, 0x7b, 0xe9, 0x3d, 0x63, 0xff, 0x97, 0x50, 0x4d, 0xaa, 0xa3, 0x7f, 0xee, 0x21, 0x6d, 0xd8,
-	0xd0, 0xfb, 0x49, 0xdc, 0x8d, 0x95, 0xdb, 0x35, 0x84, 0xf8, 0xed, 0xb8, 0x13, 0x84, 0x91, 0xf4,
-	0x03, 0x58, 0x4f, 0xf5, 0x3e, 0xa2, 0x6e, 0x55, 0x79, 0x0d, 0xb1, 0x9e, 0x3f, 0x27, 0x0a, 0xed,
-	0xff, 0xad, 0xbc, 0xe9, 0xd0, 0x01, 0xf5, 0x2e, 0x29, 0x23, 0x0d, 0x80, 0xf8, 0xf7, 0x14, 0xa2,
-	0x2c, 0xcf, 0xfc, 0x08, 0x54, 0xaf, 0x65, 0x09, 0x91, 0x4f, 0x1b, 0x00, 0xf1, 0x4f, 0x15, 0x5a,
-	0x44, 0xe6, 0x37, 0x15, 0x2d, 0x22, 0xfb, 0xab, 0x86, 0x14, 0x11, 0xff, 0x44, 0xa0, 0x45, 0x64,
-	0x7e, 0xaf, 0xd0, 0x22, 0xb2, 0xbf, 0x26, 0xd8, 0x4b, 0xfb, 0xff, 0x33, 0x80, 0x24, 0x2d, 0x53,
-	0x41, 0x78, 0x08, 0x56, 0xac, 0xb4, 0xc2, 0xbd, 0x88, 0x95, 0x18, 0x1c, 0x14, 0x16, 0xab, 0x9f,
-	0x16, 0x76, 0x23, 0x7b, 0xb5, 0xb0, 0xd8, 0x90, 0xb4, 0xb0, 0x1b, 0x59, 0x2e, 0x8e, 0xcd, 0x7f,
-	0xb0, 0x8e, 0xc9, 0x96, 0x24, 0x9a, 0x25, 0x65, 0xa4, 0x05, 0xab, 0x89, 0xe7, 0x78, 0xa2, 0x24,
-	0x64, 0x9f, 0xfa, 0xeb, 0xaf, 0xe4, 0x50, 0xa2, 0xc8, 0xdc, 0x87, 0xb5, 0xe4, 0x03, 0x3a, 0x51,
-	0xcc, 0x39, 0xcf, 0xf3, 0xf5, 0x7a, 0x1e, 0x29, 0x29, 0x28, 0xf9, 0xe8, 0xad, 0x05, 0xe5, 0x3c,
-	0xa9, 0x6b, 0x41, 0x79, 0x6f, 0xe4, 0xf6, 0xd2, 0xfe, 0x17, 0x32, 0xce, 0xe2, 0x4c, 0x87, 0x51,
-	0x55, 0xf8, 0x1c, 0x2a, 0xd1, 0xa3, 0xb6, 0x4e, 0xec, 0xc5, 0x97, 0x71, 0x9d, 0xd8, 0x99, 0xd7,
-	0x6f, 0x91, 0x1a, 0x4d, 0x28, 0xcb, 0xe2, 0x48, 0x19, 0xf9, 0x10, 0x4a, 0x72, 0x4d, 0xb6, 0x93,
-	0xcf, 0xb0, 0x5a, 0x4e, 0x35, 0x8d, 0x4c, 0x08, 0xd9, 0x86, 0x2d, 0x91, 0x76, 0xb2, 0xc1, 0x60,
-	0x8e, 0x53, 0xb6, 0x80, 0x3c, 0x63, 0x1e, 0xa7, 0x6c, 0xff, 0xa9, 0x09, 0xeb, 0x88, 0x55, 0x4f,
-	0x1e, 0x94, 0x91, 0x9f, 0xc2, 0x7a, 0xea, 0x91, 0x55, 0xe7, 0x78, 0xde, 0x63, 0x6f, 0xfd, 0xd5,
-	0x5c, 0x5a, 0xd2, 0xdb, 0xc9, 0xa7, 0x3f, 0xed, 0xed, 0x9c, 0x07, 0xc7, 0x7a, 0x3d, 0x8f, 0x14,
-	0x09, 0x3a, 0x84, 0xb5, 0xe4, 0xf3, 0xab, 0x16, 0x94, 0xf3, 0x92, 0xab, 0x05, 0xe5, 0xbd, 0xd6,
-	0xa2, 0x6f, 0xf0, 0x40, 0x26, 0x9e, 0x4c, 0xf5, 0x81, 0xcc, 0xbe, 0xcc, 0xea, 0x03, 0x99, 0xf3,
-	0xbe, 0x6a, 0x2f, 0xa1, 0x97, 0x52, 0xaf, 0x7f, 0xda, 0x4b, 0x79, 0x0f, 0x90, 0xda, 0x4b, 0xb9,
-	0xcf, 0x85, 0xf6, 0xd2, 0x3e, 0x85, 0x0d, 0xbc, 0xf3, 0x3d, 0xa4, 0xf3, 0x63, 0xd7, 0x77, 0x47,
-	0x94, 0x91, 0x1e, 0x58, 0x8b, 0x03, 0x14, 0x79, 0x5d, 0x5f, 0x62, 0x72, 0x67, 0xbb, 0xfa, 0x1b,
-	0xd7, 0x91, 0xa3, 0x6d, 0x7e, 0x6f, 0xc0, 0x6a, 0xdc, 0x71, 0x43, 0xf2, 0x11, 0x98, 0xdd, 0x19,
-	0x27, 0xd6, 0xe2, 0x6c, 0x13, 0xa9, 0x9b, 0xd7, 0xe8, 0x31, 0xd1, 0xc9, 0x8f, 0xa3, 0x73, 0xf9,
-	0x7a, 0xf2, 0x08, 0x66, 0xda, 0x79, 0x3d, 0x23, 0x1b, 0x23, 0xf0, 0xa8, 0x24, 0xfe, 0xe5, 0xe5,
-	0xee, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc0, 0xb1, 0x05, 0xa8, 0x00, 0x23, 0x00, 0x00,
+	// 2947 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x1a, 0xdb, 0x6e, 0x1b, 0xc7,
+	0xd5, 0xcb, 0xa5, 0x28, 0xf2, 0xe8, 0x46, 0x8d, 0x28, 0x7b, 0xb3, 0x4e, 0x52, 0x77, 0x1b, 0xa4,
+	0x4a, 0x1a, 0x08, 0x89, 0xdc, 0x34, 0xd7, 0xa2, 0xa1, 0x49, 0xca, 0x56, 0xac, 0x5b, 0x97, 0x54,
+	0x84, 0x16, 0x28, 0xd2, 0x35, 0x39, 0xa2, 0xb6, 0x26, 0x77, 0xe9, 0xd9, 0xa1, 0x22, 0xf6, 0xa5,
+	0xf5, 0x4b, 0xdf, 0x8a, 0x02, 0x05, 0xda, 0xf7, 0xa2, 0x40, 0x1f, 0xfa, 0x03, 0x7d, 0xec, 0x4b,
+	0x9e, 0xfa, 0x03, 0xfd, 0x85, 0xfe, 0x42, 0xd1, 0x97, 0xe2, 0xcc, 0x65, 0x2f, 0xdc, 0x55, 0x6c,
+	0xd9, 0x79, 0x21, 0xe6, 0x5c, 0xf6, 0xcc, 0xb9, 0xcc, 0x39, 0x73, 0x66, 0x86, 0x00, 0x9c, 0x51,
+	0xba, 0x3d, 0x61, 0x21, 0x0f, 0x49, 0x19, 0xc7, 0xce, 0x5f, 0x0d, 0x58, 0x73, 0xa9, 0x37, 0x38,
+	0x0c, 0x07, 0xd4, 0xa5, 0x4f, 0xa6, 0x34, 0xe2, 0xe4, 0x75, 0x28, 0x23, 0x68, 0x19, 0x77, 0x8c,
+	0xad, 0xa5, 0x1d, 0xd8, 0x16, 0x1f, 0x09, 0x06, 0x81, 0x27, 0x77, 0x60, 0xe9, 0xd4, 0xe7, 0xe7,
+	0xad, 0x70, 0x3c, 0xf6, 0x79, 0x64, 0x95, 0xee, 0x18, 0x5b, 0x55, 0x37, 0x8d, 0x22, 0xef, 0xc0,
+	0x3a, 0x82, 0x9d, 0x4b, 0x4e, 0x83, 0x01, 0x1d, 0x74, 0xb9, 0xc7, 0x23, 0xcb, 0x14, 0x7c, 0x79,
+	0x02, 0xca, 0x3b, 0x7a, 0xf4, 0x2b, 0xda, 0xe7, 0x92, 0xaf, 0x2c, 0xe5, 0xa5, 0x50, 0xce, 0x3e,
+	0xd4, 0x13, 0x25, 0xa3, 0x49, 0x18, 0x44, 0x94, 0x58, 0xb0, 0xd8, 0x9d, 0xf6, 0xfb, 0x34, 0x8a,
+	0x84, 0xa2, 0x55, 0x57, 0x83, 0xb1, 0xfe, 0xa5, 0x62, 0xfd, 0x9d, 0x3f, 0x96, 0xa0, 0xbe, 0xef,
+	0x47, 0x1c, 0x81, 0xe8, 0x79, 0x8d, 0x7e, 0x15, 0x6a, 0x2e, 0xed, 0x4f, 0x59, 0xe4, 0x5f, 0x50,
+	0x65, 0x72, 0x82, 0x40, 0x6a, 0x33, 0xe8, 0xd3, 0x88, 0x87, 0x4c, 0x1b, 0x9a, 0x20, 0x88, 0x03,
+	0xcb, 0x68, 0xf5, 0x17, 0x94, 0x45, 0x7e, 0x18, 0x44, 0xd6, 0xa2, 0x60, 0xc8, 0xe0, 0xe6, 0x9d,
+	0x5a, 0xcd, 0x3b, 0xb5, 0x01, 0x0b, 0xfb, 0xfe, 0xd8, 0xe7, 0xc2, 0x41, 0xa6, 0x2b, 0x01, 0x72,
+	0x13, 0x2a, 0x47, 0x67, 0x67, 0x11, 0xe5, 0xd6, 0x82, 0x40, 0x2b, 0x88, 0x6c, 0x03, 0xec, 0xfa,
+	0x23, 0x4e, 0x59, 0x6f, 0x36, 0xa1, 0x56, 0xe5, 0x8e, 0xb1, 0xb5, 0xba, 0xb3, 0x9a, 0x58, 0x85,
+	0x58, 0x37, 0xc5, 0xe1, 0xdc, 0x85, 0xf5, 0x94, 0x4f, 0x94, 0x8f, 0x9f, 0xe1, 0x14, 0xe7, 0x6b,
+	0x03, 0xac, 0x53, 0xe6, 0x4d, 0x26, 0x7e, 0x30, 0xec, 0x72, 0x46, 0xbd, 0x31, 0x65, 0xf1, 0xc7,
+	0xf7, 0x0b, 0x24, 0x2a, 0x49, 0xb7, 0xa4, 0xa4, 0x1c, 0xf9, 0xc1, 0x0d, 0xb7, 0x40, 0x8b, 0x26,
+	0xac, 0x21, 0xa2, 0x75, 0xee, 0x05, 0x43, 0xda, 0xb9, 0xa0, 0x01, 0x57, 0xa1, 0xdd, 0x4c, 0x14,
+	0x4a, 0x11, 0x1f, 0xdc, 0x70, 0xe7, 0xf9, 0xd1, 0x77, 0x1d, 0xc6, 0x42, 0x26, 0x62, 0x53, 0x73,
+	0x25, 0x70, 0xaf, 0x02, 0xe5, 0xb6, 0xc7, 0x3d, 0xe7, 0x2f, 0x06, 0xac, 0xb7, 0x18, 0xf5, 0x38,
+	0xbd, 0x4e, 0x1a, 0xbc, 0x09, 0xab, 0x27, 0x93, 0x81, 0xc7, 0xe9, 0xde, 0x59, 0xe7, 0xd2, 0x8f,
+	0xe2, 0x4c, 0x98, 0xc3, 0x62, 0x32, 0xec, 0x05, 0x03, 0x7a, 0xe9, 0x71, 0x3f, 0x0c, 0xba, 0x34,
+	0xc2, 0x78, 0x2b, 0x3d, 0xf2, 0x04, 0x8c, 0x67, 0xd7, 0x1f, 0xd1, 0x40, 0x86, 0xb9, 0xea, 0x2a,
+	0xc8, 0x39, 0x04, 0x92, 0x56, 0xf1, 0xa5, 0x93, 0xe0, 0x4f, 0x06, 0xac, 0x4b, 0x45, 0xe7, 0x6c,
+	0xde, 0x65, 0xe1, 0xb8, 0xc8, 0x66, 0xc4, 0x13, 0x1b, 0x4a, 0xbd, 0xb0, 0x40, 0x66, 0xa9, 0x17,
+	0x7e, 0x7b, 0x76, 0xa6, 0xd5, 0x7a, 0x69, 0x3b, 0x67, 0xb0, 0xde, 0xa6, 0x23, 0x7a, 0xbd, 0xd0,
+	0x16, 0x9a, 0x52, 0x7a, 0xb6, 0x29, 0x66, 0xc6, 0x94, 0x6d, 0x20, 0xe9, 0xa9, 0x9f, 0x65, 0x8a,
+	0xf3, 0x5f, 0xa3, 0x60, 0x5a, 0x42, 0xa0, 0x7c, 0x32, 0xf5, 0x07, 0x82, 0xb9, 0xe6, 0x8a, 0x31,
+	0x16, 0x8b, 0x36, 0x8d, 0xfa, 0xcc, 0x9f, 0xf0, 0x44, 0xb3, 0x34, 0x8a, 0xbc, 0x09, 0x55, 0x37,
+	0x0c, 0x45, 0x22, 0x59, 0x66, 0xce, 0xca, 0x98, 0x46, 0x3e, 0x84, 0x5b, 0x9d, 0xcb, 0x09, 0xed,
+	0x73, 0x3a, 0x38, 0x9a, 0x50, 0x26, 0x66, 0x8e, 0x5a, 0xe1, 0x34, 0xd0, 0x65, 0xe6, 0x2a, 0x32,
+	0xf9, 0x21, 0x6c, 0xb6, 0xa6, 0x8c, 0xd1, 0x80, 0xc7, 0x14, 0xf9, 0x9d, 0xac, 0x43, 0xc5, 0xc4,
+	0x94, 0xaf, 0x2a, 0x19, 0x5f, 0x3d, 0x81, 0x8d, 0xc4, 0xf4, 0xf8, 0x1b, 0x34, 0x54, 0xf9, 0x21,
+	0xe5, 0x83, 0x34, 0xea, 0x39, 0x5c, 0x71, 0x13, 0x2a, 0xad, 0x29, 0x8b, 0x54, 0xf2, 0x9b, 0xae,
+	0x82, 0x9c, 0xfb, 0x40, 0x8e, 0x26, 0x54, 0xfb, 0x59, 0x2f, 0x8d, 0xf7, 0x60, 0x51, 0x07, 0x3c,
+	0x53, 0xab, 0x72, 0x81, 0x71, 0x35, 0x9f, 0xf3, 0x00, 0x36, 0x32, 0x82, 0x54, 0xa0, 0x5f, 0x4c,
+	0xd2, 0xee, 0x68, 0x1a, 0x9d, 0xbf, 0xbc, 0x4e, 0x7b, 0xd0, 0xc8, 0x4a, 0x7a, 0x29, 0xa5, 0x5a,
+	0xa3, 0x30, 0xa2, 0xdf, 0x8a, 0x52, 0x59, 0x49, 0x2f, 0xae, 0xd4, 0x0e, 0xd4, 0x4f, 0x3d, 0xde,
+	0x3f, 0xbf, 0x46, 0x56, 0xe3, 0x16, 0x97, 0xfa, 0xe6, 0x39, 0xb7, 0x38, 0x0e, 0x2b, 0x5d, 0xea,
+	0xb1, 0xfe, 0xb9, 0x9e, 0xe5, 0xbb, 0xb0, 0xf0, 0xd3, 0x29, 0x65, 0x33, 0xf5, 0xc5, 0x92, 0xfc,
+	0x42, 0xa0, 0x5c, 0x49, 0xc1, 0x94, 0xed, 0xfa, 0xbf, 0x96, 0x35, 0x69, 0xc1, 0x15, 0x63, 0xc4,
+	0x89, 0xca, 0x6a, 0x4a, 0x1c, 0x8e, 0xb1, 0x14, 0xb4, 0x29, 0xf7, 0xfc, 0x91, 0x6e, 0x7a, 0x34,
+	0xe8, 0xfc, 0xc3, 0x80, 0x25, 0x39, 0xed, 0xae, 0xd7, 0xa7, 0x1c, 0xfb, 0x8b, 0x5d, 0x9f, 0x8e,
+	0x06, 0x87, 0xde, 0x98, 0xaa, 0x2c, 0x48, 0x10, 0xa2, 0x33, 0xf0, 0x1e, 0xd1, 0x91, 0x5a, 0xfd,
+	0x12, 0x40, 0xac, 0x4c, 0x48, 0x39, 0xa5, 0x04, 0x50, 0x8f, 0x1e, 0x65, 0x63, 0x31, 0x61, 0xcd,
+	0x15, 0x63, 0x52, 0x07, 0xf3, 0xc0, 0x0f, 0x54, 0xe2, 0xe2, 0x50, 0x60, 0xbc, 0x4b, 0xab, 0xa2,
+	0x30, 0xde, 0x25, 0x4a, 0xeb, 0x72, 0x8f, 0x71, 0xd1, 0xbc, 0x2c, 0xb8, 0x12, 0x40, 0xbe, 0x4e,
+	0x30, 0x10, 0xdd, 0xca, 0x82, 0x8b, 0x43, 0xe7, 0x67, 0xb0, 0xaa, 0xfd, 0xf5, 0x7c, 0x1e, 0x26,
+	0xdf, 0x87, 0x05, 0x61, 0xa4, 0x2a, 0xe1, 0xeb, 0x92, 0x21, 0x65, 0xbd, 0x2b, 0xe9, 0xce, 0x13,
+	0x68, 0xc8, 0x2d, 0x50, 0x35, 0x4d, 0xcf, 0x5b, 0xcd, 0x3f, 0x82, 0xe5, 0x1e, 0xf3, 0x87, 0x43,
+	0xca, 0x9e, 0xdd, 0x3c, 0xb8, 0x19, 0x56, 0xe7, 0x1e, 0x6c, 0xce, 0x4d, 0xa9, 0x8c, 0x7a, 0x0b,
+	0x16, 0x15, 0x4a, 0x4d, 0xbb, 0x26, 0xc5, 0x49, 0x51, 0xfb, 0xe1, 0xd0, 0xd5, 0x74, 0xe7, 0x7d,
+	0xd8, 0xc0, 0x9e, 0x46, 0x81, 0xcf, 0xdb, 0x70, 0x3a, 0x4d, 0x68, 0x64, 0x3f, 0xbb, 0xfe, 0xcc,
+	0x2e, 0x90, 0x07, 0xd4, 0x1b, 0x5c, 0xd3, 0x5d, 0xaf, 0x42, 0x4d, 0x7d, 0xb1, 0x37, 0x50, 0x2b,
+	0x2a, 0x41, 0x38, 0x9f, 0xc1, 0x46, 0x46, 0xe6, 0xf5, 0xb5, 0xfa, 0x25, 0x6c, 0x74, 0x79, 0xc8,
+	0xae, 0x1b, 0xc5, 0xd4, 0x0c, 0xa5, 0x67, 0xcc, 0x30, 0x84, 0x46, 0x76, 0x86, 0x67, 0x76, 0x11,
+	0xef, 0xc3, 0xca, 0x31, 0x9b, 0x06, 0x34, 0x6e, 0xd1, 0x4b, 0x77, 0xcc, 0xa2, 0x29, 0xb2, 0x5c,
+	0xce, 0x08, 0x1a, 0x19, 0x84, 0xb6, 0xe5, 0x6d, 0x80, 0x93, 0xc0, 0x7f, 0x32, 0xa5, 0x57, 0x58,
+	0x94, 0xa2, 0x92, 0x2d, 0x58, 0x6b, 0x8e, 0x46, 0xb2, 0x51, 0x10, 0x27, 0x1c, 0xdd, 0x47, 0xce,
+	0xa3, 0x9d, 0x26, 0x6c, 0xce, 0xcd, 0xa6, 0xec, 0xda, 0x82, 0x35, 0xc5, 0x18, 0xeb, 0x6f, 0xdc,
+	0x31, 0xb7, 0x6a, 0xee, 0x3c, 0xda, 0xf9, 0xda, 0x84, 0xba, 0x02, 0xfc, 0x60, 0x78, 0x1c, 0x8e,
+	0xfc, 0xfe, 0xac, 0xb0, 0xc3, 0x20, 0x50, 0x16, 0xb5, 0x46, 0xc6, 0x5f, 0x8c, 0xe7, 0xb7, 0x5a,
+	0x33, 0xbf, 0xd5, 0xfe, 0x08, 0x6e, 0xea, 0xa9, 0xb0, 0xb1, 0xee, 0x86, 0x53, 0xd6, 0xa7, 0x42,
+	0x8e, 0x2c, 0x37, 0x57, 0x50, 0xc9, 0xc7, 0x60, 0xe5, 0x29, 0xf7, 0xa6, 0xfd, 0xc7, 0xea, 0x58,
+	0x53, 0x73, 0xaf, 0xa4, 0xe3, 0xe1, 0xea, 0xc0, 0xbb, 0xec, 0x85, 0xdc, 0x1b, 0x89, 0xa2, 0x2b,
+	0x6b, 0x56, 0x06, 0x87, 0xad, 0xfa, 0x81, 0x77, 0x89, 0xc3, 0x63, 0xca, 0x76, 0xfd, 0x11, 0x15,
+	0x55, 0xcc, 0x74, 0xe7, 0xb0, 0xa8, 0xff, 0xde, 0x30, 0x08, 0x19, 0x45, 0x28, 0xba, 0x2f, 0x32,
+	0x9f, 0xf5, 0xce, 0xbd, 0x40, 0x54, 0x38, 0xd3, 0xbd, 0x82, 0x4a, 0x3e, 0x85, 0xa5, 0x87, 0x94,
+	0x4e, 0x8e, 0x29, 0xf3, 0xc3, 0x41, 0x64, 0xd5, 0xc4, 0xe2, 0xb1, 0x65, 0xc0, 0x13, 0x77, 0x27,
+	0x2c, 0x6e, 0x9a, 0x9d, 0xbc, 0x0b, 0x0b, 0xee, 0x74, 0x44, 0x23, 0x0b, 0x8a, 0xbf, 0x93, 0x61,
+	0x42, 0x16, 0x57, 0x32, 0x3a, 0x3f, 0x87, 0x46, 0x91, 0x58, 0xf2, 0x06, 0xac, 0xec, 0x05, 0x9c,
+	0xb2, 0x0b, 0x6f, 0x24, 0x8b, 0xb5, 0x0c, 0x69, 0x16, 0x89, 0x09, 0x7e, 0xe0, 0x5d, 0x1e, 0x4e,
+	0xc7, 0x8f, 0x28, 0x53, 0x9b, 0x43, 0x82, 0x70, 0xfe, 0x5c, 0x82, 0x46, 0xd1, 0xdc, 0xf1, 0x92,
+	0x30, 0x52, 0x4b, 0xc2, 0x81, 0xe5, 0x63, 0x8f, 0x9f, 0x1f, 0x33, 0x7a, 0xe6, 0x5f, 0x52, 0x99,
+	0x36, 0x35, 0x37, 0x83, 0x23, 0xaf, 0x03, 0x88, 0xf3, 0xbe, 0x5c, 0x98, 0xa6, 0xe0, 0x48, 0x61,
+	0x84, 0x3a, 0xfe, 0x58, 0x9c, 0x48, 0x71, 0x1f, 0x44, 0x72, 0x82, 0xc0, 0x9c, 0x3d, 0xf0, 0x03,
+	0x11, 0x59, 0xb9, 0x3f, 0x69, 0x50, 0x50, 0xbc, 0xcb, 0x54, 0xcc, 0x35, 0x88, 0x1d, 0x9f, 0x0c,
+	0x94, 0x3a, 0x69, 0x2b, 0x68, 0x3e, 0x4c, 0xd5, 0x6b, 0x85, 0xc9, 0x79, 0x6a, 0xca, 0x0a, 0x75,
+	0x55, 0xbe, 0xa0, 0xd1, 0x3a, 0x5f, 0x70, 0x4c, 0x1c, 0x28, 0x8b, 0xc3, 0xb7, 0x59, 0x78, 0xf8,
+	0x16, 0xb4, 0xb8, 0x55, 0x90, 0xcd, 0xb6, 0x18, 0xe3, 0x56, 0x7b, 0xd0, 0xf3, 0xc7, 0xda, 0x60,
+	0x09, 0x20, 0xe7, 0x41, 0x38, 0x90, 0xb6, 0x2e, 0xb8, 0x62, 0x8c, 0xb8, 0x0e, 0xf7, 0x86, 0xc2,
+	0xcc, 0x9a, 0x2b, 0xc6, 0x58, 0x27, 0xf5, 0x25, 0x42, 0xad, 0xb8, 0x88, 0x69, 0x3a, 0xf9, 0x00,
+	0x6a, 0x07, 0x94, 0x7b, 0xa2, 0x56, 0x2a, 0x6f, 0xbc, 0x92, 0x68, 0xb9, 0x1d, 0xd3, 0x3a, 0x01,
+	0x67, 0x33, 0x37, 0xe1, 0x25, 0x1f, 0x41, 0xad, 0x39, 0x99, 0x50, 0x8f, 0x45, 0x7b, 0x81, 0x5a,
+	0xb5, 0xb7, 0xe5, 0x87, 0xa7, 0x21, 0x7b, 0x1c, 0x4d, 0xbc, 0x3e, 0x75, 0xe9, 0xc8, 0xe3, 0xfe,
+	0x05, 0x45, 0x4f, 0xb8, 0x09, 0xb7, 0xfd, 0x29, 0xac, 0x66, 0xe5, 0x62, 0x0f, 0xf1, 0x98, 0xce,
+	0x94, 0x37, 0x71, 0x88, 0x0e, 0xb8, 0xf0, 0x46, 0x53, 0x5d, 0x7d, 0x24, 0xf0, 0x71, 0xe9, 0x43,
+	0xc3, 0xf9, 0x83, 0x01, 0x9b, 0x85, 0x53, 0x60, 0xcc, 0x4f, 0xa3, 0x54, 0x58, 0x14, 0x84, 0xab,
+	0xe4, 0x34, 0x4a, 0x77, 0x47, 0x1a, 0x8c, 0x43, 0x66, 0xa6, 0x42, 0x26, 0xa4, 0x74, 0x47, 0xd3,
+	0xa1, 0x2a, 0x58, 0x0a, 0x92, 0x52, 0xba, 0xfd, 0x70, 0x42, 0x55, 0x3d, 0xd2, 0xa0, 0xf3, 0x2f,
+	0x03, 0x6a, 0xb1, 0x6b, 0x5f, 0xf0, 0xb0, 0x16, 0x07, 0xdc, 0x9c, 0x0b, 0x78, 0x6e, 0x69, 0x10,
+	0x79, 0x63, 0x21, 0x94, 0x58, 0x76, 0xc5, 0x18, 0xf3, 0xe7, 0xe8, 0xab, 0x80, 0x32, 0x31, 0x71,
+	0x45, 0xee, 0xd7, 0x31, 0x82, 0xfc, 0x00, 0x16, 0x64, 0xd7, 0xb3, 0xf8, 0x4d, 0x5d, 0x8f, 0xe4,
+	0x71, 0x9e, 0x96, 0x55, 0x73, 0x8b, 0x2a, 0xa1, 0x43, 0x22, 0x6b, 0x45, 0x24, 0xa4, 0x04, 0x30,
+	0x95, 0x93, 0xd4, 0x56, 0x7b, 0x4c, 0x0a, 0x93, 0x4e, 0xd6, 0xd2, 0x95, 0xc9, 0x6a, 0x66, 0x93,
+	0x55, 0x7e, 0xd3, 0xf6, 0xb8, 0xb6, 0x54, 0x83, 0xea, 0x1b, 0x41, 0x59, 0x88, 0xbf, 0x11, 0x14,
+	0x07, 0x96, 0xdb, 0x53, 0x79, 0x44, 0x14, 0xe4, 0xba, 0xb0, 0x3a, 0x83, 0x8b, 0xb3, 0xaf, 0xf2,
+	0x0d, 0xd9, 0x67, 0x43, 0x15, 0x6b, 0xb9, 0x28, 0x6b, 0x32, 0x87, 0x62, 0x18, 0x67, 0x6f, 0x85,
+	0x01, 0x47, 0xd7, 0x55, 0x65, 0xc8, 0x15, 0x88, 0xb7, 0x03, 0x9a, 0xeb, 0x88, 0x69, 0x9e, 0x75,
+	0x79, 0x3b, 0x90, 0x23, 0xa0, 0xcf, 0x76, 0x19, 0xa5, 0x5d, 0xce, 0xfc, 0x60, 0x68, 0xd5, 0x04,
+	0x5b, 0x0a, 0x83, 0xe1, 0x8b, 0x8b, 0xa1, 0x05, 0x32, 0x7c, 0x31, 0x82, 0xbc, 0x0d, 0xd5, 0xfb,
+	0x34, 0x94, 0x07, 0x8e, 0x25, 0x11, 0x41, 0x65, 0x89, 0xc6, 0xba, 0x31, 0x1d, 0x25, 0x61, 0x2c,
+	0xda, 0x74, 0xc2, 0xcf, 0xad, 0x65, 0x59, 0xd7, 0x63, 0x04, 0x46, 0xf4, 0xe4, 0x64, 0xaf, 0x1d,
+	0x59, 0x6b, 0x32, 0xa2, 0x02, 0xc0, 0xe4, 0x3b, 0x0c, 0xb9, 0xb5, 0x2a, 0xea, 0x24, 0x0e, 0x9d,
+	0xbf, 0x1b, 0xc9, 0x94, 0xe4, 0x4d, 0xa8, 0xb4, 0x28, 0x6e, 0x1e, 0x96, 0x31, 0x37, 0xf9, 0x71,
+	0xe8, 0x07, 0xdc, 0x55, 0x54, 0x74, 0x64, 0xdb, 0x8f, 0xb8, 0x17, 0xf4, 0x75, 0xd2, 0xc6, 0x30,
+	0xd9, 0x82, 0xc5, 0x5e, 0x38, 0xd9, 0xa7, 0x67, 0xdc, 0x32, 0x0b, 0x85, 0x68, 0x32, 0x79, 0x17,
+	0x96, 0xee, 0x85, 0x9c, 0x87, 0x63, 0xd7, 0x1f, 0x9e, 0xcb, 0x0b, 0x88, 0x3c, 0x77, 0x9a, 0xc5,
+	0xd9, 0x86, 0xaa, 0x26, 0xa0, 0x29, 0xfb, 0x9e, 0xdc, 0xf2, 0x0c, 0x17, 0x87, 0x02, 0xa3, 0x32,
+	0x0e, 0x31, 0xe2, 0xd8, 0xd8, 0x90, 0xf7, 0x94, 0x72, 0xf1, 0xc7, 0x0d, 0x9b, 0x2d, 0xaf, 0x4b,
+	0x44, 0x3d, 0x90, 0xb9, 0x1b, 0xc3, 0xce, 0x3f, 0xcd, 0xdc, 0xfd, 0x23, 0xb9, 0xab, 0x16, 0x97,
+	0x21, 0x16, 0xd7, 0x77, 0x0a, 0x93, 0x6a, 0x5b, 0xfc, 0xa6, 0x56, 0x9b, 0x03, 0x15, 0xd9, 0xb9,
+	0x14, 0x5c, 0x56, 0x29, 0x0a, 0xf2, 0xf4, 0x3c, 0x36, 0xa4, 0xbc, 0xe0, 0xd6, 0x46, 0x51, 0xc8,
+	0x4f, 0xa0, 0x8a, 0x25, 0x74, 0x80, 0x85, 0xa0, 0x22, 0x8a, 0xef, 0xf7, 0x8a, 0x15, 0xd0, 0x5c,
+	0xb2, 0x7e, 0xc7, 0x1f, 0x5d, 0x75, 0xf7, 0x86, 0x4b, 0xf5, 0x68, 0xc2, 0xfd, 0xb1, 0x1f, 0x71,
+	0xbf, 0x2f, 0x72, 0xae, 0xea, 0xa6, 0x30, 0xf6, 0x27, 0xb0, 0x92, 0x11, 0x79, 0xad, 0xd2, 0x3d,
+	0x83, 0x5a, 0xec, 0x10, 0x02, 0x50, 0x69, 0xb9, 0x9d, 0x66, 0xaf, 0x53, 0xbf, 0x41, 0xaa, 0x50,
+	0x76, 0x3b, 0xcd, 0x76, 0xdd, 0x20, 0x6b, 0xb0, 0x74, 0x72, 0xdc, 0x6e, 0xf6, 0x3a, 0x5f, 0x1e,
+	0x37, 0x7b, 0x0f, 0xea, 0x25, 0x42, 0x60, 0x55, 0x21, 0x5a, 0x47, 0x87, 0xbd, 0xce, 0x61, 0xaf,
+	0x6e, 0xa6, 0x98, 0x0e, 0x3a, 0xbd, 0x66, 0xbd, 0x4c, 0x1a, 0x50, 0x57, 0x88, 0x93, 0x6e, 0xc7,
+	0x95, 0xd8, 0x0a, 0xce, 0xd0, 0xee, 0xec, 0x77, 0x7a, 0x9d, 0xfa, 0x82, 0xf3, 0x37, 0x03, 0x40,
+	0xdc, 0x25, 0xc8, 0xe0, 0xbd, 0x01, 0x2b, 0xe2, 0xfe, 0xb7, 0x4d, 0xb9, 0xb8, 0xd9, 0x52, 0x87,
+	0x81, 0x2c, 0x12, 0x7b, 0xc6, 0xb9, 0x1e, 0x56, 0x9a, 0x34, 0x87, 0x15, 0xf9, 0x8b, 0x1f, 0xa6,
+	0xf6, 0x92, 0x04, 0x81, 0xb5, 0x42, 0x5d, 0x59, 0xec, 0x86, 0xac, 0x4f, 0xc5, 0xf5, 0x87, 0xda,
+	0x5b, 0xf2, 0x04, 0xe7, 0xa9, 0x01, 0xb7, 0xee, 0x53, 0xde, 0x09, 0xfa, 0x6c, 0x26, 0xb6, 0x86,
+	0x87, 0x74, 0xa6, 0x97, 0x28, 0x6e, 0x2d, 0x11, 0x65, 0xf1, 0xd6, 0x12, 0xc9, 0xb4, 0x3b, 0xf6,
+	0xa2, 0xe8, 0xab, 0x90, 0xe9, 0x93, 0x5a, 0x0c, 0xc7, 0xe7, 0x29, 0xf3, 0x8a, 0xf3, 0x14, 0x5e,
+	0x8b, 0x89, 0x16, 0x56, 0x05, 0x5a, 0x41, 0xce, 0x3b, 0x60, 0xe5, 0x55, 0x50, 0x07, 0x8d, 0x3a,
+	0x98, 0x0f, 0x55, 0xbc, 0x97, 0x5d, 0x1c, 0x3a, 0xbf, 0x2d, 0x01, 0x74, 0x67, 0x41, 0x5f, 0x2e,
+	0x3b, 0x64, 0x88, 0xe8, 0x13, 0xc1, 0x50, 0x76, 0x71, 0x48, 0x6e, 0x41, 0x25, 0x08, 0x07, 0x34,
+	0x3e, 0x4a, 0x2e, 0x22, 0xf4, 0xa5, 0x3f, 0x20, 0x6f, 0x41, 0x99, 0x27, 0xdd, 0x91, 0xda, 0x97,
+	0x12, 0x51, 0xdb, 0x32, 0x71, 0x90, 0x05, 0x55, 0x8d, 0x64, 0xe2, 0xa8, 0x5d, 0x59, 0x42, 0x88,
+	0xe7, 0x32, 0x59, 0xe4, 0xa6, 0xac, 0x20, 0xb2, 0x05, 0xe5, 0x40, 0xb7, 0x4a, 0x4b, 0x3b, 0x8d,
+	0x79, 0xd1, 0xd2, 0x09, 0xc8, 0xe1, 0xdc, 0x93, 0x79, 0x4c, 0x96, 0x60, 0x71, 0x1a, 0x3c, 0x0e,
+	0xc2, 0xaf, 0x82, 0xfa, 0x0d, 0x5c, 0x3a, 0x7d, 0xe1, 0x8b, 0xba, 0x81, 0xe3, 0x81, 0x38, 0x43,
+	0xd5, 0x4b, 0xb8, 0x50, 0x27, 0x1e, 0x3f, 0xaf, 0x9b, 0xc8, 0xde, 0x97, 0xe5, 0xbd, 0x5e, 0xc6,
+	0xd5, 0xb5, 0x9a, 0x15, 0x8e, 0x71, 0x79, 0x34, 0xe3, 0x34, 0xc2, 0xed, 0xce, 0x10, 0x5b, 0x57,
+	0x0c, 0xa3, 0x8b, 0xc6, 0x83, 0xf7, 0x95, 0x37, 0x70, 0x88, 0x39, 0x33, 0xe6, 0xa9, 0xed, 0x5f,
+	0x00, 0xe4, 0x36, 0x54, 0x51, 0x45, 0xb1, 0xac, 0xa4, 0xd9, 0x35, 0xe1, 0x3a, 0x54, 0x81, 0xdc,
+	0x85, 0x06, 0xa3, 0x93, 0x30, 0xf2, 0x79, 0xc8, 0x66, 0x7b, 0x03, 0x1a, 0x70, 0xff, 0xcc, 0xa7,
+	0x4c, 0xf9, 0x61, 0x33, 0xa1, 0x7d, 0xe9, 0xc7, 0x44, 0xa7, 0x05, 0x9b, 0xc7, 0x53, 0x9e, 0xa8,
+	0x9a, 0x3e, 0x17, 0x47, 0xd9, 0x73, 0xb1, 0x02, 0x85, 0xb2, 0xd1, 0x30, 0x56, 0x36, 0x1a, 0x3a,
+	0xbf, 0x81, 0x5b, 0xf2, 0x6a, 0x26, 0x2d, 0x47, 0xae, 0xd0, 0x7c, 0xf0, 0x2d, 0x58, 0x3c, 0x1b,
+	0x79, 0x9c, 0xd3, 0x40, 0x9d, 0x69, 0x35, 0x88, 0xa1, 0x9b, 0xc8, 0x2e, 0x42, 0xa6, 0x8c, 0x82,
+	0xb0, 0x59, 0x1a, 0x79, 0x11, 0xef, 0xd2, 0x27, 0x47, 0xc1, 0x68, 0xa6, 0xdf, 0x02, 0x53, 0xa8,
+	0xb7, 0xdf, 0x83, 0xaa, 0xde, 0xc5, 0x31, 0x0e, 0x27, 0x87, 0x0f, 0x0f, 0x8f, 0x4e, 0x0f, 0x65,
+	0x1d, 0xd9, 0xef, 0x34, 0x77, 0xeb, 0x06, 0x59, 0x05, 0x68, 0x1d, 0xed, 0xef, 0x77, 0x5a, 0xbd,
+	0xbd, 0xa3, 0xc3, 0x7a, 0x69, 0xe7, 0xf7, 0x06, 0x2c, 0xe3, 0x37, 0xc7, 0x2c, 0xbc, 0xf0, 0x07,
+	0x94, 0x91, 0x4f, 0xa0, 0xaa, 0xdf, 0x13, 0x89, 0x5a, 0x79, 0x73, 0x8f, 0xa0, 0xf6, 0xcd, 0x79,
+	0xb4, 0xf4, 0x95, 0x73, 0x83, 0x7c, 0x06, 0xb5, 0xf8, 0x8d, 0x8a, 0xdc, 0xcc, 0xbd, 0x64, 0xc9,
+	0xcf, 0xaf, 0x7a, 0xe1, 0x72, 0x6e, 0xbc, 0x6b, 0xec, 0xfc, 0x02, 0x1a, 0x69, 0x75, 0xf4, 0xcb,
+	0x19, 0xe9, 0xc0, 0xaa, 0x9e, 0x4f, 0xe2, 0xae, 0xad, 0xdc, 0x96, 0x21, 0xc4, 0x6f, 0x24, 0x3b,
+	0x41, 0x14, 0x4b, 0xdf, 0x85, 0x95, 0xcc, 0xde, 0x47, 0xd4, 0xc9, 0xa7, 0x68, 0x43, 0xb4, 0x8b,
+	0xfb, 0x44, 0xa1, 0xfd, 0xbf, 0x95, 0x37, 0x5d, 0xda, 0xa7, 0xfe, 0x05, 0x65, 0xa4, 0x09, 0x90,
+	0x3c, 0x4d, 0x11, 0x65, 0x79, 0xee, 0x3d, 0xcd, 0xb6, 0xf2, 0x84, 0xd8, 0xa7, 0x4d, 0x80, 0xe4,
+	0xd5, 0x47, 0x8b, 0xc8, 0x3d, 0x4f, 0xd9, 0x56, 0x9e, 0x90, 0x16, 0x91, 0xbc, 0xb6, 0x68, 0x11,
+	0xb9, 0xa7, 0x1f, 0xdb, 0xca, 0x13, 0xb4, 0x88, 0x9d, 0xff, 0x19, 0x40, 0xd2, 0x96, 0xa9, 0x20,
+	0x3c, 0x84, 0x7a, 0xa2, 0xb4, 0xc2, 0xbd, 0x88, 0x95, 0x18, 0x1c, 0x14, 0x96, 0xa8, 0x9f, 0x15,
+	0x76, 0x2d, 0x7b, 0xb5, 0xb0, 0xc4, 0x90, 0xac, 0xb0, 0x6b, 0x59, 0x2e, 0x96, 0xcd, 0x7f, 0xb0,
+	0x8e, 0xc9, 0x2d, 0x49, 0x6c, 0x96, 0x94, 0x91, 0x36, 0x2c, 0xa5, 0x5e, 0x36, 0x88, 0x92, 0x90,
+	0x7f, 0x35, 0xb1, 0x5f, 0x29, 0xa0, 0xc4, 0x91, 0xb9, 0x0f, 0xcb, 0xe9, 0xb7, 0x08, 0xa2, 0x98,
+	0x0b, 0x5e, 0x3a, 0x6c, 0xbb, 0x88, 0x94, 0x16, 0x94, 0x7e, 0x3f, 0xd0, 0x82, 0x0a, 0x5e, 0x27,
+	0x6c, 0xbb, 0x88, 0x14, 0x07, 0xfa, 0x0b, 0x19, 0x67, 0xb1, 0xa6, 0xa3, 0xb8, 0x2a, 0x7c, 0x06,
+	0xb5, 0xf8, 0x7d, 0x40, 0x27, 0xf6, 0xfc, 0x23, 0x83, 0x7d, 0x2b, 0x87, 0x4f, 0x25, 0x76, 0x0b,
+	0xaa, 0xb2, 0x38, 0x52, 0x46, 0x3e, 0x80, 0x8a, 0x1c, 0x93, 0x8d, 0xf4, 0x8d, 0xb6, 0x96, 0xd3,
+	0xc8, 0x22, 0x53, 0x42, 0x36, 0x60, 0x5d, 0xa4, 0x9d, 0xdc, 0x60, 0x30, 0xc7, 0x29, 0x9b, 0x43,
+	0x9e, 0x32, 0x9f, 0x53, 0xb6, 0xf3, 0xd4, 0x84, 0x15, 0xc4, 0xaa, 0xbb, 0x0b, 0xca, 0xc8, 0xe7,
+	0xb0, 0x92, 0xb9, 0xaf, 0xd6, 0x39, 0x5e, 0x74, 0x6f, 0x6e, 0xdf, 0x2e, 0xa4, 0xa5, 0xbd, 0x9d,
+	0xbe, 0x45, 0xd5, 0xde, 0x2e, 0xb8, 0xbb, 0xb5, 0xed, 0x22, 0x52, 0x2c, 0x68, 0x0f, 0x96, 0xd3,
+	0x37, 0xd9, 0x5a, 0x50, 0xc1, 0xa5, 0xb8, 0x6d, 0x17, 0x91, 0x12, 0xdf, 0xe0, 0x82, 0x4c, 0xdd,
+	0x3e, 0xeb, 0x05, 0x99, 0xbf, 0xe4, 0xb6, 0x5f, 0x29, 0xa0, 0xc4, 0x0a, 0x7d, 0x3e, 0x77, 0xdb,
+	0xab, 0xbd, 0x54, 0x74, 0x97, 0x6b, 0xdf, 0x2e, 0xa4, 0xc5, 0x4b, 0x89, 0xc2, 0x2a, 0x9e, 0xf9,
+	0x1e, 0xd2, 0xd9, 0x81, 0x17, 0x78, 0x43, 0xca, 0x48, 0x17, 0xea, 0xf3, 0x0d, 0x14, 0x79, 0x4d,
+	0x1f, 0x62, 0x0a, 0x7b, 0x3b, 0xfb, 0xf5, 0xab, 0xc8, 0xf1, 0x34, 0xbf, 0xc3, 0x07, 0xa1, 0x78,
+	0xc7, 0x8d, 0xc8, 0x87, 0x60, 0x1e, 0x4f, 0x39, 0xa9, 0xcf, 0xf7, 0x36, 0xb1, 0xba, 0x45, 0x1b,
+	0x3d, 0x26, 0x3a, 0xf9, 0x71, 0xbc, 0x2e, 0x5f, 0x4b, 0x2f, 0xc1, 0xdc, 0x76, 0x6e, 0xe7, 0x64,
+	0x63, 0x04, 0x1e, 0x55, 0xc4, 0xbf, 0x87, 0xee, 0xfe, 0x7f, 0x00, 0x43, 0xa7, 0x9b, 0x2b, 0x4b,
+	0x24, 0x00, 0x00,
 }
@@ -304,11 +304,28 @@ func (this *VersioningPolicy) Validate() error {
 			}
 		}
 	}
+	for _, item := range this.Rules {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Rules", err)
+			}
+		}
+	}
 	return nil
 }
 func (this *VersioningKeepPeriod) Validate() error {
 	return nil
 }
+func (this *VersioningPolicyRule) Validate() error {
+	for _, item := range this.KeepPeriods {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("KeepPeriods", err)
+			}
+		}
+	}
+	return nil
+}
 func (this *Node) Validate() error {
 	for _, item := range this.Commits {
 		if item != nil {
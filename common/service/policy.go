@@ -71,6 +71,7 @@ func PolicyHTTPWrapper(h http.Handler) http.Handler {
 		}
 
 		permissions.PolicyContextFromMetadata(policyRequestContext, c)
+		permissions.PolicyContextFromClaims(policyRequestContext, c)
 		if len(policyRequestContext) > 0 {
 			request.Context = policyRequestContext
 		}
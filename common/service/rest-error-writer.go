@@ -46,6 +46,21 @@ func RestError500(req *restful.Request, resp *restful.Response, err error) {
 	resp.WriteHeaderAndEntity(500, e)
 }
 
+// RestError400 logs the error with context and writes an Error 400 on the response.
+func RestError400(req *restful.Request, resp *restful.Response, err error) {
+	log.Logger(req.Request.Context()).Error("Rest Error 400", zap.Error(err))
+	resp.AddHeader("Content-Type", "application/json")
+	e := &rest.Error{
+		Title:  err.Error(),
+		Detail: err.Error(),
+	}
+	if parsed := errors.Parse(err.Error()); parsed.Status != "" && parsed.Detail != "" {
+		e.Title = parsed.Detail
+		e.Detail = parsed.Status + ": " + parsed.Detail
+	}
+	resp.WriteHeaderAndEntity(400, e)
+}
+
 // RestError404 logs the error with context and writes an Error 404 on the response.
 func RestError404(req *restful.Request, resp *restful.Response, err error) {
 	log.Logger(req.Request.Context()).Error("Rest Error 404", zap.Error(err))
@@ -125,6 +140,7 @@ func RestError401(req *restful.Request, resp *restful.Response, err error) {
 func RestErrorDetect(req *restful.Request, resp *restful.Response, err error, defaultCode ...int32) {
 	emitters := map[int32]restErrorEmitter{
 		500: RestError500,
+		400: RestError400,
 		404: RestError404,
 		403: RestError403,
 		401: RestError401,
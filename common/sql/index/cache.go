@@ -708,3 +708,11 @@ func (d *daocache) LostAndFounds() ([]LostAndFound, error) {
 func (d *daocache) FixLostAndFound(lost LostAndFound) error {
 	return d.DAO.FixLostAndFound(lost)
 }
+
+func (d *daocache) BrokenMPaths() ([]*mtree.TreeNode, error) {
+	return d.DAO.BrokenMPaths()
+}
+
+func (d *daocache) RehashNodes(uuids ...string) (int64, error) {
+	return d.DAO.RehashNodes(uuids...)
+}
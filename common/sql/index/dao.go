@@ -67,6 +67,8 @@ type DAO interface {
 	LostAndFounds() ([]LostAndFound, error)
 	FixLostAndFound(lost LostAndFound) error
 	FixRandHash2(excludes ...LostAndFound) (int64, error)
+	BrokenMPaths() ([]*mtree.TreeNode, error)
+	RehashNodes(uuids ...string) (int64, error)
 
 	GetSQLDAO() sql.DAO
 }
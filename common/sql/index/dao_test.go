@@ -925,3 +925,34 @@ func TestUnderscoreIssue(t *testing.T) {
 		So(mp, ShouldBeNil)
 	})
 }
+
+func TestBrokenMPaths(t *testing.T) {
+	Convey("Test finding and fixing inconsistent levels", t, func() {
+		dao := getDAO(ctxNoCache)
+		dao.Path("fsck/file", true, &tree.Node{Type: tree.NodeType_LEAF})
+		dao.Flush(true)
+
+		broken, err := dao.BrokenMPaths()
+		So(err, ShouldBeNil)
+		So(broken, ShouldBeEmpty)
+
+		mpath, _, _ := dao.Path("fsck/file", false)
+		So(mpath, ShouldNotBeNil)
+		node, _ := dao.GetNode(mpath)
+		_, err = dao.GetSQLDAO().DB().Exec("update test_idx_tree set level = 12 where uuid = ?", node.Uuid)
+		So(err, ShouldBeNil)
+
+		broken, err = dao.BrokenMPaths()
+		So(err, ShouldBeNil)
+		So(broken, ShouldHaveLength, 1)
+		So(broken[0].Uuid, ShouldEqual, node.Uuid)
+
+		n, err := dao.RehashNodes(node.Uuid)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 1)
+
+		broken, err = dao.BrokenMPaths()
+		So(err, ShouldBeNil)
+		So(broken, ShouldBeEmpty)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/pydio/cells/common/sql"
+	"github.com/pydio/cells/common/utils/mtree"
 )
 
 func init() {
@@ -63,6 +64,44 @@ func init() {
 		`)
 	}
 
+	queries["findBrokenMPaths"] = func(dao sql.DAO, args ...string) string {
+		cc := dao.Concat("mpath1", "mpath2", "mpath3", "mpath4")
+		where := []string{`level != (LENGTH(` + cc + `) - LENGTH(REPLACE(` + cc + `, '.', '')) + 1)`}
+		if hash := dao.Hash("mpath1", "mpath2", "mpath3", "mpath4"); hash != "" {
+			where = append(where, `hash != `+hash)
+		}
+		if hash2 := dao.HashParent("name", "mpath1", "mpath2", "mpath3", "mpath4"); hash2 != "" {
+			where = append(where, `hash2 != `+hash2)
+		}
+		return `
+				select
+					uuid, level, mpath1, mpath2, mpath3, mpath4, name, leaf, mtime, etag, size, mode
+				from
+					%%PREFIX%%_idx_tree
+				where
+					` + strings.Join(where, " or ")
+	}
+
+	queries["rehashNode"] = func(dao sql.DAO, args ...string) string {
+		cc := dao.Concat("mpath1", "mpath2", "mpath3", "mpath4")
+		// Level is updated first, as it is used to compute hash2
+		sets := []string{`level = (LENGTH(` + cc + `) - LENGTH(REPLACE(` + cc + `, '.', '')) + 1)`}
+		if hash := dao.Hash("mpath1", "mpath2", "mpath3", "mpath4"); hash != "" {
+			sets = append(sets, `hash = `+hash)
+		}
+		if hash2 := dao.HashParent("name", "mpath1", "mpath2", "mpath3", "mpath4"); hash2 != "" {
+			sets = append(sets, `hash2 = `+hash2)
+		}
+		return `
+				update
+					%%PREFIX%%_idx_tree
+				set
+					` + strings.Join(sets, ", ") + `
+				where
+					uuid = ?
+		`
+	}
+
 	queries["fixRandHash2"] = func(dao sql.DAO, args ...string) string {
 		hash2 := dao.HashParent("name", "mpath1", "mpath2", "mpath3", "mpath4")
 		return `
@@ -272,3 +311,49 @@ func (dao *IndexSQL) FixRandHash2(excludes ...LostAndFound) (int64, error) {
 	}
 	return affected, e
 }
+
+// BrokenMPaths finds nodes whose level or hashes are not consistent with their materialized path
+func (dao *IndexSQL) BrokenMPaths() (output []*mtree.TreeNode, err error) {
+	dao.Lock()
+	defer dao.Unlock()
+
+	stmt, er := dao.GetStmt("findBrokenMPaths")
+	if er != nil {
+		return nil, er
+	}
+	rows, er := stmt.Query()
+	if er != nil {
+		return nil, er
+	}
+	defer rows.Close()
+	for rows.Next() {
+		node, e := dao.scanDbRowToTreeNode(rows)
+		if e != nil {
+			return nil, e
+		}
+		output = append(output, node)
+	}
+	return output, rows.Err()
+}
+
+// RehashNodes recomputes level and hashes of the given nodes from their materialized path
+func (dao *IndexSQL) RehashNodes(uuids ...string) (int64, error) {
+	dao.Lock()
+	defer dao.Unlock()
+
+	stmt, e := dao.GetStmt("rehashNode")
+	if e != nil {
+		return 0, e
+	}
+	var affected int64
+	for _, id := range uuids {
+		r, e := stmt.Exec(id)
+		if e != nil {
+			return affected, e
+		}
+		if a, e := r.RowsAffected(); e == nil {
+			affected += a
+		}
+	}
+	return affected, nil
+}
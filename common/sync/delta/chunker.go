@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package delta provides content-defined chunking of files, allowing to transfer only
+// the modified parts of a file between two endpoints.
+package delta
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Params defines the minimum, average and maximum sizes of the chunks
+type Params struct {
+	MinSize int
+	AvgSize int
+	MaxSize int
+}
+
+// DefaultParams are used for chunking files bigger than MinFileSize
+var DefaultParams = Params{
+	MinSize: 256 * 1024,
+	AvgSize: 1024 * 1024,
+	MaxSize: 4 * 1024 * 1024,
+}
+
+// MinFileSize is the size under which delta transfer is not worth it
+const MinFileSize = 4 * 1024 * 1024
+
+// Validate checks that sizes are consistent
+func (p Params) Validate() error {
+	if p.MinSize <= 0 || p.AvgSize <= p.MinSize || p.MaxSize <= p.AvgSize {
+		return fmt.Errorf("invalid chunking parameters, expecting 0 < min < avg < max")
+	}
+	return nil
+}
+
+// gear is a table of random values used by the rolling hash. It must never change,
+// otherwise stored signatures would not match anymore.
+var gear [256]uint64
+
+func init() {
+	// Use a splitmix64 generator with a fixed seed
+	seed := uint64(0x5079646963656c6c)
+	for i := range gear {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		gear[i] = z ^ (z >> 31)
+	}
+}
+
+// Chunk is a piece of content identified by its hash
+type Chunk struct {
+	Offset int64
+	Length int64
+	Hash   string
+}
+
+// Chunker splits a stream into chunks whose boundaries depend on the content: inserting or
+// removing bytes only modifies the chunks around the modification.
+type Chunker struct {
+	r      io.Reader
+	params Params
+	mask   uint64
+
+	buf    []byte
+	start  int
+	end    int
+	offset int64
+	eof    bool
+}
+
+// NewChunker creates a Chunker reading from r
+func NewChunker(r io.Reader, params Params) (*Chunker, error) {
+	if e := params.Validate(); e != nil {
+		return nil, e
+	}
+	b := uint(bits.Len(uint(params.AvgSize-params.MinSize))) - 1
+	return &Chunker{
+		r:      r,
+		params: params,
+		mask:   ((uint64(1) << b) - 1) << (64 - b),
+		buf:    make([]byte, 2*params.MaxSize),
+	}, nil
+}
+
+// Next returns the next chunk and its data, or io.EOF when the stream is fully read.
+// Data is only valid until the next call.
+func (c *Chunker) Next() (Chunk, []byte, error) {
+	if e := c.fill(); e != nil {
+		return Chunk{}, nil, e
+	}
+	if c.start == c.end {
+		return Chunk{}, nil, io.EOF
+	}
+	data := c.buf[c.start:c.end]
+	data = data[:c.cut(data)]
+	h := sha256.Sum256(data)
+	chunk := Chunk{
+		Offset: c.offset,
+		Length: int64(len(data)),
+		Hash:   hex.EncodeToString(h[:]),
+	}
+	c.start += len(data)
+	c.offset += int64(len(data))
+	return chunk, data, nil
+}
+
+// fill makes sure that at least MaxSize bytes are buffered, unless the stream is finished
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= c.params.MaxSize {
+		return nil
+	}
+	copy(c.buf, c.buf[c.start:c.end])
+	c.end -= c.start
+	c.start = 0
+	n, e := io.ReadFull(c.r, c.buf[c.end:])
+	c.end += n
+	if e == io.EOF || e == io.ErrUnexpectedEOF {
+		c.eof = true
+		return nil
+	}
+	return e
+}
+
+// cut finds the next chunk boundary
+func (c *Chunker) cut(data []byte) int {
+	if len(data) <= c.params.MinSize {
+		return len(data)
+	}
+	limit := len(data)
+	if limit > c.params.MaxSize {
+		limit = c.params.MaxSize
+	}
+	var hash uint64
+	for i := c.params.MinSize; i < limit; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&c.mask == 0 {
+			return i + 1
+		}
+	}
+	return limit
+}
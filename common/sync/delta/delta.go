@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package delta
+
+import (
+	"fmt"
+	"io"
+)
+
+// RangeReader reads a range of the new content
+type RangeReader interface {
+	ReadRange(offset, length int64) (io.ReadCloser, error)
+}
+
+// RangeReaderFunc is a function implementing RangeReader
+type RangeReaderFunc func(offset, length int64) (io.ReadCloser, error)
+
+// ReadRange implements RangeReader
+func (f RangeReaderFunc) ReadRange(offset, length int64) (io.ReadCloser, error) {
+	return f(offset, length)
+}
+
+// Block is a contiguous piece of the new content: either reused from the old content, or read from the source
+type Block struct {
+	// Offset of the block in the new content
+	Offset int64
+	Length int64
+	// Reuse is true if the block can be read from the old content, at OldOffset
+	Reuse     bool
+	OldOffset int64
+}
+
+// Delta describes how to rebuild a new content from an old one
+type Delta struct {
+	Size   int64
+	Blocks []Block
+	// Signature of the new content
+	Signature *Signature
+}
+
+// Compute compares the signatures of the old and new contents. Signatures must have been
+// computed with the same parameters.
+func Compute(old, new *Signature) (*Delta, error) {
+	if old.Params != new.Params {
+		return nil, fmt.Errorf("cannot compare signatures computed with different parameters")
+	}
+	known := make(map[string]Chunk, len(old.Chunks))
+	for _, c := range old.Chunks {
+		if _, ok := known[c.Hash]; !ok {
+			known[c.Hash] = c
+		}
+	}
+	d := &Delta{Size: new.Size, Signature: new}
+	for _, c := range new.Chunks {
+		block := Block{Offset: c.Offset, Length: c.Length}
+		if o, ok := known[c.Hash]; ok && o.Length == c.Length {
+			block.Reuse = true
+			block.OldOffset = o.Offset
+		}
+		if l := len(d.Blocks); l > 0 {
+			last := &d.Blocks[l-1]
+			if last.Reuse == block.Reuse && (!block.Reuse || last.OldOffset+last.Length == block.OldOffset) {
+				last.Length += block.Length
+				continue
+			}
+		}
+		d.Blocks = append(d.Blocks, block)
+	}
+	return d, nil
+}
+
+// ReusedSize is the number of bytes that do not need to be transferred
+func (d *Delta) ReusedSize() (s int64) {
+	for _, b := range d.Blocks {
+		if b.Reuse {
+			s += b.Length
+		}
+	}
+	return
+}
+
+// TransferSize is the number of bytes that must be read from the source
+func (d *Delta) TransferSize() int64 {
+	return d.Size - d.ReusedSize()
+}
+
+// Apply writes the new content to w, by reading reused blocks from old and other blocks from source.
+func (d *Delta) Apply(old io.ReaderAt, source RangeReader, w io.Writer) error {
+	for _, b := range d.Blocks {
+		if b.Reuse {
+			if _, e := io.Copy(w, io.NewSectionReader(old, b.OldOffset, b.Length)); e != nil {
+				return e
+			}
+			continue
+		}
+		reader, e := source.ReadRange(b.Offset, b.Length)
+		if e != nil {
+			return e
+		}
+		_, e = io.CopyN(w, reader, b.Length)
+		reader.Close()
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package delta
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testParams = Params{MinSize: 2 * 1024, AvgSize: 8 * 1024, MaxSize: 32 * 1024}
+
+func syntheticData(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+func sourceOf(data []byte) RangeReader {
+	return RangeReaderFunc(func(offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	})
+}
+
+// edit inserts, replaces and removes a few bytes in a copy of data
+func edit(data []byte) []byte {
+	l := len(data)
+	out := append([]byte{}, data[:l/4]...)
+	out = append(out, []byte("some inserted content")...)
+	out = append(out, data[l/4:l/2]...)
+	out = append(out, []byte("replaced")...)
+	out = append(out, data[l/2+8:3*l/4]...)
+	out = append(out, data[3*l/4+100:]...)
+	return out
+}
+
+func TestChunker(t *testing.T) {
+
+	Convey("Test invalid parameters", t, func() {
+		_, e := NewChunker(bytes.NewReader(nil), Params{MinSize: 10, AvgSize: 5, MaxSize: 20})
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test chunks boundaries", t, func() {
+		data := syntheticData(1, 1024*1024)
+		sig, e := ComputeSignature(bytes.NewReader(data), testParams)
+		So(e, ShouldBeNil)
+		So(sig.Size, ShouldEqual, len(data))
+		var offset int64
+		for i, c := range sig.Chunks {
+			So(c.Offset, ShouldEqual, offset)
+			So(c.Length, ShouldBeLessThanOrEqualTo, testParams.MaxSize)
+			if i < len(sig.Chunks)-1 {
+				So(c.Length, ShouldBeGreaterThanOrEqualTo, testParams.MinSize)
+			}
+			offset += c.Length
+		}
+		So(offset, ShouldEqual, len(data))
+
+		sig2, _ := ComputeSignature(bytes.NewReader(data), testParams)
+		So(sig2, ShouldResemble, sig)
+	})
+
+	Convey("Test empty content", t, func() {
+		sig, e := ComputeSignature(bytes.NewReader(nil), testParams)
+		So(e, ShouldBeNil)
+		So(sig.Size, ShouldEqual, 0)
+		So(sig.Chunks, ShouldBeEmpty)
+	})
+
+	Convey("Test signature writer", t, func() {
+		data := syntheticData(2, 512*1024)
+		w := NewSignatureWriter(testParams)
+		_, e := io.Copy(w, bytes.NewReader(data))
+		So(e, ShouldBeNil)
+		So(w.Close(), ShouldBeNil)
+		sig, e := w.Signature()
+		So(e, ShouldBeNil)
+		expected, _ := ComputeSignature(bytes.NewReader(data), testParams)
+		So(sig, ShouldResemble, expected)
+	})
+
+}
+
+func TestDelta(t *testing.T) {
+
+	Convey("Test delta of an edited content", t, func() {
+		old := syntheticData(3, 4*1024*1024)
+		updated := edit(old)
+		oldSig, _ := ComputeSignature(bytes.NewReader(old), testParams)
+		newSig, _ := ComputeSignature(bytes.NewReader(updated), testParams)
+
+		d, e := Compute(oldSig, newSig)
+		So(e, ShouldBeNil)
+		So(d.Size, ShouldEqual, len(updated))
+		So(d.TransferSize(), ShouldBeGreaterThan, 0)
+		// Only the chunks around the three modifications should be transferred
+		So(d.TransferSize(), ShouldBeLessThan, 6*testParams.MaxSize)
+		So(d.ReusedSize()+d.TransferSize(), ShouldEqual, len(updated))
+
+		buf := &bytes.Buffer{}
+		So(d.Apply(bytes.NewReader(old), sourceOf(updated), buf), ShouldBeNil)
+		So(bytes.Equal(buf.Bytes(), updated), ShouldBeTrue)
+	})
+
+	Convey("Test delta of a totally different content", t, func() {
+		old := syntheticData(4, 256*1024)
+		updated := syntheticData(5, 300*1024)
+		oldSig, _ := ComputeSignature(bytes.NewReader(old), testParams)
+		newSig, _ := ComputeSignature(bytes.NewReader(updated), testParams)
+		d, e := Compute(oldSig, newSig)
+		So(e, ShouldBeNil)
+		So(d.Blocks, ShouldHaveLength, 1)
+		So(d.TransferSize(), ShouldEqual, len(updated))
+
+		buf := &bytes.Buffer{}
+		So(d.Apply(bytes.NewReader(old), sourceOf(updated), buf), ShouldBeNil)
+		So(bytes.Equal(buf.Bytes(), updated), ShouldBeTrue)
+	})
+
+	Convey("Test incompatible signatures", t, func() {
+		data := syntheticData(6, 64*1024)
+		s1, _ := ComputeSignature(bytes.NewReader(data), testParams)
+		s2, _ := ComputeSignature(bytes.NewReader(data), DefaultParams)
+		_, e := Compute(s1, s2)
+		So(e, ShouldNotBeNil)
+	})
+
+}
+
+func TestStores(t *testing.T) {
+
+	sig := &Signature{Params: testParams, Size: 10, Chunks: []Chunk{{Offset: 0, Length: 10, Hash: "hash"}}}
+	testStore := func(s SignatureStore) {
+		So(s.Store("/path/file", "etag1", sig), ShouldBeNil)
+		loaded, ok := s.Load("/path/file", "etag1")
+		So(ok, ShouldBeTrue)
+		So(loaded, ShouldResemble, sig)
+		_, ok = s.Load("/path/file", "etag2")
+		So(ok, ShouldBeFalse)
+		So(s.Delete("/path/file"), ShouldBeNil)
+		_, ok = s.Load("/path/file", "etag1")
+		So(ok, ShouldBeFalse)
+	}
+
+	Convey("Test memory store", t, func() {
+		testStore(NewMemoryStore())
+	})
+
+	Convey("Test bolt store", t, func() {
+		dir, _ := ioutil.TempDir("", "delta")
+		s, e := NewBoltStore(filepath.Join(dir, "signatures"))
+		So(e, ShouldBeNil)
+		defer s.Close()
+		testStore(s)
+	})
+
+}
+
+const benchmarkSize = 64 * 1024 * 1024
+
+func BenchmarkSignature(b *testing.B) {
+	data := syntheticData(7, benchmarkSize)
+	b.SetBytes(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, e := ComputeSignature(bytes.NewReader(data), DefaultParams); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
+
+func BenchmarkDeltaSmallEdit(b *testing.B) {
+	old := syntheticData(8, benchmarkSize)
+	updated := edit(old)
+	oldSig, _ := ComputeSignature(bytes.NewReader(old), DefaultParams)
+	b.SetBytes(int64(len(updated)))
+	b.ResetTimer()
+	var d *Delta
+	for i := 0; i < b.N; i++ {
+		newSig, e := ComputeSignature(bytes.NewReader(updated), DefaultParams)
+		if e != nil {
+			b.Fatal(e)
+		}
+		if d, e = Compute(oldSig, newSig); e != nil {
+			b.Fatal(e)
+		}
+		if e = d.Apply(bytes.NewReader(old), sourceOf(updated), ioutil.Discard); e != nil {
+			b.Fatal(e)
+		}
+	}
+	b.ReportMetric(float64(d.TransferSize()), "transferred-bytes")
+	b.ReportMetric(100*float64(d.TransferSize())/float64(d.Size), "transferred-%")
+}
+
+func BenchmarkFullTransfer(b *testing.B) {
+	updated := edit(syntheticData(8, benchmarkSize))
+	b.SetBytes(int64(len(updated)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if e := (&Delta{Size: int64(len(updated)), Blocks: []Block{{Length: int64(len(updated))}}}).Apply(nil, sourceOf(updated), ioutil.Discard); e != nil {
+			b.Fatal(e)
+		}
+	}
+	b.ReportMetric(float64(len(updated)), "transferred-bytes")
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package delta
+
+import (
+	"io"
+)
+
+// Signature lists the chunks of a content
+type Signature struct {
+	Params Params
+	Size   int64
+	Chunks []Chunk
+}
+
+// ComputeSignature reads r until EOF and computes its chunks
+func ComputeSignature(r io.Reader, params Params) (*Signature, error) {
+	chunker, e := NewChunker(r, params)
+	if e != nil {
+		return nil, e
+	}
+	sig := &Signature{Params: params}
+	for {
+		chunk, _, e := chunker.Next()
+		if e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		sig.Chunks = append(sig.Chunks, chunk)
+		sig.Size += chunk.Length
+	}
+	return sig, nil
+}
+
+// SignatureWriter computes the signature of the data written to it. It can be used
+// to compute a signature while transferring a file, with an io.TeeReader.
+type SignatureWriter struct {
+	*io.PipeWriter
+	done chan struct{}
+	sig  *Signature
+	err  error
+}
+
+// NewSignatureWriter creates a SignatureWriter. Close must be called before reading the signature.
+func NewSignatureWriter(params Params) *SignatureWriter {
+	pr, pw := io.Pipe()
+	w := &SignatureWriter{
+		PipeWriter: pw,
+		done:       make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		w.sig, w.err = ComputeSignature(pr, params)
+		pr.CloseWithError(w.err)
+	}()
+	return w
+}
+
+// Close finishes the signature computation
+func (w *SignatureWriter) Close() error {
+	w.PipeWriter.Close()
+	<-w.done
+	return w.err
+}
+
+// Signature returns the computed signature, after Close was called
+func (w *SignatureWriter) Signature() (*Signature, error) {
+	<-w.done
+	return w.sig, w.err
+}
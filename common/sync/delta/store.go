@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package delta
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/etcd-io/bbolt"
+)
+
+// SignatureStore keeps signatures of contents, identified by their path and their ETag.
+// It is used by endpoints that cannot compute signatures locally.
+type SignatureStore interface {
+	// Load finds a signature for the given path, if its ETag did not change
+	Load(path, etag string) (*Signature, bool)
+	// Store registers a signature for a given path and ETag
+	Store(path, etag string, sig *Signature) error
+	// Delete removes the signature of a path
+	Delete(path string) error
+}
+
+type storedSignature struct {
+	Etag      string
+	Signature *Signature
+}
+
+// MemoryStore is an in-memory SignatureStore
+type MemoryStore struct {
+	sync.Mutex
+	sigs map[string]storedSignature
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sigs: make(map[string]storedSignature)}
+}
+
+// Load implements SignatureStore
+func (m *MemoryStore) Load(path, etag string) (*Signature, bool) {
+	m.Lock()
+	defer m.Unlock()
+	if s, ok := m.sigs[path]; ok && s.Etag == etag {
+		return s.Signature, true
+	}
+	return nil, false
+}
+
+// Store implements SignatureStore
+func (m *MemoryStore) Store(path, etag string, sig *Signature) error {
+	m.Lock()
+	defer m.Unlock()
+	m.sigs[path] = storedSignature{Etag: etag, Signature: sig}
+	return nil
+}
+
+// Delete implements SignatureStore
+func (m *MemoryStore) Delete(path string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.sigs, path)
+	return nil
+}
+
+var signaturesBucket = []byte("signatures")
+
+// BoltStore is a SignatureStore persisted in a bolt DB file
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens or creates a BoltStore at the given file path
+func NewBoltStore(filename string) (*BoltStore, error) {
+	options := bbolt.DefaultOptions
+	options.Timeout = 5 * time.Second
+	db, e := bbolt.Open(filename, 0644, options)
+	if e != nil {
+		return nil, e
+	}
+	e = db.Update(func(tx *bbolt.Tx) error {
+		_, e := tx.CreateBucketIfNotExists(signaturesBucket)
+		return e
+	})
+	if e != nil {
+		db.Close()
+		return nil, e
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Load implements SignatureStore
+func (b *BoltStore) Load(path, etag string) (*Signature, bool) {
+	var stored storedSignature
+	var found bool
+	b.db.View(func(tx *bbolt.Tx) error {
+		if data := tx.Bucket(signaturesBucket).Get([]byte(path)); data != nil {
+			found = json.Unmarshal(data, &stored) == nil
+		}
+		return nil
+	})
+	if !found || stored.Etag != etag {
+		return nil, false
+	}
+	return stored.Signature, true
+}
+
+// Store implements SignatureStore
+func (b *BoltStore) Store(path, etag string, sig *Signature) error {
+	data, e := json.Marshal(storedSignature{Etag: etag, Signature: sig})
+	if e != nil {
+		return e
+	}
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(signaturesBucket).Put([]byte(path), data)
+	})
+}
+
+// Delete implements SignatureStore
+func (b *BoltStore) Delete(path string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(signaturesBucket).Delete([]byte(path))
+	})
+}
+
+// Close closes the underlying DB
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
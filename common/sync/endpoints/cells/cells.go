@@ -38,6 +38,7 @@ import (
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/delta"
 	"github.com/pydio/cells/common/sync/endpoints/memory"
 	"github.com/pydio/cells/common/sync/model"
 	context2 "github.com/pydio/cells/common/utils/context"
@@ -82,6 +83,7 @@ type abstract struct {
 	updateSnapshot    model.PathSyncTarget
 	watchCtxCancelled bool
 	globalCtx         context.Context
+	signatureStore    delta.SignatureStore
 }
 
 // SetUpdateSnapshot registers a snapshot to be updated when events are received from server
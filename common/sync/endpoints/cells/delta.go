@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cells
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pydio/cells-sdk-go/transport/oidc"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/delta"
+	"github.com/pydio/cells/common/views"
+)
+
+// rangeObjectsProvider is implemented by factories whose objects client does not support ranges
+type rangeObjectsProvider interface {
+	GetObjectRange(ctx context.Context, node *tree.Node, offset, length int64) (io.ReadCloser, error)
+}
+
+// SetSignatureStore registers a store for keeping chunks signatures of the files, enabling delta transfers
+func (c *abstract) SetSignatureStore(store delta.SignatureStore) {
+	c.signatureStore = store
+}
+
+// LoadSignature finds the signature of the current file version in the signature store
+func (c *abstract) LoadSignature(ctx context.Context, p string) (*delta.Signature, error) {
+	if c.signatureStore == nil {
+		return nil, fmt.Errorf("no signature store registered")
+	}
+	n, e := c.LoadNode(ctx, p)
+	if e != nil {
+		return nil, e
+	}
+	if sig, ok := c.signatureStore.Load(c.rooted(p), n.Etag); ok {
+		return sig, nil
+	}
+	return nil, fmt.Errorf("no signature found for %s", p)
+}
+
+// StoreSignature registers the signature of the current file version in the signature store
+func (c *abstract) StoreSignature(ctx context.Context, p string, sig *delta.Signature) error {
+	if c.signatureStore == nil {
+		return nil
+	}
+	n, e := c.LoadNode(ctx, p)
+	if e != nil {
+		return e
+	}
+	return c.signatureStore.Store(c.rooted(p), n.Etag, sig)
+}
+
+// GetReaderOnRange retrieves an io.ReadCloser on a range of a file content
+func (c *abstract) GetReaderOnRange(p string, offset, length int64) (io.ReadCloser, error) {
+	n := &tree.Node{Path: c.rooted(p)}
+	if rp, ok := c.factory.(rangeObjectsProvider); ok {
+		return rp.GetObjectRange(c.getContext(), n, offset, length)
+	}
+	ctx, cli, err := c.factory.GetObjectsClient(c.getContext())
+	if err != nil {
+		return nil, err
+	}
+	return cli.GetObject(ctx, n, &views.GetRequestData{StartOffset: offset, Length: length})
+}
+
+// gatewayBucket is the bucket exposed by the S3 gateway of a Cells server
+const gatewayBucket = "data"
+
+// s3CoreProvider is implemented by factories giving a direct access to the S3 gateway of a remote server
+type s3CoreProvider interface {
+	s3Core() (*minio.Core, error)
+}
+
+// s3Core creates a client of the remote S3 gateway authenticated with the current token
+func (f *remoteClientFactory) s3Core() (*minio.Core, error) {
+	conf, e := f.sdkConfig()
+	if e != nil {
+		return nil, e
+	}
+	jwt, e := oidc.RetrieveToken(conf)
+	if e != nil {
+		return nil, e
+	}
+	u, e := url.Parse(conf.Url)
+	if e != nil {
+		return nil, e
+	}
+	core, e := minio.NewCore(u.Host, jwt, "gatewaysecret", u.Scheme == "https")
+	if e != nil {
+		return nil, e
+	}
+	var t http.RoundTripper = http.DefaultTransport
+	if conf.SkipVerify {
+		t = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	if len(conf.CustomHeaders) > 0 {
+		t = &headersRoundTripper{rt: t, headers: conf.CustomHeaders}
+	}
+	core.SetCustomTransport(t)
+	return core, nil
+}
+
+// GetObjectRange sends a ranged GET request to the remote S3 gateway
+func (f *remoteClientFactory) GetObjectRange(ctx context.Context, node *tree.Node, offset, length int64) (io.ReadCloser, error) {
+	core, e := f.s3Core()
+	if e != nil {
+		return nil, e
+	}
+	opts := minio.GetObjectOptions{}
+	end := offset + length - 1
+	if length <= 0 {
+		// Read until the end of the object
+		end = 0
+	}
+	if offset > 0 || end > 0 {
+		if e := opts.SetRange(offset, end); e != nil {
+			return nil, e
+		}
+	}
+	reader, _, e := core.GetObject(gatewayBucket, node.Path, opts)
+	return reader, e
+}
+
+type headersRoundTripper struct {
+	rt      http.RoundTripper
+	headers map[string]string
+}
+
+func (h *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	for k, v := range h.headers {
+		req.Header.Set(k, v)
+	}
+	return h.rt.RoundTrip(req)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cells
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/pydio/cells-sdk-go"
+)
+
+const (
+	clientCredentialsTokenPath = "/oidc/oauth2/token"
+)
+
+// clientCredentials retrieves and renews tokens using the OAuth2 client credentials grant, for servers
+// accessed with a dedicated OAuth client instead of a user account. The base SDK config is never modified:
+// each token is set on a fresh copy of it, that is shared by transports as read-only.
+type clientCredentials struct {
+	sync.Mutex
+	config  *sdk.SdkConfig
+	current *sdk.SdkConfig
+	client  *http.Client
+}
+
+func newClientCredentials(config *sdk.SdkConfig) *clientCredentials {
+	c := &clientCredentials{config: config, client: http.DefaultClient}
+	if config.SkipVerify {
+		c.client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	}
+	return c
+}
+
+// sdkConfig returns a copy of the SDK config carrying a valid token. A new token is fetched if there is
+// none or if the current one is about to expire.
+func (c *clientCredentials) sdkConfig() (*sdk.SdkConfig, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.current != nil && time.Now().Add(time.Minute).Unix() < int64(c.current.TokenExpiresAt) {
+		return c.current, nil
+	}
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	req, e := http.NewRequest("POST", strings.TrimRight(c.config.Url, "/")+clientCredentialsTokenPath, strings.NewReader(data.Encode()))
+	if e != nil {
+		return nil, e
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.config.ClientKey, c.config.ClientSecret)
+	resp, e := c.client.Do(req)
+	if e != nil {
+		return nil, e
+	}
+	defer resp.Body.Close()
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if e := json.NewDecoder(resp.Body).Decode(&token); e != nil {
+		return nil, fmt.Errorf("could not decode token response with status %s: %s", resp.Status, e.Error())
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("could not retrieve token, %s: %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return nil, fmt.Errorf("could not retrieve token, server answered with status %s", resp.Status)
+	}
+	conf := *c.config
+	conf.IdToken = token.AccessToken
+	conf.TokenExpiresAt = int(time.Now().Unix()) + token.ExpiresIn
+	c.current = &conf
+	return c.current, nil
+}
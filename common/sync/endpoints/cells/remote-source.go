@@ -0,0 +1,344 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cells
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/metadata"
+	"github.com/pborman/uuid"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+)
+
+// Keys of the StorageConfiguration of datasources hosted by a remote Cells server
+const (
+	StorageKeyUrl          = "url"
+	StorageKeyRoot         = "root"
+	StorageKeyClientKey    = "clientKey"
+	StorageKeyClientSecret = "clientSecret"
+	StorageKeyToken        = "token"
+	StorageKeySkipVerify   = "skipVerify"
+)
+
+// StorageSecretKeys lists the StorageConfiguration keys whose values are stored in the secrets vault
+var StorageSecretKeys = []string{StorageKeyClientSecret, StorageKeyToken}
+
+func init() {
+	views.RegisterRemoteSourceFactory(object.StorageType_CELLS, func(ds *object.DataSource) (views.RemoteSourceClient, error) {
+		conf, root, e := RemoteConfigFromDataSource(ds)
+		if e != nil {
+			return nil, e
+		}
+		return NewRemoteSource(NewRemote(conf, root, Options{})), nil
+	})
+}
+
+// RemoteConfigFromDataSource builds the configuration of a Remote endpoint from the storage configuration of
+// a datasource of type CELLS. It returns the config and the root path on the remote server.
+func RemoteConfigFromDataSource(ds *object.DataSource) (RemoteConfig, string, error) {
+	storage := ds.StorageConfiguration
+	if storage == nil || storage[StorageKeyUrl] == "" {
+		return RemoteConfig{}, "", fmt.Errorf("missing remote server url for datasource %s", ds.Name)
+	}
+	conf := RemoteConfig{
+		Url:          strings.TrimRight(storage[StorageKeyUrl], "/"),
+		ClientKey:    storage[StorageKeyClientKey],
+		ClientSecret: resolveSecret(storage[StorageKeyClientSecret]),
+		IdToken:      resolveSecret(storage[StorageKeyToken]),
+		SkipVerify:   storage[StorageKeySkipVerify] == "true",
+	}
+	if conf.IdToken == "" && (conf.ClientKey == "" || conf.ClientSecret == "") {
+		return RemoteConfig{}, "", fmt.Errorf("please provide either a personal token or OAuth client credentials for datasource %s", ds.Name)
+	}
+	return conf, strings.Trim(storage[StorageKeyRoot], "/"), nil
+}
+
+// resolveSecret replaces a secret identifier by its value from the vault.
+func resolveSecret(value string) string {
+	if value == "" || uuid.Parse(value) == nil {
+		return value
+	}
+	if sec := config.GetSecret(value).String(); sec != "" {
+		return sec
+	}
+	return value
+}
+
+// RemoteSource exposes a Remote endpoint as a views.RemoteSourceClient, so that browsing and reading
+// a datasource hosted by a remote Cells server are directly proxied to this server.
+type RemoteSource struct {
+	remote *Remote
+}
+
+// NewRemoteSource wraps a Remote endpoint.
+func NewRemoteSource(remote *Remote) *RemoteSource {
+	return &RemoteSource{remote: remote}
+}
+
+// context drops the local metadata (user, session...) before talking to the remote server,
+// which only relies on its own authentication.
+func (s *RemoteSource) context(ctx context.Context) context.Context {
+	return s.remote.getContext(metadata.NewContext(ctx, metadata.Metadata{}))
+}
+
+func (s *RemoteSource) rootedNode(node *tree.Node) *tree.Node {
+	n := node.Clone()
+	n.Path = s.remote.rooted(node.Path)
+	return n
+}
+
+func (s *RemoteSource) unrootedNode(node *tree.Node) *tree.Node {
+	n := node.Clone()
+	n.Path = s.remote.unrooted(node.Path)
+	return n
+}
+
+// ReadNode reads a node on the remote server
+func (s *RemoteSource) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	ctx, cli, e := s.remote.factory.GetNodeProviderClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	req := proto.Clone(in).(*tree.ReadNodeRequest)
+	req.Node = s.rootedNode(in.Node)
+	resp, e := cli.ReadNode(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	resp.Node = s.unrootedNode(resp.Node)
+	return resp, nil
+}
+
+// ListNodes lists nodes on the remote server
+func (s *RemoteSource) ListNodes(ctx context.Context, in *tree.ListNodesRequest, opts ...client.CallOption) (tree.NodeProvider_ListNodesClient, error) {
+	ctx, cli, e := s.remote.factory.GetNodeProviderClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	req := proto.Clone(in).(*tree.ListNodesRequest)
+	req.Node = s.rootedNode(in.Node)
+	stream, e := cli.ListNodes(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	out := views.NewWrappingStreamer()
+	go func() {
+		defer stream.Close()
+		defer out.Close()
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && err != io.ErrUnexpectedEOF {
+					out.SendError(err)
+				}
+				break
+			}
+			if resp == nil || resp.Node == nil || resp.Node.Etag == common.NodeFlagEtagTemporary {
+				continue
+			}
+			resp.Node = s.unrootedNode(resp.Node)
+			out.Send(resp)
+		}
+	}()
+	return out, nil
+}
+
+// CreateNode creates a node on the remote server
+func (s *RemoteSource) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	ctx, cli, e := s.remote.factory.GetNodeReceiverClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	req := proto.Clone(in).(*tree.CreateNodeRequest)
+	req.Node = s.rootedNode(in.Node)
+	resp, e := cli.CreateNode(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	if resp.Node != nil {
+		resp.Node = s.unrootedNode(resp.Node)
+	}
+	return resp, nil
+}
+
+// UpdateNode moves a node on the remote server
+func (s *RemoteSource) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	ctx, cli, e := s.remote.factory.GetNodeReceiverClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	req := proto.Clone(in).(*tree.UpdateNodeRequest)
+	req.From = s.rootedNode(in.From)
+	req.To = s.rootedNode(in.To)
+	resp, e := cli.UpdateNode(ctx, req, append(opts, client.WithRequestTimeout(5*time.Minute))...)
+	if e != nil {
+		return nil, e
+	}
+	if resp.Node != nil {
+		resp.Node = s.unrootedNode(resp.Node)
+	}
+	return resp, nil
+}
+
+// DeleteNode deletes a node on the remote server
+func (s *RemoteSource) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	ctx, cli, e := s.remote.factory.GetNodeReceiverClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	req := proto.Clone(in).(*tree.DeleteNodeRequest)
+	req.Node = s.rootedNode(in.Node)
+	return cli.DeleteNode(ctx, req, append(opts, client.WithRequestTimeout(5*time.Minute))...)
+}
+
+// GetObject reads the content of a node through the remote S3 gateway. Ranges are sent as a ranged GET request.
+func (s *RemoteSource) GetObject(ctx context.Context, node *tree.Node, requestData *views.GetRequestData) (io.ReadCloser, error) {
+	if requestData.StartOffset > 0 || requestData.Length > 0 {
+		if rp, ok := s.remote.factory.(rangeObjectsProvider); ok {
+			return rp.GetObjectRange(s.context(ctx), s.rootedNode(node), requestData.StartOffset, requestData.Length)
+		}
+	}
+	ctx, cli, e := s.remote.factory.GetObjectsClient(s.context(ctx))
+	if e != nil {
+		return nil, e
+	}
+	return cli.GetObject(ctx, s.rootedNode(node), requestData)
+}
+
+// PutObject writes the content of a node through the remote S3 gateway. The local node
+// uuid is not sent, the remote server assigns its own.
+func (s *RemoteSource) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *views.PutRequestData) (int64, error) {
+	ctx, cli, e := s.remote.factory.GetObjectsClient(s.context(ctx))
+	if e != nil {
+		return 0, e
+	}
+	data := *requestData
+	data.Metadata = withoutNodeUuid(requestData.Metadata)
+	return cli.PutObject(ctx, s.rootedNode(node), reader, &data)
+}
+
+// CopyObject copies a node on the remote server
+func (s *RemoteSource) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *views.CopyRequestData) (int64, error) {
+	ctx, cli, e := s.remote.factory.GetObjectsClient(s.context(ctx))
+	if e != nil {
+		return 0, e
+	}
+	return cli.CopyObject(ctx, s.rootedNode(from), s.rootedNode(to), requestData)
+}
+
+// core gives access to the remote S3 gateway, used for multipart uploads
+func (s *RemoteSource) core() (*minio.Core, error) {
+	if p, ok := s.remote.factory.(s3CoreProvider); ok {
+		return p.s3Core()
+	}
+	return nil, fmt.Errorf("multipart uploads are not supported by this endpoint")
+}
+
+// MultipartCreate starts a multipart upload on the remote S3 gateway. As for PutObject, the local node
+// uuid is not sent.
+func (s *RemoteSource) MultipartCreate(ctx context.Context, target *tree.Node, requestData *views.MultipartRequestData) (string, error) {
+	core, e := s.core()
+	if e != nil {
+		return "", e
+	}
+	return core.NewMultipartUpload(gatewayBucket, s.remote.rooted(target.Path), minio.PutObjectOptions{UserMetadata: withoutNodeUuid(requestData.Metadata)})
+}
+
+// MultipartPutObjectPart uploads a part on the remote S3 gateway
+func (s *RemoteSource) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *views.PutRequestData) (minio.ObjectPart, error) {
+	core, e := s.core()
+	if e != nil {
+		return minio.ObjectPart{PartNumber: partNumberMarker}, e
+	}
+	var md5Base64, sha256Hex string
+	if len(requestData.Md5Sum) > 0 {
+		md5Base64 = base64.StdEncoding.EncodeToString(requestData.Md5Sum)
+	}
+	if len(requestData.Sha256Sum) > 0 {
+		sha256Hex = hex.EncodeToString(requestData.Sha256Sum)
+	}
+	return core.PutObjectPart(gatewayBucket, s.remote.rooted(target.Path), uploadID, partNumberMarker, reader, requestData.Size, md5Base64, sha256Hex, nil)
+}
+
+// MultipartComplete completes a multipart upload on the remote S3 gateway and stats the resulting object
+func (s *RemoteSource) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	core, e := s.core()
+	if e != nil {
+		return minio.ObjectInfo{}, e
+	}
+	p := s.remote.rooted(target.Path)
+	if _, e := core.CompleteMultipartUpload(gatewayBucket, p, uploadID, uploadedParts); e != nil {
+		return minio.ObjectInfo{}, e
+	}
+	return core.StatObject(gatewayBucket, p, minio.StatObjectOptions{})
+}
+
+// MultipartList lists the pending multipart uploads on the remote S3 gateway
+func (s *RemoteSource) MultipartList(ctx context.Context, prefix string, requestData *views.MultipartRequestData) (minio.ListMultipartUploadsResult, error) {
+	core, e := s.core()
+	if e != nil {
+		return minio.ListMultipartUploadsResult{}, e
+	}
+	return core.ListMultipartUploads(gatewayBucket, s.remote.rooted(prefix), requestData.ListKeyMarker, requestData.ListUploadIDMarker, requestData.ListDelimiter, requestData.ListMaxUploads)
+}
+
+// MultipartAbort aborts a multipart upload on the remote S3 gateway
+func (s *RemoteSource) MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *views.MultipartRequestData) error {
+	core, e := s.core()
+	if e != nil {
+		return e
+	}
+	return core.AbortMultipartUpload(gatewayBucket, s.remote.rooted(target.Path), uploadID)
+}
+
+// MultipartListObjectParts lists the parts already uploaded on the remote S3 gateway
+func (s *RemoteSource) MultipartListObjectParts(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, maxParts int) (minio.ListObjectPartsResult, error) {
+	core, e := s.core()
+	if e != nil {
+		return minio.ListObjectPartsResult{}, e
+	}
+	return core.ListObjectParts(gatewayBucket, s.remote.rooted(target.Path), uploadID, partNumberMarker, maxParts)
+}
+
+// withoutNodeUuid copies metadata without the node uuid, which is assigned by the remote server
+func withoutNodeUuid(metadata map[string]string) map[string]string {
+	m := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if k != common.XAmzMetaNodeUuid {
+			m[k] = v
+		}
+	}
+	return m
+}
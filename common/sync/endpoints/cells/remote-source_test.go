@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cells
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	sdk "github.com/pydio/cells-sdk-go"
+	"github.com/pydio/minio-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+)
+
+// mockFactory serves all clients from a views.HandlerMock and records the PutObject metadata and GET ranges.
+// S3 requests are sent to the s3 server, if any.
+type mockFactory struct {
+	mock     *views.HandlerMock
+	metadata map[string]string
+	ranges   [][2]int64
+	s3       *httptest.Server
+}
+
+func (f *mockFactory) s3Core() (*minio.Core, error) {
+	if f.s3 == nil {
+		return nil, fmt.Errorf("no s3 server")
+	}
+	u, _ := url.Parse(f.s3.URL)
+	return minio.NewCore(u.Host, "jwt", "gatewaysecret", false)
+}
+
+func (f *mockFactory) GetNodeProviderClient(ctx context.Context) (context.Context, tree.NodeProviderClient, error) {
+	return ctx, f.mock, nil
+}
+
+func (f *mockFactory) GetNodeReceiverClient(ctx context.Context) (context.Context, tree.NodeReceiverClient, error) {
+	return ctx, f.mock, nil
+}
+
+func (f *mockFactory) GetNodeChangesStreamClient(ctx context.Context) (context.Context, tree.NodeChangesStreamerClient, error) {
+	return ctx, nil, nil
+}
+
+func (f *mockFactory) GetObjectsClient(ctx context.Context) (context.Context, objectsClient, error) {
+	return ctx, f, nil
+}
+
+func (f *mockFactory) GetNodeProviderStreamClient(ctx context.Context) (context.Context, tree.NodeProviderStreamerClient, error) {
+	return ctx, nil, nil
+}
+
+func (f *mockFactory) GetNodeReceiverStreamClient(ctx context.Context) (context.Context, tree.NodeReceiverStreamClient, error) {
+	return ctx, nil, nil
+}
+
+func (f *mockFactory) GetObject(ctx context.Context, node *tree.Node, requestData *views.GetRequestData) (io.ReadCloser, error) {
+	return f.mock.GetObject(ctx, node, requestData)
+}
+
+func (f *mockFactory) GetObjectRange(ctx context.Context, node *tree.Node, offset, length int64) (io.ReadCloser, error) {
+	f.ranges = append(f.ranges, [2]int64{offset, length})
+	reader, e := f.mock.GetObject(ctx, node, &views.GetRequestData{})
+	if e != nil {
+		return nil, e
+	}
+	data, _ := ioutil.ReadAll(reader)
+	return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+}
+
+func (f *mockFactory) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *views.PutRequestData) (int64, error) {
+	f.metadata = requestData.Metadata
+	return f.mock.PutObject(ctx, node, reader, requestData)
+}
+
+func (f *mockFactory) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *views.CopyRequestData) (int64, error) {
+	return f.mock.CopyObject(ctx, from, to, requestData)
+}
+
+func TestRemoteConfigFromDataSource(t *testing.T) {
+
+	Convey("Test remote datasource configuration", t, func() {
+
+		ds := &object.DataSource{Name: "remote", StorageType: object.StorageType_CELLS}
+		_, _, e := RemoteConfigFromDataSource(ds)
+		So(e, ShouldNotBeNil)
+
+		ds.StorageConfiguration = map[string]string{StorageKeyUrl: "https://remote.example.com/"}
+		_, _, e = RemoteConfigFromDataSource(ds)
+		So(e, ShouldNotBeNil)
+
+		ds.StorageConfiguration[StorageKeyClientKey] = "client"
+		_, _, e = RemoteConfigFromDataSource(ds)
+		So(e, ShouldNotBeNil)
+
+		ds.StorageConfiguration[StorageKeyClientSecret] = "secret"
+		ds.StorageConfiguration[StorageKeyRoot] = "/personal/admin/"
+		conf, root, e := RemoteConfigFromDataSource(ds)
+		So(e, ShouldBeNil)
+		So(conf.Url, ShouldEqual, "https://remote.example.com")
+		So(conf.ClientSecret, ShouldEqual, "secret")
+		So(root, ShouldEqual, "personal/admin")
+
+		ds.StorageConfiguration = map[string]string{StorageKeyUrl: "https://remote.example.com", StorageKeyToken: "token", StorageKeySkipVerify: "true"}
+		conf, _, e = RemoteConfigFromDataSource(ds)
+		So(e, ShouldBeNil)
+		So(conf.IdToken, ShouldEqual, "token")
+		So(conf.SkipVerify, ShouldBeTrue)
+
+	})
+}
+
+func TestRemoteSource(t *testing.T) {
+
+	Convey("Test requests proxied to a remote server", t, func() {
+
+		mock := views.NewHandlerMock()
+		mock.Nodes["ws/root/folder/file"] = &tree.Node{Path: "ws/root/folder/file", Uuid: "remote-uuid"}
+		factory := &mockFactory{mock: mock}
+		source := NewRemoteSource(&Remote{abstract: abstract{root: "ws/root", factory: factory}})
+		ctx := context.Background()
+
+		Convey("Nodes are rooted on the remote server", func() {
+			resp, e := source.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: "folder/file"}})
+			So(e, ShouldBeNil)
+			So(mock.Nodes["in"].Path, ShouldEqual, "ws/root/folder/file")
+			So(resp.Node.Path, ShouldEqual, "folder/file")
+			So(resp.Node.Uuid, ShouldEqual, "remote-uuid")
+
+			_, e = source.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: &tree.Node{Path: "folder/file"}})
+			So(e, ShouldBeNil)
+			So(mock.Nodes["in"].Path, ShouldEqual, "ws/root/folder/file")
+		})
+
+		Convey("Ranges are sent as ranged requests", func() {
+			reader, e := source.GetObject(ctx, &tree.Node{Path: "folder/file"}, &views.GetRequestData{StartOffset: 8, Length: 4})
+			So(e, ShouldBeNil)
+			data, _ := ioutil.ReadAll(reader)
+			So(string(data), ShouldEqual, "fold")
+			reader.Close()
+			So(factory.ranges, ShouldResemble, [][2]int64{{8, 4}})
+		})
+
+		Convey("Multipart uploads are sent to the S3 gateway", func() {
+			var requests []string
+			var headers http.Header
+			factory.s3 = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := r.URL.Query()["location"]; ok {
+					w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+					return
+				}
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				if _, ok := r.URL.Query()["uploads"]; ok {
+					headers = r.Header
+					w.Write([]byte(`<InitiateMultipartUploadResult><Bucket>data</Bucket><Key>ws/root/folder/big</Key><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`))
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer factory.s3.Close()
+
+			id, e := source.MultipartCreate(ctx, &tree.Node{Path: "folder/big"}, &views.MultipartRequestData{
+				Metadata: map[string]string{common.XAmzMetaNodeUuid: "local-uuid"},
+			})
+			So(e, ShouldBeNil)
+			So(id, ShouldEqual, "upload-id")
+			So(headers.Get(common.XAmzMetaNodeUuid), ShouldBeEmpty)
+			So(source.MultipartAbort(ctx, &tree.Node{Path: "folder/big"}, id, &views.MultipartRequestData{}), ShouldBeNil)
+			So(requests, ShouldResemble, []string{"POST /data/ws/root/folder/big", "DELETE /data/ws/root/folder/big"})
+		})
+
+		Convey("Local uuid is not sent", func() {
+			_, e := source.PutObject(ctx, &tree.Node{Path: "folder/new"}, nil, &views.PutRequestData{
+				Metadata: map[string]string{common.XAmzMetaNodeUuid: "local-uuid", "X-Amz-Meta-Other": "value"},
+			})
+			So(e, ShouldBeNil)
+			So(mock.Nodes["in"].Path, ShouldEqual, "ws/root/folder/new")
+			So(factory.metadata, ShouldNotContainKey, common.XAmzMetaNodeUuid)
+			So(factory.metadata, ShouldContainKey, "X-Amz-Meta-Other")
+		})
+
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+
+	Convey("Test OAuth client credentials grant", t, func() {
+
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			user, pass, ok := r.BasicAuth()
+			if r.URL.Path != clientCredentialsTokenPath || !ok || user != "client" || pass != "secret" || r.FormValue("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": "invalid_client"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-token", "expires_in": 3600})
+		}))
+		defer server.Close()
+
+		Convey("Token is retrieved and reused while valid", func() {
+			conf := &sdk.SdkConfig{Url: server.URL, ClientKey: "client", ClientSecret: "secret"}
+			c := newClientCredentials(conf)
+			withToken, e := c.sdkConfig()
+			So(e, ShouldBeNil)
+			So(withToken.IdToken, ShouldEqual, "access-token")
+			So(int64(withToken.TokenExpiresAt), ShouldBeGreaterThan, time.Now().Unix())
+			So(conf.IdToken, ShouldBeEmpty)
+			again, e := c.sdkConfig()
+			So(e, ShouldBeNil)
+			So(again, ShouldEqual, withToken)
+			So(calls, ShouldEqual, 1)
+		})
+
+		Convey("Wrong credentials are reported", func() {
+			conf := &sdk.SdkConfig{Url: server.URL, ClientKey: "client", ClientSecret: "wrong"}
+			_, e := newClientCredentials(conf).sdkConfig()
+			So(e, ShouldNotBeNil)
+		})
+
+	})
+}
@@ -49,12 +49,12 @@ import (
 type RemoteConfig struct {
 	// Url stores domain name or IP & port to the server.
 	Url string `json:"url"`
-	// OIDC GrantPassword Flow
+	// OIDC GrantPassword Flow. If User is empty, the OAuth2 client credentials grant is used instead.
 	ClientKey    string `json:"clientKey"`
 	ClientSecret string `json:"clientSecret"`
 	User         string `json:"user"`
 	Password     string `json:"password"`
-	// OIDC Code Flow
+	// OIDC Code Flow, or personal token if there is no RefreshToken
 	IdToken      string `json:"id_token"`
 	RefreshToken string `json:"refresh_token"`
 	ExpiresAt    int    `json:"expires_at"`
@@ -75,7 +75,8 @@ type Remote struct {
 // NewRemote creates a new Remote Endpoint
 func NewRemote(config RemoteConfig, root string, options Options) *Remote {
 	useCache := true
-	if config.IdToken != "" {
+	withClientCredentials := config.IdToken == "" && config.User == "" && config.ClientKey != ""
+	if config.IdToken != "" || withClientCredentials {
 		useCache = false
 	}
 	sdkConfig := &sdk.SdkConfig{
@@ -102,10 +103,14 @@ func NewRemote(config RemoteConfig, root string, options Options) *Remote {
 		},
 		config: sdkConfig,
 	}
-	c.factory = &remoteClientFactory{
+	factory := &remoteClientFactory{
 		config:   sdkConfig,
 		registry: NewDynamicRegistry(sdkConfig),
 	}
+	if withClientCredentials {
+		factory.credentials = newClientCredentials(sdkConfig)
+	}
+	c.factory = factory
 	c.source = c
 	logCtx := context.Background()
 	logCtx = servicecontext.WithServiceName(logCtx, "endpoint.cells.remote")
@@ -136,8 +141,9 @@ func (c *Remote) GetEndpointInfo() model.EndpointInfo {
 
 // remoteClientFactory implements the clientProviderFactory interface
 type remoteClientFactory struct {
-	config   *sdk.SdkConfig
-	registry *DynamicRegistry
+	config      *sdk.SdkConfig
+	registry    *DynamicRegistry
+	credentials *clientCredentials
 }
 
 func (f *remoteClientFactory) GetNodeProviderClient(ctx context.Context) (context.Context, tree.NodeProviderClient, error) {
@@ -180,13 +186,29 @@ func (f *remoteClientFactory) GetNodeProviderStreamClient(ctx context.Context) (
 	return ctx, tree.NewNodeProviderStreamerClient(RemoteCellsServiceName, cli), nil
 }
 
+// sdkConfig returns the config to use for the next request, with a fresh token when using client credentials.
+func (f *remoteClientFactory) sdkConfig() (*sdk.SdkConfig, error) {
+	if f.credentials != nil {
+		return f.credentials.sdkConfig()
+	}
+	return f.config, nil
+}
+
 func (f *remoteClientFactory) GetObjectsClient(ctx context.Context) (context.Context, objectsClient, error) {
-	return ctx, mc.NewS3Client(f.config), nil
+	conf, e := f.sdkConfig()
+	if e != nil {
+		return nil, nil, e
+	}
+	return ctx, mc.NewS3Client(conf), nil
 
 }
 
 func (f *remoteClientFactory) getClient(ctx context.Context) (context.Context, client.Client, error) {
-	jwt, err := oidc.RetrieveToken(f.config)
+	conf, err := f.sdkConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	jwt, err := oidc.RetrieveToken(conf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package filesystem
+
+import (
+	"context"
+	"io"
+
+	"github.com/pydio/cells/common/sync/delta"
+)
+
+// LoadSignature computes the chunks signature of a local file
+func (c *FSClient) LoadSignature(ctx context.Context, path string) (*delta.Signature, error) {
+	file, e := c.FS.Open(c.denormalize(path))
+	if e != nil {
+		return nil, e
+	}
+	defer file.Close()
+	return delta.ComputeSignature(file, delta.DefaultParams)
+}
+
+// GetReaderOnRange provides a ReadCloser for reading a range of a file content
+func (c *FSClient) GetReaderOnRange(path string, offset, length int64) (io.ReadCloser, error) {
+	file, e := c.FS.Open(c.denormalize(path))
+	if e != nil {
+		return nil, e
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{Reader: io.NewSectionReader(file, offset, length), Closer: file}, nil
+}
+
+// ApplyDelta rebuilds a file from its current content and the ranges read from source.
+// The new content is written to a temporary file, like with GetWriterOn.
+func (c *FSClient) ApplyDelta(ctx context.Context, path string, d *delta.Delta, source delta.RangeReader) error {
+	old, e := c.FS.Open(c.denormalize(path))
+	if e != nil {
+		return e
+	}
+	defer old.Close()
+	wCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer, _, _, e := c.GetWriterOn(wCtx, path, d.Size)
+	if e != nil {
+		return e
+	}
+	if e := d.Apply(old, source, writer); e != nil {
+		cancel()
+		writer.Close()
+		return e
+	}
+	return writer.Close()
+}
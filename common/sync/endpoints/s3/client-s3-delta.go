@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/sync/delta"
+)
+
+// minDeltaPartSize is the minimum size of all parts but the last one in a multipart upload
+const minDeltaPartSize = 5 * 1024 * 1024
+
+// deltaPart is a part of the multipart upload used to apply a delta. It is either copied server-side
+// from the current object, or uploaded from a list of segments read from the current object or from the source.
+type deltaPart struct {
+	Copy      bool
+	OldOffset int64
+	Length    int64
+	Segments  []delta.Block
+}
+
+// SetSignatureStore registers a store for keeping chunks signatures of the objects, enabling delta transfers
+func (c *Client) SetSignatureStore(store delta.SignatureStore) {
+	c.signatureStore = store
+}
+
+// LoadSignature finds the signature of the current object version in the signature store
+func (c *Client) LoadSignature(ctx context.Context, path string) (*delta.Signature, error) {
+	if c.signatureStore == nil {
+		return nil, fmt.Errorf("no signature store registered")
+	}
+	if _, e := c.core(); e != nil {
+		return nil, e
+	}
+	key := c.getFullPath(path)
+	info, e := c.Mc.StatObject(c.Bucket, key, minio.StatObjectOptions{})
+	if e != nil {
+		return nil, e
+	}
+	if sig, ok := c.signatureStore.Load(key, strings.Trim(info.ETag, "\"")); ok {
+		return sig, nil
+	}
+	return nil, fmt.Errorf("no signature found for %s", path)
+}
+
+// StoreSignature registers the signature of the current object version in the signature store
+func (c *Client) StoreSignature(ctx context.Context, path string, sig *delta.Signature) error {
+	if c.signatureStore == nil {
+		return nil
+	}
+	key := c.getFullPath(path)
+	info, e := c.Mc.StatObject(c.Bucket, key, minio.StatObjectOptions{})
+	if e != nil {
+		return e
+	}
+	return c.signatureStore.Store(key, strings.Trim(info.ETag, "\""), sig)
+}
+
+// GetReaderOnRange provides a ReadCloser for reading a range of an object
+func (c *Client) GetReaderOnRange(path string, offset, length int64) (io.ReadCloser, error) {
+	return c.getRange(c.getFullPath(path), offset, length)
+}
+
+// ApplyDelta rebuilds an object with a multipart upload: large unmodified ranges are copied server-side,
+// other ranges are uploaded.
+func (c *Client) ApplyDelta(ctx context.Context, path string, d *delta.Delta, source delta.RangeReader) error {
+	core, e := c.core()
+	if e != nil {
+		return e
+	}
+	key := c.getFullPath(path)
+	uploadID, e := core.NewMultipartUploadWithContext(ctx, c.Bucket, key, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if e != nil {
+		return e
+	}
+	var completed []minio.CompletePart
+	for i, part := range planDeltaParts(d.Blocks) {
+		var cp minio.CompletePart
+		if part.Copy {
+			cp, e = core.CopyObjectPartWithContext(ctx, c.Bucket, key, c.Bucket, key, uploadID, i+1, part.OldOffset, part.Length, nil)
+		} else {
+			reader := &segmentsReader{client: c, key: key, source: source, segments: part.Segments}
+			var op minio.ObjectPart
+			op, e = core.PutObjectPartWithContext(ctx, c.Bucket, key, uploadID, i+1, reader, part.Length, "", "", nil)
+			reader.Close()
+			cp = minio.CompletePart{PartNumber: op.PartNumber, ETag: op.ETag}
+		}
+		if e != nil {
+			log.Logger(ctx).Error("Cannot upload part for delta transfer, aborting", zap.String("path", path), zap.Int("part", i+1), zap.Error(e))
+			core.AbortMultipartUploadWithContext(ctx, c.Bucket, key, uploadID)
+			return e
+		}
+		completed = append(completed, cp)
+	}
+	_, e = core.CompleteMultipartUploadWithContext(ctx, c.Bucket, key, uploadID, completed)
+	return e
+}
+
+func (c *Client) core() (*minio.Core, error) {
+	cl, ok := c.Mc.(*minio.Client)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert MockableMinio to minio.Client")
+	}
+	return &minio.Core{Client: cl}, nil
+}
+
+func (c *Client) getRange(key string, offset, length int64) (io.ReadCloser, error) {
+	opts := minio.GetObjectOptions{}
+	if e := opts.SetRange(offset, offset+length-1); e != nil {
+		return nil, e
+	}
+	return c.Mc.GetObject(c.Bucket, key, opts)
+}
+
+// planDeltaParts groups delta blocks into multipart upload parts. Reused blocks big enough are copied, others
+// are merged into uploaded parts, making sure that all parts but the last one respect the minimum part size.
+func planDeltaParts(blocks []delta.Block) (parts []deltaPart) {
+	var pending []delta.Block
+	var pendingSize int64
+	add := func(b delta.Block) {
+		pending = append(pending, b)
+		pendingSize += b.Length
+	}
+	flush := func() {
+		if len(pending) > 0 {
+			parts = append(parts, deltaPart{Length: pendingSize, Segments: pending})
+			pending, pendingSize = nil, 0
+		}
+	}
+	for _, b := range blocks {
+		if !b.Reuse || b.Length < minDeltaPartSize {
+			add(b)
+			if pendingSize >= minPartSize {
+				flush()
+			}
+			continue
+		}
+		if pendingSize > 0 && pendingSize < minDeltaPartSize {
+			// Complete the pending part with the beginning of the reused block
+			missing := minDeltaPartSize - pendingSize
+			add(delta.Block{Offset: b.Offset, Length: missing, Reuse: true, OldOffset: b.OldOffset})
+			b.Offset += missing
+			b.OldOffset += missing
+			b.Length -= missing
+		}
+		flush()
+		if b.Length < minDeltaPartSize {
+			add(b)
+			continue
+		}
+		for b.Length > MaxCopyObjectSize {
+			l := int64(MaxCopyObjectSize - minDeltaPartSize)
+			parts = append(parts, deltaPart{Copy: true, OldOffset: b.OldOffset, Length: l})
+			b.Offset += l
+			b.OldOffset += l
+			b.Length -= l
+		}
+		parts = append(parts, deltaPart{Copy: true, OldOffset: b.OldOffset, Length: b.Length})
+	}
+	flush()
+	return
+}
+
+// segmentsReader sequentially reads segments either from the current object or from the source
+type segmentsReader struct {
+	client   *Client
+	key      string
+	source   delta.RangeReader
+	segments []delta.Block
+	current  io.ReadCloser
+}
+
+func (s *segmentsReader) Read(p []byte) (int, error) {
+	for {
+		if s.current == nil {
+			if len(s.segments) == 0 {
+				return 0, io.EOF
+			}
+			seg := s.segments[0]
+			s.segments = s.segments[1:]
+			var e error
+			if seg.Reuse {
+				s.current, e = s.client.getRange(s.key, seg.OldOffset, seg.Length)
+			} else {
+				s.current, e = s.source.ReadRange(seg.Offset, seg.Length)
+			}
+			if e != nil {
+				return 0, e
+			}
+		}
+		n, e := s.current.Read(p)
+		if e == io.EOF {
+			s.current.Close()
+			s.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, e
+	}
+}
+
+func (s *segmentsReader) Close() error {
+	if s.current != nil {
+		return s.current.Close()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package s3
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/sync/delta"
+)
+
+func TestPlanDeltaParts(t *testing.T) {
+
+	const mb = 1024 * 1024
+
+	Convey("Test small modification in the middle of a big file", t, func() {
+		parts := planDeltaParts([]delta.Block{
+			{Offset: 0, Length: 20 * mb, Reuse: true, OldOffset: 0},
+			{Offset: 20 * mb, Length: 1 * mb},
+			{Offset: 21 * mb, Length: 20 * mb, Reuse: true, OldOffset: 21 * mb},
+		})
+		So(parts, ShouldHaveLength, 3)
+		So(parts[0], ShouldResemble, deltaPart{Copy: true, OldOffset: 0, Length: 20 * mb})
+		So(parts[1].Copy, ShouldBeFalse)
+		So(parts[1].Length, ShouldEqual, minDeltaPartSize)
+		So(parts[1].Segments, ShouldHaveLength, 2)
+		So(parts[1].Segments[1], ShouldResemble, delta.Block{Offset: 21 * mb, Length: 4 * mb, Reuse: true, OldOffset: 21 * mb})
+		So(parts[2], ShouldResemble, deltaPart{Copy: true, OldOffset: 25 * mb, Length: 16 * mb})
+	})
+
+	Convey("Test small reused blocks are merged into uploaded parts", t, func() {
+		parts := planDeltaParts([]delta.Block{
+			{Offset: 0, Length: 2 * mb},
+			{Offset: 2 * mb, Length: 1 * mb, Reuse: true, OldOffset: 10 * mb},
+			{Offset: 3 * mb, Length: 6 * mb, Reuse: true, OldOffset: 0},
+		})
+		So(parts, ShouldHaveLength, 2)
+		So(parts[0].Length, ShouldEqual, minDeltaPartSize)
+		So(parts[1].Copy, ShouldBeFalse)
+		So(parts[1].Length, ShouldEqual, 4*mb)
+		var total int64
+		for _, p := range parts {
+			total += p.Length
+		}
+		So(total, ShouldEqual, 9*mb)
+	})
+
+}
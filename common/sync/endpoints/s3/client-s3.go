@@ -46,6 +46,7 @@ import (
 	servicescommon "github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/delta"
 	"github.com/pydio/cells/common/sync/model"
 )
 
@@ -68,6 +69,8 @@ type Client struct {
 
 	checksumMapper       ChecksumMapper
 	purgeMapperAfterWalk bool
+
+	signatureStore delta.SignatureStore
 }
 
 func NewClient(ctx context.Context, host string, key string, secret string, bucket string, rootPath string, secure bool, options model.EndpointOptions) (*Client, error) {
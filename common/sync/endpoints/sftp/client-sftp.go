@@ -0,0 +1,412 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package sftp provides an endpoint for reading/writing from/to a folder of a remote SFTP server
+package sftp
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	errors2 "github.com/micro/go-micro/errors"
+	"github.com/pborman/uuid"
+	sftpclient "github.com/pkg/sftp"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+const (
+	SyncTmpPrefix = ".tmp.write."
+)
+
+// Discarder swallows the content written to folders hidden files
+type Discarder struct {
+	io.Writer
+}
+
+func (d *Discarder) Close() error {
+	return nil
+}
+
+// WrapperWriter writes to a temporary file that is renamed to its target path on Close
+type WrapperWriter struct {
+	io.WriteCloser
+	tmpPath     string
+	targetPath  string
+	client      *Client
+	cancellable context.Context
+}
+
+func (w *WrapperWriter) Close() error {
+	select {
+	case <-w.cancellable.Done():
+		w.WriteCloser.Close()
+		w.client.sftp.Remove(w.tmpPath)
+		return w.cancellable.Err()
+	default:
+		if err := w.WriteCloser.Close(); err != nil {
+			w.client.sftp.Remove(w.tmpPath)
+			return err
+		}
+		// SFTP v3 rename does not overwrite existing files
+		if _, e := w.client.sftp.Stat(w.targetPath); e == nil {
+			if e := w.client.sftp.Remove(w.targetPath); e != nil {
+				w.client.sftp.Remove(w.tmpPath)
+				return e
+			}
+		}
+		return w.client.sftp.Rename(w.tmpPath, w.targetPath)
+	}
+}
+
+// Client implementation of an endpoint on a remote SFTP server
+// Implements PathSyncTarget, PathSyncSource, DataSyncTarget, DataSyncSource and ChecksumProvider
+// Takes an SSH address and a root folder as main parameters.
+// SFTP does not provide change notifications: Watch does not emit any event, changes are detected
+// by running the sync again.
+type Client struct {
+	RootPath     string
+	sshClient    *ssh.Client
+	sftp         *sftpclient.Client
+	refHashStore model.PathSyncSource
+	options      model.EndpointOptions
+	uri          string
+}
+
+// NewClient connects to the SFTP server listening at addr (host:port) and stats the provided root.
+// It returns an error if the server or the folder is not reachable.
+func NewClient(addr string, config *ssh.ClientConfig, rootPath string, options model.EndpointOptions) (*Client, error) {
+	conn, e := ssh.Dial("tcp", addr, config)
+	if e != nil {
+		return nil, e
+	}
+	cl, e := sftpclient.NewClient(conn)
+	if e != nil {
+		conn.Close()
+		return nil, e
+	}
+	c := &Client{
+		RootPath:  "/" + strings.Trim(rootPath, "/"),
+		sshClient: conn,
+		sftp:      cl,
+		options:   options,
+		uri:       fmt.Sprintf("sftp://%s@%s/%s", config.User, addr, strings.Trim(rootPath, "/")),
+	}
+	if stat, e := c.sftp.Stat(c.RootPath); e != nil || !stat.IsDir() {
+		c.Close()
+		return nil, fmt.Errorf("cannot stat root folder %s", c.RootPath)
+	}
+	return c, nil
+}
+
+// Close closes the underlying SFTP session and SSH connection
+func (c *Client) Close() error {
+	c.sftp.Close()
+	return c.sshClient.Close()
+}
+
+func (c *Client) SetRefHashStore(source model.PathSyncSource) {
+	c.refHashStore = source
+}
+
+func (c *Client) GetEndpointInfo() model.EndpointInfo {
+	return model.EndpointInfo{
+		URI:                   c.uri,
+		RequiresFoldersRescan: true,
+		RequiresNormalization: false,
+	}
+}
+
+// LoadNode stats a node on the server. Files content is read to compute their hash, unless
+// it can be found in the reference hash store.
+func (c *Client) LoadNode(ctx context.Context, path string, extendedStats ...bool) (node *tree.Node, err error) {
+	stat, err := c.sftp.Stat(c.fullPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors2.NotFound("not.found", path, err)
+		}
+		return nil, err
+	}
+	node, err = c.loadNode(ctx, path, stat)
+	if err == nil && node.IsLeaf() && node.Etag == "" {
+		err = c.ComputeChecksum(node)
+	}
+	return node, err
+}
+
+// Walk lists the nodes of the server. Files are sent without hash (unless it can be found in the reference hash store),
+// the sync will call ComputeChecksum when required.
+func (c *Client) Walk(walkFunc model.WalkNodesFunc, root string, recursive bool) (err error) {
+	root = strings.Trim(root, "/")
+	wrappingFunc := func(p string, info os.FileInfo) {
+		if p == "" || p == root || strings.HasPrefix(path.Base(p), SyncTmpPrefix) {
+			return
+		}
+		// Do not follow symbolic links or special files
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return
+		}
+		if node, e := c.loadNode(context.Background(), p, info); e != nil {
+			walkFunc("", nil, e)
+		} else {
+			walkFunc(p, node, nil)
+		}
+	}
+	if !recursive {
+		infos, er := c.sftp.ReadDir(c.fullPath(root))
+		if er != nil {
+			return er
+		}
+		for _, i := range infos {
+			wrappingFunc(path.Join(root, i.Name()), i)
+		}
+		return nil
+	}
+	walker := c.sftp.Walk(c.fullPath(root))
+	for walker.Step() {
+		if e := walker.Err(); e != nil {
+			walkFunc("", nil, e)
+			continue
+		}
+		wrappingFunc(c.relPath(walker.Path()), walker.Stat())
+	}
+	return nil
+}
+
+// Watch does not emit any event, as SFTP does not provide changes notifications.
+func (c *Client) Watch(recursivePath string) (*model.WatchObject, error) {
+	w := &model.WatchObject{
+		EventInfoChan: make(chan model.EventInfo),
+		ErrorChan:     make(chan error),
+		DoneChan:      make(chan bool),
+	}
+	go func() {
+		<-w.DoneChan
+		close(w.EventInfoChan)
+		close(w.ErrorChan)
+	}()
+	return w, nil
+}
+
+// ComputeChecksum reads the file content to compute its md5 hash.
+func (c *Client) ComputeChecksum(node *tree.Node) error {
+	f, err := c.sftp.Open(c.fullPath(node.Path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	node.Etag = fmt.Sprintf("%x", h.Sum(nil))
+	return nil
+}
+
+func (c *Client) CreateNode(ctx context.Context, node *tree.Node, updateIfExists bool) (err error) {
+	if node.IsLeaf() {
+		return fmt.Errorf("this is a DataSyncTarget, use PutNode for leafs instead of CreateNode")
+	}
+	fPath := c.fullPath(node.Path)
+	if _, e := c.sftp.Stat(fPath); !os.IsNotExist(e) {
+		return e
+	}
+	if err = c.mkdirAll(fPath); err != nil {
+		return err
+	}
+	if node.Uuid != "" && !c.options.BrowseOnly {
+		err = c.writeFile(path.Join(fPath, common.PydioSyncHiddenFile), node.Uuid)
+	}
+	return err
+}
+
+func (c *Client) DeleteNode(ctx context.Context, path string) (err error) {
+	return c.removeAll(c.fullPath(path))
+}
+
+// MoveNode renames a file or a folder on the server.
+func (c *Client) MoveNode(ctx context.Context, oldPath string, newPath string) (err error) {
+	oldPath = c.fullPath(oldPath)
+	newPath = c.fullPath(newPath)
+	if _, e := c.sftp.Stat(oldPath); os.IsNotExist(e) {
+		return nil
+	}
+	if e := c.mkdirAll(path.Dir(newPath)); e != nil {
+		return e
+	}
+	return c.sftp.Rename(oldPath, newPath)
+}
+
+func (c *Client) GetWriterOn(cancel context.Context, p string, targetSize int64) (out io.WriteCloser, writeDone chan bool, writeErr chan error, err error) {
+
+	// Ignore .pydio except for root folder .pydio
+	if path.Base(p) == common.PydioSyncHiddenFile && strings.Trim(p, "/") != common.PydioSyncHiddenFile {
+		return &Discarder{Writer: ioutil.Discard}, writeDone, writeErr, nil
+	}
+	fPath := c.fullPath(p)
+	tmpPath := path.Join(path.Dir(fPath), SyncTmpPrefix+path.Base(fPath))
+	file, openErr := c.sftp.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if openErr != nil {
+		return nil, writeDone, writeErr, openErr
+	}
+	return &WrapperWriter{
+		WriteCloser: file,
+		cancellable: cancel,
+		client:      c,
+		tmpPath:     tmpPath,
+		targetPath:  fPath,
+	}, writeDone, writeErr, nil
+
+}
+
+func (c *Client) GetReaderOn(p string) (out io.ReadCloser, err error) {
+	return c.sftp.Open(c.fullPath(p))
+}
+
+func (c *Client) fullPath(p string) string {
+	return path.Join(c.RootPath, strings.Trim(p, "/"))
+}
+
+func (c *Client) relPath(p string) string {
+	return strings.Trim(strings.TrimPrefix(p, c.RootPath), "/")
+}
+
+// loadNode builds a node from an already known os.FileInfo
+func (c *Client) loadNode(ctx context.Context, p string, stat os.FileInfo) (node *tree.Node, err error) {
+	if stat.IsDir() {
+		id, err := c.readOrCreateFolderId(c.fullPath(p))
+		if err != nil {
+			return nil, err
+		}
+		node = &tree.Node{
+			Path: p,
+			Type: tree.NodeType_COLLECTION,
+			Uuid: id,
+		}
+	} else {
+		node = &tree.Node{
+			Path: p,
+			Type: tree.NodeType_LEAF,
+		}
+		if c.refHashStore != nil {
+			refNode, e := c.refHashStore.LoadNode(ctx, p)
+			if e == nil && refNode.Size == stat.Size() && refNode.MTime == stat.ModTime().Unix() && refNode.Etag != "" {
+				node.Etag = refNode.Etag
+			}
+		}
+	}
+	node.MTime = stat.ModTime().Unix()
+	node.Size = stat.Size()
+	node.Mode = int32(stat.Mode())
+	return node, nil
+}
+
+// Expects full path
+func (c *Client) readOrCreateFolderId(p string) (uid string, e error) {
+
+	if c.options.BrowseOnly {
+		return uuid.New(), nil
+	}
+	hiddenFilePath := path.Join(p, common.PydioSyncHiddenFile)
+	f, err := c.sftp.Open(hiddenFilePath)
+	if err != nil && os.IsNotExist(err) {
+		uid = uuid.New()
+		if we := c.writeFile(hiddenFilePath, uid); we != nil {
+			return "", we
+		}
+		return uid, nil
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	content, re := ioutil.ReadAll(f)
+	if re != nil {
+		return "", re
+	}
+	return string(content), nil
+
+}
+
+// Expects full path
+func (c *Client) writeFile(p string, content string) error {
+	f, e := c.sftp.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if e != nil {
+		return e
+	}
+	if _, e := f.Write([]byte(content)); e != nil {
+		f.Close()
+		return e
+	}
+	return f.Close()
+}
+
+// Expects full path
+func (c *Client) mkdirAll(p string) error {
+	if stat, e := c.sftp.Stat(p); e == nil {
+		if !stat.IsDir() {
+			return fmt.Errorf("%s is not a folder", p)
+		}
+		return nil
+	}
+	if parent := path.Dir(p); parent != p {
+		if e := c.mkdirAll(parent); e != nil {
+			return e
+		}
+	}
+	return c.sftp.Mkdir(p)
+}
+
+// Expects full path. SFTP only removes empty folders, children are removed first.
+func (c *Client) removeAll(p string) error {
+	stat, e := c.sftp.Lstat(p)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil
+		}
+		return e
+	}
+	if stat.IsDir() {
+		infos, e := c.sftp.ReadDir(p)
+		if e != nil {
+			return e
+		}
+		for _, i := range infos {
+			if e := c.removeAll(path.Join(p, i.Name())); e != nil {
+				return e
+			}
+		}
+	}
+	if e := c.sftp.Remove(p); e != nil {
+		log.Logger(context.Background()).Error("Cannot remove "+p, zap.Error(e))
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package sftp
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sftpclient "github.com/pkg/sftp"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/filesystem"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/sync/task"
+)
+
+// startTestServer starts an in-process SSH server exposing the local filesystem over SFTP.
+// It returns the server address and its host key.
+func startTestServer(t *testing.T) (string, ssh.PublicKey) {
+	_, private, _ := ed25519.GenerateKey(rand.Reader)
+	signer, e := ssh.NewSignerFromKey(private)
+	if e != nil {
+		t.Fatal(e)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == "partner" && string(pass) == "secret" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+	}
+	config.AddHostKey(signer)
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	go func() {
+		for {
+			conn, e := listener.Accept()
+			if e != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, e := ssh.NewServerConn(conn, config)
+	if e != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, e := newChannel.Accept()
+		if e != nil {
+			continue
+		}
+		go func(in <-chan *ssh.Request) {
+			for req := range in {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+			}
+		}(requests)
+		go func() {
+			if server, e := sftpclient.NewServer(channel); e == nil {
+				server.Serve()
+			}
+			channel.Close()
+		}()
+	}
+}
+
+func testClientConfig(password string, hostKey ssh.PublicKey) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            "partner",
+		Auth:            []ssh.AuthMethod{ssh.Password(password)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         5 * time.Second,
+	}
+}
+
+func md5String(content string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+}
+
+func TestNewClient(t *testing.T) {
+
+	addr, hostKey := startTestServer(t)
+	root, _ := ioutil.TempDir("", "sftp-root")
+	defer os.RemoveAll(root)
+
+	Convey("Test connection to SFTP server", t, func() {
+
+		c, e := NewClient(addr, testClientConfig("secret", hostKey), root, model.EndpointOptions{})
+		So(e, ShouldBeNil)
+		So(c.GetEndpointInfo().URI, ShouldStartWith, "sftp://partner@"+addr)
+		c.Close()
+
+		_, e = NewClient(addr, testClientConfig("wrong", hostKey), root, model.EndpointOptions{})
+		So(e, ShouldNotBeNil)
+
+		_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+		otherSigner, _ := ssh.NewSignerFromKey(otherKey)
+		_, e = NewClient(addr, testClientConfig("secret", otherSigner.PublicKey()), root, model.EndpointOptions{})
+		So(e, ShouldNotBeNil)
+
+		_, e = NewClient(addr, testClientConfig("secret", hostKey), filepath.Join(root, "missing"), model.EndpointOptions{})
+		So(e, ShouldNotBeNil)
+
+	})
+}
+
+func TestClientOperations(t *testing.T) {
+
+	addr, hostKey := startTestServer(t)
+
+	Convey("Test operations on SFTP endpoint", t, func() {
+
+		root, _ := ioutil.TempDir("", "sftp-root")
+		defer os.RemoveAll(root)
+		c, e := NewClient(addr, testClientConfig("secret", hostKey), root, model.EndpointOptions{})
+		So(e, ShouldBeNil)
+		defer c.Close()
+		ctx := context.Background()
+
+		So(c.CreateNode(ctx, &tree.Node{Path: "folder/sub", Uuid: "folder-uuid", Type: tree.NodeType_COLLECTION}, false), ShouldBeNil)
+		id, _ := ioutil.ReadFile(filepath.Join(root, "folder", "sub", common.PydioSyncHiddenFile))
+		So(string(id), ShouldEqual, "folder-uuid")
+
+		w, _, _, e := c.GetWriterOn(ctx, "folder/sub/file.txt", 12)
+		So(e, ShouldBeNil)
+		w.Write([]byte("file content"))
+		So(w.Close(), ShouldBeNil)
+		data, _ := ioutil.ReadFile(filepath.Join(root, "folder", "sub", "file.txt"))
+		So(string(data), ShouldEqual, "file content")
+
+		// Overwrite existing file
+		w, _, _, _ = c.GetWriterOn(ctx, "folder/sub/file.txt", 11)
+		w.Write([]byte("new content"))
+		So(w.Close(), ShouldBeNil)
+
+		node, e := c.LoadNode(ctx, "folder/sub/file.txt")
+		So(e, ShouldBeNil)
+		So(node.IsLeaf(), ShouldBeTrue)
+		So(node.Size, ShouldEqual, 11)
+		So(node.Etag, ShouldEqual, md5String("new content"))
+
+		folder, e := c.LoadNode(ctx, "folder/sub")
+		So(e, ShouldBeNil)
+		So(folder.Uuid, ShouldEqual, "folder-uuid")
+
+		_, e = c.LoadNode(ctx, "missing")
+		So(e, ShouldNotBeNil)
+
+		walked := map[string]*tree.Node{}
+		So(c.Walk(func(p string, n *tree.Node, err error) {
+			walked[p] = n
+		}, "/", true), ShouldBeNil)
+		So(walked, ShouldHaveLength, 5)
+		So(walked, ShouldContainKey, "folder")
+		So(walked, ShouldContainKey, "folder/sub/"+common.PydioSyncHiddenFile)
+		So(walked["folder/sub/file.txt"].Etag, ShouldBeEmpty)
+		So(model.NodeRequiresChecksum(walked["folder/sub/file.txt"]), ShouldBeTrue)
+		So(c.ComputeChecksum(walked["folder/sub/file.txt"]), ShouldBeNil)
+		So(walked["folder/sub/file.txt"].Etag, ShouldEqual, md5String("new content"))
+
+		children := map[string]*tree.Node{}
+		So(c.Walk(func(p string, n *tree.Node, err error) {
+			children[p] = n
+		}, "folder", false), ShouldBeNil)
+		So(children, ShouldHaveLength, 2)
+		So(children, ShouldContainKey, "folder/sub")
+
+		reader, e := c.GetReaderOn("folder/sub/file.txt")
+		So(e, ShouldBeNil)
+		data, _ = ioutil.ReadAll(reader)
+		reader.Close()
+		So(string(data), ShouldEqual, "new content")
+
+		So(c.MoveNode(ctx, "folder/sub", "moved/sub"), ShouldBeNil)
+		_, e = os.Stat(filepath.Join(root, "moved", "sub", "file.txt"))
+		So(e, ShouldBeNil)
+
+		So(c.DeleteNode(ctx, "moved"), ShouldBeNil)
+		_, e = os.Stat(filepath.Join(root, "moved"))
+		So(os.IsNotExist(e), ShouldBeTrue)
+
+	})
+}
+
+func TestMirrorToFolder(t *testing.T) {
+
+	addr, hostKey := startTestServer(t)
+
+	Convey("Test mirroring an SFTP tree with a one-shot sync", t, func() {
+
+		root, _ := ioutil.TempDir("", "sftp-root")
+		defer os.RemoveAll(root)
+		target, _ := ioutil.TempDir("", "sftp-target")
+		defer os.RemoveAll(target)
+
+		os.MkdirAll(filepath.Join(root, "drops", "2020"), 0755)
+		ioutil.WriteFile(filepath.Join(root, "drops", "readme.txt"), []byte("partner readme"), 0644)
+		ioutil.WriteFile(filepath.Join(root, "drops", "2020", "report.csv"), []byte("a;b;c"), 0644)
+
+		source, e := NewClient(addr, testClientConfig("secret", hostKey), root, model.EndpointOptions{BrowseOnly: true})
+		So(e, ShouldBeNil)
+		defer source.Close()
+		fsTarget, e := filesystem.NewFSClient(target, model.EndpointOptions{})
+		So(e, ShouldBeNil)
+
+		s := task.NewSync(source, fsTarget, model.DirectionRight)
+		statuses := make(chan model.Status)
+		done := make(chan interface{})
+		s.SetupEventsChan(statuses, done, nil)
+		s.Start(context.Background(), false)
+		defer s.Shutdown()
+
+		finished := make(chan bool)
+		go func() {
+			for {
+				select {
+				case <-statuses:
+				case <-done:
+					close(finished)
+					return
+				}
+			}
+		}()
+		_, e = s.Run(context.Background(), false, false)
+		So(e, ShouldBeNil)
+		select {
+		case <-finished:
+		case <-time.After(20 * time.Second):
+			t.Fatal("sync did not finish")
+		}
+
+		data, e := ioutil.ReadFile(filepath.Join(target, "drops", "2020", "report.csv"))
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "a;b;c")
+		data, e = ioutil.ReadFile(filepath.Join(target, "drops", "readme.txt"))
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "partner readme")
+
+		// Source is browsed only, no hidden files are written on the partner server
+		_, e = os.Stat(filepath.Join(root, "drops", common.PydioSyncHiddenFile))
+		So(os.IsNotExist(e), ShouldBeTrue)
+
+	})
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package filters
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// StorageFilter merges events detected directly on the storage underlying an endpoint with the
+// events emitted by the endpoint itself. Storage events are retained for a short delay and
+// successive events on a same path are coalesced into the last one. They are dropped if the
+// endpoint reported the same path in the meantime: the change was then performed through the
+// endpoint and is already processed.
+type StorageFilter struct {
+	delay   time.Duration
+	seen    map[string]time.Time
+	pending map[string]*pendingStorageEvent
+}
+
+type pendingStorageEvent struct {
+	event model.EventInfo
+	last  time.Time
+}
+
+// NewStorageFilter creates a new StorageFilter retaining storage events for the given delay
+func NewStorageFilter(delay time.Duration) *StorageFilter {
+	return &StorageFilter{
+		delay:   delay,
+		seen:    make(map[string]time.Time),
+		pending: make(map[string]*pendingStorageEvent),
+	}
+}
+
+// Pipe forwards endpoint events as is, and storage events once they are known not to be echoes.
+// Output is closed when the endpoint input is closed.
+func (f *StorageFilter) Pipe(in chan model.EventInfo, storage chan model.EventInfo) (out chan model.EventInfo) {
+
+	out = make(chan model.EventInfo)
+	tick := f.delay / 4
+	if tick < 10*time.Millisecond {
+		tick = 10 * time.Millisecond
+	}
+
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case event, ok := <-in:
+				if !ok {
+					return
+				}
+				f.seen[f.key(event.Path)] = time.Now()
+				out <- event
+			case event, ok := <-storage:
+				if !ok {
+					// Storage watcher is gone, keep forwarding endpoint events
+					storage = nil
+					continue
+				}
+				k := f.key(event.Path)
+				now := time.Now()
+				if f.isEcho(k, now) {
+					delete(f.pending, k)
+					continue
+				}
+				f.pending[k] = &pendingStorageEvent{event: event, last: now}
+			case now := <-ticker.C:
+				for k, p := range f.pending {
+					if now.Sub(p.last) < f.delay {
+						continue
+					}
+					delete(f.pending, k)
+					if !f.isEcho(k, p.last) {
+						out <- p.event
+					}
+				}
+				for k, t := range f.seen {
+					if now.Sub(t) > 2*f.delay {
+						delete(f.seen, k)
+					}
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// isEcho checks if the endpoint reported an event on this path around the given time
+func (f *StorageFilter) isEcho(key string, t time.Time) bool {
+	s, ok := f.seen[key]
+	return ok && s.After(t.Add(-f.delay))
+}
+
+func (f *StorageFilter) key(p string) string {
+	return strings.Trim(p, model.InternalPathSeparator)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package filters
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/sync/model"
+)
+
+func collectEvents(out chan model.EventInfo, wait time.Duration) (paths []string) {
+	for {
+		select {
+		case e, ok := <-out:
+			if !ok {
+				return
+			}
+			paths = append(paths, e.Path)
+		case <-time.After(wait):
+			return
+		}
+	}
+}
+
+func TestStorageFilter_Pipe(t *testing.T) {
+
+	Convey("Test endpoint events are forwarded immediately", t, func() {
+		in := make(chan model.EventInfo)
+		storage := make(chan model.EventInfo)
+		out := NewStorageFilter(50*time.Millisecond).Pipe(in, storage)
+		go func() {
+			in <- model.EventInfo{Path: "file"}
+		}()
+		So(collectEvents(out, 20*time.Millisecond), ShouldResemble, []string{"file"})
+		close(in)
+		_, ok := <-out
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Test storage events are delayed and coalesced", t, func() {
+		in := make(chan model.EventInfo)
+		storage := make(chan model.EventInfo)
+		out := NewStorageFilter(50*time.Millisecond).Pipe(in, storage)
+		storage <- model.EventInfo{Path: "/folder/file", Type: model.EventCreate}
+		storage <- model.EventInfo{Path: "/folder/file", Type: model.EventRemove}
+		So(collectEvents(out, 10*time.Millisecond), ShouldBeEmpty)
+		var received []model.EventInfo
+		for e := range out {
+			received = append(received, e)
+			close(in)
+		}
+		So(received, ShouldHaveLength, 1)
+		So(received[0].Type, ShouldEqual, model.EventRemove)
+	})
+
+	Convey("Test storage echoes of endpoint events are dropped", t, func() {
+		in := make(chan model.EventInfo)
+		storage := make(chan model.EventInfo)
+		out := NewStorageFilter(50*time.Millisecond).Pipe(in, storage)
+		go func() {
+			storage <- model.EventInfo{Path: "/before"}
+			in <- model.EventInfo{Path: "before"}
+			in <- model.EventInfo{Path: "after"}
+			storage <- model.EventInfo{Path: "/after"}
+			storage <- model.EventInfo{Path: "/external"}
+		}()
+		So(collectEvents(out, 200*time.Millisecond), ShouldResemble, []string{"before", "after", "/external"})
+		close(in)
+	})
+
+	Convey("Test endpoint events still flow when storage is closed", t, func() {
+		in := make(chan model.EventInfo)
+		storage := make(chan model.EventInfo)
+		out := NewStorageFilter(50*time.Millisecond).Pipe(in, storage)
+		close(storage)
+		go func() {
+			in <- model.EventInfo{Path: "file"}
+			close(in)
+		}()
+		So(collectEvents(out, 100*time.Millisecond), ShouldResemble, []string{"file"})
+	})
+
+}
@@ -0,0 +1,388 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package merger
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// ConflictStrategy defines how a BidirectionalPatch reconciles two nodes conflicting at the same path
+type ConflictStrategy int
+
+const (
+	// ConflictStrategyManual keeps conflicts pending, they must be resolved afterward
+	ConflictStrategyManual ConflictStrategy = iota
+	// ConflictStrategyKeepBoth renames the right node with a "conflicted copy" suffix and replicates both nodes on both sides
+	ConflictStrategyKeepBoth
+	// ConflictStrategyNewest keeps the most recently modified node
+	ConflictStrategyNewest
+	// ConflictStrategyLeftWins always keeps the left node
+	ConflictStrategyLeftWins
+	// ConflictStrategyRightWins always keeps the right node
+	ConflictStrategyRightWins
+)
+
+var conflictStrategyNames = map[ConflictStrategy]string{
+	ConflictStrategyManual:    "manual",
+	ConflictStrategyKeepBoth:  "keep-both",
+	ConflictStrategyNewest:    "newest",
+	ConflictStrategyLeftWins:  "left-wins",
+	ConflictStrategyRightWins: "right-wins",
+}
+
+// String gives a string representation of this strategy
+func (s ConflictStrategy) String() string {
+	if n, ok := conflictStrategyNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("strategy-%d", int(s))
+}
+
+// ParseConflictStrategy finds a ConflictStrategy by its name
+func ParseConflictStrategy(name string) (ConflictStrategy, error) {
+	if name == "" {
+		return ConflictStrategyManual, nil
+	}
+	for s, n := range conflictStrategyNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return ConflictStrategyManual, fmt.Errorf("unknown conflict strategy %s", name)
+}
+
+// ConflictResolution tells a BidirectionalPatch which strategy to apply to the conflicts it detects
+type ConflictResolution struct {
+	// Strategy is applied to all conflicts
+	Strategy ConflictStrategy
+	// Paths overrides Strategy for the conflicts detected at specific paths
+	Paths map[string]ConflictStrategy
+}
+
+// StrategyFor finds the strategy to apply at a given path
+func (r *ConflictResolution) StrategyFor(p string) ConflictStrategy {
+	if r == nil {
+		return ConflictStrategyManual
+	}
+	if s, ok := r.Paths[p]; ok {
+		return s
+	}
+	return r.Strategy
+}
+
+// ConflictSolution lists the operations required to reconcile both sides once a conflict is solved
+type ConflictSolution struct {
+	// Prepare operations move or delete the conflicting nodes out of the way, they are processed before
+	// any other operation of the patch
+	Prepare []Operation
+	// ToRight operations replicate nodes from the left to the right
+	ToRight []Operation
+	// ToLeft operations replicate nodes from the right to the left
+	ToLeft []Operation
+}
+
+// ConflictSolver reconciles two nodes currently found at the same path on the left and right endpoints.
+// It must not modify the endpoints, as patches are also computed for dry runs: it returns the operations
+// to be processed for the reconciliation, or false if the conflict cannot be solved and must stay pending.
+type ConflictSolver func(ctx context.Context, p *BidirectionalPatch, left, right *tree.Node) (*ConflictSolution, bool)
+
+var conflictSolvers = map[ConflictStrategy]ConflictSolver{
+	ConflictStrategyKeepBoth:  solveKeepBoth,
+	ConflictStrategyNewest:    solveNewest,
+	ConflictStrategyLeftWins:  solveLeftWins,
+	ConflictStrategyRightWins: solveRightWins,
+}
+
+// RegisterConflictSolver registers a solver for a given strategy, replacing the existing one if any.
+// It is not thread-safe and should be called at init time.
+func RegisterConflictSolver(strategy ConflictStrategy, solver ConflictSolver) {
+	conflictSolvers[strategy] = solver
+}
+
+// PendingConflict describes a conflict that was not solved during the merge
+type PendingConflict struct {
+	Path      string
+	Type      ConflictType
+	NodeLeft  *tree.Node
+	NodeRight *tree.Node
+}
+
+// SetConflictResolution sets the strategies used for solving conflicts
+func (p *BidirectionalPatch) SetConflictResolution(r *ConflictResolution) {
+	p.resolution = r
+}
+
+// ConflictResolution returns the strategies used for solving conflicts, nil by default.
+func (p *BidirectionalPatch) ConflictResolution() *ConflictResolution {
+	return p.resolution
+}
+
+// PendingConflicts lists the conflicts that could not be solved with the current ConflictResolution
+func (p *BidirectionalPatch) PendingConflicts() (conflicts []*PendingConflict) {
+	p.WalkOperations([]OperationType{OpConflict}, func(operation Operation) {
+		co, ok := operation.(ConflictOperation)
+		if !ok {
+			return
+		}
+		t, leftOp, rightOp := co.ConflictInfo()
+		c := &PendingConflict{
+			Path:      operation.GetNode().GetPath(),
+			Type:      t,
+			NodeLeft:  operation.GetNode(),
+			NodeRight: operation.GetNode(),
+		}
+		if leftOp != nil && leftOp.GetNode() != nil {
+			c.NodeLeft = leftOp.GetNode()
+		}
+		if rightOp != nil && rightOp.GetNode() != nil {
+			c.NodeRight = rightOp.GetNode()
+		}
+		conflicts = append(conflicts, c)
+	})
+	return
+}
+
+// solveConflict applies the configured strategy to a conflict detected at a given path. Only conflicts on
+// nodes currently existing on both sides at this path can be solved: others stay pending.
+func (p *BidirectionalPatch) solveConflict(t ConflictType, conflictPath string) (*ConflictSolution, bool) {
+	if t != ConflictFileContent && t != ConflictNodeType && t != ConflictPathOperation {
+		return nil, false
+	}
+	if path.Base(conflictPath) == common.PydioSyncHiddenFile {
+		return nil, false
+	}
+	strategy := p.resolution.StrategyFor(conflictPath)
+	solver, ok := conflictSolvers[strategy]
+	if !ok {
+		return nil, false
+	}
+	targetAsSource, _ := model.AsPathSyncSource(p.Target())
+	left, e1 := p.Source().LoadNode(p.ctx, conflictPath)
+	right, e2 := targetAsSource.LoadNode(p.ctx, conflictPath)
+	if e1 != nil || e2 != nil {
+		log.Logger(p.ctx).Debug("Conflicting node not found on both sides, keeping conflict", zap.String("path", conflictPath))
+		return nil, false
+	}
+	if left.IsLeaf() && right.IsLeaf() && left.Etag != "" && left.Etag == right.Etag {
+		return &ConflictSolution{}, true
+	}
+	solution, solved := solver(p.ctx, p, left, right)
+	if solved {
+		log.Logger(p.ctx).Info("-- Conflict solved", zap.String("path", conflictPath), zap.Stringer("strategy", strategy))
+	}
+	return solution, solved
+}
+
+// applySolutionToInputs queues the solution operations inside the left and right input trees, and
+// flag them as modified so that they are merged again.
+func (p *BidirectionalPatch) applySolutionToInputs(left, right *TreeNode, solution *ConflictSolution) {
+	clearBranchOperations(left)
+	clearBranchOperations(right)
+	for _, op := range solution.ToRight {
+		left.getRoot().QueueOperation(op)
+	}
+	for _, op := range solution.ToLeft {
+		right.getRoot().QueueOperation(op)
+	}
+	p.addConflictOperations(solution.Prepare...)
+	p.inputsModified = true
+}
+
+// applySolution removes all operations at or below the conflicting path and enqueues the solution operations.
+func (p *BidirectionalPatch) applySolution(conflictPath string, solution *ConflictSolution) {
+	p.TreeNode.Walk(func(n *TreeNode) bool {
+		if n.Path == conflictPath || strings.HasPrefix(n.Path, conflictPath+"/") {
+			n.PathOperation = nil
+			n.DataOperation = nil
+			n.Conflict = nil
+			n.OpMoveTarget = nil
+		}
+		return false
+	})
+	for _, op := range solution.ToRight {
+		p.Enqueue(op.SetDirection(OperationDirRight))
+	}
+	for _, op := range solution.ToLeft {
+		p.Enqueue(op.SetDirection(OperationDirLeft))
+	}
+	p.addConflictOperations(solution.Prepare...)
+}
+
+// addConflictOperations attaches the preparation operations of a solution to this patch
+func (p *BidirectionalPatch) addConflictOperations(ops ...Operation) {
+	for _, op := range ops {
+		op.AttachToPatch(p)
+		p.conflictOps = append(p.conflictOps, op)
+	}
+}
+
+// ConflictOperations lists the moves and deletes required by the solved conflicts. They must be processed
+// before all other operations of the patch, to free the conflicting paths.
+func (p *BidirectionalPatch) ConflictOperations() []Operation {
+	return p.conflictOps
+}
+
+// clearBranchOperations removes all operations from a branch
+func clearBranchOperations(branch *TreeNode) {
+	branch.Walk(func(n *TreeNode) bool {
+		n.PathOperation = nil
+		n.DataOperation = nil
+		n.Conflict = nil
+		n.OpMoveTarget = nil
+		return false
+	})
+}
+
+// replicateNode builds the operations copying a node (and its children for a folder) from one side to the other.
+func (p *BidirectionalPatch) replicateNode(ctx context.Context, node *tree.Node, dir OperationDirection, update bool) (ops []Operation) {
+	return p.replicateNodeAs(ctx, node, node.GetPath(), dir, update)
+}
+
+// replicateNodeAs builds the operations copying a node (and its children for a folder) from one side to the other,
+// for a node that is moved to targetPath before the operations are processed.
+func (p *BidirectionalPatch) replicateNodeAs(ctx context.Context, node *tree.Node, targetPath string, dir OperationDirection, update bool) (ops []Operation) {
+	source := p.Source()
+	if dir == OperationDirLeft {
+		source, _ = model.AsPathSyncSource(p.Target())
+	}
+	opType := OpCreateFolder
+	if node.IsLeaf() && update {
+		opType = OpUpdateFile
+	} else if node.IsLeaf() {
+		opType = OpCreateFile
+	}
+	// Operations update their node path, do not share nodes with the endpoints
+	moved := movedClone(node, node.GetPath(), targetPath)
+	ops = append(ops, NewOperation(opType, model.NodeToEventInfo(ctx, targetPath, moved, model.EventCreate), moved.Clone()))
+	if node.IsLeaf() {
+		return
+	}
+	source.Walk(func(childPath string, child *tree.Node, err error) {
+		if err != nil || child == nil || !strings.HasPrefix(child.GetPath(), node.GetPath()+"/") {
+			return
+		}
+		childType := OpCreateFolder
+		if child.IsLeaf() {
+			childType = OpCreateFile
+		}
+		moved := movedClone(child, node.GetPath(), targetPath)
+		ops = append(ops, NewOperation(childType, model.NodeToEventInfo(ctx, moved.GetPath(), moved, model.EventCreate), moved.Clone()))
+	}, node.GetPath(), true)
+	return
+}
+
+// movedClone clones a node and replaces the from prefix of its path by to
+func movedClone(node *tree.Node, from, to string) *tree.Node {
+	c := node.Clone()
+	c.Path = to + strings.TrimPrefix(node.GetPath(), from)
+	return c
+}
+
+// overwrite replaces the loser node by the winner one on the loser side. A loser of a different type is deleted first.
+func (p *BidirectionalPatch) overwrite(ctx context.Context, winner, loser *tree.Node, dir OperationDirection) (*ConflictSolution, bool) {
+	if !winner.IsLeaf() && !loser.IsLeaf() {
+		// Two folders do not conflict, children will be merged
+		return &ConflictSolution{}, true
+	}
+	update := winner.IsLeaf() && loser.IsLeaf()
+	solution := &ConflictSolution{}
+	if !update {
+		del := NewOperation(OpDelete, model.EventInfo{Path: loser.GetPath()}, loser.Clone())
+		solution.Prepare = append(solution.Prepare, del.SetDirection(dir))
+	}
+	ops := p.replicateNode(ctx, winner, dir, update)
+	if dir == OperationDirLeft {
+		solution.ToLeft = ops
+	} else {
+		solution.ToRight = ops
+	}
+	return solution, true
+}
+
+// conflictedCopyPath finds a free path on both sides for renaming a conflicting node.
+func (p *BidirectionalPatch) conflictedCopyPath(ctx context.Context, node *tree.Node, suffix string) string {
+	base, ext := node.GetPath(), ""
+	if node.IsLeaf() {
+		ext = path.Ext(base)
+		base = strings.TrimSuffix(base, ext)
+	}
+	targetAsSource, _ := model.AsPathSyncSource(p.Target())
+	for i := 1; ; i++ {
+		label := suffix + " conflicted copy"
+		if i > 1 {
+			label = fmt.Sprintf("%s %d", label, i)
+		}
+		candidate := fmt.Sprintf("%s (%s)%s", base, label, ext)
+		_, e1 := p.Source().LoadNode(ctx, candidate)
+		_, e2 := targetAsSource.LoadNode(ctx, candidate)
+		if e1 != nil && e2 != nil {
+			return candidate
+		}
+	}
+}
+
+// solveKeepBoth renames the right node to a "conflicted copy" and replicates both nodes on both sides.
+func solveKeepBoth(ctx context.Context, p *BidirectionalPatch, left, right *tree.Node) (*ConflictSolution, bool) {
+	if !left.IsLeaf() && !right.IsLeaf() {
+		return &ConflictSolution{}, true
+	}
+	_, suffix := p.computeAutoFixSuffixes(p.Source().GetEndpointInfo(), p.Target().GetEndpointInfo())
+	copyPath := p.conflictedCopyPath(ctx, right, suffix)
+	moveType := OpMoveFolder
+	if right.IsLeaf() {
+		moveType = OpMoveFile
+	}
+	// Move operations use the node path as origin and the event path as target
+	move := NewOperation(moveType, model.EventInfo{Path: copyPath}, right.Clone())
+	return &ConflictSolution{
+		Prepare: []Operation{move.SetDirection(OperationDirRight)},
+		ToRight: p.replicateNode(ctx, left, OperationDirRight, false),
+		ToLeft:  p.replicateNodeAs(ctx, right, copyPath, OperationDirLeft, false),
+	}, true
+}
+
+// solveNewest keeps the most recently modified node.
+func solveNewest(ctx context.Context, p *BidirectionalPatch, left, right *tree.Node) (*ConflictSolution, bool) {
+	if MostRecentNode(left, right) == left {
+		return p.overwrite(ctx, left, right, OperationDirRight)
+	}
+	return p.overwrite(ctx, right, left, OperationDirLeft)
+}
+
+// solveLeftWins replaces the right node by the left one.
+func solveLeftWins(ctx context.Context, p *BidirectionalPatch, left, right *tree.Node) (*ConflictSolution, bool) {
+	return p.overwrite(ctx, left, right, OperationDirRight)
+}
+
+// solveRightWins replaces the left node by the right one.
+func solveRightWins(ctx context.Context, p *BidirectionalPatch, left, right *tree.Node) (*ConflictSolution, bool) {
+	return p.overwrite(ctx, right, left, OperationDirLeft)
+}
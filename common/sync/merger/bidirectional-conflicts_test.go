@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package merger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/memory"
+)
+
+// conflictingTrees creates a folder with a child on the left and a file on the right at the same path,
+// and the corresponding CreateFolder / CreateFile patches.
+func conflictingTrees(ctx context.Context, leftTime, rightTime time.Time) (source, target *memory.MemDB, left, right *TreePatch) {
+	source, target = memory.NewMemDB(), memory.NewMemDB()
+	source.CreateNode(ctx, &tree.Node{Path: "/conflict", Type: tree.NodeType_COLLECTION, MTime: leftTime.Unix()}, true)
+	source.CreateNode(ctx, &tree.Node{Path: "/conflict/child", Type: tree.NodeType_LEAF, Etag: "child-hash", MTime: leftTime.Unix()}, true)
+	target.CreateNode(ctx, &tree.Node{Path: "/conflict", Type: tree.NodeType_LEAF, Etag: "file-hash", MTime: rightTime.Unix()}, true)
+
+	left = newTreePatch(source, target, PatchOptions{MoveDetection: false})
+	right = newTreePatch(target, source, PatchOptions{MoveDetection: false})
+	left.Enqueue(&patchOperation{OpType: OpCreateFolder, Node: &tree.Node{Path: "/conflict", Type: tree.NodeType_COLLECTION}})
+	left.Enqueue(&patchOperation{OpType: OpCreateFile, Node: &tree.Node{Path: "/conflict/child", Type: tree.NodeType_LEAF}})
+	right.Enqueue(&patchOperation{OpType: OpCreateFile, Node: &tree.Node{Path: "/conflict", Type: tree.NodeType_LEAF}})
+	return
+}
+
+// patchOperationsByDir lists the operations sent to each direction, as Type:RefPath strings.
+func patchOperationsByDir(p Patch) (toRight, toLeft []string) {
+	p.WalkOperations([]OperationType{}, func(operation Operation) {
+		op, ok := operation.(*patchOperation)
+		if !ok {
+			return
+		}
+		if op.Dir == OperationDirLeft {
+			toLeft = append(toLeft, op.Type().String()+":"+op.GetRefPath())
+		} else {
+			toRight = append(toRight, op.Type().String()+":"+op.GetRefPath())
+		}
+	})
+	return
+}
+
+// conflictOperations lists the preparation operations of solved conflicts, as Type:RefPath strings.
+func conflictOperations(p *BidirectionalPatch) (ops []string) {
+	for _, op := range p.ConflictOperations() {
+		ops = append(ops, op.Type().String()+":"+op.GetRefPath())
+	}
+	return
+}
+
+func TestConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	old := time.Now()
+	recent := old.Add(2 * time.Second)
+
+	Convey("Test strategy names", t, func() {
+		s, e := ParseConflictStrategy("keep-both")
+		So(e, ShouldBeNil)
+		So(s, ShouldEqual, ConflictStrategyKeepBoth)
+		s, e = ParseConflictStrategy("")
+		So(e, ShouldBeNil)
+		So(s, ShouldEqual, ConflictStrategyManual)
+		_, e = ParseConflictStrategy("unknown")
+		So(e, ShouldNotBeNil)
+		So(ConflictStrategyRightWins.String(), ShouldEqual, "right-wins")
+	})
+
+	Convey("Test manual strategy keeps conflict pending", t, func() {
+		_, _, left, right := conflictingTrees(ctx, old, recent)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right)
+		So(e, ShouldNotBeNil)
+		pending := bi.PendingConflicts()
+		So(pending, ShouldHaveLength, 1)
+		So(pending[0].Path, ShouldEqual, "/conflict")
+		So(pending[0].Type, ShouldEqual, ConflictPathOperation)
+		So(pending[0].NodeLeft.IsLeaf(), ShouldBeFalse)
+		So(pending[0].NodeRight.IsLeaf(), ShouldBeTrue)
+	})
+
+	Convey("Test left wins strategy", t, func() {
+		_, target, left, right := conflictingTrees(ctx, old, recent)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{Strategy: ConflictStrategyLeftWins})
+		So(e, ShouldBeNil)
+		So(bi.PendingConflicts(), ShouldBeEmpty)
+		// Conflicting node is only deleted when the patch is processed
+		_, e = target.LoadNode(ctx, "/conflict")
+		So(e, ShouldBeNil)
+		So(conflictOperations(bi), ShouldResemble, []string{"Delete:/conflict"})
+		So(bi.ConflictOperations()[0].Target(), ShouldEqual, target)
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toLeft, ShouldBeEmpty)
+		So(toRight, ShouldResemble, []string{"CreateFolder:conflict", "CreateFile:conflict/child"})
+	})
+
+	Convey("Test right wins strategy", t, func() {
+		source, _, left, right := conflictingTrees(ctx, old, recent)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{Strategy: ConflictStrategyRightWins})
+		So(e, ShouldBeNil)
+		_, e = source.LoadNode(ctx, "/conflict/child")
+		So(e, ShouldBeNil)
+		So(conflictOperations(bi), ShouldResemble, []string{"Delete:/conflict"})
+		So(bi.ConflictOperations()[0].Target(), ShouldEqual, source)
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toRight, ShouldBeEmpty)
+		So(toLeft, ShouldResemble, []string{"CreateFile:conflict"})
+	})
+
+	Convey("Test newest strategy", t, func() {
+		_, _, left, right := conflictingTrees(ctx, recent, old)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{Strategy: ConflictStrategyNewest})
+		So(e, ShouldBeNil)
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toLeft, ShouldBeEmpty)
+		So(toRight, ShouldHaveLength, 2)
+
+		_, _, left, right = conflictingTrees(ctx, old, recent)
+		bi, e = ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{Strategy: ConflictStrategyNewest})
+		So(e, ShouldBeNil)
+		toRight, toLeft = patchOperationsByDir(bi)
+		So(toRight, ShouldBeEmpty)
+		So(toLeft, ShouldResemble, []string{"CreateFile:conflict"})
+	})
+
+	Convey("Test keep both strategy", t, func() {
+		source, target, left, right := conflictingTrees(ctx, old, recent)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{Strategy: ConflictStrategyKeepBoth})
+		So(e, ShouldBeNil)
+		_, e = source.LoadNode(ctx, "/conflict/child")
+		So(e, ShouldBeNil)
+		_, e = target.LoadNode(ctx, "/conflict (right conflicted copy)")
+		So(e, ShouldNotBeNil)
+		So(conflictOperations(bi), ShouldResemble, []string{"MoveFile:/conflict (right conflicted copy)"})
+		So(bi.ConflictOperations()[0].GetMoveOriginPath(), ShouldEqual, "/conflict")
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toRight, ShouldResemble, []string{"CreateFolder:conflict", "CreateFile:conflict/child"})
+		So(toLeft, ShouldResemble, []string{"CreateFile:conflict (right conflicted copy)"})
+	})
+
+	Convey("Test strategy override for a given path", t, func() {
+		source, _, left, right := conflictingTrees(ctx, old, recent)
+		bi, e := ComputeBidirectionalPatch(ctx, left, right, &ConflictResolution{
+			Strategy: ConflictStrategyManual,
+			Paths:    map[string]ConflictStrategy{"/conflict": ConflictStrategyRightWins},
+		})
+		So(e, ShouldBeNil)
+		So(bi.PendingConflicts(), ShouldBeEmpty)
+		So(conflictOperations(bi), ShouldHaveLength, 1)
+		So(bi.ConflictOperations()[0].Target(), ShouldEqual, source)
+	})
+
+}
+
+func TestDiffConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	old := time.Now()
+	recent := old.Add(2 * time.Second)
+
+	contentConflict := func() (*memory.MemDB, *memory.MemDB) {
+		source, target := memory.NewMemDB(), memory.NewMemDB()
+		source.CreateNode(ctx, &tree.Node{Path: "/file.txt", Type: tree.NodeType_LEAF, Etag: "left-hash", MTime: old.Unix()}, true)
+		target.CreateNode(ctx, &tree.Node{Path: "/file.txt", Type: tree.NodeType_LEAF, Etag: "right-hash", MTime: recent.Unix()}, true)
+		target.CreateNode(ctx, &tree.Node{Path: "/file (right conflicted copy).txt", Type: tree.NodeType_LEAF, Etag: "other-hash", MTime: old.Unix()}, true)
+		return source, target
+	}
+
+	Convey("Test file content conflict is pending by default", t, func() {
+		source, target := contentConflict()
+		diff := newTreeDiff(ctx, source, target)
+		So(diff.Compute("/", nil, nil), ShouldBeNil)
+		bi := NewBidirectionalPatch(ctx, source, target)
+		e := diff.ToBidirectionalPatch(source, target, bi)
+		So(e, ShouldNotBeNil)
+		pending := bi.PendingConflicts()
+		So(pending, ShouldHaveLength, 1)
+		So(pending[0].Type, ShouldEqual, ConflictFileContent)
+	})
+
+	Convey("Test file content conflict solved by newest", t, func() {
+		source, target := contentConflict()
+		diff := newTreeDiff(ctx, source, target)
+		So(diff.Compute("/", nil, nil), ShouldBeNil)
+		bi := NewBidirectionalPatch(ctx, source, target)
+		bi.SetConflictResolution(&ConflictResolution{Strategy: ConflictStrategyNewest})
+		So(diff.ToBidirectionalPatch(source, target, bi), ShouldBeNil)
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toRight, ShouldBeEmpty)
+		So(toLeft, ShouldContain, "UpdateFile:file.txt")
+		So(toLeft, ShouldContain, "CreateFile:file (right conflicted copy).txt")
+	})
+
+	Convey("Test file content conflict solved by keeping both", t, func() {
+		source, target := contentConflict()
+		diff := newTreeDiff(ctx, source, target)
+		So(diff.Compute("/", nil, nil), ShouldBeNil)
+		bi := NewBidirectionalPatch(ctx, source, target)
+		bi.SetConflictResolution(&ConflictResolution{Strategy: ConflictStrategyKeepBoth})
+		So(diff.ToBidirectionalPatch(source, target, bi), ShouldBeNil)
+		right, e := target.LoadNode(ctx, "/file.txt")
+		So(e, ShouldBeNil)
+		So(right.Etag, ShouldEqual, "right-hash")
+		So(conflictOperations(bi), ShouldResemble, []string{"MoveFile:/file (right conflicted copy 2).txt"})
+		toRight, toLeft := patchOperationsByDir(bi)
+		So(toRight, ShouldContain, "CreateFile:file.txt")
+		So(toLeft, ShouldContain, "CreateFile:file (right conflicted copy 2).txt")
+	})
+
+}
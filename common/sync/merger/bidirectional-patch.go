@@ -52,6 +52,8 @@ type BidirectionalPatch struct {
 	unexpected          []error
 	ctx                 context.Context
 	ignoreUUIDConflicts bool
+	resolution          *ConflictResolution
+	conflictOps         []Operation
 }
 
 func NewBidirectionalPatch(ctx context.Context, source, target model.Endpoint) *BidirectionalPatch {
@@ -72,14 +74,18 @@ func NewBidirectionalPatch(ctx context.Context, source, target model.Endpoint) *
 	return b
 }
 
-// ComputeBidirectionalPatch merges two unidirectional Patch into one BidirectionalPatch
-func ComputeBidirectionalPatch(ctx context.Context, left, right Patch) (*BidirectionalPatch, error) {
+// ComputeBidirectionalPatch merges two unidirectional Patch into one BidirectionalPatch.
+// An optional ConflictResolution can be passed to automatically solve the detected conflicts.
+func ComputeBidirectionalPatch(ctx context.Context, left, right Patch, resolution ...*ConflictResolution) (*BidirectionalPatch, error) {
 	source := left.Source()
 	target, _ := model.AsPathSyncTarget(right.Source())
 	b := &BidirectionalPatch{
 		TreePatch: *newTreePatch(source, target, PatchOptions{MoveDetection: false}),
 		ctx:       ctx,
 	}
+	if len(resolution) > 0 {
+		b.resolution = resolution[0]
+	}
 
 	// If syncing on same server, do not trigger conflicts on .pydio
 	u1, _ := url.Parse(source.GetEndpointInfo().URI)
@@ -206,9 +212,28 @@ func (p *BidirectionalPatch) Filter(ctx context.Context, ignores ...glob.Glob) {
 	return
 }
 
+// Stats overrides TreePatch.Stats to count the operations required by solved conflicts
+func (p *BidirectionalPatch) Stats() map[string]interface{} {
+	s := p.TreePatch.Stats()
+	if len(p.conflictOps) > 0 {
+		s["ConflictOperations"] = len(p.conflictOps)
+	}
+	return s
+}
+
+// FilterToTarget overrides TreePatch.FilterToTarget. If conflicts were solved, the target status is not checked,
+// as the conflicting nodes are only moved or deleted when the patch is processed.
+func (p *BidirectionalPatch) FilterToTarget(ctx context.Context) {
+	if len(p.conflictOps) > 0 {
+		return
+	}
+	p.TreePatch.FilterToTarget(ctx)
+}
+
 // AppendBranch merges another bidir patch into this existing patch
 func (p *BidirectionalPatch) AppendBranch(ctx context.Context, branch *BidirectionalPatch) {
 	p.enqueueOperations(&branch.TreeNode)
+	p.addConflictOperations(branch.conflictOps...)
 }
 
 // initMatrix builds a matrix of left/right operations with Solver functions
@@ -333,8 +358,13 @@ func (p *BidirectionalPatch) enqueueRight(left, right *TreeNode) {
 	p.enqueueOperations(right, OperationDirLeft)
 }
 
-// enqueueConflict sets a Conflict flag on the the given path in side the patch. The Conflict has references to left and right operations
+// enqueueConflict sets a Conflict flag on the the given path in side the patch. The Conflict has references to left and right operations.
+// If the ConflictResolution can solve it, the conflict is replaced by the solution operations instead.
 func (p *BidirectionalPatch) enqueueConflict(left, right *TreeNode, t ConflictType) {
+	if solution, ok := p.solveConflict(t, left.Path); ok {
+		p.applySolutionToInputs(left, right, solution)
+		return
+	}
 	log.Logger(p.ctx).Error("-- Unsolvable conflict!", zap.Any("left", left.PathOperation), zap.Any("right", right.PathOperation))
 	p.unexpected = append(p.unexpected, fmt.Errorf("registered conflict at path %s", left.Path))
 	var leftOp, rightOp Operation
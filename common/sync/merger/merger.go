@@ -45,6 +45,27 @@ const (
 	ConflictMetaChanged
 )
 
+// String gives a string representation of this integer type
+func (t ConflictType) String() string {
+	switch t {
+	case ConflictFolderUUID:
+		return "FolderUUID"
+	case ConflictFileContent:
+		return "FileContent"
+	case ConflictNodeType:
+		return "NodeType"
+	case ConflictPathOperation:
+		return "PathOperation"
+	case ConflictMoveSameSource:
+		return "MoveSameSource"
+	case ConflictMoveSameTarget:
+		return "MoveSameTarget"
+	case ConflictMetaChanged:
+		return "MetaChanged"
+	}
+	return ""
+}
+
 type OperationDirection int
 
 const (
@@ -232,13 +232,17 @@ func (diff *TreeDiff) ToBidirectionalPatch(leftTarget model.PathSyncTarget, righ
 	diff.solveConflicts(diff.ctx)
 
 	leftPatch, rightPatch := diff.leftAndRightPatches(leftTarget, rightTarget)
-	b, err = ComputeBidirectionalPatch(diff.ctx, leftPatch, rightPatch)
+	b, err = ComputeBidirectionalPatch(diff.ctx, leftPatch, rightPatch, patch.ConflictResolution())
 	if err != nil {
 		return
 	}
 
-	// Re-enqueue Diff conflicts to Patch conflicts
+	// Re-enqueue Diff conflicts to Patch conflicts, unless they can be solved
 	for _, c := range diff.conflicts {
+		if solution, ok := b.solveConflict(c.Type, c.NodeLeft.Path); ok {
+			b.applySolution(c.NodeLeft.Path, solution)
+			continue
+		}
 		var leftOp, rightOp Operation
 		if c.NodeLeft.IsLeaf() {
 			leftOp = NewOperation(OpCreateFile, model.EventInfo{Path: c.NodeLeft.Path}, c.NodeLeft)
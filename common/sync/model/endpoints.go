@@ -30,6 +30,7 @@ import (
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/delta"
 )
 
 //AsPathSyncSource tries to cast an Endpoint to a PathSyncSource
@@ -56,6 +57,24 @@ func AsDataSyncTarget(endpoint Endpoint) (DataSyncTarget, bool) {
 	return i, ok
 }
 
+//AsDeltaSyncSource tries to cast an Endpoint to a DeltaSyncSource
+func AsDeltaSyncSource(endpoint Endpoint) (DeltaSyncSource, bool) {
+	i, ok := endpoint.(DeltaSyncSource)
+	return i, ok
+}
+
+//AsDeltaSyncTarget tries to cast an Endpoint to a DeltaSyncTarget
+func AsDeltaSyncTarget(endpoint Endpoint) (DeltaSyncTarget, bool) {
+	i, ok := endpoint.(DeltaSyncTarget)
+	return i, ok
+}
+
+//AsSignatureReceiver tries to cast an Endpoint to a SignatureReceiver
+func AsSignatureReceiver(endpoint Endpoint) (SignatureReceiver, bool) {
+	i, ok := endpoint.(SignatureReceiver)
+	return i, ok
+}
+
 //AsSessionProvider tries to cast an Endpoint to a SessionProvider
 func AsSessionProvider(endpoint Endpoint) (SessionProvider, bool) {
 	i, ok := endpoint.(SessionProvider)
@@ -191,6 +210,33 @@ type DataSyncSource interface {
 	GetReaderOn(path string) (out io.ReadCloser, err error)
 }
 
+// SignatureProvider provides the chunks signature of a file content, used to compute deltas
+type SignatureProvider interface {
+	// LoadSignature returns the signature of the current content, or an error if it is not available
+	LoadSignature(ctx context.Context, path string) (*delta.Signature, error)
+}
+
+// SignatureReceiver keeps the signature of a file content once it has been fully transferred
+type SignatureReceiver interface {
+	StoreSignature(ctx context.Context, path string, sig *delta.Signature) error
+}
+
+// DeltaSyncSource can provide ranges of the nodes contents, to be used for delta transfers
+type DeltaSyncSource interface {
+	DataSyncSource
+	SignatureProvider
+	// GetReaderOnRange provides a ReadCloser for reading a range of a node content
+	GetReaderOnRange(path string, offset, length int64) (io.ReadCloser, error)
+}
+
+// DeltaSyncTarget can rebuild a node content from its current content and the ranges that changed
+type DeltaSyncTarget interface {
+	DataSyncTarget
+	SignatureProvider
+	// ApplyDelta writes the new content described by the delta, reading modified ranges from source
+	ApplyDelta(ctx context.Context, path string, d *delta.Delta, source delta.RangeReader) error
+}
+
 // UuidProvider declares an endpoint to be able to load a node by its unique UUID
 type UuidProvider interface {
 	// LoadNodeByUuid loads a node by UUID.
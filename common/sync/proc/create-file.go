@@ -30,6 +30,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/pydio/cells/common/sync/delta"
 	"github.com/pydio/cells/common/sync/merger"
 	"github.com/pydio/cells/common/sync/model"
 )
@@ -70,6 +71,12 @@ func (pr *Processor) processCreateFile(ctx context.Context, operation merger.Ope
 	}
 	if dtOk && dsOk {
 
+		if pr.DeltaTransfers && operation.Type() == merger.OpUpdateFile && operation.GetNode().Size >= delta.MinFileSize {
+			if done, err := pr.processDelta(ctx, operation, localPath, pg); done {
+				return err
+			}
+		}
+
 		reader, rErr := dataSource.GetReaderOn(localPath)
 		if rErr != nil {
 			pr.Logger().Error("Cannot get reader on source", zap.String("job", "create"), zap.String("path", localPath), zap.Error(rErr))
@@ -82,8 +89,16 @@ func (pr *Processor) processCreateFile(ctx context.Context, operation merger.Ope
 			pr.Logger().Error("Cannot get writer on target", zap.String("job", "create"), zap.String("path", localPath), zap.Error(wErr))
 			return wErr
 		}
+		var copySource io.Reader = reader
+		var sigWriter *delta.SignatureWriter
+		if pr.receivesSignatures(operation) {
+			// Compute signature while copying, for next updates to be transferred as deltas
+			sigWriter = delta.NewSignatureWriter(delta.DefaultParams)
+			defer sigWriter.Close()
+			copySource = io.TeeReader(reader, sigWriter)
+		}
 		progressReader := &cancellableReaderWithProgress{
-			Reader:   reader,
+			Reader:   copySource,
 			pg:       pg,
 			canceler: ctx,
 		}
@@ -101,12 +116,16 @@ func (pr *Processor) processCreateFile(ctx context.Context, operation merger.Ope
 			for {
 				select {
 				case <-writeDone:
+					pr.storeComputedSignature(ctx, operation, localPath, sigWriter)
 					return nil
 				case e := <-writeErr:
 					return e
 				}
 			}
 		} else {
+			if err == nil {
+				pr.storeComputedSignature(ctx, operation, localPath, sigWriter)
+			}
 			return err
 		}
 
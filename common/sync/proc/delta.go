@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package proc
+
+import (
+	"context"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/sync/delta"
+	"github.com/pydio/cells/common/sync/merger"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// progressReadCloser sends progress of bytes read, see cancellableReaderWithProgress
+type progressReadCloser struct {
+	*cancellableReaderWithProgress
+	io.Closer
+}
+
+// processDelta tries to transfer only the modified chunks of a file. It returns false if delta transfer is not
+// possible for this operation, in which case the whole file must be transferred.
+func (pr *Processor) processDelta(ctx context.Context, operation merger.Operation, localPath string, pg chan int64) (bool, error) {
+
+	dataTarget, dtOk := model.AsDeltaSyncTarget(operation.Target())
+	dataSource, dsOk := model.AsDeltaSyncSource(operation.Source())
+	if !dtOk || !dsOk {
+		return false, nil
+	}
+	oldSig, e := dataTarget.LoadSignature(ctx, localPath)
+	if e != nil {
+		pr.Logger().Debug("No signature on target, skipping delta transfer", zap.String("path", localPath), zap.Error(e))
+		return false, nil
+	}
+	newSig, e := dataSource.LoadSignature(ctx, localPath)
+	if e != nil {
+		pr.Logger().Debug("No signature on source, skipping delta transfer", zap.String("path", localPath), zap.Error(e))
+		return false, nil
+	}
+	d, e := delta.Compute(oldSig, newSig)
+	if e != nil || d.ReusedSize() == 0 {
+		return false, nil
+	}
+
+	var readers []*cancellableReaderWithProgress
+	reused := d.ReusedSize()
+	pg <- reused
+	source := delta.RangeReaderFunc(func(offset, length int64) (io.ReadCloser, error) {
+		reader, e := dataSource.GetReaderOnRange(localPath, offset, length)
+		if e != nil {
+			return nil, e
+		}
+		progressReader := &cancellableReaderWithProgress{
+			Reader:   reader,
+			pg:       pg,
+			canceler: ctx,
+		}
+		readers = append(readers, progressReader)
+		return &progressReadCloser{cancellableReaderWithProgress: progressReader, Closer: reader}, nil
+	})
+	if e := dataTarget.ApplyDelta(ctx, localPath, d, source); e != nil {
+		pr.Logger().Error("Cannot apply delta on target", zap.String("path", localPath), zap.Error(e))
+		// Revert progress count to 0 for this operation
+		total := reused
+		for _, r := range readers {
+			total += r.totalRead
+		}
+		pg <- -total
+		return true, e
+	}
+	pr.Logger().Debug("Delta transfer finished", zap.String("path", localPath), zap.Int64("size", d.Size), zap.Int64("transferred", d.TransferSize()))
+	pr.storeSignature(ctx, operation, localPath, newSig)
+	return true, nil
+
+}
+
+// receivesSignatures checks if signatures should be computed while transferring this operation
+func (pr *Processor) receivesSignatures(operation merger.Operation) bool {
+	if !pr.DeltaTransfers || operation.GetNode().Size < delta.MinFileSize {
+		return false
+	}
+	_, sOk := model.AsSignatureReceiver(operation.Source())
+	_, tOk := model.AsSignatureReceiver(operation.Target())
+	return sOk || tOk
+}
+
+// storeComputedSignature waits for the signature computation and stores it on the endpoints
+func (pr *Processor) storeComputedSignature(ctx context.Context, operation merger.Operation, localPath string, sigWriter *delta.SignatureWriter) {
+	if sigWriter == nil {
+		return
+	}
+	if e := sigWriter.Close(); e != nil {
+		pr.Logger().Debug("Cannot compute signature", zap.String("path", localPath), zap.Error(e))
+		return
+	}
+	sig, _ := sigWriter.Signature()
+	pr.storeSignature(ctx, operation, localPath, sig)
+}
+
+// storeSignature registers the signature of the transferred content on the endpoints keeping signatures
+func (pr *Processor) storeSignature(ctx context.Context, operation merger.Operation, localPath string, sig *delta.Signature) {
+	for _, ep := range []model.Endpoint{operation.Source(), operation.Target()} {
+		if receiver, ok := model.AsSignatureReceiver(ep); ok {
+			if e := receiver.StoreSignature(ctx, localPath, sig); e != nil {
+				pr.Logger().Debug("Cannot store signature", zap.String("path", localPath), zap.Error(e))
+			}
+		}
+	}
+}
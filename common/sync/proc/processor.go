@@ -60,6 +60,7 @@ type Processor struct {
 	SkipTargetChecks bool
 	Ignores          []glob.Glob
 	PatchListener    merger.PatchListener
+	DeltaTransfers   bool
 }
 
 // NewProcessor creates a new processor
@@ -231,6 +232,12 @@ func (pr *Processor) Process(patch merger.Patch, cmd *model.Command) {
 		pr.applyProcessFunc(ctx, patch, op, processUUID, &cursor, total, false)
 	}
 
+	// Solved conflicts may first require moving or deleting nodes to free their paths
+	if bi, ok := patch.(*merger.BidirectionalPatch); ok {
+		for _, op := range bi.ConflictOperations() {
+			serialWalker(op)
+		}
+	}
 	patch.WalkOperations([]merger.OperationType{merger.OpCreateFolder}, serialWalker)
 	patch.WalkOperations([]merger.OperationType{merger.OpMoveFolder}, serialWalker)
 	patch.WalkOperations([]merger.OperationType{merger.OpMoveFile}, serialWalker)
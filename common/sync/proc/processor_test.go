@@ -124,3 +124,55 @@ func TestProcess(t *testing.T) {
 	})
 
 }
+
+func TestProcessSolvedConflicts(t *testing.T) {
+
+	Convey("Test processing conflicts solved by left wins", t, func() {
+		source := memory.NewMemDB()
+		target := memory.NewMemDB()
+		source.CreateNode(testCtx, &tree.Node{Path: "conflict", Type: tree.NodeType_COLLECTION, Uuid: "uuid"}, true)
+		source.CreateNode(testCtx, &tree.Node{Path: "conflict/child", Type: tree.NodeType_LEAF, Etag: "child-hash"}, true)
+		target.CreateNode(testCtx, &tree.Node{Path: "conflict", Type: tree.NodeType_LEAF, Etag: "file-hash"}, true)
+
+		diff := merger.NewTreeDiff(testCtx, source, target)
+		So(diff.Compute("/", nil, nil), ShouldBeNil)
+		patch := merger.NewBidirectionalPatch(testCtx, source, target)
+		patch.SetConflictResolution(&merger.ConflictResolution{Strategy: merger.ConflictStrategyLeftWins})
+		So(diff.ToBidirectionalPatch(source, target, patch), ShouldBeNil)
+		So(patch.ConflictOperations(), ShouldHaveLength, 1)
+
+		NewProcessor(testCtx).Process(patch, nil)
+
+		folder, e := target.LoadNode(testCtx, "conflict")
+		So(e, ShouldBeNil)
+		So(folder.IsLeaf(), ShouldBeFalse)
+		child, e := target.LoadNode(testCtx, "conflict/child")
+		So(e, ShouldBeNil)
+		So(child.Etag, ShouldEqual, "child-hash")
+	})
+
+	Convey("Test processing conflicts solved by keeping both", t, func() {
+		source := memory.NewMemDB()
+		target := memory.NewMemDB()
+		source.CreateNode(testCtx, &tree.Node{Path: "file.txt", Type: tree.NodeType_LEAF, Etag: "left-hash"}, true)
+		target.CreateNode(testCtx, &tree.Node{Path: "file.txt", Type: tree.NodeType_LEAF, Etag: "right-hash"}, true)
+
+		diff := merger.NewTreeDiff(testCtx, source, target)
+		So(diff.Compute("/", nil, nil), ShouldBeNil)
+		patch := merger.NewBidirectionalPatch(testCtx, source, target)
+		patch.SetConflictResolution(&merger.ConflictResolution{Strategy: merger.ConflictStrategyKeepBoth})
+		So(diff.ToBidirectionalPatch(source, target, patch), ShouldBeNil)
+
+		NewProcessor(testCtx).Process(patch, nil)
+
+		for _, side := range []*memory.MemDB{source, target} {
+			file, e := side.LoadNode(testCtx, "file.txt")
+			So(e, ShouldBeNil)
+			So(file.Etag, ShouldEqual, "left-hash")
+			copied, e := side.LoadNode(testCtx, "file (right conflicted copy).txt")
+			So(e, ShouldBeNil)
+			So(copied.Etag, ShouldEqual, "right-hash")
+		}
+	})
+
+}
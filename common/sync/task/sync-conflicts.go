@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package task
+
+import (
+	"fmt"
+
+	"github.com/pydio/cells/common/sync/merger"
+)
+
+// PendingConflicts lists the conflicts detected by the last bidirectional run that could not be solved.
+// Conflicts are only exposed to the code embedding the Sync: no service of this repository runs bidirectional
+// syncs, hence there is no gRPC or REST API to list or resolve them.
+func (s *Sync) PendingConflicts() []*merger.PendingConflict {
+	s.conflictsLock.Lock()
+	defer s.conflictsLock.Unlock()
+	return s.pendingConflicts
+}
+
+// ResolveConflict registers the strategy used to solve a pending conflict. It is applied at the next run.
+func (s *Sync) ResolveConflict(path string, strategy merger.ConflictStrategy) error {
+	if strategy == merger.ConflictStrategyManual {
+		return fmt.Errorf("please choose a strategy for solving conflict")
+	}
+	s.conflictsLock.Lock()
+	defer s.conflictsLock.Unlock()
+	for _, c := range s.pendingConflicts {
+		if c.Path == path {
+			if s.resolutions == nil {
+				s.resolutions = make(map[string]merger.ConflictStrategy)
+			}
+			s.resolutions[path] = strategy
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot find pending conflict at path %s", path)
+}
+
+// conflictResolution builds the ConflictResolution passed to bidirectional patches
+func (s *Sync) conflictResolution() *merger.ConflictResolution {
+	s.conflictsLock.Lock()
+	defer s.conflictsLock.Unlock()
+	r := &merger.ConflictResolution{
+		Strategy: s.ConflictStrategy,
+		Paths:    make(map[string]merger.ConflictStrategy, len(s.resolutions)),
+	}
+	for p, st := range s.resolutions {
+		r.Paths[p] = st
+	}
+	return r
+}
+
+// setPendingConflicts stores the conflicts left by a run, and forgets the resolutions that were applied
+func (s *Sync) setPendingConflicts(conflicts []*merger.PendingConflict) {
+	s.conflictsLock.Lock()
+	defer s.conflictsLock.Unlock()
+	s.pendingConflicts = conflicts
+	s.resolutions = nil
+}
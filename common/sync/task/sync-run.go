@@ -48,8 +48,13 @@ func (s *Sync) run(ctx context.Context, dryRun bool, force bool) (model.Stater,
 		bb := merger.NewBidirectionalPatch(ctx, s.Source, s.Target)
 		bb.SetSessionData(ctx, false)
 		bb.SetupChannels(s.statuses, s.runDone, s.cmd)
+		bb.SetConflictResolution(s.conflictResolution())
 
-		if e := s.runBi(ctx, bb, dryRun, force, rootsInfo); e != nil || dryRun {
+		e := s.runBi(ctx, bb, dryRun, force, rootsInfo)
+		if !dryRun {
+			s.setPendingConflicts(bb.PendingConflicts())
+		}
+		if e != nil || dryRun {
 			bb.Done(bb)
 
 			return bb, e
@@ -185,7 +190,7 @@ func (s *Sync) runBi(ctx context.Context, bb *merger.BidirectionalPatch, dryRun
 
 		log.Logger(ctx).Info("Computing patches from Snapshots")
 		for _, r := range roots {
-			b, e := merger.ComputeBidirectionalPatch(ctx, leftPatches[r], rightPatches[r])
+			b, e := merger.ComputeBidirectionalPatch(ctx, leftPatches[r], rightPatches[r], bb.ConflictResolution())
 			if b != nil {
 				bb.AppendBranch(ctx, b)
 			}
@@ -22,6 +22,7 @@ package task
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/pydio/cells/common/log"
@@ -36,7 +37,7 @@ func (s *Sync) startWatchers(ctx context.Context) {
 	source, sOk := model.AsPathSyncSource(s.Source)
 	target, tOk := model.AsPathSyncTarget(s.Target)
 	if s.Direction != model.DirectionLeft && sOk && tOk {
-		if stop, err := s.setupWatcher(ctx, source, target); err == nil {
+		if stop, err := s.setupWatcher(ctx, source, target, s.storageWatcher); err == nil {
 			s.watchersChan = append(s.watchersChan, stop)
 		} else {
 			log.Logger(ctx).Error("Could not setup watcher on "+s.Source.GetEndpointInfo().URI, zap.Error(err))
@@ -51,7 +52,7 @@ func (s *Sync) startWatchers(ctx context.Context) {
 	source2, sOk2 := model.AsPathSyncSource(s.Target)
 	target2, tOk2 := model.AsPathSyncTarget(s.Source)
 	if s.Direction != model.DirectionRight && sOk2 && tOk2 {
-		if stop, err := s.setupWatcher(ctx, source2, target2); err == nil {
+		if stop, err := s.setupWatcher(ctx, source2, target2, nil); err == nil {
 			s.watchersChan = append(s.watchersChan, stop)
 		} else {
 			log.Logger(ctx).Error("Could not setup watcher on "+s.Target.GetEndpointInfo().URI, zap.Error(err))
@@ -74,8 +75,8 @@ func (s *Sync) stopWatchers() {
 	s.watchersChan = []chan bool{}
 }
 
-// setupWatcher starts watching events for sync
-func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, target model.PathSyncTarget) (chan bool, error) {
+// setupWatcher starts watching events for sync. If storage is not nil, its events are merged with the source ones.
+func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, target model.PathSyncTarget, storage model.PathSyncSource) (chan bool, error) {
 
 	var err error
 	watchObject, err := source.Watch("")
@@ -91,6 +92,22 @@ func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, ta
 	inputCloser := make(chan bool)
 
 	out := input
+	// If a storage watcher is registered, merge its events and drop echoes
+	var storageCloser chan bool
+	if storage != nil {
+		if storageEvents, closer, e := s.watchStorage(ctx, storage, source); e == nil {
+			out = filters.NewStorageFilter(s.storageDelay).Pipe(out, storageEvents)
+			storageCloser = closer
+		} else {
+			log.Logger(ctx).Error("Could not setup storage watcher, changes performed directly on the storage will not be detected", zap.Error(e))
+		}
+	}
+	closeStorage := func() {
+		if storageCloser != nil {
+			close(storageCloser)
+		}
+	}
+
 	// If EchoFilter is registered, pipe
 	if s.echoFilter != nil {
 		out = s.echoFilter.Pipe(out)
@@ -137,6 +154,7 @@ func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, ta
 							WatchConnection: model.WatchDisconnected,
 							EndpointInfo:    source.GetEndpointInfo(),
 						}
+						closeStorage()
 						close(input)
 						return
 					}
@@ -151,6 +169,7 @@ func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, ta
 			case <-inputCloser:
 				inputClosed = true
 				watchObject.DoneChan <- true
+				closeStorage()
 				close(input)
 				return
 			}
@@ -168,3 +187,65 @@ func (s *Sync) setupWatcher(ctx context.Context, source model.PathSyncSource, ta
 	return inputCloser, nil
 
 }
+
+// watchStorage starts watching the storage and rewrites its events as if they were emitted by the source.
+// Closing the returned chan stops the storage watcher.
+func (s *Sync) watchStorage(ctx context.Context, storage model.PathSyncSource, source model.PathSyncSource) (chan model.EventInfo, chan bool, error) {
+
+	watchObject, err := storage.Watch("")
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make(chan model.EventInfo)
+	closer := make(chan bool)
+	stop := func() {
+		// Do not block if the watcher is itself trying to send an event, drain until it is closed
+		go func() {
+			watchObject.DoneChan <- true
+		}()
+		for range watchObject.Events() {
+		}
+	}
+
+	go func() {
+		defer close(events)
+		errs := watchObject.Errors()
+		for {
+			select {
+			case event, ok := <-watchObject.Events():
+				if !ok {
+					return
+				}
+				event.Path = strings.TrimLeft(event.Path, model.InternalPathSeparator)
+				event.Source = source
+				select {
+				case events <- event:
+				case <-closer:
+					stop()
+					return
+				}
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				if err == nil {
+					continue
+				}
+				if err.Error() == "API Not Supported" {
+					log.Logger(ctx).Warn("Storage does not support watching events, changes performed directly on the storage will not be detected", zap.Error(err))
+					return
+				}
+				log.Logger(ctx).Error("Received error from storage watcher", zap.Error(err))
+			case connInfo := <-watchObject.ConnectionInfo:
+				log.Logger(ctx).Debug("Storage watcher connection status", zap.Any("status", connInfo))
+			case <-closer:
+				stop()
+				return
+			}
+		}
+	}()
+
+	return events, closer, nil
+
+}
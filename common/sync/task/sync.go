@@ -51,12 +51,18 @@ type Sync struct {
 	Ignores          []glob.Glob
 	SkipTargetChecks bool
 	FailsafeDeletes  bool
+	// ConflictStrategy is applied to the conflicts detected by bidirectional syncs
+	ConflictStrategy merger.ConflictStrategy
+	// DeltaTransfers only transfers the modified chunks of updated files, if both endpoints support it
+	DeltaTransfers bool
 
 	snapshotFactory model.SnapshotFactory
 	echoFilter      *filters.EchoFilter
 	eventsBatchers  []*filters.EventsBatcher
 	processor       *proc.ConnectedProcessor
 	patchListener   merger.PatchListener
+	storageWatcher  model.PathSyncSource
+	storageDelay    time.Duration
 
 	watch        bool
 	watchersChan []chan bool
@@ -65,6 +71,10 @@ type Sync struct {
 	runDone      chan interface{}
 	cmd          *model.Command
 	patchChan    chan merger.Patch
+
+	conflictsLock    sync.Mutex
+	pendingConflicts []*merger.PendingConflict
+	resolutions      map[string]merger.ConflictStrategy
 }
 
 // NewSync creates a new sync task
@@ -97,6 +107,14 @@ func (s *Sync) SetPatchListener(listener merger.PatchListener) {
 	s.patchListener = listener
 }
 
+// SetStorageWatcher registers an additional watcher detecting changes performed directly on the storage
+// underlying the Source endpoint. Its events are merged with the Source ones, after dropping the echoes of
+// operations already reported by the Source during the echoDelay.
+func (s *Sync) SetStorageWatcher(storage model.PathSyncSource, echoDelay time.Duration) {
+	s.storageWatcher = storage
+	s.storageDelay = echoDelay
+}
+
 // Start makes a first sync and setup watchers
 func (s *Sync) Start(ctx context.Context, withWatches bool) {
 
@@ -110,6 +128,7 @@ func (s *Sync) Start(ctx context.Context, withWatches bool) {
 		s.processor.PatchListener = s.patchListener
 	}
 	s.processor.Ignores = s.Ignores
+	s.processor.DeltaTransfers = s.DeltaTransfers
 	s.processor.Start()
 
 	// Init EchoFilter
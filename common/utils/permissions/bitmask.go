@@ -85,6 +85,7 @@ func (f Bitmask) HasFlag(ctx context.Context, flag BitmaskFlag, ctxNodes ...*tre
 		// We should first resolve the policy, given the ctx and the node
 		policyContext := make(map[string]string)
 		PolicyContextFromMetadata(policyContext, ctx)
+		PolicyContextFromClaims(policyContext, ctx)
 		var subjects []string
 		for k, _ := range f.PolicyIds {
 			subjects = append(subjects, fmt.Sprintf("policy:%s", k))
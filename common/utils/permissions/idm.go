@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 	"time"
 
@@ -498,6 +499,53 @@ func SearchUniqueUser(ctx context.Context, login string, uuid string, queries ..
 	return
 }
 
+// SearchUsersForRole lists the users that are granted the passed role: the user itself for a user role,
+// all users of the group (recursively) for a group role, and the users having the role attached otherwise.
+func SearchUsersForRole(ctx context.Context, roleID string) ([]*idm.User, error) {
+	roles := GetRoles(ctx, []string{roleID})
+	if len(roles) == 0 {
+		return nil, errors.NotFound(common.ServiceRole, "Cannot find role %s", roleID)
+	}
+	role := roles[0]
+	var query *idm.UserSingleQuery
+	if role.UserRole {
+		u, e := SearchUniqueUser(ctx, "", roleID)
+		if e != nil {
+			return nil, e
+		}
+		return []*idm.User{u}, nil
+	} else if role.GroupRole {
+		group, e := SearchUniqueUser(ctx, "", roleID, &idm.UserSingleQuery{NodeType: idm.NodeType_GROUP})
+		if e != nil {
+			return nil, e
+		}
+		query = &idm.UserSingleQuery{GroupPath: path.Join("/", group.GroupPath, group.GroupLabel), Recursive: true, NodeType: idm.NodeType_USER}
+	} else {
+		query = &idm.UserSingleQuery{HasRole: roleID, NodeType: idm.NodeType_USER}
+	}
+	q, _ := ptypes.MarshalAny(query)
+	userCli := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
+	streamer, err := userCli.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if err != nil {
+		return nil, err
+	}
+	defer streamer.Close()
+	var users []*idm.User
+	for {
+		resp, e := streamer.Recv()
+		if e != nil {
+			if e != io.EOF {
+				return nil, e
+			}
+			break
+		}
+		if u := resp.GetUser(); u != nil && !u.IsGroup {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
 // IsUserLocked checks if the passed user has a logout attribute defined.
 func IsUserLocked(user *idm.User) bool {
 	var hasLock bool
@@ -27,6 +27,8 @@ import (
 	"strings"
 	"time"
 
+	json "github.com/pydio/cells/x/jsonx"
+
 	"github.com/pydio/cells/idm/policy/converter"
 
 	"github.com/ory/ladon"
@@ -38,6 +40,7 @@ import (
 	"github.com/micro/go-micro/metadata"
 
 	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service/context"
@@ -51,6 +54,18 @@ const (
 	PolicyNodeMetaSize      = "NodeMetaSize"
 	PolicyNodeMetaMTime     = "NodeMetaMTime"
 	PolicyNodeMeta_         = "NodeMeta:"
+
+	PolicyUserLogin      = "UserLogin"
+	PolicyUserProfile    = "UserProfile"
+	PolicyUserGroupPath  = "UserGroupPath"
+	PolicyUserAuthSource = "UserAuthSource"
+	PolicyUserAttr_      = "UserAttr:"
+
+	// accessStartLayout is the date format of the ServerTime policy context value
+	accessStartLayout = "2006-01-02T15:04-0700"
+
+	// PolicyUserAttributesMaxSize bounds the size of the user attributes passed along with every query in the claims
+	PolicyUserAttributesMaxSize = 2 * 1024
 )
 
 // PolicyRequestSubjectsFromUser builds an array of string subjects from the passed User.
@@ -85,6 +100,50 @@ func PolicyRequestSubjectsFromClaims(claims claim.Claims) []string {
 	return subjects
 }
 
+// accessStartCondition is the JSON form of a DateAfterCondition checked against the server time,
+// as read by the policy engine.
+type accessStartCondition struct {
+	Type    string `json:"type"`
+	Options struct {
+		Matches string `json:"matches"`
+	} `json:"options"`
+}
+
+// AccessStartConditions builds the JsonConditions of a resource policy that only applies after
+// the start date. The date is rounded up to the minute, as conditions have a minute precision.
+func AccessStartConditions(start int64) string {
+	c := &accessStartCondition{Type: "DateAfterCondition"}
+	t := time.Unix(start, 0)
+	if r := t.Truncate(time.Minute); r.Before(t) {
+		t = r.Add(time.Minute)
+	}
+	c.Options.Matches = t.Format(accessStartLayout)
+	data, _ := json.Marshal(map[string]*accessStartCondition{servicecontext.ServerTime: c})
+	return string(data)
+}
+
+// IsUserPending checks if the passed user has a policy restricted to an access start date that
+// is not reached yet. This is used by hidden users of scheduled public links.
+func IsUserPending(user *idm.User) bool {
+	for _, pol := range user.Policies {
+		if pol.JsonConditions == "" {
+			continue
+		}
+		var conditions map[string]*accessStartCondition
+		if e := json.Unmarshal([]byte(pol.JsonConditions), &conditions); e != nil {
+			continue
+		}
+		c, ok := conditions[servicecontext.ServerTime]
+		if !ok || c == nil || c.Type != "DateAfterCondition" {
+			continue
+		}
+		if start, e := time.Parse(accessStartLayout, c.Options.Matches); e == nil && time.Now().Before(start) {
+			return true
+		}
+	}
+	return false
+}
+
 // PolicyContextFromMetadata extracts metadata directly from the context and enriches the passed policyContext.
 func PolicyContextFromMetadata(policyContext map[string]string, ctx context.Context) {
 	if ctxMeta, has := metadata.FromContext(ctx); has {
@@ -107,6 +166,63 @@ func PolicyContextFromMetadata(policyContext map[string]string, ctx context.Cont
 	}
 }
 
+// PolicyUserAttributes lists the user attributes that can be checked by policies conditions, as configured
+// in the policy service. These attributes are managed by administrators or auth sources: users cannot modify them.
+func PolicyUserAttributes() []string {
+	return config.Get("services", common.ServiceGrpcNamespace_+common.ServicePolicy, "userAttributes").StringArray()
+}
+
+// PolicyAttributesFromUser returns the attributes of a user that can be checked by policies conditions.
+func PolicyAttributesFromUser(user *idm.User) map[string]string {
+	return user.PolicyAttributes(PolicyUserAttributes(), PolicyUserAttributesMaxSize)
+}
+
+// PolicyContextFromClaims extracts the current user claims from the context and enriches the passed policyContext.
+// Claims are read from the context value if set, or from the metadata passed along by grpc queries.
+func PolicyContextFromClaims(policyContext map[string]string, ctx context.Context) {
+	var claims claim.Claims
+	if c, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok {
+		claims = c
+	} else if ctxMeta, has := metadata.FromContext(ctx); has {
+		js, ok := ctxMeta[claim.MetadataKey]
+		if !ok {
+			js, ok = ctxMeta[strings.Title(claim.MetadataKey)]
+		}
+		if !ok || json.Unmarshal([]byte(js), &claims) != nil {
+			return
+		}
+	} else {
+		return
+	}
+	if claims.Name == "" {
+		return
+	}
+	policyContext[PolicyUserLogin] = claims.Name
+	policyContext[PolicyUserProfile] = claims.Profile
+	policyContext[PolicyUserGroupPath] = claims.GroupPath
+	policyContext[PolicyUserAuthSource] = claims.AuthSource
+	for _, k := range PolicyUserAttributes() {
+		if v, ok := claims.Attributes[k]; ok {
+			policyContext[PolicyUserAttr_+k] = v
+		}
+	}
+}
+
+// PolicyContextFromUser enriches the passed policyContext with the properties of a user, as PolicyContextFromClaims does.
+func PolicyContextFromUser(policyContext map[string]string, user *idm.User) {
+	policyContext[PolicyUserLogin] = user.Login
+	policyContext[PolicyUserGroupPath] = user.GroupPath
+	if profile, ok := user.Attributes[idm.UserAttrProfile]; ok {
+		policyContext[PolicyUserProfile] = profile
+	} else {
+		policyContext[PolicyUserProfile] = common.PydioProfileStandard
+	}
+	policyContext[PolicyUserAuthSource] = user.Attributes[idm.UserAttrAuthSource]
+	for k, v := range PolicyAttributesFromUser(user) {
+		policyContext[PolicyUserAttr_+k] = v
+	}
+}
+
 // PolicyContextFromNode extracts metadata from the Node and enriches the passed policyContext.
 func PolicyContextFromNode(policyContext map[string]string, node *tree.Node) {
 	policyContext[PolicyNodeMetaName] = node.GetStringMeta("name")
@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+	cronMonths = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronWeekdays = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// CronSchedule is a parsed cron expression. Supported syntax is the standard 5-fields format
+// (minute hour day-of-month month day-of-week) with lists, ranges, steps, month and weekday names,
+// plus "L" (last day of month) in the day-of-month field and "weekday#n" (n-th weekday of month) in
+// the day-of-week field. Descriptors @yearly, @monthly, @weekly, @daily and @hourly are also accepted.
+type CronSchedule struct {
+	expression string
+	location   *time.Location
+
+	minute, hour, dom, month, dow uint64
+	// Nth weekday of month, as a bitmask of n for each weekday
+	nth [7]uint8
+	// Last day of month
+	lastDom bool
+
+	domStar, dowStar bool
+}
+
+// ParseCron parses a cron expression, to be evaluated in the given time zone (time.Local if empty).
+func ParseCron(expression string, timezone string) (*CronSchedule, error) {
+
+	loc := time.Local
+	if timezone != "" {
+		l, e := time.LoadLocation(timezone)
+		if e != nil {
+			return nil, errors.Wrap(e, "invalid timezone")
+		}
+		loc = l
+	}
+	expr := strings.TrimSpace(expression)
+	if d, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %s: expected 5 fields, found %d", expression, len(fields))
+	}
+
+	c := &CronSchedule{expression: expression, location: loc}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, errors.Wrap(err, "minute")
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, errors.Wrap(err, "hour")
+	}
+	if err = c.parseDom(fields[2]); err != nil {
+		return nil, errors.Wrap(err, "day-of-month")
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return nil, errors.Wrap(err, "month")
+	}
+	if err = c.parseDow(fields[4]); err != nil {
+		return nil, errors.Wrap(err, "day-of-week")
+	}
+	return c, nil
+
+}
+
+// String returns the original expression.
+func (c *CronSchedule) String() string {
+	return c.expression
+}
+
+// Next finds the first time strictly after the given time that matches the expression.
+// It returns a zero time if nothing is found in the next five years.
+func (c *CronSchedule) Next(from time.Time) time.Time {
+
+	loc := c.location
+	f := from.In(loc)
+	t := time.Date(f.Year(), f.Month(), f.Day(), f.Hour(), f.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// Ambiguous hour when switching back from daylight saving time
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) > 0 || (c.lastDom && t.AddDate(0, 0, 1).Day() == 1)
+	wd := t.Weekday()
+	dowMatch := c.dow&(1<<uint(wd)) > 0 || c.nth[wd]&(1<<uint((t.Day()-1)/7+1)) > 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	// Standard cron behavior: if both fields are restricted, match any of them
+	return domMatch || dowMatch
+}
+
+func (c *CronSchedule) parseDom(field string) (err error) {
+	c.domStar = field == "*" || field == "?"
+	var parts []string
+	for _, p := range strings.Split(field, ",") {
+		if strings.ToUpper(p) == "L" {
+			c.lastDom = true
+		} else {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) > 0 {
+		c.dom, err = parseCronField(strings.Join(parts, ","), 1, 31, nil)
+	}
+	return
+}
+
+func (c *CronSchedule) parseDow(field string) error {
+	c.dowStar = field == "*" || field == "?"
+	var parts []string
+	for _, p := range strings.Split(field, ",") {
+		if !strings.Contains(p, "#") {
+			parts = append(parts, p)
+			continue
+		}
+		spec := strings.SplitN(p, "#", 2)
+		d, e := parseCronValue(spec[0], 0, 7, cronWeekdays)
+		if e != nil {
+			return e
+		}
+		n, e := strconv.Atoi(spec[1])
+		if e != nil || n < 1 || n > 5 {
+			return fmt.Errorf("invalid weekday occurrence in %s", p)
+		}
+		c.nth[d%7] |= 1 << uint(n)
+	}
+	if len(parts) > 0 {
+		dow, e := parseCronField(strings.Join(parts, ","), 0, 7, cronWeekdays)
+		if e != nil {
+			return e
+		}
+		// Both 0 and 7 stand for Sunday
+		if dow&(1<<7) > 0 {
+			dow |= 1
+		}
+		c.dow = dow
+	}
+	return nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps into a bitset.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i > -1 {
+			rangePart = part[:i]
+			s, e := strconv.Atoi(part[i+1:])
+			if e != nil || s < 1 {
+				return 0, fmt.Errorf("invalid step in %s", part)
+			}
+			step = s
+		}
+		start, end := min, max
+		if rangePart != "*" && rangePart != "?" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var e error
+			if start, e = parseCronValue(bounds[0], min, max, names); e != nil {
+				return 0, e
+			}
+			if len(bounds) == 2 {
+				if end, e = parseCronValue(bounds[1], min, max, names); e != nil {
+					return 0, e
+				}
+			} else if step == 1 {
+				end = start
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range %s", rangePart)
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, min, max int, names map[string]int) (int, error) {
+	if names != nil {
+		if v, ok := names[strings.ToUpper(value)]; ok {
+			return v, nil
+		}
+	}
+	v, e := strconv.Atoi(value)
+	if e != nil {
+		return 0, fmt.Errorf("invalid value %s", value)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d]", v, min, max)
+	}
+	return v, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseCron(t *testing.T) {
+
+	Convey("Parse invalid cron expressions", t, func() {
+
+		_, e := ParseCron("* * * *", "")
+		So(e, ShouldNotBeNil)
+		_, e = ParseCron("61 * * * *", "")
+		So(e, ShouldNotBeNil)
+		_, e = ParseCron("0 2 * * FOO", "")
+		So(e, ShouldNotBeNil)
+		_, e = ParseCron("0 2 * * SUN#6", "")
+		So(e, ShouldNotBeNil)
+		_, e = ParseCron("0 5-2 * * *", "")
+		So(e, ShouldNotBeNil)
+		_, e = ParseCron("0 2 * * *", "Mars/Olympus")
+		So(e, ShouldNotBeNil)
+
+	})
+
+	Convey("Compute next runs", t, func() {
+
+		paris, _ := time.LoadLocation("Europe/Paris")
+		// Wednesday
+		from := time.Date(2020, 4, 15, 10, 30, 0, 0, paris)
+
+		c, e := ParseCron("0 2 * * MON-FRI", "Europe/Paris")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 4, 16, 2, 0, 0, 0, paris))
+		So(c.Next(time.Date(2020, 4, 17, 2, 0, 0, 0, paris)), ShouldEqual, time.Date(2020, 4, 20, 2, 0, 0, 0, paris))
+
+		c, e = ParseCron("0 2 * * SUN#1", "Europe/Paris")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 5, 3, 2, 0, 0, 0, paris))
+
+		c, e = ParseCron("30 23 L * *", "Europe/Paris")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 4, 30, 23, 30, 0, 0, paris))
+
+		c, e = ParseCron("*/15 9-17 * * *", "Europe/Paris")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 4, 15, 10, 45, 0, 0, paris))
+		So(c.Next(time.Date(2020, 4, 15, 17, 50, 0, 0, paris)), ShouldEqual, time.Date(2020, 4, 16, 9, 0, 0, 0, paris))
+
+		// Both day-of-month and day-of-week restricted: any of them matches
+		c, e = ParseCron("0 0 1 * FRI", "Europe/Paris")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 4, 17, 0, 0, 0, 0, paris))
+
+		c, e = ParseCron("@monthly", "UTC")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC))
+
+		c, e = ParseCron("0 12 29 FEB 7", "UTC")
+		So(e, ShouldBeNil)
+		So(c.Next(from), ShouldEqual, time.Date(2021, 2, 7, 12, 0, 0, 0, time.UTC))
+
+	})
+
+	Convey("Compute next runs across daylight saving time changes", t, func() {
+
+		paris, _ := time.LoadLocation("Europe/Paris")
+		c, e := ParseCron("30 2 * * *", "Europe/Paris")
+		So(e, ShouldBeNil)
+		// 2:30 does not exist on March 29th, 2020
+		next := c.Next(time.Date(2020, 3, 28, 12, 0, 0, 0, paris))
+		So(next, ShouldEqual, time.Date(2020, 3, 30, 2, 30, 0, 0, paris))
+
+		c, e = ParseCron("0 * * * *", "Europe/Paris")
+		So(e, ShouldBeNil)
+		start := time.Date(2020, 10, 25, 0, 30, 0, 0, paris)
+		runs := NewTicker(&TickerSchedule{cron: c}, nil).NextRuns(start, 4)
+		So(runs, ShouldHaveLength, 4)
+		for i := 1; i < len(runs); i++ {
+			So(runs[i].Sub(runs[i-1]), ShouldBeGreaterThan, 0)
+		}
+
+	})
+
+}
+
+func TestNextRuns(t *testing.T) {
+
+	Convey("Preview ISO8601 schedules", t, func() {
+
+		s, e := NewTickerScheduleFromISO("R/2012-06-04T19:25:00Z/PT10M")
+		So(e, ShouldBeNil)
+		runs := s.NextRuns(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+		So(runs, ShouldHaveLength, 3)
+		So(runs[0], ShouldEqual, time.Date(2020, 1, 1, 0, 5, 0, 0, time.UTC))
+		So(runs[2], ShouldEqual, time.Date(2020, 1, 1, 0, 25, 0, 0, time.UTC))
+
+		s, e = NewTickerScheduleFromISO("R2/2012-06-04T19:25:00Z/PT10M")
+		So(e, ShouldBeNil)
+		So(s.NextRuns(time.Date(2012, 6, 4, 19, 0, 0, 0, time.UTC), 5), ShouldHaveLength, 3)
+		So(s.NextRuns(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 5), ShouldBeEmpty)
+
+	})
+
+	Convey("Preview Cron schedules", t, func() {
+
+		s, e := NewTickerScheduleFromCron("0 2 * * MON-FRI", "UTC")
+		So(e, ShouldBeNil)
+		runs := s.NextRuns(time.Date(2020, 4, 17, 12, 0, 0, 0, time.UTC), 2)
+		So(runs, ShouldResemble, []time.Time{
+			time.Date(2020, 4, 20, 2, 0, 0, 0, time.UTC),
+			time.Date(2020, 4, 21, 2, 0, 0, 0, time.UTC),
+		})
+
+		waiter := NewTicker(s, func() error { return nil })
+		wait, stop := waiter.computeNextWait()
+		So(stop, ShouldBeFalse)
+		So(wait, ShouldBeLessThanOrEqualTo, 72*time.Hour)
+
+	})
+
+}
@@ -19,7 +19,7 @@
  */
 
 // Package schedule provides a fixed ticker based on a start time
-// iso8601 interval periods are supported
+// iso8601 interval periods and cron expressions are supported
 package schedule
 
 import (
@@ -45,6 +45,8 @@ type TickerSchedule struct {
 	startTime time.Time
 	// Interval between ticks
 	interval time.Duration
+	// Cron expression, replaces all other parameters if set
+	cron *CronSchedule
 }
 
 // ParseSchedule parses the given Iso 8601 string and stores corresponding values.
@@ -109,6 +111,16 @@ func NewTickerScheduleFromISO(is8601 string) (*TickerSchedule, error) {
 	return s, nil
 }
 
+// NewTickerScheduleFromCron creates a schedule from a cron expression evaluated in the given time zone.
+// It can return an error if the expression or the time zone are invalid.
+func NewTickerScheduleFromCron(expression string, timezone string) (*TickerSchedule, error) {
+	c, err := ParseCron(expression, timezone)
+	if err != nil {
+		return nil, err
+	}
+	return &TickerSchedule{cron: c}, nil
+}
+
 // NewTickerSchedule creates a schedule from parameters
 func NewTickerSchedule(interval time.Duration, startTime time.Time, repeat int64) *TickerSchedule {
 	s := &TickerSchedule{
@@ -185,6 +197,13 @@ func (w *Ticker) WaitUntilNext() {
 func (w *Ticker) computeNextWait() (time.Duration, bool) {
 
 	now := time.Now()
+	if w.cron != nil {
+		next := w.cron.Next(now)
+		if next.IsZero() {
+			return 0, true
+		}
+		return next.Sub(now), false
+	}
 	var wait time.Duration
 	// First let's wait until start time
 	wait = w.startTime.Sub(now)
@@ -208,3 +227,38 @@ func (w *Ticker) computeNextWait() (time.Duration, bool) {
 	return wait, false
 
 }
+
+// NextRuns computes at most count occurrences of this schedule strictly after the given time.
+func (s *TickerSchedule) NextRuns(from time.Time, count int) []time.Time {
+
+	var runs []time.Time
+	if s.cron != nil {
+		t := from
+		for len(runs) < count {
+			if t = s.cron.Next(t); t.IsZero() {
+				break
+			}
+			runs = append(runs, t)
+		}
+		return runs
+	}
+
+	next := s.startTime
+	if !next.After(from) {
+		if s.interval == 0 {
+			return runs
+		}
+		next = next.Add((from.Sub(next)/s.interval + 1) * s.interval)
+	}
+	for len(runs) < count {
+		if !s.endTime.IsZero() && next.After(s.endTime) {
+			break
+		}
+		runs = append(runs, next)
+		if s.interval == 0 {
+			break
+		}
+		next = next.Add(s.interval)
+	}
+	return runs
+}
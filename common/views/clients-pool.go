@@ -70,11 +70,16 @@ type ClientsPool struct {
 	confWatcher   configx.Receiver
 }
 
-// NewSource instantiates a LoadedSource with a minio client
+// NewSource instantiates a LoadedSource with a minio client, or with a remote client if the storage
+// type of the datasource is served by a remote server.
 func NewSource(data *object.DataSource) (LoadedSource, error) {
 	loaded := LoadedSource{}
 	loaded.DataSource = *data
 	var err error
+	if factory, ok := remoteSourceFactory(data.StorageType); ok {
+		loaded.RemoteClient, err = factory(data)
+		return loaded, err
+	}
 	loaded.Client, err = data.CreateClient()
 	return loaded, err
 }
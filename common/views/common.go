@@ -69,6 +69,8 @@ type (
 	LoadedSource struct {
 		object.DataSource
 		Client *minio.Core
+		// RemoteClient is set instead of Client for datasources hosted by a remote server
+		RemoteClient RemoteSourceClient
 	}
 
 	SourcesPool interface {
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// FrozenSourceFilter refuses all writes on datasources whose writes are frozen, e.g. while they are migrated
+// to a new storage. Reads are passed to the next handler.
+type FrozenSourceFilter struct {
+	AbstractHandler
+}
+
+// frozen returns an error if one of the branches identified by the given keys has its writes frozen.
+func (f *FrozenSourceFilter) frozen(ctx context.Context, identifiers ...string) error {
+	for _, id := range identifiers {
+		if info, ok := GetBranchInfo(ctx, id); ok && info.WritesFrozen() {
+			return errors.New("datasource.frozen", "Datasource "+info.Name+" is currently read-only for maintenance, please retry later", 423)
+		}
+	}
+	return nil
+}
+
+func (f *FrozenSourceFilter) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return nil, e
+	}
+	return f.next.CreateNode(ctx, in, opts...)
+}
+
+func (f *FrozenSourceFilter) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	if e := f.frozen(ctx, "from", "to"); e != nil {
+		return nil, e
+	}
+	return f.next.UpdateNode(ctx, in, opts...)
+}
+
+func (f *FrozenSourceFilter) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return nil, e
+	}
+	return f.next.DeleteNode(ctx, in, opts...)
+}
+
+func (f *FrozenSourceFilter) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *PutRequestData) (int64, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return 0, e
+	}
+	return f.next.PutObject(ctx, node, reader, requestData)
+}
+
+func (f *FrozenSourceFilter) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *CopyRequestData) (int64, error) {
+	if e := f.frozen(ctx, "to"); e != nil {
+		return 0, e
+	}
+	return f.next.CopyObject(ctx, from, to, requestData)
+}
+
+func (f *FrozenSourceFilter) MultipartCreate(ctx context.Context, target *tree.Node, requestData *MultipartRequestData) (string, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return "", e
+	}
+	return f.next.MultipartCreate(ctx, target, requestData)
+}
+
+func (f *FrozenSourceFilter) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *PutRequestData) (minio.ObjectPart, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return minio.ObjectPart{}, e
+	}
+	return f.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+}
+
+// MultipartComplete is refused as well: uploads started before the freeze must not complete during it.
+func (f *FrozenSourceFilter) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	if e := f.frozen(ctx, "in"); e != nil {
+		return minio.ObjectInfo{}, e
+	}
+	return f.next.MultipartComplete(ctx, target, uploadID, uploadedParts)
+}
+
+// WrappedCanApply refuses operations modifying a frozen datasource.
+func (f *FrozenSourceFilter) WrappedCanApply(srcCtx context.Context, targetCtx context.Context, operation *tree.NodeChangeEvent) error {
+	switch operation.GetType() {
+	case tree.NodeChangeEvent_CREATE:
+		if e := f.frozen(targetCtx, "in"); e != nil {
+			return e
+		}
+	case tree.NodeChangeEvent_DELETE:
+		if e := f.frozen(srcCtx, "in"); e != nil {
+			return e
+		}
+	case tree.NodeChangeEvent_UPDATE_PATH:
+		if e := f.frozen(srcCtx, "in"); e != nil {
+			return e
+		}
+		if e := f.frozen(targetCtx, "in"); e != nil {
+			return e
+		}
+	}
+	return f.next.WrappedCanApply(srcCtx, targetCtx, operation)
+}
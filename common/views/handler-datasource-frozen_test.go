@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestFrozenSourceFilter(t *testing.T) {
+
+	Convey("Test writes on frozen datasources", t, func() {
+
+		next := NewHandlerMock()
+		handler := &FrozenSourceFilter{}
+		handler.SetNextHandler(next)
+
+		frozenInfo := BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{
+			Name:                 "frozen",
+			StorageConfiguration: map[string]string{object.StorageKeyWritesFrozen: "true"},
+		}}}
+		openInfo := BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{Name: "open"}}}
+		frozenCtx := WithBranchInfo(context.Background(), "in", frozenInfo)
+		openCtx := WithBranchInfo(context.Background(), "in", openInfo)
+
+		Convey("Writes are refused", func() {
+			_, e := handler.PutObject(frozenCtx, &tree.Node{Path: "frozen/file"}, strings.NewReader(""), &PutRequestData{})
+			So(e, ShouldNotBeNil)
+			So(errors.Parse(e.Error()).Code, ShouldEqual, 423)
+			_, e = handler.CreateNode(frozenCtx, &tree.CreateNodeRequest{Node: &tree.Node{Path: "frozen/folder"}})
+			So(e, ShouldNotBeNil)
+			_, e = handler.DeleteNode(frozenCtx, &tree.DeleteNodeRequest{Node: &tree.Node{Path: "frozen/file"}})
+			So(e, ShouldNotBeNil)
+			_, e = handler.MultipartCreate(frozenCtx, &tree.Node{Path: "frozen/file"}, &MultipartRequestData{})
+			So(e, ShouldNotBeNil)
+			_, e = handler.MultipartComplete(frozenCtx, &tree.Node{Path: "frozen/file"}, "upload", nil)
+			So(e, ShouldNotBeNil)
+			So(next.Nodes, ShouldNotContainKey, "in")
+		})
+
+		Convey("Moves and copies are refused if one side is frozen", func() {
+			ctx := WithBranchInfo(context.Background(), "from", openInfo)
+			ctx = WithBranchInfo(ctx, "to", frozenInfo)
+			_, e := handler.UpdateNode(ctx, &tree.UpdateNodeRequest{From: &tree.Node{Path: "open/file"}, To: &tree.Node{Path: "frozen/file"}})
+			So(e, ShouldNotBeNil)
+			_, e = handler.CopyObject(ctx, &tree.Node{Path: "open/file"}, &tree.Node{Path: "frozen/file"}, &CopyRequestData{})
+			So(e, ShouldNotBeNil)
+			e = handler.WrappedCanApply(openCtx, frozenCtx, &tree.NodeChangeEvent{Type: tree.NodeChangeEvent_UPDATE_PATH})
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("Reads and other datasources are passed to next handler", func() {
+			next.Nodes["frozen/file"] = &tree.Node{Path: "frozen/file"}
+			_, e := handler.GetObject(frozenCtx, &tree.Node{Path: "frozen/file"}, &GetRequestData{Length: -1})
+			So(e, ShouldBeNil)
+			_, e = handler.PutObject(openCtx, &tree.Node{Path: "open/file"}, strings.NewReader(""), &PutRequestData{})
+			So(e, ShouldBeNil)
+			So(next.Nodes["in"].Path, ShouldEqual, "open/file")
+		})
+	})
+}
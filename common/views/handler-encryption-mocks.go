@@ -152,6 +152,11 @@ func NewMockNodeKeyManagerClient() encryption.NodeKeyManagerClient {
 func (m *mockNodeKeyManagerClient) GetNodeInfo(ctx context.Context, in *encryption.GetNodeInfoRequest, opts ...client.CallOption) (*encryption.GetNodeInfoResponse, error) {
 
 	nodeKey, entryFound := m.keys[in.NodeId]
+	if shared, ok := m.keys[fmt.Sprintf("%s:%s", in.UserId, in.NodeId)]; ok {
+		nodeKey, entryFound = shared, true
+	} else if entryFound && in.UserId != "" && nodeKey.UserId != "" && nodeKey.UserId != in.UserId {
+		return nil, errors.Forbidden("mock.NodeKeyManager", "Key not shared with user")
+	}
 	if !entryFound {
 		return nil, errors.NotFound("mock.NodeKeyManager", "Key not found")
 	}
@@ -277,6 +282,11 @@ func (m *mockNodeKeyManagerClient) DeleteNodeSharedKey(ctx context.Context, in *
 	return &encryption.DeleteNodeSharedKeyResponse{}, nil
 }
 
+func (m *mockNodeKeyManagerClient) SetNodeKey(ctx context.Context, in *encryption.SetNodeKeyRequest, opts ...client.CallOption) (*encryption.SetNodeKeyResponse, error) {
+	m.keys[fmt.Sprintf("%s:%s", in.NodeKey.UserId, in.NodeKey.NodeId)] = in.NodeKey
+	return &encryption.SetNodeKeyResponse{}, nil
+}
+
 // mockUserKeyTool
 type mockUserKeyTool struct {
 	key []byte
@@ -26,6 +26,8 @@ import (
 	"io"
 	"strings"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/micro/go-micro/errors"
 	"github.com/pborman/uuid"
 	"go.uber.org/zap"
@@ -35,8 +37,11 @@ import (
 	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/object"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/cells/idm/key"
 	"github.com/pydio/minio-go"
 )
@@ -46,6 +51,8 @@ type EncryptionHandler struct {
 	AbstractHandler
 	userKeyTool          key.UserKeyTool
 	nodeKeyManagerClient encryption.NodeKeyManagerClient
+	// sharedNodeUsers finds the users a node is shared with, defaults to the users of the read ACLs on the node and its parents
+	sharedNodeUsers func(ctx context.Context, node *tree.Node, owner string) []string
 }
 
 func (e *EncryptionHandler) SetUserKeyTool(keyTool key.UserKeyTool) {
@@ -63,7 +70,7 @@ func (e *EncryptionHandler) GetObject(ctx context.Context, node *tree.Node, requ
 	}
 
 	branchInfo, ok := GetBranchInfo(ctx, "in")
-	if !ok || branchInfo.EncryptionMode != object.EncryptionMode_MASTER {
+	if !ok || !isEncrypted(branchInfo.EncryptionMode) {
 		return e.next.GetObject(ctx, node, requestData)
 	}
 
@@ -96,7 +103,13 @@ func (e *EncryptionHandler) GetObject(ctx context.Context, node *tree.Node, requ
 		clone.SetMeta(common.MetaNamespaceDatasourceName, dsName)
 	}
 
-	info, offset, length, skipBytesCount, err := e.getNodeInfoForRead(ctx, clone, requestData)
+	keyProtectionTool, keyID, keyUser, err := e.getKeyProtection(ctx, branchInfo, dsName)
+	if err != nil {
+		log.Logger(ctx).Error("views.handler.encryption.GetObject: failed to load key tool", zap.Error(err))
+		return nil, err
+	}
+
+	info, offset, length, skipBytesCount, err := e.getNodeInfoForRead(ctx, clone, keyUser, requestData)
 	if err != nil {
 		log.Logger(ctx).Error("views.handler.encryption.GetObject: failed to get node info", zap.Error(err))
 		return nil, err
@@ -106,13 +119,7 @@ func (e *EncryptionHandler) GetObject(ctx context.Context, node *tree.Node, requ
 		length = -1
 	}
 
-	keyProtectionTool, err := e.getKeyProtectionTool(ctx)
-	if err != nil {
-		log.Logger(ctx).Error("views.handler.encryption.GetObject: failed to load key tool", zap.Error(err))
-		return nil, err
-	}
-
-	plainKey, err := keyProtectionTool.GetDecrypted(ctx, branchInfo.EncryptionKey, info.NodeKey.KeyData)
+	plainKey, err := keyProtectionTool.GetDecrypted(ctx, keyID, info.NodeKey.KeyData)
 	if err != nil {
 		log.Logger(ctx).Error("views.handler.encryption.GetObject: failed to decrypt materials key", zap.String("user", dsName), zap.Error(err))
 		return nil, err
@@ -173,7 +180,7 @@ func (e *EncryptionHandler) PutObject(ctx context.Context, node *tree.Node, read
 
 	branchInfo, ok := GetBranchInfo(ctx, "in")
 	var err error
-	if !ok || branchInfo.EncryptionMode != object.EncryptionMode_MASTER {
+	if !ok || !isEncrypted(branchInfo.EncryptionMode) {
 		return e.next.PutObject(ctx, node, reader, requestData)
 	}
 
@@ -198,7 +205,7 @@ func (e *EncryptionHandler) PutObject(ctx context.Context, node *tree.Node, read
 		_ = clone.SetMeta(common.MetaNamespaceDatasourceName, branchInfo.Name)
 	}
 
-	keyProtectionTool, err := e.getKeyProtectionTool(ctx)
+	keyProtectionTool, keyID, keyUser, err := e.getKeyProtection(ctx, branchInfo, clone.GetStringMeta(common.MetaNamespaceDatasourceName))
 	if err != nil {
 		return 0, err
 	}
@@ -216,21 +223,24 @@ func (e *EncryptionHandler) PutObject(ctx context.Context, node *tree.Node, read
 	}
 
 	var encryptionKeyPlainBytes []byte
+	var created bool
 
-	info, err := e.getNodeInfoForWrite(ctx, clone)
+	info, err := e.getNodeInfoForWrite(ctx, clone, keyUser)
 	if err != nil {
+		// Only create a key for unknown nodes: the key service answers 403 when the node is already
+		// encrypted but its key was not shared with this user, and the node must not be re-keyed.
 		if errors.Parse(err.Error()).Code != 404 {
 			return 0, err
 		}
 
-		info, err = e.createNodeInfo(ctx, clone)
+		info, err = e.createNodeInfo(ctx, clone, keyUser)
 		if err != nil {
 			log.Logger(ctx).Error("views.handler.encryption.PutObject: failed to create node info", zap.Error(err))
 			return 0, err
 		}
 
 		encryptionKeyPlainBytes = info.NodeKey.KeyData
-		info.NodeKey.KeyData, err = keyProtectionTool.GetEncrypted(ctx, branchInfo.EncryptionKey, info.NodeKey.KeyData)
+		info.NodeKey.KeyData, err = keyProtectionTool.GetEncrypted(ctx, keyID, info.NodeKey.KeyData)
 		if err != nil {
 			log.Logger(ctx).Error("views.handler.encryption.PutObject: failed to encrypt node key", zap.Error(err))
 			return 0, err
@@ -241,9 +251,10 @@ func (e *EncryptionHandler) PutObject(ctx context.Context, node *tree.Node, read
 			log.Logger(ctx).Error("views.handler.encryption.PutObject: failed to set nodeKey", zap.Error(err))
 			return 0, err
 		}
+		created = true
 
 	} else {
-		encryptionKeyPlainBytes, err = keyProtectionTool.GetDecrypted(ctx, branchInfo.EncryptionKey, info.NodeKey.KeyData)
+		encryptionKeyPlainBytes, err = keyProtectionTool.GetDecrypted(ctx, keyID, info.NodeKey.KeyData)
 		if err != nil {
 			log.Logger(ctx).Error("views.handler.encryption.PutObject: failed to decrypt key", zap.Error(err))
 			return 0, err
@@ -277,6 +288,9 @@ func (e *EncryptionHandler) PutObject(ctx context.Context, node *tree.Node, read
 	requestData.Size = encryptionMaterials.CalculateOutputSize(requestData.Size, info.NodeKey.OwnerId)
 
 	n, err := e.next.PutObject(ctx, node, encryptionMaterials, requestData)
+	if err == nil && created {
+		e.shareNodeKey(ctx, branchInfo, clone, encryptionKeyPlainBytes, keyID, keyUser)
+	}
 	return n, err
 }
 
@@ -290,7 +304,7 @@ func (e *EncryptionHandler) CopyObject(ctx context.Context, from *tree.Node, to
 	readCtx := WithBranchInfo(ctx, "in", srcInfo, true)
 	writeCtx := WithBranchInfo(ctx, "in", destInfo, true)
 	// Ds are not encrypted, let if flow
-	if srcInfo.EncryptionMode != object.EncryptionMode_MASTER && destInfo.EncryptionMode != object.EncryptionMode_MASTER {
+	if !isEncrypted(srcInfo.EncryptionMode) && !isEncrypted(destInfo.EncryptionMode) {
 		return e.next.CopyObject(ctx, from, to, requestData)
 	}
 	// Move
@@ -380,7 +394,7 @@ func (e *EncryptionHandler) CopyObject(ctx context.Context, from *tree.Node, to
 func (e *EncryptionHandler) MultipartCreate(ctx context.Context, target *tree.Node, requestData *MultipartRequestData) (string, error) {
 	var err error
 	branchInfo, ok := GetBranchInfo(ctx, "in")
-	if !ok || branchInfo.EncryptionMode != object.EncryptionMode_MASTER {
+	if !ok || !isEncrypted(branchInfo.EncryptionMode) {
 		if _, ok := requestData.Metadata[common.XAmzMetaClearSize]; ok {
 			// Not necessary for non-encrypted data source
 			delete(requestData.Metadata, common.XAmzMetaClearSize)
@@ -414,7 +428,7 @@ func (e *EncryptionHandler) MultipartCreate(ctx context.Context, target *tree.No
 		_ = clone.SetMeta(common.MetaNamespaceDatasourceName, branchInfo.Name)
 	}
 
-	keyProtectionTool, err := e.getKeyProtectionTool(ctx)
+	keyProtectionTool, keyID, keyUser, err := e.getKeyProtection(ctx, branchInfo, clone.GetStringMeta(common.MetaNamespaceDatasourceName))
 	if err != nil {
 		return "", err
 	}
@@ -432,20 +446,22 @@ func (e *EncryptionHandler) MultipartCreate(ctx context.Context, target *tree.No
 		ctx:      ctx,
 	}
 
-	info, err := e.getNodeInfoForWrite(ctx, clone)
+	var encryptionKeyPlainBytes []byte
+	info, err := e.getNodeInfoForWrite(ctx, clone, keyUser)
 	if err != nil {
+		// Only create a key for unknown nodes, see PutObject
 		if errors.Parse(err.Error()).Code != 404 {
 			return "", err
 		}
 
-		info, err = e.createNodeInfo(ctx, clone)
+		info, err = e.createNodeInfo(ctx, clone, keyUser)
 		if err != nil {
 			log.Logger(ctx).Error("views.handler.encryption.MultiPartCreate: failed to create node info", zap.Error(err))
 			return "", err
 		}
 
-		encryptionKeyPlainBytes := info.NodeKey.KeyData
-		info.NodeKey.KeyData, err = keyProtectionTool.GetEncrypted(ctx, branchInfo.EncryptionKey, encryptionKeyPlainBytes)
+		encryptionKeyPlainBytes = info.NodeKey.KeyData
+		info.NodeKey.KeyData, err = keyProtectionTool.GetEncrypted(ctx, keyID, encryptionKeyPlainBytes)
 		if err != nil {
 			log.Logger(ctx).Error("views.handler.encryption.MultiPartCreate: failed to encrypt node key", zap.Error(err))
 			return "", err
@@ -467,6 +483,8 @@ func (e *EncryptionHandler) MultipartCreate(ctx context.Context, target *tree.No
 
 	if err := streamer.Close(); err != nil {
 		log.Logger(ctx).Error("views.handler.encryption.MultiPartCreate: failed to close setNodeInfo stream", zap.Error(err))
+	} else if encryptionKeyPlainBytes != nil {
+		e.shareNodeKey(ctx, branchInfo, clone, encryptionKeyPlainBytes, keyID, keyUser)
 	}
 	return e.next.MultipartCreate(ctx, target, requestData)
 }
@@ -474,7 +492,7 @@ func (e *EncryptionHandler) MultipartCreate(ctx context.Context, target *tree.No
 func (e *EncryptionHandler) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *PutRequestData) (minio.ObjectPart, error) {
 	var err error
 	branchInfo, ok := GetBranchInfo(ctx, "in")
-	if !ok || branchInfo.EncryptionMode != object.EncryptionMode_MASTER {
+	if !ok || !isEncrypted(branchInfo.EncryptionMode) {
 		return e.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
 	}
 
@@ -499,7 +517,7 @@ func (e *EncryptionHandler) MultipartPutObjectPart(ctx context.Context, target *
 		_ = clone.SetMeta(common.MetaNamespaceDatasourceName, branchInfo.Name)
 	}
 
-	keyProtectionTool, err := e.getKeyProtectionTool(ctx)
+	keyProtectionTool, keyID, keyUser, err := e.getKeyProtection(ctx, branchInfo, clone.GetStringMeta(common.MetaNamespaceDatasourceName))
 	if err != nil {
 		return minio.ObjectPart{}, err
 	}
@@ -517,14 +535,14 @@ func (e *EncryptionHandler) MultipartPutObjectPart(ctx context.Context, target *
 		partId:   uint32(partNumberMarker),
 		ctx:      ctx,
 	}
-	info, err := e.getNodeInfoForWrite(ctx, clone)
+	info, err := e.getNodeInfoForWrite(ctx, clone, keyUser)
 	if err != nil {
 		log.Logger(ctx).Error("views.handler.encryption.MultiPartPutObject: failed to get node info", zap.Error(err))
 		return minio.ObjectPart{}, err
 	}
 
 	var encryptionKeyPlainBytes []byte
-	encryptionKeyPlainBytes, err = keyProtectionTool.GetDecrypted(ctx, branchInfo.EncryptionKey, info.NodeKey.KeyData)
+	encryptionKeyPlainBytes, err = keyProtectionTool.GetDecrypted(ctx, keyID, info.NodeKey.KeyData)
 	if err != nil {
 		log.Logger(ctx).Error("views.handler.encryption.MultiPartPutObject: failed to unseal key", zap.Error(err))
 		return minio.ObjectPart{}, err
@@ -562,16 +580,15 @@ func (e *EncryptionHandler) copyNodeEncryptionData(ctx context.Context, source *
 	return err
 }
 
-func (e *EncryptionHandler) getNodeInfoForRead(ctx context.Context, node *tree.Node, requestData *GetRequestData) (*encryption.NodeInfo, int64, int64, int64, error) {
+func (e *EncryptionHandler) getNodeInfoForRead(ctx context.Context, node *tree.Node, keyUser string, requestData *GetRequestData) (*encryption.NodeInfo, int64, int64, int64, error) {
 	nodeEncryptionClient := e.nodeKeyManagerClient
 	if nodeEncryptionClient == nil {
 		nodeEncryptionClient = encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, defaults.NewClient())
 	}
 
 	fullRead := requestData.StartOffset == 0 && (requestData.Length <= 0 || requestData.Length == node.Size)
-	dsName := node.GetStringMeta(common.MetaNamespaceDatasourceName)
 	rsp, err := nodeEncryptionClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{
-		UserId:      fmt.Sprintf("ds:%s", dsName),
+		UserId:      keyUser,
 		NodeId:      node.Uuid,
 		WithRange:   !fullRead,
 		PlainOffset: requestData.StartOffset,
@@ -583,15 +600,14 @@ func (e *EncryptionHandler) getNodeInfoForRead(ctx context.Context, node *tree.N
 	return rsp.NodeInfo, int64(rsp.EncryptedOffset), int64(rsp.EncryptedCount), rsp.HeadSKippedPlainBytesCount, nil
 }
 
-func (e *EncryptionHandler) getNodeInfoForWrite(ctx context.Context, node *tree.Node) (*encryption.NodeInfo, error) {
+func (e *EncryptionHandler) getNodeInfoForWrite(ctx context.Context, node *tree.Node, keyUser string) (*encryption.NodeInfo, error) {
 	nodeEncryptionClient := e.nodeKeyManagerClient
 	if nodeEncryptionClient == nil {
 		nodeEncryptionClient = encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, defaults.NewClient())
 	}
 
-	dsName := node.GetStringMeta(common.MetaNamespaceDatasourceName)
 	rsp, err := nodeEncryptionClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{
-		UserId:    fmt.Sprintf("ds:%s", dsName),
+		UserId:    keyUser,
 		NodeId:    node.Uuid,
 		WithRange: false,
 	})
@@ -609,16 +625,14 @@ func (e *EncryptionHandler) getNodeInfo(ctx context.Context, request *encryption
 	return nodeEncryptionClient.GetNodeInfo(ctx, request)
 }
 
-func (e *EncryptionHandler) createNodeInfo(ctx context.Context, node *tree.Node) (*encryption.NodeInfo, error) {
-	dsName := node.GetStringMeta(common.MetaNamespaceDatasourceName)
-	user := fmt.Sprintf("ds:%s", dsName)
+func (e *EncryptionHandler) createNodeInfo(ctx context.Context, node *tree.Node, keyUser string) (*encryption.NodeInfo, error) {
 	info := new(encryption.NodeInfo)
 
 	//we generate a new key
 	info.NodeKey = &encryption.NodeKey{
-		UserId:  user,
+		UserId:  keyUser,
 		NodeId:  node.Uuid,
-		OwnerId: user,
+		OwnerId: keyUser,
 	}
 
 	encKey, err := crypto.RandomBytes(32)
@@ -646,6 +660,118 @@ func (e *EncryptionHandler) getKeyProtectionTool(ctx context.Context) (key.UserK
 	return tool, err
 }
 
+// getKeyProtection finds the tool protecting node keys depending on the datasource encryption mode, along with
+// the ID of the key used by this tool and the user under which node keys are stored.
+// In MASTER mode, node keys are protected by the datasource key and stored for the datasource. In USER and
+// USER_PWD modes, they are protected by the keyring of the current user and stored for this user.
+func (e *EncryptionHandler) getKeyProtection(ctx context.Context, branchInfo BranchInfo, dsName string) (tool key.UserKeyTool, keyID string, keyUser string, err error) {
+	if branchInfo.EncryptionMode == object.EncryptionMode_MASTER {
+		if tool, err = e.getKeyProtectionTool(ctx); err != nil {
+			return
+		}
+		// Master key may be in the middle of a rotation
+		return key.WithKeyRotation(tool, dsName), branchInfo.EncryptionKey, fmt.Sprintf("ds:%s", dsName), nil
+	}
+
+	keyUser, _ = permissions.FindUserNameInContext(ctx)
+	if keyUser == "" || keyUser == common.PydioSystemUsername {
+		return nil, "", "", errors.Forbidden("views.handler.encryption", "datasource %s is encrypted with user keys and requires a user", dsName)
+	}
+	keyID = key.UserKeyringID
+	if branchInfo.EncryptionMode == object.EncryptionMode_USER_PWD {
+		keyID = key.UserPwdKeyringID
+	}
+	tool = e.userKeyTool
+	if tool == nil {
+		tool = key.GetUserKeyTool(keyUser, nil)
+	}
+	return
+}
+
+// shareNodeKey gives access to a new file to the users its parent folder is shared with, in USER and USER_PWD modes:
+// the plain node key is wrapped with the keyring of each of these users. Otherwise, they could only read the files
+// that existed when the folder was shared. Users whose keyring does not exist yet are ignored, as done by shares.
+func (e *EncryptionHandler) shareNodeKey(ctx context.Context, branchInfo BranchInfo, node *tree.Node, plainKey []byte, keyID string, owner string) {
+	if branchInfo.EncryptionMode != object.EncryptionMode_USER && branchInfo.EncryptionMode != object.EncryptionMode_USER_PWD {
+		return
+	}
+	finder := e.sharedNodeUsers
+	if finder == nil {
+		finder = e.findSharedNodeUsers
+	}
+	for _, recipient := range finder(ctx, node, owner) {
+		tool := e.userKeyTool
+		if tool == nil {
+			tool = key.GetUserKeyTool(recipient, nil)
+		}
+		wrapped, err := tool.GetEncrypted(ctx, keyID, plainKey)
+		if err != nil {
+			log.Logger(ctx).Warn("views.handler.encryption: cannot wrap node key for shared user", zap.String("user", recipient), node.ZapPath(), zap.Error(err))
+			continue
+		}
+		if _, err := e.getNodeKeyManagerClient().SetNodeKey(ctx, &encryption.SetNodeKeyRequest{NodeKey: &encryption.NodeKey{
+			NodeId:  node.Uuid,
+			UserId:  recipient,
+			OwnerId: owner,
+			KeyData: wrapped,
+		}}); err != nil {
+			log.Logger(ctx).Error("views.handler.encryption: failed to share node key", zap.String("user", recipient), node.ZapPath(), zap.Error(err))
+		}
+	}
+}
+
+// findSharedNodeUsers finds the logins of the users, other than the owner, having a read ACL on the node or on one
+// of its parents. Group and role ACLs are resolved to their users, like when sharing the node keys of a folder.
+func (e *EncryptionHandler) findSharedNodeUsers(ctx context.Context, node *tree.Node, owner string) []string {
+	_, ancestors, err := AncestorsListFromContext(ctx, node, "in", e.clientsPool, false)
+	if err != nil {
+		log.Logger(ctx).Error("views.handler.encryption: cannot load node parents to share its key", node.ZapPath(), zap.Error(err))
+		return nil
+	}
+	var nodeIDs []string
+	for _, a := range ancestors {
+		nodeIDs = append(nodeIDs, a.Uuid)
+	}
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{NodeIDs: nodeIDs, Actions: []*idm.ACLAction{permissions.AclRead}})
+	aclClient := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	stream, err := aclClient.SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if err != nil {
+		log.Logger(ctx).Error("views.handler.encryption: cannot load node ACLs to share its key", node.ZapPath(), zap.Error(err))
+		return nil
+	}
+	defer stream.Close()
+	roles := make(map[string]struct{})
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if acl := resp.GetACL(); acl.GetWorkspaceID() != "" && acl.GetRoleID() != "" {
+			roles[acl.GetRoleID()] = struct{}{}
+		}
+	}
+	var logins []string
+	seen := map[string]struct{}{owner: {}}
+	for roleID := range roles {
+		// Resolve user, group and generic roles to the users they are granted to
+		users, er := permissions.SearchUsersForRole(ctx, roleID)
+		if er != nil {
+			log.Logger(ctx).Warn("views.handler.encryption: cannot resolve users for role "+roleID, zap.Error(er))
+			continue
+		}
+		for _, u := range users {
+			if _, ok := seen[u.Login]; !ok {
+				seen[u.Login] = struct{}{}
+				logins = append(logins, u.Login)
+			}
+		}
+	}
+	return logins
+}
+
 func (e *EncryptionHandler) getNodeKeyManagerClient() encryption.NodeKeyManagerClient {
 	nodeEncryptionClient := e.nodeKeyManagerClient
 	if nodeEncryptionClient == nil {
@@ -654,6 +780,10 @@ func (e *EncryptionHandler) getNodeKeyManagerClient() encryption.NodeKeyManagerC
 	return nodeEncryptionClient
 }
 
+func isEncrypted(mode object.EncryptionMode) bool {
+	return mode == object.EncryptionMode_MASTER || mode == object.EncryptionMode_USER || mode == object.EncryptionMode_USER_PWD
+}
+
 // setBlockStream
 type setBlockStream struct {
 	client   encryption.NodeKeyManager_SetNodeInfoClient
@@ -28,6 +28,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/micro/go-micro/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/pydio/cells/common"
@@ -326,3 +327,63 @@ func TestRangeEncryptionHandler_Encrypted(t *testing.T) {
 		}
 	})
 }
+
+func TestEncryptionHandler_SharedNodeKeys(t *testing.T) {
+
+	keyClient := NewMockNodeKeyManagerClient()
+	handler := &EncryptionHandler{
+		sharedNodeUsers: func(ctx context.Context, node *tree.Node, owner string) []string {
+			return []string{"bob"}
+		},
+	}
+	handler.SetNextHandler(NewHandlerMock())
+	handler.SetUserKeyTool(NewMockUserKeyTool())
+	handler.SetNodeKeyManagerClient(keyClient)
+	keys := keyClient.(*mockNodeKeyManagerClient).keys
+
+	ctx := context.WithValue(context.Background(), common.PydioContextUserKey, "alice")
+	branchInfo := BranchInfo{}
+	branchInfo.Name = "test"
+	branchInfo.EncryptionMode = object.EncryptionMode_USER
+
+	Convey("Keys of files created in a shared folder are shared", t, func() {
+		node := &tree.Node{Path: "test/shared/file", Uuid: "shared-file"}
+		_, e := handler.PutObject(WithBranchInfo(ctx, "in", branchInfo), node, strings.NewReader("data"), &PutRequestData{Size: 4})
+		So(e, ShouldBeNil)
+		So(keys, ShouldContainKey, "bob:shared-file")
+		So(keys["bob:shared-file"].OwnerId, ShouldEqual, "alice")
+
+		// Updates reuse the existing keys
+		delete(keys, "bob:shared-file")
+		_, e = handler.PutObject(WithBranchInfo(ctx, "in", branchInfo), node, strings.NewReader("data"), &PutRequestData{Size: 4})
+		So(e, ShouldBeNil)
+		So(keys, ShouldNotContainKey, "bob:shared-file")
+	})
+
+	Convey("Existing nodes are not re-keyed by users without their key", t, func() {
+		node := &tree.Node{Path: "test/shared/private", Uuid: "private-file"}
+		handler.sharedNodeUsers = func(ctx context.Context, node *tree.Node, owner string) []string { return nil }
+		_, e := handler.PutObject(WithBranchInfo(ctx, "in", branchInfo), node, strings.NewReader("data"), &PutRequestData{Size: 4})
+		So(e, ShouldBeNil)
+		ownerKey := keys["private-file"].KeyData
+
+		bobCtx := context.WithValue(context.Background(), common.PydioContextUserKey, "bob")
+		_, e = handler.PutObject(WithBranchInfo(bobCtx, "in", branchInfo), node, strings.NewReader("data"), &PutRequestData{Size: 4})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+		So(keys["private-file"].KeyData, ShouldResemble, ownerKey)
+		So(keys["private-file"].UserId, ShouldEqual, "alice")
+
+		_, e = handler.MultipartCreate(WithBranchInfo(bobCtx, "in", branchInfo), node, &MultipartRequestData{Metadata: map[string]string{}})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+	})
+
+	Convey("Keys are not shared in MASTER mode", t, func() {
+		branchInfo.EncryptionMode = object.EncryptionMode_MASTER
+		node := &tree.Node{Path: "test/shared/master", Uuid: "master-file"}
+		_, e := handler.PutObject(WithBranchInfo(ctx, "in", branchInfo), node, strings.NewReader("data"), &PutRequestData{Size: 4})
+		So(e, ShouldBeNil)
+		So(keys, ShouldNotContainKey, "bob:master-file")
+	})
+}
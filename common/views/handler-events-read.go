@@ -23,6 +23,7 @@ package views
 import (
 	"context"
 	"io"
+	"time"
 
 	json "github.com/pydio/cells/x/jsonx"
 
@@ -89,9 +90,13 @@ func (h *HandlerEventRead) GetObject(ctx context.Context, node *tree.Node, reque
 		linkData *docstore.ShareDocument
 	)
 
-	if doc, linkData = h.sharedLinkWithDownloadLimit(ctx); doc != nil && linkData != nil {
+	if doc, linkData = h.sharedLinkWithRestrictions(ctx); doc != nil && linkData != nil {
+		// Check start date
+		if linkData.StartTime > 0 && time.Now().Before(time.Unix(linkData.StartTime, 0)) {
+			return nil, errors.Forbidden("LinkNotActive", "This link is not active yet")
+		}
 		// Check download limit!
-		if linkData.DownloadCount >= linkData.DownloadLimit {
+		if linkData.DownloadLimit > 0 && linkData.DownloadCount >= linkData.DownloadLimit {
 			return nil, errors.Forbidden("MaxDownloadsReached", "You are not allowed to download this document")
 		}
 	}
@@ -116,7 +121,7 @@ func (h *HandlerEventRead) GetObject(ctx context.Context, node *tree.Node, reque
 				}))
 			}()
 		}
-		if doc != nil && linkData != nil {
+		if doc != nil && linkData != nil && linkData.DownloadLimit > 0 {
 			go func() {
 				bgContext := context.Background()
 				linkData.DownloadCount++
@@ -137,7 +142,8 @@ func (h *HandlerEventRead) GetObject(ctx context.Context, node *tree.Node, reque
 
 }
 
-func (h *HandlerEventRead) sharedLinkWithDownloadLimit(ctx context.Context) (doc *docstore.Document, linkData *docstore.ShareDocument) {
+// sharedLinkWithRestrictions loads the link data for hidden users, if it has a download limit or a start date.
+func (h *HandlerEventRead) sharedLinkWithRestrictions(ctx context.Context) (doc *docstore.Document, linkData *docstore.ShareDocument) {
 
 	userLogin, claims := permissions.FindUserNameInContext(ctx)
 	// TODO - Have the 'hidden' info directly in claims => could it be a profile instead ?
@@ -190,7 +196,7 @@ func (h *HandlerEventRead) sharedLinkWithDownloadLimit(ctx context.Context) (doc
 
 	if doc != nil {
 		var data *docstore.ShareDocument
-		if e2 := json.Unmarshal([]byte(doc.Data), &data); e2 == nil && data.DownloadLimit > 0 {
+		if e2 := json.Unmarshal([]byte(doc.Data), &data); e2 == nil && (data.DownloadLimit > 0 || data.StartTime > 0) {
 			linkData = data
 		}
 	}
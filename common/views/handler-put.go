@@ -157,7 +157,8 @@ func (m *PutHandler) CreateParent(ctx context.Context, node *tree.Node) error {
 func (m *PutHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *PutRequestData) (int64, error) {
 	log.Logger(ctx).Debug("[HANDLER PUT] > Putting object", zap.String("UUID", node.Uuid), zap.String("Path", node.Path))
 
-	if branchInfo, ok := GetBranchInfo(ctx, "in"); ok && branchInfo.Binary {
+	if branchInfo, ok := GetBranchInfo(ctx, "in"); ok && (branchInfo.Binary || branchInfo.RemoteClient != nil) {
+		// Remote datasources are indexed by their own server
 		return m.next.PutObject(ctx, node, reader, requestData)
 	}
 
@@ -221,6 +222,10 @@ func (m *PutHandler) MultipartCreate(ctx context.Context, node *tree.Node, reque
 		return m.next.MultipartCreate(ctx, node, requestData)
 	}
 
+	if branchInfo, ok := GetBranchInfo(ctx, "in"); ok && branchInfo.RemoteClient != nil {
+		return m.next.MultipartCreate(ctx, node, requestData)
+	}
+
 	if requestData.Metadata == nil {
 		requestData.Metadata = make(map[string]string)
 	}
@@ -0,0 +1,301 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"github.com/pydio/minio-go"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// RemoteSourceClient gives access to a datasource whose data is hosted by another server, like a remote Cells.
+// All paths are relative to the root of the datasource.
+type RemoteSourceClient interface {
+	tree.NodeProviderClient
+	tree.NodeReceiverClient
+	GetObject(ctx context.Context, node *tree.Node, requestData *GetRequestData) (io.ReadCloser, error)
+	PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *PutRequestData) (int64, error)
+	CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *CopyRequestData) (int64, error)
+	MultipartCreate(ctx context.Context, target *tree.Node, requestData *MultipartRequestData) (string, error)
+	MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *PutRequestData) (minio.ObjectPart, error)
+	MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error)
+	MultipartList(ctx context.Context, prefix string, requestData *MultipartRequestData) (minio.ListMultipartUploadsResult, error)
+	MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *MultipartRequestData) error
+	MultipartListObjectParts(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, maxParts int) (minio.ListObjectPartsResult, error)
+}
+
+// RemoteSourceFactory creates a RemoteSourceClient for a given datasource.
+type RemoteSourceFactory func(ds *object.DataSource) (RemoteSourceClient, error)
+
+var (
+	remoteSourceFactories = make(map[object.StorageType]RemoteSourceFactory)
+	remoteSourceLock      = &sync.RWMutex{}
+)
+
+// RegisterRemoteSourceFactory declares a storage type whose datasources are proxied to a remote server
+// instead of being accessed through the objects service.
+func RegisterRemoteSourceFactory(storageType object.StorageType, factory RemoteSourceFactory) {
+	remoteSourceLock.Lock()
+	defer remoteSourceLock.Unlock()
+	remoteSourceFactories[storageType] = factory
+}
+
+func remoteSourceFactory(storageType object.StorageType) (RemoteSourceFactory, bool) {
+	remoteSourceLock.RLock()
+	defer remoteSourceLock.RUnlock()
+	f, ok := remoteSourceFactories[storageType]
+	return f, ok
+}
+
+// RemoteSourceHandler forwards all requests targeting a remote datasource to its RemoteSourceClient.
+// Requests on other datasources are passed to the next handler.
+type RemoteSourceHandler struct {
+	AbstractHandler
+}
+
+func (r *RemoteSourceHandler) remote(ctx context.Context, identifier string) (BranchInfo, bool) {
+	info, ok := GetBranchInfo(ctx, identifier)
+	return info, ok && info.RemoteClient != nil
+}
+
+func (r *RemoteSourceHandler) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.ReadNode(ctx, in, opts...)
+	}
+	req := proto.Clone(in).(*tree.ReadNodeRequest)
+	req.Node = toRemoteNode(in.Node)
+	resp, e := info.RemoteClient.ReadNode(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	resp.Node = fromRemoteNode(info, resp.Node)
+	return resp, nil
+}
+
+func (r *RemoteSourceHandler) ListNodes(ctx context.Context, in *tree.ListNodesRequest, opts ...client.CallOption) (tree.NodeProvider_ListNodesClient, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.ListNodes(ctx, in, opts...)
+	}
+	req := proto.Clone(in).(*tree.ListNodesRequest)
+	req.Node = toRemoteNode(in.Node)
+	stream, e := info.RemoteClient.ListNodes(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	s := NewWrappingStreamer()
+	go func() {
+		defer stream.Close()
+		defer s.Close()
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && err != io.ErrUnexpectedEOF {
+					s.SendError(err)
+				}
+				break
+			}
+			if resp == nil || resp.Node == nil {
+				continue
+			}
+			resp.Node = fromRemoteNode(info, resp.Node)
+			s.Send(resp)
+		}
+	}()
+	return s, nil
+}
+
+func (r *RemoteSourceHandler) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.CreateNode(ctx, in, opts...)
+	}
+	req := proto.Clone(in).(*tree.CreateNodeRequest)
+	req.Node = toRemoteNode(in.Node)
+	resp, e := info.RemoteClient.CreateNode(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	resp.Node = fromRemoteNode(info, resp.Node)
+	return resp, nil
+}
+
+func (r *RemoteSourceHandler) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	fromInfo, fromRemote := r.remote(ctx, "from")
+	toInfo, toRemote := r.remote(ctx, "to")
+	if !fromRemote && !toRemote {
+		return r.next.UpdateNode(ctx, in, opts...)
+	}
+	if !fromRemote || !toRemote || fromInfo.Name != toInfo.Name {
+		return nil, errors.BadRequest(VIEWS_LIBRARY_NAME, "nodes cannot be moved between a remote datasource and another datasource")
+	}
+	req := proto.Clone(in).(*tree.UpdateNodeRequest)
+	req.From = toRemoteNode(in.From)
+	req.To = toRemoteNode(in.To)
+	resp, e := toInfo.RemoteClient.UpdateNode(ctx, req, opts...)
+	if e != nil {
+		return nil, e
+	}
+	if resp.Node != nil {
+		resp.Node = fromRemoteNode(toInfo, resp.Node)
+	}
+	return resp, nil
+}
+
+func (r *RemoteSourceHandler) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.DeleteNode(ctx, in, opts...)
+	}
+	req := proto.Clone(in).(*tree.DeleteNodeRequest)
+	req.Node = toRemoteNode(in.Node)
+	return info.RemoteClient.DeleteNode(ctx, req, opts...)
+}
+
+func (r *RemoteSourceHandler) GetObject(ctx context.Context, node *tree.Node, requestData *GetRequestData) (io.ReadCloser, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.GetObject(ctx, node, requestData)
+	}
+	return info.RemoteClient.GetObject(ctx, toRemoteNode(node), requestData)
+}
+
+func (r *RemoteSourceHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *PutRequestData) (int64, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.PutObject(ctx, node, reader, requestData)
+	}
+	return info.RemoteClient.PutObject(ctx, toRemoteNode(node), reader, requestData)
+}
+
+// CopyObject copies directly on the remote server if both nodes belong to the same remote datasource,
+// or streams the content from one datasource to the other.
+func (r *RemoteSourceHandler) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *CopyRequestData) (int64, error) {
+	fromInfo, fromRemote := r.remote(ctx, "from")
+	toInfo, toRemote := r.remote(ctx, "to")
+	if !fromRemote && !toRemote {
+		return r.next.CopyObject(ctx, from, to, requestData)
+	}
+	if fromRemote && toRemote && fromInfo.Name == toInfo.Name {
+		return toInfo.RemoteClient.CopyObject(ctx, toRemoteNode(from), toRemoteNode(to), requestData)
+	}
+	var reader io.ReadCloser
+	var e error
+	if fromRemote {
+		reader, e = fromInfo.RemoteClient.GetObject(ctx, toRemoteNode(from), &GetRequestData{Length: -1})
+	} else {
+		fromCtx := ctx
+		if info, ok := GetBranchInfo(ctx, "from"); ok {
+			fromCtx = WithBranchInfo(ctx, "in", info)
+		}
+		reader, e = r.next.GetObject(fromCtx, from, &GetRequestData{Length: -1, VersionId: requestData.SrcVersionId})
+	}
+	if e != nil {
+		return 0, e
+	}
+	defer reader.Close()
+	putData := &PutRequestData{Size: from.Size, Metadata: requestData.Metadata}
+	if toRemote {
+		return toInfo.RemoteClient.PutObject(ctx, toRemoteNode(to), reader, putData)
+	}
+	toCtx := ctx
+	if info, ok := GetBranchInfo(ctx, "to"); ok {
+		toCtx = WithBranchInfo(ctx, "in", info)
+	}
+	return r.next.PutObject(toCtx, to, reader, putData)
+}
+
+func (r *RemoteSourceHandler) MultipartCreate(ctx context.Context, target *tree.Node, requestData *MultipartRequestData) (string, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartCreate(ctx, target, requestData)
+	}
+	return info.RemoteClient.MultipartCreate(ctx, toRemoteNode(target), requestData)
+}
+
+func (r *RemoteSourceHandler) MultipartPutObjectPart(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, reader io.Reader, requestData *PutRequestData) (minio.ObjectPart, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartPutObjectPart(ctx, target, uploadID, partNumberMarker, reader, requestData)
+	}
+	return info.RemoteClient.MultipartPutObjectPart(ctx, toRemoteNode(target), uploadID, partNumberMarker, reader, requestData)
+}
+
+func (r *RemoteSourceHandler) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartComplete(ctx, target, uploadID, uploadedParts)
+	}
+	return info.RemoteClient.MultipartComplete(ctx, toRemoteNode(target), uploadID, uploadedParts)
+}
+
+func (r *RemoteSourceHandler) MultipartList(ctx context.Context, prefix string, requestData *MultipartRequestData) (minio.ListMultipartUploadsResult, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartList(ctx, prefix, requestData)
+	}
+	return info.RemoteClient.MultipartList(ctx, prefix, requestData)
+}
+
+func (r *RemoteSourceHandler) MultipartAbort(ctx context.Context, target *tree.Node, uploadID string, requestData *MultipartRequestData) error {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartAbort(ctx, target, uploadID, requestData)
+	}
+	return info.RemoteClient.MultipartAbort(ctx, toRemoteNode(target), uploadID, requestData)
+}
+
+func (r *RemoteSourceHandler) MultipartListObjectParts(ctx context.Context, target *tree.Node, uploadID string, partNumberMarker int, maxParts int) (minio.ListObjectPartsResult, error) {
+	info, ok := r.remote(ctx, "in")
+	if !ok {
+		return r.next.MultipartListObjectParts(ctx, target, uploadID, partNumberMarker, maxParts)
+	}
+	return info.RemoteClient.MultipartListObjectParts(ctx, toRemoteNode(target), uploadID, partNumberMarker, maxParts)
+}
+
+// toRemoteNode replaces the node path by its path inside the datasource.
+func toRemoteNode(node *tree.Node) *tree.Node {
+	n := node.Clone()
+	n.Path = node.GetStringMeta(common.MetaNamespaceDatasourcePath)
+	return n
+}
+
+// fromRemoteNode prefixes the node path with the datasource name, as it would be stored in the local index.
+func fromRemoteNode(info BranchInfo, node *tree.Node) *tree.Node {
+	n := node.Clone()
+	rel := strings.Trim(n.Path, "/")
+	n.Path = path.Join(info.Name, rel)
+	n.SetMeta(common.MetaNamespaceDatasourceName, info.Name)
+	n.SetMeta(common.MetaNamespaceDatasourcePath, rel)
+	return n
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func remoteTestNode(p, dsPath string) *tree.Node {
+	n := &tree.Node{Path: p}
+	n.SetMeta(common.MetaNamespaceDatasourcePath, dsPath)
+	return n
+}
+
+func TestRemoteSourceHandler(t *testing.T) {
+
+	Convey("Test requests on remote datasources", t, func() {
+
+		remote := NewHandlerMock()
+		remote.Nodes["folder"] = &tree.Node{Path: "folder", Type: tree.NodeType_COLLECTION}
+		remote.Nodes["folder/file"] = &tree.Node{Path: "folder/file", Uuid: "remote-uuid", Type: tree.NodeType_LEAF}
+		next := NewHandlerMock()
+		next.Nodes["local/file"] = &tree.Node{Path: "local/file", Type: tree.NodeType_LEAF}
+
+		handler := &RemoteSourceHandler{}
+		handler.SetNextHandler(next)
+
+		remoteInfo := BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{Name: "remote", StorageType: object.StorageType_CELLS}, RemoteClient: remote}}
+		localInfo := BranchInfo{LoadedSource: LoadedSource{DataSource: object.DataSource{Name: "local"}}}
+		remoteCtx := WithBranchInfo(context.Background(), "in", remoteInfo)
+
+		Convey("Read and list are proxied", func() {
+			resp, e := handler.ReadNode(remoteCtx, &tree.ReadNodeRequest{Node: remoteTestNode("remote/folder/file", "folder/file")})
+			So(e, ShouldBeNil)
+			So(remote.Nodes["in"].Path, ShouldEqual, "folder/file")
+			So(next.Nodes, ShouldNotContainKey, "in")
+			So(resp.Node.Path, ShouldEqual, "remote/folder/file")
+			So(resp.Node.Uuid, ShouldEqual, "remote-uuid")
+			So(resp.Node.GetStringMeta(common.MetaNamespaceDatasourceName), ShouldEqual, "remote")
+			So(resp.Node.GetStringMeta(common.MetaNamespaceDatasourcePath), ShouldEqual, "folder/file")
+
+			stream, e := handler.ListNodes(remoteCtx, &tree.ListNodesRequest{Node: remoteTestNode("remote/folder", "folder")})
+			So(e, ShouldBeNil)
+			var paths []string
+			for {
+				r, er := stream.Recv()
+				if er != nil || r == nil {
+					break
+				}
+				paths = append(paths, r.Node.Path)
+			}
+			So(paths, ShouldResemble, []string{"remote/folder/file"})
+
+			reader, e := handler.GetObject(remoteCtx, remoteTestNode("remote/folder/file", "folder/file"), &GetRequestData{Length: -1})
+			So(e, ShouldBeNil)
+			data, _ := ioutil.ReadAll(reader)
+			So(string(data), ShouldEqual, "folder/filehello world")
+		})
+
+		Convey("Other datasources are passed to next handler", func() {
+			localCtx := WithBranchInfo(context.Background(), "in", localInfo)
+			resp, e := handler.ReadNode(localCtx, &tree.ReadNodeRequest{Node: remoteTestNode("local/file", "file")})
+			So(e, ShouldBeNil)
+			So(resp.Node.Path, ShouldEqual, "local/file")
+			So(remote.Nodes, ShouldNotContainKey, "in")
+		})
+
+		Convey("Moves between remote and local datasources are refused", func() {
+			ctx := WithBranchInfo(remoteCtx, "from", remoteInfo)
+			ctx = WithBranchInfo(ctx, "to", localInfo)
+			_, e := handler.UpdateNode(ctx, &tree.UpdateNodeRequest{From: remoteTestNode("remote/folder/file", "folder/file"), To: remoteTestNode("local/file2", "file2")})
+			So(e, ShouldNotBeNil)
+			So(errors.Parse(e.Error()).Code, ShouldEqual, 400)
+		})
+
+		Convey("Copy from remote to local datasource streams the content", func() {
+			ctx := WithBranchInfo(remoteCtx, "from", remoteInfo)
+			ctx = WithBranchInfo(ctx, "to", localInfo)
+			_, e := handler.CopyObject(ctx, remoteTestNode("remote/folder/file", "folder/file"), remoteTestNode("local/copy", "copy"), &CopyRequestData{})
+			So(e, ShouldBeNil)
+			So(remote.Nodes["in"].Path, ShouldEqual, "folder/file")
+			So(next.Nodes["in"].Path, ShouldEqual, "local/copy")
+		})
+
+		Convey("Multipart uploads are proxied", func() {
+			_, e := handler.MultipartCreate(remoteCtx, remoteTestNode("remote/folder/big", "folder/big"), &MultipartRequestData{})
+			So(e, ShouldBeNil)
+			So(remote.Nodes["in"].Path, ShouldEqual, "folder/big")
+			_, e = handler.MultipartComplete(remoteCtx, remoteTestNode("remote/folder/big", "folder/big"), "upload", nil)
+			So(e, ShouldBeNil)
+			So(remote.Nodes["in"].Path, ShouldEqual, "folder/big")
+			So(next.Nodes, ShouldNotContainKey, "in")
+		})
+
+	})
+}
@@ -82,6 +82,7 @@ func NewStandardRouter(options RouterOptions) *Router {
 		handlers = append(handlers, &HandlerEventRead{})
 	}
 
+	handlers = append(handlers, &FrozenSourceFilter{})
 	handlers = append(handlers, &PutHandler{})
 	handlers = append(handlers, &AclLockFilter{})
 	if !options.AdminView {
@@ -93,6 +94,7 @@ func NewStandardRouter(options RouterOptions) *Router {
 	if options.SynchronousTasks {
 		handlers = append(handlers, &SyncFolderTasksHandler{})
 	}
+	handlers = append(handlers, &RemoteSourceHandler{}) // proxies requests on datasources hosted by a remote server
 	handlers = append(handlers, &EncryptionHandler{})
 	handlers = append(handlers, &VersionHandler{})
 	handlers = append(handlers, &Executor{})
@@ -118,14 +120,16 @@ func NewUuidRouter(options RouterOptions) *Router {
 	if !options.AdminView {
 		handlers = append(handlers, &AclFilterHandler{})
 	}
-	handlers = append(handlers, &PutHandler{}) // adds a node precreation on PUT file request
+	handlers = append(handlers, &FrozenSourceFilter{}) // refuses writes on datasources being migrated
+	handlers = append(handlers, &PutHandler{})         // adds a node precreation on PUT file request
 	if !options.AdminView {
 		handlers = append(handlers, &UploadLimitFilter{})
 		handlers = append(handlers, &AclLockFilter{})
 		handlers = append(handlers, &AclContentLockFilter{})
 		handlers = append(handlers, &AclQuotaFilter{})
 	}
-	handlers = append(handlers, &EncryptionHandler{}) // retrieves encryption materials from encryption service
+	handlers = append(handlers, &RemoteSourceHandler{}) // proxies requests on datasources hosted by a remote server
+	handlers = append(handlers, &EncryptionHandler{})   // retrieves encryption materials from encryption service
 	handlers = append(handlers, &VersionHandler{})
 	handlers = append(handlers, &Executor{})
 
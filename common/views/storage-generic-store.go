@@ -58,24 +58,24 @@ func GetGenericStoreClient(ctx context.Context, storeNamespace string, microClie
 // GetGenericStoreClientConfig finds datasource/bucket for a given store.
 func GetGenericStoreClientConfig(storeNamespace string) (dataSource string, bucket string, e error) {
 
+	c := config.Get("services", GetGenericStoreConfigKey(storeNamespace))
+
+	dataSource = c.Val("datasource").Default(configx.Reference("#/defaults/datasource")).String()
+	bucket = c.Val("bucket").String()
+
+	return dataSource, bucket, nil
+}
+
+// GetGenericStoreConfigKey finds the services configuration key of a given store.
+func GetGenericStoreConfigKey(storeNamespace string) string {
+
 	// TMP - TO BE FIXED
-	var configKey string
 	switch storeNamespace {
 	case common.PydioDocstoreBinariesNamespace:
-		configKey = "pydio.docstore-binaries"
-		break
+		return "pydio.docstore-binaries"
 	case common.PydioThumbstoreNamespace:
-		configKey = "pydio.thumbs_store"
-		break
+		return "pydio.thumbs_store"
 	default:
-		configKey = "pydio." + storeNamespace
-		break
+		return "pydio." + storeNamespace
 	}
-
-	c := config.Get("services", configKey)
-
-	dataSource = c.Val("datasource").Default(configx.Reference("#/defaults/datasource")).String()
-	bucket = c.Val("bucket").String()
-
-	return dataSource, bucket, nil
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/blevesearch/bleve"
 	"github.com/blevesearch/bleve/index/scorch"
 	"github.com/blevesearch/bleve/index/store/boltdb"
+	bleveQuery "github.com/blevesearch/bleve/search/query"
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common/log"
@@ -124,18 +125,36 @@ func (s *BleveServer) Reset() error {
 
 func (s *BleveServer) SearchDocuments(storeID string, query *docstore.DocumentQuery, countOnly bool) ([]string, int64, error) {
 
-	parts := strings.Split(query.MetaQuery, " ")
-	for i, p := range parts {
+	var parts []string
+	var groups, notGroups []bleveQuery.Query
+	for _, p := range splitMetaQuery(query.MetaQuery) {
+		if g := strings.TrimLeft(p, "+-"); strings.HasPrefix(g, "(") && strings.HasSuffix(g, ")") {
+			// Parenthesized groups match any of their parts
+			var alternatives []bleveQuery.Query
+			for _, a := range splitMetaQuery(g[1 : len(g)-1]) {
+				alternatives = append(alternatives, bleve.NewQueryStringQuery(a))
+			}
+			if strings.HasPrefix(p, "-") {
+				notGroups = append(notGroups, bleve.NewDisjunctionQuery(alternatives...))
+			} else {
+				groups = append(groups, bleve.NewDisjunctionQuery(alternatives...))
+			}
+			continue
+		}
 		if !strings.HasPrefix(p, "+") && !strings.HasPrefix(p, "-") {
-			parts[i] = "+" + p
+			p = "+" + p
 		}
+		parts = append(parts, p)
 	}
 
 	parts = append(parts, " +DOCSTORE_STORE_ID:"+s.escapeMetaValue(storeID))
 	if len(query.Owner) > 0 {
 		parts = append(parts, " +DOCSTORE_OWNER:"+s.escapeMetaValue(query.Owner))
 	}
-	qStringQuery := bleve.NewQueryStringQuery(strings.Join(parts, " "))
+	var qStringQuery bleveQuery.Query = bleve.NewQueryStringQuery(strings.Join(parts, " "))
+	if len(groups) > 0 || len(notGroups) > 0 {
+		qStringQuery = bleveQuery.NewBooleanQuery(append(groups, qStringQuery), nil, notGroups)
+	}
 
 	log.Logger(context.Background()).Debug("SearchDocuments", zap.Any("query", qStringQuery))
 	searchRequest := bleve.NewSearchRequest(qStringQuery)
@@ -170,6 +189,35 @@ func (s *BleveServer) SearchDocuments(storeID string, query *docstore.DocumentQu
 
 }
 
+// splitMetaQuery splits a query string on spaces, keeping quoted values and parenthesized groups in one part,
+// so that a group can hold alternatives like +(FIELD:"a" FIELD:"b").
+func splitMetaQuery(query string) (parts []string) {
+	var current []rune
+	var quoted bool
+	var depth int
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '(' && !quoted:
+			depth++
+		case r == ')' && !quoted && depth > 0:
+			depth--
+		case r == ' ' && !quoted && depth == 0:
+			if len(current) > 0 {
+				parts = append(parts, string(current))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		parts = append(parts, string(current))
+	}
+	return
+}
+
 func (s *BleveServer) escapeMetaValue(value string) string {
 
 	r := strings.NewReplacer("-", "\\-", "~", "\\~", "*", "\\*", ":", "\\:", "/", "\\/", " ", "\\ ")
@@ -26,6 +26,8 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/docstore"
 )
 
 func newPath(tmpName string) string {
@@ -61,3 +63,45 @@ func TestNewBleveEngine(t *testing.T) {
 	})
 
 }
+
+func TestBleveServer_SearchDocuments(t *testing.T) {
+
+	Convey("Test Bleve Search with alternatives", t, func() {
+
+		p := newPath("docstore-search-tmp.bleve")
+		s, e := NewBleveEngine(p, true)
+		So(e, ShouldBeNil)
+		defer s.Close()
+
+		for id, meta := range map[string]string{
+			"doc1": `{"REPOSITORY":"0c0a2f6e-1111","SHARE_TYPE":"minisite"}`,
+			"doc2": `{"REPOSITORY":"0c0a2f6e-2222","SHARE_TYPE":"minisite"}`,
+			"doc3": `{"REPOSITORY":"0c0a2f6e-3333","SHARE_TYPE":"minisite"}`,
+			"doc4": `{"REPOSITORY":"0c0a2f6e-2222","SHARE_TYPE":"other"}`,
+		} {
+			So(s.IndexDocument("shares", &docstore.Document{ID: id, IndexableMeta: meta}), ShouldBeNil)
+		}
+
+		docs, _, e := s.SearchDocuments("shares", &docstore.DocumentQuery{
+			MetaQuery: `+(REPOSITORY:"0c0a2f6e-1111" REPOSITORY:"0c0a2f6e-2222") +SHARE_TYPE:minisite`,
+		}, false)
+		So(e, ShouldBeNil)
+		So(docs, ShouldHaveLength, 2)
+		So(docs, ShouldContain, "doc1")
+		So(docs, ShouldContain, "doc2")
+
+		docs, _, e = s.SearchDocuments("shares", &docstore.DocumentQuery{
+			MetaQuery: `REPOSITORY:"0c0a2f6e-2222" SHARE_TYPE:minisite`,
+		}, false)
+		So(e, ShouldBeNil)
+		So(docs, ShouldResemble, []string{"doc2"})
+
+	})
+
+	Convey("Test splitting meta queries", t, func() {
+
+		So(splitMetaQuery(`+A:"x y"  (B:1 B:2) -C:3`), ShouldResemble, []string{`+A:"x y"`, `(B:1 B:2)`, `-C:3`})
+
+	})
+
+}
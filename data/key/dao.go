@@ -44,6 +44,7 @@ type DAO interface {
 	DeleteNode(nodeUuid string) error
 
 	SaveNodeKey(nodeKey *encryption.NodeKey) error
+	UpdateNodeKey(nodeKey *encryption.NodeKey) error
 	GetNodeKey(node string, user string) (*encryption.NodeKey, error)
 	DeleteNodeKey(nodeKey *encryption.NodeKey) error
 }
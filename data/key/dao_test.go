@@ -109,6 +109,21 @@ func TestSqlimpl_GetNodeKey(t *testing.T) {
 	})
 }
 
+func TestSqlimpl_UpdateNodeKey(t *testing.T) {
+	convey.Convey("Update node key", t, func() {
+		err := mockDAO.UpdateNodeKey(&encryption.NodeKey{
+			NodeId:  "node_id",
+			UserId:  "pydio",
+			OwnerId: "pydio",
+			KeyData: []byte("new-key"),
+		})
+		convey.So(err, convey.ShouldBeNil)
+		k, err := mockDAO.GetNodeKey("node_id", "pydio")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(k.KeyData), convey.ShouldEqual, "new-key")
+	})
+}
+
 func TestSqlimpl_DeleteNodeSharedKey(t *testing.T) {
 	convey.Convey("Get node key", t, func() {
 		err := mockDAO.DeleteNodeKey(&encryption.NodeKey{
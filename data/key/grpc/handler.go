@@ -82,6 +82,10 @@ func (km *NodeKeyManagerHandler) GetNodeInfo(ctx context.Context, req *encryptio
 	rsp.NodeInfo.NodeKey, err = dao.GetNodeKey(req.NodeId, req.UserId)
 	if err != nil {
 		log.Logger(ctx).Error("data.key.handler: failed to get node key for "+req.NodeId+" - "+req.UserId, zap.Error(err))
+		if errors.Parse(err.Error()).Code == 404 {
+			// Node is already encrypted but its key was not shared with this user: do not let callers re-key it
+			return errors.Forbidden("node.key.dao", "no key found for node %s and user %s", req.NodeId, req.UserId)
+		}
 		return err
 	}
 
@@ -328,6 +332,32 @@ func (km *NodeKeyManagerHandler) DeleteNodeSharedKey(ctx context.Context, req *e
 	})
 }
 
+// SetNodeKey stores the key of an existing node for a given user, replacing any previous key for this user.
+// It is used to share keys of nodes encrypted with per-user keys, and to re-wrap keys on master key rotation.
+func (km *NodeKeyManagerHandler) SetNodeKey(ctx context.Context, req *encryption.SetNodeKeyRequest, rsp *encryption.SetNodeKeyResponse) error {
+	if req.NodeKey == nil || req.NodeKey.NodeId == "" || req.NodeKey.UserId == "" {
+		return errors.BadRequest("data.key.handler", "please provide a node key with node and user ids")
+	}
+	dao, err := getDAO(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := dao.GetNode(req.NodeKey.NodeId); err != nil {
+		return err
+	}
+	// Update in place when possible, so that the node is never left without key
+	if _, e := dao.GetNodeKey(req.NodeKey.NodeId, req.NodeKey.UserId); e == nil {
+		err = dao.UpdateNodeKey(req.NodeKey)
+	} else {
+		err = dao.SaveNodeKey(req.NodeKey)
+	}
+	if err != nil {
+		log.Logger(ctx).Error("failed to save node key", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (km *NodeKeyManagerHandler) saveNodeKey(ctx context.Context, dao key.DAO, nodeKey *encryption.NodeKey) error {
 	err := dao.SaveNode(&encryption.Node{
 		NodeId: nodeKey.NodeId,
@@ -46,6 +46,7 @@ var (
 		"node_key_select":               `SELECT * FROM enc_node_keys WHERE node_id=? AND user_id=?;`,
 		"node_key_select_all":           `SELECT * FROM enc_node_keys WHERE node_id=?;`,
 		"node_key_copy":                 `INSERT INTO enc_node_keys (SELECT ?, owner_id, user_id, key_data FROM enc_node_keys WHERE node_id=?);`,
+		"node_key_update":               `UPDATE enc_node_keys SET owner_id=?, key_data=? WHERE node_id=? AND user_id=?;`,
 		"node_key_delete":               `DELETE FROM enc_node_keys WHERE node_id=? AND user_id=?;`,
 		"node_shared_key_delete":        `DELETE FROM enc_node_keys WHERE user_id<>owner_id AND node_id=? AND owner_id=? AND user_id=?`,
 		"node_shared_key_delete_all":    `DELETE FROM enc_node_keys WHERE  user_id<>owner_id AND node_id=? AND owner_id=?`,
@@ -318,6 +319,16 @@ func (h *sqlimpl) GetNodeKey(nodeUuid string, user string) (*encryption.NodeKey,
 	return k.(*encryption.NodeKey), c.Close()
 }
 
+func (h *sqlimpl) UpdateNodeKey(key *encryption.NodeKey) error {
+	stmt, er := h.GetStmt("node_key_update")
+	if er != nil {
+		return er
+	}
+
+	_, err := stmt.Exec(key.OwnerId, key.KeyData, key.NodeId, key.UserId)
+	return err
+}
+
 func (h *sqlimpl) DeleteNodeKey(key *encryption.NodeKey) error {
 	stmt, er := h.GetStmt("node_key_delete")
 	if er != nil {
@@ -1,4 +1,4 @@
-package bleve
+package dao
 
 import (
 	"compress/gzip"
@@ -11,7 +11,6 @@ import (
 
 	"github.com/pydio/cells/common/auth"
 
-	"github.com/blevesearch/bleve"
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
@@ -23,7 +22,10 @@ import (
 	"github.com/pydio/cells/common/views"
 )
 
-// Batch avoids overflowing bleve index by batching indexation events (index/delete)
+// FlushFunc receives the loaded nodes to index and the uuids to delete when a batch is flushed.
+type FlushFunc func(inserts map[string]*tree.IndexableNode, deletes []string) error
+
+// Batch avoids overflowing the index by batching indexation events (index/delete)
 type Batch struct {
 	sync.Mutex
 	inserts    map[string]*tree.IndexableNode
@@ -70,7 +72,7 @@ func (b *Batch) Size() int {
 	return l
 }
 
-func (b *Batch) Flush(index bleve.Index) error {
+func (b *Batch) Flush(flush FlushFunc) error {
 	b.Lock()
 	l := len(b.inserts) + len(b.deletes)
 	if l == 0 {
@@ -78,22 +80,23 @@ func (b *Batch) Flush(index bleve.Index) error {
 		return nil
 	}
 	log.Logger(b.ctx).Info("Flushing search batch", zap.Int("size", l))
-	batch := index.NewBatch()
 	excludes := b.NamespacesProvider().ExcludeIndexes()
 	b.NamespacesProvider().InitStreamers(b.ctx)
 	defer b.NamespacesProvider().CloseStreamers()
+	inserts := make(map[string]*tree.IndexableNode, len(b.inserts))
 	for uuid, node := range b.inserts {
 		if e := b.LoadIndexableNode(node, excludes); e == nil {
-			batch.Index(uuid, node)
+			inserts[uuid] = node
 		}
 		delete(b.inserts, uuid)
 	}
+	deletes := make([]string, 0, len(b.deletes))
 	for uuid, _ := range b.deletes {
-		batch.Delete(uuid)
+		deletes = append(deletes, uuid)
 		delete(b.deletes, uuid)
 	}
 	b.Unlock()
-	return index.Batch(batch)
+	return flush(inserts, deletes)
 }
 
 func (b *Batch) LoadIndexableNode(indexNode *tree.IndexableNode, excludes map[string]struct{}) error {
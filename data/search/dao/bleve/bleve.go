@@ -43,6 +43,7 @@ import (
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/data/search/dao"
 
 	_ "github.com/blevesearch/bleve/analysis/lang/ar"
 	_ "github.com/blevesearch/bleve/analysis/lang/bg"
@@ -96,6 +97,7 @@ type BleveServer struct {
 	batch   *bleve.Batch
 	inserts chan *tree.IndexableNode
 	deletes chan string
+	flushes chan chan error
 	done    chan bool
 	closed  chan bool
 
@@ -129,6 +131,7 @@ func NewBleveEngine(indexContent bool, configs map[string]interface{}) (*BleveSe
 		contentAnalyzer:  cA,
 		inserts:          make(chan *tree.IndexableNode),
 		deletes:          make(chan string),
+		flushes:          make(chan chan error),
 		done:             make(chan bool, 1),
 	}
 	go server.watchOperations()
@@ -136,29 +139,53 @@ func NewBleveEngine(indexContent bool, configs map[string]interface{}) (*BleveSe
 }
 
 func (s *BleveServer) watchOperations() {
-	batch := NewBatch(BatchOptions{IndexContent: s.IndexContent})
+	batch := dao.NewBatch(dao.BatchOptions{IndexContent: s.IndexContent})
 	for {
 		select {
 		case n := <-s.inserts:
 			batch.Index(n)
 			if batch.Size() >= BatchSize {
-				batch.Flush(s.Engine)
+				batch.Flush(s.flush)
 			}
 		case d := <-s.deletes:
 			batch.Delete(d)
 			if batch.Size() >= BatchSize {
-				batch.Flush(s.Engine)
+				batch.Flush(s.flush)
 			}
+		case res := <-s.flushes:
+			res <- batch.Flush(s.flush)
 		case <-time.After(3 * time.Second):
-			batch.Flush(s.Engine)
+			batch.Flush(s.flush)
 		case <-s.done:
-			batch.Flush(s.Engine)
+			batch.Flush(s.flush)
 			s.Engine.Close()
 			return
 		}
 	}
 }
 
+// Flush synchronously writes pending operations to the index.
+func (s *BleveServer) Flush() error {
+	res := make(chan error, 1)
+	select {
+	case s.flushes <- res:
+	case <-s.done:
+		return fmt.Errorf("search engine is closed")
+	}
+	return <-res
+}
+
+func (s *BleveServer) flush(inserts map[string]*tree.IndexableNode, deletes []string) error {
+	batch := s.Engine.NewBatch()
+	for uuid, node := range inserts {
+		batch.Index(uuid, node)
+	}
+	for _, uuid := range deletes {
+		batch.Delete(uuid)
+	}
+	return s.Engine.Batch(batch)
+}
+
 func createIndex(indexPath string, bnAna, cAna string) (bleve.Index, error) {
 
 	mapping := bleve.NewIndexMapping()
@@ -33,6 +33,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/data/search/dao"
 )
 
 func getTmpIndex(createNodes bool) (s *BleveServer, dir string) {
@@ -154,7 +155,7 @@ func TestMakeIndexableNode(t *testing.T) {
 		}
 		node.SetMeta("name", "node.txt")
 
-		b := NewBatch(BatchOptions{})
+		b := dao.NewBatch(dao.BatchOptions{})
 		indexNode := &tree.IndexableNode{Node: *node}
 		e := b.LoadIndexableNode(indexNode, nil)
 		So(e, ShouldBeNil)
@@ -0,0 +1,341 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package opensearch implements the search engine on top of an OpenSearch (or Elasticsearch) compatible
+// HTTP API, allowing to share one index between many cells nodes.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/meta"
+	"github.com/pydio/cells/data/search/dao"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+var (
+	BatchSize = 2000
+	// DefaultIndexName is used when no index name is configured.
+	DefaultIndexName = "cells-search"
+	// ErrEngineClosed is returned by operations sent after Close was called.
+	ErrEngineClosed = fmt.Errorf("search engine is closed")
+)
+
+// Engine implements dao.SearchEngine using the OpenSearch REST API.
+type Engine struct {
+	URL          string
+	IndexName    string
+	IndexContent bool
+	Client       *http.Client
+
+	inserts chan *tree.IndexableNode
+	deletes chan string
+	flushes chan chan error
+	done    chan bool
+	stopped chan bool
+	closing sync.Once
+	// closeErr is the result of the last flush, set before stopped is closed
+	closeErr error
+
+	nsProvider *meta.NamespacesProvider
+	nsOnce     sync.Once
+}
+
+// document is the JSON representation of a node inside the index.
+type document struct {
+	Uuid        string                 `json:"Uuid"`
+	Path        string                 `json:"Path"`
+	Basename    string                 `json:"Basename"`
+	NodeType    string                 `json:"NodeType"`
+	Extension   string                 `json:"Extension,omitempty"`
+	Size        int64                  `json:"Size"`
+	ModifTime   int64                  `json:"ModifTime"`
+	GeoPoint    map[string]interface{} `json:"GeoPoint,omitempty"`
+	TextContent string                 `json:"TextContent,omitempty"`
+	Meta        map[string]interface{} `json:"Meta,omitempty"`
+}
+
+// NewEngine connects to the server at serverUrl and creates the index if it does not exist yet.
+func NewEngine(serverUrl string, indexName string, indexContent bool) (*Engine, error) {
+	if serverUrl == "" {
+		return nil, fmt.Errorf("please provide the url of the opensearch server")
+	}
+	if _, e := url.Parse(serverUrl); e != nil {
+		return nil, e
+	}
+	if indexName == "" {
+		indexName = DefaultIndexName
+	}
+	s := &Engine{
+		URL:          strings.TrimRight(serverUrl, "/"),
+		IndexName:    indexName,
+		IndexContent: indexContent,
+		Client:       &http.Client{Timeout: 30 * time.Second},
+		inserts:      make(chan *tree.IndexableNode),
+		deletes:      make(chan string),
+		flushes:      make(chan chan error),
+		done:         make(chan bool, 1),
+		stopped:      make(chan bool),
+	}
+	if e := s.createIndex(context.Background()); e != nil {
+		return nil, e
+	}
+	go s.watchOperations()
+	return s, nil
+}
+
+func (s *Engine) watchOperations() {
+	batch := dao.NewBatch(dao.BatchOptions{IndexContent: s.IndexContent})
+	for {
+		select {
+		case n := <-s.inserts:
+			batch.Index(n)
+			if batch.Size() >= BatchSize {
+				batch.Flush(s.flush)
+			}
+		case d := <-s.deletes:
+			batch.Delete(d)
+			if batch.Size() >= BatchSize {
+				batch.Flush(s.flush)
+			}
+		case res := <-s.flushes:
+			res <- batch.Flush(s.flush)
+		case <-time.After(3 * time.Second):
+			batch.Flush(s.flush)
+		case <-s.done:
+			s.closeErr = batch.Flush(s.flush)
+			close(s.stopped)
+			return
+		}
+	}
+}
+
+// Flush synchronously sends pending operations to the server.
+func (s *Engine) Flush() error {
+	res := make(chan error, 1)
+	select {
+	case s.flushes <- res:
+	case <-s.done:
+		return ErrEngineClosed
+	}
+	return <-res
+}
+
+// flush sends a _bulk request to the server.
+func (s *Engine) flush(inserts map[string]*tree.IndexableNode, deletes []string) error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	for uuid, node := range inserts {
+		enc.Encode(map[string]interface{}{"index": map[string]string{"_index": s.IndexName, "_id": uuid}})
+		enc.Encode(toDocument(node))
+	}
+	for _, uuid := range deletes {
+		enc.Encode(map[string]interface{}{"delete": map[string]string{"_index": s.IndexName, "_id": uuid}})
+	}
+	var resp struct {
+		Errors bool `json:"errors"`
+	}
+	if e := s.request(context.Background(), http.MethodPost, "/_bulk?refresh=true", "application/x-ndjson", buf, &resp); e != nil {
+		return e
+	}
+	if resp.Errors {
+		return fmt.Errorf("some operations failed while indexing batch")
+	}
+	return nil
+}
+
+func toDocument(n *tree.IndexableNode) *document {
+	d := &document{
+		Uuid:        n.Uuid,
+		Path:        n.Path,
+		Basename:    n.Basename,
+		NodeType:    n.NodeType,
+		Extension:   n.Extension,
+		Size:        n.Size,
+		ModifTime:   n.ModifTime.Unix(),
+		TextContent: n.TextContent,
+		Meta:        n.Meta,
+	}
+	if lat, ok := n.GeoPoint["lat"]; ok {
+		if lon, ok := n.GeoPoint["lon"]; ok {
+			d.GeoPoint = map[string]interface{}{"lat": lat, "lon": lon}
+		}
+	}
+	return d
+}
+
+// request sends a JSON request to the server and decodes the response in target, if not nil.
+func (s *Engine) request(ctx context.Context, method, uri, contentType string, body io.Reader, target interface{}) error {
+	req, e := http.NewRequest(method, s.URL+uri, body)
+	if e != nil {
+		return e
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, e := s.Client.Do(req)
+	if e != nil {
+		return e
+	}
+	defer resp.Body.Close()
+	data, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return &serverError{status: resp.StatusCode, body: string(data)}
+	}
+	if target != nil {
+		return json.Unmarshal(data, target)
+	}
+	return nil
+}
+
+func (s *Engine) jsonRequest(ctx context.Context, method, uri string, body interface{}, target interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, e := json.Marshal(body)
+		if e != nil {
+			return e
+		}
+		reader = bytes.NewReader(data)
+	}
+	return s.request(ctx, method, uri, "application/json", reader, target)
+}
+
+type serverError struct {
+	status int
+	body   string
+}
+
+func (e *serverError) Error() string {
+	return fmt.Sprintf("opensearch server responded with status %d: %s", e.status, e.body)
+}
+
+func isNotFound(e error) bool {
+	se, ok := e.(*serverError)
+	return ok && se.status == http.StatusNotFound
+}
+
+// createIndex creates the index with its mappings, unless it already exists.
+func (s *Engine) createIndex(ctx context.Context) error {
+	e := s.jsonRequest(ctx, http.MethodHead, "/"+s.IndexName, nil, nil)
+	if e == nil {
+		return nil
+	} else if !isNotFound(e) {
+		return e
+	}
+	return s.jsonRequest(ctx, http.MethodPut, "/"+s.IndexName, indexMapping(), nil)
+}
+
+func indexMapping() map[string]interface{} {
+	keyword := map[string]interface{}{"type": "keyword"}
+	return map[string]interface{}{
+		"settings": map[string]interface{}{
+			"analysis": map[string]interface{}{
+				"normalizer": map[string]interface{}{
+					"lowercase": map[string]interface{}{"type": "custom", "filter": []string{"lowercase"}},
+				},
+			},
+		},
+		"mappings": map[string]interface{}{
+			"dynamic_templates": []interface{}{
+				map[string]interface{}{
+					"meta_strings": map[string]interface{}{
+						"path_match":         "Meta.*",
+						"match_mapping_type": "string",
+						"mapping": map[string]interface{}{
+							"type":   "text",
+							"fields": map[string]interface{}{"raw": map[string]interface{}{"type": "keyword", "ignore_above": 256}},
+						},
+					},
+				},
+			},
+			"properties": map[string]interface{}{
+				"Uuid": keyword,
+				"Path": keyword,
+				"Basename": map[string]interface{}{
+					"type":   "text",
+					"fields": map[string]interface{}{"raw": map[string]interface{}{"type": "keyword", "normalizer": "lowercase"}},
+				},
+				"NodeType":    keyword,
+				"Extension":   keyword,
+				"Size":        map[string]interface{}{"type": "long"},
+				"ModifTime":   map[string]interface{}{"type": "date", "format": "epoch_second"},
+				"GeoPoint":    map[string]interface{}{"type": "geo_point"},
+				"TextContent": map[string]interface{}{"type": "text"},
+				"Meta":        map[string]interface{}{"type": "object", "dynamic": true},
+			},
+		},
+	}
+}
+
+// IndexNode implements dao.SearchEngine interface.
+func (s *Engine) IndexNode(c context.Context, n *tree.Node, reloadCore bool, excludes map[string]struct{}) error {
+	if n.GetUuid() == "" {
+		return fmt.Errorf("missing uuid")
+	}
+	select {
+	case s.inserts <- &tree.IndexableNode{
+		Node:       *n,
+		ReloadCore: reloadCore,
+		ReloadNs:   !reloadCore,
+	}:
+		return nil
+	case <-s.done:
+		return ErrEngineClosed
+	}
+}
+
+// DeleteNode implements dao.SearchEngine interface.
+func (s *Engine) DeleteNode(c context.Context, n *tree.Node) error {
+	select {
+	case s.deletes <- n.GetUuid():
+		return nil
+	case <-s.done:
+		return ErrEngineClosed
+	}
+}
+
+// ClearIndex drops and recreates the remote index.
+func (s *Engine) ClearIndex(ctx context.Context) error {
+	if e := s.jsonRequest(ctx, http.MethodDelete, "/"+s.IndexName, nil, nil); e != nil && !isNotFound(e) {
+		return e
+	}
+	return s.createIndex(ctx)
+}
+
+// Close flushes pending operations and stops the batch, waiting for the last flush to be done.
+// It can be called more than once.
+func (s *Engine) Close() error {
+	s.closing.Do(func() {
+		close(s.done)
+	})
+	<-s.stopped
+	return s.closeErr
+}
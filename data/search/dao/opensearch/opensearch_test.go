@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pydio/cells/common/proto/tree"
+	json "github.com/pydio/cells/x/jsonx"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeServer stands in for an OpenSearch server, recording received requests.
+type fakeServer struct {
+	sync.Mutex
+	exists   bool
+	requests map[string][]string
+	response string
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	key := r.Method + " " + r.URL.Path
+	f.requests[key] = append(f.requests[key], string(body))
+	switch {
+	case r.Method == http.MethodHead:
+		if !f.exists {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut:
+		f.exists = true
+		w.Write([]byte(`{"acknowledged":true}`))
+	case r.Method == http.MethodDelete:
+		f.exists = false
+		w.Write([]byte(`{"acknowledged":true}`))
+	case r.URL.Path == "/_bulk":
+		w.Write([]byte(`{"errors":false}`))
+	case strings.HasSuffix(r.URL.Path, "/_search"):
+		w.Write([]byte(f.response))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeServer) last(key string) string {
+	f.Lock()
+	defer f.Unlock()
+	rr := f.requests[key]
+	if len(rr) == 0 {
+		return ""
+	}
+	return rr[len(rr)-1]
+}
+
+const cannedSearchResponse = `{
+  "hits": {"hits": [
+    {"_id": "docID1", "_source": {"Uuid": "docID1", "Path": "/path/to/node.txt", "Basename": "node.txt", "NodeType": "file", "Size": 24, "ModifTime": 1600000000},
+     "highlight": {"TextContent": ["some <em>content</em>"]}},
+    {"_id": "docID2", "_source": {"Uuid": "docID2", "Path": "/a/folder", "Basename": "folder", "NodeType": "folder"},
+     "highlight": {"Basename.raw": ["<em>folder</em>"]}}
+  ]},
+  "aggregations": {
+    "Type": {"buckets": [{"key": "file", "doc_count": 1}, {"key": "folder", "doc_count": 1}]},
+    "Size": {"buckets": [{"key": "size.lt.1MB", "to": 1048576.0, "doc_count": 2}]},
+    "Date": {"buckets": [{"key": "date.last.7", "from": 1600000000000.0, "to": 1600600000000.0, "doc_count": 1}]}
+  }
+}`
+
+func TestOpenSearchEngine(t *testing.T) {
+
+	Convey("Index and search nodes on an OpenSearch server", t, func() {
+
+		fake := &fakeServer{requests: map[string][]string{}, response: cannedSearchResponse}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		engine, e := NewEngine(server.URL, "", false)
+		So(e, ShouldBeNil)
+		defer engine.Close()
+		So(engine.IndexName, ShouldEqual, DefaultIndexName)
+		So(fake.last("PUT /"+DefaultIndexName), ShouldContainSubstring, `"geo_point"`)
+
+		ctx := context.Background()
+		node := &tree.Node{Uuid: "docID1", Path: "/path/to/node.txt", MTime: time.Now().Unix(), Type: 1, Size: 24}
+		node.SetMeta("name", "node.txt")
+		node.SetMeta("GeoLocation", map[string]float64{"lat": 47.1, "lon": 8.3})
+		So(engine.IndexNode(ctx, node, false, nil), ShouldBeNil)
+		So(engine.IndexNode(ctx, &tree.Node{}, false, nil), ShouldNotBeNil)
+		So(engine.DeleteNode(ctx, &tree.Node{Uuid: "docID3"}), ShouldBeNil)
+		So(engine.Flush(), ShouldBeNil)
+
+		bulk := strings.Split(strings.TrimSpace(fake.last("POST /_bulk")), "\n")
+		So(bulk, ShouldHaveLength, 3)
+		So(bulk[0], ShouldContainSubstring, `"_id":"docID1"`)
+		var doc map[string]interface{}
+		So(json.Unmarshal([]byte(bulk[1]), &doc), ShouldBeNil)
+		So(doc["Basename"], ShouldEqual, "node.txt")
+		So(doc["Extension"], ShouldEqual, "txt")
+		So(doc["GeoPoint"], ShouldResemble, map[string]interface{}{"lat": 47.1, "lon": 8.3})
+		So(bulk[2], ShouldContainSubstring, `"delete"`)
+
+		query := &tree.Query{
+			FileName:   "Node",
+			FreeString: "+Meta.tags:important",
+			GeoQuery: &tree.GeoQuery{
+				Center:   &tree.GeoPoint{Lat: 47.1, Lon: 8.3},
+				Distance: "10km",
+			},
+		}
+		var results []*tree.Node
+		var facets []*tree.SearchFacet
+		resultsChan := make(chan *tree.Node)
+		facetsChan := make(chan *tree.SearchFacet)
+		doneChan := make(chan bool)
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case n := <-resultsChan:
+					results = append(results, n)
+				case f := <-facetsChan:
+					facets = append(facets, f)
+				case <-doneChan:
+					return
+				}
+			}
+		}()
+		e = engine.SearchNodes(ctx, query, 0, 10, resultsChan, facetsChan, doneChan)
+		wg.Wait()
+		So(e, ShouldBeNil)
+
+		sent := fake.last("POST /" + DefaultIndexName + "/_search")
+		So(sent, ShouldContainSubstring, `"Basename.raw":{"value":"*node*"}`)
+		So(sent, ShouldContainSubstring, `"query_string":{"query":"+Meta.tags:important"}`)
+		So(sent, ShouldContainSubstring, `"geo_distance":{"GeoPoint":{"lat":47.1,"lon":8.3},"distance":"10km"}`)
+
+		So(results, ShouldHaveLength, 2)
+		So(results[0].Uuid, ShouldEqual, "docID1")
+		So(results[0].Type, ShouldEqual, tree.NodeType_LEAF)
+		So(results[0].GetStringMeta("name"), ShouldEqual, "node.txt")
+		So(results[0].MTime, ShouldEqual, 1600000000)
+		So(results[1].Type, ShouldEqual, tree.NodeType_COLLECTION)
+
+		byLabel := map[string]*tree.SearchFacet{}
+		for _, f := range facets {
+			byLabel[f.Label] = f
+		}
+		So(byLabel["file"].FieldName, ShouldEqual, "NodeType")
+		So(byLabel["size.lt.1MB"].Max, ShouldEqual, 1048576)
+		So(byLabel["date.last.7"].Start, ShouldEqual, 1600000000)
+		So(byLabel["found.contents"].Count, ShouldEqual, 1)
+		So(byLabel["found.basename"].Count, ShouldEqual, 1)
+
+		So(engine.ClearIndex(ctx), ShouldBeNil)
+		So(fake.requests["DELETE /"+DefaultIndexName], ShouldHaveLength, 1)
+		So(fake.requests["PUT /"+DefaultIndexName], ShouldHaveLength, 2)
+	})
+
+	Convey("Operations sent to a closed engine fail instead of blocking", t, func() {
+
+		fake := &fakeServer{requests: map[string][]string{}, response: cannedSearchResponse}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		engine, e := NewEngine(server.URL, "", false)
+		So(e, ShouldBeNil)
+		So(engine.Close(), ShouldBeNil)
+		So(engine.Close(), ShouldBeNil)
+
+		ctx := context.Background()
+		So(engine.IndexNode(ctx, &tree.Node{Uuid: "docID1"}, false, nil), ShouldEqual, ErrEngineClosed)
+		So(engine.DeleteNode(ctx, &tree.Node{Uuid: "docID1"}), ShouldEqual, ErrEngineClosed)
+		So(engine.Flush(), ShouldEqual, ErrEngineClosed)
+	})
+
+	Convey("Close waits for pending operations to be sent", t, func() {
+
+		fake := &fakeServer{requests: map[string][]string{}, response: cannedSearchResponse}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		engine, e := NewEngine(server.URL, "", false)
+		So(e, ShouldBeNil)
+		So(engine.DeleteNode(context.Background(), &tree.Node{Uuid: "docID1"}), ShouldBeNil)
+		So(engine.Close(), ShouldBeNil)
+		So(fake.last("POST /_bulk"), ShouldContainSubstring, "docID1")
+	})
+
+}
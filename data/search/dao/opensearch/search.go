@@ -0,0 +1,301 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/meta"
+)
+
+type m map[string]interface{}
+
+type searchResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID        string              `json:"_id"`
+			Source    document            `json:"_source"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]struct {
+		Buckets []struct {
+			Key      interface{} `json:"key"`
+			DocCount int64       `json:"doc_count"`
+			From     *float64    `json:"from"`
+			To       *float64    `json:"to"`
+		} `json:"buckets"`
+	} `json:"aggregations"`
+}
+
+// aggregations maps aggregation names to the field they are computed on.
+type aggregations map[string]string
+
+func basenameQuery(term string, boost float64) m {
+	wildcard := m{"value": "*" + strings.Trim(strings.ToLower(term), "*") + "*"}
+	if boost > 0 {
+		wildcard["boost"] = boost
+	}
+	return m{"wildcard": m{"Basename.raw": wildcard}}
+}
+
+func contentQuery(term string) m {
+	return m{"match": m{"TextContent": m{"query": term}}}
+}
+
+// buildQuery translates a tree.Query into the OpenSearch query DSL.
+func buildQuery(queryObject *tree.Query) (m, error) {
+
+	var must []interface{}
+	if term := queryObject.GetFileNameOrContent(); term != "" {
+		must = append(must, m{"bool": m{
+			"should":               []interface{}{basenameQuery(term, 5), contentQuery(term)},
+			"minimum_should_match": 1,
+		}})
+	} else {
+		if term := queryObject.GetFileName(); term != "" {
+			must = append(must, basenameQuery(term, 0))
+		}
+		if term := queryObject.GetContent(); term != "" {
+			must = append(must, contentQuery(term))
+		}
+	}
+
+	// File Size Range
+	if queryObject.MinSize > 0 || queryObject.MaxSize > 0 {
+		r := m{"gte": queryObject.MinSize}
+		if queryObject.MaxSize > 0 {
+			r["lt"] = queryObject.MaxSize
+		}
+		must = append(must, m{"range": m{"Size": r}})
+	}
+	// Date Range
+	if e := queryObject.ParseDurationDate(); e != nil {
+		return nil, e
+	}
+	if queryObject.MinDate > 0 || queryObject.MaxDate > 0 {
+		r := m{"gte": queryObject.MinDate, "format": "epoch_second"}
+		if queryObject.MaxDate > 0 {
+			r["lt"] = queryObject.MaxDate
+		} else {
+			r["lt"] = time.Now().Unix()
+		}
+		must = append(must, m{"range": m{"ModifTime": r}})
+	}
+	// Limit to a SubTree
+	if len(queryObject.PathPrefix) > 0 {
+		var should []interface{}
+		for _, pref := range queryObject.PathPrefix {
+			should = append(should, m{"prefix": m{"Path": pref}})
+		}
+		must = append(must, m{"bool": m{"should": should, "minimum_should_match": 1}})
+	}
+	// Limit to a given node type
+	if queryObject.Type > 0 {
+		nodeType := "file"
+		if queryObject.Type == 2 {
+			nodeType = "folder"
+		}
+		must = append(must, m{"term": m{"NodeType": nodeType}})
+	}
+	if len(queryObject.Extension) > 0 {
+		must = append(must, m{"term": m{"Extension": strings.ToLower(queryObject.Extension)}})
+	}
+	// Metadata queries use the same syntax as bleve query strings
+	if len(queryObject.FreeString) > 0 {
+		must = append(must, m{"query_string": m{"query": queryObject.FreeString}})
+	}
+
+	if geo := queryObject.GeoQuery; geo != nil {
+		if geo.Center != nil && len(geo.Distance) > 0 {
+			must = append(must, m{"geo_distance": m{
+				"distance": geo.Distance,
+				"GeoPoint": m{"lat": geo.Center.Lat, "lon": geo.Center.Lon},
+			}})
+		} else if geo.TopLeft != nil && geo.BottomRight != nil {
+			must = append(must, m{"geo_bounding_box": m{"GeoPoint": m{
+				"top_left":     m{"lat": geo.TopLeft.Lat, "lon": geo.TopLeft.Lon},
+				"bottom_right": m{"lat": geo.BottomRight.Lat, "lon": geo.BottomRight.Lon},
+			}}})
+		}
+	}
+
+	if len(must) == 0 {
+		return m{"match_all": m{}}, nil
+	}
+	return m{"bool": m{"must": must}}, nil
+}
+
+// buildAggregations computes facets equivalent to the ones provided by the bleve engine.
+func buildAggregations(indexedMeta map[string]struct{}) (m, aggregations) {
+	fields := aggregations{
+		"Type":      "NodeType",
+		"Extension": "Extension",
+		"Size":      "Size",
+		"Date":      "ModifTime",
+	}
+	var s2, s3, s4 int64
+	s2 = 1024 * 1024
+	s3 = 1024 * 1024 * 1024 * 10
+	s4 = 1024 * 1024 * 1024 * 100
+	now := time.Now()
+	last5 := now.Add(-5 * time.Minute)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	aggs := m{
+		"Type":      m{"terms": m{"field": "NodeType", "size": 2}},
+		"Extension": m{"terms": m{"field": "Extension", "size": 5}},
+		"Size": m{"range": m{"field": "Size", "ranges": []interface{}{
+			m{"key": "size.lt.1MB", "to": s2},
+			m{"key": "size.1MB.to.10MB", "from": s2, "to": s3},
+			m{"key": "size.10MB.to.100MB", "from": s3, "to": s4},
+			m{"key": "size.gt.100MB", "from": s4},
+		}}},
+		"Date": m{"date_range": m{"field": "ModifTime", "format": "epoch_second", "ranges": []interface{}{
+			m{"key": "date.moments", "from": last5.Unix(), "to": now.Unix()},
+			m{"key": "date.today", "from": today.Unix(), "to": last5.Unix()},
+			m{"key": "date.last.7", "from": now.Add(-7 * 24 * time.Hour).Unix(), "to": today.Unix()},
+			m{"key": "date.last.30", "from": now.Add(-30 * 24 * time.Hour).Unix(), "to": now.Add(-7 * 24 * time.Hour).Unix()},
+			m{"key": "date.older.30", "to": now.Add(-30 * 24 * time.Hour).Unix()},
+		}}},
+	}
+	for metaName := range indexedMeta {
+		aggs[metaName] = m{"terms": m{"field": "Meta." + metaName + ".raw", "size": 4}}
+		fields[metaName] = "Meta." + metaName
+	}
+	return aggs, fields
+}
+
+// SearchNodes implements dao.SearchEngine interface.
+func (s *Engine) SearchNodes(c context.Context, queryObject *tree.Query, from int32, size int32, resultChan chan *tree.Node, facets chan *tree.SearchFacet, doneChan chan bool) error {
+
+	q, e := buildQuery(queryObject)
+	if e != nil {
+		return e
+	}
+	if size <= 0 {
+		size = 10
+	}
+	s.nsOnce.Do(func() {
+		s.nsProvider = meta.NewNamespacesProvider()
+	})
+	aggs, fields := buildAggregations(s.nsProvider.IncludedIndexes())
+	request := m{
+		"query":   q,
+		"from":    from,
+		"size":    size,
+		"_source": []string{"Uuid", "Path", "NodeType", "Basename", "Size", "ModifTime"},
+		"aggs":    aggs,
+		"highlight": m{"fields": m{
+			"Basename.raw": m{},
+			"TextContent":  m{},
+		}},
+	}
+	log.Logger(c).Debug("SearchObjects", zap.Any("query", request))
+
+	var resp searchResponse
+	if e := s.jsonRequest(c, http.MethodPost, "/"+s.IndexName+"/_search", request, &resp); e != nil {
+		doneChan <- true
+		return e
+	}
+
+	for name, agg := range resp.Aggregations {
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+		for _, b := range agg.Buckets {
+			label, _ := b.Key.(string)
+			if label == "" {
+				continue
+			}
+			facet := &tree.SearchFacet{FieldName: field, Label: label, Count: int32(b.DocCount)}
+			switch name {
+			case "Size":
+				if b.From != nil {
+					facet.Min = int64(*b.From)
+				}
+				if b.To != nil {
+					facet.Max = int64(*b.To)
+				}
+			case "Date":
+				// Dates are returned as milliseconds
+				if b.From != nil {
+					facet.Start = int32(*b.From / 1000)
+				}
+				if b.To != nil {
+					facet.End = int32(*b.To / 1000)
+				}
+			}
+			facets <- facet
+		}
+	}
+
+	// Manual facet gathering for fname / content
+	basenameFacet := &tree.SearchFacet{FieldName: "Basename", Label: "found.basename", Count: 0}
+	contentFacet := &tree.SearchFacet{FieldName: "TextContent", Label: "found.contents", Count: 0}
+
+	for _, hit := range resp.Hits.Hits {
+		doc := hit.Source
+		node := &tree.Node{
+			Uuid:  doc.Uuid,
+			Path:  doc.Path,
+			Size:  doc.Size,
+			MTime: doc.ModifTime,
+		}
+		if node.Uuid == "" {
+			node.Uuid = hit.ID
+		}
+		if doc.Basename != "" {
+			node.SetMeta("name", doc.Basename)
+		}
+		if doc.NodeType == "file" {
+			node.Type = tree.NodeType_LEAF
+		} else if doc.NodeType == "folder" {
+			node.Type = tree.NodeType_COLLECTION
+		}
+		for k := range hit.Highlight {
+			if k == "TextContent" {
+				node.SetMeta("document_content_hit", true)
+				contentFacet.Count++
+			} else if strings.HasPrefix(k, "Basename") {
+				basenameFacet.Count++
+			}
+		}
+		resultChan <- node
+	}
+
+	if contentFacet.Count > 0 {
+		facets <- contentFacet
+	}
+	if basenameFacet.Count > 0 {
+		facets <- basenameFacet
+	}
+
+	doneChan <- true
+	return nil
+}
@@ -44,6 +44,31 @@ var ExposedConfigs = &forms.Form{
 	I18NBundle: lang.Bundle(),
 	Groups: []*forms.Group{{
 		Fields: []forms.Field{
+			&forms.FormField{
+				Name:        "engine",
+				Type:        forms.ParamSelect,
+				Label:       "Search.Config.Engine.Label",
+				Description: "Search.Config.Engine.Description",
+				Default:     EngineBleve,
+				Mandatory:   true,
+				ChoicePresetList: []map[string]string{
+					{EngineBleve: "Bleve (embedded)"},
+					{EngineOpenSearch: "OpenSearch / Elasticsearch"},
+				},
+			},
+			&forms.FormField{
+				Name:        "opensearchUrl",
+				Type:        forms.ParamString,
+				Label:       "Search.Config.OpenSearchUrl.Label",
+				Description: "Search.Config.OpenSearchUrl.Description",
+			},
+			&forms.FormField{
+				Name:        "opensearchIndex",
+				Type:        forms.ParamString,
+				Label:       "Search.Config.OpenSearchIndex.Label",
+				Description: "Search.Config.OpenSearchIndex.Description",
+				Default:     "cells-search",
+			},
 			&forms.FormField{
 				Name:             "basenameAnalyzer",
 				Type:             forms.ParamSelect,
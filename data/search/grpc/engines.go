@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/data/search/dao"
+	"github.com/pydio/cells/data/search/dao/bleve"
+	"github.com/pydio/cells/data/search/dao/opensearch"
+	"github.com/pydio/cells/x/configx"
+)
+
+const (
+	EngineBleve      = "bleve"
+	EngineOpenSearch = "opensearch"
+)
+
+// EngineName reads the engine type from the service configuration, defaulting to bleve.
+func EngineName(cfg configx.Values) string {
+	if name := cfg.Val("engine").String(); name != "" {
+		return name
+	}
+	return EngineBleve
+}
+
+// NewEngine opens the search engine selected by the service configuration.
+func NewEngine(cfg configx.Values) (dao.SearchEngine, error) {
+
+	indexContent := cfg.Val("indexContent").Bool()
+
+	switch name := EngineName(cfg); name {
+	case EngineBleve:
+		dir, _ := config.ServiceDataDir(Name)
+		bleve.BleveIndexPath = filepath.Join(dir, "searchengine.bleve")
+		bleveConfs := make(map[string]interface{})
+		bleveConfs["basenameAnalyzer"] = cfg.Val("basenameAnalyzer").String()
+		bleveConfs["contentAnalyzer"] = cfg.Val("contentAnalyzer").String()
+		return bleve.NewBleveEngine(indexContent, bleveConfs)
+	case EngineOpenSearch:
+		return opensearch.NewEngine(cfg.Val("opensearchUrl").String(), cfg.Val("opensearchIndex").String(), indexContent)
+	default:
+		return nil, fmt.Errorf("unknown search engine type %s", name)
+	}
+
+}
+
+// flusher is implemented by engines batching their operations, to send them synchronously.
+type flusher interface {
+	Flush() error
+}
+
+// teeEngine is used while migrating from one engine to another: searches are still
+// served by the current engine, but indexation events are sent to both.
+type teeEngine struct {
+	dao.SearchEngine
+	target dao.SearchEngine
+}
+
+func (t *teeEngine) IndexNode(ctx context.Context, n *tree.Node, reloadCore bool, excludes map[string]struct{}) error {
+	t.target.IndexNode(ctx, n, reloadCore, excludes)
+	return t.SearchEngine.IndexNode(ctx, n, reloadCore, excludes)
+}
+
+func (t *teeEngine) DeleteNode(ctx context.Context, n *tree.Node) error {
+	t.target.DeleteNode(ctx, n)
+	return t.SearchEngine.DeleteNode(ctx, n)
+}
@@ -25,7 +25,6 @@ package grpc
 
 import (
 	"context"
-	"path/filepath"
 
 	servicecontext "github.com/pydio/cells/common/service/context"
 
@@ -37,7 +36,6 @@ import (
 	"github.com/pydio/cells/common/proto/sync"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
-	"github.com/pydio/cells/data/search/dao/bleve"
 )
 
 var (
@@ -61,21 +59,14 @@ func init() {
 
 				cfg := servicecontext.GetConfig(m.Options().Context)
 
-				indexContent := cfg.Val("indexContent").Bool()
-
-				dir, _ := config.ServiceDataDir(Name)
-				bleve.BleveIndexPath = filepath.Join(dir, "searchengine.bleve")
-				bleveConfs := make(map[string]interface{})
-				bleveConfs["basenameAnalyzer"] = cfg.Val("basenameAnalyzer").String()
-				bleveConfs["contentAnalyzer"] = cfg.Val("contentAnalyzer").String()
-
-				bleveEngine, err := bleve.NewBleveEngine(indexContent, bleveConfs)
+				engine, err := NewEngine(cfg)
 				if err != nil {
 					return err
 				}
 
 				server := &SearchServer{
-					Engine:           bleveEngine,
+					Engine:           engine,
+					EngineName:       EngineName(cfg),
 					TreeClient:       tree.NewNodeProviderClient(common.ServiceGrpcNamespace_+common.ServiceTree, defaults.NewClient()),
 					ReIndexThrottler: make(chan struct{}, 5),
 				}
@@ -84,7 +75,7 @@ func init() {
 				sync.RegisterSyncEndpointHandler(m.Options().Server, server)
 
 				m.Init(
-					micro.BeforeStop(bleveEngine.Close),
+					micro.BeforeStop(server.Close),
 				)
 
 				// Register Subscribers
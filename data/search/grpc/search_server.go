@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/event"
 	"github.com/pydio/cells/common/log"
 	protosync "github.com/pydio/cells/common/proto/sync"
@@ -43,10 +44,31 @@ import (
 // SearchServer implements GRPC server for index/search
 type SearchServer struct {
 	Engine           dao.SearchEngine
+	EngineName       string
 	eventsChannel    chan *event.EventWithContext
 	TreeClient       tree.NodeProviderClient
 	NsProvider       *meta.NamespacesProvider
 	ReIndexThrottler chan struct{}
+
+	engineLock sync.RWMutex
+	migrating  bool
+}
+
+func (s *SearchServer) getEngine() dao.SearchEngine {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	return s.Engine
+}
+
+func (s *SearchServer) setEngine(engine dao.SearchEngine) {
+	s.engineLock.Lock()
+	s.Engine = engine
+	s.engineLock.Unlock()
+}
+
+// Close closes the current engine.
+func (s *SearchServer) Close() error {
+	return s.getEngine().Close()
 }
 
 // CreateNodeChangeSubscriber that will treat events for the meta server
@@ -83,6 +105,7 @@ func (s *SearchServer) processEvent(ctx context.Context, e *tree.NodeChangeEvent
 
 	log.Logger(ctx).Debug("processEvent", zap.Any("event", e))
 	excludes := s.NamespacesProvider().ExcludeIndexes()
+	engine := s.getEngine()
 
 	switch e.GetType() {
 	case tree.NodeChangeEvent_CREATE:
@@ -90,14 +113,14 @@ func (s *SearchServer) processEvent(ctx context.Context, e *tree.NodeChangeEvent
 		if e.Target.Etag == common.NodeFlagEtagTemporary || tree.IgnoreNodeForOutput(ctx, e.Target) {
 			break
 		}
-		s.Engine.IndexNode(ctx, e.Target, false, excludes)
+		engine.IndexNode(ctx, e.Target, false, excludes)
 		break
 	case tree.NodeChangeEvent_UPDATE_PATH:
 		// Let's extract the basic information from the tree and store it
 		if tree.IgnoreNodeForOutput(ctx, e.Target) {
 			break
 		}
-		s.Engine.IndexNode(ctx, e.Target, false, excludes)
+		engine.IndexNode(ctx, e.Target, false, excludes)
 		if !e.Target.IsLeaf() {
 			go s.ReindexFolder(ctx, e.Target, excludes)
 		}
@@ -107,28 +130,28 @@ func (s *SearchServer) processEvent(ctx context.Context, e *tree.NodeChangeEvent
 		if e.Target.Path != "" && tree.IgnoreNodeForOutput(ctx, e.Target) {
 			break
 		}
-		s.Engine.IndexNode(ctx, e.Target, false, excludes)
+		engine.IndexNode(ctx, e.Target, false, excludes)
 		break
 	case tree.NodeChangeEvent_UPDATE_USER_META:
 		// Let's extract the basic information from the tree and store it
 		if e.Target.Path != "" && tree.IgnoreNodeForOutput(ctx, e.Target) {
 			break
 		}
-		s.Engine.IndexNode(ctx, e.Target, true, excludes)
+		engine.IndexNode(ctx, e.Target, true, excludes)
 		break
 	case tree.NodeChangeEvent_UPDATE_CONTENT:
 		// We may have to store the metadata again
 		if tree.IgnoreNodeForOutput(ctx, e.Target) {
 			break
 		}
-		s.Engine.IndexNode(ctx, e.Target, false, excludes)
+		engine.IndexNode(ctx, e.Target, false, excludes)
 		break
 	case tree.NodeChangeEvent_DELETE:
 		// Lets delete all metadata
 		if tree.IgnoreNodeForOutput(ctx, e.Source) {
 			break
 		}
-		s.Engine.DeleteNode(ctx, e.Source)
+		engine.DeleteNode(ctx, e.Source)
 	default:
 		log.Logger(ctx).Error("Could not recognize event type", zap.Any("type", e.GetType()))
 	}
@@ -184,7 +207,7 @@ func (s *SearchServer) Search(ctx context.Context, req *tree.SearchRequest, stre
 		}
 	}()
 
-	err := s.Engine.SearchNodes(ctx, req.GetQuery(), req.GetFrom(), req.GetSize(), resultsChan, facetsChan, doneChan)
+	err := s.getEngine().SearchNodes(ctx, req.GetQuery(), req.GetFrom(), req.GetSize(), resultsChan, facetsChan, doneChan)
 	if err != nil {
 		return err
 	}
@@ -192,34 +215,72 @@ func (s *SearchServer) Search(ctx context.Context, req *tree.SearchRequest, stre
 	return nil
 }
 
+// TriggerResync clears and rebuilds the index from the tree. If the configured engine differs from the running one,
+// the new engine is fully indexed before replacing the current one, which is used for searches in the meantime.
 func (s *SearchServer) TriggerResync(c context.Context, req *protosync.ResyncRequest, resp *protosync.ResyncResponse) error {
 
+	cfg := config.Get("services", Name)
+	target := s.getEngine()
+	targetName := EngineName(cfg)
+	s.engineLock.Lock()
+	if s.migrating {
+		s.engineLock.Unlock()
+		return errors.Conflict(Name, "a migration to another engine is already running")
+	}
+	currentName := s.EngineName
+	migrate := targetName != currentName
+	s.migrating = migrate
+	s.engineLock.Unlock()
+	if migrate {
+		var e error
+		if target, e = NewEngine(cfg); e != nil {
+			s.engineLock.Lock()
+			s.migrating = false
+			s.engineLock.Unlock()
+			return e
+		}
+		log.Logger(c).Info("Migrating search engine from " + currentName + " to " + targetName)
+	}
+
 	go func() {
 		bg := context.Background()
-		s.Engine.ClearIndex(bg)
+		target.ClearIndex(bg)
+		var current dao.SearchEngine
+		if migrate {
+			current = s.getEngine()
+			s.setEngine(&teeEngine{SearchEngine: current, target: target})
+		}
 		excludes := s.NamespacesProvider().ExcludeIndexes()
 
-		dsStream, err := s.TreeClient.ListNodes(bg, &tree.ListNodesRequest{
-			Node:      &tree.Node{Path: ""},
-			Recursive: true,
-		})
+		count, err := s.indexAll(bg, target, excludes)
 		if err != nil {
 			log.Logger(c).Error("Resync", zap.Error(err))
-			return
+		} else {
+			log.Logger(c).Info(fmt.Sprintf("Search Server indexed %d nodes", count))
 		}
-		defer dsStream.Close()
-		var count int
-		for {
-			response, e := dsStream.Recv()
-			if e != nil || response == nil {
-				break
+		if migrate {
+			if err != nil {
+				// Keep current engine
+				s.setEngine(current)
+				target.Close()
+			} else {
+				// Send the operations still pending in the target batch before serving searches with it
+				if f, ok := target.(flusher); ok {
+					if e := f.Flush(); e != nil {
+						log.Logger(c).Error("Could not flush new search engine before swapping", zap.Error(e))
+					}
+				}
+				s.setEngine(target)
+				current.Close()
+				log.Logger(c).Info("Search engine is now " + targetName)
 			}
-			if !strings.HasPrefix(response.Node.GetUuid(), "DATASOURCE:") && !tree.IgnoreNodeForOutput(c, response.Node) {
-				s.Engine.IndexNode(bg, response.Node, false, excludes)
-				count++
+			s.engineLock.Lock()
+			if err == nil {
+				s.EngineName = targetName
 			}
+			s.migrating = false
+			s.engineLock.Unlock()
 		}
-		log.Logger(c).Info(fmt.Sprintf("Search Server indexed %d nodes", count))
 
 	}()
 
@@ -228,6 +289,30 @@ func (s *SearchServer) TriggerResync(c context.Context, req *protosync.ResyncReq
 	return nil
 }
 
+// indexAll lists the whole tree and sends all nodes to the given engine.
+func (s *SearchServer) indexAll(ctx context.Context, engine dao.SearchEngine, excludes map[string]struct{}) (int, error) {
+	dsStream, err := s.TreeClient.ListNodes(ctx, &tree.ListNodesRequest{
+		Node:      &tree.Node{Path: ""},
+		Recursive: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer dsStream.Close()
+	var count int
+	for {
+		response, e := dsStream.Recv()
+		if e != nil || response == nil {
+			break
+		}
+		if !strings.HasPrefix(response.Node.GetUuid(), "DATASOURCE:") && !tree.IgnoreNodeForOutput(ctx, response.Node) {
+			engine.IndexNode(ctx, response.Node, false, excludes)
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (s *SearchServer) ReindexFolder(c context.Context, node *tree.Node, excludes map[string]struct{}) {
 
 	s.ReIndexThrottler <- struct{}{}
@@ -251,7 +336,7 @@ func (s *SearchServer) ReindexFolder(c context.Context, node *tree.Node, exclude
 			break
 		}
 		if !strings.HasPrefix(response.Node.GetUuid(), "DATASOURCE:") && !tree.IgnoreNodeForOutput(c, response.Node) {
-			s.Engine.IndexNode(bg, response.Node, false, excludes)
+			s.getEngine().IndexNode(bg, response.Node, false, excludes)
 			count++
 		}
 	}
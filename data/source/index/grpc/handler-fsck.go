@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/sql/index"
+	"github.com/pydio/cells/common/utils/mtree"
+)
+
+// Fsck reports orphans, duplicates and broken materialized paths of the index. If req.Apply is set, explicit
+// fixes, or default fixes when req.AutoFix is set, are applied to the detected issues.
+func (s *TreeServer) Fsck(ctx context.Context, req *sync.FsckRequest, resp *sync.FsckResponse) error {
+	dao := getDAO(ctx, "")
+	issues, losts, err := s.fsckIssues(dao)
+	if err != nil {
+		return err
+	}
+	fixes := make(map[string]*sync.FsckFix, len(req.Fixes))
+	for _, f := range req.Fixes {
+		fixes[f.IssueId] = f
+	}
+	for _, issue := range issues {
+		resp.Issues = append(resp.Issues, issue)
+		if !req.Apply {
+			continue
+		}
+		fix, ok := fixes[issue.Id]
+		if !ok && req.AutoFix && len(issue.Fixes) > 0 {
+			fix, ok = &sync.FsckFix{IssueId: issue.Id, Type: issue.Fixes[0]}, true
+		}
+		if !ok || fix.Type == sync.FsckFixType_NoFix {
+			continue
+		}
+		if e := s.fsckApply(ctx, dao, issue, losts[issue.Id], fix); e != nil {
+			log.Logger(ctx).Error("[Index] Cannot fix "+issue.Id, zap.Error(e))
+			issue.Error = e.Error()
+		} else {
+			log.Logger(ctx).Info("[Index] Fixed " + issue.Id + " with " + fix.Type.String())
+			issue.Applied = fix.Type
+		}
+	}
+	return nil
+}
+
+// fsckIssues lists all issues detected in the index
+func (s *TreeServer) fsckIssues(dao index.DAO) (issues []*sync.FsckIssue, losts map[string]index.LostAndFound, err error) {
+	losts = make(map[string]index.LostAndFound)
+	lostAndFounds, err := dao.LostAndFounds()
+	if err != nil {
+		return nil, nil, err
+	}
+	inDuplicates := make(map[string]struct{})
+	for _, l := range lostAndFounds {
+		uuids := l.GetUUIDs()
+		if len(uuids) == 0 {
+			continue
+		}
+		node, e := dao.GetNodeByUUID(uuids[0])
+		if e != nil || node == nil {
+			continue
+		}
+		issue := &sync.FsckIssue{
+			Uuids: uuids,
+			Name:  node.Name(),
+			MPath: node.MPath.String(),
+			Leaf:  node.IsLeaf(),
+		}
+		if l.IsDuplicate() {
+			issue.Id = "duplicate-" + uuids[0]
+			issue.Type = sync.FsckIssueType_DuplicatePath
+			issue.Path = s.fsckPath(dao, node)
+			issue.Fixes = []sync.FsckFixType{sync.FsckFixType_Delete}
+			for _, id := range uuids {
+				inDuplicates[id] = struct{}{}
+			}
+		} else {
+			// Children of lost nodes are listed as well: only report the top-most ones
+			if p, e := dao.GetNode(node.MPath.Parent()); e == nil && p != nil {
+				continue
+			}
+			issue.Id = "orphan-" + uuids[0]
+			issue.Type = sync.FsckIssueType_Orphan
+			issue.Fixes = []sync.FsckFixType{sync.FsckFixType_Reparent, sync.FsckFixType_Delete}
+		}
+		losts[issue.Id] = l
+		issues = append(issues, issue)
+	}
+
+	broken, err := dao.BrokenMPaths()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, node := range broken {
+		if _, ok := inDuplicates[node.Uuid]; ok {
+			// Duplicates have random hashes, they are handled above
+			continue
+		}
+		issues = append(issues, &sync.FsckIssue{
+			Id:    "mpath-" + node.Uuid,
+			Type:  sync.FsckIssueType_BrokenMPath,
+			Uuids: []string{node.Uuid},
+			Name:  node.Name(),
+			MPath: node.MPath.String(),
+			Path:  s.fsckPath(dao, node),
+			Leaf:  node.IsLeaf(),
+			Fixes: []sync.FsckFixType{sync.FsckFixType_Rehash},
+		})
+	}
+	return issues, losts, nil
+}
+
+// fsckApply applies a fix on an issue
+func (s *TreeServer) fsckApply(ctx context.Context, dao index.DAO, issue *sync.FsckIssue, lost index.LostAndFound, fix *sync.FsckFix) error {
+	if !s.fsckAccepts(issue, fix.Type) {
+		return fmt.Errorf("fix %s cannot be applied to %s", fix.Type.String(), issue.Type.String())
+	}
+	switch fix.Type {
+	case sync.FsckFixType_Rehash:
+		_, e := dao.RehashNodes(issue.Uuids...)
+		return e
+
+	case sync.FsckFixType_Delete:
+		uuids := fix.Uuids
+		if issue.Type == sync.FsckIssueType_DuplicatePath && len(uuids) == 0 {
+			marked, _, e := s.checkACLs(ctx, []index.LostAndFound{lost})
+			if e != nil {
+				return e
+			}
+			if len(marked) == 0 {
+				return fmt.Errorf("all duplicates have ACLs, please select the nodes to delete")
+			}
+			uuids = marked[0].GetUUIDs()
+		} else if len(uuids) == 0 {
+			uuids = issue.Uuids
+		}
+		for _, id := range uuids {
+			if !s.fsckContains(issue.Uuids, id) {
+				return fmt.Errorf("node %s is not part of issue %s", id, issue.Id)
+			}
+			node, e := dao.GetNodeByUUID(id)
+			if e != nil {
+				return e
+			}
+			if node == nil {
+				continue
+			}
+			if e := dao.DelNode(node); e != nil {
+				return e
+			}
+		}
+		if issue.Type == sync.FsckIssueType_DuplicatePath {
+			// Remaining node may have kept a random hash
+			_, e := dao.FixRandHash2()
+			return e
+		}
+		return nil
+
+	case sync.FsckFixType_Reparent:
+		nodeFrom, e := dao.GetNodeByUUID(issue.Uuids[0])
+		if e != nil || nodeFrom == nil {
+			return fmt.Errorf("cannot find node %s", issue.Uuids[0])
+		}
+		parent := safePath(fix.ReparentPath)
+		if parent != "/" {
+			if pp, _, e := dao.Path(parent, false); e != nil || pp == nil {
+				return fmt.Errorf("cannot find folder %s", parent)
+			}
+		}
+		targetPath := path.Join(parent, nodeFrom.Name())
+		if existing, _, _ := dao.Path(targetPath, false); existing != nil {
+			targetPath = path.Join(parent, nodeFrom.Name()+"-"+nodeFrom.Uuid)
+		}
+		pathTo, _, e := dao.Path(targetPath, true)
+		if e != nil {
+			return e
+		}
+		nodeTo, e := dao.GetNode(pathTo)
+		if e != nil {
+			return e
+		}
+		dao.Flush(false)
+		if e := dao.DelNode(nodeTo); e != nil {
+			return e
+		}
+		dao.Flush(false)
+		return dao.MoveNodeTree(nodeFrom, nodeTo)
+	}
+	return nil
+}
+
+// fsckAccepts checks that a fix is allowed for an issue
+func (s *TreeServer) fsckAccepts(issue *sync.FsckIssue, fix sync.FsckFixType) bool {
+	for _, f := range issue.Fixes {
+		if f == fix {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TreeServer) fsckContains(uuids []string, uuid string) bool {
+	for _, id := range uuids {
+		if id == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+// fsckPath rebuilds the path of a node from its parents
+func (s *TreeServer) fsckPath(dao index.DAO, node *mtree.TreeNode) string {
+	var names []string
+	for pnode := range dao.GetNodes(node.MPath.Parents()...) {
+		names = append(names, pnode.Name())
+	}
+	names = append(names, node.Name())
+	return safePath(strings.Join(names, "/"))
+}
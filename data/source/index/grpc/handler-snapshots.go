@@ -0,0 +1,400 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/registry"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	sindex "github.com/pydio/cells/common/sync/endpoints/index"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/data/source/index/snapshots"
+)
+
+// CreateSnapshot captures the whole index in a named snapshot, along with the encryption keys of the files
+// if the datasource is encrypted. Oldest snapshots are pruned if req.Keep is set.
+func (s *TreeServer) CreateSnapshot(ctx context.Context, req *sync.CreateSnapshotRequest, resp *sync.CreateSnapshotResponse) error {
+	store, e := s.snapshotStore(ctx)
+	if e != nil {
+		return e
+	}
+	info, e := store.Create(ctx, req.Name, s.snapshotSource(), s.snapshotKeys())
+	if e != nil {
+		return e
+	}
+	log.Logger(ctx).Info("[Index] Created snapshot "+info.Name, zap.Int64("nodes", info.Nodes), zap.Int64("keys", info.Keys))
+	resp.Snapshot = snapshotToProto(info)
+	if req.Keep > 0 {
+		if resp.Pruned, e = store.Prune(int(req.Keep)); e != nil {
+			return e
+		}
+		if len(resp.Pruned) > 0 {
+			log.Logger(ctx).Info("[Index] Pruned snapshots "+strings.Join(resp.Pruned, ", "), zap.Int32("keep", req.Keep))
+		}
+	}
+	return nil
+}
+
+// ListSnapshots lists all snapshots of the index, oldest first.
+func (s *TreeServer) ListSnapshots(ctx context.Context, req *sync.ListSnapshotsRequest, resp *sync.ListSnapshotsResponse) error {
+	store, e := s.snapshotStore(ctx)
+	if e != nil {
+		return e
+	}
+	infos, e := store.List()
+	if e != nil {
+		return e
+	}
+	for _, info := range infos {
+		resp.Snapshots = append(resp.Snapshots, snapshotToProto(info))
+	}
+	return nil
+}
+
+// DeleteSnapshot removes a snapshot.
+func (s *TreeServer) DeleteSnapshot(ctx context.Context, req *sync.DeleteSnapshotRequest, resp *sync.DeleteSnapshotResponse) error {
+	store, e := s.snapshotStore(ctx)
+	if e != nil {
+		return e
+	}
+	if e := store.Delete(req.Name); e != nil {
+		return e
+	}
+	log.Logger(ctx).Info("[Index] Deleted snapshot " + req.Name)
+	resp.Success = true
+	return nil
+}
+
+// DiffSnapshots lists the changes transforming a snapshot, or the current index, into another one. When
+// req.WithKeys is set, created and updated files carry their encryption key in the target state.
+func (s *TreeServer) DiffSnapshots(ctx context.Context, req *sync.DiffSnapshotsRequest, resp *sync.DiffSnapshotsResponse) error {
+	store, e := s.snapshotStore(ctx)
+	if e != nil {
+		return e
+	}
+	if req.From == req.To {
+		return errors.BadRequest(servicecontext.GetServiceName(ctx), "cannot diff %s with itself", req.From)
+	}
+	from, e := s.snapshotTree(store, req.From)
+	if e != nil {
+		return e
+	}
+	to, e := s.snapshotTree(store, req.To)
+	if e != nil {
+		return e
+	}
+	root := strings.Trim(req.Path, "/")
+	if root == "" {
+		root = "/"
+	}
+	ops, e := snapshots.Diff(ctx, from, to, root)
+	if e != nil {
+		return e
+	}
+	var uuids []string
+	for _, op := range ops {
+		if op.IsTypeData() && op.GetNode() != nil && op.GetNode().Uuid != "" {
+			uuids = append(uuids, op.GetNode().Uuid)
+		}
+	}
+	keys := make(map[string]*encryption.NodeKey, len(uuids))
+	if req.WithKeys && len(uuids) > 0 {
+		if req.To != "" {
+			if keys, e = store.Keys(req.To, uuids...); e != nil {
+				return e
+			}
+		} else if provider := s.snapshotKeys(); provider != nil {
+			for _, u := range uuids {
+				if k, e := provider(ctx, u); e != nil {
+					return e
+				} else if k != nil {
+					keys[u] = k
+				}
+			}
+		}
+	}
+	for _, op := range ops {
+		change := &sync.SnapshotChange{
+			Type: op.Type().String(),
+			Path: op.GetRefPath(),
+			Node: op.GetNode(),
+		}
+		if op.IsTypeMove() {
+			change.MoveFrom = op.GetMoveOriginPath()
+		}
+		if op.IsTypeData() && op.GetNode() != nil {
+			change.NodeKey = keys[op.GetNode().Uuid]
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+	return nil
+}
+
+// snapshotStore returns the snapshots store, or an error if the service was not set up with one.
+func (s *TreeServer) snapshotStore(ctx context.Context) (*snapshots.Store, error) {
+	if s.snapshots == nil {
+		return nil, errors.InternalServerError(servicecontext.GetServiceName(ctx), "snapshots are not available on this index")
+	}
+	return s.snapshots, nil
+}
+
+// snapshotSource returns the current index as a sync endpoint, by querying the service itself.
+func (s *TreeServer) snapshotSource() *sindex.Client {
+	name := common.ServiceGrpcNamespace_ + common.ServiceDataIndex_ + s.DataSourceName
+	return sindex.NewClient(s.DataSourceName,
+		tree.NewNodeProviderClient(name, s.client),
+		tree.NewNodeReceiverClient(name, s.client),
+		tree.NewSessionIndexerClient(name, s.client),
+	)
+}
+
+// snapshotTree returns the named snapshot, or the current index if name is empty.
+func (s *TreeServer) snapshotTree(store *snapshots.Store, name string) (snapshots.Tree, error) {
+	if name == "" {
+		return s.snapshotSource(), nil
+	}
+	return store.Get(name)
+}
+
+// snapshotKeys loads node keys from the key service when the datasource is encrypted, and returns nil otherwise.
+func (s *TreeServer) snapshotKeys() snapshots.KeyProvider {
+	ds, ok := config.ListSourcesFromConfig()[s.DataSourceName]
+	if !ok || ds.EncryptionMode == object.EncryptionMode_CLEAR {
+		return nil
+	}
+	keyUser := "ds:" + s.DataSourceName
+	keyClient := encryption.NewNodeKeyManagerClient(registry.GetClient(common.ServiceEncKey))
+	return func(ctx context.Context, nodeUuid string) (*encryption.NodeKey, error) {
+		rsp, e := keyClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{NodeId: nodeUuid, UserId: keyUser})
+		if e != nil {
+			if code := errors.Parse(e.Error()).Code; code == 404 || code == 403 {
+				return nil, nil
+			}
+			return nil, e
+		}
+		return rsp.GetNodeInfo().GetNodeKey(), nil
+	}
+}
+
+// inSnapshots tells whether p is inside the reserved snapshots folder, and splits it into the snapshot name
+// and the path inside this snapshot. A real node indexed with the same name takes precedence over the
+// snapshots folder, which is then hidden.
+func (s *TreeServer) inSnapshots(ctx context.Context, p string) (name string, inner string, ok bool) {
+	p = strings.Trim(p, "/")
+	if s.snapshots == nil || (p != snapshots.Folder && !strings.HasPrefix(p, snapshots.Folder+"/")) {
+		return "", "", false
+	}
+	if s.snapshotsShadowed(ctx) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimPrefix(p, snapshots.Folder), "/"), "/", 2)
+	name = parts[0]
+	if len(parts) > 1 {
+		inner = parts[1]
+	}
+	return name, inner, true
+}
+
+// snapshotsAllowed restricts the snapshots folder to admins and internal calls: snapshots expose the
+// whole datasource, whatever the ACLs of the users.
+func (s *TreeServer) snapshotsAllowed(ctx context.Context) bool {
+	userName, claims := permissions.FindUserNameInContext(ctx)
+	if claims.Name != "" {
+		return claims.Profile == common.PydioProfileAdmin
+	}
+	return userName == "" || userName == common.PydioSystemUsername
+}
+
+// snapshotsShadowed checks if a real node is indexed at the path of the snapshots folder.
+func (s *TreeServer) snapshotsShadowed(ctx context.Context) bool {
+	mPath, _, e := getDAO(ctx, "").Path("/"+snapshots.Folder, false)
+	return e == nil && mPath != nil
+}
+
+// snapshotsReadOnly rejects modifications inside the snapshots folder. The folder itself can still be
+// created, for instance when it is found on the storage, and then shadows the snapshots.
+func (s *TreeServer) snapshotsReadOnly(ctx context.Context, paths ...string) error {
+	for _, p := range paths {
+		if name, _, ok := s.inSnapshots(ctx, p); ok && name != "" {
+			return errors.Forbidden(servicecontext.GetServiceName(ctx), "snapshots are read-only, cannot modify %s", p)
+		}
+	}
+	return nil
+}
+
+// readSnapshotNode loads a node of the snapshots folder: the folder itself, the root of a snapshot or a node inside it.
+// Returned nodes have a uuid derived from their original one, and their path rewritten inside the snapshots folder.
+func (s *TreeServer) readSnapshotNode(ctx context.Context, name, inner string) (*tree.Node, error) {
+	if !s.snapshotsAllowed(ctx) {
+		return nil, errors.NotFound(servicecontext.GetServiceName(ctx), "cannot find node %s", path.Join(snapshots.Folder, name, inner))
+	}
+	var node *tree.Node
+	if name == "" {
+		node = &tree.Node{
+			Uuid: "snapshots-" + s.DataSourceName,
+			Path: "/" + snapshots.Folder,
+			Type: tree.NodeType_COLLECTION,
+			Etag: "-1",
+		}
+	} else if info, e := s.snapshots.Info(name); e != nil {
+		return nil, e
+	} else if inner == "" {
+		node = snapshotRootNode(s.DataSourceName, info)
+	} else {
+		snap, e := s.snapshots.Get(name)
+		if e != nil {
+			return nil, e
+		}
+		n, e := snap.LoadNode(ctx, inner)
+		if e != nil {
+			return nil, errors.NotFound(servicecontext.GetServiceName(ctx), "cannot find %s in snapshot %s", inner, name)
+		}
+		node = n.Clone()
+		node.Uuid = snapshotNodeUuid(name, n.Uuid)
+		node.Path = "/" + path.Join(snapshots.Folder, name, inner)
+	}
+	node.SetMeta(common.MetaNamespaceDatasourceName, s.DataSourceName)
+	return node, nil
+}
+
+// listSnapshotNodes lists the children of a node of the snapshots folder, or its ancestors.
+func (s *TreeServer) listSnapshotNodes(ctx context.Context, name, inner string, req *tree.ListNodesRequest, resp tree.NodeProvider_ListNodesStream) error {
+	if !s.snapshotsAllowed(ctx) {
+		return errors.NotFound(servicecontext.GetServiceName(ctx), "cannot find node %s", path.Join(snapshots.Folder, name, inner))
+	}
+	if req.Ancestors {
+		var ancestors []string
+		if inner != "" {
+			for p := path.Dir(inner); p != "." && p != "/"; p = path.Dir(p) {
+				ancestors = append(ancestors, p)
+			}
+			ancestors = append(ancestors, "")
+		}
+		for _, p := range ancestors {
+			n, e := s.readSnapshotNode(ctx, name, p)
+			if e != nil {
+				return e
+			}
+			resp.Send(&tree.ListNodesResponse{Node: n})
+		}
+		if name != "" {
+			n, _ := s.readSnapshotNode(ctx, "", "")
+			resp.Send(&tree.ListNodesResponse{Node: n})
+		}
+		rootResp := &tree.ReadNodeResponse{}
+		if e := s.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: "/"}}, rootResp); e != nil {
+			return e
+		}
+		resp.Send(&tree.ListNodesResponse{Node: rootResp.Node})
+		return nil
+	}
+
+	metaFilter := tree.NewMetaFilter(req.GetNode())
+	hasFilter := metaFilter.Parse()
+	send := func(n *tree.Node) {
+		if req.FilterType == tree.NodeType_COLLECTION && n.IsLeaf() || req.FilterType == tree.NodeType_LEAF && !n.IsLeaf() {
+			return
+		}
+		if hasFilter && !metaFilter.Match(path.Base(n.Path), n) {
+			return
+		}
+		resp.Send(&tree.ListNodesResponse{Node: n})
+	}
+	var names []string
+	if name == "" {
+		infos, e := s.snapshots.List()
+		if e != nil {
+			return e
+		}
+		for _, info := range infos {
+			send(snapshotRootNode(s.DataSourceName, info))
+			names = append(names, info.Name)
+		}
+		if !req.Recursive {
+			return nil
+		}
+	} else {
+		names = []string{name}
+	}
+	for _, n := range names {
+		snap, e := s.snapshots.Get(n)
+		if e != nil {
+			return e
+		}
+		root := inner
+		if root == "" {
+			root = "/"
+		}
+		prefix := "/" + path.Join(snapshots.Folder, n)
+		e = snap.Walk(func(p string, node *tree.Node, err error) {
+			node.Uuid = snapshotNodeUuid(n, node.Uuid)
+			node.Path = path.Join(prefix, p)
+			node.SetMeta(common.MetaNamespaceDatasourceName, s.DataSourceName)
+			send(node)
+		}, root, req.Recursive)
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// snapshotRootNode builds the folder node representing the root of a snapshot.
+func snapshotRootNode(dsName string, info *snapshots.Info) *tree.Node {
+	node := &tree.Node{
+		Uuid:  "snapshot-" + dsName + "-" + info.Name,
+		Path:  "/" + path.Join(snapshots.Folder, info.Name),
+		Type:  tree.NodeType_COLLECTION,
+		Etag:  "-1",
+		Size:  info.Size,
+		MTime: info.CreatedAt,
+	}
+	node.SetMeta(common.MetaNamespaceDatasourceName, dsName)
+	return node
+}
+
+// snapshotNodeUuid derives the uuid of a node seen inside a snapshot, so that it never collides with the live node.
+func snapshotNodeUuid(name, uuid string) string {
+	return "snapshot-" + name + "-" + uuid
+}
+
+func snapshotToProto(info *snapshots.Info) *sync.IndexSnapshot {
+	return &sync.IndexSnapshot{
+		Name:      info.Name,
+		CreatedAt: int32(info.CreatedAt),
+		Nodes:     info.Nodes,
+		Size:      info.Size,
+		Keys:      info.Keys,
+	}
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/pydio/cells/common/utils/mtree"
 	"github.com/pydio/cells/data/source/index"
 	"github.com/pydio/cells/data/source/index/sessions"
+	"github.com/pydio/cells/data/source/index/snapshots"
 )
 
 // TreeServer definition.
@@ -51,6 +52,7 @@ type TreeServer struct {
 	DataSourceName string
 	client         client.Client
 	sessionStore   sessions.DAO
+	snapshots      *snapshots.Store
 }
 
 /* =============================================================================
@@ -112,6 +114,10 @@ func (s *TreeServer) CreateNode(ctx context.Context, req *tree.CreateNodeRequest
 		}
 	}()
 
+	if err = s.snapshotsReadOnly(ctx, req.GetNode().GetPath()); err != nil {
+		return err
+	}
+
 	dao := getDAO(ctx, req.GetIndexationSession())
 	name := servicecontext.GetServiceName(ctx)
 
@@ -232,6 +238,16 @@ func (s *TreeServer) ReadNode(ctx context.Context, req *tree.ReadNodeRequest, re
 
 	defer track(ctx, "ReadNode", time.Now(), req, resp)
 
+	if snapName, inner, ok := s.inSnapshots(ctx, req.GetNode().GetPath()); ok {
+		node, err := s.readSnapshotNode(ctx, snapName, inner)
+		if err != nil {
+			return err
+		}
+		resp.Success = true
+		resp.Node = node
+		return nil
+	}
+
 	var session = ""
 	md, has := metadata.FromContext(ctx)
 	if has {
@@ -325,6 +341,10 @@ func (s *TreeServer) ListNodes(ctx context.Context, req *tree.ListNodesRequest,
 		return errors.InternalServerError(name, "Please use either Recursive (children) or Ancestors (parents) flag, but not both.")
 	}
 
+	if snapName, inner, ok := s.inSnapshots(ctx, req.GetNode().GetPath()); ok {
+		return s.listSnapshotNodes(ctx, snapName, inner, req, resp)
+	}
+
 	var c chan *mtree.TreeNode
 
 	// Special case for  "Ancestors", node can have either Path or Uuid
@@ -458,6 +478,10 @@ func (s *TreeServer) UpdateNode(ctx context.Context, req *tree.UpdateNodeRequest
 	}()
 
 	// dao := servicecontext.GetDAO(ctx).(index.DAO)
+	if err = s.snapshotsReadOnly(ctx, req.GetFrom().GetPath(), req.GetTo().GetPath()); err != nil {
+		return err
+	}
+
 	dao := getDAO(ctx, req.GetIndexationSession())
 	name := servicecontext.GetServiceName(ctx)
 
@@ -538,6 +562,10 @@ func (s *TreeServer) DeleteNode(ctx context.Context, req *tree.DeleteNodeRequest
 		}
 	}()
 
+	if err = s.snapshotsReadOnly(ctx, req.GetNode().GetPath()); err != nil {
+		return err
+	}
+
 	dao := getDAO(ctx, req.GetIndexationSession())
 	name := servicecontext.GetServiceName(ctx)
 
@@ -24,14 +24,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 
 	json "github.com/pydio/cells/x/jsonx"
 
+	errors2 "github.com/micro/go-micro/errors"
 	"github.com/micro/go-micro/server"
 	"github.com/micro/go-plugins/broker/nats"
 	"github.com/micro/go-plugins/registry/memory"
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/sql"
 	"github.com/pydio/cells/x/configx"
@@ -39,6 +44,7 @@ import (
 	defaults "github.com/pydio/cells/common/micro"
 	servicecontext "github.com/pydio/cells/common/service/context"
 	"github.com/pydio/cells/data/source/index"
+	"github.com/pydio/cells/data/source/index/snapshots"
 
 	. "github.com/smartystreets/goconvey/convey"
 	// SQLite Driver
@@ -580,6 +586,41 @@ func TestIndex(t *testing.T) {
 	})
 }
 
+func TestSnapshotsFolder(t *testing.T) {
+
+	Convey("Real nodes take precedence over the snapshots folder", t, func() {
+		dir, e := ioutil.TempDir("", "index-snapshots")
+		So(e, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		store, e := snapshots.NewStore(dir)
+		So(e, ShouldBeNil)
+		defer store.Close()
+
+		s := NewTreeServer("snapds")
+		s.snapshots = store
+
+		resp, _ := send(s, "GetNode", &tree.ReadNodeRequest{Node: &tree.Node{Path: "/" + snapshots.Folder}})
+		So(resp.(*tree.ReadNodeResponse).Node.Uuid, ShouldEqual, "snapshots-snapds")
+
+		userCtx := context.WithValue(ctx, claim.ContextKey, claim.Claims{Name: "user", Profile: common.PydioProfileStandard})
+		e = s.ReadNode(userCtx, &tree.ReadNodeRequest{Node: &tree.Node{Path: "/" + snapshots.Folder}}, &tree.ReadNodeResponse{})
+		So(errors2.Parse(e.Error()).Code, ShouldEqual, 404)
+		adminCtx := context.WithValue(ctx, claim.ContextKey, claim.Claims{Name: "admin", Profile: common.PydioProfileAdmin})
+		So(s.ReadNode(adminCtx, &tree.ReadNodeRequest{Node: &tree.Node{Path: "/" + snapshots.Folder}}, &tree.ReadNodeResponse{}), ShouldBeNil)
+
+		_, e = send(s, "CreateNode", &tree.CreateNodeRequest{Node: &tree.Node{Uuid: "inside", Path: "/" + snapshots.Folder + "/inside", Type: tree.NodeType_LEAF}})
+		So(e, ShouldNotBeNil)
+
+		_, e = send(s, "CreateNode", &tree.CreateNodeRequest{Node: &tree.Node{Uuid: "real-snapshots", Path: "/" + snapshots.Folder, Type: tree.NodeType_COLLECTION}})
+		So(e, ShouldBeNil)
+		resp, _ = send(s, "GetNode", &tree.ReadNodeRequest{Node: &tree.Node{Path: "/" + snapshots.Folder}})
+		So(resp.(*tree.ReadNodeResponse).Node.Uuid, ShouldEqual, "real-snapshots")
+
+		_, e = send(s, "CreateNode", &tree.CreateNodeRequest{Node: &tree.Node{Uuid: "inside", Path: "/" + snapshots.Folder + "/inside", Type: tree.NodeType_LEAF}})
+		So(e, ShouldBeNil)
+	})
+}
+
 /*
 // TODO
 func TestMassiveOperations(t *testing.T) {
@@ -25,6 +25,7 @@ package grpc
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 
 	"github.com/pydio/cells/common/proto/sync"
@@ -38,6 +39,7 @@ import (
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
 	"github.com/pydio/cells/data/source/index"
+	"github.com/pydio/cells/data/source/index/snapshots"
 )
 
 func init() {
@@ -69,6 +71,15 @@ func init() {
 					source := server.Options().Metadata["source"]
 
 					engine := NewTreeServer(source)
+					if dir, e := config.ServiceDataDir(common.ServiceGrpcNamespace_ + name); e == nil {
+						if store, e := snapshots.NewStore(filepath.Join(dir, "snapshots")); e == nil {
+							engine.snapshots = store
+							m.Init(micro.BeforeStop(func() error {
+								store.Close()
+								return nil
+							}))
+						}
+					}
 					tree.RegisterNodeReceiverHandler(m.Options().Server, engine)
 					tree.RegisterNodeProviderHandler(m.Options().Server, engine)
 					tree.RegisterNodeReceiverStreamHandler(m.Options().Server, engine)
@@ -76,6 +87,8 @@ func init() {
 					tree.RegisterSessionIndexerHandler(m.Options().Server, engine)
 					object.RegisterResourceCleanerEndpointHandler(m.Options().Server, engine)
 					sync.RegisterSyncEndpointHandler(m.Options().Server, engine)
+					sync.RegisterIndexFsckHandler(m.Options().Server, engine)
+					sync.RegisterIndexSnapshotsHandler(m.Options().Server, engine)
 
 					return nil
 				}),
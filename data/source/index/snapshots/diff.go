@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package snapshots
+
+import (
+	"context"
+
+	"github.com/pydio/cells/common/sync/merger"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// Tree is a tree of nodes that can be both walked and updated, typically a snapshot or the index itself.
+type Tree interface {
+	model.PathSyncSource
+	model.PathSyncTarget
+}
+
+var opsOrder = [][]merger.OperationType{
+	{merger.OpCreateFolder},
+	{merger.OpMoveFolder},
+	{merger.OpMoveFile},
+	{merger.OpCreateFile, merger.OpUpdateFile},
+	{merger.OpDelete},
+}
+
+// Diff computes the operations transforming the tree of from into the tree of to, restricted to the subtree
+// at root. It relies on a merger.TreeDiff, whose patch is filtered to detect moves. Operations are returned in
+// the order they must be applied: folder creations, moves, file creations and updates, then deletions.
+func Diff(ctx context.Context, from Tree, to model.PathSyncSource, root string) ([]merger.Operation, error) {
+	diff := merger.NewTreeDiff(ctx, to, from)
+	if e := diff.Compute(root, nil, nil); e != nil {
+		return nil, e
+	}
+	patch := merger.NewPatch(to, from, merger.PatchOptions{MoveDetection: true})
+	if e := diff.ToUnidirectionalPatch(model.DirectionRight, patch); e != nil {
+		return nil, e
+	}
+	patch.Filter(ctx)
+	var ops []merger.Operation
+	for _, types := range opsOrder {
+		patch.WalkOperations(types, func(op merger.Operation) {
+			ops = append(ops, op)
+		})
+	}
+	return ops, nil
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package snapshots stores named snapshots of a datasource index, along with the encryption keys of its files.
+package snapshots
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/etcd-io/bbolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/snapshot"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+const (
+	// Folder is the reserved folder of the index under which snapshots are browsed.
+	Folder = ".snapshots"
+
+	infoFile  = "snapshot.json"
+	keysFile  = "keys.db"
+	nameStamp = "2006-01-02T15-04-05"
+)
+
+var (
+	keysBucket = []byte("keys")
+	validName  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+)
+
+// Info describes a snapshot.
+type Info struct {
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"createdAt"`
+	Nodes     int64  `json:"nodes"`
+	Size      int64  `json:"size"`
+	Keys      int64  `json:"keys"`
+}
+
+// KeyProvider loads the encryption key of a node. It returns nil if the node is not encrypted.
+type KeyProvider func(ctx context.Context, nodeUuid string) (*encryption.NodeKey, error)
+
+// Store manages snapshots in subfolders of a directory. Each snapshot is a BoltSnapshot of the
+// index tree, plus a bolt database of the node keys.
+type Store struct {
+	dir    string
+	lock   *sync.Mutex
+	opened map[string]*snapshot.BoltSnapshot
+}
+
+// NewStore creates a Store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if e := os.MkdirAll(dir, 0755); e != nil {
+		return nil, e
+	}
+	return &Store{
+		dir:    dir,
+		lock:   &sync.Mutex{},
+		opened: make(map[string]*snapshot.BoltSnapshot),
+	}, nil
+}
+
+// Create captures the whole tree of source in a new snapshot. If keys is not nil, it is called for each file
+// to store its encryption key. An empty name is replaced by the current date.
+func (s *Store) Create(ctx context.Context, name string, source model.PathSyncSource, keys KeyProvider) (*Info, error) {
+	if name == "" {
+		name = time.Now().Format(nameStamp)
+	}
+	if !validName.MatchString(name) {
+		return nil, errors.BadRequest(common.ServiceDataIndex, "invalid snapshot name %s", name)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	folder := filepath.Join(s.dir, name)
+	if _, e := os.Stat(folder); e == nil {
+		return nil, errors.Conflict(common.ServiceDataIndex, "snapshot %s already exists", name)
+	}
+	if e := os.MkdirAll(folder, 0755); e != nil {
+		return nil, e
+	}
+	snap, e := snapshot.NewBoltSnapshot(folder, name)
+	if e != nil {
+		os.RemoveAll(folder)
+		return nil, e
+	}
+	info := &Info{Name: name, CreatedAt: time.Now().Unix()}
+	if e := s.capture(ctx, snap, source, keys, info); e != nil {
+		snap.Close(true)
+		return nil, e
+	}
+	s.opened[name] = snap
+	return info, nil
+}
+
+func (s *Store) capture(ctx context.Context, snap *snapshot.BoltSnapshot, source model.PathSyncSource, keys KeyProvider, info *Info) error {
+	if e := snap.Capture(ctx, source); e != nil {
+		return e
+	}
+	var leaves []string
+	snap.Walk(func(path string, node *tree.Node, err error) {
+		info.Nodes++
+		if node.IsLeaf() {
+			info.Size += node.Size
+			if node.Uuid != "" {
+				leaves = append(leaves, node.Uuid)
+			}
+		}
+	}, "/", true)
+	if keys != nil && len(leaves) > 0 {
+		db, e := openKeys(filepath.Join(s.dir, info.Name))
+		if e != nil {
+			return e
+		}
+		defer db.Close()
+		if e := db.Update(func(tx *bbolt.Tx) error {
+			b, e := tx.CreateBucketIfNotExists(keysBucket)
+			if e != nil {
+				return e
+			}
+			for _, u := range leaves {
+				k, e := keys(ctx, u)
+				if e != nil {
+					return e
+				}
+				if k == nil {
+					continue
+				}
+				data, e := proto.Marshal(k)
+				if e != nil {
+					return e
+				}
+				if e := b.Put([]byte(u), data); e != nil {
+					return e
+				}
+				info.Keys++
+			}
+			return nil
+		}); e != nil {
+			return e
+		}
+	}
+	data, _ := json.Marshal(info)
+	return ioutil.WriteFile(filepath.Join(s.dir, info.Name, infoFile), data, 0644)
+}
+
+// List returns all snapshots, oldest first.
+func (s *Store) List() ([]*Info, error) {
+	entries, e := ioutil.ReadDir(s.dir)
+	if e != nil {
+		return nil, e
+	}
+	var infos []*Info
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if info, e := s.readInfo(entry.Name()); e == nil {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].CreatedAt == infos[j].CreatedAt {
+			return infos[i].Name < infos[j].Name
+		}
+		return infos[i].CreatedAt < infos[j].CreatedAt
+	})
+	return infos, nil
+}
+
+// Info returns the description of a snapshot.
+func (s *Store) Info(name string) (*Info, error) {
+	info, e := s.readInfo(name)
+	if e != nil {
+		return nil, errors.NotFound(common.ServiceDataIndex, "cannot find snapshot %s", name)
+	}
+	return info, nil
+}
+
+func (s *Store) readInfo(name string) (*Info, error) {
+	if !validName.MatchString(name) {
+		return nil, errors.BadRequest(common.ServiceDataIndex, "invalid snapshot name %s", name)
+	}
+	data, e := ioutil.ReadFile(filepath.Join(s.dir, name, infoFile))
+	if e != nil {
+		return nil, e
+	}
+	info := &Info{}
+	if e := json.Unmarshal(data, info); e != nil {
+		return nil, e
+	}
+	return info, nil
+}
+
+// Get opens a snapshot for reading. The returned snapshot is shared and must not be closed nor modified.
+func (s *Store) Get(name string) (*snapshot.BoltSnapshot, error) {
+	if _, e := s.Info(name); e != nil {
+		return nil, e
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if snap, ok := s.opened[name]; ok {
+		return snap, nil
+	}
+	snap, e := snapshot.NewBoltSnapshot(filepath.Join(s.dir, name), name)
+	if e != nil {
+		return nil, e
+	}
+	s.opened[name] = snap
+	return snap, nil
+}
+
+// Keys returns the stored encryption keys of the given nodes, indexed by node uuid.
+func (s *Store) Keys(name string, nodeUuids ...string) (map[string]*encryption.NodeKey, error) {
+	if _, e := s.Info(name); e != nil {
+		return nil, e
+	}
+	keys := make(map[string]*encryption.NodeKey, len(nodeUuids))
+	folder := filepath.Join(s.dir, name)
+	if _, e := os.Stat(filepath.Join(folder, keysFile)); e != nil {
+		return keys, nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	db, e := openKeys(folder)
+	if e != nil {
+		return nil, e
+	}
+	defer db.Close()
+	e = db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(keysBucket)
+		if b == nil {
+			return nil
+		}
+		for _, u := range nodeUuids {
+			data := b.Get([]byte(u))
+			if data == nil {
+				continue
+			}
+			k := &encryption.NodeKey{}
+			if e := proto.Unmarshal(data, k); e != nil {
+				return e
+			}
+			keys[u] = k
+		}
+		return nil
+	})
+	return keys, e
+}
+
+// Delete removes a snapshot.
+func (s *Store) Delete(name string) error {
+	if _, e := s.Info(name); e != nil {
+		return e
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if snap, ok := s.opened[name]; ok {
+		delete(s.opened, name)
+		snap.Close(true)
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(s.dir, name))
+}
+
+// Prune deletes the oldest snapshots to keep at most keep snapshots, and returns the deleted names.
+// Nothing is deleted if keep is not positive.
+func (s *Store) Prune(keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	infos, e := s.List()
+	if e != nil {
+		return nil, e
+	}
+	var pruned []string
+	for i := 0; i < len(infos)-keep; i++ {
+		if e := s.Delete(infos[i].Name); e != nil {
+			return pruned, e
+		}
+		pruned = append(pruned, infos[i].Name)
+	}
+	return pruned, nil
+}
+
+// Close closes all opened snapshots.
+func (s *Store) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for name, snap := range s.opened {
+		snap.Close()
+		delete(s.opened, name)
+	}
+}
+
+func openKeys(folder string) (*bbolt.DB, error) {
+	options := bbolt.DefaultOptions
+	options.Timeout = 5 * time.Second
+	return bbolt.Open(filepath.Join(folder, keysFile), 0644, options)
+}
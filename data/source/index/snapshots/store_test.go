@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package snapshots
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pborman/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/memory"
+	"github.com/pydio/cells/common/sync/merger"
+)
+
+func testSource() *memory.MemDB {
+	source := memory.NewMemDB()
+	source.Nodes = []*tree.Node{
+		{Path: "a", Uuid: "ua", Etag: "-1", Type: tree.NodeType_COLLECTION},
+		{Path: "a/f1", Uuid: "u1", Etag: "e1", Size: 10, Type: tree.NodeType_LEAF},
+		{Path: "a/f2", Uuid: "u2", Etag: "e2", Size: 5, Type: tree.NodeType_LEAF},
+		{Path: "b", Uuid: "ub", Etag: "-1", Type: tree.NodeType_COLLECTION},
+	}
+	return source
+}
+
+func TestStore(t *testing.T) {
+
+	ctx := context.Background()
+
+	Convey("Create, browse and delete snapshots", t, func() {
+		dir, _ := ioutil.TempDir("", "snapshots-"+uuid.New())
+		defer os.RemoveAll(dir)
+		store, e := NewStore(dir)
+		So(e, ShouldBeNil)
+		defer store.Close()
+
+		keys := func(ctx context.Context, nodeUuid string) (*encryption.NodeKey, error) {
+			if nodeUuid == "u1" {
+				return &encryption.NodeKey{NodeId: nodeUuid, UserId: "ds:test", OwnerId: "admin", KeyData: []byte("key")}, nil
+			}
+			return nil, nil
+		}
+		info, e := store.Create(ctx, "first", testSource(), keys)
+		So(e, ShouldBeNil)
+		So(info.Nodes, ShouldEqual, 4)
+		So(info.Size, ShouldEqual, 15)
+		So(info.Keys, ShouldEqual, 1)
+
+		_, e = store.Create(ctx, "first", testSource(), nil)
+		So(e, ShouldNotBeNil)
+		_, e = store.Create(ctx, "../first", testSource(), nil)
+		So(e, ShouldNotBeNil)
+
+		snap, e := store.Get("first")
+		So(e, ShouldBeNil)
+		n, e := snap.LoadNode(ctx, "a/f1")
+		So(e, ShouldBeNil)
+		So(n.Uuid, ShouldEqual, "u1")
+
+		kk, e := store.Keys("first", "u1", "u2")
+		So(e, ShouldBeNil)
+		So(kk, ShouldHaveLength, 1)
+		So(string(kk["u1"].KeyData), ShouldEqual, "key")
+
+		_, e = store.Create(ctx, "second", testSource(), nil)
+		So(e, ShouldBeNil)
+		infos, e := store.List()
+		So(e, ShouldBeNil)
+		So(infos, ShouldHaveLength, 2)
+
+		pruned, e := store.Prune(1)
+		So(e, ShouldBeNil)
+		So(pruned, ShouldResemble, []string{"first"})
+		_, e = store.Get("first")
+		So(e, ShouldNotBeNil)
+
+		So(store.Delete("second"), ShouldBeNil)
+		infos, e = store.List()
+		So(e, ShouldBeNil)
+		So(infos, ShouldBeEmpty)
+	})
+
+	Convey("Diff a snapshot with the current tree", t, func() {
+		dir, _ := ioutil.TempDir("", "snapshots-"+uuid.New())
+		defer os.RemoveAll(dir)
+		store, e := NewStore(dir)
+		So(e, ShouldBeNil)
+		defer store.Close()
+
+		source := testSource()
+		_, e = store.Create(ctx, "before", source, nil)
+		So(e, ShouldBeNil)
+		snap, e := store.Get("before")
+		So(e, ShouldBeNil)
+
+		source.Nodes[1] = &tree.Node{Path: "a/f1", Uuid: "u1", Etag: "e1-bis", Size: 12, Type: tree.NodeType_LEAF}
+		source.Nodes = append(source.Nodes[:2], source.Nodes[3:]...)
+		source.Nodes = append(source.Nodes, &tree.Node{Path: "b/f3", Uuid: "u3", Etag: "e3", Size: 1, Type: tree.NodeType_LEAF})
+
+		ops, e := Diff(ctx, source, snap, "/")
+		So(e, ShouldBeNil)
+		changes := make(map[string]merger.OperationType, len(ops))
+		for _, op := range ops {
+			changes[op.GetRefPath()] = op.Type()
+		}
+		So(changes, ShouldResemble, map[string]merger.OperationType{
+			"a/f1": merger.OpUpdateFile,
+			"a/f2": merger.OpCreateFile,
+			"b/f3": merger.OpDelete,
+		})
+
+		ops, e = Diff(ctx, source, snap, "b")
+		So(e, ShouldBeNil)
+		So(ops, ShouldHaveLength, 1)
+		So(ops[0].Type(), ShouldEqual, merger.OpDelete)
+	})
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/object"
+)
+
+// FreezeWrites sets or releases the writes freeze of the datasource by saving its configuration. Once its sync
+// service has loaded the new configuration, gateways refuse all writes on the datasource, so that the current
+// storage can be copied a last time and verified before the cutover.
+func (m *Migration) FreezeWrites(user string, frozen bool) error {
+	var ds *object.DataSource
+	if e := config.Get("services", "pydio.grpc.data.sync."+m.Source.Name).Scan(&ds); e != nil || ds == nil {
+		return fmt.Errorf("cannot find datasource %s in configuration", m.Source.Name)
+	}
+	if ds.StorageConfiguration == nil {
+		ds.StorageConfiguration = make(map[string]string)
+	}
+	msg := fmt.Sprintf("Freeze writes on datasource %s for migration", ds.Name)
+	if frozen {
+		ds.StorageConfiguration[object.StorageKeyWritesFrozen] = "true"
+	} else {
+		delete(ds.StorageConfiguration, object.StorageKeyWritesFrozen)
+		msg = fmt.Sprintf("Release writes on datasource %s", ds.Name)
+	}
+	config.Set(ds, "services", "pydio.grpc.data.sync."+ds.Name)
+	return config.Save(user, msg)
+}
+
+// Cutover switches the datasource to the target storage with a single configuration save: the datasource
+// configuration, the objects service serving it and the buckets of the migrated stores are updated together.
+// The previous objects service is left untouched, so that the migration can be reverted by restoring the
+// previous datasource configuration, which is returned. Writes must be frozen before: the new configuration
+// releases the freeze once the sync service has restarted on the target storage.
+func (m *Migration) Cutover(ctx context.Context, user string) (*object.DataSource, error) {
+	sources := config.ListSourcesFromConfig()
+	previous, ok := sources[m.Source.Name]
+	if !ok {
+		return nil, fmt.Errorf("cannot find datasource %s in configuration", m.Source.Name)
+	}
+	previous = proto.Clone(previous).(*object.DataSource)
+	delete(previous.StorageConfiguration, object.StorageKeyWritesFrozen)
+	minios := config.ListMinioConfigsFromConfig()
+
+	ds := m.targetConfig(previous)
+	minioConfig, e := config.FactorizeMinioServers(minios, ds, false)
+	if e != nil {
+		return nil, e
+	}
+	if ds.ApiSecret != "" && config.GetSecret(ds.ApiSecret).String() == "" {
+		secretUuid := uuid.New()
+		config.SetSecret(secretUuid, ds.ApiSecret)
+		ds.ApiSecret = secretUuid
+		minioConfig.ApiSecret = secretUuid
+	}
+	minios[minioConfig.Name] = minioConfig
+	sources[ds.Name] = ds
+
+	if ds.PeerAddress != "" {
+		config.Set(ds.PeerAddress, "services", "pydio.grpc.data.index."+ds.Name, "PeerAddress")
+	} else {
+		config.Del("services", "pydio.grpc.data.index."+ds.Name, "PeerAddress")
+	}
+	config.Set(ds, "services", "pydio.grpc.data.sync."+ds.Name)
+	config.Set(minioConfig, "services", "pydio.grpc.data.objects."+minioConfig.Name)
+	config.SourceNamesToConfig(sources)
+	config.MinioConfigNamesToConfig(minios)
+	for _, b := range m.Buckets {
+		if b.Store != "" && b.Target != b.Name {
+			config.Set(b.Target, "services", b.Store, "bucket")
+		}
+	}
+	if e := config.Save(user, fmt.Sprintf("Migrate datasource %s to %s storage", ds.Name, ds.StorageType.String())); e != nil {
+		return nil, e
+	}
+
+	if e := client.Publish(ctx, client.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
+		Name:   ds.Name,
+		Type:   object.DataSourceEvent_UPDATE,
+		Config: ds,
+	})); e != nil {
+		log.Logger(ctx).Warn("could not notify the datasource update", zap.Error(e))
+	}
+	return previous, nil
+}
+
+// targetConfig builds the new configuration of the datasource from the target storage.
+func (m *Migration) targetConfig(previous *object.DataSource) *object.DataSource {
+	ds := proto.Clone(m.Target).(*object.DataSource)
+	delete(ds.StorageConfiguration, object.StorageKeyWritesFrozen)
+	ds.Disabled = previous.Disabled
+	ds.ObjectsServiceName = ""
+	ds.ObjectsHost = ""
+	ds.ObjectsPort = 0
+	ds.LastSynchronizationDate = previous.LastSynchronizationDate
+	return ds
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/filesystem"
+	"github.com/pydio/cells/common/sync/endpoints/s3"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+const (
+	defaultS3Host = "s3.amazonaws.com"
+	// Metadata of the minio FS backend: <fsPath>/.minio.sys/buckets/<bucket>/<object>/fs.json
+	minioFSMetaFolder  = ".minio.sys"
+	minioFSMetaFile    = "fs.json"
+	minioFSMetaVersion = "1.0.2"
+)
+
+// objectsEndpoint opens a bucket of the objects service currently serving the datasource.
+func (m *Migration) objectsEndpoint(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+	return s3.NewClient(ctx, m.Source.BuildUrl(), m.Source.ApiKey, m.Source.ApiSecret, bucket, baseFolder, m.Source.ObjectsSecure, model.EndpointOptions{})
+}
+
+// storageEndpoint directly opens a bucket of the target storage: a folder for LOCAL storages, a bucket for S3 storages.
+func (m *Migration) storageEndpoint(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+	if m.Target.StorageType == object.StorageType_LOCAL {
+		return newLocalEndpoint(filepath.Dir(m.Target.StorageConfiguration["folder"]), bucket)
+	}
+	secret := m.Target.ApiSecret
+	if s := config.GetSecret(secret).String(); s != "" {
+		secret = s
+	}
+	host, secure, e := s3Host(m.Target.StorageConfiguration["customEndpoint"])
+	if e != nil {
+		return nil, e
+	}
+	c, e := s3.NewClient(ctx, host, m.Target.ApiKey, secret, bucket, baseFolder, secure, model.EndpointOptions{})
+	if e != nil {
+		return nil, e
+	}
+	if exists, e := c.Mc.BucketExists(bucket); e != nil {
+		return nil, e
+	} else if !exists {
+		return nil, fmt.Errorf("bucket %s does not exist on target storage", bucket)
+	}
+	return c, nil
+}
+
+// s3Host extracts the host and security flag from an optional custom endpoint url.
+func s3Host(customEndpoint string) (host string, secure bool, e error) {
+	if customEndpoint == "" {
+		return defaultS3Host, true, nil
+	}
+	if !strings.Contains(customEndpoint, "://") {
+		return strings.TrimRight(customEndpoint, "/"), true, nil
+	}
+	u, e := url.Parse(customEndpoint)
+	if e != nil {
+		return "", false, e
+	}
+	return u.Host, u.Scheme == "https", nil
+}
+
+// localEndpoint is a bucket of a LOCAL storage, that is a folder of the path served by a minio FS backend.
+// Files UUIDs are stored as object metadata in the minio FS metadata files, so that the objects service
+// serves them once the datasource is switched to this storage.
+type localEndpoint struct {
+	*filesystem.FSClient
+	folder     string
+	metaFolder string
+}
+
+// fsMeta is the content of a minio FS metadata file.
+type fsMeta struct {
+	Version string            `json:"version"`
+	Meta    map[string]string `json:"meta,omitempty"`
+	ModTime time.Time         `json:"modTime,omitempty"`
+}
+
+func newLocalEndpoint(fsPath string, bucket string) (*localEndpoint, error) {
+	folder := filepath.Join(fsPath, bucket)
+	if e := os.MkdirAll(folder, 0755); e != nil {
+		return nil, e
+	}
+	c, e := filesystem.NewFSClient(folder, model.EndpointOptions{})
+	if e != nil {
+		return nil, e
+	}
+	return &localEndpoint{
+		FSClient:   c,
+		folder:     folder,
+		metaFolder: filepath.Join(fsPath, minioFSMetaFolder, "buckets", bucket),
+	}, nil
+}
+
+// LoadNode loads the node and the UUID of files.
+func (l *localEndpoint) LoadNode(ctx context.Context, p string, extendedStats ...bool) (*tree.Node, error) {
+	n, e := l.FSClient.LoadNode(ctx, p, extendedStats...)
+	if e == nil && n.IsLeaf() {
+		n.Uuid = l.readMeta(p).Meta[common.XAmzMetaNodeUuid]
+	}
+	return n, e
+}
+
+// Walk walks the nodes, loading the UUID of files.
+func (l *localEndpoint) Walk(walkFunc model.WalkNodesFunc, root string, recursive bool) error {
+	return l.FSClient.Walk(func(p string, node *tree.Node, err error) {
+		if err == nil && node != nil && node.IsLeaf() {
+			node.Uuid = l.readMeta(p).Meta[common.XAmzMetaNodeUuid]
+		}
+		walkFunc(p, node, err)
+	}, root, recursive)
+}
+
+// UpdateNodeUuid implements model.UuidReceiver by writing the UUID in the file metadata. The ETag and the
+// modification time of the file are written along, otherwise minio considers the metadata outdated.
+func (l *localEndpoint) UpdateNodeUuid(ctx context.Context, node *tree.Node) (*tree.Node, error) {
+	p := strings.Trim(node.Path, "/")
+	n, e := l.FSClient.LoadNode(ctx, p)
+	if e != nil {
+		return nil, e
+	}
+	stat, e := os.Stat(filepath.Join(l.folder, filepath.FromSlash(p)))
+	if e != nil {
+		return nil, e
+	}
+	meta := l.readMeta(p)
+	if meta.Meta == nil {
+		meta.Meta = make(map[string]string)
+	}
+	meta.Version = minioFSMetaVersion
+	meta.Meta["etag"] = n.Etag
+	meta.Meta[common.XAmzMetaNodeUuid] = node.Uuid
+	meta.ModTime = stat.ModTime()
+	data, e := json.Marshal(meta)
+	if e != nil {
+		return nil, e
+	}
+	metaFile := l.metaFile(p)
+	if e := os.MkdirAll(filepath.Dir(metaFile), 0755); e != nil {
+		return nil, e
+	}
+	if e := ioutil.WriteFile(metaFile, data, 0644); e != nil {
+		return nil, e
+	}
+	n.Uuid = node.Uuid
+	return n, nil
+}
+
+func (l *localEndpoint) metaFile(p string) string {
+	return filepath.Join(l.metaFolder, filepath.FromSlash(strings.Trim(p, "/")), minioFSMetaFile)
+}
+
+// readMeta reads the metadata of a file, returning empty metadata if it is missing or unreadable.
+func (l *localEndpoint) readMeta(p string) *fsMeta {
+	meta := &fsMeta{}
+	if data, e := ioutil.ReadFile(l.metaFile(p)); e == nil {
+		json.Unmarshal(data, meta)
+	}
+	return meta
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package migration moves the objects of a datasource to a new storage while keeping its index, and
+// switches the datasource configuration to the new storage once both storages are identical.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/merger"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/sync/proc"
+)
+
+// Bucket is a bucket of the current objects service that is copied to the target storage.
+type Bucket struct {
+	// Name of the bucket in the current storage
+	Name       string
+	BaseFolder string
+	// Target is the name of the bucket in the target storage
+	Target           string
+	TargetBaseFolder string
+	// Store is the services configuration key of the technical store using this bucket, empty for the datasource bucket
+	Store string
+}
+
+// EndpointFactory opens a sync endpoint on a bucket of a storage.
+type EndpointFactory func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error)
+
+// Migration copies the buckets of a datasource from its current objects service to a target storage.
+type Migration struct {
+	Source  *object.DataSource
+	Target  *object.DataSource
+	Buckets []*Bucket
+
+	SourceEndpoint EndpointFactory
+	TargetEndpoint EndpointFactory
+	Ignores        []glob.Glob
+}
+
+// NewMigration validates the target configuration and prepares the migration of the datasource bucket.
+// The source is the live configuration of the datasource, as returned by its sync service.
+func NewMigration(source *object.DataSource, target *object.DataSource) (*Migration, error) {
+	if source.StorageType == object.StorageType_CELLS {
+		return nil, fmt.Errorf("datasource %s is hosted by a remote Cells server and cannot be migrated", source.Name)
+	}
+	if source.ObjectsBucket == "" {
+		return nil, fmt.Errorf("datasource %s is not bound to a single bucket and cannot be migrated", source.Name)
+	}
+	if target.StorageConfiguration == nil {
+		target.StorageConfiguration = make(map[string]string)
+	}
+	switch target.StorageType {
+	case object.StorageType_LOCAL:
+		folder := target.StorageConfiguration["folder"]
+		if folder == "" {
+			return nil, fmt.Errorf("missing folder for the target storage")
+		}
+		target.ObjectsBucket = filepath.Base(folder)
+		target.ObjectsBaseFolder = ""
+	case object.StorageType_S3:
+		if target.ObjectsBucket == "" {
+			return nil, fmt.Errorf("missing bucket for the target storage")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported target storage type %s, use LOCAL or S3", target.StorageType.String())
+	}
+	if sameStorage(source, target) {
+		return nil, fmt.Errorf("target storage is the current storage of datasource %s", source.Name)
+	}
+	// Data is copied as is: keys, encryption and versioning stay attached to the datasource
+	target.Name = source.Name
+	target.EncryptionMode = source.EncryptionMode
+	target.EncryptionKey = source.EncryptionKey
+	target.VersioningPolicyName = source.VersioningPolicyName
+	target.CreationDate = source.CreationDate
+	target.Watch = source.Watch
+
+	m := &Migration{
+		Source: source,
+		Target: target,
+		Buckets: []*Bucket{{
+			Name:             source.ObjectsBucket,
+			BaseFolder:       source.ObjectsBaseFolder,
+			Target:           target.ObjectsBucket,
+			TargetBaseFolder: target.ObjectsBaseFolder,
+		}},
+	}
+	m.SourceEndpoint = m.objectsEndpoint
+	m.TargetEndpoint = m.storageEndpoint
+	for _, i := range []string{"**/.minio.sys", "**/.minio.sys/**"} {
+		m.Ignores = append(m.Ignores, glob.MustCompile(i, '/'))
+	}
+	return m, nil
+}
+
+// AddStore registers the bucket of a technical store (versions, thumbnails) hosted by the datasource objects
+// service, to be copied to a bucket of the target storage. An empty targetBucket keeps the same name.
+// For LOCAL targets, buckets are folders next to the datasource folder and the name cannot be changed.
+func (m *Migration) AddStore(configKey string, bucket string, targetBucket string) {
+	if targetBucket == "" || m.Target.StorageType == object.StorageType_LOCAL {
+		targetBucket = bucket
+	}
+	m.Buckets = append(m.Buckets, &Bucket{
+		Name:   bucket,
+		Target: targetBucket,
+		Store:  configKey,
+	})
+}
+
+// Pass computes the differences between the source and the target buckets, and applies them on the target.
+// It returns the number of operations applied. The processing can be interrupted through the optional cmd,
+// and its progress is reported to the optional progress callback.
+func (m *Migration) Pass(ctx context.Context, b *Bucket, cmd *model.Command, progress func(float32)) (int, error) {
+	source, target, e := m.endpoints(ctx, b)
+	if e != nil {
+		return 0, e
+	}
+	targetTarget, ok := model.AsPathSyncTarget(target)
+	if !ok {
+		return 0, fmt.Errorf("target endpoint of bucket %s cannot be written", b.Target)
+	}
+	diff := merger.NewDiff(ctx, source, target)
+	if e := diff.Compute("/", nil, nil, m.Ignores...); e != nil {
+		return 0, e
+	}
+	patch := merger.NewPatch(source, targetTarget, merger.PatchOptions{MoveDetection: true})
+	patch.SkipFilterToTarget(true)
+	if e := diff.ToUnidirectionalPatch(model.DirectionRight, patch); e != nil {
+		return 0, e
+	}
+	patch.Filter(ctx, m.Ignores...)
+	ops := patch.Size()
+	if ops == 0 {
+		return 0, nil
+	}
+	status := make(chan model.Status)
+	done := make(chan interface{}, 1)
+	patch.SetupChannels(status, done, cmd)
+	go func() {
+		// Statuses are sent asynchronously: late senders recover from the closed channel
+		defer close(status)
+		for {
+			select {
+			case s := <-status:
+				if progress != nil && s.Progress() > 0 {
+					progress(s.Progress())
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	pr := proc.NewProcessor(ctx)
+	pr.Silent = true
+	pr.Process(patch, cmd)
+	if errs, ok := patch.HasErrors(); ok {
+		return ops, errs[0]
+	}
+	return ops, nil
+}
+
+// RestoreUuids writes the source files UUIDs on the target files. Folders UUIDs are already copied by the passes.
+// It returns the number of updated files, or an error if the target storage cannot store the UUIDs of the files.
+func (m *Migration) RestoreUuids(ctx context.Context, b *Bucket) (int, error) {
+	source, target, e := m.endpoints(ctx, b)
+	if e != nil {
+		return 0, e
+	}
+	sourceNodes, e := m.walk(source)
+	if e != nil {
+		return 0, e
+	}
+	receiver, ok := target.(model.UuidReceiver)
+	if !ok {
+		for p, s := range sourceNodes {
+			if s.IsLeaf() && s.Uuid != "" {
+				return 0, fmt.Errorf("target storage of bucket %s cannot store files UUIDs (e.g. %s), they would be lost", b.Target, p)
+			}
+		}
+		return 0, nil
+	}
+	targetNodes, e := m.walk(target)
+	if e != nil {
+		return 0, e
+	}
+	var count int
+	for p, n := range targetNodes {
+		s, ok := sourceNodes[p]
+		if !ok || !n.IsLeaf() || s.Uuid == "" || s.Uuid == n.Uuid {
+			continue
+		}
+		if _, e := receiver.UpdateNodeUuid(ctx, &tree.Node{Path: p, Uuid: s.Uuid, Type: tree.NodeType_LEAF}); e != nil {
+			return count, e
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Verify compares the source and target buckets.
+func (m *Migration) Verify(ctx context.Context, b *Bucket) (*Report, error) {
+	source, target, e := m.endpoints(ctx, b)
+	if e != nil {
+		return nil, e
+	}
+	sourceNodes, e := m.walk(source)
+	if e != nil {
+		return nil, e
+	}
+	targetNodes, e := m.walk(target)
+	if e != nil {
+		return nil, e
+	}
+	_, checkUuids := target.(model.UuidReceiver)
+	return compare(b.Name, sourceNodes, targetNodes, checkUuids), nil
+}
+
+func (m *Migration) endpoints(ctx context.Context, b *Bucket) (source model.PathSyncSource, target model.PathSyncSource, e error) {
+	s, e := m.SourceEndpoint(ctx, b.Name, b.BaseFolder)
+	if e != nil {
+		return nil, nil, fmt.Errorf("cannot open bucket %s on current storage: %s", b.Name, e.Error())
+	}
+	t, e := m.TargetEndpoint(ctx, b.Target, b.TargetBaseFolder)
+	if e != nil {
+		return nil, nil, fmt.Errorf("cannot open bucket %s on target storage: %s", b.Target, e.Error())
+	}
+	var ok bool
+	if source, ok = model.AsPathSyncSource(s); !ok {
+		return nil, nil, fmt.Errorf("source endpoint of bucket %s cannot be walked", b.Name)
+	}
+	if target, ok = model.AsPathSyncSource(t); !ok {
+		return nil, nil, fmt.Errorf("target endpoint of bucket %s cannot be walked", b.Target)
+	}
+	return
+}
+
+func (m *Migration) walk(endpoint model.PathSyncSource) (map[string]*tree.Node, error) {
+	nodes := make(map[string]*tree.Node)
+	var walkErr error
+	e := endpoint.Walk(func(p string, node *tree.Node, err error) {
+		if err != nil {
+			walkErr = err
+			return
+		}
+		p = strings.Trim(p, "/")
+		if p == "" {
+			return
+		}
+		for _, i := range m.Ignores {
+			if i.Match(p) {
+				return
+			}
+		}
+		nodes[p] = node
+	}, "/", true)
+	if e != nil {
+		return nil, e
+	}
+	return nodes, walkErr
+}
+
+// sameStorage checks if two configurations point to the same bucket or folder.
+func sameStorage(a, b *object.DataSource) bool {
+	if a.StorageType != b.StorageType {
+		return false
+	}
+	if a.StorageType == object.StorageType_LOCAL {
+		return filepath.Clean(a.StorageConfiguration["folder"]) == filepath.Clean(b.StorageConfiguration["folder"]) && a.PeerAddress == b.PeerAddress
+	}
+	return a.ObjectsBucket == b.ObjectsBucket && strings.Trim(a.ObjectsBaseFolder, "/") == strings.Trim(b.ObjectsBaseFolder, "/") &&
+		a.StorageConfiguration["customEndpoint"] == b.StorageConfiguration["customEndpoint"]
+}
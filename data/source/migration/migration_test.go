@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package migration
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/endpoints/filesystem"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+func TestNewMigration(t *testing.T) {
+	Convey("Test migration validation", t, func() {
+		source := &object.DataSource{
+			Name:                 "ds",
+			StorageType:          object.StorageType_LOCAL,
+			ObjectsBucket:        "ds",
+			StorageConfiguration: map[string]string{"folder": "/data/ds"},
+			EncryptionMode:       object.EncryptionMode_MASTER,
+			EncryptionKey:        "key",
+		}
+		_, e := NewMigration(&object.DataSource{Name: "ds", StorageType: object.StorageType_CELLS}, &object.DataSource{})
+		So(e, ShouldNotBeNil)
+		_, e = NewMigration(&object.DataSource{Name: "ds", StorageType: object.StorageType_S3}, &object.DataSource{StorageType: object.StorageType_S3, ObjectsBucket: "b"})
+		So(e, ShouldNotBeNil)
+		_, e = NewMigration(source, &object.DataSource{StorageType: object.StorageType_S3})
+		So(e, ShouldNotBeNil)
+		_, e = NewMigration(source, &object.DataSource{StorageType: object.StorageType_AZURE})
+		So(e, ShouldNotBeNil)
+		_, e = NewMigration(source, &object.DataSource{StorageType: object.StorageType_LOCAL, StorageConfiguration: map[string]string{"folder": "/data/ds/"}})
+		So(e, ShouldNotBeNil)
+
+		m, e := NewMigration(source, &object.DataSource{StorageType: object.StorageType_S3, ObjectsBucket: "bucket"})
+		So(e, ShouldBeNil)
+		So(m.Target.Name, ShouldEqual, "ds")
+		So(m.Target.EncryptionMode, ShouldEqual, object.EncryptionMode_MASTER)
+		So(m.Target.EncryptionKey, ShouldEqual, "key")
+		So(m.Buckets, ShouldHaveLength, 1)
+		So(m.Buckets[0].Name, ShouldEqual, "ds")
+		So(m.Buckets[0].Target, ShouldEqual, "bucket")
+		m.AddStore("pydio.versions-store", "versions", "new-versions")
+		So(m.Buckets[1].Target, ShouldEqual, "new-versions")
+
+		m, e = NewMigration(source, &object.DataSource{StorageType: object.StorageType_LOCAL, StorageConfiguration: map[string]string{"folder": "/other/ds2"}})
+		So(e, ShouldBeNil)
+		So(m.Target.ObjectsBucket, ShouldEqual, "ds2")
+		m.AddStore("pydio.versions-store", "versions", "new-versions")
+		So(m.Buckets[1].Target, ShouldEqual, "versions")
+	})
+}
+
+func TestMigrationPasses(t *testing.T) {
+	Convey("Test passes and verification", t, func() {
+		ctx := context.Background()
+		root, _ := ioutil.TempDir("", "migration")
+		defer os.RemoveAll(root)
+		sourceDir := filepath.Join(root, "source", "ds")
+		os.MkdirAll(filepath.Join(sourceDir, "folder"), 0755)
+		ioutil.WriteFile(filepath.Join(sourceDir, "file1"), []byte("content 1"), 0644)
+		ioutil.WriteFile(filepath.Join(sourceDir, "folder", "file2"), []byte("content 2"), 0644)
+
+		m, e := NewMigration(
+			&object.DataSource{Name: "ds", StorageType: object.StorageType_S3, ObjectsBucket: "ds"},
+			&object.DataSource{StorageType: object.StorageType_LOCAL, StorageConfiguration: map[string]string{"folder": filepath.Join(root, "target", "ds")}},
+		)
+		So(e, ShouldBeNil)
+		m.SourceEndpoint = func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+			return filesystem.NewFSClient(filepath.Join(root, "source", bucket), model.EndpointOptions{})
+		}
+		b := m.Buckets[0]
+
+		r, e := m.Verify(ctx, b)
+		So(e, ShouldBeNil)
+		So(r.Clean(), ShouldBeFalse)
+		So(r.Missing, ShouldContain, "folder/file2")
+
+		ops, e := m.Pass(ctx, b, nil, nil)
+		So(e, ShouldBeNil)
+		So(ops, ShouldBeGreaterThan, 0)
+		data, e := ioutil.ReadFile(filepath.Join(root, "target", "ds", "folder", "file2"))
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "content 2")
+
+		r, e = m.Verify(ctx, b)
+		So(e, ShouldBeNil)
+		So(r.Clean(), ShouldBeTrue)
+		So(r.Size, ShouldBeGreaterThan, 0)
+
+		ops, e = m.Pass(ctx, b, nil, nil)
+		So(e, ShouldBeNil)
+		So(ops, ShouldEqual, 0)
+
+		Convey("Changes on both sides are reported and fixed by the next pass", func() {
+			ioutil.WriteFile(filepath.Join(sourceDir, "file1"), []byte("content 1 modified"), 0644)
+			ioutil.WriteFile(filepath.Join(root, "target", "ds", "extra"), []byte("extra"), 0644)
+			r, e := m.Verify(ctx, b)
+			So(e, ShouldBeNil)
+			So(r.Different, ShouldResemble, []string{"file1"})
+			So(r.Extra, ShouldResemble, []string{"extra"})
+			So(r.String(), ShouldContainSubstring, "1 extra (extra)")
+
+			ops, e := m.Pass(ctx, b, nil, nil)
+			So(e, ShouldBeNil)
+			So(ops, ShouldEqual, 2)
+			r, e = m.Verify(ctx, b)
+			So(e, ShouldBeNil)
+			So(r.Clean(), ShouldBeTrue)
+		})
+
+		Convey("Files uuids are stored in the minio metadata of local targets", func() {
+			source, e := newLocalEndpoint(filepath.Join(root, "source"), "ds")
+			So(e, ShouldBeNil)
+			_, e = source.UpdateNodeUuid(ctx, &tree.Node{Path: "folder/file2", Uuid: "file2-uuid"})
+			So(e, ShouldBeNil)
+			m.SourceEndpoint = func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+				return newLocalEndpoint(filepath.Join(root, "source"), bucket)
+			}
+			r, e := m.Verify(ctx, b)
+			So(e, ShouldBeNil)
+			So(r.Different, ShouldResemble, []string{"folder/file2"})
+
+			count, e := m.RestoreUuids(ctx, b)
+			So(e, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+			data, e := ioutil.ReadFile(filepath.Join(root, "target", ".minio.sys", "buckets", "ds", "folder", "file2", "fs.json"))
+			So(e, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, `"X-Amz-Meta-Pydio-Node-Uuid":"file2-uuid"`)
+			r, e = m.Verify(ctx, b)
+			So(e, ShouldBeNil)
+			So(r.Clean(), ShouldBeTrue)
+		})
+
+		Convey("Files uuids cannot be silently lost", func() {
+			m.SourceEndpoint = func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+				c, e := newLocalEndpoint(filepath.Join(root, "source"), bucket)
+				if e == nil {
+					_, e = c.UpdateNodeUuid(ctx, &tree.Node{Path: "file1", Uuid: "file1-uuid"})
+				}
+				return c, e
+			}
+			m.TargetEndpoint = func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+				return filesystem.NewFSClient(filepath.Join(root, "target", bucket), model.EndpointOptions{})
+			}
+			_, e := m.RestoreUuids(ctx, b)
+			So(e, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+var (
+	// Maximum number of paths listed in a report string
+	reportMaxPaths = 10
+)
+
+// Report is the result of the comparison of a source and a target bucket.
+type Report struct {
+	Bucket string
+	Nodes  int
+	Size   int64
+	// Missing paths exist in the source but not in the target
+	Missing []string
+	// Extra paths exist in the target but not in the source
+	Extra []string
+	// Different paths exist on both sides with a different type, size, content or UUID
+	Different []string
+}
+
+// Clean is true if source and target are identical.
+func (r *Report) Clean() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Different) == 0
+}
+
+func (r *Report) String() string {
+	s := fmt.Sprintf("Bucket %s: %d node(s), %d byte(s)", r.Bucket, r.Nodes, r.Size)
+	if r.Clean() {
+		return s + ", target is identical"
+	}
+	for _, l := range []struct {
+		label string
+		paths []string
+	}{{"missing", r.Missing}, {"extra", r.Extra}, {"different", r.Different}} {
+		if len(l.paths) == 0 {
+			continue
+		}
+		paths := l.paths
+		if len(paths) > reportMaxPaths {
+			paths = append(paths[:reportMaxPaths:reportMaxPaths], "...")
+		}
+		s += fmt.Sprintf(", %d %s (%s)", len(l.paths), l.label, strings.Join(paths, ", "))
+	}
+	return s
+}
+
+// compare builds a report from the nodes of the source and the target. As storages may compute
+// different multipart ETags for the same content, ETags are only compared when both are plain MD5.
+func compare(bucket string, source, target map[string]*tree.Node, checkUuids bool) *Report {
+	r := &Report{Bucket: bucket}
+	for p, s := range source {
+		r.Nodes++
+		if s.IsLeaf() {
+			r.Size += s.Size
+		}
+		t, ok := target[p]
+		if !ok {
+			r.Missing = append(r.Missing, p)
+			continue
+		}
+		if s.IsLeaf() != t.IsLeaf() {
+			r.Different = append(r.Different, p)
+		} else if s.IsLeaf() && (s.Size != t.Size || (plainEtag(s.Etag) && plainEtag(t.Etag) && s.Etag != t.Etag)) {
+			r.Different = append(r.Different, p)
+		} else if s.IsLeaf() && checkUuids && s.Uuid != "" && s.Uuid != t.Uuid {
+			r.Different = append(r.Different, p)
+		} else if !s.IsLeaf() && s.Uuid != "" && t.Uuid != "" && s.Uuid != t.Uuid {
+			r.Different = append(r.Different, p)
+		}
+	}
+	for p := range target {
+		if _, ok := source[p]; !ok {
+			r.Extra = append(r.Extra, p)
+		}
+	}
+	sort.Strings(r.Missing)
+	sort.Strings(r.Extra)
+	sort.Strings(r.Different)
+	return r
+}
+
+func plainEtag(etag string) bool {
+	return etag != "" && !strings.Contains(etag, "-")
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	protosync "github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+// Fsck forwards the request to the index, then compares the index with the storage if req.CheckStorage is set.
+func (s *Handler) Fsck(ctx context.Context, req *protosync.FsckRequest, resp *protosync.FsckResponse) error {
+	if s.IndexFsckClient == nil || s.syncTask == nil {
+		return errors.InternalServerError(common.ServiceDataSync, "sync task is not started")
+	}
+	indexResp, e := s.IndexFsckClient.Fsck(ctx, req, client.WithRequestTimeout(30*time.Minute))
+	if e != nil {
+		return e
+	}
+	resp.Issues = indexResp.Issues
+	if !req.CheckStorage {
+		return nil
+	}
+
+	issues, e := s.fsckStorage(ctx)
+	if e != nil {
+		return e
+	}
+	fixes := make(map[string]*protosync.FsckFix, len(req.Fixes))
+	for _, f := range req.Fixes {
+		fixes[f.IssueId] = f
+	}
+	indexTarget, _ := model.AsPathSyncTarget(s.syncTask.Target)
+	for _, issue := range issues {
+		resp.Issues = append(resp.Issues, issue)
+		if !req.Apply {
+			continue
+		}
+		fix, ok := fixes[issue.Id]
+		if !ok && req.AutoFix && len(issue.Fixes) > 0 {
+			fix, ok = &protosync.FsckFix{IssueId: issue.Id, Type: issue.Fixes[0]}, true
+		}
+		if !ok || fix.Type == protosync.FsckFixType_NoFix {
+			continue
+		}
+		if fix.Type != protosync.FsckFixType_Delete || issue.Type != protosync.FsckIssueType_MissingInStorage || indexTarget == nil {
+			issue.Error = fmt.Sprintf("fix %s cannot be applied to %s", fix.Type.String(), issue.Type.String())
+			continue
+		}
+		if e := indexTarget.DeleteNode(ctx, issue.Path); e != nil {
+			log.Logger(ctx).Error("[Sync] Cannot fix "+issue.Id, zap.Error(e))
+			issue.Error = e.Error()
+		} else {
+			issue.Applied = fix.Type
+		}
+	}
+	return nil
+}
+
+// fsckStorage lists files that are in the index but not in the storage, and vice-versa.
+func (s *Handler) fsckStorage(ctx context.Context) (issues []*protosync.FsckIssue, e error) {
+	storage, ok := model.AsPathSyncSource(s.S3client)
+	if !ok {
+		return nil, fmt.Errorf("storage cannot be listed")
+	}
+	idx, ok := model.AsPathSyncSource(s.syncTask.Target)
+	if !ok {
+		return nil, fmt.Errorf("index cannot be listed")
+	}
+	storageFiles, e := s.fsckLeafs(storage)
+	if e != nil {
+		return nil, e
+	}
+	indexFiles, e := s.fsckLeafs(idx)
+	if e != nil {
+		return nil, e
+	}
+	for p, n := range indexFiles {
+		if _, ok := storageFiles[p]; !ok {
+			issues = append(issues, &protosync.FsckIssue{
+				Id:    "storage-" + p,
+				Type:  protosync.FsckIssueType_MissingInStorage,
+				Uuids: []string{n.Uuid},
+				Name:  path.Base(p),
+				Path:  p,
+				Leaf:  true,
+				Fixes: []protosync.FsckFixType{protosync.FsckFixType_Delete},
+			})
+		}
+	}
+	for p, n := range storageFiles {
+		if _, ok := indexFiles[p]; !ok {
+			// Will be indexed by next resync
+			issues = append(issues, &protosync.FsckIssue{
+				Id:    "index-" + p,
+				Type:  protosync.FsckIssueType_MissingInIndex,
+				Uuids: []string{n.Uuid},
+				Name:  path.Base(p),
+				Path:  p,
+				Leaf:  true,
+			})
+		}
+	}
+	return issues, nil
+}
+
+// fsckLeafs walks an endpoint and collects its files, ignoring hidden folder files
+func (s *Handler) fsckLeafs(endpoint model.PathSyncSource) (map[string]*tree.Node, error) {
+	leafs := make(map[string]*tree.Node)
+	var walkErr error
+	e := endpoint.Walk(func(p string, node *tree.Node, err error) {
+		if err != nil {
+			walkErr = err
+			return
+		}
+		if node.IsLeaf() && !model.IsFolderHiddenFile(p) {
+			leafs[p] = node
+		}
+	}, "/", true)
+	if e != nil {
+		return nil, e
+	}
+	return leafs, walkErr
+}
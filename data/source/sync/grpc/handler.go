@@ -57,6 +57,7 @@ import (
 	"github.com/pydio/cells/common/service"
 	servicecontext "github.com/pydio/cells/common/service/context"
 	protoservice "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/sync/endpoints/cells"
 	"github.com/pydio/cells/common/sync/endpoints/index"
 	"github.com/pydio/cells/common/sync/endpoints/s3"
 	"github.com/pydio/cells/common/sync/model"
@@ -73,6 +74,7 @@ type Handler struct {
 
 	IndexClient      tree.NodeProviderClient
 	IndexCleanClient protosync.SyncEndpointClient
+	IndexFsckClient  protosync.IndexFsckClient
 	S3client         model.PathSyncTarget
 	syncTask         *task.Sync
 	SyncConfig       *object.DataSource
@@ -162,6 +164,10 @@ func (s *Handler) initSync(syncConfig *object.DataSource) error {
 	// Making sure Objects is started
 	go func() {
 		defer wg.Done()
+		if syncConfig.StorageType == object.StorageType_CELLS {
+			// Remote Cells are accessed directly, there is no objects service
+			return
+		}
 		service.Retry(ctx, func() error {
 			log.Logger(ctx).Info("Sync " + dataSource + " - Try to contact Objects")
 			cli := object.NewObjectsEndpointClient(registry.GetClient(common.ServiceDataObjects_ + syncConfig.ObjectsServiceName))
@@ -207,16 +213,13 @@ func (s *Handler) initSync(syncConfig *object.DataSource) error {
 
 	wg.Wait()
 
-	if minioConfig == nil {
+	if minioConfig == nil && syncConfig.StorageType != object.StorageType_CELLS {
 		return fmt.Errorf("objects not reachable")
 	} else if !indexOK {
 		return fmt.Errorf("index not reachable")
 	}
 
 	var source model.PathSyncTarget
-	if syncConfig.Watch {
-		return fmt.Errorf("datasource watch is not implemented yet")
-	}
 	normalizeS3, _ := strconv.ParseBool(syncConfig.StorageConfiguration["normalize"])
 	var computer func(string) (int64, error)
 	if syncConfig.EncryptionMode != object.EncryptionMode_CLEAR {
@@ -257,7 +260,13 @@ func (s *Handler) initSync(syncConfig *object.DataSource) error {
 	if k, o := syncConfig.StorageConfiguration["nativeEtags"]; o && k == "true" {
 		keepNativeEtags = true
 	}
-	if syncConfig.ObjectsBucket == "" {
+	if syncConfig.StorageType == object.StorageType_CELLS {
+		remoteConfig, root, e := cells.RemoteConfigFromDataSource(syncConfig)
+		if e != nil {
+			return e
+		}
+		source = cells.NewRemote(remoteConfig, root, cells.Options{EndpointOptions: options})
+	} else if syncConfig.ObjectsBucket == "" {
 		var bucketsFilter string
 		if f, o := syncConfig.StorageConfiguration["bucketsRegexp"]; o {
 			bucketsFilter = f
@@ -334,11 +343,19 @@ func (s *Handler) initSync(syncConfig *object.DataSource) error {
 	s.S3client = source
 	s.IndexClient = indexClientRead
 	s.IndexCleanClient = protosync.NewSyncEndpointClient(indexName, indexClient)
+	s.IndexFsckClient = protosync.NewIndexFsckClient(indexName, indexClient)
 	s.SyncConfig = syncConfig
 	s.ObjectConfig = minioConfig
 	s.syncTask = task.NewSync(source, target, model.DirectionRight)
 	s.syncTask.SkipTargetChecks = true
 	s.syncTask.FailsafeDeletes = true
+	if syncConfig.Watch && syncConfig.StorageType != object.StorageType_CELLS {
+		if storage, e := newStorageWatcher(ctx, syncConfig, minioConfig, options); e == nil {
+			s.syncTask.SetStorageWatcher(storage, storageWatchEchoDelay)
+		} else {
+			log.Logger(ctx).Warn("Cannot watch storage for datasource "+dataSource+", changes performed directly on the storage will not be detected", zap.Error(e))
+		}
+	}
 
 	return nil
 
@@ -425,14 +442,31 @@ func (s *Handler) watchConfigs() {
 			log.Logger(s.globalCtx).Debug("Config changed on "+serviceName+", comparing", zap.Any("old", s.SyncConfig), zap.Any("new", &cfg))
 			if s.SyncConfig.ObjectsBaseFolder != cfg.ObjectsBaseFolder || s.SyncConfig.ObjectsBucket != cfg.ObjectsBucket {
 				// @TODO - Object service must be restarted before restarting sync
+				// Writes stay frozen if they were: they are released by the restarted service, once it serves the new storage
 				log.Logger(s.globalCtx).Info("Path changed on " + serviceName + ", should reload sync task entirely - Please restart service")
-			} else if s.SyncConfig.VersioningPolicyName != cfg.VersioningPolicyName || s.SyncConfig.EncryptionMode != cfg.EncryptionMode {
-				log.Logger(s.globalCtx).Info("Versioning policy changed on "+serviceName+", updating internal config", zap.Any("cfg", &cfg))
-				s.SyncConfig.VersioningPolicyName = cfg.VersioningPolicyName
-				s.SyncConfig.EncryptionMode = cfg.EncryptionMode
-				s.SyncConfig.EncryptionKey = cfg.EncryptionKey
-				<-time.After(2 * time.Second)
-				config.TouchSourceNamesForDataServices(common.ServiceDataSync)
+			} else {
+				var changed bool
+				if s.SyncConfig.VersioningPolicyName != cfg.VersioningPolicyName || s.SyncConfig.EncryptionMode != cfg.EncryptionMode || s.SyncConfig.EncryptionKey != cfg.EncryptionKey {
+					log.Logger(s.globalCtx).Info("Versioning policy or encryption changed on "+serviceName+", updating internal config", zap.Any("cfg", &cfg))
+					s.SyncConfig.VersioningPolicyName = cfg.VersioningPolicyName
+					s.SyncConfig.EncryptionMode = cfg.EncryptionMode
+					s.SyncConfig.EncryptionKey = cfg.EncryptionKey
+					changed = true
+				}
+				if s.SyncConfig.WritesFrozen() != cfg.WritesFrozen() {
+					log.Logger(s.globalCtx).Info("Writes freeze changed on "+serviceName+", updating internal config", zap.Bool("frozen", cfg.WritesFrozen()))
+					storageConfig := make(map[string]string, len(s.SyncConfig.StorageConfiguration)+1)
+					for k, v := range s.SyncConfig.StorageConfiguration {
+						storageConfig[k] = v
+					}
+					storageConfig[object.StorageKeyWritesFrozen] = cfg.StorageConfiguration[object.StorageKeyWritesFrozen]
+					s.SyncConfig.StorageConfiguration = storageConfig
+					changed = true
+				}
+				if changed {
+					<-time.After(2 * time.Second)
+					config.TouchSourceNamesForDataServices(common.ServiceDataSync)
+				}
 			}
 		} else {
 			log.Logger(s.globalCtx).Error("Could not scan event", zap.Error(err))
@@ -558,11 +592,13 @@ func (s *Handler) TriggerResync(c context.Context, req *protosync.ResyncRequest,
 // Implements the S3Endpoint Interface by using the real object configs + the local datasource configs for bucket and base folder
 func (s *Handler) GetDataSourceConfig(ctx context.Context, request *object.GetDataSourceConfigRequest, response *object.GetDataSourceConfigResponse) error {
 
-	s.SyncConfig.ObjectsHost = s.ObjectConfig.RunningHost
-	s.SyncConfig.ObjectsPort = s.ObjectConfig.RunningPort
-	s.SyncConfig.ObjectsSecure = s.ObjectConfig.RunningSecure
-	s.SyncConfig.ApiKey = s.ObjectConfig.ApiKey
-	s.SyncConfig.ApiSecret = s.ObjectConfig.ApiSecret
+	if s.ObjectConfig != nil {
+		s.SyncConfig.ObjectsHost = s.ObjectConfig.RunningHost
+		s.SyncConfig.ObjectsPort = s.ObjectConfig.RunningPort
+		s.SyncConfig.ObjectsSecure = s.ObjectConfig.RunningSecure
+		s.SyncConfig.ApiKey = s.ObjectConfig.ApiKey
+		s.SyncConfig.ApiSecret = s.ObjectConfig.ApiSecret
+	}
 
 	response.DataSource = s.SyncConfig
 
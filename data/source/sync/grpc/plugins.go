@@ -98,6 +98,7 @@ func init() {
 					tree.RegisterNodeProviderHandler(m.Server(), syncHandler)
 					tree.RegisterNodeReceiverHandler(m.Server(), syncHandler)
 					protosync.RegisterSyncEndpointHandler(m.Server(), syncHandler)
+					protosync.RegisterIndexFsckHandler(m.Server(), syncHandler)
 					object.RegisterDataSourceEndpointHandler(m.Server(), syncHandler)
 					object.RegisterResourceCleanerEndpointHandler(m.Options().Server, syncHandler)
 
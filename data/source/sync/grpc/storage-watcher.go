@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/sync/endpoints/filesystem"
+	"github.com/pydio/cells/common/sync/endpoints/s3"
+	"github.com/pydio/cells/common/sync/model"
+)
+
+var (
+	// storageWatchEchoDelay is the time during which events detected on the storage are retained, to make sure
+	// they are not the echo of an operation performed through the objects service.
+	storageWatchEchoDelay = 3 * time.Second
+)
+
+// newStorageWatcher creates an endpoint watching changes performed directly on the storage underlying the
+// datasource, bypassing the objects service: fsnotify on the folder for LOCAL storage, bucket notifications
+// on the remote server for S3 storage.
+func newStorageWatcher(ctx context.Context, syncConfig *object.DataSource, minioConfig *object.MinioConfig, options model.EndpointOptions) (model.PathSyncSource, error) {
+
+	switch syncConfig.StorageType {
+	case object.StorageType_LOCAL:
+		folder := filepath.Join(minioConfig.LocalFolder, syncConfig.ObjectsBucket, syncConfig.ObjectsBaseFolder)
+		if _, e := os.Stat(folder); e != nil {
+			return nil, fmt.Errorf("cannot watch folder %s (is the sync service running on the same peer as the objects service?): %s", folder, e.Error())
+		}
+		return filesystem.NewFSClient(folder, model.EndpointOptions{BrowseOnly: true})
+
+	case object.StorageType_S3:
+		host, secure, e := remoteStorageHost(minioConfig.EndpointUrl)
+		if e != nil {
+			return nil, e
+		}
+		if syncConfig.ObjectsBucket == "" {
+			bucketsFilter := syncConfig.StorageConfiguration["bucketsRegexp"]
+			return s3.NewMultiBucketClient(ctx, host, minioConfig.ApiKey, minioConfig.ApiSecret, secure, options, bucketsFilter)
+		}
+		return s3.NewClient(ctx, host, minioConfig.ApiKey, minioConfig.ApiSecret, syncConfig.ObjectsBucket, syncConfig.ObjectsBaseFolder, secure, options)
+
+	default:
+		return nil, fmt.Errorf("watching %s storage is not supported", syncConfig.StorageType.String())
+	}
+
+}
+
+// remoteStorageHost extracts host and secure flag from the gateway custom endpoint, defaulting to Amazon S3.
+func remoteStorageHost(endpoint string) (host string, secure bool, e error) {
+	if endpoint == "" {
+		return "s3.amazonaws.com", true, nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		return strings.TrimRight(endpoint, "/"), true, nil
+	}
+	u, e := url.Parse(endpoint)
+	if e != nil {
+		return "", false, e
+	}
+	return u.Host, u.Scheme == "https", nil
+}
@@ -126,7 +126,7 @@ func (c *VersionAction) Run(ctx context.Context, channels *actions.RunnableChann
 		return input.WithError(err), err
 	}
 	if (resp.Version == nil || resp.Version == &tree.ChangeLog{}) {
-		// No version returned, means content did not change, do not update
+		// No version returned, means content did not change or node is ignored by a policy rule, do not update
 		return input.WithIgnore(), nil
 	}
 
@@ -120,6 +120,10 @@ func (h *Handler) CreateVersion(ctx context.Context, request *tree.CreateVersion
 		return err
 	}
 	log.Logger(ctx).Debug("[VERSION] GetLastVersion for node ", zap.Any("last", last), zap.Any("request", request))
+	if p := h.findPolicyForNode(ctx, request.Node); p != nil && versions.PolicyForNode(p, request.Node) == nil {
+		log.Logger(ctx).Debug("[VERSION] Node is ignored by a versioning policy rule", request.Node.Zap())
+		return nil
+	}
 	if last == nil || string(last.Data) != request.Node.Etag {
 		resp.Version = NewChangeLogFromNode(ctx, request.Node, request.TriggerEvent)
 	}
@@ -129,6 +133,9 @@ func (h *Handler) CreateVersion(ctx context.Context, request *tree.CreateVersion
 func (h *Handler) StoreVersion(ctx context.Context, request *tree.StoreVersionRequest, resp *tree.StoreVersionResponse) error {
 
 	p := h.findPolicyForNode(ctx, request.Node)
+	if p != nil {
+		p = versions.PolicyForNode(p, request.Node)
+	}
 	if p == nil {
 		log.Logger(ctx).Info("Ignoring StoreVersion for this node")
 		return nil
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package versions
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// MatchRule returns the first rule of the policy matching the node, or nil if none matches.
+func MatchRule(policy *tree.VersioningPolicy, node *tree.Node) *tree.VersioningPolicyRule {
+	for _, rule := range policy.GetRules() {
+		if RuleMatches(rule, node) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// RuleMatches checks that the node matches all non-empty criteria of the rule.
+func RuleMatches(rule *tree.VersioningPolicyRule, node *tree.Node) bool {
+	if len(rule.PathPrefixes) > 0 {
+		dsPath := "/" + strings.Trim(dataSourcePath(node), "/")
+		var match bool
+		for _, prefix := range rule.PathPrefixes {
+			prefix = "/" + strings.Trim(prefix, "/")
+			if prefix == "/" || dsPath == prefix || strings.HasPrefix(dsPath, prefix+"/") {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(node.GetPath()), "."))
+	if len(rule.Extensions) > 0 {
+		var match bool
+		for _, e := range rule.Extensions {
+			if strings.ToLower(strings.TrimPrefix(e, ".")) == ext {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	if len(rule.MimeTypes) > 0 {
+		if ext == "" {
+			return false
+		}
+		mimeType := strings.SplitN(mime.TypeByExtension("."+ext), ";", 2)[0]
+		var match bool
+		for _, m := range rule.MimeTypes {
+			if strings.HasSuffix(m, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(m, "*")) || m == mimeType {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	if rule.MinSize > 0 && node.GetSize() < rule.MinSize {
+		return false
+	}
+	if rule.MaxSize > 0 && node.GetSize() > rule.MaxSize {
+		return false
+	}
+	return true
+}
+
+// PolicyForNode computes the policy actually applied to a node: if a rule matches, its KeepPeriods replace
+// the policy ones. It returns nil if the node must not be versioned.
+func PolicyForNode(policy *tree.VersioningPolicy, node *tree.Node) *tree.VersioningPolicy {
+	rule := MatchRule(policy, node)
+	if rule == nil {
+		return policy
+	}
+	if rule.Ignore {
+		return nil
+	}
+	applied := *policy
+	if len(rule.KeepPeriods) > 0 {
+		applied.KeepPeriods = rule.KeepPeriods
+	}
+	return &applied
+}
+
+// dataSourcePath finds the path of the node relative to its datasource root.
+func dataSourcePath(node *tree.Node) string {
+	if p := node.GetStringMeta(common.MetaNamespaceDatasourcePath); p != "" {
+		return p
+	}
+	parts := strings.SplitN(strings.Trim(node.GetPath(), "/"), "/", 2)
+	if len(parts) == 2 {
+		return parts[1]
+	}
+	return ""
+}
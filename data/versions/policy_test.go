@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package versions
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestPolicyRules(t *testing.T) {
+
+	policy := &tree.VersioningPolicy{
+		Uuid:        "policy",
+		KeepPeriods: []*tree.VersioningKeepPeriod{{IntervalStart: "0", MaxNumber: 10}},
+		Rules: []*tree.VersioningPolicyRule{
+			{Name: "scratch", PathPrefixes: []string{"/scratch"}, Ignore: true},
+			{Name: "iso", Extensions: []string{".ISO"}, Ignore: true},
+			{Name: "docx", Extensions: []string{"docx"}, KeepPeriods: []*tree.VersioningKeepPeriod{{IntervalStart: "0", MaxNumber: 50}}},
+			{Name: "big images", MimeTypes: []string{"image/*"}, MinSize: 1024, KeepPeriods: []*tree.VersioningKeepPeriod{{IntervalStart: "0", MaxNumber: 2}}},
+		},
+	}
+
+	Convey("Match rules on path, extension, mime and size", t, func() {
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/scratch/file.txt"}).Name, ShouldEqual, "scratch")
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/scratch"}).Name, ShouldEqual, "scratch")
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/scratchpad/file.txt"}), ShouldBeNil)
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/folder/image.iso"}).Name, ShouldEqual, "iso")
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/folder/report.DOCX"}).Name, ShouldEqual, "docx")
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/photo.png", Size: 2048}).Name, ShouldEqual, "big images")
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/photo.png", Size: 10}), ShouldBeNil)
+		So(MatchRule(policy, &tree.Node{Path: "pydiods1/README"}), ShouldBeNil)
+
+		// Datasource path is read from meta if available
+		n := &tree.Node{Path: "personal/admin/file.txt"}
+		n.SetMeta(common.MetaNamespaceDatasourcePath, "scratch/file.txt")
+		So(MatchRule(policy, n).Name, ShouldEqual, "scratch")
+	})
+
+	Convey("Compute policy applied to a node", t, func() {
+		So(PolicyForNode(policy, &tree.Node{Path: "pydiods1/scratch/file.docx"}), ShouldBeNil)
+		So(PolicyForNode(policy, &tree.Node{Path: "pydiods1/file.txt"}), ShouldEqual, policy)
+
+		applied := PolicyForNode(policy, &tree.Node{Path: "pydiods1/file.docx"})
+		So(applied, ShouldNotBeNil)
+		So(applied.Uuid, ShouldEqual, "policy")
+		So(applied.KeepPeriods[0].MaxNumber, ShouldEqual, 50)
+		// Original policy is untouched
+		So(policy.KeepPeriods[0].MaxNumber, ShouldEqual, 10)
+	})
+
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/pydio/cells/common/proto/tree"
 	"github.com/pydio/cells/common/service"
 	service2 "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/sync/endpoints/cells"
 	"github.com/pydio/cells/common/utils/filesystem"
 	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/minio-go/pkg/credentials"
@@ -116,14 +117,23 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 	currentMinios := config.ListMinioConfigsFromConfig()
 	_, update := currentSources[ds.Name]
 
-	minioConfig, e := config.FactorizeMinioServers(currentMinios, &ds, update)
-	if e != nil {
-		service.RestError500(req, resp, e)
-		return
+	var minioConfig *object.MinioConfig
+	if ds.StorageType == object.StorageType_CELLS {
+		// Remote Cells are directly accessed, without objects service
+		if e := s.prepareRemoteCellsDataSource(&ds); e != nil {
+			service.RestError500(req, resp, e)
+			return
+		}
+	} else {
+		var e error
+		if minioConfig, e = config.FactorizeMinioServers(currentMinios, &ds, update); e != nil {
+			service.RestError500(req, resp, e)
+			return
+		}
+		currentMinios[minioConfig.Name] = minioConfig
 	}
 	currentSources[ds.Name] = &ds
-	currentMinios[minioConfig.Name] = minioConfig
-	if ds.ApiSecret != "" {
+	if ds.ApiSecret != "" && minioConfig != nil {
 		if secretUuid == "" {
 			secretUuid = uuid.New()
 			config.SetSecret(secretUuid, ds.ApiSecret)
@@ -149,7 +159,9 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 	// UPDATE SYNC
 	config.Set(ds, "services", "pydio.grpc.data.sync."+dsName)
 	// UPDATE OBJECTS
-	config.Set(minioConfig, "services", "pydio.grpc.data.objects."+minioConfig.Name)
+	if minioConfig != nil {
+		config.Set(minioConfig, "services", "pydio.grpc.data.objects."+minioConfig.Name)
+	}
 	fmt.Println(config.Get("services", "pydio.grpc.data.sync."+dsName))
 
 	log.Logger(ctx).Info("Now Store Sources", zap.Any("sources", currentSources), zap.Any("ds", &ds))
@@ -187,6 +199,32 @@ func (s *Handler) PutDataSource(req *restful.Request, resp *restful.Response) {
 
 }
 
+// prepareRemoteCellsDataSource validates the configuration of a datasource hosted by a remote Cells server
+// and moves its credentials to the secrets vault.
+func (s *Handler) prepareRemoteCellsDataSource(ds *object.DataSource) error {
+	if ds.EncryptionMode != object.EncryptionMode_CLEAR {
+		return fmt.Errorf("datasources hosted by a remote Cells server cannot be encrypted")
+	}
+	if _, _, e := cells.RemoteConfigFromDataSource(ds); e != nil {
+		return e
+	}
+	if u, e := url.Parse(ds.StorageConfiguration[cells.StorageKeyUrl]); e != nil || u.Host == "" {
+		return fmt.Errorf("invalid remote server url for datasource %s", ds.Name)
+	}
+	ds.ObjectsServiceName = ""
+	for _, k := range cells.StorageSecretKeys {
+		v := ds.StorageConfiguration[k]
+		if v == "" || (uuid.Parse(v) != nil && config.GetSecret(v).String() != "") {
+			// Empty or already stored in the vault
+			continue
+		}
+		secretUuid := uuid.New()
+		config.SetSecret(secretUuid, v)
+		ds.StorageConfiguration[k] = secretUuid
+	}
+	return nil
+}
+
 func (s *Handler) DeleteDataSource(req *restful.Request, resp *restful.Response) {
 
 	dsName := req.PathParameter("Name")
@@ -50,6 +50,7 @@ import (
 	servicecontext "github.com/pydio/cells/common/service/context"
 	"github.com/pydio/cells/common/utils/i18n"
 	"github.com/pydio/cells/common/utils/net"
+	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/cells/scheduler/actions"
 )
 
@@ -317,6 +318,10 @@ func (s *Handler) SchedulerActionFormDiscovery(req *restful.Request, rsp *restfu
 					{servicecontext.HttpMetaCookiesString: "contextMetaField." + servicecontext.HttpMetaCookiesString},
 					//{servicecontext.ClientTime: servicecontext.ClientTime},
 					{servicecontext.ServerTime: "contextMetaField." + servicecontext.ServerTime},
+					{permissions.PolicyUserLogin: "contextMetaField." + permissions.PolicyUserLogin},
+					{permissions.PolicyUserProfile: "contextMetaField." + permissions.PolicyUserProfile},
+					{permissions.PolicyUserGroupPath: "contextMetaField." + permissions.PolicyUserGroupPath},
+					{permissions.PolicyUserAuthSource: "contextMetaField." + permissions.PolicyUserAuthSource},
 				}
 				if asSwitch {
 					sw := form.Groups[0].Fields[0].(*forms.SwitchField)
@@ -21,11 +21,17 @@
 package rest
 
 import (
+	"fmt"
+
 	"github.com/emicklei/go-restful"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/registry"
 	"github.com/pydio/cells/common/service"
 )
 
@@ -121,3 +127,65 @@ func (s *Handler) ImportEncryptionKey(req *restful.Request, resp *restful.Respon
 	}
 	resp.WriteEntity(response)
 }
+
+// RotateEncryptionKey starts a background job re-wrapping all node keys of a datasource with a new master key
+func (s *Handler) RotateEncryptionKey(req *restful.Request, resp *restful.Response) {
+	var request encryption.AdminRotateKeyRequest
+	if e := req.ReadEntity(&request); e != nil {
+		service.RestError400(req, resp, e)
+		return
+	}
+	ds, ok := config.ListSourcesFromConfig()[request.DataSource]
+	if !ok {
+		service.RestError404(req, resp, fmt.Errorf("cannot find datasource %s", request.DataSource))
+		return
+	}
+	if ds.EncryptionMode != object.EncryptionMode_MASTER {
+		service.RestError400(req, resp, fmt.Errorf("datasource %s is not encrypted with a master key", request.DataSource))
+		return
+	}
+	if request.NewKeyID == "" && !request.VerifyOnly {
+		service.RestError400(req, resp, fmt.Errorf("please provide the new key identifier"))
+		return
+	}
+
+	params := map[string]string{
+		"dataSource": request.DataSource,
+		"newKeyId":   request.NewKeyID,
+	}
+	if request.BatchSize > 0 {
+		params["batchSize"] = fmt.Sprintf("%d", request.BatchSize)
+	}
+	label := "Rotate master key for datasource " + request.DataSource
+	if request.VerifyOnly {
+		params["verifyOnly"] = "true"
+		label = "Verify master key for datasource " + request.DataSource
+	}
+	// One job per datasource, so that two rotations never run concurrently on the same keys
+	jobUuid := "rotate-key-" + request.DataSource
+	cli := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs))
+	if r, e := cli.GetJob(req.Request.Context(), &jobs.GetJobRequest{JobID: jobUuid, LoadTasks: jobs.TaskStatus_Running}); e == nil && len(r.GetJob().GetTasks()) > 0 {
+		service.RestError423(req, resp, fmt.Errorf("a key rotation is already running for datasource %s", request.DataSource))
+		return
+	}
+	job := &jobs.Job{
+		ID:             jobUuid,
+		Owner:          common.PydioSystemUsername,
+		Label:          label,
+		Inactive:       false,
+		MaxConcurrency: 1,
+		AutoStart:      true,
+		AutoClean:      true,
+		Actions: []*jobs.Action{
+			{
+				ID:         "actions.encryption.rotate-key",
+				Parameters: params,
+			},
+		},
+	}
+	if _, e := cli.PutJob(req.Request.Context(), &jobs.PutJobRequest{Job: job}); e != nil {
+		service.RestError500(req, resp, e)
+		return
+	}
+	resp.WriteEntity(&encryption.AdminRotateKeyResponse{JobUuid: jobUuid})
+}
@@ -51,7 +51,7 @@ class JobSchedule extends React.Component {
             }
             job.AutoStart = true;
         } else {
-            job.Schedule = {Iso8601Schedule: ScheduleForm.makeIso8601FromState(formState)};
+            job.Schedule = ScheduleForm.makeScheduleFromState(formState);
             if(job.AutoStart !== undefined){
                 delete job.AutoStart;
             }
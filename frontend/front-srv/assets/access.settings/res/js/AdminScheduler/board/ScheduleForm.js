@@ -20,7 +20,9 @@
 
 import React from 'react'
 import Pydio from 'pydio'
-import {JobsJob} from 'pydio/http/rest-api'
+import PydioApi from 'pydio/http/api'
+import debounce from 'lodash.debounce'
+import {JobsJob, JobsServiceApi, RestSchedulePreviewRequest, JobsSchedule} from 'pydio/http/rest-api'
 import {Dialog, FlatButton, FontIcon, MenuItem, SelectField, TextField, TimePicker} from 'material-ui'
 
 const {moment} = Pydio.requireLib('boot');
@@ -36,11 +38,20 @@ class ScheduleForm extends React.Component {
         const {schedule} = props;
         if(!schedule){
             this.state = ScheduleForm.parseIso8601('');
+        } else if(schedule.Cron){
+            this.state = {frequency: 'cron', cron: schedule.Cron, timezone: schedule.Timezone || ''};
         } else if(schedule.Iso8601Schedule){
             this.state = ScheduleForm.parseIso8601(schedule.Iso8601Schedule);
         } else{
             this.state = {frequency:'daily', daytime:new Date()}
         }
+        this._previewDebounced = debounce(() => this.loadPreview(), 500);
+    }
+
+    componentDidMount(){
+        if(this.props.edit){
+            this.loadPreview();
+        }
     }
 
     onUpdate(){
@@ -48,7 +59,10 @@ class ScheduleForm extends React.Component {
         if(onChangeState){
             onChangeState(this.state)
         } else {
-            schedule.Iso8601Schedule = ScheduleForm.makeIso8601FromState(this.state);
+            const {Iso8601Schedule, Cron, Timezone} = ScheduleForm.makeScheduleFromState(this.state);
+            schedule.Iso8601Schedule = Iso8601Schedule;
+            schedule.Cron = Cron;
+            schedule.Timezone = Timezone;
             onChange(schedule);
         }
     }
@@ -56,9 +70,37 @@ class ScheduleForm extends React.Component {
     componentDidUpdate(prevProps, prevState){
         if(prevState !== this.state) {
             this.onUpdate();
+            if(this.props.edit && prevState.preview === this.state.preview && prevState.previewError === this.state.previewError){
+                this._previewDebounced();
+            }
         }
     }
 
+    loadPreview(){
+        const {frequency} = this.state;
+        if(frequency === 'manual' || (frequency === 'cron' && !this.state.cron)){
+            this.setState({preview: [], previewError: false});
+            return;
+        }
+        const api = new JobsServiceApi(PydioApi.getRestClient());
+        const request = new RestSchedulePreviewRequest();
+        request.Schedule = JobsSchedule.constructFromObject(ScheduleForm.makeScheduleFromState(this.state));
+        request.Count = 5;
+        api.previewSchedule(request).then(response => {
+            this.setState({preview: response.NextRuns || [], previewError: false});
+        }).catch(() => {
+            this.setState({preview: [], previewError: true});
+        });
+    }
+
+    static makeScheduleFromState(state){
+        const {frequency, cron, timezone} = state;
+        if(frequency === 'cron'){
+            return {Cron: cron, Timezone: timezone || undefined};
+        }
+        return {Iso8601Schedule: ScheduleForm.makeIso8601FromState(state)};
+    }
+
     static parseIso8601(value){
         if (value === '' || value.indexOf('/') === -1){
             return {frequency: 'manual'};
@@ -173,6 +215,8 @@ class ScheduleForm extends React.Component {
                 } else {
                     return T("schedule.daily").replace('%1', dTRead);
                 }
+            case "cron":
+                return T("schedule.cron").replace('%1', state.cron + (state.timezone ? ' (' + state.timezone + ')' : ''));
             case "timely":
                 const duration = moment.duration(everyminutes, 'minutes');
                 return T("schedule.timely").replace('%1', (duration.hours()?duration.hours()+'h':'') + (duration.minutes()?duration.minutes()+'mn':''));
@@ -186,7 +230,7 @@ class ScheduleForm extends React.Component {
         if(!edit){
             return <span>{ScheduleForm.readableString(this.state, this.T, true)}</span>
         }
-        const {frequency, monthday, weekday, daytime, everyminutes} = this.state;
+        const {frequency, monthday, weekday, daytime, everyminutes, cron, timezone, preview, previewError} = this.state;
         let monthdays = [];
         let weekdays = moment.weekdays();
         for (let i = 1;i<30; i++){
@@ -196,7 +240,7 @@ class ScheduleForm extends React.Component {
             <div>
                 <div style={{padding: '10px 0', textAlign:'center'}}>
                     <div style={{color: Blue, fontSize: 15, fontWeight:500}}>{ScheduleForm.readableString(this.state, this.T, false)}</div>
-                    {frequency !== 'manual' && <div style={{fontSize:11, paddingTop: 5, color:LightGrey}}>ISO8601: {ScheduleForm.makeIso8601FromState(this.state)}</div>}
+                    {frequency !== 'manual' && frequency !== 'cron' && <div style={{fontSize:11, paddingTop: 5, color:LightGrey}}>ISO8601: {ScheduleForm.makeIso8601FromState(this.state)}</div>}
                 </div>
                 <ModernSelectField
                     floatingLabelText={this.T('schedule.type')}
@@ -211,6 +255,7 @@ class ScheduleForm extends React.Component {
                     <MenuItem value={'weekly'} primaryText={this.T('schedule.type.weekly')} />
                     <MenuItem value={'daily'} primaryText={this.T('schedule.type.daily')} />
                     <MenuItem value={'timely'} primaryText={this.T('schedule.type.timely')} />
+                    <MenuItem value={'cron'} primaryText={this.T('schedule.type.cron')} />
                 </ModernSelectField>
                 {frequency === 'monthly' &&
                 <div>
@@ -260,6 +305,29 @@ class ScheduleForm extends React.Component {
                     />
                 </div>
                 }
+                {frequency === 'cron' &&
+                <div>
+                    <ModernTextField
+                        floatingLabelText={this.T('schedule.detail.cron')}
+                        value={cron || ''}
+                        onChange={(e,val)=>{this.setState({cron:val})}}
+                        fullWidth={true}
+                    />
+                    <ModernTextField
+                        floatingLabelText={this.T('schedule.detail.timezone')}
+                        value={timezone || ''}
+                        onChange={(e,val)=>{this.setState({timezone:val})}}
+                        fullWidth={true}
+                    />
+                </div>
+                }
+                {frequency !== 'manual' && (previewError || (preview && preview.length > 0)) &&
+                <div style={{paddingTop: 10, fontSize: 13}}>
+                    <div style={{color: Blue, fontWeight: 500, paddingBottom: 5}}>{this.T('schedule.preview')}</div>
+                    {previewError && <div style={{color: '#e53935'}}>{this.T('schedule.preview.error')}</div>}
+                    {!previewError && preview.map(t => <div key={t}>{moment(t * 1000).format('LLLL')}</div>)}
+                </div>
+                }
             </div>
         );
 
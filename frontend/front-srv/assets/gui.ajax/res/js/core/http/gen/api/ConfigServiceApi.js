@@ -529,7 +529,7 @@ export default class ConfigServiceApi {
      * @param {String} opts.apiKey Corresponding objects service api key.
      * @param {String} opts.apiSecret Corresponding objects service api secret.
      * @param {String} opts.peerAddress Peer address of the data source.
-     * @param {Boolean} opts.watch Not implemented, whether to watch for underlying changes on the FS.
+     * @param {Boolean} opts.watch Whether to watch for changes performed directly on the underlying storage.
      * @param {module:model/String} opts.encryptionMode Type of encryption applied before sending data to storage. (default to CLEAR)
      * @param {String} opts.encryptionKey Encryption key used for encrypting data.
      * @param {String} opts.versioningPolicyName Versioning policy describes how files are kept in the versioning queue.
@@ -601,7 +601,7 @@ export default class ConfigServiceApi {
      * @param {String} opts.apiKey Corresponding objects service api key.
      * @param {String} opts.apiSecret Corresponding objects service api secret.
      * @param {String} opts.peerAddress Peer address of the data source.
-     * @param {Boolean} opts.watch Not implemented, whether to watch for underlying changes on the FS.
+     * @param {Boolean} opts.watch Whether to watch for changes performed directly on the underlying storage.
      * @param {module:model/String} opts.encryptionMode Type of encryption applied before sending data to storage. (default to CLEAR)
      * @param {String} opts.encryptionKey Encryption key used for encrypting data.
      * @param {String} opts.versioningPolicyName Versioning policy describes how files are kept in the versioning queue.
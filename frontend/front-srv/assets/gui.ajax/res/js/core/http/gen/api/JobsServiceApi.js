@@ -20,6 +20,8 @@ import JobsDeleteTasksResponse from '../model/JobsDeleteTasksResponse';
 import JobsListJobsRequest from '../model/JobsListJobsRequest';
 import LogListLogRequest from '../model/LogListLogRequest';
 import RestLogMessageCollection from '../model/RestLogMessageCollection';
+import RestSchedulePreviewRequest from '../model/RestSchedulePreviewRequest';
+import RestSchedulePreviewResponse from '../model/RestSchedulePreviewResponse';
 import RestUserJobRequest from '../model/RestUserJobRequest';
 import RestUserJobResponse from '../model/RestUserJobResponse';
 import RestUserJobsCollection from '../model/RestUserJobsCollection';
@@ -92,6 +94,54 @@ export default class JobsServiceApi {
     }
 
 
+    /**
+     * Compute the next run times of a given schedule
+     * @param {module:model/RestSchedulePreviewRequest} body 
+     * @return {Promise} a {@link https://www.promisejs.org/|Promise}, with an object containing data of type {@link module:model/RestSchedulePreviewResponse} and HTTP response
+     */
+    previewScheduleWithHttpInfo(body) {
+      let postBody = body;
+
+      // verify the required parameter 'body' is set
+      if (body === undefined || body === null) {
+        throw new Error("Missing the required parameter 'body' when calling previewSchedule");
+      }
+
+
+      let pathParams = {
+      };
+      let queryParams = {
+      };
+      let headerParams = {
+      };
+      let formParams = {
+      };
+
+      let authNames = [];
+      let contentTypes = ['application/json'];
+      let accepts = ['application/json'];
+      let returnType = RestSchedulePreviewResponse;
+
+      return this.apiClient.callApi(
+        '/jobs/schedule/preview', 'POST',
+        pathParams, queryParams, headerParams, formParams, postBody,
+        authNames, contentTypes, accepts, returnType
+      );
+    }
+
+    /**
+     * Compute the next run times of a given schedule
+     * @param {module:model/RestSchedulePreviewRequest} body 
+     * @return {Promise} a {@link https://www.promisejs.org/|Promise}, with data of type {@link module:model/RestSchedulePreviewResponse}
+     */
+    previewSchedule(body) {
+      return this.previewScheduleWithHttpInfo(body)
+        .then(function(response_and_data) {
+          return response_and_data.data;
+        });
+    }
+
+
     /**
      * Send Control Commands to one or many jobs / tasks
      * @param {module:model/JobsCtrlCommand} body 
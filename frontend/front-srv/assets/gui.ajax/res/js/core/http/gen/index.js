@@ -184,6 +184,8 @@ import RestRestoreNodesResponse from './model/RestRestoreNodesResponse';
 import RestRevokeRequest from './model/RestRevokeRequest';
 import RestRevokeResponse from './model/RestRevokeResponse';
 import RestRolesCollection from './model/RestRolesCollection';
+import RestSchedulePreviewRequest from './model/RestSchedulePreviewRequest';
+import RestSchedulePreviewResponse from './model/RestSchedulePreviewResponse';
 import RestSchedulerActionFormResponse from './model/RestSchedulerActionFormResponse';
 import RestSchedulerActionsResponse from './model/RestSchedulerActionsResponse';
 import RestSearchACLRequest from './model/RestSearchACLRequest';
@@ -236,6 +238,7 @@ import TreeSearchFacet from './model/TreeSearchFacet';
 import TreeSearchRequest from './model/TreeSearchRequest';
 import TreeVersioningKeepPeriod from './model/TreeVersioningKeepPeriod';
 import TreeVersioningPolicy from './model/TreeVersioningPolicy';
+import TreeVersioningPolicyRule from './model/TreeVersioningPolicyRule';
 import TreeWorkspaceRelativePath from './model/TreeWorkspaceRelativePath';
 import UpdateApplyUpdateRequest from './model/UpdateApplyUpdateRequest';
 import UpdateApplyUpdateResponse from './model/UpdateApplyUpdateResponse';
@@ -1332,6 +1335,18 @@ export {
      */
     RestRolesCollection,
 
+    /**
+     * The RestSchedulePreviewRequest model constructor.
+     * @property {module:model/RestSchedulePreviewRequest}
+     */
+    RestSchedulePreviewRequest,
+
+    /**
+     * The RestSchedulePreviewResponse model constructor.
+     * @property {module:model/RestSchedulePreviewResponse}
+     */
+    RestSchedulePreviewResponse,
+
     /**
      * The RestSchedulerActionFormResponse model constructor.
      * @property {module:model/RestSchedulerActionFormResponse}
@@ -1644,6 +1659,12 @@ export {
      */
     TreeVersioningPolicy,
 
+    /**
+     * The TreeVersioningPolicyRule model constructor.
+     * @property {module:model/TreeVersioningPolicyRule}
+     */
+    TreeVersioningPolicyRule,
+
     /**
      * The TreeWorkspaceRelativePath model constructor.
      * @property {module:model/TreeWorkspaceRelativePath}
@@ -62,6 +62,12 @@ export default class JobsSchedule {
             if (data.hasOwnProperty('Iso8601MinDelta')) {
                 obj['Iso8601MinDelta'] = ApiClient.convertToType(data['Iso8601MinDelta'], 'String');
             }
+            if (data.hasOwnProperty('Cron')) {
+                obj['Cron'] = ApiClient.convertToType(data['Cron'], 'String');
+            }
+            if (data.hasOwnProperty('Timezone')) {
+                obj['Timezone'] = ApiClient.convertToType(data['Timezone'], 'String');
+            }
         }
         return obj;
     }
@@ -75,6 +81,15 @@ export default class JobsSchedule {
     * @member {String} Iso8601MinDelta
     */
     Iso8601MinDelta = undefined;
+    /**
+    * Cron expression (minute hour day-of-month month day-of-week), for instance \"0 2 * * MON-FRI\" or \"0 2 * * SUN#1\". Takes precedence over Iso8601Schedule if set.
+    * @member {String} Cron
+    */
+    Cron = undefined;
+    /**
+    * @member {String} Timezone
+    */
+    Timezone = undefined;
 
 
 
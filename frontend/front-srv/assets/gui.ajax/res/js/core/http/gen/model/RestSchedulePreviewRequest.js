@@ -0,0 +1,88 @@
+/**
+ * Pydio Cells Rest API
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * OpenAPI spec version: 1.0
+ * 
+ *
+ * NOTE: This class is auto generated by the swagger code generator program.
+ * https://github.com/swagger-api/swagger-codegen.git
+ * Do not edit the class manually.
+ *
+ */
+
+
+import ApiClient from '../ApiClient';
+import JobsSchedule from './JobsSchedule';
+
+
+
+
+
+/**
+* The RestSchedulePreviewRequest model module.
+* @module model/RestSchedulePreviewRequest
+* @version 1.0
+*/
+export default class RestSchedulePreviewRequest {
+    /**
+    * Constructs a new <code>RestSchedulePreviewRequest</code>.
+    * @alias module:model/RestSchedulePreviewRequest
+    * @class
+    */
+
+    constructor() {
+        
+
+        
+        
+
+        
+
+        
+    }
+
+    /**
+    * Constructs a <code>RestSchedulePreviewRequest</code> from a plain JavaScript object, optionally creating a new instance.
+    * Copies all relevant properties from <code>data</code> to <code>obj</code> if supplied or a new instance if not.
+    * @param {Object} data The plain JavaScript object bearing properties of interest.
+    * @param {module:model/RestSchedulePreviewRequest} obj Optional instance to populate.
+    * @return {module:model/RestSchedulePreviewRequest} The populated <code>RestSchedulePreviewRequest</code> instance.
+    */
+    static constructFromObject(data, obj) {
+        if (data) {
+            obj = obj || new RestSchedulePreviewRequest();
+
+            
+            
+            
+
+            if (data.hasOwnProperty('Schedule')) {
+                obj['Schedule'] = JobsSchedule.constructFromObject(data['Schedule']);
+            }
+            if (data.hasOwnProperty('Count')) {
+                obj['Count'] = ApiClient.convertToType(data['Count'], 'Number');
+            }
+        }
+        return obj;
+    }
+
+    /**
+    * @member {module:model/JobsSchedule} Schedule
+    */
+    Schedule = undefined;
+    /**
+    * @member {Number} Count
+    */
+    Count = undefined;
+
+
+
+
+
+
+
+
+}
+
+
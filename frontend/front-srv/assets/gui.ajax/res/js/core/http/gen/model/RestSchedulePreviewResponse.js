@@ -0,0 +1,80 @@
+/**
+ * Pydio Cells Rest API
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * OpenAPI spec version: 1.0
+ * 
+ *
+ * NOTE: This class is auto generated by the swagger code generator program.
+ * https://github.com/swagger-api/swagger-codegen.git
+ * Do not edit the class manually.
+ *
+ */
+
+
+import ApiClient from '../ApiClient';
+
+
+
+
+
+/**
+* The RestSchedulePreviewResponse model module.
+* @module model/RestSchedulePreviewResponse
+* @version 1.0
+*/
+export default class RestSchedulePreviewResponse {
+    /**
+    * Constructs a new <code>RestSchedulePreviewResponse</code>.
+    * @alias module:model/RestSchedulePreviewResponse
+    * @class
+    */
+
+    constructor() {
+        
+
+        
+        
+
+        
+
+        
+    }
+
+    /**
+    * Constructs a <code>RestSchedulePreviewResponse</code> from a plain JavaScript object, optionally creating a new instance.
+    * Copies all relevant properties from <code>data</code> to <code>obj</code> if supplied or a new instance if not.
+    * @param {Object} data The plain JavaScript object bearing properties of interest.
+    * @param {module:model/RestSchedulePreviewResponse} obj Optional instance to populate.
+    * @return {module:model/RestSchedulePreviewResponse} The populated <code>RestSchedulePreviewResponse</code> instance.
+    */
+    static constructFromObject(data, obj) {
+        if (data) {
+            obj = obj || new RestSchedulePreviewResponse();
+
+            
+            
+            
+
+            if (data.hasOwnProperty('NextRuns')) {
+                obj['NextRuns'] = ApiClient.convertToType(data['NextRuns'], ['Number']);
+            }
+        }
+        return obj;
+    }
+
+    /**
+    * @member {Array.<Number>} NextRuns
+    */
+    NextRuns = undefined;
+
+
+
+
+
+
+
+
+}
+
+
@@ -14,6 +14,7 @@
 
 import ApiClient from '../ApiClient';
 import TreeVersioningKeepPeriod from './TreeVersioningKeepPeriod';
+import TreeVersioningPolicyRule from './TreeVersioningPolicyRule';
 
 
 
@@ -84,6 +85,9 @@ export default class TreeVersioningPolicy {
             if (data.hasOwnProperty('KeepPeriods')) {
                 obj['KeepPeriods'] = ApiClient.convertToType(data['KeepPeriods'], [TreeVersioningKeepPeriod]);
             }
+            if (data.hasOwnProperty('Rules')) {
+                obj['Rules'] = ApiClient.convertToType(data['Rules'], [TreeVersioningPolicyRule]);
+            }
         }
         return obj;
     }
@@ -124,6 +128,10 @@ export default class TreeVersioningPolicy {
     * @member {Array.<module:model/TreeVersioningKeepPeriod>} KeepPeriods
     */
     KeepPeriods = undefined;
+    /**
+    * @member {Array.<module:model/TreeVersioningPolicyRule>} Rules
+    */
+    Rules = undefined;
 
 
 
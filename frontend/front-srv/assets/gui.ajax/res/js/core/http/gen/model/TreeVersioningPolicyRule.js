@@ -0,0 +1,130 @@
+/**
+ * Pydio Cells Rest API
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * OpenAPI spec version: 1.0
+ * 
+ *
+ * NOTE: This class is auto generated by the swagger code generator program.
+ * https://github.com/swagger-api/swagger-codegen.git
+ * Do not edit the class manually.
+ *
+ */
+
+
+import ApiClient from '../ApiClient';
+import TreeVersioningKeepPeriod from './TreeVersioningKeepPeriod';
+
+
+
+
+
+/**
+* The TreeVersioningPolicyRule model module.
+* @module model/TreeVersioningPolicyRule
+* @version 1.0
+*/
+export default class TreeVersioningPolicyRule {
+    /**
+    * Constructs a new <code>TreeVersioningPolicyRule</code>.
+    * @alias module:model/TreeVersioningPolicyRule
+    * @class
+    */
+
+    constructor() {
+        
+
+        
+        
+
+        
+
+        
+    }
+
+    /**
+    * Constructs a <code>TreeVersioningPolicyRule</code> from a plain JavaScript object, optionally creating a new instance.
+    * Copies all relevant properties from <code>data</code> to <code>obj</code> if supplied or a new instance if not.
+    * @param {Object} data The plain JavaScript object bearing properties of interest.
+    * @param {module:model/TreeVersioningPolicyRule} obj Optional instance to populate.
+    * @return {module:model/TreeVersioningPolicyRule} The populated <code>TreeVersioningPolicyRule</code> instance.
+    */
+    static constructFromObject(data, obj) {
+        if (data) {
+            obj = obj || new TreeVersioningPolicyRule();
+
+            
+            
+            
+
+            if (data.hasOwnProperty('Name')) {
+                obj['Name'] = ApiClient.convertToType(data['Name'], 'String');
+            }
+            if (data.hasOwnProperty('PathPrefixes')) {
+                obj['PathPrefixes'] = ApiClient.convertToType(data['PathPrefixes'], ['String']);
+            }
+            if (data.hasOwnProperty('Extensions')) {
+                obj['Extensions'] = ApiClient.convertToType(data['Extensions'], ['String']);
+            }
+            if (data.hasOwnProperty('MimeTypes')) {
+                obj['MimeTypes'] = ApiClient.convertToType(data['MimeTypes'], ['String']);
+            }
+            if (data.hasOwnProperty('MinSize')) {
+                obj['MinSize'] = ApiClient.convertToType(data['MinSize'], 'String');
+            }
+            if (data.hasOwnProperty('MaxSize')) {
+                obj['MaxSize'] = ApiClient.convertToType(data['MaxSize'], 'String');
+            }
+            if (data.hasOwnProperty('Ignore')) {
+                obj['Ignore'] = ApiClient.convertToType(data['Ignore'], 'Boolean');
+            }
+            if (data.hasOwnProperty('KeepPeriods')) {
+                obj['KeepPeriods'] = ApiClient.convertToType(data['KeepPeriods'], [TreeVersioningKeepPeriod]);
+            }
+        }
+        return obj;
+    }
+
+    /**
+    * @member {String} Name
+    */
+    Name = undefined;
+    /**
+    * @member {Array.<String>} PathPrefixes
+    */
+    PathPrefixes = undefined;
+    /**
+    * @member {Array.<String>} Extensions
+    */
+    Extensions = undefined;
+    /**
+    * @member {Array.<String>} MimeTypes
+    */
+    MimeTypes = undefined;
+    /**
+    * @member {String} MinSize
+    */
+    MinSize = undefined;
+    /**
+    * @member {String} MaxSize
+    */
+    MaxSize = undefined;
+    /**
+    * @member {Boolean} Ignore
+    */
+    Ignore = undefined;
+    /**
+    * @member {Array.<module:model/TreeVersioningKeepPeriod>} KeepPeriods
+    */
+    KeepPeriods = undefined;
+
+
+
+
+
+
+
+
+}
+
+
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package modifiers
+
+import (
+	"github.com/emicklei/go-restful"
+	"github.com/gorilla/sessions"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service/frontend"
+)
+
+// LoginKeyringWrapper unlocks the passphrase protected keyring of a user after a successful login, if any
+// datasource is encrypted in USER_PWD mode. When the keyring is created, the recovery key is passed back
+// to the client in the TriggerInfo. When it must be recovered after a password reset, the client is told so.
+func LoginKeyringWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddleware {
+	return func(req *restful.Request, rsp *restful.Response, in *rest.FrontSessionRequest, out *rest.FrontSessionResponse, session *sessions.Session) error {
+		if a, ok := in.AuthInfo["type"]; !ok || a != "credentials" { // Ignore this middleware
+			return middleware(req, rsp, in, out, session)
+		}
+
+		if err := middleware(req, rsp, in, out, session); err != nil {
+			return err
+		}
+
+		if !hasUserPwdDataSource() {
+			return nil
+		}
+
+		ctx := req.Request.Context()
+		login := in.AuthInfo["login"]
+		cli := encryption.NewUserKeyStoreClient(common.ServiceGrpcNamespace_+common.ServiceUserKey, defaults.NewClient())
+		resp, e := cli.UnlockUserKey(ctx, &encryption.UnlockUserKeyRequest{
+			UserLogin:   login,
+			StrPassword: in.AuthInfo["password"],
+		})
+		if e != nil {
+			// Do not prevent login, user will simply not be able to access encrypted data
+			log.Logger(ctx).Error("cannot unlock user keyring", zap.String(common.KEY_USERNAME, login), zap.Error(e))
+			return nil
+		}
+		if resp.Created && resp.RecoveryKey != "" {
+			if out.TriggerInfo == nil {
+				out.TriggerInfo = make(map[string]string)
+			}
+			out.TriggerInfo["keyring_recovery_key"] = resp.RecoveryKey
+		} else if resp.RecoveryRequired {
+			// Password was reset, keyring must be recovered with its recovery key
+			if out.TriggerInfo == nil {
+				out.TriggerInfo = make(map[string]string)
+			}
+			out.TriggerInfo["keyring_recovery_required"] = "true"
+		}
+		return nil
+	}
+}
+
+func hasUserPwdDataSource() bool {
+	for _, ds := range config.ListSourcesFromConfig() {
+		if ds.EncryptionMode == object.EncryptionMode_USER_PWD {
+			return true
+		}
+	}
+	return false
+}
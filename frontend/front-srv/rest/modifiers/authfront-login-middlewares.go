@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/mfa"
 	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/idm"
@@ -63,6 +64,16 @@ func LoginSuccessWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddle
 			return errors.Unauthorized(common.ServiceUser, "User "+user.Login+" has been blocked. Contact your sysadmin.")
 		}
 
+		// Checking user access start date (scheduled public links)
+		if permissions.IsUserPending(user) {
+			log.Auditer(ctx).Error(
+				"Pending user ["+user.Login+"] tried to log in before its access start date.",
+				log.GetAuditId(common.AUDIT_LOGIN_POLICY_DENIAL),
+				zap.String(common.KEY_USER_UUID, user.Uuid),
+			)
+			return errors.Unauthorized(common.ServiceUser, "This link is not active yet")
+		}
+
 		// Reset failed connections
 		if user.Attributes != nil {
 			if _, ok := user.Attributes["failedConnections"]; ok {
@@ -77,6 +88,7 @@ func LoginSuccessWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddle
 		cli := idm.NewPolicyEngineServiceClient(common.ServiceGrpcNamespace_+common.ServicePolicy, defaults.NewClient())
 		policyContext := make(map[string]string)
 		permissions.PolicyContextFromMetadata(policyContext, ctx)
+		permissions.PolicyContextFromUser(policyContext, user)
 		subjects := permissions.PolicyRequestSubjectsFromUser(user)
 
 		// Check all subjects, if one has deny return false
@@ -121,6 +133,11 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 			return nil
 		}
 
+		// Password was valid, the frontend must now ask for the second factor
+		if id := errors.Parse(err.Error()).Id; id == mfa.ErrRequired || id == mfa.ErrEnroll {
+			return err
+		}
+
 		fmt.Println("Login failed with ", err)
 
 		ctx := req.Request.Context()
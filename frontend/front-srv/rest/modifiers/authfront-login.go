@@ -10,6 +10,7 @@ import (
 
 	"github.com/pydio/cells/common/auth"
 	"github.com/pydio/cells/common/auth/hydra"
+	"github.com/pydio/cells/common/auth/mfa"
 	pauth "github.com/pydio/cells/common/proto/auth"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/service/frontend"
@@ -23,10 +24,12 @@ func LoginPasswordAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddlewa
 
 		username := in.AuthInfo["login"]
 		password := in.AuthInfo["password"]
+		// Second factor, if the user is enrolled
+		ctx := mfa.WithCode(req.Request.Context(), in.AuthInfo["mfa_code"])
 
 		if challenge, ok := in.AuthInfo["challenge"]; ok {
 			// If we do have a challenge, then we're coming from an external source and
-			code, err := auth.DefaultJWTVerifier().PasswordCredentialsCode(req.Request.Context(), username, password, auth.SetChallenge(challenge))
+			code, err := auth.DefaultJWTVerifier().PasswordCredentialsCode(ctx, username, password, auth.SetChallenge(challenge))
 			if err != nil {
 				return err
 			}
@@ -53,7 +56,7 @@ func LoginPasswordAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddlewa
 		}
 
 		// If we don't have a challenge then we proceed with a normal login
-		token, err := auth.DefaultJWTVerifier().PasswordCredentialsToken(req.Request.Context(), username, password)
+		token, err := auth.DefaultJWTVerifier().PasswordCredentialsToken(ctx, username, password)
 		if err != nil {
 			return err
 		}
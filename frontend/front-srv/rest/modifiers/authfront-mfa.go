@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package modifiers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/auth/mfa"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service"
+)
+
+// EnrollMFA manages the second factor of the current user. EnrollInfo["action"] is one of:
+//   - status: tells whether the user is enrolled and whether a second factor is required
+//   - setup: returns a new secret and its otpauth URI
+//   - confirm: activates the secret with a first "code" and returns recovery codes
+//   - recovery-codes: checks the "code" and returns new recovery codes
+//   - disable: checks the "code" and removes the second factor
+func EnrollMFA(req *restful.Request, rsp *restful.Response, in *rest.FrontEnrollAuthRequest) bool {
+	if in.EnrollType != mfa.EnrollType {
+		return false
+	}
+	ctx := req.Request.Context()
+	claims, ok := ctx.Value(claim.ContextKey).(claim.Claims)
+	if !ok || claims.Subject == "" {
+		service.RestError401(req, rsp, errors.Unauthorized(common.ServiceUser, "user must be logged in"))
+		return true
+	}
+	m := mfa.NewManager()
+	user, err := m.LoadUser(ctx, claims.Subject)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return true
+	}
+
+	info := make(map[string]string)
+	code := in.EnrollInfo["code"]
+	var codes []string
+	switch in.EnrollInfo["action"] {
+	case "status":
+	case "setup":
+		if mfa.Enrolled(user) {
+			err = errors.BadRequest(mfa.ErrEnroll, "a second factor is already enrolled, disable it first")
+			break
+		}
+		info["secret"], info["uri"], err = m.Setup(ctx, user)
+	case "confirm":
+		if codes, err = m.Confirm(ctx, user, code); err == nil {
+			log.Auditer(ctx).Info("User "+user.Login+" enrolled a second authentication factor", user.ZapLogin())
+		}
+	case "recovery-codes":
+		if err = m.CheckCode(ctx, user, code); err == nil {
+			codes, err = m.RecoveryCodes(ctx, user)
+		}
+	case "disable":
+		if err = m.Disable(ctx, user, code); err == nil {
+			log.Auditer(ctx).Info("User "+user.Login+" disabled the second authentication factor", user.ZapLogin())
+		}
+	default:
+		err = errors.BadRequest(mfa.ErrEnroll, "unknown action %s", in.EnrollInfo["action"])
+	}
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err, 403)
+		return true
+	}
+	if len(codes) > 0 {
+		info["recoveryCodes"] = strings.Join(codes, ",")
+	}
+	info["enrolled"] = strconv.FormatBool(mfa.Enrolled(user))
+	info["required"] = strconv.FormatBool(m.Required(ctx, user))
+	info["recoveryCodesLeft"] = strconv.Itoa(mfa.CountRecoveryCodes(user.Attributes[mfa.AttrRecoveryCodes]))
+	rsp.WriteEntity(&rest.FrontEnrollAuthResponse{Info: info})
+	return true
+}
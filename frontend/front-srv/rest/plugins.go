@@ -95,6 +95,9 @@ func init() {
 
 		frontend.WrapAuthMiddleware(modifiers.LoginSuccessWrapper)
 		frontend.WrapAuthMiddleware(modifiers.LoginFailedWrapper)
+		frontend.WrapAuthMiddleware(modifiers.LoginKeyringWrapper)
+
+		frontend.RegisterEnrollMiddleware("FrontEnrollAuth", modifiers.EnrollMFA)
 
 		s := service.NewService(
 			service.Name(common.ServiceRestNamespace_+common.ServiceFrontend),
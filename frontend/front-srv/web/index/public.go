@@ -72,6 +72,12 @@ func (h *PublicHandler) computeTplConf(req *http.Request, linkId string) (status
 		}
 	}
 
+	// Check start time
+	if linkData.StartTime > 0 && time.Now().Before(time.Unix(linkData.StartTime, 0)) {
+		tplConf.ErrorMessage = "This link is not active yet. Please come back later."
+		return 403, tplConf
+	}
+
 	// Check expiration time
 	if linkData.ExpireTime > 0 && time.Now().After(time.Unix(linkData.ExpireTime, 0)) {
 		tplConf.ErrorMessage = "This link has expired. Please contact the person who sent it to you."
@@ -66,11 +66,11 @@ func newHandler(ctx context.Context, router *views.Router) http.Handler {
 		Debug:  true,
 		mu:     sync.Mutex{},
 	}
+	lockSystem := NewLockSystem(fs)
 
 	dav := &webdav.Handler{
 		FileSystem: fs,
 		Prefix:     "/dav",
-		LockSystem: webdav.NewMemLS(),
 		Logger: func(r *http.Request, err error) {
 			if strings.HasPrefix(path.Base(r.URL.Path), ".") {
 				// Ignore dot files
@@ -97,5 +97,12 @@ func newHandler(ctx context.Context, router *views.Router) http.Handler {
 		},
 	}
 
-	return basicAuthenticator.Wrap(logRequest(dav))
+	// LockSystem must be bound to each request to resolve nodes and users
+	withLocks := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := *dav
+		h.LockSystem = lockSystem.WithRequest(r)
+		h.ServeHTTP(w, r)
+	})
+
+	return basicAuthenticator.Wrap(logRequest(withLocks))
 }
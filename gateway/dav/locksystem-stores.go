@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package dav
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+	serviceproto "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+// docStoreLockStore persists lock records as JSON documents in the DocStore service.
+type docStoreLockStore struct{}
+
+func (d *docStoreLockStore) client() docstore.DocStoreClient {
+	return docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+}
+
+// lockMeta is indexed along with each lock to find it by path. Paths are hashed
+// to be matched as single terms by the DocStore indexer.
+type lockMeta struct {
+	RootKey    string   `json:"rootKey"`
+	ParentKeys []string `json:"parentKeys,omitempty"`
+}
+
+func pathKey(name string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(name)))
+}
+
+func newLockMeta(record *lockRecord) *lockMeta {
+	m := &lockMeta{RootKey: pathKey(record.Root)}
+	for _, p := range ancestors(record.Root) {
+		m.ParentKeys = append(m.ParentKeys, pathKey(p))
+	}
+	return m
+}
+
+// ListByPath loads the locks rooted at the resource or its ancestors, and optionally under the resource.
+func (d *docStoreLockStore) ListByPath(ctx context.Context, root string, descendants bool) ([]*lockRecord, error) {
+	var queries []string
+	for _, p := range append(ancestors(root), root) {
+		queries = append(queries, "+rootKey:"+pathKey(p))
+	}
+	if descendants {
+		queries = append(queries, "+parentKeys:"+pathKey(root))
+	}
+	var records []*lockRecord
+	seen := make(map[string]struct{})
+	for _, q := range queries {
+		rr, e := d.search(ctx, q)
+		if e != nil {
+			return nil, e
+		}
+		for _, r := range rr {
+			if _, ok := seen[r.Token]; !ok {
+				seen[r.Token] = struct{}{}
+				records = append(records, r)
+			}
+		}
+	}
+	return records, nil
+}
+
+func (d *docStoreLockStore) search(ctx context.Context, metaQuery string) ([]*lockRecord, error) {
+	stream, e := d.client().ListDocuments(ctx, &docstore.ListDocumentsRequest{
+		StoreID: common.DocStoreIdDavLocks,
+		Query:   &docstore.DocumentQuery{MetaQuery: metaQuery},
+	})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	var records []*lockRecord
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if resp == nil || resp.Document == nil {
+			continue
+		}
+		var r lockRecord
+		if json.Unmarshal([]byte(resp.Document.Data), &r) == nil {
+			records = append(records, &r)
+		}
+	}
+	return records, nil
+}
+
+// Get loads one lock by its token.
+func (d *docStoreLockStore) Get(ctx context.Context, token string) (*lockRecord, error) {
+	resp, e := d.client().GetDocument(ctx, &docstore.GetDocumentRequest{StoreID: common.DocStoreIdDavLocks, DocumentID: token})
+	if e != nil {
+		return nil, e
+	}
+	if resp.Document == nil || resp.Document.Data == "" {
+		return nil, nil
+	}
+	var r lockRecord
+	if e := json.Unmarshal([]byte(resp.Document.Data), &r); e != nil {
+		return nil, e
+	}
+	return &r, nil
+}
+
+// Put stores or updates a lock.
+func (d *docStoreLockStore) Put(ctx context.Context, record *lockRecord) error {
+	data, e := json.Marshal(record)
+	if e != nil {
+		return e
+	}
+	meta, e := json.Marshal(newLockMeta(record))
+	if e != nil {
+		return e
+	}
+	_, e = d.client().PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdDavLocks,
+		DocumentID: record.Token,
+		Document: &docstore.Document{
+			ID:            record.Token,
+			Owner:         record.Owner,
+			Type:          docstore.DocumentType_JSON,
+			Data:          string(data),
+			IndexableMeta: string(meta),
+		},
+	})
+	return e
+}
+
+// Delete removes a lock.
+func (d *docStoreLockStore) Delete(ctx context.Context, token string) error {
+	_, e := d.client().DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{StoreID: common.DocStoreIdDavLocks, DocumentID: token})
+	return e
+}
+
+// aclContentLocker reads and writes "content_lock" ACLs, exactly as the web interface does.
+type aclContentLocker struct {
+	fs *FileSystem
+}
+
+func (a *aclContentLocker) client() idm.ACLServiceClient {
+	return idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+}
+
+func (a *aclContentLocker) query(nodeUuid string) *serviceproto.Query {
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		NodeIDs: []string{nodeUuid},
+		Actions: []*idm.ACLAction{{Name: permissions.AclContentLock.Name}},
+	})
+	return &serviceproto.Query{SubQueries: []*any.Any{q}}
+}
+
+// Resolve reads the node through the router and looks up an existing content lock.
+func (a *aclContentLocker) Resolve(ctx context.Context, name string) (string, string, error) {
+	resp, e := a.fs.Router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: name}})
+	if e != nil {
+		return "", "", e
+	}
+	nodeUuid := resp.Node.Uuid
+	stream, e := a.client().SearchACL(ctx, &idm.SearchACLRequest{Query: a.query(nodeUuid)})
+	if e != nil {
+		return nodeUuid, "", e
+	}
+	defer stream.Close()
+	var owner string
+	for {
+		rsp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if rsp == nil || rsp.ACL == nil {
+			continue
+		}
+		owner = rsp.ACL.Action.Value
+		break
+	}
+	return nodeUuid, owner, nil
+}
+
+// Lock creates a content_lock ACL for the given user, expiring with the DAV lock.
+func (a *aclContentLocker) Lock(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error {
+	_, e := a.client().CreateACL(ctx, &idm.CreateACLRequest{ACL: &idm.ACL{
+		NodeID: nodeUuid,
+		Action: &idm.ACLAction{Name: permissions.AclContentLock.Name, Value: owner},
+	}})
+	if e != nil || expiry.IsZero() {
+		return e
+	}
+	return a.Expire(ctx, nodeUuid, owner, expiry)
+}
+
+// Expire sets the expiration of the content_lock ACL of the given user.
+func (a *aclContentLocker) Expire(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error {
+	if expiry.IsZero() {
+		// Infinite lock: expiration cannot be removed, push it far away
+		expiry = time.Now().AddDate(100, 0, 0)
+	}
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		NodeIDs: []string{nodeUuid},
+		Actions: []*idm.ACLAction{{Name: permissions.AclContentLock.Name, Value: owner}},
+	})
+	_, e := a.client().ExpireACL(ctx, &idm.ExpireACLRequest{
+		Query:     &serviceproto.Query{SubQueries: []*any.Any{q}},
+		Timestamp: expiry.Unix(),
+	})
+	return e
+}
+
+// Unlock removes content_lock ACLs from the node.
+func (a *aclContentLocker) Unlock(ctx context.Context, nodeUuid string) error {
+	_, e := a.client().DeleteACL(ctx, &idm.DeleteACLRequest{Query: a.query(nodeUuid)})
+	return e
+}
@@ -0,0 +1,474 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package dav
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/net/webdav"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// lockRecord is the persisted representation of a WebDAV lock.
+type lockRecord struct {
+	Token     string        `json:"token"`
+	Root      string        `json:"root"`
+	ZeroDepth bool          `json:"zeroDepth"`
+	OwnerXML  string        `json:"ownerXML,omitempty"`
+	Owner     string        `json:"owner,omitempty"`
+	NodeUuid  string        `json:"nodeUuid,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	Expiry    int64         `json:"expiry,omitempty"`
+	// AclLocked is true if this lock created the content_lock ACL on the node
+	AclLocked bool `json:"aclLocked,omitempty"`
+}
+
+func (r *lockRecord) details() webdav.LockDetails {
+	return webdav.LockDetails{
+		Root:      r.Root,
+		Duration:  r.Duration,
+		OwnerXML:  r.OwnerXML,
+		ZeroDepth: r.ZeroDepth,
+	}
+}
+
+func (r *lockRecord) expired(now time.Time) bool {
+	return r.Duration >= 0 && r.Expiry > 0 && now.UnixNano() >= r.Expiry
+}
+
+// expiryTime returns the lock expiration, or a zero time for infinite locks.
+func (r *lockRecord) expiryTime() time.Time {
+	if r.Expiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, r.Expiry)
+}
+
+func (r *lockRecord) refresh(now time.Time, duration time.Duration) {
+	r.Duration = duration
+	if duration >= 0 {
+		r.Expiry = now.Add(duration).UnixNano()
+	} else {
+		r.Expiry = 0
+	}
+}
+
+// covers checks if this lock applies to the given resource name.
+func (r *lockRecord) covers(name string) bool {
+	if name == r.Root {
+		return true
+	}
+	if r.ZeroDepth {
+		return false
+	}
+	return r.Root == "/" || strings.HasPrefix(name, r.Root+"/")
+}
+
+// lockStore persists lock records in a place shared by all gateway instances.
+type lockStore interface {
+	// ListByPath lists the locks rooted at the resource or at one of its ancestors,
+	// and also the locks rooted under the resource if descendants is true.
+	ListByPath(ctx context.Context, root string, descendants bool) ([]*lockRecord, error)
+	Get(ctx context.Context, token string) (*lockRecord, error)
+	Put(ctx context.Context, record *lockRecord) error
+	Delete(ctx context.Context, token string) error
+}
+
+// contentLocker mirrors WebDAV locks to the content_lock ACLs used by the
+// web interface and by the views.AclContentLockFilter.
+type contentLocker interface {
+	// Resolve finds the node UUID for a resource name, and the login of the user
+	// currently holding a content lock on it (empty if there is none).
+	Resolve(ctx context.Context, name string) (nodeUuid string, lockOwner string, err error)
+	// Lock sets a content lock expiring with the DAV lock (never if expiry is zero).
+	Lock(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error
+	// Expire updates the expiration of the content lock set by Lock.
+	Expire(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error
+	Unlock(ctx context.Context, nodeUuid string) error
+}
+
+// sessionLockerFactory creates the cluster-wide lock used to serialize lock creation.
+type sessionLockerFactory func(nodeUUID, sessionUUID string, expireAfter time.Duration) permissions.SessionLocker
+
+const (
+	// clusterLockNode is the pseudo node carrying the cluster-wide lock
+	clusterLockNode = "dav-lock-system"
+	// clusterLockTTL bounds the time a crashed gateway can block lock creation
+	clusterLockTTL = 10 * time.Second
+)
+
+var (
+	clusterLockWait  = 5 * time.Second
+	clusterLockRetry = 50 * time.Millisecond
+)
+
+// LockSystem is a cluster-wide implementation of the webdav.LockSystem interface.
+// Locks are persisted in a shared lockStore, so that they survive restarts and are
+// visible to all gateways, and are mirrored as content locks on the target node.
+//
+// The webdav.Handler only creates real locks when serving LOCK requests. Locks it
+// creates for other requests without an "If" header are temporary and only live
+// for the duration of the request: they are kept in memory and never persisted.
+//
+// Creating a persisted lock checks for conflicts then stores the new lock: this
+// sequence runs under a cluster-wide lock, so that two gateways cannot both grant it.
+type LockSystem struct {
+	store         lockStore
+	locker        contentLocker
+	clusterLocker sessionLockerFactory
+
+	mu   sync.Mutex
+	temp map[string]*lockRecord
+	held map[string]struct{}
+}
+
+// NewLockSystem creates a LockSystem backed by the docstore and the ACL service.
+func NewLockSystem(fs *FileSystem) *LockSystem {
+	return newLockSystem(&docStoreLockStore{}, &aclContentLocker{fs: fs}, func(nodeUUID, sessionUUID string, expireAfter time.Duration) permissions.SessionLocker {
+		return permissions.NewLockSession(nodeUUID, sessionUUID, expireAfter)
+	})
+}
+
+func newLockSystem(store lockStore, locker contentLocker, clusterLocker sessionLockerFactory) *LockSystem {
+	return &LockSystem{
+		store:         store,
+		locker:        locker,
+		clusterLocker: clusterLocker,
+		temp:          make(map[string]*lockRecord),
+		held:          make(map[string]struct{}),
+	}
+}
+
+// WithRequest binds the LockSystem to a request, whose context is required to
+// resolve nodes and users, and whose method tells if created locks are temporary.
+func (l *LockSystem) WithRequest(r *http.Request) webdav.LockSystem {
+	return l.bind(r.Context(), r.Method != "LOCK")
+}
+
+func (l *LockSystem) bind(ctx context.Context, temporary bool) *contextLockSystem {
+	return &contextLockSystem{LockSystem: l, ctx: ctx, temporary: temporary}
+}
+
+type contextLockSystem struct {
+	*LockSystem
+	ctx       context.Context
+	temporary bool
+}
+
+// Confirm implements webdav.LockSystem interface.
+func (c *contextLockSystem) Confirm(now time.Time, name0, name1 string, conditions ...webdav.Condition) (func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var r0, r1 *lockRecord
+	if name0 != "" {
+		if r0 = c.lookup(now, lockName(name0), conditions...); r0 == nil {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+	if name1 != "" {
+		if r1 = c.lookup(now, lockName(name1), conditions...); r1 == nil {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+	if r1 != nil && r0 != nil && r1.Token == r0.Token {
+		r1 = nil
+	}
+	for _, r := range []*lockRecord{r0, r1} {
+		if r != nil {
+			c.held[r.Token] = struct{}{}
+		}
+	}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, r := range []*lockRecord{r0, r1} {
+			if r != nil {
+				delete(c.held, r.Token)
+			}
+		}
+	}, nil
+}
+
+// lookup returns the record that locks the named resource, provided that it
+// matches one of the conditions and is not currently held.
+func (c *contextLockSystem) lookup(now time.Time, name string, conditions ...webdav.Condition) *lockRecord {
+	for _, cond := range conditions {
+		if cond.Token == "" {
+			continue
+		}
+		if _, h := c.held[cond.Token]; h {
+			continue
+		}
+		r, ok := c.temp[cond.Token]
+		if !ok {
+			stored, err := c.store.Get(c.ctx, cond.Token)
+			if err != nil || stored == nil {
+				continue
+			}
+			if stored.expired(now) {
+				c.release(stored)
+				continue
+			}
+			r = stored
+		}
+		if r.covers(name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Create implements webdav.LockSystem interface.
+func (c *contextLockSystem) Create(now time.Time, details webdav.LockDetails) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	details.Root = lockName(details.Root)
+	if !c.temporary {
+		unlock, err := c.lockCluster()
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+	}
+	records, err := c.query(now, details.Root, !details.ZeroDepth)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range records {
+		if conflicts(r, details.Root, details.ZeroDepth) {
+			return "", webdav.ErrLocked
+		}
+	}
+
+	userName, _ := permissions.FindUserNameInContext(c.ctx)
+	record := &lockRecord{
+		Token:     "opaquelocktoken:" + uuid.New(),
+		Root:      details.Root,
+		ZeroDepth: details.ZeroDepth,
+		OwnerXML:  details.OwnerXML,
+		Owner:     userName,
+	}
+	record.refresh(now, details.Duration)
+
+	nodeUuid, lockOwner, er := c.locker.Resolve(c.ctx, details.Root)
+	if er == nil && lockOwner != "" && lockOwner != userName {
+		// Locked by another user from the web interface
+		return "", webdav.ErrLocked
+	}
+
+	if c.temporary {
+		c.temp[record.Token] = record
+		return record.Token, nil
+	}
+
+	if er == nil && nodeUuid != "" {
+		record.NodeUuid = nodeUuid
+		c.mirror(record, lockOwner)
+	}
+	if e := c.store.Put(c.ctx, record); e != nil {
+		c.unmirror(record)
+		return "", e
+	}
+	return record.Token, nil
+}
+
+// Refresh implements webdav.LockSystem interface.
+func (c *contextLockSystem) Refresh(now time.Time, token string, duration time.Duration) (webdav.LockDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, h := c.held[token]; h {
+		return webdav.LockDetails{}, webdav.ErrLocked
+	}
+	if r, ok := c.temp[token]; ok {
+		r.refresh(now, duration)
+		return r.details(), nil
+	}
+	record, err := c.store.Get(c.ctx, token)
+	if err != nil || record == nil {
+		return webdav.LockDetails{}, webdav.ErrNoSuchLock
+	}
+	if record.expired(now) {
+		c.release(record)
+		return webdav.LockDetails{}, webdav.ErrNoSuchLock
+	}
+	record.refresh(now, duration)
+	// Node may have been created after the lock (lock-null resource), or the
+	// content lock may have been removed from the web interface.
+	if nodeUuid, lockOwner, er := c.locker.Resolve(c.ctx, record.Root); er == nil && nodeUuid != "" {
+		if lockOwner != "" && lockOwner != record.Owner {
+			return webdav.LockDetails{}, webdav.ErrLocked
+		}
+		record.NodeUuid = nodeUuid
+		c.mirror(record, lockOwner)
+	}
+	if e := c.store.Put(c.ctx, record); e != nil {
+		return webdav.LockDetails{}, e
+	}
+	return record.details(), nil
+}
+
+// Unlock implements webdav.LockSystem interface.
+func (c *contextLockSystem) Unlock(now time.Time, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, h := c.held[token]; h {
+		return webdav.ErrLocked
+	}
+	if _, ok := c.temp[token]; ok {
+		delete(c.temp, token)
+		return nil
+	}
+	record, err := c.store.Get(c.ctx, token)
+	if err != nil || record == nil {
+		return webdav.ErrNoSuchLock
+	}
+	if record.expired(now) {
+		c.release(record)
+		return webdav.ErrNoSuchLock
+	}
+	return c.release(record)
+}
+
+// lockCluster acquires the cluster-wide lock, waiting for other gateways to release it.
+func (c *contextLockSystem) lockCluster() (func(), error) {
+	session := c.clusterLocker(clusterLockNode, uuid.New(), clusterLockTTL)
+	deadline := time.Now().Add(clusterLockWait)
+	for {
+		err := session.Lock(c.ctx)
+		if err == nil {
+			break
+		}
+		// Lock may have been created without its expiration
+		session.Unlock(c.ctx)
+		if time.Now().After(deadline) {
+			log.Logger(c.ctx).Error("Cannot acquire cluster lock for DAV locks", zap.Error(err))
+			return nil, webdav.ErrLocked
+		}
+		time.Sleep(clusterLockRetry)
+	}
+	return func() {
+		if e := session.Unlock(c.ctx); e != nil {
+			log.Logger(c.ctx).Error("Cannot release cluster lock for DAV locks", zap.Error(e))
+		}
+	}, nil
+}
+
+// query lists the valid locks that may conflict with a lock on root, garbage-collecting
+// expired ones on the way. It must be called with c.mu held.
+func (c *contextLockSystem) query(now time.Time, root string, descendants bool) ([]*lockRecord, error) {
+	stored, err := c.store.ListByPath(c.ctx, root, descendants)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*lockRecord, 0, len(stored)+len(c.temp))
+	for _, r := range stored {
+		if r.expired(now) {
+			c.release(r)
+			continue
+		}
+		records = append(records, r)
+	}
+	for _, r := range c.temp {
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// release removes the record from the store and clears the content lock if required.
+func (c *contextLockSystem) release(record *lockRecord) error {
+	if e := c.store.Delete(c.ctx, record.Token); e != nil {
+		return e
+	}
+	c.unmirror(record)
+	return nil
+}
+
+func (c *contextLockSystem) mirror(record *lockRecord, currentOwner string) {
+	if record.AclLocked {
+		if e := c.locker.Expire(c.ctx, record.NodeUuid, record.Owner, record.expiryTime()); e != nil {
+			log.Logger(c.ctx).Error("Cannot refresh content lock for DAV lock", zap.String("root", record.Root), zap.Error(e))
+		}
+		return
+	}
+	if currentOwner != "" || record.Owner == "" {
+		return
+	}
+	if e := c.locker.Lock(c.ctx, record.NodeUuid, record.Owner, record.expiryTime()); e != nil {
+		log.Logger(c.ctx).Error("Cannot set content lock for DAV lock", zap.String("root", record.Root), zap.Error(e))
+		return
+	}
+	record.AclLocked = true
+}
+
+func (c *contextLockSystem) unmirror(record *lockRecord) {
+	if !record.AclLocked || record.NodeUuid == "" {
+		return
+	}
+	if e := c.locker.Unlock(c.ctx, record.NodeUuid); e != nil {
+		log.Logger(c.ctx).Error("Cannot remove content lock for DAV lock", zap.String("root", record.Root), zap.Error(e))
+	}
+}
+
+// conflicts checks if an existing lock prevents creating a new lock on root.
+func conflicts(existing *lockRecord, root string, zeroDepth bool) bool {
+	if existing.Root == root {
+		return true
+	}
+	// Existing lock is an ancestor with infinite depth
+	if existing.covers(root) {
+		return true
+	}
+	// New lock has infinite depth and existing lock is a descendant
+	if !zeroDepth && (root == "/" || strings.HasPrefix(existing.Root, root+"/")) {
+		return true
+	}
+	return false
+}
+
+// ancestors lists the parent folders of a normalized resource name, up to the root.
+func ancestors(name string) (parents []string) {
+	for name != "/" {
+		name = path.Dir(name)
+		parents = append(parents, name)
+	}
+	return
+}
+
+// lockName normalizes a resource name the same way webdav memLS does.
+func lockName(name string) string {
+	if name == "" || name[0] != '/' {
+		name = "/" + name
+	}
+	return path.Clean(name)
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package dav
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/webdav"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/utils/permissions"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type memLockStore struct {
+	sync.Mutex
+	records map[string]lockRecord
+}
+
+func (m *memLockStore) ListByPath(ctx context.Context, root string, descendants bool) (rr []*lockRecord, e error) {
+	m.Lock()
+	defer m.Unlock()
+	for _, r := range m.records {
+		c := r
+		if c.Root == root || strings.HasPrefix(root, c.Root+"/") || c.Root == "/" || (descendants && strings.HasPrefix(c.Root, root+"/")) {
+			rr = append(rr, &c)
+		}
+	}
+	return
+}
+
+func (m *memLockStore) Get(ctx context.Context, token string) (*lockRecord, error) {
+	m.Lock()
+	defer m.Unlock()
+	if r, ok := m.records[token]; ok {
+		return &r, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (m *memLockStore) Put(ctx context.Context, record *lockRecord) error {
+	m.Lock()
+	defer m.Unlock()
+	m.records[record.Token] = *record
+	return nil
+}
+
+func (m *memLockStore) Delete(ctx context.Context, token string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.records, token)
+	return nil
+}
+
+type memLocker struct {
+	mu      sync.Mutex
+	uuids   map[string]string
+	acls    map[string]string
+	expires map[string]time.Time
+}
+
+func (m *memLocker) Resolve(ctx context.Context, name string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if u, ok := m.uuids[name]; ok {
+		return u, m.acls[u], nil
+	}
+	return "", "", fmt.Errorf("not found")
+}
+
+func (m *memLocker) Lock(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.acls[nodeUuid] = owner
+	if m.expires != nil {
+		m.expires[nodeUuid] = expiry
+	}
+	return nil
+}
+
+func (m *memLocker) Expire(ctx context.Context, nodeUuid string, owner string, expiry time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.expires != nil && m.acls[nodeUuid] == owner {
+		m.expires[nodeUuid] = expiry
+	}
+	return nil
+}
+
+func (m *memLocker) Unlock(ctx context.Context, nodeUuid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.acls, nodeUuid)
+	return nil
+}
+
+// memClusterLock mimics ACL based lock sessions: a lock on a node fails while another session holds it.
+type memClusterLock struct {
+	sync.Mutex
+	holders map[string]string
+}
+
+func (m *memClusterLock) factory(nodeUUID, sessionUUID string, expireAfter time.Duration) permissions.SessionLocker {
+	return &memSession{m: m, node: nodeUUID, session: sessionUUID}
+}
+
+type memSession struct {
+	m             *memClusterLock
+	node, session string
+}
+
+func (s *memSession) Lock(ctx context.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if h, ok := s.m.holders[s.node]; ok && h != s.session {
+		return fmt.Errorf("duplicate entry")
+	}
+	s.m.holders[s.node] = s.session
+	return nil
+}
+
+func (s *memSession) UpdateExpiration(ctx context.Context, expireAfter time.Duration) error {
+	return nil
+}
+
+func (s *memSession) Unlock(ctx context.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.m.holders[s.node] == s.session {
+		delete(s.m.holders, s.node)
+	}
+	return nil
+}
+
+func (s *memSession) AddChildTarget(parentUUID, targetChildName string) {}
+
+func newMemClusterLock() *memClusterLock {
+	return &memClusterLock{holders: map[string]string{}}
+}
+
+func userCtx(login string) context.Context {
+	return context.WithValue(context.Background(), common.PydioContextUserKey, login)
+}
+
+func TestLockSystem(t *testing.T) {
+
+	Convey("Locks are shared between instances", t, func() {
+		store := &memLockStore{records: map[string]lockRecord{}}
+		locker := &memLocker{uuids: map[string]string{"/ws/file.docx": "file-uuid"}, acls: map[string]string{}}
+		cluster := newMemClusterLock()
+		ls1 := newLockSystem(store, locker, cluster.factory).bind(userCtx("alice"), false)
+		gw2 := newLockSystem(store, locker, cluster.factory)
+		ls2 := gw2.bind(userCtx("bob"), false)
+		now := time.Now()
+
+		token, e := ls1.Create(now, webdav.LockDetails{Root: "/ws/file.docx", Duration: time.Minute, OwnerXML: "<owner>alice</owner>", ZeroDepth: true})
+		So(e, ShouldBeNil)
+		So(token, ShouldNotBeEmpty)
+		So(locker.acls["file-uuid"], ShouldEqual, "alice")
+
+		_, e = ls2.Create(now, webdav.LockDetails{Root: "/ws/file.docx", Duration: time.Minute, OwnerXML: "<owner>bob</owner>", ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+		// Temporary lock from a request without If header
+		_, e = gw2.bind(userCtx("bob"), true).Create(now, webdav.LockDetails{Root: "/ws/file.docx", Duration: -1, ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+
+		release, e := ls2.Confirm(now, "/ws/file.docx", "", webdav.Condition{Token: token})
+		So(e, ShouldBeNil)
+		// Lock is held by the current request
+		_, e = ls2.Confirm(now, "/ws/file.docx", "", webdav.Condition{Token: token})
+		So(e, ShouldEqual, webdav.ErrConfirmationFailed)
+		release()
+
+		_, e = ls1.Confirm(now, "/ws/file.docx", "", webdav.Condition{Token: "unknown"})
+		So(e, ShouldEqual, webdav.ErrConfirmationFailed)
+
+		So(ls2.Unlock(now, token), ShouldBeNil)
+		So(locker.acls, ShouldBeEmpty)
+		So(ls1.Unlock(now, token), ShouldEqual, webdav.ErrNoSuchLock)
+	})
+
+	Convey("Locks expire and can be refreshed", t, func() {
+		store := &memLockStore{records: map[string]lockRecord{}}
+		locker := &memLocker{uuids: map[string]string{"/ws/file.docx": "file-uuid"}, acls: map[string]string{}, expires: map[string]time.Time{}}
+		ls := newLockSystem(store, locker, newMemClusterLock().factory).bind(userCtx("alice"), false)
+		now := time.Now()
+
+		token, e := ls.Create(now, webdav.LockDetails{Root: "/ws/file.docx", Duration: time.Minute, OwnerXML: "<owner>alice</owner>", ZeroDepth: true})
+		So(e, ShouldBeNil)
+		// Content lock expires with the DAV lock
+		So(locker.expires["file-uuid"].UnixNano(), ShouldEqual, now.Add(time.Minute).UnixNano())
+
+		details, e := ls.Refresh(now.Add(50*time.Second), token, time.Minute)
+		So(e, ShouldBeNil)
+		So(details.Root, ShouldEqual, "/ws/file.docx")
+		So(locker.expires["file-uuid"].UnixNano(), ShouldEqual, now.Add(110*time.Second).UnixNano())
+
+		// Still valid after initial expiration thanks to the refresh
+		release, e := ls.Confirm(now.Add(90*time.Second), "/ws/file.docx", "", webdav.Condition{Token: token})
+		So(e, ShouldBeNil)
+		release()
+
+		_, e = ls.Refresh(now.Add(3*time.Minute), token, time.Minute)
+		So(e, ShouldEqual, webdav.ErrNoSuchLock)
+		So(store.records, ShouldBeEmpty)
+		So(locker.acls, ShouldBeEmpty)
+
+		_, e = ls.Create(now.Add(3*time.Minute), webdav.LockDetails{Root: "/ws/file.docx", Duration: time.Minute, ZeroDepth: true})
+		So(e, ShouldBeNil)
+	})
+
+	Convey("Depth and web interface locks are respected", t, func() {
+		store := &memLockStore{records: map[string]lockRecord{}}
+		locker := &memLocker{uuids: map[string]string{"/ws/folder": "folder-uuid", "/ws/other.txt": "other-uuid"}, acls: map[string]string{"other-uuid": "bob"}}
+		ls := newLockSystem(store, locker, newMemClusterLock().factory).bind(userCtx("alice"), false)
+		now := time.Now()
+
+		token, e := ls.Create(now, webdav.LockDetails{Root: "/ws/folder", Duration: time.Minute, OwnerXML: "<owner>alice</owner>"})
+		So(e, ShouldBeNil)
+		_, e = ls.Create(now, webdav.LockDetails{Root: "/ws/folder/child.txt", Duration: time.Minute, ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+		_, e = ls.Create(now, webdav.LockDetails{Root: "/ws", Duration: time.Minute})
+		So(e, ShouldEqual, webdav.ErrLocked)
+
+		release, e := ls.Confirm(now, "/ws/folder/child.txt", "", webdav.Condition{Token: token})
+		So(e, ShouldBeNil)
+		release()
+
+		_, e = ls.Create(now, webdav.LockDetails{Root: "/ws/other.txt", Duration: time.Minute, ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+		So(locker.acls["other-uuid"], ShouldEqual, "bob")
+	})
+
+	Convey("Lock creation is serialized between gateways", t, func() {
+		store := &memLockStore{records: map[string]lockRecord{}}
+		locker := &memLocker{uuids: map[string]string{}, acls: map[string]string{}}
+		cluster := newMemClusterLock()
+		gw := newLockSystem(store, locker, cluster.factory)
+		now := time.Now()
+
+		wait := clusterLockWait
+		clusterLockWait = 100 * time.Millisecond
+		defer func() {
+			clusterLockWait = wait
+		}()
+
+		// Another gateway is creating a lock
+		other := cluster.factory(clusterLockNode, "other-gateway", clusterLockTTL)
+		So(other.Lock(context.Background()), ShouldBeNil)
+		_, e := gw.bind(userCtx("alice"), false).Create(now, webdav.LockDetails{Root: "/ws/file.txt", Duration: time.Minute, ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+		So(store.records, ShouldBeEmpty)
+
+		// Temporary locks are not persisted and do not need the cluster lock
+		_, e = gw.bind(userCtx("alice"), true).Create(now, webdav.LockDetails{Root: "/ws/tmp.txt", Duration: -1, ZeroDepth: true})
+		So(e, ShouldBeNil)
+
+		So(other.Unlock(context.Background()), ShouldBeNil)
+		token, e := gw.bind(userCtx("alice"), false).Create(now, webdav.LockDetails{Root: "/ws/file.txt", Duration: time.Minute, ZeroDepth: true})
+		So(e, ShouldBeNil)
+		So(store.records, ShouldContainKey, token)
+		So(cluster.holders, ShouldBeEmpty)
+	})
+
+	Convey("Only locks created by LOCK requests are persisted", t, func() {
+		store := &memLockStore{records: map[string]lockRecord{}}
+		locker := &memLocker{uuids: map[string]string{"/ws/file.txt": "file-uuid"}, acls: map[string]string{}}
+		gw := newLockSystem(store, locker, newMemClusterLock().factory)
+		now := time.Now()
+
+		tmp := gw.bind(userCtx("alice"), true)
+		token, e := tmp.Create(now, webdav.LockDetails{Root: "/ws/tmp.txt", Duration: -1, ZeroDepth: true})
+		So(e, ShouldBeNil)
+		So(store.records, ShouldBeEmpty)
+		So(tmp.Unlock(now, token), ShouldBeNil)
+
+		// Infinite, depth-0 and ownerless, but explicitly requested by the client
+		ls := gw.bind(userCtx("alice"), false)
+		token, e = ls.Create(now, webdav.LockDetails{Root: "/ws/file.txt", Duration: -1, ZeroDepth: true})
+		So(e, ShouldBeNil)
+		So(store.records, ShouldContainKey, token)
+		So(locker.acls["file-uuid"], ShouldEqual, "alice")
+
+		_, e = gw.bind(userCtx("bob"), true).Create(now, webdav.LockDetails{Root: "/ws/file.txt", Duration: -1, ZeroDepth: true})
+		So(e, ShouldEqual, webdav.ErrLocked)
+	})
+
+}
@@ -42,6 +42,13 @@ import (
 	"github.com/pydio/cells/common/views"
 )
 
+const (
+	headerItemVersion = "X-WOPI-ItemVersion"
+	// Collabora sends the LastModifiedTime of the file opened in the editor session
+	headerCoolTimestamp = "X-COOL-WOPI-Timestamp"
+	headerLoolTimestamp = "X-LOOL-WOPI-Timestamp"
+)
+
 type File struct {
 	BaseFileName     string
 	OwnerId          string
@@ -52,6 +59,42 @@ type File struct {
 	UserCanWrite     bool
 	LastModifiedTime string
 	PydioPath        string
+
+	SupportsLocks           bool
+	SupportsGetLock         bool
+	SupportsUpdate          bool
+	SupportsRename          bool
+	UserCanRename           bool
+	UserCanNotWriteRelative bool
+}
+
+// override dispatches POST requests on a file based on the X-WOPI-Override header.
+func override(w http.ResponseWriter, r *http.Request) {
+	op := r.Header.Get(headerOverride)
+	log.Logger(r.Context()).Debug("WOPI BACKEND - Override", zap.String("operation", op))
+
+	n, err := findNodeFromRequest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch op {
+	case "LOCK":
+		lock(w, r, n)
+	case "GET_LOCK":
+		getLock(w, r, n)
+	case "REFRESH_LOCK":
+		refreshLock(w, r, n)
+	case "UNLOCK":
+		unlock(w, r, n)
+	case "PUT_RELATIVE":
+		putRelativeFile(w, r, n)
+	case "RENAME_FILE":
+		renameFile(w, r, n)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
 }
 
 func getNodeInfos(w http.ResponseWriter, r *http.Request) {
@@ -111,6 +154,10 @@ func uploadStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkPutLock(w, r, n) {
+		return
+	}
+
 	var size int64
 	if h, ok := r.Header["Content-Length"]; ok && len(h) > 0 {
 		size, _ = strconv.ParseInt(h[0], 10, 64)
@@ -130,18 +177,71 @@ func uploadStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Logger(r.Context()).Debug("uploaded node", n.Zap(), zap.Int64("Data Length", written))
+	if resp, e := viewsRouter.ReadNode(r.Context(), &tree.ReadNodeRequest{Node: &tree.Node{Uuid: n.Uuid}}); e == nil {
+		w.Header().Set(headerItemVersion, itemVersion(resp.Node))
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// checkPutLock verifies the X-WOPI-Lock header against the current lock. Unlocked files
+// can only be written if they are empty, as stated by the PutFile specification, or if
+// the client proves that it is editing the version currently stored.
+func checkPutLock(w http.ResponseWriter, r *http.Request, n *tree.Node) bool {
+	current, e := locks.GetLock(r.Context(), n.Uuid)
+	if e != nil {
+		log.Logger(r.Context()).Error("cannot load wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	if current == nil {
+		if n.GetSize() > 0 && !isCurrentVersion(r, n) {
+			lockConflict(w, nil, "File is not locked")
+			return false
+		}
+		return true
+	}
+	if current.ID != r.Header.Get(headerLock) {
+		lockConflict(w, current, "Lock mismatch")
+		return false
+	}
+	return true
+}
+
+// isCurrentVersion checks the item version or editor session timestamp sent by the client
+// against the stored node.
+func isCurrentVersion(r *http.Request, n *tree.Node) bool {
+	if v := r.Header.Get(headerItemVersion); v != "" && v == itemVersion(n) {
+		return true
+	}
+	for _, h := range []string{headerCoolTimestamp, headerLoolTimestamp} {
+		if v := r.Header.Get(h); v != "" && v == lastModifiedTime(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func itemVersion(n *tree.Node) string {
+	return fmt.Sprintf("%d", n.GetModTime().Unix())
+}
+
+func lastModifiedTime(n *tree.Node) string {
+	return n.GetModTime().Format(time.RFC3339)
+}
+
 func buildFileFromNode(ctx context.Context, n *tree.Node) *File {
 
 	f := File{
 		BaseFileName:     n.GetStringMeta("name"),
 		OwnerId:          "pydio", // TODO get an ownerID?
 		Size:             n.GetSize(),
-		Version:          fmt.Sprintf("%d", n.GetModTime().Unix()),
-		LastModifiedTime: n.GetModTime().Format(time.RFC3339),
+		Version:          itemVersion(n),
+		LastModifiedTime: lastModifiedTime(n),
 		PydioPath:        n.Path,
+		SupportsLocks:    true,
+		SupportsGetLock:  true,
+		SupportsUpdate:   true,
+		SupportsRename:   true,
 	}
 
 	// Find user info in claims, if any
@@ -157,6 +257,8 @@ func buildFileFromNode(ctx context.Context, n *tree.Node) *File {
 			} else {
 				f.UserCanWrite = true
 			}
+			f.UserCanRename = f.UserCanWrite
+			f.UserCanNotWriteRelative = !f.UserCanWrite
 		}
 	} else {
 		log.Logger(ctx).Debug("No Claims Found", zap.Any("ctx", ctx))
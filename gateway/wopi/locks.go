@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package wopi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+	serviceproto "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+const (
+	// lockDuration is the WOPI lock expiration time as defined by the protocol.
+	lockDuration = 30 * time.Minute
+	// maxLockLength is the longest lock ID allowed by the protocol.
+	maxLockLength = 1024
+
+	headerOverride = "X-WOPI-Override"
+	headerLock     = "X-WOPI-Lock"
+	headerOldLock  = "X-WOPI-OldLock"
+	headerLockFail = "X-WOPI-LockFailureReason"
+)
+
+// wopiLock is the document stored for a locked node.
+type wopiLock struct {
+	ID     string `json:"id"`
+	Owner  string `json:"owner,omitempty"`
+	Expiry int64  `json:"expiry"`
+}
+
+// lockStore reads and writes WOPI locks for a given node UUID.
+type lockStore interface {
+	// GetLock returns the current valid lock on the node, or nil.
+	GetLock(ctx context.Context, nodeUuid string) (*wopiLock, error)
+	// SetLock creates or replaces the lock on the node in a single write.
+	SetLock(ctx context.Context, nodeUuid string, lock *wopiLock) error
+	// ClearLock removes the lock on the node.
+	ClearLock(ctx context.Context, nodeUuid string) error
+	// ContentLockOwner returns the login of the user holding a content lock set from the web interface.
+	ContentLockOwner(ctx context.Context, nodeUuid string) (string, error)
+}
+
+var locks lockStore = &docStoreLockStore{}
+
+// docStoreLockStore stores WOPI locks as JSON documents in the DocStore service, keyed by node UUID, so that
+// lock IDs of any length can be kept and a lock is replaced by overwriting its document.
+type docStoreLockStore struct{}
+
+func (d *docStoreLockStore) client() docstore.DocStoreClient {
+	return docstore.NewDocStoreClient(common.ServiceGrpcNamespace_+common.ServiceDocStore, defaults.NewClient())
+}
+
+// GetLock implements lockStore interface. Expired locks are cleared on the fly.
+func (d *docStoreLockStore) GetLock(ctx context.Context, nodeUuid string) (*wopiLock, error) {
+	resp, e := d.client().GetDocument(ctx, &docstore.GetDocumentRequest{StoreID: common.DocStoreIdWopiLocks, DocumentID: nodeUuid})
+	if e != nil {
+		if strings.Contains(e.Error(), "document not found") {
+			return nil, nil
+		}
+		return nil, e
+	}
+	if resp.Document == nil || resp.Document.Data == "" {
+		return nil, nil
+	}
+	var l wopiLock
+	if e := json.Unmarshal([]byte(resp.Document.Data), &l); e != nil {
+		return nil, e
+	}
+	if time.Now().Unix() >= l.Expiry {
+		return nil, d.ClearLock(ctx, nodeUuid)
+	}
+	return &l, nil
+}
+
+// SetLock implements lockStore interface.
+func (d *docStoreLockStore) SetLock(ctx context.Context, nodeUuid string, lock *wopiLock) error {
+	data, e := json.Marshal(lock)
+	if e != nil {
+		return e
+	}
+	_, e = d.client().PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    common.DocStoreIdWopiLocks,
+		DocumentID: nodeUuid,
+		Document: &docstore.Document{
+			ID:    nodeUuid,
+			Owner: lock.Owner,
+			Type:  docstore.DocumentType_JSON,
+			Data:  string(data),
+		},
+	})
+	return e
+}
+
+// ClearLock implements lockStore interface.
+func (d *docStoreLockStore) ClearLock(ctx context.Context, nodeUuid string) error {
+	_, e := d.client().DeleteDocuments(ctx, &docstore.DeleteDocumentsRequest{StoreID: common.DocStoreIdWopiLocks, DocumentID: nodeUuid})
+	return e
+}
+
+// ContentLockOwner implements lockStore interface, reading the "content_lock" ACL of the node.
+func (d *docStoreLockStore) ContentLockOwner(ctx context.Context, nodeUuid string) (string, error) {
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{
+		NodeIDs: []string{nodeUuid},
+		Actions: []*idm.ACLAction{{Name: permissions.AclContentLock.Name}},
+	})
+	aclClient := idm.NewACLServiceClient(common.ServiceGrpcNamespace_+common.ServiceAcl, defaults.NewClient())
+	stream, e := aclClient.SearchACL(ctx, &idm.SearchACLRequest{Query: &serviceproto.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return "", e
+	}
+	defer stream.Close()
+	for {
+		rsp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if rsp == nil || rsp.ACL == nil {
+			continue
+		}
+		return rsp.ACL.Action.Value, nil
+	}
+	return "", nil
+}
+
+// canWrite checks the read-only flag set on nodes by the views ACL filter.
+func canWrite(n *tree.Node) bool {
+	return n.GetStringMeta(common.MetaFlagReadonly) != "true"
+}
+
+// lockConflict sends a 409 with the current lock ID, as required by the protocol.
+func lockConflict(w http.ResponseWriter, current *wopiLock, reason string) {
+	var id string
+	if current != nil {
+		id = current.ID
+	}
+	w.Header().Set(headerLock, id)
+	if reason != "" {
+		w.Header().Set(headerLockFail, reason)
+	}
+	w.WriteHeader(http.StatusConflict)
+}
+
+// lockOrConflict loads the node lock and verifies it matches the requested one.
+// It writes the appropriate response and returns false if the request cannot proceed.
+func lockOrConflict(w http.ResponseWriter, r *http.Request, n *tree.Node, requested string) (*wopiLock, bool) {
+	current, e := locks.GetLock(r.Context(), n.Uuid)
+	if e != nil {
+		log.Logger(r.Context()).Error("cannot load wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+	if current == nil || current.ID != requested {
+		lockConflict(w, current, "Lock mismatch")
+		return current, false
+	}
+	return current, true
+}
+
+func newLock(ctx context.Context, id string) *wopiLock {
+	owner, _ := permissions.FindUserNameInContext(ctx)
+	return &wopiLock{ID: id, Owner: owner, Expiry: time.Now().Add(lockDuration).Unix()}
+}
+
+// lock implements the Lock operation, and UnlockAndRelock if X-WOPI-OldLock is set.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/Lock.html
+func lock(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	ctx := r.Context()
+	requested := r.Header.Get(headerLock)
+	if requested == "" || len(requested) > maxLockLength {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !canWrite(n) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	current, e := locks.GetLock(ctx, n.Uuid)
+	if e != nil {
+		log.Logger(ctx).Error("cannot load wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if oldLock := r.Header.Get(headerOldLock); oldLock != "" {
+		if current == nil || current.ID != oldLock {
+			lockConflict(w, current, "Old lock mismatch")
+			return
+		}
+	} else if current != nil && current.ID != requested {
+		lockConflict(w, current, "File already locked")
+		return
+	}
+	if current == nil {
+		// Respect locks set from the web interface
+		userName, _ := permissions.FindUserNameInContext(ctx)
+		if owner, er := locks.ContentLockOwner(ctx, n.Uuid); er == nil && owner != "" && owner != userName {
+			lockConflict(w, nil, "File locked by another user")
+			return
+		}
+	}
+	if e := locks.SetLock(ctx, n.Uuid, newLock(ctx, requested)); e != nil {
+		log.Logger(ctx).Error("cannot store wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(headerItemVersion, itemVersion(n))
+	w.WriteHeader(http.StatusOK)
+}
+
+// getLock implements the GetLock operation.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/GetLock.html
+func getLock(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	current, e := locks.GetLock(r.Context(), n.Uuid)
+	if e != nil {
+		log.Logger(r.Context()).Error("cannot load wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var id string
+	if current != nil {
+		id = current.ID
+	}
+	w.Header().Set(headerLock, id)
+	w.WriteHeader(http.StatusOK)
+}
+
+// refreshLock implements the RefreshLock operation.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/RefreshLock.html
+func refreshLock(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	if !canWrite(n) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	requested := r.Header.Get(headerLock)
+	current, ok := lockOrConflict(w, r, n, requested)
+	if !ok {
+		return
+	}
+	current.Expiry = time.Now().Add(lockDuration).Unix()
+	if e := locks.SetLock(r.Context(), n.Uuid, current); e != nil {
+		log.Logger(r.Context()).Error("cannot refresh wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// unlock implements the Unlock operation.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/Unlock.html
+func unlock(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	requested := r.Header.Get(headerLock)
+	if _, ok := lockOrConflict(w, r, n, requested); !ok {
+		return
+	}
+	if e := locks.ClearLock(r.Context(), n.Uuid); e != nil {
+		log.Logger(r.Context()).Error("cannot clear wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(headerItemVersion, itemVersion(n))
+	w.WriteHeader(http.StatusOK)
+}
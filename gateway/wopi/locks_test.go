@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package wopi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type memLocks struct {
+	sync.Mutex
+	locks   map[string]*wopiLock
+	content map[string]string
+}
+
+func (m *memLocks) GetLock(ctx context.Context, nodeUuid string) (*wopiLock, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.locks[nodeUuid], nil
+}
+
+func (m *memLocks) SetLock(ctx context.Context, nodeUuid string, lock *wopiLock) error {
+	m.Lock()
+	defer m.Unlock()
+	m.locks[nodeUuid] = lock
+	return nil
+}
+
+func (m *memLocks) ClearLock(ctx context.Context, nodeUuid string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.locks, nodeUuid)
+	return nil
+}
+
+func (m *memLocks) ContentLockOwner(ctx context.Context, nodeUuid string) (string, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.content[nodeUuid], nil
+}
+
+// uuidMock resolves nodes by UUID and stores uploaded contents.
+type uuidMock struct {
+	*views.HandlerMock
+	mu       sync.Mutex
+	contents map[string]string
+}
+
+func (u *uuidMock) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	for _, n := range u.Nodes {
+		if n.Uuid != "" && n.Uuid == in.Node.Uuid {
+			return &tree.ReadNodeResponse{Node: n}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (u *uuidMock) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *views.PutRequestData) (int64, error) {
+	data, e := ioutil.ReadAll(reader)
+	if e != nil {
+		return 0, e
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.contents[node.Uuid] = string(data)
+	return int64(len(data)), nil
+}
+
+// fakeClient plays the role of a WOPI client (Collabora, OnlyOffice) talking to the host.
+type fakeClient struct {
+	server *httptest.Server
+}
+
+func (c *fakeClient) do(method, uri string, headers map[string]string, body string) *http.Response {
+	req, _ := http.NewRequest(method, c.server.URL+uri, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, e := http.DefaultClient.Do(req)
+	So(e, ShouldBeNil)
+	resp.Body.Close()
+	return resp
+}
+
+func (c *fakeClient) override(op, lockId string, extra ...string) *http.Response {
+	h := map[string]string{headerOverride: op, headerLock: lockId}
+	for i := 0; i+1 < len(extra); i += 2 {
+		h[extra[i]] = extra[i+1]
+	}
+	return c.do("POST", "/wopi/files/file-uuid", h, "")
+}
+
+func (c *fakeClient) putFile(lockId, content string) *http.Response {
+	return c.do("POST", "/wopi/files/file-uuid/contents", map[string]string{headerOverride: "PUT", headerLock: lockId}, content)
+}
+
+func TestLocks(t *testing.T) {
+
+	Convey("Conflicting WOPI clients are detected", t, func() {
+
+		mock := &uuidMock{HandlerMock: views.NewHandlerMock(), contents: map[string]string{}}
+		mock.Nodes["file.docx"] = &tree.Node{Uuid: "file-uuid", Path: "ds/file.docx", Size: 12, MTime: 1600000000, Type: tree.NodeType_LEAF}
+		viewsRouter = views.NewRouter(nil, []views.Handler{mock})
+		store := &memLocks{locks: map[string]*wopiLock{}, content: map[string]string{}}
+		locks = store
+
+		withClaims := func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), claim.ContextKey, claim.Claims{Name: "alice"})
+				h.ServeHTTP(w, r.WithContext(ctx))
+			})
+		}
+		server := httptest.NewServer(newRouter(withClaims))
+		defer server.Close()
+		collabora := &fakeClient{server: server}
+		onlyoffice := &fakeClient{server: server}
+
+		// Unlocked non-empty file cannot be written
+		resp := collabora.putFile("", "content")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		So(resp.Header.Get(headerLock), ShouldEqual, "")
+
+		resp = collabora.override("LOCK", "lock-A")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+		// Second client cannot steal the lock
+		resp = onlyoffice.override("LOCK", "lock-B")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		So(resp.Header.Get(headerLock), ShouldEqual, "lock-A")
+
+		resp = onlyoffice.putFile("lock-B", "overwrite")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		So(resp.Header.Get(headerLock), ShouldEqual, "lock-A")
+		So(mock.contents, ShouldBeEmpty)
+
+		resp = collabora.putFile("lock-A", "new content")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(mock.contents["file-uuid"], ShouldEqual, "new content")
+
+		resp = onlyoffice.override("GET_LOCK", "")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(resp.Header.Get(headerLock), ShouldEqual, "lock-A")
+
+		resp = onlyoffice.override("REFRESH_LOCK", "lock-B")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		resp = collabora.override("REFRESH_LOCK", "lock-A")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+		// UnlockAndRelock
+		resp = onlyoffice.override("LOCK", "lock-B", headerOldLock, "lock-X")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		So(resp.Header.Get(headerLock), ShouldEqual, "lock-A")
+		resp = collabora.override("LOCK", "lock-C", headerOldLock, "lock-A")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(store.locks["file-uuid"].ID, ShouldEqual, "lock-C")
+
+		resp = onlyoffice.override("UNLOCK", "lock-A")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		So(resp.Header.Get(headerLock), ShouldEqual, "lock-C")
+		resp = collabora.override("UNLOCK", "lock-C")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(store.locks, ShouldBeEmpty)
+
+		// Unlocked file can be written by a client editing the current version
+		resp = collabora.do("POST", "/wopi/files/file-uuid/contents", map[string]string{headerOverride: "PUT", headerItemVersion: "1500000000"}, "stale")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+		resp = collabora.do("POST", "/wopi/files/file-uuid/contents", map[string]string{headerOverride: "PUT", headerItemVersion: "1600000000"}, "versioned")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(mock.contents["file-uuid"], ShouldEqual, "versioned")
+		resp = collabora.do("POST", "/wopi/files/file-uuid/contents", map[string]string{headerOverride: "PUT", headerCoolTimestamp: lastModifiedTime(mock.Nodes["file.docx"])}, "session")
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(mock.contents["file-uuid"], ShouldEqual, "session")
+
+		// Content lock set by another user from the web interface
+		store.content["file-uuid"] = "bob"
+		resp = collabora.override("LOCK", "lock-D")
+		So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+
+		resp = collabora.override("UNKNOWN_OP", "")
+		So(resp.StatusCode, ShouldEqual, http.StatusNotImplemented)
+
+		// Lock IDs up to 1024 characters are accepted
+		delete(store.content, "file-uuid")
+		longId := strings.Repeat("x", maxLockLength)
+		resp = collabora.override("LOCK", longId)
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		So(store.locks["file-uuid"].ID, ShouldEqual, longId)
+		resp = collabora.override("UNLOCK", longId)
+		So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		resp = collabora.override("LOCK", longId+"x")
+		So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+
+		// Read-only files cannot be locked
+		mock.Nodes["file.docx"].SetMeta(common.MetaFlagReadonly, "true")
+		resp = collabora.override("LOCK", "lock-E")
+		So(resp.StatusCode, ShouldEqual, http.StatusForbidden)
+		resp = collabora.override("REFRESH_LOCK", "lock-E")
+		So(resp.StatusCode, ShouldEqual, http.StatusForbidden)
+		So(store.locks, ShouldBeEmpty)
+	})
+
+}
+
+func TestUTF7(t *testing.T) {
+
+	Convey("UTF-7 file names", t, func() {
+		So(decodeUTF7("Report.docx"), ShouldEqual, "Report.docx")
+		So(decodeUTF7("A+ImIDkQ."), ShouldEqual, "A≢Α.")
+		So(decodeUTF7("1 +- 1"), ShouldEqual, "1 + 1")
+		So(decodeUTF7("R+AOk-sum+AOk-.docx"), ShouldEqual, "Résumé.docx")
+		for _, s := range []string{"Résumé.docx", "日本語.xlsx", "a+b.odt", "plain.txt"} {
+			So(decodeUTF7(encodeUTF7(s)), ShouldEqual, s)
+		}
+	})
+
+}
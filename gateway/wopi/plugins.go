@@ -33,6 +33,7 @@ import (
 
 var (
 	viewsRouter *views.Router
+	pathRouter  *views.Router
 )
 
 func init() {
@@ -45,6 +46,8 @@ func init() {
 			service.Description("WOPI REST Gateway to tree service"),
 			service.WithHTTP(func() http.Handler {
 				viewsRouter = views.NewUuidRouter(views.RouterOptions{WatchRegistry: true, AuditEvent: true})
+				// Path-based router is required to create or rename files (PutRelativeFile, RenameFile)
+				pathRouter = views.NewStandardRouter(views.RouterOptions{WatchRegistry: true, AuditEvent: true})
 
 				return NewRouter()
 			}),
@@ -0,0 +1,289 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package wopi
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+const (
+	headerSuggestedTarget   = "X-WOPI-SuggestedTarget"
+	headerRelativeTarget    = "X-WOPI-RelativeTarget"
+	headerOverwriteRelative = "X-WOPI-OverwriteRelativeTarget"
+	headerValidRelative     = "X-WOPI-ValidRelativeTarget"
+	headerRequestedName     = "X-WOPI-RequestedName"
+	headerInvalidName       = "X-WOPI-InvalidFileNameError"
+	headerSize              = "X-WOPI-Size"
+)
+
+// RelativeFile is the response body of a PutRelativeFile operation.
+type RelativeFile struct {
+	Name string
+	Url  string
+}
+
+// putRelativeFile implements the PutRelativeFile operation, creating a new file next to the current one.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/PutRelativeFile.html
+func putRelativeFile(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	ctx := r.Context()
+	suggested := r.Header.Get(headerSuggestedTarget)
+	relative := r.Header.Get(headerRelativeTarget)
+	if (suggested == "") == (relative == "") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	wsPath, ok := workspacePath(n)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	dir := path.Dir(wsPath)
+
+	var name string
+	if relative != "" {
+		name = decodeUTF7(relative)
+		if !validName(name) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if existing, e := pathRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: path.Join(dir, name)}}); e == nil {
+			if !strings.EqualFold(r.Header.Get(headerOverwriteRelative), "true") {
+				w.Header().Set(headerValidRelative, encodeUTF7(uniqueName(ctx, dir, name)))
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			if current, _ := locks.GetLock(ctx, existing.Node.Uuid); current != nil {
+				lockConflict(w, current, "Target file is locked")
+				return
+			}
+		}
+	} else {
+		name = decodeUTF7(suggested)
+		if strings.HasPrefix(name, ".") {
+			base := path.Base(wsPath)
+			name = strings.TrimSuffix(base, path.Ext(base)) + name
+		}
+		if !validName(name) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		name = uniqueName(ctx, dir, name)
+	}
+
+	var size int64
+	if s := r.Header.Get(headerSize); s != "" {
+		size, _ = strconv.ParseInt(s, 10, 64)
+	} else if r.ContentLength > 0 {
+		size = r.ContentLength
+	}
+	target := path.Join(dir, name)
+	if written, e := pathRouter.PutObject(ctx, &tree.Node{Path: target}, r.Body, &views.PutRequestData{Size: size}); e != nil {
+		log.Logger(ctx).Error("cannot put relative object", zap.String("target", target), zap.Int64("written", written), zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, e := pathRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: target}})
+	if e != nil {
+		log.Logger(ctx).Error("cannot read relative object after upload", zap.String("target", target), zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	data, _ := json.Marshal(&RelativeFile{Name: name, Url: wopiSrc(r, resp.Node.Uuid)})
+	w.Write(data)
+}
+
+// renameFile implements the RenameFile operation. Requested name does not contain the extension.
+// See https://wopi.readthedocs.io/projects/wopirest/en/latest/files/RenameFile.html
+func renameFile(w http.ResponseWriter, r *http.Request, n *tree.Node) {
+	ctx := r.Context()
+	current, e := locks.GetLock(ctx, n.Uuid)
+	if e != nil {
+		log.Logger(ctx).Error("cannot load wopi lock", zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if current != nil && current.ID != r.Header.Get(headerLock) {
+		lockConflict(w, current, "Lock mismatch")
+		return
+	}
+	wsPath, ok := workspacePath(n)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	requested := decodeUTF7(r.Header.Get(headerRequestedName))
+	newName := requested + path.Ext(wsPath)
+	if requested == "" || !validName(newName) {
+		w.Header().Set(headerInvalidName, "Invalid file name")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	dir := path.Dir(wsPath)
+	if _, e := pathRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: path.Join(dir, newName)}}); e == nil {
+		w.Header().Set(headerInvalidName, "A file with this name already exists")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	from, e := pathRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: wsPath}})
+	if e != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if _, e := pathRouter.UpdateNode(ctx, &tree.UpdateNodeRequest{From: from.Node, To: &tree.Node{Path: path.Join(dir, newName)}}); e != nil {
+		log.Logger(ctx).Error("cannot rename node", n.Zap(), zap.Error(e))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	data, _ := json.Marshal(map[string]string{"Name": requested})
+	w.Write(data)
+}
+
+// workspacePath computes the node path as seen through the first workspace it appears in.
+func workspacePath(n *tree.Node) (string, bool) {
+	if len(n.AppearsIn) == 0 {
+		return "", false
+	}
+	ws := n.AppearsIn[0]
+	return path.Join(ws.WsSlug, ws.Path), true
+}
+
+// uniqueName appends a numeric suffix to the name until it does not exist in dir.
+func uniqueName(ctx context.Context, dir, name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 1; ; i++ {
+		if _, e := pathRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: path.Join(dir, candidate)}}); e != nil {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+func validName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\")
+}
+
+// wopiSrc builds the WOPI URL of a file, reusing the current access token.
+func wopiSrc(r *http.Request, uuid string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	u := &url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     "/wopi/files/" + uuid,
+		RawQuery: url.Values{"access_token": []string{r.URL.Query().Get("access_token")}}.Encode(),
+	}
+	return u.String()
+}
+
+// decodeUTF7 decodes file names sent by WOPI clients, which are UTF-7 encoded (RFC 2152).
+func decodeUTF7(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '+' {
+			out.WriteByte(s[i])
+			continue
+		}
+		j := i + 1
+		for j < len(s) && isBase64Char(s[j]) {
+			j++
+		}
+		if j == i+1 {
+			// "+-" encodes a plus sign
+			out.WriteByte('+')
+		} else {
+			raw, e := base64.RawStdEncoding.DecodeString(s[i+1 : j])
+			if e != nil {
+				out.WriteString(s[i:j])
+			} else {
+				units := make([]uint16, len(raw)/2)
+				for k := range units {
+					units[k] = uint16(raw[2*k])<<8 | uint16(raw[2*k+1])
+				}
+				out.WriteString(string(utf16.Decode(units)))
+			}
+		}
+		if j < len(s) && s[j] == '-' {
+			i = j
+		} else {
+			i = j - 1
+		}
+	}
+	return out.String()
+}
+
+// encodeUTF7 is the reverse of decodeUTF7.
+func encodeUTF7(s string) string {
+	var out strings.Builder
+	var pending []rune
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		units := utf16.Encode(pending)
+		raw := make([]byte, 0, len(units)*2)
+		for _, u := range units {
+			raw = append(raw, byte(u>>8), byte(u))
+		}
+		out.WriteString("+" + base64.RawStdEncoding.EncodeToString(raw) + "-")
+		pending = nil
+	}
+	for _, c := range s {
+		switch {
+		case c == '+':
+			flush()
+			out.WriteString("+-")
+		case c < 0x80 && c >= 0x20 && c != '~' && c != '\\':
+			flush()
+			out.WriteRune(c)
+		default:
+			pending = append(pending, c)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+func isBase64Char(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '+' || c == '/'
+}
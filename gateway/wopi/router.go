@@ -38,12 +38,16 @@ type routes []route
 
 // NewRouter creates and configures a new mux router to serve wopi REST requests and enable integration with WOPI clients.
 func NewRouter() *mux.Router {
+	return newRouter(auth)
+}
+
+func newRouter(authWrapper func(http.Handler) http.Handler) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	for _, route := range myRoutes {
 		var handler http.Handler
 		handler = route.handlerFunc
 		handler = logger(handler, route.name)
-		handler = auth(handler)
+		handler = authWrapper(handler)
 
 		router.
 			Methods(route.method).
@@ -80,6 +84,15 @@ var myRoutes = routes{
 		getNodeInfos,
 	},
 
+	// Lock, GetLock, RefreshLock, Unlock, PutRelativeFile and RenameFile operations
+	// are all sent on the file URL, the operation being given by the X-WOPI-Override header.
+	route{
+		"Override",
+		"POST",
+		"/wopi/files/{uuid}",
+		override,
+	},
+
 	route{
 		"Download",
 		"GET",
@@ -0,0 +1,371 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/crypto"
+	"github.com/pydio/cells/common/log"
+	enc "github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/idm"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/key"
+)
+
+const (
+	// defaultUnlockTTL is the time during which a passphrase protected keyring stays unlocked, in seconds
+	defaultUnlockTTL = 24 * 60 * 60
+)
+
+var (
+	timeNow = time.Now
+)
+
+type unlockedKeyring struct {
+	private string
+	expire  time.Time
+}
+
+// getUserKeyring loads a user keyring. USER keyrings are created on first use and opened with the master
+// password. USER_PWD keyrings are created when first unlocked, and opened with the passed password or from
+// the unlocked keyrings cache. The public part is readable by anyone, but the private part is only returned
+// to the keyring owner.
+func (ukm *userKeyStore) getUserKeyring(ctx context.Context, dao key.DAO, req *enc.GetKeyRequest, rsp *enc.GetKeyResponse) error {
+
+	if req.KeyID != key.UserKeyringID && req.KeyID != key.UserPwdKeyringID {
+		return errors.BadRequest(common.ServiceUserKey, "unsupported keyring id %s", req.KeyID)
+	}
+
+	k, err := dao.GetKey(req.Owner, req.KeyID)
+	if err != nil {
+		if errors.Parse(err.Error()).Code != 404 {
+			return err
+		}
+		if req.KeyID == key.UserPwdKeyringID {
+			return errors.NotFound(common.ServiceUserKey, "keyring of %s is not created yet, user must log in first", req.Owner)
+		}
+		masterPassword, er := getMasterPassword()
+		if er != nil {
+			return er
+		}
+		if k, _, _, err = createUserKeyring(dao, req.Owner, req.KeyID, masterPassword, false); err != nil {
+			return err
+		}
+	}
+	if k.Info == nil {
+		k.Info = &enc.KeyInfo{}
+	}
+	k.Info.RecoveryContent = ""
+
+	if req.PublicOnly {
+		k.Content = ""
+		rsp.Key = k
+		return nil
+	}
+
+	if ctxUser, _ := permissions.FindUserNameInContext(ctx); ctxUser != req.Owner {
+		log.Auditer(ctx).Error(fmt.Sprintf("User %s tried to open the keyring of %s", ctxUser, req.Owner))
+		return errors.Forbidden(common.ServiceUserKey, "keyring of %s can only be opened by its owner", req.Owner)
+	}
+
+	if req.KeyID == key.UserKeyringID {
+		if err := openWithMasterKey(k); err != nil {
+			return err
+		}
+	} else if req.StrPassword != "" {
+		if err := open(k, []byte(req.StrPassword)); err != nil {
+			return errors.Forbidden(common.ServiceUserKey, "cannot open keyring of %s", req.Owner)
+		}
+	} else if private, ok := ukm.unlockedPrivate(req.Owner); ok {
+		k.Content = private
+	} else {
+		return errors.Forbidden(common.ServiceUserKey, "keyring of %s is locked", req.Owner)
+	}
+
+	rsp.Key = k
+	return nil
+}
+
+// UnlockUserKey opens the passphrase protected keyring of a user and keeps it unlocked for a given time.
+// The keyring is created if it does not exist yet, in which case a recovery key is returned.
+func (ukm *userKeyStore) UnlockUserKey(ctx context.Context, req *enc.UnlockUserKeyRequest, rsp *enc.UnlockUserKeyResponse) error {
+
+	if req.UserLogin == "" || req.StrPassword == "" {
+		return errors.BadRequest(common.ServiceUserKey, "please provide a user login and a passphrase")
+	}
+	dao, err := ukm.getDAO(ctx)
+	if err != nil {
+		return err
+	}
+
+	var private string
+	k, err := dao.GetKey(req.UserLogin, key.UserPwdKeyringID)
+	if err != nil {
+		if errors.Parse(err.Error()).Code != 404 {
+			return err
+		}
+		_, privateBytes, recoveryKey, er := createUserKeyring(dao, req.UserLogin, key.UserPwdKeyringID, []byte(req.StrPassword), true)
+		if er != nil {
+			return er
+		}
+		private = base64.StdEncoding.EncodeToString(privateBytes)
+		rsp.Created = true
+		rsp.RecoveryKey = recoveryKey
+		log.Logger(ctx).Info("Created keyring for user " + req.UserLogin)
+	} else if k.Info.GetRecoveryRequired() {
+		// Password was reset by someone else, keyring is still sealed with the previous one
+		rsp.RecoveryRequired = true
+		return nil
+	} else {
+		if err := open(k, []byte(req.StrPassword)); err != nil {
+			return errors.Forbidden(common.ServiceUserKey, "cannot unlock keyring of %s with this passphrase", req.UserLogin)
+		}
+		private = k.Content
+	}
+
+	ttl := req.TTL
+	if ttl <= 0 {
+		ttl = defaultUnlockTTL
+		if conf := servicecontext.GetConfig(ctx); conf != nil {
+			ttl = int32(conf.Val("keyringUnlockTTL").Default(defaultUnlockTTL).Int())
+		}
+	}
+	ukm.Lock()
+	ukm.unlocked[req.UserLogin] = &unlockedKeyring{
+		private: private,
+		expire:  timeNow().Add(time.Duration(ttl) * time.Second),
+	}
+	ukm.Unlock()
+
+	rsp.Success = true
+	return nil
+}
+
+// LockUserKey removes a user keyring from the unlocked keyrings.
+func (ukm *userKeyStore) LockUserKey(ctx context.Context, req *enc.LockUserKeyRequest, rsp *enc.LockUserKeyResponse) error {
+	ukm.Lock()
+	delete(ukm.unlocked, req.UserLogin)
+	ukm.Unlock()
+	rsp.Success = true
+	return nil
+}
+
+// ChangeUserKeyPassword seals the passphrase protected keyring of a user with a new passphrase.
+func (ukm *userKeyStore) ChangeUserKeyPassword(ctx context.Context, req *enc.ChangeUserKeyPasswordRequest, rsp *enc.ChangeUserKeyPasswordResponse) error {
+
+	if req.UserLogin == "" || req.NewPassword == "" {
+		return errors.BadRequest(common.ServiceUserKey, "please provide a user login and a new passphrase")
+	}
+	dao, err := ukm.getDAO(ctx)
+	if err != nil {
+		return err
+	}
+	k, err := dao.GetKey(req.UserLogin, key.UserPwdKeyringID)
+	if err != nil {
+		return err
+	}
+	if err := open(k, []byte(req.OldPassword)); err != nil {
+		return errors.Forbidden(common.ServiceUserKey, "cannot open keyring of %s with current passphrase", req.UserLogin)
+	}
+	if err := seal(k, []byte(req.NewPassword)); err != nil {
+		return err
+	}
+	if k.Info != nil {
+		k.Info.RecoveryRequired = false
+	}
+	if err := dao.SaveKey(k); err != nil {
+		return err
+	}
+	log.Auditer(ctx).Info(fmt.Sprintf("Changed keyring passphrase for user %s", req.UserLogin))
+	rsp.Success = true
+	return nil
+}
+
+// RecoverUserKey opens the passphrase protected keyring of a user with its recovery key, and seals it with a
+// new passphrase. A new recovery key is generated.
+func (ukm *userKeyStore) RecoverUserKey(ctx context.Context, req *enc.RecoverUserKeyRequest, rsp *enc.RecoverUserKeyResponse) error {
+
+	if req.UserLogin == "" || req.RecoveryKey == "" || req.NewPassword == "" {
+		return errors.BadRequest(common.ServiceUserKey, "please provide a user login, a recovery key and a new passphrase")
+	}
+	dao, err := ukm.getDAO(ctx)
+	if err != nil {
+		return err
+	}
+	k, err := dao.GetKey(req.UserLogin, key.UserPwdKeyringID)
+	if err != nil {
+		return err
+	}
+	if k.Info == nil || k.Info.RecoveryContent == "" {
+		return errors.BadRequest(common.ServiceUserKey, "keyring of %s has no recovery key", req.UserLogin)
+	}
+	recovered := &enc.Key{Content: k.Info.RecoveryContent}
+	if err := open(recovered, []byte(normalizeRecoveryKey(req.RecoveryKey))); err != nil {
+		return errors.Forbidden(common.ServiceUserKey, "invalid recovery key for %s", req.UserLogin)
+	}
+
+	recoveryKey, recoveryContent, err := newRecoveryContent(recovered.Content)
+	if err != nil {
+		return err
+	}
+	k.Content = recovered.Content
+	k.Info.RecoveryContent = recoveryContent
+	k.Info.RecoveryRequired = false
+	if err := seal(k, []byte(req.NewPassword)); err != nil {
+		return err
+	}
+	if err := dao.SaveKey(k); err != nil {
+		return err
+	}
+
+	ukm.Lock()
+	delete(ukm.unlocked, req.UserLogin)
+	ukm.Unlock()
+
+	log.Auditer(ctx).Info(fmt.Sprintf("Recovered keyring for user %s", req.UserLogin))
+	rsp.RecoveryKey = recoveryKey
+	return nil
+}
+
+// RequireUserKeyRecovery flags the passphrase protected keyring of a user whose password was reset by someone
+// else: it cannot be unlocked at login anymore, until it is recovered with its recovery key.
+func (ukm *userKeyStore) RequireUserKeyRecovery(ctx context.Context, req *enc.RequireUserKeyRecoveryRequest, rsp *enc.RequireUserKeyRecoveryResponse) error {
+
+	if req.UserLogin == "" {
+		return errors.BadRequest(common.ServiceUserKey, "please provide a user login")
+	}
+	dao, err := ukm.getDAO(ctx)
+	if err != nil {
+		return err
+	}
+	k, err := dao.GetKey(req.UserLogin, key.UserPwdKeyringID)
+	if err != nil {
+		return err
+	}
+	if k.Info == nil {
+		k.Info = &enc.KeyInfo{}
+	}
+	k.Info.RecoveryRequired = true
+	if err := dao.SaveKey(k); err != nil {
+		return err
+	}
+
+	ukm.Lock()
+	delete(ukm.unlocked, req.UserLogin)
+	ukm.Unlock()
+
+	log.Auditer(ctx).Info(fmt.Sprintf("Keyring of user %s must be recovered after a password reset", req.UserLogin))
+	rsp.Success = true
+	return nil
+}
+
+// HandleIdmChange locks the keyring of users logging out.
+func (ukm *userKeyStore) HandleIdmChange(ctx context.Context, msg *idm.ChangeEvent) error {
+	if msg.Type == idm.ChangeEventType_LOGOUT && msg.User != nil && msg.User.Login != "" {
+		return ukm.LockUserKey(ctx, &enc.LockUserKeyRequest{UserLogin: msg.User.Login}, &enc.LockUserKeyResponse{})
+	}
+	return nil
+}
+
+func (ukm *userKeyStore) unlockedPrivate(login string) (string, bool) {
+	ukm.Lock()
+	defer ukm.Unlock()
+	u, ok := ukm.unlocked[login]
+	if !ok {
+		return "", false
+	}
+	if timeNow().After(u.expire) {
+		delete(ukm.unlocked, login)
+		return "", false
+	}
+	return u.private, true
+}
+
+// createUserKeyring generates a new key pair for a user and stores its private part sealed with a password.
+// If withRecovery is set, a recovery key is generated and returned, and the private part is also stored
+// sealed with this recovery key.
+func createUserKeyring(dao key.DAO, owner string, keyID string, password []byte, withRecovery bool) (k *enc.Key, private []byte, recoveryKey string, err error) {
+
+	var public []byte
+	public, private, err = crypto.GenerateUserKeyPair()
+	if err != nil {
+		return
+	}
+	k = &enc.Key{
+		Owner:        owner,
+		ID:           keyID,
+		Label:        "User keyring",
+		Content:      base64.StdEncoding.EncodeToString(private),
+		CreationDate: int32(timeNow().Unix()),
+		Info: &enc.KeyInfo{
+			PublicKey: base64.StdEncoding.EncodeToString(public),
+		},
+	}
+	if withRecovery {
+		if recoveryKey, k.Info.RecoveryContent, err = newRecoveryContent(k.Content); err != nil {
+			return
+		}
+	}
+	if err = seal(k, password); err != nil {
+		return
+	}
+	err = dao.SaveKey(k)
+	return
+}
+
+// newRecoveryContent generates a recovery key and seals the private key content with it.
+func newRecoveryContent(privateContent string) (recoveryKey string, recoveryContent string, e error) {
+	random, e := crypto.RandomBytes(20)
+	if e != nil {
+		return
+	}
+	raw := base32.StdEncoding.EncodeToString(random)
+	var groups []string
+	for i := 0; i < len(raw); i += 4 {
+		groups = append(groups, raw[i:i+4])
+	}
+	recoveryKey = strings.Join(groups, "-")
+	recovered := &enc.Key{Content: privateContent}
+	if e = seal(recovered, []byte(raw)); e != nil {
+		return
+	}
+	recoveryContent = recovered.Content
+	return
+}
+
+// normalizeRecoveryKey removes separators and case differences a user may introduce when typing a recovery key.
+func normalizeRecoveryKey(recoveryKey string) string {
+	r := strings.ToUpper(recoveryKey)
+	for _, sep := range []string{"-", " "} {
+		r = strings.Replace(r, sep, "", -1)
+	}
+	return r
+}
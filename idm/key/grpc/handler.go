@@ -25,6 +25,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/micro/go-micro/errors"
@@ -39,11 +40,20 @@ import (
 	"github.com/pydio/cells/idm/key"
 )
 
-type userKeyStore struct{}
+type userKeyStore struct {
+	sync.Mutex
+	unlocked map[string]*unlockedKeyring
+}
 
 // NewUserKeyStore creates a master password based
 func NewUserKeyStore() (enc.UserKeyStoreHandler, error) {
-	return &userKeyStore{}, nil
+	return newUserKeyStore(), nil
+}
+
+func newUserKeyStore() *userKeyStore {
+	return &userKeyStore{
+		unlocked: make(map[string]*unlockedKeyring),
+	}
 }
 
 func (ukm *userKeyStore) getDAO(ctx context.Context) (key.DAO, error) {
@@ -80,6 +90,10 @@ func (ukm *userKeyStore) GetKey(ctx context.Context, req *enc.GetKeyRequest, rsp
 		return err
 	}
 
+	if req.Owner != "" && req.Owner != common.PydioSystemUsername {
+		return ukm.getUserKeyring(ctx, dao, req, rsp)
+	}
+
 	// TODO: Extract user / password info from Context
 	user := common.PydioSystemUsername
 	pwd := config.Vault().Val("masterPassword").Bytes()
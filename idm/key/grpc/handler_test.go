@@ -20,6 +20,273 @@
 
 package grpc
 
-import _ "github.com/smartystreets/goconvey/convey"
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
 
-// TODO add tests or remove this file.
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/crypto"
+	"github.com/pydio/cells/common/dao"
+	enc "github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/idm"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/idm/key"
+)
+
+// memDAO is an in-memory key.DAO
+type memDAO struct {
+	dao.DAO
+	keys map[string]*enc.Key
+}
+
+func (m *memDAO) SaveKey(k *enc.Key) error {
+	m.keys[k.Owner+":"+k.ID] = proto.Clone(k).(*enc.Key)
+	return nil
+}
+
+func (m *memDAO) GetKey(owner string, keyID string) (*enc.Key, error) {
+	k, ok := m.keys[owner+":"+keyID]
+	if !ok {
+		return nil, errors.NotFound("mem.key.dao", "key not found")
+	}
+	return proto.Clone(k).(*enc.Key), nil
+}
+
+func (m *memDAO) ListKeys(owner string) ([]*enc.Key, error) {
+	var kk []*enc.Key
+	for _, k := range m.keys {
+		if k.Owner == owner {
+			kk = append(kk, proto.Clone(k).(*enc.Key))
+		}
+	}
+	return kk, nil
+}
+
+func (m *memDAO) DeleteKey(owner string, keyID string) error {
+	delete(m.keys, owner+":"+keyID)
+	return nil
+}
+
+// userCtx sets the login of the user calling the service.
+func userCtx(ctx context.Context, login string) context.Context {
+	return context.WithValue(ctx, common.PydioContextUserKey, login)
+}
+
+func TestUserPwdKeyring(t *testing.T) {
+
+	Convey("Test passphrase protected keyring", t, func() {
+
+		// Nested conveys re-run this setup, start each time with an empty store
+		ctx := userCtx(servicecontext.WithDAO(context.Background(), &memDAO{keys: map[string]*enc.Key{}}), "alice")
+		h := newUserKeyStore()
+
+		getRsp := &enc.GetKeyResponse{}
+		e := h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID}, getRsp)
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 404)
+
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: "other-key"}, getRsp)
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 400)
+
+		unlockRsp := &enc.UnlockUserKeyResponse{}
+		e = h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "alice", StrPassword: "pass1"}, unlockRsp)
+		So(e, ShouldBeNil)
+		So(unlockRsp.Success, ShouldBeTrue)
+		So(unlockRsp.Created, ShouldBeTrue)
+		So(unlockRsp.RecoveryKey, ShouldNotBeEmpty)
+		recoveryKey := unlockRsp.RecoveryKey
+
+		// Public part is always readable
+		pubRsp := &enc.GetKeyResponse{}
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID, PublicOnly: true}, pubRsp)
+		So(e, ShouldBeNil)
+		So(pubRsp.Key.Content, ShouldBeEmpty)
+		So(pubRsp.Key.Info.PublicKey, ShouldNotBeEmpty)
+		So(pubRsp.Key.Info.RecoveryContent, ShouldBeEmpty)
+
+		// Private part is available while unlocked
+		privRsp := &enc.GetKeyResponse{}
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID}, privRsp)
+		So(e, ShouldBeNil)
+		So(privRsp.Key.Content, ShouldNotBeEmpty)
+		private := privRsp.Key.Content
+
+		// Second unlock does not create a new keyring
+		unlockRsp = &enc.UnlockUserKeyResponse{}
+		e = h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "alice", StrPassword: "pass1"}, unlockRsp)
+		So(e, ShouldBeNil)
+		So(unlockRsp.Created, ShouldBeFalse)
+		So(unlockRsp.RecoveryKey, ShouldBeEmpty)
+
+		e = h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "alice", StrPassword: "wrong"}, &enc.UnlockUserKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+
+		// Logout locks keyring
+		e = h.HandleIdmChange(ctx, &idm.ChangeEvent{Type: idm.ChangeEventType_LOGOUT, User: &idm.User{Login: "alice"}})
+		So(e, ShouldBeNil)
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+
+		// Explicit password still opens keyring
+		privRsp = &enc.GetKeyResponse{}
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID, StrPassword: "pass1"}, privRsp)
+		So(e, ShouldBeNil)
+		So(privRsp.Key.Content, ShouldEqual, private)
+
+		Convey("Test passphrase change", func() {
+			e := h.ChangeUserKeyPassword(ctx, &enc.ChangeUserKeyPasswordRequest{UserLogin: "alice", OldPassword: "wrong", NewPassword: "pass2"}, &enc.ChangeUserKeyPasswordResponse{})
+			So(e, ShouldNotBeNil)
+
+			e = h.ChangeUserKeyPassword(ctx, &enc.ChangeUserKeyPasswordRequest{UserLogin: "alice", OldPassword: "pass1", NewPassword: "pass2"}, &enc.ChangeUserKeyPasswordResponse{})
+			So(e, ShouldBeNil)
+
+			e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID, StrPassword: "pass1"}, &enc.GetKeyResponse{})
+			So(e, ShouldNotBeNil)
+
+			privRsp := &enc.GetKeyResponse{}
+			e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID, StrPassword: "pass2"}, privRsp)
+			So(e, ShouldBeNil)
+			So(privRsp.Key.Content, ShouldEqual, private)
+		})
+
+		Convey("Test recovery", func() {
+			e := h.RecoverUserKey(ctx, &enc.RecoverUserKeyRequest{UserLogin: "alice", RecoveryKey: "AAAA-BBBB", NewPassword: "pass3"}, &enc.RecoverUserKeyResponse{})
+			So(e, ShouldNotBeNil)
+
+			recoverRsp := &enc.RecoverUserKeyResponse{}
+			e = h.RecoverUserKey(ctx, &enc.RecoverUserKeyRequest{UserLogin: "alice", RecoveryKey: " " + recoveryKey, NewPassword: "pass3"}, recoverRsp)
+			So(e, ShouldBeNil)
+			So(recoverRsp.RecoveryKey, ShouldNotBeEmpty)
+			So(recoverRsp.RecoveryKey, ShouldNotEqual, recoveryKey)
+
+			privRsp := &enc.GetKeyResponse{}
+			e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID, StrPassword: "pass3"}, privRsp)
+			So(e, ShouldBeNil)
+			So(privRsp.Key.Content, ShouldEqual, private)
+
+			// Old recovery key is not valid anymore
+			e = h.RecoverUserKey(ctx, &enc.RecoverUserKeyRequest{UserLogin: "alice", RecoveryKey: recoveryKey, NewPassword: "pass4"}, &enc.RecoverUserKeyResponse{})
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("Test recovery required after password reset", func() {
+			e := h.RequireUserKeyRecovery(ctx, &enc.RequireUserKeyRecoveryRequest{UserLogin: "alice"}, &enc.RequireUserKeyRecoveryResponse{})
+			So(e, ShouldBeNil)
+			e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "alice", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+			So(e, ShouldNotBeNil)
+
+			unlockRsp := &enc.UnlockUserKeyResponse{}
+			e = h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "alice", StrPassword: "reset"}, unlockRsp)
+			So(e, ShouldBeNil)
+			So(unlockRsp.Success, ShouldBeFalse)
+			So(unlockRsp.RecoveryRequired, ShouldBeTrue)
+
+			e = h.RecoverUserKey(ctx, &enc.RecoverUserKeyRequest{UserLogin: "alice", RecoveryKey: recoveryKey, NewPassword: "reset"}, &enc.RecoverUserKeyResponse{})
+			So(e, ShouldBeNil)
+			unlockRsp = &enc.UnlockUserKeyResponse{}
+			e = h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "alice", StrPassword: "reset"}, unlockRsp)
+			So(e, ShouldBeNil)
+			So(unlockRsp.Success, ShouldBeTrue)
+			So(unlockRsp.RecoveryRequired, ShouldBeFalse)
+
+			// Users without keyring are not flagged
+			e = h.RequireUserKeyRecovery(ctx, &enc.RequireUserKeyRecoveryRequest{UserLogin: "nobody"}, &enc.RequireUserKeyRecoveryResponse{})
+			So(e, ShouldNotBeNil)
+			So(errors.Parse(e.Error()).Code, ShouldEqual, 404)
+		})
+	})
+}
+
+func TestUserKeyringExpiration(t *testing.T) {
+
+	ctx := userCtx(servicecontext.WithDAO(context.Background(), &memDAO{keys: map[string]*enc.Key{}}), "bob")
+	h := newUserKeyStore()
+
+	Convey("Test unlocked keyring expiration", t, func() {
+		e := h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "bob", StrPassword: "pass", TTL: 60}, &enc.UnlockUserKeyResponse{})
+		So(e, ShouldBeNil)
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+		So(e, ShouldBeNil)
+
+		now := time.Now()
+		timeNow = func() time.Time {
+			return now.Add(2 * time.Minute)
+		}
+		defer func() {
+			timeNow = time.Now
+		}()
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+	})
+}
+
+func TestUserKeyringWrapping(t *testing.T) {
+
+	ctx := servicecontext.WithDAO(context.Background(), &memDAO{keys: map[string]*enc.Key{}})
+	h := newUserKeyStore()
+
+	Convey("Test keys wrapped for a user can be unwrapped with its keyring only", t, func() {
+		for _, u := range []string{"alice", "bob"} {
+			So(h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: u, StrPassword: u}, &enc.UnlockUserKeyResponse{}), ShouldBeNil)
+		}
+		keys := map[string]*enc.Key{}
+		for _, u := range []string{"alice", "bob"} {
+			rsp := &enc.GetKeyResponse{}
+			So(h.GetKey(userCtx(ctx, u), &enc.GetKeyRequest{Owner: u, KeyID: key.UserPwdKeyringID}, rsp), ShouldBeNil)
+			keys[u] = rsp.Key
+		}
+		nodeKey := []byte(fmt.Sprintf("%032d", 42))
+		wrapped, e := crypto.WrapKey(mustDecode(keys["alice"].Info.PublicKey), nodeKey)
+		So(e, ShouldBeNil)
+		unwrapped, e := crypto.UnwrapKey(mustDecode(keys["alice"].Content), wrapped)
+		So(e, ShouldBeNil)
+		So(unwrapped, ShouldResemble, nodeKey)
+		_, e = crypto.UnwrapKey(mustDecode(keys["bob"].Content), wrapped)
+		So(e, ShouldNotBeNil)
+	})
+}
+
+func TestUserKeyringOwnership(t *testing.T) {
+
+	ctx := servicecontext.WithDAO(context.Background(), &memDAO{keys: map[string]*enc.Key{}})
+	h := newUserKeyStore()
+
+	Convey("Test users cannot open the keyring of another user", t, func() {
+		So(h.UnlockUserKey(ctx, &enc.UnlockUserKeyRequest{UserLogin: "bob", StrPassword: "bob"}, &enc.UnlockUserKeyResponse{}), ShouldBeNil)
+
+		e := h.GetKey(userCtx(ctx, "alice"), &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+		e = h.GetKey(userCtx(ctx, "alice"), &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID, StrPassword: "bob"}, &enc.GetKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+		e = h.GetKey(ctx, &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID}, &enc.GetKeyResponse{})
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 403)
+
+		// Public part can still be used to share keys with bob
+		pubRsp := &enc.GetKeyResponse{}
+		So(h.GetKey(userCtx(ctx, "alice"), &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID, PublicOnly: true}, pubRsp), ShouldBeNil)
+		So(pubRsp.Key.Info.PublicKey, ShouldNotBeEmpty)
+
+		privRsp := &enc.GetKeyResponse{}
+		So(h.GetKey(userCtx(ctx, "bob"), &enc.GetKeyRequest{Owner: "bob", KeyID: key.UserPwdKeyringID}, privRsp), ShouldBeNil)
+		So(privRsp.Key.Content, ShouldNotBeEmpty)
+	})
+}
+
+func mustDecode(s string) []byte {
+	b, _ := base64.StdEncoding.DecodeString(s)
+	return b
+}
@@ -42,11 +42,12 @@ func init() {
 			service.Description("Encryption Keys server"),
 			service.WithStorage(key.NewDAO, "idm_key"),
 			service.WithMicro(func(m micro.Service) error {
-				h, err := NewUserKeyStore()
-				if err != nil {
+				h := newUserKeyStore()
+				encryption.RegisterUserKeyStoreHandler(m.Options().Server, h)
+				// Lock passphrase protected keyrings on logout
+				if err := m.Options().Server.Subscribe(m.Options().Server.NewSubscriber(common.TopicIdmEvent, h.HandleIdmChange)); err != nil {
 					return err
 				}
-				encryption.RegisterUserKeyStoreHandler(m.Options().Server, h)
 				return nil
 			}),
 		)
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package key
+
+import (
+	"context"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+// KeyRotation describes the last master key rotation of a datasource. While it is not finished, node keys
+// of the datasource may be wrapped with either key. It is kept once finished, as services may still use
+// the previous key until they reload the datasource configuration.
+type KeyRotation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Started  int64  `json:"started"`
+	Finished int64  `json:"finished,omitempty"`
+}
+
+func keyRotationPath(dsName string) []string {
+	return []string{"services", common.ServiceGrpcNamespace_ + common.ServiceEncKey, "rotations", dsName}
+}
+
+// GetKeyRotation finds the last rotation of a datasource, if any.
+func GetKeyRotation(dsName string) (*KeyRotation, bool) {
+	var r *KeyRotation
+	if e := config.Get(keyRotationPath(dsName)...).Scan(&r); e != nil || r == nil || r.From == "" || r.To == "" {
+		return nil, false
+	}
+	return r, true
+}
+
+// SetKeyRotation stores the rotation of a datasource.
+func SetKeyRotation(dsName string, r *KeyRotation, ctxUser string) error {
+	if e := config.Set(r, keyRotationPath(dsName)...); e != nil {
+		return e
+	}
+	msg := "Start master key rotation for datasource " + dsName
+	if r.Finished > 0 {
+		msg = "Finish master key rotation for datasource " + dsName
+	}
+	return config.Save(ctxUser, msg)
+}
+
+// WithKeyRotation wraps a master key tool to support a rotation in progress on a datasource: data is always
+// encrypted with the new key, and decrypted with whichever key of the rotation protects it.
+func WithKeyRotation(tool UserKeyTool, dsName string) UserKeyTool {
+	return &rotationKeyTool{UserKeyTool: tool, dsName: dsName}
+}
+
+type rotationKeyTool struct {
+	UserKeyTool
+	dsName string
+}
+
+// GetEncrypted uses the target key of the rotation instead of the key being replaced.
+func (t *rotationKeyTool) GetEncrypted(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	if r, ok := GetKeyRotation(t.dsName); ok && keyID == r.From {
+		keyID = r.To
+	}
+	return t.UserKeyTool.GetEncrypted(ctx, keyID, data)
+}
+
+// GetDecrypted tries the other keys of the rotation if the data cannot be decrypted with keyID.
+func (t *rotationKeyTool) GetDecrypted(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	plain, err := t.UserKeyTool.GetDecrypted(ctx, keyID, data)
+	if err == nil {
+		return plain, nil
+	}
+	r, ok := GetKeyRotation(t.dsName)
+	if !ok {
+		return nil, err
+	}
+	for _, other := range []string{r.To, r.From} {
+		if other == keyID {
+			continue
+		}
+		if plain, e := t.UserKeyTool.GetDecrypted(ctx, other, data); e == nil {
+			return plain, nil
+		}
+	}
+	return nil, err
+}
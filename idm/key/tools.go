@@ -23,8 +23,11 @@ package key
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"sync"
 
+	"github.com/micro/go-micro/errors"
+
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/encryption"
@@ -32,6 +35,25 @@ import (
 	"github.com/pydio/cells/common/crypto"
 )
 
+const (
+	// UserKeyringID identifies the keyring of a user for datasources in USER encryption mode.
+	// Its private key is sealed with the master password.
+	UserKeyringID = "user-keyring"
+	// UserPwdKeyringID identifies the keyring of a user for datasources in USER_PWD encryption mode.
+	// Its private key is sealed with a passphrase that only the user knows.
+	UserPwdKeyringID = "user-pwd-keyring"
+)
+
+// RequireUserKeyRecovery flags the passphrase protected keyring of a user after the user password was reset
+// by someone else, so that it is recovered with its recovery key. Users without such keyring are ignored.
+func RequireUserKeyRecovery(ctx context.Context, login string) error {
+	client := encryption.NewUserKeyStoreClient(common.ServiceGrpcNamespace_+common.ServiceUserKey, defaults.NewClient())
+	if _, e := client.RequireUserKeyRecovery(ctx, &encryption.RequireUserKeyRecoveryRequest{UserLogin: login}); e != nil && errors.Parse(e.Error()).Code != 404 {
+		return e
+	}
+	return nil
+}
+
 // UserKeyTool describes a tool that can encrypt/decrypt data based on user context
 type UserKeyTool interface {
 	GetEncrypted(ctx context.Context, keyID string, data []byte) ([]byte, error)
@@ -45,9 +67,14 @@ func MasterKeyTool(ctx context.Context) (UserKeyTool, error) {
 	return kt, nil
 }
 
-// GetUserKeyTool creates a keytool based on specified @user and @pass
+// GetUserKeyTool creates a keytool based on specified @user and @pass. Data is wrapped with the public key
+// of the user keyring passed as keyID, and unwrapped with its private key. The keyring must either be unlocked
+// on the key service, or be opened with @pass.
 func GetUserKeyTool(user string, pass []byte) UserKeyTool {
-	return &userKeyTool{}
+	return &userKeyringTool{
+		user: user,
+		pass: pass,
+	}
 }
 
 type userKeyTool struct {
@@ -100,3 +127,49 @@ func (kt *userKeyTool) GetDecrypted(ctx context.Context, keyID string, encrypted
 
 	return crypto.Open(keyBytes, encrypted[:12], encrypted[12:])
 }
+
+type userKeyringTool struct {
+	user string
+	pass []byte
+}
+
+func (kt *userKeyringTool) loadKeyring(ctx context.Context, keyID string, publicOnly bool) (*encryption.Key, error) {
+	client := encryption.NewUserKeyStoreClient(common.ServiceGrpcNamespace_+common.ServiceUserKey, defaults.NewClient())
+	rsp, err := client.GetKey(ctx, &encryption.GetKeyRequest{
+		Owner:       kt.user,
+		KeyID:       keyID,
+		StrPassword: string(kt.pass),
+		PublicOnly:  publicOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Key == nil || rsp.Key.Info == nil {
+		return nil, fmt.Errorf("cannot find keyring %s for user %s", keyID, kt.user)
+	}
+	return rsp.Key, nil
+}
+
+func (kt *userKeyringTool) GetEncrypted(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	k, err := kt.loadKeyring(ctx, keyID, true)
+	if err != nil {
+		return nil, err
+	}
+	public, err := base64.StdEncoding.DecodeString(k.Info.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.WrapKey(public, data)
+}
+
+func (kt *userKeyringTool) GetDecrypted(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	k, err := kt.loadKeyring(ctx, keyID, false)
+	if err != nil {
+		return nil, err
+	}
+	private, err := base64.StdEncoding.DecodeString(k.Content)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.UnwrapKey(private, encrypted)
+}
@@ -23,12 +23,17 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/micro/go-micro"
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/ldap"
+	"github.com/pydio/cells/common/auth/oidc"
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/plugins"
 	proto "github.com/pydio/cells/common/proto/auth"
@@ -74,6 +79,10 @@ func init() {
 				auth.InitConfiguration(config.Get("services", common.ServiceWebNamespace_+common.ServiceOAuth))
 			}),
 			service.BeforeStart(initialize),
+			service.AfterStart(func(s service.Service) error {
+				go oauth.InsertLdapSyncJobs(s.Options().Context)
+				return nil
+			}),
 		)
 
 		service.NewService(
@@ -93,9 +102,10 @@ func init() {
 
 		auth.OnConfigurationInit(func(scanner common.Scanner) {
 			var m []struct {
-				ID   string
-				Name string
-				Type string
+				ID     string
+				Name   string
+				Type   string
+				Config json.RawMessage
 			}
 
 			if err := scanner.Scan(&m); err != nil {
@@ -103,9 +113,17 @@ func init() {
 			}
 
 			for _, mm := range m {
-				if mm.Type == "pydio" {
+				switch mm.Type {
+				case "pydio":
 					// Registering the first connector
 					auth.RegisterConnector(mm.ID, mm.Name, mm.Type, nil)
+				case ldap.ConnectorType, oidc.ConnectorType:
+					conf := &structpb.Struct{}
+					if err := jsonpb.UnmarshalString(string(mm.Config), conf); err != nil {
+						log.Println("Wrong configuration for connector "+mm.ID, err)
+						continue
+					}
+					auth.RegisterConnector(mm.ID, mm.Name, mm.Type, conf)
 				}
 			}
 		})
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package oauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/ldap"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/registry"
+	"github.com/pydio/cells/common/service"
+)
+
+// InsertLdapSyncJobs creates or updates the synchronization jobs of LDAP connectors, and removes the jobs of
+// connectors that have no synchronization interval.
+func InsertLdapSyncJobs(ctx context.Context) error {
+
+	connectors, e := ldap.ListConnectors()
+	if e != nil || len(connectors) == 0 {
+		return e
+	}
+
+	return service.Retry(ctx, func() error {
+
+		cli := jobs.NewJobServiceClient(registry.GetClient(common.ServiceJobs))
+		timeout := registry.ShortRequestTimeout()
+		for _, c := range connectors {
+			if c.Config.SyncInterval == "" {
+				if _, e := cli.DeleteJob(ctx, &jobs.DeleteJobRequest{JobID: ldap.SyncJobID(c.ID)}, timeout); e != nil && errors.Parse(e.Error()).Id == "go.micro.client" {
+					return e // not ready yet, retry
+				}
+				continue
+			}
+			log.Logger(ctx).Info("Inserting synchronization job for LDAP connector "+c.ID, zap.String("interval", c.Config.SyncInterval))
+			if _, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: ldap.SyncJob(c.ID, c.Name, c.Config)}, timeout); e != nil {
+				return e
+			}
+		}
+		return nil
+
+	}, 5*time.Second, 2*time.Minute)
+}
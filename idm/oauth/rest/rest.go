@@ -45,6 +45,7 @@ import (
 	"github.com/pydio/cells/common/utils/i18n"
 	"github.com/pydio/cells/common/utils/permissions"
 	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/idm/key"
 	"github.com/pydio/cells/idm/oauth/lang"
 	json "github.com/pydio/cells/x/jsonx"
 )
@@ -221,6 +222,9 @@ func (a *TokenHandler) ResetPassword(req *restful.Request, resp *restful.Respons
 		service.RestError500(req, resp, fmt.Errorf(T("ResetPassword.Err.ResetFailed")))
 		return
 	}
+	if e := key.RequireUserKeyRecovery(ctx, u.Login); e != nil {
+		log.Logger(ctx).Error("Cannot flag keyring for recovery", zap.String(common.KEY_USERNAME, u.Login), zap.Error(e))
+	}
 
 	go func() {
 		// Send email
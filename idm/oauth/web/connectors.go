@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package web
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/ory/fosite"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/auth/hydra"
+	"github.com/pydio/cells/common/log"
+)
+
+const (
+	connectorsPrefix = "/oidc/connectors"
+
+	stateCookie = "cells-connector-state"
+	dataCookie  = "cells-connector-data"
+	// maxDataCookie leaves room for the other cookies of the site
+	maxDataCookie = 3000
+)
+
+// connectorsHandler serves the endpoints of connectors redirecting users to an upstream provider. The login page
+// sends users to /oidc/connectors/{id}/login with the current login_challenge; once authenticated upstream, they
+// come back to the callback endpoint which accepts the challenge and redirects to the client with a code.
+type connectorsHandler struct {
+	site *url.URL
+}
+
+func (h *connectorsHandler) routes(r *mux.Router) {
+	r.HandleFunc(connectorsPrefix, h.list).Methods(http.MethodGet)
+	r.HandleFunc(connectorsPrefix+"/{id}/login", h.login).Methods(http.MethodGet)
+	r.HandleFunc(connectorsPrefix+"/{id}/callback", h.callback).Methods(http.MethodGet)
+	r.HandleFunc(connectorsPrefix+"/{id}/logout", h.logout).Methods(http.MethodGet)
+	r.HandleFunc(connectorsPrefix+"/{id}/backchannel-logout", h.backChannelLogout).Methods(http.MethodPost)
+}
+
+// list returns the connectors that can be displayed as buttons on the login page.
+func (h *connectorsHandler) list(w http.ResponseWriter, r *http.Request) {
+	type connector struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		LoginURL string `json:"loginURL"`
+	}
+	list := []connector{}
+	for _, c := range auth.GetConnectors() {
+		if _, ok := c.Conn().(auth.CallbackConnector); ok {
+			list = append(list, connector{ID: c.ID(), Name: c.Name(), Type: c.Type(), LoginURL: h.path(c.ID(), "login")})
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *connectorsHandler) login(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cc, ok := findConnector(id).(auth.CallbackConnector)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	challenge := r.URL.Query().Get("login_challenge")
+	if challenge == "" {
+		http.Error(w, "missing login_challenge", http.StatusBadRequest)
+		return
+	}
+	state := uuid.New()
+	loginURL, err := cc.LoginURL(auth.Scopes{}, h.site.Scheme+"://"+h.site.Host+h.path(id, "callback"), state)
+	if err != nil {
+		log.Logger(r.Context()).Error("Cannot build login URL for connector "+id, zap.Error(err))
+		http.Error(w, "identity provider is not available", http.StatusBadGateway)
+		return
+	}
+	v := url.Values{"state": {state}, "challenge": {challenge}}
+	h.setCookie(w, id, stateCookie, v.Encode(), 600)
+	http.Redirect(w, r, loginURL, http.StatusFound)
+}
+
+func (h *connectorsHandler) callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	cc, ok := findConnector(id).(auth.CallbackConnector)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	stored, ok := h.readCookie(r, stateCookie)
+	if !ok {
+		http.Error(w, "missing login state", http.StatusBadRequest)
+		return
+	}
+	h.setCookie(w, id, stateCookie, "", -1)
+	v, _ := url.ParseQuery(stored)
+	challenge := v.Get("challenge")
+	if v.Get("state") == "" || v.Get("state") != r.URL.Query().Get("state") || challenge == "" {
+		http.Error(w, "invalid login state", http.StatusBadRequest)
+		return
+	}
+
+	r.URL.Scheme, r.URL.Host = h.site.Scheme, h.site.Host
+	identity, err := cc.HandleCallback(auth.Scopes{}, r)
+	if err != nil {
+		log.Logger(ctx).Error("Authentication through connector "+id+" failed", zap.Error(err))
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	code, err := auth.DefaultJWTVerifier().LoginChallengeCode(ctx, claim.Claims{
+		Subject:    identity.UserID,
+		Name:       identity.Username,
+		Email:      identity.Email,
+		AuthSource: id,
+	}, auth.SetChallenge(challenge))
+	if err != nil {
+		log.Logger(ctx).Error("Cannot accept login challenge", zap.Error(err))
+		http.Error(w, "cannot accept login", http.StatusInternalServerError)
+		return
+	}
+	login, err := hydra.GetLogin(ctx, challenge)
+	if err != nil {
+		http.Error(w, "cannot find login request", http.StatusInternalServerError)
+		return
+	}
+	requestURL, err := url.Parse(login.GetRequestURL())
+	if err != nil {
+		http.Error(w, "invalid login request", http.StatusInternalServerError)
+		return
+	}
+	requestURLValues := requestURL.Query()
+	redirectURL, err := fosite.GetRedirectURIFromRequestValues(requestURLValues)
+	if err != nil {
+		http.Error(w, "invalid login request", http.StatusInternalServerError)
+		return
+	}
+
+	// Keep the upstream data for the logout, if it is small enough for a cookie
+	if data := base64.RawURLEncoding.EncodeToString(identity.ConnectorData); len(identity.ConnectorData) > 0 && len(data) < maxDataCookie {
+		h.setCookie(w, id, dataCookie, data, 0)
+	}
+	http.Redirect(w, r, redirectURL+"?code="+code+"&state="+url.QueryEscape(requestURLValues.Get("state")), http.StatusFound)
+}
+
+// logout ends the upstream session. The frontend sends users here once they are logged out of Cells.
+func (h *connectorsHandler) logout(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	lc, ok := findConnector(id).(auth.LogoutConnector)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var data []byte
+	if stored, ok := h.readCookie(r, dataCookie); ok {
+		data, _ = base64.RawURLEncoding.DecodeString(stored)
+		h.setCookie(w, id, dataCookie, "", -1)
+	}
+	redirect := h.site.Scheme + "://" + h.site.Host + "/"
+	if logoutURL, err := lc.LogoutURL(data, redirect); err != nil {
+		log.Logger(r.Context()).Error("Cannot build logout URL for connector "+id, zap.Error(err))
+	} else if logoutURL != "" {
+		redirect = logoutURL
+	}
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+// backChannelLogout receives logout notifications from the provider and revokes the sessions and tokens of the user.
+func (h *connectorsHandler) backChannelLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	lc, ok := findConnector(id).(auth.LogoutConnector)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	userID, err := lc.HandleLogout(r)
+	if err != nil {
+		log.Logger(ctx).Error("Invalid back-channel logout for connector "+id, zap.Error(err))
+		http.Error(w, "invalid logout request", http.StatusBadRequest)
+		return
+	}
+	cm := auth.GetRegistry().ConsentManager()
+	if err := cm.RevokeSubjectLoginSession(ctx, userID); err != nil {
+		log.Logger(ctx).Error("Cannot revoke login sessions", zap.Error(err))
+		http.Error(w, "cannot revoke sessions", http.StatusInternalServerError)
+		return
+	}
+	if err := cm.RevokeSubjectConsentSession(ctx, userID); err != nil {
+		log.Logger(ctx).Error("Cannot revoke consent sessions", zap.Error(err))
+		http.Error(w, "cannot revoke sessions", http.StatusInternalServerError)
+		return
+	}
+	log.Logger(ctx).Info("Revoked sessions of user " + userID + " after upstream logout")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *connectorsHandler) path(id, endpoint string) string {
+	return connectorsPrefix + "/" + url.PathEscape(id) + "/" + endpoint
+}
+
+func (h *connectorsHandler) setCookie(w http.ResponseWriter, id, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     connectorsPrefix + "/" + url.PathEscape(id) + "/",
+		MaxAge:   maxAge,
+		Secure:   strings.EqualFold(h.site.Scheme, "https"),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (h *connectorsHandler) readCookie(r *http.Request, name string) (string, bool) {
+	c, err := r.Cookie(name)
+	if err != nil || c.Value == "" {
+		return "", false
+	}
+	return c.Value, true
+}
+
+func findConnector(id string) auth.Connector {
+	for _, c := range auth.GetConnectors() {
+		if c.ID() == id {
+			return c.Conn()
+		}
+	}
+	return nil
+}
@@ -85,6 +85,9 @@ func init() {
 						r.PathPrefix("/oidc-admin/").Handler(http.StripPrefix("/oidc-admin", servicecontext.HttpMetaExtractorWrapper(admin)))
 					}
 
+					// Registered before the public router, as they share the /oidc/ prefix
+					(&connectorsHandler{site: u}).routes(r)
+
 					r.PathPrefix("/oidc/").Handler(http.StripPrefix("/oidc", servicecontext.HttpMetaExtractorWrapper(public)))
 				}
 
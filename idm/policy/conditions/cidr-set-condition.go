@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/ory/ladon"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+)
+
+// CIDRSetCondition is a condition which is fulfilled if the given IP address belongs to
+// one of the networks listed in CIDRs, or to none of them if Not is set.
+type CIDRSetCondition struct {
+	// CIDRs is a list of networks (e.g. 192.168.0.0/16) or single addresses, separated by commas or spaces
+	CIDRs string `json:"cidrs"`
+	Not   bool   `json:"not"`
+}
+
+// Fulfills returns true if the given value is an IP address, with an optional port, matching the set.
+// A value that cannot be parsed is considered out of the set.
+func (c *CIDRSetCondition) Fulfills(value interface{}, _ *ladon.Request) bool {
+
+	var in bool
+	if s, ok := value.(string); ok {
+		if ip := parseIP(s); ip != nil {
+			in = c.contains(ip)
+		} else {
+			log.Logger(context.Background()).Debug("cannot parse IP address", zap.String("input param", s))
+		}
+	}
+
+	return in != c.Not
+}
+
+// GetName returns the condition's name.
+func (c *CIDRSetCondition) GetName() string {
+	return "CIDRSetCondition"
+}
+
+func (c *CIDRSetCondition) contains(ip net.IP) bool {
+	for _, cidr := range splitList(c.CIDRs) {
+		if !strings.Contains(cidr, "/") {
+			if ip.Equal(net.ParseIP(cidr)) {
+				return true
+			}
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Logger(context.Background()).Error("cannot parse CIDR", zap.String("cidr", cidr), zap.Error(err))
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP reads an address as found in the RemoteAddress metadata, possibly followed by a port.
+func parseIP(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	return net.ParseIP(strings.Trim(s, "[]"))
+}
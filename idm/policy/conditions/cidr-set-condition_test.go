@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	"github.com/ory/ladon/manager/memory"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pydio/cells/common/service/context"
+)
+
+func TestCIDRSetCondition(t *testing.T) {
+
+	Convey("Canonical CIDR set tests", t, func() {
+
+		for _, c := range []struct {
+			cidrs string
+			not   bool
+			value interface{}
+			pass  bool
+		}{
+			{cidrs: "192.168.0.0/16", value: "192.168.1.12", pass: true},
+			{cidrs: "192.168.0.0/16", value: "192.168.1.12:52310", pass: true},
+			{cidrs: "10.0.0.0/8, 192.168.0.0/16", value: "10.1.2.3", pass: true},
+			{cidrs: "10.0.0.0/8 192.168.0.0/16", value: "172.16.0.1", pass: false},
+			{cidrs: "172.16.0.1", value: "172.16.0.1", pass: true},
+			{cidrs: "2001:db8::/32", value: "[2001:db8::1]:8080", pass: true},
+			{cidrs: "2001:db8::/32", value: "2001:db9::1", pass: false},
+			{cidrs: "not-a-cidr/12,10.0.0.0/8", value: "10.1.2.3", pass: true},
+			{cidrs: "10.0.0.0/8", not: true, value: "10.1.2.3", pass: false},
+			{cidrs: "10.0.0.0/8", not: true, value: "172.16.0.1", pass: true},
+			{cidrs: "10.0.0.0/8", value: "unknown", pass: false},
+			{cidrs: "10.0.0.0/8", value: 10, pass: false},
+		} {
+			condition := &CIDRSetCondition{
+				CIDRs: c.cidrs,
+				Not:   c.not,
+			}
+
+			So(condition.Fulfills(c.value, new(ladon.Request)), ShouldEqual, c.pass)
+		}
+	})
+}
+
+func TestCIDRSetPolicy(t *testing.T) {
+
+	Convey("Test CIDRSetPolicy", t, func() {
+
+		ladonPolicy := &ladon.DefaultPolicy{
+			ID:          "cidr-set-rule",
+			Description: "ACL Rule example, allowing read from the internal networks only",
+			Subjects:    []string{"max"},
+			Resources:   []string{"resource1"},
+			Actions:     []string{"read"},
+			Effect:      ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				servicecontext.HttpMetaRemoteAddress: &CIDRSetCondition{
+					CIDRs: "10.0.0.0/8,192.168.0.0/16",
+				},
+			},
+		}
+
+		warden := &ladon.Ladon{Manager: memory.NewMemoryManager()}
+		require.Nil(t, warden.Manager.Create(ladonPolicy))
+
+		requestOK := &ladon.Request{
+			Subject:  "max",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				servicecontext.HttpMetaRemoteAddress: "192.168.0.10:41234",
+			},
+		}
+		requestNotOK := &ladon.Request{
+			Subject:  "max",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				servicecontext.HttpMetaRemoteAddress: "82.10.10.10:41234",
+			},
+		}
+
+		So(warden.IsAllowed(requestOK), ShouldBeNil)
+		So(warden.IsAllowed(requestNotOK), ShouldNotBeNil)
+	})
+}
@@ -22,17 +22,51 @@
 package conditions
 
 import (
+	"context"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/ory/ladon"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/utils/permissions"
 )
 
 const (
 	timeLayout        = "2006-01-02T15:04-0700"
 	officeHoursLayout = "Monday-Tuesday/15:04-0700/18:04"
+
+	// Operators used by comparison conditions, an empty operator means OperatorEquals
+	OperatorEquals      = "equals"
+	OperatorNotEquals   = "notEquals"
+	OperatorContains    = "contains"
+	OperatorMatches     = "matches"
+	OperatorIn          = "in"
+	OperatorGreaterThan = "greaterThan"
+	OperatorLowerThan   = "lowerThan"
 )
 
 var (
+	// Names of the user properties that are not attributes
+	userProperties = map[string]string{
+		"login":      permissions.PolicyUserLogin,
+		"profile":    permissions.PolicyUserProfile,
+		"groupPath":  permissions.PolicyUserGroupPath,
+		"authSource": permissions.PolicyUserAuthSource,
+	}
+	// Names of the node properties that are not metadata
+	nodeProperties = map[string]string{
+		"name":      permissions.PolicyNodeMetaName,
+		"path":      permissions.PolicyNodeMetaPath,
+		"type":      permissions.PolicyNodeMetaType,
+		"extension": permissions.PolicyNodeMetaExtension,
+		"size":      permissions.PolicyNodeMetaSize,
+		"mtime":     permissions.PolicyNodeMetaMTime,
+	}
+
 	// Ease implementation by defining this map
 	daysMap = map[string]uint{
 		time.Sunday.String():    0,
@@ -103,3 +137,82 @@ func timeStringToMinutes(str string) int {
 	minutes, _ := strconv.Atoi(tokens[1])
 	return hours*60 + minutes
 }
+
+// compareValues applies an operator to a value read from the request context and a reference value.
+// OperatorIn expects a comma-separated list of values, numeric operators parse both values as numbers.
+func compareValues(operator, value, reference string) bool {
+	switch operator {
+	case "", OperatorEquals:
+		return value == reference
+	case OperatorNotEquals:
+		return value != reference
+	case OperatorContains:
+		return strings.Contains(value, reference)
+	case OperatorMatches:
+		matches, err := regexp.MatchString(reference, value)
+		return err == nil && matches
+	case OperatorIn:
+		for _, r := range splitList(reference) {
+			if r == value {
+				return true
+			}
+		}
+		return false
+	case OperatorGreaterThan, OperatorLowerThan:
+		v, e1 := strconv.ParseFloat(value, 64)
+		r, e2 := strconv.ParseFloat(reference, 64)
+		if e1 != nil || e2 != nil {
+			return false
+		}
+		if operator == OperatorGreaterThan {
+			return v > r
+		}
+		return v < r
+	}
+	log.Logger(context.Background()).Error("unknown condition operator", zap.String("operator", operator))
+	return false
+}
+
+// splitList splits a list of values separated by commas, spaces or new lines.
+func splitList(list string) []string {
+	return strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	})
+}
+
+// contextString reads a string value in the request context.
+func contextString(r *ladon.Request, key string) (string, bool) {
+	if r == nil || r.Context == nil {
+		return "", false
+	}
+	s, ok := r.Context[key].(string)
+	return s, ok
+}
+
+// userAttribute reads a property or an attribute of the current user in the request context, a missing
+// attribute is read as an empty string. It returns false if there is no user in the context.
+func userAttribute(r *ladon.Request, name string) (string, bool) {
+	if _, ok := contextString(r, permissions.PolicyUserLogin); !ok {
+		return "", false
+	}
+	key, ok := userProperties[name]
+	if !ok {
+		key = permissions.PolicyUserAttr_ + name
+	}
+	value, _ := contextString(r, key)
+	return value, true
+}
+
+// nodeMetadata reads a property or a metadata of the current node in the request context, a missing
+// metadata is read as an empty string. It returns false if there is no node in the context.
+func nodeMetadata(r *ladon.Request, name string) (string, bool) {
+	if _, ok := contextString(r, permissions.PolicyNodeMetaPath); !ok {
+		return "", false
+	}
+	key, ok := nodeProperties[name]
+	if !ok {
+		key = permissions.PolicyNodeMeta_ + name
+	}
+	value, _ := contextString(r, key)
+	return value, true
+}
@@ -31,13 +31,14 @@ import (
 )
 
 // DateWithinPeriodCondition is a condition which is fulfilled if the
-// server current date time between start date and end date
+// given date time is between start date and end date. Either date can be
+// left empty for an open period.
 type DateWithinPeriodCondition struct {
 	DateBegin string `json:"dateBegin"`
 	DateEnd   string `json:"dateEnd"`
 }
 
-// Fulfills returns true if the given value is a Time and is between start and end date.
+// Fulfills returns true if the given value is a Time and is between start and end date, included.
 // It expects a string formatted this way: "2006-01-02T15:04-0700"
 func (c *DateWithinPeriodCondition) Fulfills(value interface{}, _ *ladon.Request) bool {
 
@@ -47,19 +48,35 @@ func (c *DateWithinPeriodCondition) Fulfills(value interface{}, _ *ladon.Request
 		return false
 	}
 
-	// log.Logger(context.Background()).Error("checking time period condition for date: " + s)
-
 	t, parseErr := time.Parse(timeLayout, s)
 	if parseErr != nil {
 		log.Logger(context.Background()).Error("cannot parse passed value. reference layout is "+timeLayout, zap.String("input param", s), zap.Error(parseErr))
 		return false
 	}
 
-	// We assume date format has been checked upon creation
-	dateBegin, _ := time.Parse(timeLayout, c.DateBegin)
-	dateEnd, _ := time.Parse(timeLayout, c.DateEnd)
+	if c.DateBegin != "" {
+		dateBegin, err := time.Parse(timeLayout, c.DateBegin)
+		if err != nil {
+			log.Logger(context.Background()).Error("cannot parse begin date. reference layout is "+timeLayout, zap.String("dateBegin", c.DateBegin), zap.Error(err))
+			return false
+		}
+		if t.Before(dateBegin) {
+			return false
+		}
+	}
+
+	if c.DateEnd != "" {
+		dateEnd, err := time.Parse(timeLayout, c.DateEnd)
+		if err != nil {
+			log.Logger(context.Background()).Error("cannot parse end date. reference layout is "+timeLayout, zap.String("dateEnd", c.DateEnd), zap.Error(err))
+			return false
+		}
+		if t.After(dateEnd) {
+			return false
+		}
+	}
 
-	return dateBegin.Before(t) && dateEnd.After(t)
+	return true
 }
 
 // GetName returns the condition's name.
@@ -44,6 +44,15 @@ func TestDateWithinPeriodCondition(t *testing.T) {
 			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-02-01T15:04-0700", pass: true},
 			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-02-01T17:04-0100", pass: true},
 			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-02-03T15:04-0700", pass: false},
+			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-01-01T15:04-0700", pass: false},
+			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-01-02T15:04-0700", pass: true},
+			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "2006-02-02T15:04-0700", pass: true},
+			{dateBegin: "2006-01-02T15:04-0700", value: "2030-01-01T15:04-0700", pass: true},
+			{dateEnd: "2006-02-02T15:04-0700", value: "2000-01-01T15:04-0700", pass: true},
+			{dateEnd: "2006-02-02T15:04-0700", value: "2030-01-01T15:04-0700", pass: false},
+			{dateBegin: "2006-01-02", dateEnd: "2006-02-02T15:04-0700", value: "2006-02-01T15:04-0700", pass: false},
+			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: "not a date", pass: false},
+			{dateBegin: "2006-01-02T15:04-0700", dateEnd: "2006-02-02T15:04-0700", value: 1136214240, pass: false},
 		} {
 			condition := &DateWithinPeriodCondition{
 				DateBegin: c.dateBegin,
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"github.com/ory/ladon"
+)
+
+// FileMetadataCondition is a condition which is fulfilled if a metadata of the current node compares to
+// Value using Operator (see the Operator constants). Metadata is either one of "name", "path", "type",
+// "extension", "size", "mtime" or the name of a node metadata. If UserAttribute is set, the metadata is
+// compared to this attribute of the current user instead of Value, e.g. to match a "department" metadata
+// with the department of the user.
+//
+// The condition does not use the value of its key in the request context, it is never fulfilled if
+// there is no node in the context.
+type FileMetadataCondition struct {
+	Metadata      string `json:"metadata"`
+	Operator      string `json:"operator"`
+	Value         string `json:"value"`
+	UserAttribute string `json:"userAttribute"`
+}
+
+// Fulfills returns true if the node metadata matches the condition.
+func (c *FileMetadataCondition) Fulfills(_ interface{}, r *ladon.Request) bool {
+
+	value, ok := nodeMetadata(r, c.Metadata)
+	if !ok {
+		return false
+	}
+
+	reference := c.Value
+	if c.UserAttribute != "" {
+		if reference, ok = userAttribute(r, c.UserAttribute); !ok {
+			return false
+		}
+	}
+
+	return compareValues(c.Operator, value, reference)
+}
+
+// GetName returns the condition's name.
+func (c *FileMetadataCondition) GetName() string {
+	return "FileMetadataCondition"
+}
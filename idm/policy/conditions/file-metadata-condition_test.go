@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	"github.com/ory/ladon/manager/memory"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+func TestFileMetadataCondition(t *testing.T) {
+
+	Convey("Canonical file metadata tests", t, func() {
+
+		context := ladon.Context{
+			permissions.PolicyNodeMetaName:                 "report.pdf",
+			permissions.PolicyNodeMetaPath:                 "/sales/report.pdf",
+			permissions.PolicyNodeMetaExtension:            "pdf",
+			permissions.PolicyNodeMetaSize:                 "2048",
+			permissions.PolicyNodeMeta_ + "classification": "confidential",
+			permissions.PolicyNodeMeta_ + "department":     "sales",
+			permissions.PolicyUserLogin:                    "max",
+			permissions.PolicyUserAttr_ + "department":     "sales",
+		}
+
+		for _, c := range []struct {
+			condition *FileMetadataCondition
+			context   ladon.Context
+			pass      bool
+		}{
+			{condition: &FileMetadataCondition{Metadata: "classification", Operator: OperatorEquals, Value: "confidential"}, pass: true},
+			{condition: &FileMetadataCondition{Metadata: "classification", Operator: OperatorNotEquals, Value: "confidential"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "extension", Operator: OperatorIn, Value: "doc,pdf"}, pass: true},
+			{condition: &FileMetadataCondition{Metadata: "name", Operator: OperatorMatches, Value: `\.pdf$`}, pass: true},
+			{condition: &FileMetadataCondition{Metadata: "path", Operator: OperatorContains, Value: "/marketing/"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "size", Operator: OperatorGreaterThan, Value: "1024"}, pass: true},
+			{condition: &FileMetadataCondition{Metadata: "size", Operator: OperatorLowerThan, Value: "1024"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "department", Operator: OperatorEquals, UserAttribute: "department"}, pass: true},
+			{condition: &FileMetadataCondition{Metadata: "classification", Operator: OperatorEquals, UserAttribute: "department"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "department", Operator: OperatorEquals, UserAttribute: "unknown"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "unknown", Operator: OperatorEquals, Value: "confidential"}, pass: false},
+			{condition: &FileMetadataCondition{Metadata: "classification", Operator: OperatorEquals, Value: "confidential"}, context: ladon.Context{}, pass: false},
+		} {
+			ctx := context
+			if c.context != nil {
+				ctx = c.context
+			}
+			So(c.condition.Fulfills(nil, &ladon.Request{Context: ctx}), ShouldEqual, c.pass)
+		}
+	})
+}
+
+func TestFileMetadataPolicy(t *testing.T) {
+
+	Convey("Test FileMetadataPolicy", t, func() {
+
+		ladonPolicy := &ladon.DefaultPolicy{
+			ID:          "file-metadata-rule",
+			Description: "ACL Rule example, allowing read when the file and user departments match",
+			Subjects:    []string{"<.+>"},
+			Resources:   []string{"resource1"},
+			Actions:     []string{"read"},
+			Effect:      ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				permissions.PolicyNodeMetaPath: &FileMetadataCondition{
+					Metadata:      "department",
+					Operator:      OperatorEquals,
+					UserAttribute: "department",
+				},
+			},
+		}
+
+		warden := &ladon.Ladon{Manager: memory.NewMemoryManager()}
+		require.Nil(t, warden.Manager.Create(ladonPolicy))
+
+		requestOK := &ladon.Request{
+			Subject:  "max",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				permissions.PolicyNodeMetaPath:             "/sales/report.pdf",
+				permissions.PolicyNodeMeta_ + "department": "sales",
+				permissions.PolicyUserLogin:                "max",
+				permissions.PolicyUserAttr_ + "department": "sales",
+			},
+		}
+		requestNotOK := &ladon.Request{
+			Subject:  "john",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				permissions.PolicyNodeMetaPath:             "/sales/report.pdf",
+				permissions.PolicyNodeMeta_ + "department": "sales",
+				permissions.PolicyUserLogin:                "john",
+				permissions.PolicyUserAttr_ + "department": "support",
+			},
+		}
+
+		So(warden.IsAllowed(requestOK), ShouldBeNil)
+		So(warden.IsAllowed(requestNotOK), ShouldNotBeNil)
+	})
+}
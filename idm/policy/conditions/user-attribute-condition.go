@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"github.com/ory/ladon"
+)
+
+// UserAttributeCondition is a condition which is fulfilled if an attribute of the current user, taken
+// from its claims, compares to Value using Operator (see the Operator constants). Attribute is either one of
+// "login", "profile", "groupPath", "authSource" or the name of a user attribute, e.g. "department". User
+// attributes are only available if they are listed in the "userAttributes" configuration of the policy
+// service, as users cannot modify these ones themselves.
+//
+// The condition does not use the value of its key in the request context, it is never fulfilled if
+// there is no user in the context.
+type UserAttributeCondition struct {
+	Attribute string `json:"attribute"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+}
+
+// Fulfills returns true if the user attribute matches the condition.
+func (c *UserAttributeCondition) Fulfills(_ interface{}, r *ladon.Request) bool {
+
+	value, ok := userAttribute(r, c.Attribute)
+	if !ok {
+		return false
+	}
+
+	return compareValues(c.Operator, value, c.Value)
+}
+
+// GetName returns the condition's name.
+func (c *UserAttributeCondition) GetName() string {
+	return "UserAttributeCondition"
+}
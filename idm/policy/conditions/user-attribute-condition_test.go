@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	"github.com/ory/ladon/manager/memory"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+func TestUserAttributeCondition(t *testing.T) {
+
+	Convey("Canonical user attribute tests", t, func() {
+
+		context := ladon.Context{
+			permissions.PolicyUserLogin:                "max",
+			permissions.PolicyUserProfile:              "standard",
+			permissions.PolicyUserGroupPath:            "/sales/emea",
+			permissions.PolicyUserAttr_ + "level":      "3",
+			permissions.PolicyUserAttr_ + "department": "sales",
+		}
+
+		for _, c := range []struct {
+			condition *UserAttributeCondition
+			context   ladon.Context
+			pass      bool
+		}{
+			{condition: &UserAttributeCondition{Attribute: "department", Operator: OperatorEquals, Value: "sales"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "department", Value: "sales"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "department", Operator: OperatorNotEquals, Value: "sales"}, pass: false},
+			{condition: &UserAttributeCondition{Attribute: "department", Operator: OperatorIn, Value: "marketing, sales"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "groupPath", Operator: OperatorContains, Value: "/sales"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "groupPath", Operator: OperatorMatches, Value: "^/sales/"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "profile", Operator: OperatorEquals, Value: "admin"}, pass: false},
+			{condition: &UserAttributeCondition{Attribute: "login", Operator: OperatorEquals, Value: "max"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "level", Operator: OperatorGreaterThan, Value: "2"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "level", Operator: OperatorLowerThan, Value: "2"}, pass: false},
+			{condition: &UserAttributeCondition{Attribute: "unknown", Operator: OperatorNotEquals, Value: "sales"}, pass: true},
+			{condition: &UserAttributeCondition{Attribute: "department", Operator: "unknown", Value: "sales"}, pass: false},
+			{condition: &UserAttributeCondition{Attribute: "department", Operator: OperatorEquals, Value: "sales"}, context: ladon.Context{}, pass: false},
+		} {
+			ctx := context
+			if c.context != nil {
+				ctx = c.context
+			}
+			So(c.condition.Fulfills(nil, &ladon.Request{Context: ctx}), ShouldEqual, c.pass)
+		}
+	})
+}
+
+func TestUserAttributePolicy(t *testing.T) {
+
+	Convey("Test UserAttributePolicy", t, func() {
+
+		ladonPolicy := &ladon.DefaultPolicy{
+			ID:          "user-attribute-rule",
+			Description: "ACL Rule example, allowing read to the sales department only",
+			Subjects:    []string{"<.+>"},
+			Resources:   []string{"resource1"},
+			Actions:     []string{"read"},
+			Effect:      ladon.AllowAccess,
+			Conditions: ladon.Conditions{
+				permissions.PolicyUserLogin: &UserAttributeCondition{
+					Attribute: "department",
+					Operator:  OperatorEquals,
+					Value:     "sales",
+				},
+			},
+		}
+
+		warden := &ladon.Ladon{Manager: memory.NewMemoryManager()}
+		require.Nil(t, warden.Manager.Create(ladonPolicy))
+
+		requestOK := &ladon.Request{
+			Subject:  "max",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				permissions.PolicyUserLogin:                "max",
+				permissions.PolicyUserAttr_ + "department": "sales",
+			},
+		}
+		requestNotOK := &ladon.Request{
+			Subject:  "john",
+			Resource: "resource1",
+			Action:   "read",
+			Context: ladon.Context{
+				permissions.PolicyUserLogin:                "john",
+				permissions.PolicyUserAttr_ + "department": "support",
+			},
+		}
+
+		So(warden.IsAllowed(requestOK), ShouldBeNil)
+		So(warden.IsAllowed(requestNotOK), ShouldNotBeNil)
+	})
+}
@@ -42,12 +42,24 @@ func init() {
 		return new(conditions.WithinPeriodCondition)
 	}
 
-	// ladon.ConditionFactories[new(conditions.DateWithinPeriodCondition).GetName()] = func() ladon.Condition {
-	// 	return new(conditions.DateWithinPeriodCondition)
-	// }
+	ladon.ConditionFactories[new(conditions.DateWithinPeriodCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.DateWithinPeriodCondition)
+	}
 
 	ladon.ConditionFactories[new(conditions.DateAfterCondition).GetName()] = func() ladon.Condition {
 		return new(conditions.DateAfterCondition)
 	}
 
+	ladon.ConditionFactories[new(conditions.CIDRSetCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.CIDRSetCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.UserAttributeCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.UserAttributeCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.FileMetadataCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.FileMetadataCondition)
+	}
+
 }
@@ -154,6 +154,7 @@ func (h *SharesHandler) ListSharedResources(req *restful.Request, rsp *restful.R
 	}
 
 	// Build resources
+	var links []*rest.ShareLink
 	for nodeId, node := range rootNodes {
 		resource := &rest.ListSharedResourcesResponse_SharedResource{
 			Node: node,
@@ -167,6 +168,7 @@ func (h *SharesHandler) ListSharedResources(req *restful.Request, rsp *restful.R
 					Policies:                ws.Policies,
 					PoliciesContextEditable: h.IsContextEditable(ctx, ws.UUID, ws.Policies),
 				}
+				links = append(links, resource.Link)
 			} else {
 				resource.Cells = append(resource.Cells, &rest.Cell{
 					Uuid:                    ws.UUID,
@@ -179,6 +181,12 @@ func (h *SharesHandler) ListSharedResources(req *restful.Request, rsp *restful.R
 		}
 		response.Resources = append(response.Resources, resource)
 	}
+	// Load links data to report scheduled links as pending
+	if len(links) > 0 {
+		if e := share.LoadHashDocumentsData(ctx, links); e != nil {
+			log.Logger(ctx).Debug("Share List - cannot load links data", zap.Error(e))
+		}
+	}
 
 	rsp.WriteEntity(response)
 
@@ -185,6 +185,30 @@ func (h *SharesHandler) PutCell(req *restful.Request, rsp *restful.Response) {
 		}
 	}
 
+	// Share or revoke node keys for datasources encrypted with user keys
+	recipients := share.CellAclsUserLogins(ctx, shareRequest.Room.ACLs, ownerUser.Login)
+	if e := share.ShareNodeKeys(ctx, ownerUser.Login, shareRequest.Room.RootNodes, recipients); e != nil {
+		log.Logger(ctx).Error("Share: Error while sharing node keys", zap.Error(e))
+	}
+	if !wsCreated {
+		var revoked []string
+		for _, previous := range share.CellAclsUserLogins(ctx, share.AclsToCellAcls(ctx, currentAcls), ownerUser.Login) {
+			var kept bool
+			for _, r := range recipients {
+				if r == previous {
+					kept = true
+					break
+				}
+			}
+			if !kept {
+				revoked = append(revoked, previous)
+			}
+		}
+		if e := share.RevokeNodeKeys(ctx, shareRequest.Room.RootNodes, revoked); e != nil {
+			log.Logger(ctx).Error("Share: Error while revoking node keys", zap.Error(e))
+		}
+	}
+
 	log.Logger(ctx).Debug("Share Policies", zap.Any("before", workspace.Policies))
 	share.UpdatePoliciesFromAcls(ctx, workspace, currentAcls, targetAcls)
 
@@ -406,6 +430,9 @@ func (h *SharesHandler) PutShareLink(req *restful.Request, rsp *restful.Response
 			user.Password = putRequest.UpdatePassword
 			saveUser = true
 		}
+		if share.SetHiddenUserAccessStart(user, link) {
+			saveUser = true
+		}
 		if saveUser {
 			uCli := idm.NewUserServiceClient(common.ServiceGrpcNamespace_+common.ServiceUser, defaults.NewClient())
 			_, err := uCli.CreateUser(ctx, &idm.CreateUserRequest{
@@ -423,6 +450,10 @@ func (h *SharesHandler) PutShareLink(req *restful.Request, rsp *restful.Response
 		service.RestError500(req, rsp, err)
 		return
 	}
+	if e := share.ShareNodeKeys(ctx, ownerUser.Login, link.RootNodes, []string{user.Login}); e != nil {
+		log.Logger(ctx).Error("Share: Error while sharing node keys", zap.Error(e))
+	}
+	track("ShareNodeKeys")
 	if create {
 		log.Auditer(ctx).Info(
 			fmt.Sprintf("Created share link [%s]", link.Label),
@@ -25,6 +25,8 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 
 	json "github.com/pydio/cells/x/jsonx"
 
@@ -50,6 +52,7 @@ func StoreHashDocument(ctx context.Context, ownerUser *idm.User, link *rest.Shar
 		OwnerId:       ownerUser.Login,
 		TemplateName:  link.ViewTemplateName,
 		RepositoryId:  link.Uuid,
+		StartTime:     link.AccessStart,
 		ExpireTime:    link.AccessEnd,
 		DownloadLimit: link.MaxDownloads,
 		ShareType:     "minisite",
@@ -131,30 +134,86 @@ func LoadHashDocumentData(ctx context.Context, shareLink *rest.ShareLink, acls [
 	if linkDoc == nil {
 		return errors.NotFound(common.ServiceDocStore, "Cannot find link associated to this workspace")
 	}
-	shareLink.LinkHash = linkDoc.ID
 	var linkData *docstore.ShareDocument
-	if err := json.Unmarshal([]byte(linkDoc.Data), &linkData); err == nil {
-		shareLink.ViewTemplateName = linkData.TemplateName
-		shareLink.AccessEnd = linkData.ExpireTime
-		shareLink.MaxDownloads = linkData.DownloadLimit
-		shareLink.CurrentDownloads = linkData.DownloadCount
-		if linkData.PresetLogin != "" {
-			shareLink.PasswordRequired = true
-			shareLink.UserLogin = linkData.PresetLogin
-		} else {
-			shareLink.UserLogin = linkData.PreLogUser
-		}
-		shareLink.UserUuid = linkData.PreUserUuid
-		if linkData.TargetUsers != nil && len(linkData.TargetUsers) > 0 {
-			shareLink.TargetUsers = make(map[string]*rest.ShareLinkTargetUser)
-			for id, t := range linkData.TargetUsers {
-				shareLink.TargetUsers[id] = &rest.ShareLinkTargetUser{Display: t.Display, DownloadCount: t.DownloadCount}
+	if err := json.Unmarshal([]byte(linkDoc.Data), &linkData); err != nil {
+		return err
+	}
+	loadLinkData(shareLink, linkDoc.ID, linkData, acls)
+	return nil
+
+}
+
+// hashDocumentsBatchSize is the number of links searched at once by LoadHashDocumentsData, it must stay
+// below the maximum number of documents returned by a docstore search.
+const hashDocumentsBatchSize = 50
+
+// LoadHashDocumentsData loads the data of many links with one search of the shares store per batch of links,
+// instead of one search per link. ACLs are not used, so links permissions are not loaded.
+func LoadHashDocumentsData(ctx context.Context, shareLinks []*rest.ShareLink) error {
+
+	store := docstore.NewDocStoreClient(registry.GetClient(common.ServiceDocStore))
+	for start := 0; start < len(shareLinks); start += hashDocumentsBatchSize {
+		end := start + hashDocumentsBatchSize
+		if end > len(shareLinks) {
+			end = len(shareLinks)
+		}
+		links := make(map[string]*rest.ShareLink, end-start)
+		var repositories []string
+		for _, l := range shareLinks[start:end] {
+			links[l.Uuid] = l
+			repositories = append(repositories, "REPOSITORY:\""+l.Uuid+"\"")
+		}
+		streamer, er := store.ListDocuments(ctx, &docstore.ListDocumentsRequest{StoreID: common.DocStoreIdShares, Query: &docstore.DocumentQuery{
+			MetaQuery: "+(" + strings.Join(repositories, " ") + ") +SHARE_TYPE:minisite",
+		}})
+		if er != nil {
+			return er
+		}
+		for {
+			resp, e := streamer.Recv()
+			if e != nil {
+				break
+			}
+			if resp.Document == nil {
+				continue
+			}
+			var linkData *docstore.ShareDocument
+			if err := json.Unmarshal([]byte(resp.Document.Data), &linkData); err != nil {
+				continue
+			}
+			if l, ok := links[linkData.RepositoryId]; ok {
+				loadLinkData(l, resp.Document.ID, linkData, nil)
 			}
-			shareLink.RestrictToTargetUsers = linkData.RestrictToTargetUsers
 		}
+		streamer.Close()
+	}
+	return nil
+
+}
 
+// loadLinkData fills the ShareLink with the data of its hash document.
+func loadLinkData(shareLink *rest.ShareLink, linkHash string, linkData *docstore.ShareDocument, acls []*idm.ACL) {
+
+	shareLink.LinkHash = linkHash
+	shareLink.ViewTemplateName = linkData.TemplateName
+	shareLink.AccessStart = linkData.StartTime
+	shareLink.AccessEnd = linkData.ExpireTime
+	shareLink.Pending = IsPending(linkData)
+	shareLink.MaxDownloads = linkData.DownloadLimit
+	shareLink.CurrentDownloads = linkData.DownloadCount
+	if linkData.PresetLogin != "" {
+		shareLink.PasswordRequired = true
+		shareLink.UserLogin = linkData.PresetLogin
 	} else {
-		return err
+		shareLink.UserLogin = linkData.PreLogUser
+	}
+	shareLink.UserUuid = linkData.PreUserUuid
+	if linkData.TargetUsers != nil && len(linkData.TargetUsers) > 0 {
+		shareLink.TargetUsers = make(map[string]*rest.ShareLinkTargetUser)
+		for id, t := range linkData.TargetUsers {
+			shareLink.TargetUsers[id] = &rest.ShareLinkTargetUser{Display: t.Display, DownloadCount: t.DownloadCount}
+		}
+		shareLink.RestrictToTargetUsers = linkData.RestrictToTargetUsers
 	}
 
 	for _, acl := range acls {
@@ -178,8 +237,11 @@ func LoadHashDocumentData(ctx context.Context, shareLink *rest.ShareLink, acls [
 		}
 	}
 
-	return nil
+}
 
+// IsPending checks if the link has a start date in the future.
+func IsPending(linkData *docstore.ShareDocument) bool {
+	return linkData.StartTime > 0 && time.Now().Before(time.Unix(linkData.StartTime, 0))
 }
 
 func DeleteHashDocument(ctx context.Context, shareId string) error {
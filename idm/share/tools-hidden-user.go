@@ -63,6 +63,7 @@ func GetOrCreateHiddenUser(ctx context.Context, ownerUser *idm.User, link *rest.
 		if passwordHashed {
 			hiddenUser.Attributes[idm.UserAttrPassHashed] = "true"
 		}
+		SetHiddenUserAccessStart(hiddenUser, link)
 		resp, e := uClient.CreateUser(ctx, &idm.CreateUserRequest{User: hiddenUser})
 		if e != nil {
 			return nil, e
@@ -101,6 +102,42 @@ func GetOrCreateHiddenUser(ctx context.Context, ownerUser *idm.User, link *rest.
 	return
 }
 
+// SetHiddenUserAccessStart reports the link AccessStart as a condition of the hidden user policy
+// on itself, so that the user cannot log in before this date. It returns true if the user policies
+// were modified.
+func SetHiddenUserAccessStart(user *idm.User, link *rest.ShareLink) bool {
+	var conditions string
+	if link.AccessStart > 0 {
+		conditions = permissions2.AccessStartConditions(link.AccessStart)
+	}
+	subject := fmt.Sprintf("user:%s", user.Login)
+	for i, pol := range user.Policies {
+		if pol.Subject != subject || pol.Action != service.ResourcePolicyAction_READ || pol.Effect != service.ResourcePolicy_allow {
+			continue
+		}
+		if pol.JsonConditions == conditions {
+			return false
+		}
+		// Policies may be shared with the link and the user role: replace instead of modifying
+		clone := *pol
+		clone.JsonConditions = conditions
+		user.Policies = append([]*service.ResourcePolicy{}, user.Policies...)
+		user.Policies[i] = &clone
+		return true
+	}
+	if conditions == "" {
+		return false
+	}
+	user.Policies = append(user.Policies, &service.ResourcePolicy{
+		Resource:       user.Uuid,
+		Subject:        subject,
+		Action:         service.ResourcePolicyAction_READ,
+		Effect:         service.ResourcePolicy_allow,
+		JsonConditions: conditions,
+	})
+	return true
+}
+
 // UpdateACLsForHiddenUser deletes and replaces access ACLs for a hidden user.
 func UpdateACLsForHiddenUser(ctx context.Context, roleId string, workspaceId string, rootNodes []*tree.Node, permissions []rest.ShareLinkAccessType, update bool) error {
 
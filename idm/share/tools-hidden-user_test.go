@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package share
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHiddenUserAccessStart(t *testing.T) {
+
+	Convey("Scheduled links are reported on hidden users", t, func() {
+
+		user := &idm.User{Uuid: "hidden-uuid", Login: "hidden", Policies: []*service.ResourcePolicy{
+			{Resource: "hidden-uuid", Subject: "profile:standard", Action: service.ResourcePolicyAction_READ, Effect: service.ResourcePolicy_allow},
+		}}
+		start := time.Now().Add(time.Hour).Unix()
+		link := &rest.ShareLink{AccessStart: start}
+
+		So(SetHiddenUserAccessStart(user, link), ShouldBeTrue)
+		So(user.Policies, ShouldHaveLength, 2)
+		So(user.Policies[1].Subject, ShouldEqual, "user:hidden")
+		So(user.Policies[1].JsonConditions, ShouldContainSubstring, "DateAfterCondition")
+		So(permissions.IsUserPending(user), ShouldBeTrue)
+		So(IsPending(&docstore.ShareDocument{StartTime: start}), ShouldBeTrue)
+		// Nothing changed
+		So(SetHiddenUserAccessStart(user, link), ShouldBeFalse)
+
+		link.AccessStart = time.Now().Add(-time.Hour).Unix()
+		So(SetHiddenUserAccessStart(user, link), ShouldBeTrue)
+		So(permissions.IsUserPending(user), ShouldBeFalse)
+		So(IsPending(&docstore.ShareDocument{StartTime: link.AccessStart}), ShouldBeFalse)
+
+		link.AccessStart = 0
+		So(SetHiddenUserAccessStart(user, link), ShouldBeTrue)
+		So(user.Policies, ShouldHaveLength, 2)
+		So(user.Policies[1].JsonConditions, ShouldBeEmpty)
+		So(SetHiddenUserAccessStart(user, link), ShouldBeFalse)
+		So(IsPending(&docstore.ShareDocument{}), ShouldBeFalse)
+	})
+
+}
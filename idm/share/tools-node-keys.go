@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package share
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/key"
+)
+
+// CellAclsUserLogins finds the logins of the users targeted by a set of CellAcls, excluding the owner.
+// Group and role ACLs are resolved to the users they are granted to.
+func CellAclsUserLogins(ctx context.Context, roomAcls map[string]*rest.CellAcl, ownerLogin string) []string {
+	var logins []string
+	seen := map[string]struct{}{ownerLogin: {}}
+	for _, acl := range roomAcls {
+		users, e := permissions.SearchUsersForRole(ctx, acl.RoleId)
+		if e != nil {
+			log.Logger(ctx).Error("Cannot load cell users", zap.String("role", acl.RoleId), zap.Error(e))
+			continue
+		}
+		for _, u := range users {
+			if _, ok := seen[u.Login]; !ok && u.Login != "" {
+				seen[u.Login] = struct{}{}
+				logins = append(logins, u.Login)
+			}
+		}
+	}
+	return logins
+}
+
+// ShareNodeKeys gives recipients access to the files of the root nodes stored on datasources encrypted with
+// user keys: each file key is unwrapped with the owner keyring and wrapped again with the recipient keyring.
+// Recipients whose keyring does not exist yet (USER_PWD mode, never logged in) are ignored.
+func ShareNodeKeys(ctx context.Context, ownerLogin string, rootNodes []*tree.Node, recipients []string) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	return walkUserEncryptedLeaves(ctx, rootNodes, func(leaf *tree.Node, keyID string) error {
+		nodeKeyClient := encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, defaults.NewClient())
+		infoResp, e := nodeKeyClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{NodeId: leaf.Uuid, UserId: ownerLogin})
+		if e != nil {
+			if code := errors.Parse(e.Error()).Code; code == 404 || code == 403 {
+				// Owner has no key for this node, nothing to share
+				return nil
+			}
+			return e
+		}
+		nodeKey := infoResp.GetNodeInfo().GetNodeKey()
+		plainKey, e := key.GetUserKeyTool(ownerLogin, nil).GetDecrypted(ctx, keyID, nodeKey.KeyData)
+		if e != nil {
+			return e
+		}
+		for _, recipient := range recipients {
+			wrapped, er := key.GetUserKeyTool(recipient, nil).GetEncrypted(ctx, keyID, plainKey)
+			if er != nil {
+				if errors.Parse(er.Error()).Code == 404 {
+					log.Logger(ctx).Warn("Recipient has no keyring yet, cannot share node key", zap.String("user", recipient), leaf.ZapPath())
+					continue
+				}
+				return er
+			}
+			if _, er := nodeKeyClient.SetNodeKey(ctx, &encryption.SetNodeKeyRequest{NodeKey: &encryption.NodeKey{
+				NodeId:  leaf.Uuid,
+				UserId:  recipient,
+				OwnerId: nodeKey.OwnerId,
+				KeyData: wrapped,
+			}}); er != nil {
+				return er
+			}
+		}
+		return nil
+	})
+}
+
+// RevokeNodeKeys removes the keys shared with users for the files of the root nodes stored on datasources
+// encrypted with user keys.
+func RevokeNodeKeys(ctx context.Context, rootNodes []*tree.Node, users []string) error {
+	if len(users) == 0 {
+		return nil
+	}
+	return walkUserEncryptedLeaves(ctx, rootNodes, func(leaf *tree.Node, keyID string) error {
+		nodeKeyClient := encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, defaults.NewClient())
+		for _, u := range users {
+			if _, e := nodeKeyClient.DeleteNodeSharedKey(ctx, &encryption.DeleteNodeSharedKeyRequest{NodeId: leaf.Uuid, UserId: u}); e != nil {
+				return e
+			}
+		}
+		return nil
+	})
+}
+
+// walkUserEncryptedLeaves calls f on every file under the root nodes that belong to a datasource
+// encrypted with USER or USER_PWD mode, passing the keyring ID to use.
+func walkUserEncryptedLeaves(ctx context.Context, rootNodes []*tree.Node, f func(leaf *tree.Node, keyID string) error) error {
+
+	sources := config.ListSourcesFromConfig()
+	treeClient := tree.NewNodeProviderClient(common.ServiceGrpcNamespace_+common.ServiceTree, defaults.NewClient())
+	for _, root := range rootNodes {
+		resp, e := treeClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Uuid: root.Uuid}})
+		if e != nil {
+			return e
+		}
+		node := resp.Node
+		ds, ok := sources[node.GetStringMeta(common.MetaNamespaceDatasourceName)]
+		if !ok {
+			continue
+		}
+		var keyID string
+		switch ds.EncryptionMode {
+		case object.EncryptionMode_USER:
+			keyID = key.UserKeyringID
+		case object.EncryptionMode_USER_PWD:
+			keyID = key.UserPwdKeyringID
+		default:
+			continue
+		}
+		if node.IsLeaf() {
+			if e := f(node, keyID); e != nil {
+				return e
+			}
+			continue
+		}
+		stream, e := treeClient.ListNodes(ctx, &tree.ListNodesRequest{Node: node, Recursive: true, FilterType: tree.NodeType_LEAF})
+		if e != nil {
+			return e
+		}
+		var leaves []*tree.Node
+		for {
+			r, er := stream.Recv()
+			if er != nil {
+				break
+			}
+			if r == nil || r.Node == nil || !r.Node.IsLeaf() {
+				continue
+			}
+			leaves = append(leaves, r.Node)
+		}
+		stream.Close()
+		for _, leaf := range leaves {
+			if e := f(leaf, keyID); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
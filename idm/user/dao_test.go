@@ -11,6 +11,7 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/micro/go-micro/errors"
 
+	"github.com/pydio/cells/common/auth"
 	"github.com/pydio/cells/common/proto/idm"
 	service "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/sql"
@@ -614,3 +615,41 @@ func TestQueryBuilder(t *testing.T) {
 		//So(s, ShouldEqual, "((t.uuid = n.uuid and (n.name='user1' and n.leaf = 1)) OR (t.uuid = n.uuid and (n.name='user2' and n.leaf = 1))) AND (t.uuid = n.uuid and (n.name='user3' and n.leaf = 1))")
 	})
 }
+
+func TestBindRehash(t *testing.T) {
+
+	Convey("Legacy hashes are upgraded at bind", t, func() {
+
+		legacy := auth.PydioPW{
+			PBKDF2_HASH_ALGORITHM: "sha256",
+			PBKDF2_ITERATIONS:     1000,
+			PBKDF2_SALT_BYTE_SIZE: 32,
+			PBKDF2_HASH_BYTE_SIZE: 24,
+			HASH_SECTIONS:         4,
+			HASH_ALGORITHM_INDEX:  0,
+			HASH_ITERATION_INDEX:  1,
+			HASH_SALT_INDEX:       2,
+			HASH_PBKDF2_INDEX:     3,
+		}
+		_, _, e := mockDAO.Add(&idm.User{
+			Login:      "legacyuser",
+			Password:   legacy.CreateHash("legacyPass"),
+			Attributes: map[string]string{idm.UserAttrPassHashed: "true"},
+		})
+		So(e, ShouldBeNil)
+
+		u, e := mockDAO.Bind("legacyuser", "legacyPass")
+		So(e, ShouldBeNil)
+		So(u.Password, ShouldStartWith, "$argon2id$")
+
+		users := new([]interface{})
+		q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: "legacyuser"})
+		So(mockDAO.Search(&service.Query{SubQueries: []*any.Any{q}}, users), ShouldBeNil)
+		So(*users, ShouldHaveLength, 1)
+		So((*users)[0].(*idm.User).Password, ShouldEqual, u.Password)
+
+		u, e = mockDAO.Bind("legacyuser", "legacyPass")
+		So(e, ShouldBeNil)
+		So(u, ShouldNotBeNil)
+	})
+}
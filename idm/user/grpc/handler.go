@@ -97,6 +97,12 @@ func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, re
 	dao := servicecontext.GetDAO(ctx).(user.DAO)
 
 	passChange := req.User.Password
+	if passChange != "" && !req.User.IsGroup {
+		if err := h.checkPasswordPolicy(dao, req.User); err != nil {
+			log.Logger(ctx).Error("password rejected for user "+req.User.Login, req.User.ZapUuid(), zap.Error(err))
+			return err
+		}
+	}
 	// Create or update user
 	newUser, createdNodes, err := dao.Add(req.User)
 	if err != nil {
@@ -202,6 +208,45 @@ func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, re
 	return nil
 }
 
+// checkPasswordPolicy validates a new password against the configured policy, and updates the passwords history.
+// Already hashed passwords are not checked, nor passwords of hidden users (used by public links). For existing
+// users, the hidden flag is read from the stored user, never from the request.
+func (h *Handler) checkPasswordPolicy(dao user.DAO, u *idm.User) error {
+	if u.Attributes[idm.UserAttrPassHashed] == "true" {
+		return nil
+	}
+	var existing *idm.User
+	if u.Uuid != "" {
+		q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Uuid: u.Uuid})
+		results := new([]interface{})
+		if err := dao.Search(&service.Query{SubQueries: []*any.Any{q}}, results); err != nil {
+			return err
+		}
+		if len(*results) > 0 {
+			existing = (*results)[0].(*idm.User)
+		}
+	}
+	if (existing != nil && existing.Attributes["hidden"] == "true") || (existing == nil && u.Attributes["hidden"] == "true") {
+		return nil
+	}
+	policy := user.LoadPasswordPolicy()
+	if err := policy.Validate(u.Password); err != nil {
+		return err
+	}
+	if policy.HistorySize <= 0 || existing == nil {
+		return nil
+	}
+	history, err := policy.CheckHistory(u.Password, existing)
+	if err != nil {
+		return err
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string]string)
+	}
+	u.Attributes[user.UserAttrPasswordHistory] = history
+	return nil
+}
+
 // DeleteUser from database
 func (h *Handler) DeleteUser(ctx context.Context, req *idm.DeleteUserRequest, response *idm.DeleteUserResponse) error {
 	if servicecontext.GetDAO(ctx) == nil {
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package user
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+	json "github.com/pydio/cells/x/jsonx"
+)
+
+const (
+	// UserAttrPasswordHistory stores the hashes of the previous passwords of a user, most recent first
+	UserAttrPasswordHistory = idm.UserAttrPrivatePrefix + "password_history"
+
+	errPasswordPolicy = "password.policy"
+)
+
+var (
+	breached     map[string]struct{}
+	breachedPath string
+	breachedTime time.Time
+	breachedLock sync.Mutex
+)
+
+// PasswordPolicy defines the rules new passwords must follow. It is read from the "passwordPolicy"
+// section of the users service configuration, and is disabled by default.
+type PasswordPolicy struct {
+	MinLength      int  `json:"minLength"`
+	RequireUpper   bool `json:"requireUpper"`
+	RequireLower   bool `json:"requireLower"`
+	RequireDigit   bool `json:"requireDigit"`
+	RequireSpecial bool `json:"requireSpecial"`
+	// BreachedList is the path to a file listing forbidden passwords, one per line, either in clear or as
+	// SHA-1 hex hashes optionally followed by ":count" (the format of the Pwned Passwords downloads).
+	// The list is loaded in memory.
+	BreachedList string `json:"breachedList"`
+	// HistorySize is the number of last passwords, including the current one, that cannot be reused
+	HistorySize int `json:"historySize"`
+}
+
+// LoadPasswordPolicy reads the current password policy from the configuration.
+func LoadPasswordPolicy() *PasswordPolicy {
+	p := &PasswordPolicy{}
+	config.Get("services", common.ServiceGrpcNamespace_+common.ServiceUser, "passwordPolicy").Scan(p)
+	return p
+}
+
+// Validate checks the length, the complexity and the breached list rules.
+func (p *PasswordPolicy) Validate(password string) error {
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		return errors.Forbidden(errPasswordPolicy, "Password must be at least %d characters long", p.MinLength)
+	}
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			special = true
+		}
+	}
+	var missing []string
+	if p.RequireUpper && !upper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSpecial && !special {
+		missing = append(missing, "a special character")
+	}
+	if len(missing) > 0 {
+		return errors.Forbidden(errPasswordPolicy, "Password must contain %s", strings.Join(missing, ", "))
+	}
+	if p.BreachedList != "" {
+		list, e := loadBreachedList(p.BreachedList)
+		if e != nil {
+			return errors.InternalServerError(errPasswordPolicy, "cannot load breached passwords list: %s", e.Error())
+		}
+		if _, ok := list[sha1Hex(password)]; ok {
+			return errors.Forbidden(errPasswordPolicy, "This password appears in a list of breached passwords, please choose another one")
+		}
+	}
+	return nil
+}
+
+// CheckHistory verifies that a new password does not match the current hash nor the hashes stored
+// in the history of an existing user, and returns the history to store along with the new password.
+func (p *PasswordPolicy) CheckHistory(password string, existing *idm.User) (string, error) {
+	if p.HistorySize <= 0 || existing == nil || existing.Password == "" {
+		return "", nil
+	}
+	var history []string
+	if h := existing.GetAttributes()[UserAttrPasswordHistory]; h != "" {
+		json.Unmarshal([]byte(h), &history)
+	}
+	history = append([]string{existing.Password}, history...)
+	if len(history) > p.HistorySize {
+		history = history[:p.HistorySize]
+	}
+	for _, hash := range history {
+		if ok, _ := hasher.CheckDBKDF2PydioPwd(password, hash); ok {
+			return "", errors.Forbidden(errPasswordPolicy, "Password was already used recently, please choose another one")
+		}
+	}
+	// Current password is in the index, keep only previous ones
+	if len(history) >= p.HistorySize {
+		history = history[:p.HistorySize-1]
+	}
+	data, _ := json.Marshal(history)
+	return string(data), nil
+}
+
+func loadBreachedList(path string) (map[string]struct{}, error) {
+	breachedLock.Lock()
+	defer breachedLock.Unlock()
+	stat, e := os.Stat(path)
+	if e != nil {
+		return nil, e
+	}
+	if breached != nil && breachedPath == path && breachedTime.Equal(stat.ModTime()) {
+		return breached, nil
+	}
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	list := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if h := strings.SplitN(line, ":", 2)[0]; isSha1Hex(h) {
+			list[strings.ToUpper(h)] = struct{}{}
+		} else {
+			list[sha1Hex(line)] = struct{}{}
+		}
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, fmt.Errorf("reading %s: %v", path, e)
+	}
+	breached, breachedPath, breachedTime = list, path, stat.ModTime()
+	return breached, nil
+}
+
+func sha1Hex(s string) string {
+	h := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+func isSha1Hex(s string) bool {
+	if len(s) != 2*sha1.Size {
+		return false
+	}
+	_, e := hex.DecodeString(s)
+	return e == nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package user
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pydio/cells/common/proto/idm"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+
+	Convey("Test length and complexity", t, func() {
+		p := &PasswordPolicy{}
+		So(p.Validate("a"), ShouldBeNil)
+
+		p = &PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}
+		So(p.Validate("Sh0rt!"), ShouldNotBeNil)
+		So(p.Validate("longenough"), ShouldNotBeNil)
+		So(p.Validate("L0ngenough"), ShouldNotBeNil)
+		So(p.Validate("L0ngenough!"), ShouldBeNil)
+		So(p.Validate("Émile-1234"), ShouldBeNil)
+	})
+
+	Convey("Test breached passwords list", t, func() {
+		f, e := ioutil.TempFile("", "breached")
+		So(e, ShouldBeNil)
+		defer os.Remove(f.Name())
+		// Second line is the SHA-1 of "P@ssw0rd"
+		f.WriteString("Password1\n21BD12DC183F740EE76F27B78EB39C8AD972A757:45000\n")
+		f.Close()
+
+		p := &PasswordPolicy{BreachedList: f.Name()}
+		So(p.Validate("Password1"), ShouldNotBeNil)
+		So(p.Validate("P@ssw0rd"), ShouldNotBeNil)
+		So(p.Validate("correct horse battery staple"), ShouldBeNil)
+
+		p.BreachedList = f.Name() + ".missing"
+		So(p.Validate("correct horse battery staple"), ShouldNotBeNil)
+	})
+
+	Convey("Test passwords history", t, func() {
+		p := &PasswordPolicy{HistorySize: 3}
+		u := &idm.User{Password: hasher.CreateHash("first")}
+
+		history, e := p.CheckHistory("first", u)
+		So(e, ShouldNotBeNil)
+
+		history, e = p.CheckHistory("second", u)
+		So(e, ShouldBeNil)
+		u = &idm.User{Password: hasher.CreateHash("second"), Attributes: map[string]string{UserAttrPasswordHistory: history}}
+
+		history, e = p.CheckHistory("third", u)
+		So(e, ShouldBeNil)
+		u = &idm.User{Password: hasher.CreateHash("third"), Attributes: map[string]string{UserAttrPasswordHistory: history}}
+
+		_, e = p.CheckHistory("first", u)
+		So(e, ShouldNotBeNil)
+
+		history, e = p.CheckHistory("fourth", u)
+		So(e, ShouldBeNil)
+		u = &idm.User{Password: hasher.CreateHash("fourth"), Attributes: map[string]string{UserAttrPasswordHistory: history}}
+
+		// First password is out of the history now
+		_, e = p.CheckHistory("first", u)
+		So(e, ShouldBeNil)
+		_, e = p.CheckHistory("second", u)
+		So(e, ShouldNotBeNil)
+	})
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
 	"github.com/pydio/cells/common/proto/front"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/jobs"
@@ -49,6 +50,8 @@ import (
 	service2 "github.com/pydio/cells/common/service/proto"
 	"github.com/pydio/cells/common/service/resources"
 	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/key"
+	"github.com/pydio/cells/idm/user"
 	"github.com/pydio/cells/idm/user/grpc"
 )
 
@@ -358,6 +361,7 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 		}
 	}
 	var existingAcls []*idm.ACL
+	var keyringPasswords []string
 	ctxLogin, ctxClaims := permissions.FindUserNameInContext(ctx)
 	if update != nil {
 		// Check User Policies
@@ -391,6 +395,7 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 				service.RestError401(req, rsp, err)
 				return
 			}
+			keyringPasswords = []string{inputUser.OldPassword, inputUser.Password}
 		}
 		// Load current ACLs for personal role
 		for _, r := range update.Roles {
@@ -398,6 +403,15 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 				existingAcls = permissions.GetACLsForRoles(ctx, []*idm.Role{r}, &idm.ACLAction{Name: "parameter:*"})
 			}
 		}
+		// The hidden flag cannot be changed by an update
+		if hidden, ok := update.Attributes["hidden"]; ok {
+			if inputUser.Attributes == nil {
+				inputUser.Attributes = map[string]string{}
+			}
+			inputUser.Attributes["hidden"] = hidden
+		} else {
+			delete(inputUser.Attributes, "hidden")
+		}
 		// Put back the pydio: attributes
 		if update.Attributes != nil {
 			for k, v := range update.Attributes {
@@ -411,6 +425,26 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 		}
 	}
 
+	// Attributes checked by policies conditions can only be modified by administrators
+	if ctxClaims.Profile != common.PydioProfileAdmin {
+		for _, name := range permissions.PolicyUserAttributes() {
+			var stored string
+			if update != nil {
+				stored = update.Attributes[name]
+			}
+			if value, ok := inputUser.Attributes[name]; ok && value != stored {
+				service.RestError403(req, rsp, fmt.Errorf("you are not allowed to modify the attribute %s", name))
+				return
+			}
+			if stored != "" {
+				if inputUser.Attributes == nil {
+					inputUser.Attributes = map[string]string{}
+				}
+				inputUser.Attributes[name] = stored
+			}
+		}
+	}
+
 	// Check specific frontend USER_CREATE_USERS permission
 	var isHidden bool
 	if h, o := inputUser.Attributes["hidden"]; o && h == "true" {
@@ -441,6 +475,14 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 			service.RestError403(req, rsp, fmt.Errorf("you are not allowed to use this attribute"))
 			return
 		}
+		// Check password policy, history is checked by the users service. The hidden attribute is sent by
+		// the client here, so it cannot exempt the password from the policy.
+		if inputUser.Password != "" {
+			if err := user.LoadPasswordPolicy().Validate(inputUser.Password); err != nil {
+				service.RestErrorDetect(req, rsp, err)
+				return
+			}
+		}
 	}
 
 	var acls []*idm.ACL
@@ -476,10 +518,27 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 		User: &inputUser,
 	})
 	if er != nil {
-		service.RestError500(req, rsp, er)
+		service.RestErrorDetect(req, rsp, er)
 		return
 	}
 
+	if len(keyringPasswords) == 2 {
+		// Passphrase protected keyring follows user own password
+		keyCli := encryption.NewUserKeyStoreClient(common.ServiceGrpcNamespace_+common.ServiceUserKey, defaults.NewClient())
+		if _, e := keyCli.ChangeUserKeyPassword(ctx, &encryption.ChangeUserKeyPasswordRequest{
+			UserLogin:   inputUser.Login,
+			OldPassword: keyringPasswords[0],
+			NewPassword: keyringPasswords[1],
+		}); e != nil && errors.Parse(e.Error()).Code != 404 {
+			log.Logger(ctx).Error("Cannot update keyring passphrase", zap.String(common.KEY_USERNAME, inputUser.Login), zap.Error(e))
+		}
+	} else if update != nil && inputUser.Password != "" {
+		// Password reset by an admin: passphrase protected keyring is still sealed with the previous password
+		if e := key.RequireUserKeyRecovery(ctx, inputUser.Login); e != nil {
+			log.Logger(ctx).Error("Cannot flag keyring for recovery", zap.String(common.KEY_USERNAME, inputUser.Login), zap.Error(e))
+		}
+	}
+
 	if update == nil {
 		var newRole *idm.Role
 		if inputUser.IsGroup {
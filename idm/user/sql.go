@@ -34,6 +34,7 @@ import (
 	"github.com/pydio/packr"
 	migrate "github.com/rubenv/sql-migrate"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 
@@ -65,6 +66,7 @@ var (
 		"DeleteUserRoles":  `delete from idm_user_roles where uuid = ?`,
 		"DeleteRoleById":   `delete from idm_user_roles where role = ?`,
 		"TouchUser":        `update idm_user_idx_tree set mtime = ? where uuid = ?`,
+		"UpdatePassword":   `update idm_user_idx_tree set etag = ? where uuid = ?`,
 		//"DeleteAttsClean":      `delete from idm_user_attributes where uuid not in (select uuid from idm_user_idx_tree)`,
 		//"DeleteUserRolesClean": `delete from idm_user_roles where uuid not in (select uuid from idm_user_idx_tree)`,
 	}
@@ -102,6 +104,11 @@ var (
 		HASH_ITERATION_INDEX:  1,
 		HASH_SALT_INDEX:       2,
 		HASH_PBKDF2_INDEX:     3,
+		Algorithm:             auth.HashAlgorithmArgon2id,
+		Argon2Time:            1,
+		Argon2Memory:          64 * 1024,
+		Argon2Threads:         4,
+		BcryptCost:            12,
 	}
 )
 
@@ -160,6 +167,30 @@ func (s *sqlimpl) Init(options configx.Values) error {
 		s.loginCI = true
 	}
 
+	// Hashing parameters, existing hashes are upgraded at next successful Bind
+	hashing := options.Val("passwordHashing")
+	switch algo := hashing.Val("algorithm").Default(auth.HashAlgorithmArgon2id).String(); algo {
+	case auth.HashAlgorithmArgon2id, auth.HashAlgorithmBcrypt, auth.HashAlgorithmPBKDF2:
+		hasher.Algorithm = algo
+	default:
+		return fmt.Errorf("unsupported password hashing algorithm %s", algo)
+	}
+	if t := hashing.Val("argon2Time").Default(1).Int(); t > 0 {
+		hasher.Argon2Time = uint32(t)
+	}
+	if m := hashing.Val("argon2Memory").Default(64 * 1024).Int(); m > 0 {
+		hasher.Argon2Memory = uint32(m)
+	}
+	if p := hashing.Val("argon2Threads").Default(4).Int(); p > 0 && p < 256 {
+		hasher.Argon2Threads = uint8(p)
+	}
+	if c := hashing.Val("bcryptCost").Default(12).Int(); c >= bcrypt.MinCost && c <= bcrypt.MaxCost {
+		hasher.BcryptCost = c
+	}
+	if i := hashing.Val("pbkdf2Iterations").Default(1000).Int(); i > 0 {
+		hasher.PBKDF2_ITERATIONS = i
+	}
+
 	return nil
 }
 
@@ -429,11 +460,15 @@ func (s *sqlimpl) Bind(userName string, password string) (user *idm.User, e erro
 	// Check password
 	valid, _ := hasher.CheckDBKDF2PydioPwd(password, hashedPass)
 	if valid {
+		if hasher.NeedsRehash(hashedPass) {
+			s.rehash(user, password)
+		}
 		return user, nil
 	}
 	// Check with legacy format (coming from PHP, Salt []byte is built differently)
 	valid, _ = hasher.CheckDBKDF2PydioPwd(password, hashedPass, true)
 	if valid {
+		s.rehash(user, password)
 		return user, nil
 	}
 
@@ -441,6 +476,23 @@ func (s *sqlimpl) Bind(userName string, password string) (user *idm.User, e erro
 
 }
 
+// rehash replaces the stored hash of a user with a hash using the current algorithm and parameters.
+// Failures are only logged, as the user was successfully authenticated.
+func (s *sqlimpl) rehash(user *idm.User, password string) {
+	st, er := s.GetStmt("UpdatePassword")
+	if er != nil {
+		log.Logger(context.Background()).Error("cannot upgrade password hash", user.ZapLogin(), zap.Error(er))
+		return
+	}
+	newHash := hasher.CreateHash(password)
+	if _, er := st.Exec(newHash, user.Uuid); er != nil {
+		log.Logger(context.Background()).Error("cannot upgrade password hash", user.ZapLogin(), zap.Error(er))
+		return
+	}
+	user.Password = newHash
+	log.Logger(context.Background()).Debug("upgraded password hash", user.ZapLogin())
+}
+
 func (s *sqlimpl) TouchUser(userUuid string) error {
 
 	st, er := s.GetStmt("TouchUser")
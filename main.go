@@ -100,9 +100,12 @@ import (
 	_ "github.com/pydio/cells/scheduler/actions/archive"
 	_ "github.com/pydio/cells/scheduler/actions/changes"
 	_ "github.com/pydio/cells/scheduler/actions/cmd"
+	_ "github.com/pydio/cells/scheduler/actions/datasource"
+	_ "github.com/pydio/cells/scheduler/actions/encryption"
 	_ "github.com/pydio/cells/scheduler/actions/idm"
 	_ "github.com/pydio/cells/scheduler/actions/images"
 	_ "github.com/pydio/cells/scheduler/actions/scheduler"
+	_ "github.com/pydio/cells/scheduler/actions/snapshot"
 	_ "github.com/pydio/cells/scheduler/actions/tree"
 
 	// ETL Actions and stores
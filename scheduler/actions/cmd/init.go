@@ -39,4 +39,12 @@ func init() {
 		return &ResyncAction{}
 	})
 
+	manager.Register(webhookActionName, func() actions.ConcreteAction {
+		return &WebhookAction{}
+	})
+
+	manager.Register(sftpMirrorActionName, func() actions.ConcreteAction {
+		return &SftpMirrorAction{}
+	})
+
 }
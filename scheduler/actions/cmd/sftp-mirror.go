@@ -0,0 +1,271 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	json "github.com/pydio/cells/x/jsonx"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/sync/endpoints/cells"
+	"github.com/pydio/cells/common/sync/endpoints/sftp"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/sync/task"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	sftpMirrorActionName = "actions.cmd.sftp-mirror"
+)
+
+// SftpMirrorAction mirrors a folder of a remote SFTP server into a Cells folder, using a one-shot sync.
+type SftpMirrorAction struct {
+	host       string
+	user       string
+	password   string
+	privateKey string
+	hostKey    string
+	remotePath string
+	targetPath string
+	dryRun     bool
+}
+
+func (c *SftpMirrorAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              sftpMirrorActionName,
+		Category:        actions.ActionCategoryPutGet,
+		Label:           "SFTP Mirror",
+		Icon:            "folder-sync",
+		Description:     "Mirror a folder of a remote SFTP server into a Cells folder. Files deleted on the server are also deleted in Cells.",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *SftpMirrorAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "host",
+					Type:        forms.ParamString,
+					Label:       "Host",
+					Description: "SFTP server address, port defaults to 22",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "hostKey",
+					Type:        forms.ParamString,
+					Label:       "Host Key",
+					Description: "Public key of the server in authorized_keys format, used to verify its identity",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "user",
+					Type:        forms.ParamString,
+					Label:       "User",
+					Description: "Login on the SFTP server",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "password",
+					Type:        forms.ParamPassword,
+					Label:       "Password",
+					Description: "Password on the SFTP server",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "privateKey",
+					Type:        forms.ParamTextarea,
+					Label:       "Private Key",
+					Description: "PEM-encoded private key, used instead of the password",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "remotePath",
+					Type:        forms.ParamString,
+					Label:       "Remote Path",
+					Description: "Folder to mirror on the SFTP server",
+					Default:     "/",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "targetPath",
+					Type:        forms.ParamString,
+					Label:       "Target Path",
+					Description: "Existing Cells folder receiving the files, starting with a workspace slug",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "dry-run",
+					Type:        forms.ParamBool,
+					Label:       "Dry Run",
+					Description: "Compute the changes without applying them",
+					Default:     nil,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns the unique identifier of this action
+func (c *SftpMirrorAction) GetName() string {
+	return sftpMirrorActionName
+}
+
+// Init passes parameters
+func (c *SftpMirrorAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.host = action.Parameters["host"]
+	c.hostKey = action.Parameters["hostKey"]
+	c.user = action.Parameters["user"]
+	c.password = action.Parameters["password"]
+	c.privateKey = action.Parameters["privateKey"]
+	c.remotePath = action.Parameters["remotePath"]
+	c.targetPath = action.Parameters["targetPath"]
+	if c.host == "" || c.hostKey == "" || c.user == "" || c.targetPath == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameters for SFTP Mirror action: host, hostKey, user and targetPath are required")
+	}
+	if c.password == "" && c.privateKey == "" {
+		return errors.BadRequest(common.ServiceTasks, "please provide either a password or a private key for SFTP Mirror action")
+	}
+	if dRun, ok := action.Parameters["dry-run"]; ok && dRun == "true" {
+		c.dryRun = true
+	}
+	return nil
+}
+
+// clientConfig builds the SSH configuration, the server identity is always checked against the provided host key.
+func (c *SftpMirrorAction) clientConfig(ctx context.Context, input jobs.ActionMessage) (string, *ssh.ClientConfig, error) {
+	hostKey, _, _, _, e := ssh.ParseAuthorizedKey([]byte(jobs.EvaluateFieldStr(ctx, input, c.hostKey)))
+	if e != nil {
+		return "", nil, fmt.Errorf("cannot parse host key: %s", e.Error())
+	}
+	var auth ssh.AuthMethod
+	if c.privateKey != "" {
+		signer, e := ssh.ParsePrivateKey([]byte(c.privateKey))
+		if e != nil {
+			return "", nil, fmt.Errorf("cannot parse private key: %s", e.Error())
+		}
+		auth = ssh.PublicKeys(signer)
+	} else {
+		auth = ssh.Password(c.password)
+	}
+	addr := jobs.EvaluateFieldStr(ctx, input, c.host)
+	if _, _, e := net.SplitHostPort(addr); e != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+	return addr, &ssh.ClientConfig{
+		User:            jobs.EvaluateFieldStr(ctx, input, c.user),
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         30 * time.Second,
+	}, nil
+}
+
+// Run the actual action code
+func (c *SftpMirrorAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	addr, config, e := c.clientConfig(ctx, input)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	remotePath := jobs.EvaluateFieldStr(ctx, input, c.remotePath)
+	targetPath := jobs.EvaluateFieldStr(ctx, input, c.targetPath)
+
+	// Source is only browsed: no folder identifiers are written on the remote server
+	source, e := sftp.NewClient(addr, config, remotePath, model.EndpointOptions{BrowseOnly: true})
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cannot connect to SFTP server "+addr, zap.Error(e))
+		return input.WithError(e), e
+	}
+	defer source.Close()
+	target := cells.NewLocal(targetPath, cells.Options{})
+
+	s := task.NewSync(source, target, model.DirectionRight)
+	statuses := make(chan model.Status)
+	done := make(chan interface{})
+	s.SetupEventsChan(statuses, done, nil)
+	s.Start(ctx, false)
+	defer s.Shutdown()
+
+	finished := make(chan interface{}, 1)
+	go func() {
+		for {
+			select {
+			case status := <-statuses:
+				if status.IsError() && status.Error() != nil {
+					log.TasksLogger(ctx).Error(status.String(), zap.Error(status.Error()))
+				} else if status.String() != "" {
+					channels.StatusMsg <- status.String()
+				}
+				if !status.AtomicProgress() && status.Progress() > 0 {
+					channels.Progress <- status.Progress()
+				}
+			case data := <-done:
+				finished <- data
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Mirroring %s from %s to %s", remotePath, source.GetEndpointInfo().URI, targetPath))
+	result, e := s.Run(ctx, c.dryRun, false)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Error during SFTP mirror", zap.Error(e))
+		return input.WithError(e), e
+	}
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		return input.WithError(ctx.Err()), ctx.Err()
+	}
+	stats := result.Stats()
+	log.TasksLogger(ctx).Info("SFTP mirror completed", zap.Any("stats", stats))
+	jsonBody, _ := json.Marshal(stats)
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:  true,
+		JsonBody: jsonBody,
+	})
+	return input, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+func TestSftpMirrorAction_GetName(t *testing.T) {
+	Convey("Test GetName", t, func() {
+		action := &SftpMirrorAction{}
+		So(action.GetName(), ShouldEqual, sftpMirrorActionName)
+	})
+}
+
+func TestSftpMirrorAction_Init(t *testing.T) {
+
+	Convey("Test Init", t, func() {
+
+		pub, _, _ := ed25519.GenerateKey(rand.Reader)
+		sshPub, _ := ssh.NewPublicKey(pub)
+		hostKey := string(ssh.MarshalAuthorizedKey(sshPub))
+
+		action := &SftpMirrorAction{}
+		job := &jobs.Job{}
+		So(action.Init(job, nil, &jobs.Action{}), ShouldNotBeNil)
+
+		// Missing credentials
+		e := action.Init(job, nil, &jobs.Action{Parameters: map[string]string{
+			"host":       "sftp.example.com",
+			"hostKey":    hostKey,
+			"user":       "partner",
+			"targetPath": "common-files/drops",
+		}})
+		So(e, ShouldNotBeNil)
+
+		e = action.Init(job, nil, &jobs.Action{Parameters: map[string]string{
+			"host":       "sftp.example.com",
+			"hostKey":    hostKey,
+			"user":       "partner",
+			"password":   "secret",
+			"targetPath": "common-files/drops",
+			"dry-run":    "true",
+		}})
+		So(e, ShouldBeNil)
+		So(action.dryRun, ShouldBeTrue)
+
+		addr, config, e := action.clientConfig(context.Background(), jobs.ActionMessage{})
+		So(e, ShouldBeNil)
+		So(addr, ShouldEqual, "sftp.example.com:22")
+		So(config.User, ShouldEqual, "partner")
+		So(config.HostKeyCallback("sftp.example.com:22", nil, sshPub), ShouldBeNil)
+		otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+		otherSshPub, _ := ssh.NewPublicKey(otherPub)
+		So(config.HostKeyCallback("sftp.example.com:22", nil, otherSshPub), ShouldNotBeNil)
+
+		// Invalid keys are reported
+		action.hostKey = "invalid"
+		_, _, e = action.clientConfig(context.Background(), jobs.ActionMessage{})
+		So(e, ShouldNotBeNil)
+		action.hostKey = hostKey
+		action.privateKey = "invalid"
+		_, _, e = action.clientConfig(context.Background(), jobs.ActionMessage{})
+		So(e, ShouldNotBeNil)
+
+	})
+}
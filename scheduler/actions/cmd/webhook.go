@@ -0,0 +1,333 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	json "github.com/pydio/cells/x/jsonx"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	webhookActionName = "actions.cmd.webhook"
+)
+
+const (
+	webhookDefaultSignatureHeader = "X-Cells-Signature"
+	webhookDefaultContentType     = "application/json"
+	webhookDefaultTimeout         = 30 * time.Second
+	// webhookMaxResponseSize caps the response body kept in the action output
+	webhookMaxResponseSize = 1024 * 1024
+)
+
+// WebhookAction sends the input message to a remote HTTP endpoint
+type WebhookAction struct {
+	URL             string
+	Method          string
+	Template        string
+	ContentType     string
+	Headers         string
+	Secret          string
+	SignatureHeader string
+	Timeout         string
+	JobID           string
+}
+
+func (w *WebhookAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              webhookActionName,
+		Label:           "Webhook",
+		Category:        actions.ActionCategoryNotify,
+		Icon:            "webhook",
+		Description:     "Send the input message to a remote HTTP endpoint, optionally signed with a shared secret",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (w *WebhookAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "url",
+					Type:        forms.ParamString,
+					Label:       "URL",
+					Description: "Remote endpoint receiving the callback",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "method",
+					Type:        forms.ParamSelect,
+					Label:       "Method",
+					Description: "HTTP method used to send the request",
+					Default:     http.MethodPost,
+					Mandatory:   false,
+					Editable:    true,
+					ChoicePresetList: []map[string]string{
+						{http.MethodPost: http.MethodPost},
+						{http.MethodPut: http.MethodPut},
+						{http.MethodPatch: http.MethodPatch},
+					},
+				},
+				&forms.FormField{
+					Name:        "template",
+					Type:        forms.ParamTextarea,
+					Label:       "Body Template",
+					Description: "Go template rendered with .Nodes, .Users, .Roles, .Workspaces, .Acls, .Event and .LastOutput. Use the json function to encode a value. Leave empty to send the whole message as JSON",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "contentType",
+					Type:        forms.ParamString,
+					Label:       "Content Type",
+					Description: "Content-Type header of the request",
+					Default:     webhookDefaultContentType,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "headers",
+					Type:        forms.ParamTextarea,
+					Label:       "Headers",
+					Description: "Additional headers, one 'Name: Value' per line",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "secret",
+					Type:        forms.ParamPassword,
+					Label:       "Signing Secret",
+					Description: "If set, the body is signed with HMAC-SHA256 using this secret",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "signatureHeader",
+					Type:        forms.ParamString,
+					Label:       "Signature Header",
+					Description: "Header carrying the signature, formatted as sha256=HEX",
+					Default:     webhookDefaultSignatureHeader,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "timeout",
+					Type:        forms.ParamString,
+					Label:       "Request Timeout",
+					Description: "Set a duration (10s, 10m, 1h...)",
+					Default:     "",
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns the unique identifier of this action
+func (w *WebhookAction) GetName() string {
+	return webhookActionName
+}
+
+// Init passes parameters
+func (w *WebhookAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	w.URL = action.Parameters["url"]
+	if w.URL == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameter url in Action")
+	}
+	w.Method = http.MethodPost
+	if m, ok := action.Parameters["method"]; ok && m != "" {
+		w.Method = strings.ToUpper(m)
+	}
+	w.ContentType = webhookDefaultContentType
+	if c, ok := action.Parameters["contentType"]; ok && c != "" {
+		w.ContentType = c
+	}
+	w.SignatureHeader = webhookDefaultSignatureHeader
+	if h, ok := action.Parameters["signatureHeader"]; ok && h != "" {
+		w.SignatureHeader = h
+	}
+	w.Template = action.Parameters["template"]
+	if w.Template != "" {
+		if _, e := w.parseTemplate(w.Template); e != nil {
+			return errors.BadRequest(common.ServiceTasks, "invalid body template: %s", e.Error())
+		}
+	}
+	w.Headers = action.Parameters["headers"]
+	w.Secret = action.Parameters["secret"]
+	w.Timeout = action.Parameters["timeout"]
+	if job != nil {
+		w.JobID = job.ID
+	}
+	return nil
+}
+
+// Run the actual action code
+func (w *WebhookAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	body, e := w.renderBody(input)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	target := jobs.EvaluateFieldStr(ctx, input, w.URL)
+	req, e := http.NewRequest(w.Method, target, bytes.NewReader(body))
+	if e != nil {
+		return input.WithError(e), e
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", w.ContentType)
+	req.Header.Set("User-Agent", "Pydio-Cells-Webhook")
+	if w.JobID != "" {
+		req.Header.Set("X-Cells-Job", w.JobID)
+	}
+	for name, value := range w.parseHeaders(ctx, input) {
+		req.Header.Set(name, value)
+	}
+	if w.Secret != "" {
+		req.Header.Set(w.SignatureHeader, SignWebhookPayload(jobs.EvaluateFieldStr(ctx, input, w.Secret), body))
+	}
+
+	timeout := webhookDefaultTimeout
+	if w.Timeout != "" {
+		t := jobs.EvaluateFieldStr(ctx, input, w.Timeout)
+		if dur, er := time.ParseDuration(t); er == nil {
+			timeout = dur
+		} else {
+			log.TasksLogger(ctx).Error("Cannot parse duration " + t + ": " + er.Error())
+		}
+	}
+
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Sending webhook %s %s", w.Method, target))
+	resp, e := (&http.Client{Timeout: timeout}).Do(req)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	defer resp.Body.Close()
+	respBody, e := ioutil.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseSize))
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if resp.StatusCode >= 300 {
+		e = errors.New(common.ServiceTasks, fmt.Sprintf("webhook %s returned status %s", target, resp.Status), int32(resp.StatusCode))
+		return input.WithError(e), e
+	}
+
+	output := &jobs.ActionOutput{Success: true}
+	var parsed interface{}
+	if len(respBody) > 0 && json.Unmarshal(respBody, &parsed) == nil {
+		output.JsonBody = respBody
+	} else {
+		output.StringBody = string(respBody)
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Webhook returned status %s", resp.Status))
+	input.AppendOutput(output)
+	return input, nil
+}
+
+// SignWebhookPayload computes the HMAC-SHA256 signature of a payload, formatted as "sha256=HEX".
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// renderBody builds the request body, either from the template or from the whole JSON-encoded message.
+func (w *WebhookAction) renderBody(input jobs.ActionMessage) ([]byte, error) {
+	if w.Template == "" {
+		s, e := (&jsonpb.Marshaler{}).MarshalToString(&input)
+		return []byte(s), e
+	}
+	tpl, e := w.parseTemplate(w.Template)
+	if e != nil {
+		return nil, e
+	}
+	data := map[string]interface{}{
+		"Nodes":      input.Nodes,
+		"Users":      input.Users,
+		"Roles":      input.Roles,
+		"Workspaces": input.Workspaces,
+		"Acls":       input.Acls,
+		"Activities": input.Activities,
+		"LastOutput": input.GetLastOutput(),
+		"Event":      nil,
+	}
+	if input.Event != nil {
+		var event ptypes.DynamicAny
+		if er := ptypes.UnmarshalAny(input.Event, &event); er == nil {
+			data["Event"] = event.Message
+		}
+	}
+	buf := &bytes.Buffer{}
+	if e := tpl.Execute(buf, data); e != nil {
+		return nil, e
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *WebhookAction) parseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			if msg, ok := v.(proto.Message); ok {
+				return (&jsonpb.Marshaler{}).MarshalToString(msg)
+			}
+			data, e := json.Marshal(v)
+			return string(data), e
+		},
+	}).Parse(text)
+}
+
+// parseHeaders reads one "Name: Value" header per line, evaluating values against the input.
+func (w *WebhookAction) parseHeaders(ctx context.Context, input jobs.ActionMessage) map[string]string {
+	headers := make(map[string]string)
+	for _, line := range strings.Split(w.Headers, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(parts[0])] = jobs.EvaluateFieldStr(ctx, input, strings.TrimSpace(parts[1]))
+	}
+	return headers
+}
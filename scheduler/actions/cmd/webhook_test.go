@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+func TestWebhookAction_GetName(t *testing.T) {
+	Convey("Test GetName", t, func() {
+		action := &WebhookAction{}
+		So(action.GetName(), ShouldEqual, webhookActionName)
+	})
+}
+
+func TestWebhookAction_Init(t *testing.T) {
+
+	Convey("", t, func() {
+
+		action := &WebhookAction{}
+		job := &jobs.Job{ID: "job-id"}
+		// Missing Parameters
+		e := action.Init(job, nil, &jobs.Action{})
+		So(e, ShouldNotBeNil)
+
+		// Invalid template
+		e = action.Init(job, nil, &jobs.Action{
+			Parameters: map[string]string{
+				"url":      "http://localhost/hook",
+				"template": "{{ .Nodes ",
+			},
+		})
+		So(e, ShouldNotBeNil)
+
+		// Defaults
+		e = action.Init(job, nil, &jobs.Action{
+			Parameters: map[string]string{
+				"url":    "http://localhost/hook",
+				"method": "put",
+			},
+		})
+		So(e, ShouldBeNil)
+		So(action.Method, ShouldEqual, http.MethodPut)
+		So(action.ContentType, ShouldEqual, webhookDefaultContentType)
+		So(action.SignatureHeader, ShouldEqual, webhookDefaultSignatureHeader)
+		So(action.JobID, ShouldEqual, "job-id")
+
+	})
+}
+
+func TestWebhookAction_Run(t *testing.T) {
+
+	Convey("Send a signed templated request and store JSON response", t, func() {
+
+		var received *http.Request
+		var receivedBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = ioutil.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ticket":"T-42"}`))
+		}))
+		defer server.Close()
+
+		action := &WebhookAction{}
+		e := action.Init(&jobs.Job{ID: "job-id"}, nil, &jobs.Action{
+			Parameters: map[string]string{
+				"url":      server.URL + "/hook",
+				"template": `{"path":"{{ (index .Nodes 0).Path }}","event":{{ json .Event }}}`,
+				"headers":  "X-Ticket-Queue: drop\nmalformed line",
+				"secret":   "s3cr3t",
+			},
+		})
+		So(e, ShouldBeNil)
+
+		event, _ := ptypes.MarshalAny(&tree.NodeChangeEvent{Type: tree.NodeChangeEvent_CREATE})
+		input := jobs.ActionMessage{
+			Event: event,
+			Nodes: []*tree.Node{{Path: "drop/file.txt"}},
+		}
+		output, err := action.Run(context.Background(), &actions.RunnableChannels{}, input)
+		So(err, ShouldBeNil)
+		So(received, ShouldNotBeNil)
+		So(received.Method, ShouldEqual, http.MethodPost)
+		So(received.URL.Path, ShouldEqual, "/hook")
+		So(string(receivedBody), ShouldEqual, `{"path":"drop/file.txt","event":{}}`)
+		So(received.Header.Get("Content-Type"), ShouldEqual, webhookDefaultContentType)
+		So(received.Header.Get("X-Ticket-Queue"), ShouldEqual, "drop")
+		So(received.Header.Get("X-Cells-Job"), ShouldEqual, "job-id")
+		So(received.Header.Get(webhookDefaultSignatureHeader), ShouldEqual, SignWebhookPayload("s3cr3t", receivedBody))
+
+		last := output.GetLastOutput()
+		So(last.Success, ShouldBeTrue)
+		So(string(last.JsonBody), ShouldEqual, `{"ticket":"T-42"}`)
+
+	})
+
+	Convey("Default body is the JSON-encoded message", t, func() {
+
+		var receivedBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedBody, _ = ioutil.ReadAll(r.Body)
+			w.Write([]byte("OK"))
+		}))
+		defer server.Close()
+
+		action := &WebhookAction{}
+		action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"url": server.URL}})
+		output, err := action.Run(context.Background(), &actions.RunnableChannels{}, jobs.ActionMessage{
+			Nodes: []*tree.Node{{Path: "drop/file.txt"}},
+		})
+		So(err, ShouldBeNil)
+		So(string(receivedBody), ShouldEqual, `{"Nodes":[{"Path":"drop/file.txt"}]}`)
+		So(output.GetLastOutput().StringBody, ShouldEqual, "OK")
+		So(output.GetLastOutput().JsonBody, ShouldBeEmpty)
+
+	})
+
+	Convey("Error status is returned as an error", t, func() {
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		action := &WebhookAction{}
+		action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"url": server.URL}})
+		output, err := action.Run(context.Background(), &actions.RunnableChannels{}, jobs.ActionMessage{})
+		So(err, ShouldNotBeNil)
+		So(errors.Parse(err.Error()).Code, ShouldEqual, http.StatusServiceUnavailable)
+		So(output.GetLastOutput().ErrorString, ShouldEqual, err.Error())
+
+	})
+
+	Convey("Response body is truncated", t, func() {
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(strings.Repeat("a", webhookMaxResponseSize+10)))
+		}))
+		defer server.Close()
+
+		action := &WebhookAction{}
+		action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"url": server.URL}})
+		output, err := action.Run(context.Background(), &actions.RunnableChannels{}, jobs.ActionMessage{})
+		So(err, ShouldBeNil)
+		So(output.GetLastOutput().StringBody, ShouldHaveLength, webhookMaxResponseSize)
+
+	})
+
+}
@@ -0,0 +1,461 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/data/source/migration"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	migrateActionName = "actions.datasource.migrate"
+	// Share of the progress used by the copy passes, the remaining being used by the final pass and the cutover
+	migratePassesProgress = float32(0.8)
+	// Delay given to the gateways to reload the datasource once its sync service reports the writes freeze
+	migrateFreezeDelay = 10 * time.Second
+)
+
+// MigrateAction moves the objects of a datasource to a new storage while the datasource stays online.
+// Buckets (the datasource one, and the versions and thumbnails ones if they are hosted by the datasource)
+// are copied by successive passes until a pass has nothing left to copy. To cut over, writes on the datasource
+// are frozen, a final pass copies the last changes and the target is verified identical: the configuration
+// is then switched to the new storage in one save. Writes stay frozen until the sync service serves the new
+// storage, and are released if the final pass or the verification fails.
+// Node UUIDs are kept, as the index is left untouched and folders and files UUIDs are copied along with
+// the data.
+type MigrateAction struct {
+	dsName         string
+	target         string
+	stores         bool
+	versionsBucket string
+	thumbsBucket   string
+	maxPasses      string
+	cutover        bool
+
+	dsClient     object.DataSourceEndpointClient
+	newMigration func(source *object.DataSource, target *object.DataSource) (*migration.Migration, error)
+	freezeFunc   func(m *migration.Migration, frozen bool) error
+	cutoverFunc  func(ctx context.Context, m *migration.Migration) (*object.DataSource, error)
+}
+
+// MigrateResult is the JSON output of the action.
+type MigrateResult struct {
+	Reports       []*migration.Report
+	Operations    int
+	UuidsRestored int
+	CutoverDone   bool
+	// Previous configuration of the datasource, to be restored to revert the migration
+	Previous *object.DataSource `json:",omitempty"`
+}
+
+func (c *MigrateAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              migrateActionName,
+		Label:           "Migrate datasource",
+		Icon:            "database-export",
+		Category:        actions.ActionCategoryScheduler,
+		Description:     "Copy the objects of a datasource to a new storage, keeping nodes UUIDs, versions and thumbnails, and switch the datasource to this storage",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *MigrateAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "dataSource",
+					Type:        forms.ParamString,
+					Label:       "DataSource",
+					Description: "Name of the datasource to migrate",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "target",
+					Type:        forms.ParamTextarea,
+					Label:       "Target storage",
+					Description: "JSON description of the target storage, using the datasource format (StorageType, StorageConfiguration, ObjectsBucket, ApiKey, ApiSecret...). LOCAL folders must be reachable from the node running this task",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "stores",
+					Type:        forms.ParamBool,
+					Label:       "Migrate stores",
+					Description: "Also migrate the versions and thumbnails buckets if they are hosted by this datasource",
+					Default:     true,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "versionsBucket",
+					Type:        forms.ParamString,
+					Label:       "Versions bucket",
+					Description: "Name of the versions bucket on a S3 target storage, defaults to the current name",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "thumbsBucket",
+					Type:        forms.ParamString,
+					Label:       "Thumbnails bucket",
+					Description: "Name of the thumbnails bucket on a S3 target storage, defaults to the current name",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "maxPasses",
+					Type:        forms.ParamInteger,
+					Label:       "Maximum passes",
+					Description: "Maximum number of copy passes per bucket while the datasource is online, before freezing its writes for the final pass",
+					Default:     3,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "cutover",
+					Type:        forms.ParamBool,
+					Label:       "Cutover",
+					Description: "Freeze writes on the datasource for a final pass and switch it to the target storage once verified. If disabled, only copy and verify, the task can be run again later to cut over",
+					Default:     true,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *MigrateAction) GetName() string {
+	return migrateActionName
+}
+
+// CanPause implements ControllableAction: pause is handled between two passes
+func (c *MigrateAction) CanPause() bool {
+	return true
+}
+
+// CanStop implements ControllableAction: a running pass is interrupted
+func (c *MigrateAction) CanStop() bool {
+	return true
+}
+
+// ProvidesProgress implements ProgressProviderAction
+func (c *MigrateAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (c *MigrateAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.dsName = action.Parameters["dataSource"]
+	c.target = action.Parameters["target"]
+	if c.dsName == "" || c.target == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameters dataSource or target in Action")
+	}
+	c.stores = action.Parameters["stores"] != "false"
+	c.cutover = action.Parameters["cutover"] != "false"
+	c.versionsBucket = action.Parameters["versionsBucket"]
+	c.thumbsBucket = action.Parameters["thumbsBucket"]
+	c.maxPasses = "3"
+	if m, ok := action.Parameters["maxPasses"]; ok && m != "" {
+		c.maxPasses = m
+	}
+	if cl == nil {
+		cl = defaults.NewClient()
+	}
+	if c.dsClient == nil {
+		c.dsClient = object.NewDataSourceEndpointClient(common.ServiceGrpcNamespace_+common.ServiceDataSync_+c.dsName, cl)
+	}
+	if c.newMigration == nil {
+		c.newMigration = migration.NewMigration
+	}
+	if c.freezeFunc == nil {
+		c.freezeFunc = func(m *migration.Migration, frozen bool) error {
+			return m.FreezeWrites(common.PydioSystemUsername, frozen)
+		}
+	}
+	if c.cutoverFunc == nil {
+		c.cutoverFunc = func(ctx context.Context, m *migration.Migration) (*object.DataSource, error) {
+			return m.Cutover(ctx, common.PydioSystemUsername)
+		}
+	}
+	return nil
+}
+
+// Run copies all buckets, verifies them and cuts over.
+func (c *MigrateAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	maxPasses, e := strconv.Atoi(jobs.EvaluateFieldStr(ctx, input, c.maxPasses))
+	if e != nil || maxPasses < 1 {
+		e = errors.BadRequest(common.ServiceTasks, "invalid maxPasses parameter")
+		return input.WithError(e), e
+	}
+	m, e := c.prepare(ctx, input)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cannot prepare migration of datasource "+c.dsName, zap.Error(e))
+		return input.WithError(e), e
+	}
+	result := &MigrateResult{}
+	bucketProgress := migratePassesProgress / float32(len(m.Buckets))
+
+	for i, b := range m.Buckets {
+		pgStart := float32(i) * bucketProgress
+		stopped, e := c.copyBucket(ctx, channels, m, b, maxPasses, pgStart, bucketProgress, result)
+		if e != nil {
+			log.TasksLogger(ctx).Error("Cannot migrate bucket "+b.Name, zap.Error(e))
+			return input.WithError(e), e
+		} else if stopped {
+			log.TasksLogger(ctx).Info("Migration stopped, run the task again to resume it")
+			return input, nil
+		}
+		if c.cutover {
+			continue
+		}
+		if _, e := c.verifyBucket(ctx, channels, m, b, result); e != nil {
+			log.TasksLogger(ctx).Error("Cannot verify bucket "+b.Name, zap.Error(e))
+			return input.WithError(e), e
+		}
+	}
+
+	if c.cutover {
+		stopped, e := c.frozenCutover(ctx, channels, m, result)
+		if e != nil {
+			return input.WithError(e), e
+		} else if stopped {
+			log.TasksLogger(ctx).Info("Migration stopped, writes are released, run the task again to resume it")
+			return input, nil
+		}
+	}
+	channels.Progress <- 1
+
+	output := input
+	jsonBody, _ := json.Marshal(result)
+	var summaries []string
+	for _, r := range result.Reports {
+		summaries = append(summaries, r.String())
+	}
+	output.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		StringBody: strings.Join(summaries, "\n"),
+		JsonBody:   jsonBody,
+	})
+	return output, nil
+}
+
+// prepare loads the live configuration of the datasource and registers the stores it hosts.
+func (c *MigrateAction) prepare(ctx context.Context, input jobs.ActionMessage) (*migration.Migration, error) {
+	resp, e := c.dsClient.GetDataSourceConfig(ctx, &object.GetDataSourceConfigRequest{})
+	if e != nil {
+		return nil, e
+	}
+	var target object.DataSource
+	if e := jsonpb.UnmarshalString(jobs.EvaluateFieldStr(ctx, input, c.target), &target); e != nil {
+		return nil, fmt.Errorf("cannot parse target storage: %s", e.Error())
+	}
+	m, e := c.newMigration(resp.GetDataSource(), &target)
+	if e != nil {
+		return nil, e
+	}
+	if !c.stores {
+		return m, nil
+	}
+	for ns, targetBucket := range map[string]string{
+		common.PydioVersionsNamespace:   c.versionsBucket,
+		common.PydioThumbstoreNamespace: c.thumbsBucket,
+	} {
+		if dsName, bucket, _ := views.GetGenericStoreClientConfig(ns); dsName == c.dsName && bucket != "" {
+			m.AddStore(views.GetGenericStoreConfigKey(ns), bucket, targetBucket)
+		}
+	}
+	return m, nil
+}
+
+// copyBucket runs passes on a bucket until there is nothing left to copy, or until maxPasses is reached.
+func (c *MigrateAction) copyBucket(ctx context.Context, channels *actions.RunnableChannels, m *migration.Migration, b *migration.Bucket, maxPasses int, pgStart, pgRange float32, result *MigrateResult) (stopped bool, e error) {
+	passRange := pgRange / float32(maxPasses)
+	for pass := 1; pass <= maxPasses; pass++ {
+		if c.interrupted(channels) {
+			return true, nil
+		}
+		channels.StatusMsg <- fmt.Sprintf("Copying bucket %s (pass %d)", b.Name, pass)
+		start := pgStart + float32(pass-1)*passRange
+		ops, stopped, e := c.pass(ctx, channels, m, b, start, passRange)
+		result.Operations += ops
+		if e != nil || stopped {
+			return stopped, e
+		}
+		log.TasksLogger(ctx).Info(fmt.Sprintf("Pass %d on bucket %s: %d operation(s) applied", pass, b.Name, ops))
+		if ops == 0 {
+			break
+		}
+	}
+	channels.Progress <- pgStart + pgRange
+	return false, nil
+}
+
+// verifyBucket restores files UUIDs on the target and compares it to the source.
+func (c *MigrateAction) verifyBucket(ctx context.Context, channels *actions.RunnableChannels, m *migration.Migration, b *migration.Bucket, result *MigrateResult) (*migration.Report, error) {
+	channels.StatusMsg <- "Verifying bucket " + b.Name
+	restored, e := m.RestoreUuids(ctx, b)
+	if e != nil {
+		return nil, e
+	}
+	result.UuidsRestored += restored
+	report, e := m.Verify(ctx, b)
+	if e != nil {
+		return nil, e
+	}
+	result.Reports = append(result.Reports, report)
+	log.TasksLogger(ctx).Info(report.String())
+	return report, nil
+}
+
+// frozenCutover freezes writes on the datasource, copies the last changes of all buckets, verifies them and
+// switches the datasource to the target storage. Writes are released if the cutover is not done.
+func (c *MigrateAction) frozenCutover(ctx context.Context, channels *actions.RunnableChannels, m *migration.Migration, result *MigrateResult) (stopped bool, e error) {
+	channels.StatusMsg <- "Freezing writes on datasource " + c.dsName
+	if e := c.freezeFunc(m, true); e != nil {
+		log.TasksLogger(ctx).Error("Cannot freeze writes on datasource "+c.dsName, zap.Error(e))
+		return false, e
+	}
+	defer func() {
+		if result.CutoverDone {
+			return
+		}
+		if er := c.freezeFunc(m, false); er != nil {
+			log.TasksLogger(ctx).Error("Cannot release writes on datasource "+c.dsName+", please update its configuration", zap.Error(er))
+		} else {
+			log.TasksLogger(ctx).Info("Writes on datasource " + c.dsName + " are released")
+		}
+	}()
+	e = service.Retry(ctx, func() error {
+		resp, e := c.dsClient.GetDataSourceConfig(ctx, &object.GetDataSourceConfigRequest{})
+		if e != nil {
+			return e
+		} else if !resp.GetDataSource().WritesFrozen() {
+			return fmt.Errorf("writes freeze not loaded yet")
+		}
+		return nil
+	}, 2*time.Second, 2*time.Minute)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Sync service of datasource "+c.dsName+" did not load the writes freeze, cutover is cancelled", zap.Error(e))
+		return false, e
+	}
+	<-time.After(migrateFreezeDelay)
+
+	finalRange := (1 - migratePassesProgress) / float32(len(m.Buckets)+1)
+	for i, b := range m.Buckets {
+		if c.interrupted(channels) {
+			return true, nil
+		}
+		channels.StatusMsg <- "Copying last changes of bucket " + b.Name
+		pgStart := migratePassesProgress + float32(i)*finalRange
+		ops, stopped, e := c.pass(ctx, channels, m, b, pgStart, finalRange)
+		result.Operations += ops
+		if e != nil {
+			log.TasksLogger(ctx).Error("Cannot copy last changes of bucket "+b.Name+", cutover is cancelled", zap.Error(e))
+			return false, e
+		} else if stopped {
+			return true, nil
+		}
+		log.TasksLogger(ctx).Info(fmt.Sprintf("Final pass on bucket %s: %d operation(s) applied", b.Name, ops))
+		report, e := c.verifyBucket(ctx, channels, m, b, result)
+		if e != nil {
+			log.TasksLogger(ctx).Error("Cannot verify bucket "+b.Name+", cutover is cancelled", zap.Error(e))
+			return false, e
+		} else if !report.Clean() {
+			e = fmt.Errorf("bucket %s differs from the current storage after the final pass, cutover is cancelled", b.Name)
+			log.TasksLogger(ctx).Error(e.Error())
+			return false, e
+		}
+	}
+
+	channels.StatusMsg <- "Switching datasource " + c.dsName + " to the new storage"
+	previous, e := c.cutoverFunc(ctx, m)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cutover failed, datasource is still using its previous storage", zap.Error(e))
+		return false, e
+	}
+	result.CutoverDone = true
+	result.Previous = previous
+	log.TasksLogger(ctx).Info("Datasource " + c.dsName + " now uses the new storage, writes are released once its sync service is restarted on it. The previous objects service is kept to allow reverting the migration")
+	return false, nil
+}
+
+// pass runs a single pass, forwarding its progress and interrupting it if the task is stopped.
+func (c *MigrateAction) pass(ctx context.Context, channels *actions.RunnableChannels, m *migration.Migration, b *migration.Bucket, pgStart, pgRange float32) (ops int, stopped bool, e error) {
+	cmd := model.NewCommand()
+	defer cmd.Stop()
+	done := make(chan struct{})
+	stop := make(chan bool, 1)
+	go func() {
+		select {
+		case <-channels.Stop:
+			stop <- true
+			cmd.Publish(model.Interrupt)
+		case <-done:
+		}
+	}()
+	ops, e = m.Pass(ctx, b, cmd, func(pg float32) {
+		channels.Progress <- pgStart + pg*pgRange
+	})
+	close(done)
+	select {
+	case <-stop:
+		stopped = true
+	default:
+	}
+	return
+}
+
+func (c *MigrateAction) interrupted(channels *actions.RunnableChannels) bool {
+	select {
+	case <-channels.Stop:
+		return true
+	case <-channels.Pause:
+		<-channels.BlockUntilResume()
+	default:
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/sync/endpoints/filesystem"
+	"github.com/pydio/cells/common/sync/model"
+	"github.com/pydio/cells/data/source/migration"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+type dsMock struct {
+	object.DataSourceEndpointClient
+	ds *object.DataSource
+}
+
+func (m *dsMock) GetDataSourceConfig(ctx context.Context, in *object.GetDataSourceConfigRequest, opts ...client.CallOption) (*object.GetDataSourceConfigResponse, error) {
+	return &object.GetDataSourceConfigResponse{DataSource: m.ds}, nil
+}
+
+func drainChannels() (*actions.RunnableChannels, func()) {
+	channels := &actions.RunnableChannels{
+		Stop:      make(chan interface{}, 1),
+		Pause:     make(chan interface{}),
+		StatusMsg: make(chan string),
+		Progress:  make(chan float32),
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-channels.StatusMsg:
+			case <-channels.Progress:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return channels, func() { close(done) }
+}
+
+func TestMigrateAction_Init(t *testing.T) {
+	Convey("Test Init", t, func() {
+		action := &MigrateAction{}
+		So(action.GetName(), ShouldEqual, migrateActionName)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds"}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "target": "{}", "cutover": "false"}}), ShouldBeNil)
+		So(action.stores, ShouldBeTrue)
+		So(action.cutover, ShouldBeFalse)
+		So(action.maxPasses, ShouldEqual, "3")
+		So(action.dsClient, ShouldNotBeNil)
+		So(action.freezeFunc, ShouldNotBeNil)
+	})
+}
+
+func TestMigrateAction_Run(t *testing.T) {
+
+	Convey("Test migration", t, func() {
+
+		ctx := context.Background()
+		root, _ := ioutil.TempDir("", "migrate-action")
+		defer os.RemoveAll(root)
+		os.MkdirAll(filepath.Join(root, "source", "ds", "folder"), 0755)
+		ioutil.WriteFile(filepath.Join(root, "source", "ds", "folder", "file"), []byte("content"), 0644)
+		target := filepath.Join(root, "target", "ds")
+
+		migrateFreezeDelay = 0
+		var cutoverCalled bool
+		var cutoverErr error
+		var freezes []bool
+		onFreeze := func() {}
+		ds := &dsMock{ds: &object.DataSource{Name: "ds", StorageType: object.StorageType_S3, ObjectsBucket: "ds"}}
+		action := &MigrateAction{
+			dsClient: ds,
+			newMigration: func(source *object.DataSource, target *object.DataSource) (*migration.Migration, error) {
+				m, e := migration.NewMigration(source, target)
+				if e != nil {
+					return nil, e
+				}
+				m.SourceEndpoint = func(ctx context.Context, bucket string, baseFolder string) (model.Endpoint, error) {
+					return filesystem.NewFSClient(filepath.Join(root, "source", bucket), model.EndpointOptions{})
+				}
+				return m, nil
+			},
+			freezeFunc: func(m *migration.Migration, frozen bool) error {
+				freezes = append(freezes, frozen)
+				ds.ds.StorageConfiguration = map[string]string{}
+				if frozen {
+					ds.ds.StorageConfiguration[object.StorageKeyWritesFrozen] = "true"
+					onFreeze()
+				}
+				return nil
+			},
+			cutoverFunc: func(ctx context.Context, m *migration.Migration) (*object.DataSource, error) {
+				if cutoverErr != nil {
+					return nil, cutoverErr
+				}
+				cutoverCalled = true
+				return m.Source, nil
+			},
+		}
+		channels, closer := drainChannels()
+		defer closer()
+		params := map[string]string{
+			"dataSource": "ds",
+			"target":     `{"StorageType":"LOCAL","StorageConfiguration":{"folder":"` + target + `"}}`,
+			"stores":     "false",
+		}
+
+		Convey("Invalid targets are rejected", func() {
+			params["target"] = `{"StorageType":"S3"}`
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("Data is copied and verified without cutover", func() {
+			params["cutover"] = "false"
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+			out, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(out.GetLastOutput().GetSuccess(), ShouldBeTrue)
+			So(out.GetLastOutput().GetStringBody(), ShouldContainSubstring, "ds")
+			So(cutoverCalled, ShouldBeFalse)
+			So(freezes, ShouldBeEmpty)
+			data, e := ioutil.ReadFile(filepath.Join(target, "folder", "file"))
+			So(e, ShouldBeNil)
+			So(string(data), ShouldEqual, "content")
+		})
+
+		Convey("Cutover is performed under writes freeze once data is copied", func() {
+			// Last write before the freeze is loaded, only copied by the final pass
+			onFreeze = func() {
+				ioutil.WriteFile(filepath.Join(root, "source", "ds", "last"), []byte("last"), 0644)
+			}
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+			out, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(out.GetLastOutput().GetSuccess(), ShouldBeTrue)
+			So(cutoverCalled, ShouldBeTrue)
+			So(freezes, ShouldResemble, []bool{true})
+			data, e := ioutil.ReadFile(filepath.Join(target, "last"))
+			So(e, ShouldBeNil)
+			So(string(data), ShouldEqual, "last")
+		})
+
+		Convey("Writes are released if the cutover fails", func() {
+			cutoverErr = fmt.Errorf("cannot save")
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldNotBeNil)
+			So(freezes, ShouldResemble, []bool{true, false})
+		})
+
+		Convey("Migration can be stopped", func() {
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: params}), ShouldBeNil)
+			channels.Stop <- true
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(cutoverCalled, ShouldBeFalse)
+			_, e = os.Stat(filepath.Join(target, "folder", "file"))
+			So(os.IsNotExist(e), ShouldBeTrue)
+		})
+
+	})
+}
@@ -0,0 +1,442 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/key"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	rotateKeyActionName = "actions.encryption.rotate-key"
+	// Maximum number of failing nodes listed in the verification error
+	verifyMaxReported = 10
+)
+
+// RotateKeyAction re-wraps all node keys of a datasource encrypted with a master key using a new master key.
+// Only node keys are rewritten, objects contents are never touched. Nodes are processed by batches and nodes
+// already protected by the new key are skipped, so that an interrupted rotation is resumed by running it again.
+type RotateKeyAction struct {
+	dsName     string
+	newKeyID   string
+	batchSize  string
+	verifyOnly bool
+
+	publisher     client.Client
+	nodeKeyClient encryption.NodeKeyManagerClient
+	treeClient    tree.NodeProviderClient
+	keyTool       key.UserKeyTool
+}
+
+func (r *RotateKeyAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              rotateKeyActionName,
+		Label:           "Rotate master key",
+		Icon:            "key-change",
+		Category:        actions.ActionCategoryScheduler,
+		Description:     "Protect all node keys of an encrypted datasource with a new master key, then verify that every node can still be decrypted",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (r *RotateKeyAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "dataSource",
+					Type:        forms.ParamString,
+					Label:       "DataSource",
+					Description: "Name of a datasource encrypted with a master key",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "newKeyId",
+					Type:        forms.ParamString,
+					Label:       "New key",
+					Description: "Identifier of the master key replacing the current one",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "batchSize",
+					Type:        forms.ParamInteger,
+					Label:       "Batch size",
+					Description: "Number of nodes processed between two progress updates",
+					Default:     100,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "verifyOnly",
+					Type:        forms.ParamBool,
+					Label:       "Verify only",
+					Description: "Only check that all nodes can be decrypted with the current master key",
+					Default:     false,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (r *RotateKeyAction) GetName() string {
+	return rotateKeyActionName
+}
+
+// CanPause implements ControllableAction: pause is handled between two batches
+func (r *RotateKeyAction) CanPause() bool {
+	return true
+}
+
+// CanStop implements ControllableAction: stop is handled between two batches
+func (r *RotateKeyAction) CanStop() bool {
+	return true
+}
+
+// ProvidesProgress implements ProgressProviderAction
+func (r *RotateKeyAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (r *RotateKeyAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	r.dsName = action.Parameters["dataSource"]
+	if r.dsName == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameter dataSource in Action")
+	}
+	r.newKeyID = action.Parameters["newKeyId"]
+	r.verifyOnly = action.Parameters["verifyOnly"] == "true"
+	if r.newKeyID == "" && !r.verifyOnly {
+		return errors.BadRequest(common.ServiceTasks, "missing parameter newKeyId in Action")
+	}
+	r.batchSize = "100"
+	if bs, ok := action.Parameters["batchSize"]; ok && bs != "" {
+		r.batchSize = bs
+	}
+	if cl == nil {
+		cl = defaults.NewClient()
+	}
+	r.publisher = cl
+	if r.nodeKeyClient == nil {
+		r.nodeKeyClient = encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, cl)
+	}
+	if r.treeClient == nil {
+		r.treeClient = tree.NewNodeProviderClient(common.ServiceGrpcNamespace_+common.ServiceTree, cl)
+	}
+	return nil
+}
+
+// Run performs the rotation, followed by the verification pass. The datasource is switched to the new key
+// only once all nodes have been verified.
+func (r *RotateKeyAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	dsName := jobs.EvaluateFieldStr(ctx, input, r.dsName)
+	ds, ok := config.ListSourcesFromConfig()[dsName]
+	if !ok {
+		e := errors.NotFound(common.ServiceTasks, "cannot find datasource %s", dsName)
+		return input.WithError(e), e
+	}
+	if ds.EncryptionMode != object.EncryptionMode_MASTER {
+		e := errors.BadRequest(common.ServiceTasks, "datasource %s is not encrypted with a master key", dsName)
+		return input.WithError(e), e
+	}
+	batchSize := 100
+	if bs, e := jobs.EvaluateFieldInt64(ctx, input, r.batchSize); e == nil && bs > 0 {
+		batchSize = int(bs)
+	}
+	tool := r.keyTool
+	if tool == nil {
+		var e error
+		if tool, e = key.MasterKeyTool(ctx); e != nil {
+			return input.WithError(e), e
+		}
+	}
+
+	if r.verifyOnly {
+		if _, e := r.verify(ctx, channels, tool, dsName, ds.EncryptionKey, batchSize, 0, 1); e != nil {
+			return input.WithError(e), e
+		}
+		return input, nil
+	}
+
+	rotation, e := r.prepareRotation(ctx, tool, ds, jobs.EvaluateFieldStr(ctx, input, r.newKeyID))
+	if e != nil {
+		return input.WithError(e), e
+	}
+	stopped, e := r.rotate(ctx, channels, tool, dsName, rotation, batchSize, 0, 0.5)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if !stopped {
+		stopped, e = r.verify(ctx, channels, tool, dsName, rotation.To, batchSize, 0.5, 0.5)
+		if e != nil {
+			return input.WithError(e), e
+		}
+	}
+	if stopped {
+		log.TasksLogger(ctx).Info("Master key rotation interrupted, run the job again to resume it")
+		return input, nil
+	}
+
+	if e := r.switchKey(ctx, ds, rotation); e != nil {
+		return input.WithError(e), e
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Datasource %s is now protected by master key %s", dsName, rotation.To))
+	return input, nil
+}
+
+// prepareRotation resumes the rotation in progress for the datasource, or registers a new one.
+func (r *RotateKeyAction) prepareRotation(ctx context.Context, tool key.UserKeyTool, ds *object.DataSource, newKeyID string) (*key.KeyRotation, error) {
+	rotation, ok := key.GetKeyRotation(ds.Name)
+	if ok && rotation.Finished == 0 {
+		if rotation.To != newKeyID {
+			return nil, errors.Conflict(common.ServiceTasks, "a rotation to key %s is already in progress for datasource %s", rotation.To, ds.Name)
+		}
+		log.TasksLogger(ctx).Info("Resuming master key rotation for datasource " + ds.Name)
+		return rotation, nil
+	}
+	if newKeyID == ds.EncryptionKey {
+		return nil, errors.BadRequest(common.ServiceTasks, "datasource %s is already protected by key %s", ds.Name, newKeyID)
+	}
+	// Make sure the new key is usable before modifying anything
+	probe := []byte(ds.Name)
+	encrypted, e := tool.GetEncrypted(ctx, newKeyID, probe)
+	if e != nil {
+		return nil, e
+	}
+	if plain, e := tool.GetDecrypted(ctx, newKeyID, encrypted); e != nil || !bytes.Equal(plain, probe) {
+		return nil, errors.InternalServerError(common.ServiceTasks, "key %s cannot be used for encryption", newKeyID)
+	}
+	rotation = &key.KeyRotation{
+		From:    ds.EncryptionKey,
+		To:      newKeyID,
+		Started: time.Now().Unix(),
+	}
+	if e := key.SetKeyRotation(ds.Name, rotation, ctxUser(ctx)); e != nil {
+		return nil, e
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Starting master key rotation for datasource %s, from key %s to key %s", ds.Name, rotation.From, rotation.To))
+	return rotation, nil
+}
+
+// rotate re-wraps by batches all node keys that are not yet protected by the target key of the rotation.
+// It returns true if it was stopped before the end.
+func (r *RotateKeyAction) rotate(ctx context.Context, channels *actions.RunnableChannels, tool key.UserKeyTool, dsName string, rotation *key.KeyRotation, batchSize int, pgStart, pgScale float32) (bool, error) {
+	uuids, e := r.listLeaves(ctx, dsName)
+	if e != nil {
+		return false, e
+	}
+	keyUser := "ds:" + dsName
+	var rotated, failed int
+	for start := 0; start < len(uuids); start += batchSize {
+		if r.interrupted(channels) {
+			return true, nil
+		}
+		end := start + batchSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		for _, nodeUuid := range uuids[start:end] {
+			done, e := r.rotateNodeKey(ctx, tool, keyUser, nodeUuid, rotation)
+			if e != nil {
+				// Do not block the whole rotation, failing nodes are reported by the verification
+				log.TasksLogger(ctx).Error("Cannot rotate key for node "+nodeUuid, zap.Error(e))
+				failed++
+			} else if done {
+				rotated++
+			}
+		}
+		channels.StatusMsg <- fmt.Sprintf("Rotating keys: %d/%d nodes processed", end, len(uuids))
+		channels.Progress <- pgStart + pgScale*float32(end)/float32(len(uuids))
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Rotated keys for %d nodes (%d nodes already rotated or not encrypted, %d failures)", rotated, len(uuids)-rotated-failed, failed))
+	return false, nil
+}
+
+// rotateNodeKey re-wraps the key of a node with the target key. It returns false if there was nothing to do.
+func (r *RotateKeyAction) rotateNodeKey(ctx context.Context, tool key.UserKeyTool, keyUser, nodeUuid string, rotation *key.KeyRotation) (bool, error) {
+	nodeKey, e := r.getNodeKey(ctx, keyUser, nodeUuid)
+	if e != nil || nodeKey == nil {
+		return false, e
+	}
+	if _, e := tool.GetDecrypted(ctx, rotation.To, nodeKey.KeyData); e == nil {
+		// Already rotated
+		return false, nil
+	}
+	plain, e := tool.GetDecrypted(ctx, rotation.From, nodeKey.KeyData)
+	if e != nil {
+		return false, e
+	}
+	keyData, e := tool.GetEncrypted(ctx, rotation.To, plain)
+	if e != nil {
+		return false, e
+	}
+	_, e = r.nodeKeyClient.SetNodeKey(ctx, &encryption.SetNodeKeyRequest{NodeKey: &encryption.NodeKey{
+		NodeId:  nodeKey.NodeId,
+		UserId:  nodeKey.UserId,
+		OwnerId: nodeKey.OwnerId,
+		KeyData: keyData,
+	}})
+	return e == nil, e
+}
+
+// verify checks by batches that all node keys can be decrypted with keyID.
+// It returns true if it was stopped before the end.
+func (r *RotateKeyAction) verify(ctx context.Context, channels *actions.RunnableChannels, tool key.UserKeyTool, dsName, keyID string, batchSize int, pgStart, pgScale float32) (bool, error) {
+	uuids, e := r.listLeaves(ctx, dsName)
+	if e != nil {
+		return false, e
+	}
+	keyUser := "ds:" + dsName
+	var failed []string
+	for start := 0; start < len(uuids); start += batchSize {
+		if r.interrupted(channels) {
+			return true, nil
+		}
+		end := start + batchSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		for _, nodeUuid := range uuids[start:end] {
+			nodeKey, e := r.getNodeKey(ctx, keyUser, nodeUuid)
+			if e == nil && nodeKey != nil {
+				_, e = tool.GetDecrypted(ctx, keyID, nodeKey.KeyData)
+			}
+			if e != nil {
+				failed = append(failed, nodeUuid)
+			}
+		}
+		channels.StatusMsg <- fmt.Sprintf("Verifying keys: %d/%d nodes processed", end, len(uuids))
+		channels.Progress <- pgStart + pgScale*float32(end)/float32(len(uuids))
+	}
+	if len(failed) > 0 {
+		reported := failed
+		if len(reported) > verifyMaxReported {
+			reported = reported[:verifyMaxReported]
+		}
+		return false, errors.InternalServerError(common.ServiceTasks, "%d node(s) of datasource %s cannot be decrypted with key %s: %s", len(failed), dsName, keyID, strings.Join(reported, ", "))
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Verified keys for %d nodes of datasource %s", len(uuids), dsName))
+	return false, nil
+}
+
+// switchKey stores the new key in the datasource configuration and marks the rotation as finished.
+func (r *RotateKeyAction) switchKey(ctx context.Context, ds *object.DataSource, rotation *key.KeyRotation) error {
+	ds.EncryptionKey = rotation.To
+	if e := config.Set(ds, "services", common.ServiceGrpcNamespace_+common.ServiceDataSync_+ds.Name); e != nil {
+		return e
+	}
+	rotation.Finished = time.Now().Unix()
+	if e := key.SetKeyRotation(ds.Name, rotation, ctxUser(ctx)); e != nil {
+		return e
+	}
+	if e := r.publisher.Publish(ctx, r.publisher.NewPublication(common.TopicDatasourceEvent, &object.DataSourceEvent{
+		Name:   ds.Name,
+		Type:   object.DataSourceEvent_UPDATE,
+		Config: ds,
+	})); e != nil {
+		log.Logger(ctx).Warn("could not notify the datasource update", zap.Error(e))
+	}
+	return nil
+}
+
+// getNodeKey loads the key of a node, or nil if the node is not encrypted.
+func (r *RotateKeyAction) getNodeKey(ctx context.Context, keyUser, nodeUuid string) (*encryption.NodeKey, error) {
+	rsp, e := r.nodeKeyClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{NodeId: nodeUuid, UserId: keyUser})
+	if e != nil {
+		if code := errors.Parse(e.Error()).Code; code == 404 || code == 403 {
+			return nil, nil
+		}
+		return nil, e
+	}
+	return rsp.GetNodeInfo().GetNodeKey(), nil
+}
+
+// listLeaves lists the uuids of all files of the datasource.
+func (r *RotateKeyAction) listLeaves(ctx context.Context, dsName string) ([]string, error) {
+	stream, e := r.treeClient.ListNodes(ctx, &tree.ListNodesRequest{
+		Node:       &tree.Node{Path: dsName},
+		Recursive:  true,
+		FilterType: tree.NodeType_LEAF,
+	})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	var uuids []string
+	for {
+		rsp, e := stream.Recv()
+		if e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		if n := rsp.GetNode(); n != nil && n.Uuid != "" && n.IsLeaf() {
+			uuids = append(uuids, n.Uuid)
+		}
+	}
+	return uuids, nil
+}
+
+// interrupted checks for stop and pause requests between two batches.
+func (r *RotateKeyAction) interrupted(channels *actions.RunnableChannels) bool {
+	select {
+	case <-channels.Stop:
+		return true
+	case <-channels.Pause:
+		<-channels.BlockUntilResume()
+	default:
+	}
+	return false
+}
+
+func ctxUser(ctx context.Context) string {
+	if u, _ := permissions.FindUserNameInContext(ctx); u != "" {
+		return u
+	}
+	return common.PydioSystemUsername
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/idm/key"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// prefixKeyTool "encrypts" data by prefixing it with the key ID
+type prefixKeyTool struct{}
+
+func (p *prefixKeyTool) GetEncrypted(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	return append([]byte(keyID+"|"), data...), nil
+}
+
+func (p *prefixKeyTool) GetDecrypted(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(keyID+"|")) {
+		return nil, fmt.Errorf("wrong key %s", keyID)
+	}
+	return data[len(keyID)+1:], nil
+}
+
+type nodeKeysMock struct {
+	encryption.NodeKeyManagerClient
+	keys map[string]*encryption.NodeKey
+	sets int
+}
+
+func (m *nodeKeysMock) GetNodeInfo(ctx context.Context, in *encryption.GetNodeInfoRequest, opts ...client.CallOption) (*encryption.GetNodeInfoResponse, error) {
+	k, ok := m.keys[in.NodeId]
+	if !ok || k.UserId != in.UserId {
+		return nil, errors.NotFound("node.key.dao", "no key found for node %s", in.NodeId)
+	}
+	return &encryption.GetNodeInfoResponse{NodeInfo: &encryption.NodeInfo{NodeKey: k}}, nil
+}
+
+func (m *nodeKeysMock) SetNodeKey(ctx context.Context, in *encryption.SetNodeKeyRequest, opts ...client.CallOption) (*encryption.SetNodeKeyResponse, error) {
+	m.keys[in.NodeKey.NodeId] = in.NodeKey
+	m.sets++
+	return &encryption.SetNodeKeyResponse{}, nil
+}
+
+func drainChannels() (*actions.RunnableChannels, func()) {
+	channels := &actions.RunnableChannels{
+		Stop:      make(chan interface{}, 1),
+		Pause:     make(chan interface{}),
+		StatusMsg: make(chan string),
+		Progress:  make(chan float32),
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-channels.StatusMsg:
+			case <-channels.Progress:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return channels, func() { close(done) }
+}
+
+func TestRotateKeyAction_Init(t *testing.T) {
+	Convey("Test Init", t, func() {
+		action := &RotateKeyAction{}
+		So(action.GetName(), ShouldEqual, rotateKeyActionName)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds"}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "verifyOnly": "true"}}), ShouldBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "newKeyId": "new"}}), ShouldBeNil)
+		So(action.batchSize, ShouldEqual, "100")
+		So(action.nodeKeyClient, ShouldNotBeNil)
+		So(action.treeClient, ShouldNotBeNil)
+	})
+}
+
+func TestRotateKeyAction_Rotate(t *testing.T) {
+
+	Convey("Test rotation and verification", t, func() {
+
+		ctx := context.Background()
+		tool := &prefixKeyTool{}
+		rotation := &key.KeyRotation{From: "old", To: "new"}
+		nodes := map[string]tree.Node{
+			"ds/folder": {Uuid: "folder", Path: "ds/folder", Type: tree.NodeType_COLLECTION},
+		}
+		keys := map[string]*encryption.NodeKey{}
+		for i := 0; i < 5; i++ {
+			id := fmt.Sprintf("node-%d", i)
+			nodes["ds/folder/"+id] = tree.Node{Uuid: id, Path: "ds/folder/" + id, Type: tree.NodeType_LEAF}
+			data, _ := tool.GetEncrypted(ctx, "old", []byte("plain-"+id))
+			keys[id] = &encryption.NodeKey{NodeId: id, UserId: "ds:ds", OwnerId: "ds:ds", KeyData: data}
+		}
+		// Not encrypted
+		nodes["ds/folder/plain"] = tree.Node{Uuid: "plain", Path: "ds/folder/plain", Type: tree.NodeType_LEAF}
+		// Already rotated
+		data, _ := tool.GetEncrypted(ctx, "new", []byte("plain-node-0"))
+		keys["node-0"].KeyData = data
+
+		keysMock := &nodeKeysMock{keys: keys}
+		action := &RotateKeyAction{
+			nodeKeyClient: keysMock,
+			treeClient:    tree.NewNodeProviderMock(nodes),
+			keyTool:       tool,
+		}
+		channels, closer := drainChannels()
+		defer closer()
+
+		Convey("Verification fails before rotation", func() {
+			_, e := action.verify(ctx, channels, tool, "ds", "new", 2, 0, 1)
+			So(e, ShouldNotBeNil)
+			So(e.Error(), ShouldContainSubstring, "4 node(s)")
+		})
+
+		Convey("Rotation re-wraps remaining keys", func() {
+			stopped, e := action.rotate(ctx, channels, tool, "ds", rotation, 2, 0, 0.5)
+			So(e, ShouldBeNil)
+			So(stopped, ShouldBeFalse)
+			So(keysMock.sets, ShouldEqual, 4)
+			for i := 0; i < 5; i++ {
+				id := fmt.Sprintf("node-%d", i)
+				plain, e := tool.GetDecrypted(ctx, "new", keys[id].KeyData)
+				So(e, ShouldBeNil)
+				So(string(plain), ShouldEqual, "plain-"+id)
+				So(keys[id].OwnerId, ShouldEqual, "ds:ds")
+			}
+			stopped, e = action.verify(ctx, channels, tool, "ds", "new", 2, 0.5, 0.5)
+			So(e, ShouldBeNil)
+			So(stopped, ShouldBeFalse)
+
+			// Running again does nothing
+			_, e = action.rotate(ctx, channels, tool, "ds", rotation, 2, 0, 0.5)
+			So(e, ShouldBeNil)
+			So(keysMock.sets, ShouldEqual, 4)
+		})
+
+		Convey("Rotation can be stopped and resumed", func() {
+			channels.Stop <- true
+			stopped, e := action.rotate(ctx, channels, tool, "ds", rotation, 2, 0, 0.5)
+			So(e, ShouldBeNil)
+			So(stopped, ShouldBeTrue)
+			So(keysMock.sets, ShouldEqual, 0)
+
+			stopped, e = action.rotate(ctx, channels, tool, "ds", rotation, 2, 0, 0.5)
+			So(e, ShouldBeNil)
+			So(stopped, ShouldBeFalse)
+			So(keysMock.sets, ShouldEqual, 4)
+		})
+
+		Convey("Keys that cannot be decrypted are reported by verification", func() {
+			data, _ := tool.GetEncrypted(ctx, "other", []byte("plain-node-3"))
+			keys["node-3"].KeyData = data
+			_, e := action.rotate(ctx, channels, tool, "ds", rotation, 10, 0, 0.5)
+			So(e, ShouldBeNil)
+			So(keysMock.sets, ShouldEqual, 3)
+			_, e = action.verify(ctx, channels, tool, "ds", "new", 10, 0.5, 0.5)
+			So(e, ShouldNotBeNil)
+			So(e.Error(), ShouldContainSubstring, "node-3")
+		})
+
+	})
+}
@@ -20,7 +20,10 @@
 
 package idm
 
-import "github.com/pydio/cells/scheduler/actions"
+import (
+	"github.com/pydio/cells/common/auth/ldap"
+	"github.com/pydio/cells/scheduler/actions"
+)
 
 func init() {
 
@@ -28,5 +31,8 @@ func init() {
 	manager.Register(cleanUserDataName, func() actions.ConcreteAction {
 		return &CleanUserDataAction{}
 	})
+	manager.Register(ldap.SyncActionName, func() actions.ConcreteAction {
+		return &LdapSyncAction{}
+	})
 
 }
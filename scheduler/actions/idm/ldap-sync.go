@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package idm
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/ldap"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// LdapSyncAction synchronizes the users and groups of an LDAP connector into the users and roles services.
+type LdapSyncAction struct {
+	connector string
+	// newSyncer can be replaced for testing
+	newSyncer func(id string) (*ldap.Syncer, error)
+}
+
+func (c *LdapSyncAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              ldap.SyncActionName,
+		Label:           "LDAP synchronization",
+		Icon:            "account-multiple",
+		Category:        actions.ActionCategoryIDM,
+		Description:     "Synchronize users and groups of an LDAP / Active Directory connector, and disable users removed from the directory",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *LdapSyncAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "connector",
+					Type:        forms.ParamString,
+					Label:       "Connector",
+					Description: "Identifier of the LDAP connector in the authentication configuration",
+					Mandatory:   true,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+func (c *LdapSyncAction) GetName() string {
+	return ldap.SyncActionName
+}
+
+func (c *LdapSyncAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.connector = action.Parameters["connector"]
+	if c.connector == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameter connector in Action")
+	}
+	if c.newSyncer == nil {
+		c.newSyncer = func(id string) (*ldap.Syncer, error) {
+			conf, e := ldap.LoadConfig(id)
+			if e != nil {
+				return nil, e
+			}
+			return ldap.NewSyncer(id, conf), nil
+		}
+	}
+	return nil
+}
+
+func (c *LdapSyncAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	syncer, e := c.newSyncer(jobs.EvaluateFieldStr(ctx, input, c.connector))
+	if e != nil {
+		return input.WithError(e), e
+	}
+	log.TasksLogger(ctx).Info("Synchronizing users from directory " + syncer.Config.Host)
+	stats, e := syncer.Sync(ctx)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Synchronization failed: " + e.Error())
+		return input.WithError(e), e
+	}
+	log.TasksLogger(ctx).Info(stats.String())
+	output := input
+	jsonBody, _ := json.Marshal(stats)
+	output.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		StringBody: stats.String(),
+		JsonBody:   jsonBody,
+	})
+	return output, nil
+}
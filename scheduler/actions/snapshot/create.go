@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	createActionName = "actions.snapshot.create"
+)
+
+// CreateAction takes a named snapshot of a datasource index. Used in a scheduled job with the keep
+// parameter, it maintains a rolling set of snapshots.
+type CreateAction struct {
+	dsName string
+	name   string
+	keep   string
+
+	snapshotsClient sync.IndexSnapshotsClient
+}
+
+func (c *CreateAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              createActionName,
+		Label:           "Snapshot datasource",
+		Icon:            "camera",
+		Category:        actions.ActionCategoryScheduler,
+		Description:     "Capture the index of a datasource, along with the encryption keys of its files, in a named snapshot",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *CreateAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "dataSource",
+					Type:        forms.ParamString,
+					Label:       "DataSource",
+					Description: "Name of the datasource to capture",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "name",
+					Type:        forms.ParamString,
+					Label:       "Name",
+					Description: "Name of the snapshot, generated from the current date if empty",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "keep",
+					Type:        forms.ParamInteger,
+					Label:       "Keep",
+					Description: "If set, delete the oldest snapshots to keep only this number of snapshots",
+					Default:     0,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *CreateAction) GetName() string {
+	return createActionName
+}
+
+// Init passes parameters to the action
+func (c *CreateAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.dsName = action.Parameters["dataSource"]
+	if c.dsName == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameter dataSource in Action")
+	}
+	c.name = action.Parameters["name"]
+	c.keep = action.Parameters["keep"]
+	if cl == nil {
+		cl = defaults.NewClient()
+	}
+	if c.snapshotsClient == nil {
+		c.snapshotsClient = sync.NewIndexSnapshotsClient(common.ServiceGrpcNamespace_+common.ServiceDataIndex_+c.dsName, cl)
+	}
+	return nil
+}
+
+// Run asks the index service of the datasource to create the snapshot
+func (c *CreateAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	req := &sync.CreateSnapshotRequest{Name: jobs.EvaluateFieldStr(ctx, input, c.name)}
+	if c.keep != "" {
+		keep, e := jobs.EvaluateFieldInt64(ctx, input, c.keep)
+		if e != nil {
+			return input.WithError(e), e
+		}
+		req.Keep = int32(keep)
+	}
+	resp, e := c.snapshotsClient.CreateSnapshot(ctx, req)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cannot create snapshot for datasource "+c.dsName, zap.Error(e))
+		return input.WithError(e), e
+	}
+	snap := resp.GetSnapshot()
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Created snapshot %s of datasource %s (%d nodes, %d keys)", snap.GetName(), c.dsName, snap.GetNodes(), snap.GetKeys()))
+	if len(resp.Pruned) > 0 {
+		log.TasksLogger(ctx).Info("Deleted old snapshots " + strings.Join(resp.Pruned, ", "))
+	}
+	output := input
+	output.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		StringBody: snap.GetName(),
+	})
+	return output, nil
+}
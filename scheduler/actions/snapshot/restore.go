@@ -0,0 +1,376 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/sync/merger"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	restoreActionName = "actions.snapshot.restore"
+	// Maximum number of failing paths listed in the returned error
+	restoreMaxReported = 10
+)
+
+// RestoreAction restores a subtree of a datasource to the state captured by a snapshot. Files contents are
+// copied from the version matching the snapshot, or from a node still holding the same content, and the
+// encryption keys captured with the snapshot are re-applied to restored nodes that lost theirs. Nodes created
+// after the snapshot are only deleted if deleteNewer is set.
+type RestoreAction struct {
+	dsName      string
+	snapshot    string
+	path        string
+	dryRun      bool
+	deleteNewer bool
+
+	router          views.Handler
+	uuidRouter      views.Handler
+	snapshotsClient sync.IndexSnapshotsClient
+	versionClient   tree.NodeVersionerClient
+	nodeKeyClient   encryption.NodeKeyManagerClient
+}
+
+func (c *RestoreAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:              restoreActionName,
+		Label:           "Restore snapshot",
+		Icon:            "backup-restore",
+		Category:        actions.ActionCategoryScheduler,
+		Description:     "Restore a folder of a datasource to the state captured by a snapshot, using versions to recover files contents",
+		SummaryTemplate: "",
+		HasForm:         true,
+	}
+}
+
+func (c *RestoreAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "dataSource",
+					Type:        forms.ParamString,
+					Label:       "DataSource",
+					Description: "Name of the datasource to restore",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "snapshot",
+					Type:        forms.ParamString,
+					Label:       "Snapshot",
+					Description: "Name of the snapshot to restore",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "path",
+					Type:        forms.ParamString,
+					Label:       "Path",
+					Description: "Folder to restore, relative to the datasource root",
+					Default:     "/",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "deleteNewer",
+					Type:        forms.ParamBool,
+					Label:       "Delete newer nodes",
+					Description: "Delete files and folders created after the snapshot",
+					Default:     false,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "dryRun",
+					Type:        forms.ParamBool,
+					Label:       "Dry Run",
+					Description: "Only log the changes that would be applied",
+					Default:     false,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *RestoreAction) GetName() string {
+	return restoreActionName
+}
+
+// CanPause implements ControllableAction: pause is handled between two changes
+func (c *RestoreAction) CanPause() bool {
+	return true
+}
+
+// CanStop implements ControllableAction: stop is handled between two changes
+func (c *RestoreAction) CanStop() bool {
+	return true
+}
+
+// ProvidesProgress implements ProgressProviderAction
+func (c *RestoreAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (c *RestoreAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.dsName = action.Parameters["dataSource"]
+	c.snapshot = action.Parameters["snapshot"]
+	if c.dsName == "" || c.snapshot == "" {
+		return errors.BadRequest(common.ServiceTasks, "missing parameters dataSource or snapshot in Action")
+	}
+	c.path = "/"
+	if p, ok := action.Parameters["path"]; ok && p != "" {
+		c.path = p
+	}
+	c.dryRun = action.Parameters["dryRun"] == "true"
+	c.deleteNewer = action.Parameters["deleteNewer"] == "true"
+	if cl == nil {
+		cl = defaults.NewClient()
+	}
+	if c.router == nil {
+		c.router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+	if c.uuidRouter == nil {
+		c.uuidRouter = views.NewUuidRouter(views.RouterOptions{AdminView: true})
+	}
+	if c.snapshotsClient == nil {
+		c.snapshotsClient = sync.NewIndexSnapshotsClient(common.ServiceGrpcNamespace_+common.ServiceDataIndex_+c.dsName, cl)
+	}
+	if c.versionClient == nil {
+		c.versionClient = tree.NewNodeVersionerClient(common.ServiceGrpcNamespace_+common.ServiceVersions, cl)
+	}
+	if c.nodeKeyClient == nil {
+		c.nodeKeyClient = encryption.NewNodeKeyManagerClient(common.ServiceGrpcNamespace_+common.ServiceEncKey, cl)
+	}
+	return nil
+}
+
+// Run computes the changes from the current index to the snapshot, and applies them one by one.
+// Failing changes are logged and do not stop the restoration.
+func (c *RestoreAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	snapName := jobs.EvaluateFieldStr(ctx, input, c.snapshot)
+	root := strings.Trim(jobs.EvaluateFieldStr(ctx, input, c.path), "/")
+	resp, e := c.snapshotsClient.DiffSnapshots(ctx, &sync.DiffSnapshotsRequest{To: snapName, Path: root, WithKeys: true})
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cannot compute changes to snapshot "+snapName, zap.Error(e))
+		return input.WithError(e), e
+	}
+	changes := resp.GetChanges()
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Restoring /%s to snapshot %s: %d change(s) found", root, snapName, len(changes)))
+
+	var failed []string
+	for i, change := range changes {
+		if c.interrupted(channels) {
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Restoration interrupted after %d change(s)", i))
+			return input, nil
+		}
+		if e := c.apply(ctx, change); e != nil {
+			log.TasksLogger(ctx).Error(fmt.Sprintf("Cannot apply %s on %s", change.Type, change.Path), zap.Error(e))
+			failed = append(failed, change.Path)
+		}
+		channels.StatusMsg <- fmt.Sprintf("Restoring snapshot: %d/%d changes processed", i+1, len(changes))
+		channels.Progress <- float32(i+1) / float32(len(changes))
+	}
+	if len(failed) > 0 {
+		reported := failed
+		if len(reported) > restoreMaxReported {
+			reported = append(reported[:restoreMaxReported:restoreMaxReported], "...")
+		}
+		e := errors.InternalServerError(common.ServiceTasks, "%d change(s) could not be restored: %s", len(failed), strings.Join(reported, ", "))
+		return input.WithError(e), e
+	}
+	output := input
+	output.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		StringBody: fmt.Sprintf("%d change(s) restored from snapshot %s", len(changes), snapName),
+	})
+	return output, nil
+}
+
+// apply applies a single change on the datasource, through the router.
+func (c *RestoreAction) apply(ctx context.Context, change *sync.SnapshotChange) error {
+	target := path.Join(c.dsName, change.Path)
+	isDelete := change.Type == merger.OpDelete.String()
+	if isDelete && !c.deleteNewer {
+		log.TasksLogger(ctx).Info("Keeping " + change.Path + ", created after the snapshot")
+		return nil
+	}
+	if c.dryRun {
+		msg := "[Dry Run] " + change.Type + " " + change.Path
+		if change.MoveFrom != "" {
+			msg += " from " + change.MoveFrom
+		}
+		log.TasksLogger(ctx).Info(msg)
+		return nil
+	}
+	switch change.Type {
+	case merger.OpCreateFolder.String():
+		_, e := c.router.CreateNode(ctx, &tree.CreateNodeRequest{Node: &tree.Node{
+			Path: target,
+			Uuid: change.GetNode().GetUuid(),
+			Type: tree.NodeType_COLLECTION,
+		}})
+		return e
+	case merger.OpMoveFolder.String(), merger.OpMoveFile.String():
+		_, e := c.router.UpdateNode(ctx, &tree.UpdateNodeRequest{
+			From: &tree.Node{Path: path.Join(c.dsName, change.MoveFrom)},
+			To:   &tree.Node{Path: target},
+		})
+		return e
+	case merger.OpCreateFile.String(), merger.OpUpdateFile.String():
+		return c.restoreFile(ctx, target, change)
+	case merger.OpDelete.String():
+		return c.deleteNode(ctx, target)
+	}
+	return nil
+}
+
+// restoreFile copies the content of a file as captured by the snapshot, from the matching version, or from
+// a node still holding this content. The snapshot encryption key is then re-applied if the node has none.
+func (c *RestoreAction) restoreFile(ctx context.Context, target string, change *sync.SnapshotChange) error {
+	node := change.GetNode()
+	if node.GetUuid() == "" {
+		return fmt.Errorf("no uuid captured for %s", change.Path)
+	}
+	versionId, e := c.findVersion(ctx, node)
+	if e != nil {
+		return e
+	}
+	if versionId != "" {
+		_, e = c.router.CopyObject(ctx, &tree.Node{Uuid: node.Uuid, Path: target}, &tree.Node{Path: target}, &views.CopyRequestData{SrcVersionId: versionId})
+	} else if current := c.findCurrent(ctx, node); current != nil {
+		_, e = c.router.CopyObject(ctx, current, &tree.Node{Path: target}, &views.CopyRequestData{})
+	} else {
+		return fmt.Errorf("no version of %s matches the snapshot content", change.Path)
+	}
+	if e != nil {
+		return e
+	}
+	return c.restoreKey(ctx, change.GetNodeKey())
+}
+
+// findVersion returns the id of a version of the node whose content matches the node etag, if any.
+func (c *RestoreAction) findVersion(ctx context.Context, node *tree.Node) (string, error) {
+	stream, e := c.versionClient.ListVersions(ctx, &tree.ListVersionsRequest{Node: &tree.Node{Uuid: node.Uuid}})
+	if e != nil {
+		return "", e
+	}
+	defer stream.Close()
+	for {
+		resp, e := stream.Recv()
+		if e == io.EOF {
+			return "", nil
+		} else if e != nil {
+			return "", e
+		}
+		if v := resp.GetVersion(); v != nil && string(v.Data) == node.Etag {
+			return v.Uuid, nil
+		}
+	}
+}
+
+// findCurrent looks for the node by its uuid, and returns it if it still has the same content.
+func (c *RestoreAction) findCurrent(ctx context.Context, node *tree.Node) *tree.Node {
+	resp, e := c.uuidRouter.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Uuid: node.Uuid}})
+	if e != nil || resp.GetNode().GetEtag() != node.Etag {
+		return nil
+	}
+	return resp.GetNode()
+}
+
+// restoreKey sets the key captured by the snapshot for a node that has no key anymore.
+func (c *RestoreAction) restoreKey(ctx context.Context, key *encryption.NodeKey) error {
+	if key == nil {
+		return nil
+	}
+	_, e := c.nodeKeyClient.GetNodeInfo(ctx, &encryption.GetNodeInfoRequest{NodeId: key.NodeId, UserId: key.UserId})
+	if e == nil {
+		return nil
+	} else if code := errors.Parse(e.Error()).Code; code != 404 && code != 403 {
+		return e
+	}
+	if _, e := c.nodeKeyClient.SetNodeKey(ctx, &encryption.SetNodeKeyRequest{NodeKey: key}); e != nil {
+		return e
+	}
+	log.TasksLogger(ctx).Info("Restored encryption key of node " + key.NodeId)
+	return nil
+}
+
+// deleteNode deletes a node, and all its children if it is a folder.
+func (c *RestoreAction) deleteNode(ctx context.Context, target string) error {
+	resp, e := c.router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: target}})
+	if e != nil {
+		return e
+	}
+	node := resp.GetNode()
+	if !node.IsLeaf() {
+		stream, e := c.router.ListNodes(ctx, &tree.ListNodesRequest{Node: node, Recursive: true})
+		if e != nil {
+			return e
+		}
+		defer stream.Close()
+		for {
+			r, e := stream.Recv()
+			if e != nil {
+				break
+			}
+			if _, e := c.router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: r.GetNode()}); e != nil {
+				return e
+			}
+		}
+	}
+	_, e = c.router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: node})
+	return e
+}
+
+func (c *RestoreAction) interrupted(channels *actions.RunnableChannels) bool {
+	select {
+	case <-channels.Stop:
+		return true
+	case <-channels.Pause:
+		<-channels.BlockUntilResume()
+	default:
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/encryption"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/sync"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// recordingRouter records write operations as strings
+type recordingRouter struct {
+	*views.HandlerMock
+	ops []string
+}
+
+func (r *recordingRouter) CreateNode(ctx context.Context, in *tree.CreateNodeRequest, opts ...client.CallOption) (*tree.CreateNodeResponse, error) {
+	r.ops = append(r.ops, "mkdir "+in.Node.Path+" "+in.Node.Uuid)
+	return &tree.CreateNodeResponse{Node: in.Node}, nil
+}
+
+func (r *recordingRouter) UpdateNode(ctx context.Context, in *tree.UpdateNodeRequest, opts ...client.CallOption) (*tree.UpdateNodeResponse, error) {
+	r.ops = append(r.ops, "mv "+in.From.Path+" "+in.To.Path)
+	return &tree.UpdateNodeResponse{Node: in.To}, nil
+}
+
+func (r *recordingRouter) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	r.ops = append(r.ops, "rm "+in.Node.Path)
+	return &tree.DeleteNodeResponse{Success: true}, nil
+}
+
+func (r *recordingRouter) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *views.CopyRequestData) (int64, error) {
+	r.ops = append(r.ops, "cp "+from.Path+"@"+requestData.SrcVersionId+" "+to.Path)
+	return 0, nil
+}
+
+type snapshotsMock struct {
+	sync.IndexSnapshotsClient
+	changes []*sync.SnapshotChange
+	request *sync.DiffSnapshotsRequest
+}
+
+func (m *snapshotsMock) DiffSnapshots(ctx context.Context, in *sync.DiffSnapshotsRequest, opts ...client.CallOption) (*sync.DiffSnapshotsResponse, error) {
+	m.request = in
+	return &sync.DiffSnapshotsResponse{Changes: m.changes}, nil
+}
+
+type versionsStream struct {
+	tree.NodeVersioner_ListVersionsClient
+	versions []*tree.ChangeLog
+}
+
+func (s *versionsStream) Recv() (*tree.ListVersionsResponse, error) {
+	if len(s.versions) == 0 {
+		return nil, io.EOF
+	}
+	v := s.versions[0]
+	s.versions = s.versions[1:]
+	return &tree.ListVersionsResponse{Version: v}, nil
+}
+
+func (s *versionsStream) Close() error {
+	return nil
+}
+
+type versionsMock struct {
+	tree.NodeVersionerClient
+	versions map[string][]*tree.ChangeLog
+}
+
+func (m *versionsMock) ListVersions(ctx context.Context, in *tree.ListVersionsRequest, opts ...client.CallOption) (tree.NodeVersioner_ListVersionsClient, error) {
+	return &versionsStream{versions: m.versions[in.Node.Uuid]}, nil
+}
+
+type nodeKeysMock struct {
+	encryption.NodeKeyManagerClient
+	keys map[string]*encryption.NodeKey
+}
+
+func (m *nodeKeysMock) GetNodeInfo(ctx context.Context, in *encryption.GetNodeInfoRequest, opts ...client.CallOption) (*encryption.GetNodeInfoResponse, error) {
+	k, ok := m.keys[in.NodeId]
+	if !ok {
+		return nil, errors.NotFound("node.key.dao", "no key found for node %s", in.NodeId)
+	}
+	return &encryption.GetNodeInfoResponse{NodeInfo: &encryption.NodeInfo{NodeKey: k}}, nil
+}
+
+func (m *nodeKeysMock) SetNodeKey(ctx context.Context, in *encryption.SetNodeKeyRequest, opts ...client.CallOption) (*encryption.SetNodeKeyResponse, error) {
+	m.keys[in.NodeKey.NodeId] = in.NodeKey
+	return &encryption.SetNodeKeyResponse{}, nil
+}
+
+func drainChannels() (*actions.RunnableChannels, func()) {
+	channels := &actions.RunnableChannels{
+		Stop:      make(chan interface{}, 1),
+		Pause:     make(chan interface{}),
+		StatusMsg: make(chan string),
+		Progress:  make(chan float32),
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-channels.StatusMsg:
+			case <-channels.Progress:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return channels, func() { close(done) }
+}
+
+func TestRestoreAction_Init(t *testing.T) {
+	Convey("Test Init", t, func() {
+		action := &RestoreAction{}
+		So(action.GetName(), ShouldEqual, restoreActionName)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds"}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "snapshot": "s1", "deleteNewer": "true"}}), ShouldBeNil)
+		So(action.path, ShouldEqual, "/")
+		So(action.deleteNewer, ShouldBeTrue)
+		So(action.dryRun, ShouldBeFalse)
+		So(action.snapshotsClient, ShouldNotBeNil)
+		So(action.versionClient, ShouldNotBeNil)
+		So(action.nodeKeyClient, ShouldNotBeNil)
+	})
+}
+
+func TestRestoreAction_Run(t *testing.T) {
+
+	views.IsUnitTestEnv = true
+
+	Convey("Test restoration", t, func() {
+
+		ctx := context.Background()
+		key := &encryption.NodeKey{NodeId: "f1", UserId: "ds:ds", OwnerId: "ds:ds", KeyData: []byte("key")}
+		snaps := &snapshotsMock{changes: []*sync.SnapshotChange{
+			{Type: "CreateFolder", Path: "a/sub", Node: &tree.Node{Uuid: "sub", Type: tree.NodeType_COLLECTION}},
+			{Type: "MoveFile", Path: "a/f3", MoveFrom: "a/moved"},
+			{Type: "UpdateFile", Path: "a/f1", Node: &tree.Node{Uuid: "f1", Etag: "etag-1"}, NodeKey: key},
+			{Type: "CreateFile", Path: "a/f2", Node: &tree.Node{Uuid: "f2", Etag: "etag-2"}},
+			{Type: "Delete", Path: "a/new"},
+		}}
+		versions := &versionsMock{versions: map[string][]*tree.ChangeLog{
+			"f1": {{Uuid: "v2", Data: []byte("etag-1b")}, {Uuid: "v1", Data: []byte("etag-1")}},
+		}}
+		keys := &nodeKeysMock{keys: map[string]*encryption.NodeKey{}}
+		router := &recordingRouter{HandlerMock: &views.HandlerMock{Nodes: map[string]*tree.Node{
+			"ds/a/new":       {Uuid: "new", Path: "ds/a/new", Type: tree.NodeType_COLLECTION},
+			"ds/a/new/child": {Uuid: "child", Path: "ds/a/new/child", Type: tree.NodeType_LEAF},
+		}}}
+		uuidRouter := &views.HandlerMock{Nodes: map[string]*tree.Node{}}
+		action := &RestoreAction{
+			router:          router,
+			uuidRouter:      uuidRouter,
+			snapshotsClient: snaps,
+			versionClient:   versions,
+			nodeKeyClient:   keys,
+		}
+		channels, closer := drainChannels()
+		defer closer()
+
+		Convey("Files without matching version are reported", func() {
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "snapshot": "s1", "path": "/a"}}), ShouldBeNil)
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldNotBeNil)
+			So(e.Error(), ShouldContainSubstring, "a/f2")
+			So(snaps.request.To, ShouldEqual, "s1")
+			So(snaps.request.Path, ShouldEqual, "a")
+			So(snaps.request.WithKeys, ShouldBeTrue)
+			So(router.ops, ShouldResemble, []string{
+				"mkdir ds/a/sub sub",
+				"mv ds/a/moved ds/a/f3",
+				"cp ds/a/f1@v1 ds/a/f1",
+			})
+			So(keys.keys, ShouldContainKey, "f1")
+		})
+
+		Convey("Content is copied from a node still holding it, and newer nodes are deleted", func() {
+			uuidRouter.Nodes[""] = &tree.Node{Uuid: "f2", Path: "ds/a/copy", Etag: "etag-2"}
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "snapshot": "s1", "deleteNewer": "true"}}), ShouldBeNil)
+			out, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(out.GetLastOutput().GetSuccess(), ShouldBeTrue)
+			So(router.ops[3:], ShouldResemble, []string{
+				"cp ds/a/copy@ ds/a/f2",
+				"rm ds/a/new/child",
+				"rm ds/a/new",
+			})
+		})
+
+		Convey("Dry run does not modify anything", func() {
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "snapshot": "s1", "dryRun": "true", "deleteNewer": "true"}}), ShouldBeNil)
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(router.ops, ShouldBeEmpty)
+			So(keys.keys, ShouldBeEmpty)
+		})
+
+		Convey("Restoration can be stopped", func() {
+			So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"dataSource": "ds", "snapshot": "s1"}}), ShouldBeNil)
+			channels.Stop <- true
+			_, e := action.Run(ctx, channels, jobs.ActionMessage{})
+			So(e, ShouldBeNil)
+			So(router.ops, ShouldBeEmpty)
+		})
+
+	})
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package bundle provides tools to export jobs definitions as portable documents and to import them back.
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/errors"
+	"github.com/pborman/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+const (
+	// Version is the current format version of the bundles
+	Version = "cells.jobs/v1"
+
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ImportOptions configures the import of a bundle
+type ImportOptions struct {
+	// OwnersMapping replaces original owners by new ones. Use "*" as key to replace all other owners.
+	OwnersMapping map[string]string
+	// DryRun only computes changes
+	DryRun bool
+}
+
+// Load finds jobs by their IDs, or all custom jobs if ids is empty.
+func Load(ctx context.Context, cli jobs.JobServiceClient, ids []string) ([]*jobs.Job, error) {
+	streamer, err := cli.ListJobs(ctx, &jobs.ListJobsRequest{JobIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	defer streamer.Close()
+	var jj []*jobs.Job
+	for {
+		resp, e := streamer.Recv()
+		if e != nil {
+			break
+		}
+		if resp == nil || resp.Job == nil {
+			continue
+		}
+		if len(ids) == 0 && !resp.Job.Custom {
+			continue
+		}
+		jj = append(jj, resp.Job)
+	}
+	if len(ids) > 0 && len(jj) != len(ids) {
+		return nil, errors.NotFound(common.ServiceJobs, "some jobs could not be found")
+	}
+	return jj, nil
+}
+
+// Export serializes the jobs to a JobsBundle document in the given format.
+func Export(jj []*jobs.Job, format string) ([]byte, error) {
+	b := &rest.JobsBundle{
+		Version: Version,
+		Created: int32(time.Now().Unix()),
+	}
+	for _, j := range jj {
+		b.Jobs = append(b.Jobs, clean(j))
+	}
+	return marshal(b, format)
+}
+
+// Parse reads a JobsBundle document, either in JSON or YAML, and checks its version.
+func Parse(content []byte) (*rest.JobsBundle, error) {
+	data := bytes.TrimSpace(content)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		var e error
+		if data, e = yaml.YAMLToJSON(data); e != nil {
+			return nil, errors.BadRequest(common.ServiceJobs, "cannot parse bundle: %s", e.Error())
+		}
+	}
+	b := &rest.JobsBundle{}
+	if e := jsonpb.Unmarshal(bytes.NewReader(data), b); e != nil {
+		return nil, errors.BadRequest(common.ServiceJobs, "cannot parse bundle: %s", e.Error())
+	}
+	if b.Version != Version {
+		return nil, errors.BadRequest(common.ServiceJobs, "unsupported bundle version %s (expected %s)", b.Version, Version)
+	}
+	return b, nil
+}
+
+// Import validates the jobs of the bundle and compares them to the existing ones. Unless opts.DryRun is set,
+// new or modified jobs are saved. Invalid jobs are skipped and reported in the results.
+func Import(ctx context.Context, cli jobs.JobServiceClient, b *rest.JobsBundle, opts ImportOptions) ([]*rest.ImportJobResult, error) {
+
+	var results []*rest.ImportJobResult
+	for _, j := range b.Jobs {
+		job := clean(j)
+		if job.ID == "" {
+			job.ID = uuid.New()
+		}
+		if o, ok := opts.OwnersMapping[job.Owner]; ok {
+			job.Owner = o
+		} else if o, ok := opts.OwnersMapping["*"]; ok {
+			job.Owner = o
+		}
+		result := &rest.ImportJobResult{JobID: job.ID, Label: job.Label}
+		results = append(results, result)
+
+		if e := Validate(job); e != nil {
+			result.Status = rest.ImportJobStatus_Invalid
+			result.Error = e.Error()
+			continue
+		}
+
+		var existing *jobs.Job
+		if resp, e := cli.GetJob(ctx, &jobs.GetJobRequest{JobID: job.ID}); e == nil && resp.Job != nil {
+			existing = clean(resp.Job)
+		} else if e != nil && errors.Parse(e.Error()).Code != 404 {
+			return nil, e
+		}
+		diff, e := Diff(existing, job)
+		if e != nil {
+			return nil, e
+		}
+		result.Diff = diff
+		if existing == nil {
+			result.Status = rest.ImportJobStatus_Create
+		} else if diff == "" {
+			result.Status = rest.ImportJobStatus_Unchanged
+			continue
+		} else {
+			result.Status = rest.ImportJobStatus_Update
+		}
+
+		if opts.DryRun {
+			continue
+		}
+		if _, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: job}); e != nil {
+			result.Status = rest.ImportJobStatus_Invalid
+			result.Error = e.Error()
+		}
+	}
+
+	return results, nil
+}
+
+// Validate checks the job structure and verifies that all actions are registered in the ActionsManager.
+func Validate(job *jobs.Job) error {
+	if e := job.Validate(); e != nil {
+		return e
+	}
+	var unknown []string
+	var walk func(aa []*jobs.Action)
+	walk = func(aa []*jobs.Action) {
+		for _, a := range aa {
+			if _, ok := actions.GetActionsManager().ActionById(a.ID); !ok {
+				unknown = append(unknown, a.ID)
+			}
+			walk(a.ChainedActions)
+			walk(a.FailedFilterActions)
+		}
+	}
+	walk(job.Actions)
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown actions: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Diff computes a unified diff between the YAML representations of two jobs. Existing may be nil.
+// It returns an empty string if both jobs are identical.
+func Diff(existing, imported *jobs.Job) (string, error) {
+	var a, b []byte
+	var e error
+	if existing != nil {
+		if a, e = marshal(existing, FormatYAML); e != nil {
+			return "", e
+		}
+	}
+	if b, e = marshal(imported, FormatYAML); e != nil {
+		return "", e
+	}
+	if bytes.Equal(a, b) {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: "existing",
+		ToFile:   "imported",
+		Context:  3,
+	})
+}
+
+// clean removes runtime information from a job.
+func clean(j *jobs.Job) *jobs.Job {
+	c := proto.Clone(j).(*jobs.Job)
+	c.Tasks = nil
+	return c
+}
+
+func marshal(msg proto.Message, format string) ([]byte, error) {
+	m := &jsonpb.Marshaler{Indent: "  "}
+	s, e := m.MarshalToString(msg)
+	if e != nil {
+		return nil, e
+	}
+	switch format {
+	case FormatYAML:
+		return yaml.JSONToYAML([]byte(s))
+	case FormatJSON, "":
+		return []byte(s), nil
+	default:
+		return nil, errors.BadRequest(common.ServiceJobs, "unsupported format %s", format)
+	}
+}
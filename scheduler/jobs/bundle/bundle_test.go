@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/rest"
+
+	_ "github.com/pydio/cells/scheduler/actions/scheduler"
+)
+
+// memClient stores jobs in memory. Unused methods of the interface are left unimplemented.
+type memClient struct {
+	jobs.JobServiceClient
+	store map[string]*jobs.Job
+}
+
+func (m *memClient) GetJob(ctx context.Context, in *jobs.GetJobRequest, opts ...client.CallOption) (*jobs.GetJobResponse, error) {
+	if j, ok := m.store[in.JobID]; ok {
+		return &jobs.GetJobResponse{Job: j}, nil
+	}
+	return nil, errors.NotFound(common.ServiceJobs, "Job ID not found")
+}
+
+func (m *memClient) PutJob(ctx context.Context, in *jobs.PutJobRequest, opts ...client.CallOption) (*jobs.PutJobResponse, error) {
+	m.store[in.Job.ID] = in.Job
+	return &jobs.PutJobResponse{Job: in.Job}, nil
+}
+
+func testJob(id string) *jobs.Job {
+	actionID := "actions.internal.prune-jobs"
+	return &jobs.Job{
+		ID:     id,
+		Label:  "Test Job " + id,
+		Owner:  "admin",
+		Custom: true,
+		Actions: []*jobs.Action{{
+			ID:         actionID,
+			Parameters: map[string]string{"param": "value"},
+			NodesSelector: &jobs.NodesSelector{
+				Label: "Selector",
+			},
+			ChainedActions: []*jobs.Action{{ID: actionID}},
+		}},
+		Parameters: []*jobs.JobParameter{{Name: "param", Value: "value", Type: "text"}},
+		Tasks:      []*jobs.Task{{ID: "task"}},
+	}
+}
+
+func TestExportParse(t *testing.T) {
+
+	Convey("Export and parse bundles", t, func() {
+
+		for _, format := range []string{FormatJSON, FormatYAML} {
+			content, e := Export([]*jobs.Job{testJob("job1"), testJob("job2")}, format)
+			So(e, ShouldBeNil)
+			So(string(content), ShouldContainSubstring, Version)
+			So(string(content), ShouldNotContainSubstring, "task")
+
+			b, e := Parse(content)
+			So(e, ShouldBeNil)
+			So(b.Jobs, ShouldHaveLength, 2)
+			So(b.Jobs[0].Label, ShouldEqual, "Test Job job1")
+			So(b.Jobs[0].Actions[0].ChainedActions, ShouldHaveLength, 1)
+			So(b.Jobs[0].Parameters[0].Value, ShouldEqual, "value")
+		}
+
+		_, e := Export([]*jobs.Job{testJob("job1")}, "xml")
+		So(e, ShouldNotBeNil)
+		_, e = Parse([]byte(`{"Version":"cells.jobs/v0"}`))
+		So(e, ShouldNotBeNil)
+		_, e = Parse([]byte("Version: [unclosed"))
+		So(e, ShouldNotBeNil)
+
+	})
+}
+
+func TestValidate(t *testing.T) {
+
+	Convey("Validate actions IDs", t, func() {
+
+		j := testJob("job1")
+		So(Validate(j), ShouldBeNil)
+		j.Actions[0].ChainedActions = append(j.Actions[0].ChainedActions, &jobs.Action{ID: "actions.unknown"})
+		e := Validate(j)
+		So(e, ShouldNotBeNil)
+		So(e.Error(), ShouldContainSubstring, "actions.unknown")
+
+	})
+}
+
+func TestImport(t *testing.T) {
+
+	Convey("Import bundles", t, func() {
+
+		ctx := context.Background()
+		existing := testJob("job1")
+		cli := &memClient{store: map[string]*jobs.Job{"job1": existing}}
+
+		modified := testJob("job1")
+		modified.Actions[0].Parameters["param"] = "other"
+		invalid := testJob("job3")
+		invalid.Actions[0].ID = "actions.unknown"
+		invalid.Actions[0].NodesSelector.Pathes = []string{"/path"}
+		b := &rest.JobsBundle{Version: Version, Jobs: []*jobs.Job{modified, testJob("job2"), invalid}}
+
+		results, e := Import(ctx, cli, b, ImportOptions{DryRun: true, OwnersMapping: map[string]string{"*": "ops"}})
+		So(e, ShouldBeNil)
+		So(results, ShouldHaveLength, 3)
+		So(results[0].Status, ShouldEqual, rest.ImportJobStatus_Update)
+		So(results[0].Diff, ShouldContainSubstring, "-    param: value")
+		So(results[0].Diff, ShouldContainSubstring, "+    param: other")
+		So(results[0].Diff, ShouldContainSubstring, "+Owner: ops")
+		So(results[1].Status, ShouldEqual, rest.ImportJobStatus_Create)
+		So(results[2].Status, ShouldEqual, rest.ImportJobStatus_Invalid)
+		So(results[2].Error, ShouldContainSubstring, "actions.unknown")
+		// Dry run did not modify anything
+		So(cli.store, ShouldHaveLength, 1)
+		So(cli.store["job1"], ShouldEqual, existing)
+
+		results, e = Import(ctx, cli, b, ImportOptions{OwnersMapping: map[string]string{"admin": "ops"}})
+		So(e, ShouldBeNil)
+		So(cli.store, ShouldHaveLength, 2)
+		So(cli.store["job1"].Owner, ShouldEqual, "ops")
+		So(cli.store["job1"].Actions[0].Parameters["param"], ShouldEqual, "other")
+		So(cli.store["job2"].Tasks, ShouldBeEmpty)
+
+		results, e = Import(ctx, cli, b, ImportOptions{OwnersMapping: map[string]string{"admin": "ops"}})
+		So(e, ShouldBeNil)
+		So(results[0].Status, ShouldEqual, rest.ImportJobStatus_Unchanged)
+		So(results[0].Diff, ShouldBeEmpty)
+
+	})
+}
@@ -21,12 +21,15 @@
 package rest
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	json "github.com/pydio/cells/x/jsonx"
 
 	"github.com/emicklei/go-restful"
 	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
 	"go.uber.org/zap"
 
 	"strings"
@@ -43,6 +46,7 @@ import (
 	"github.com/pydio/cells/common/service"
 	"github.com/pydio/cells/common/utils/i18n"
 	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/jobs/bundle"
 	"github.com/pydio/cells/scheduler/lang"
 )
 
@@ -196,6 +200,110 @@ func (s *JobsHandler) UserDeleteTasks(req *restful.Request, rsp *restful.Respons
 
 }
 
+// PreviewSchedule computes the next run times of a schedule, be it defined by an ISO8601 string or a Cron expression
+func (s *JobsHandler) PreviewSchedule(req *restful.Request, rsp *restful.Response) {
+
+	var request rest.SchedulePreviewRequest
+	if err := req.ReadEntity(&request); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	if request.Schedule == nil {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceJobs, "please provide a schedule"))
+		return
+	}
+	ticker, err := request.Schedule.TickerSchedule()
+	if err != nil {
+		service.RestErrorDetect(req, rsp, errors.BadRequest(common.ServiceJobs, "%s", err.Error()))
+		return
+	}
+	count := int(request.Count)
+	if count <= 0 {
+		count = 5
+	} else if count > 100 {
+		count = 100
+	}
+	response := &rest.SchedulePreviewResponse{}
+	for _, t := range ticker.NextRuns(time.Now(), count) {
+		response.NextRuns = append(response.NextRuns, int32(t.Unix()))
+	}
+	rsp.WriteEntity(response)
+
+}
+
+// ExportJobs serializes one or more jobs to a portable bundle
+func (s *JobsHandler) ExportJobs(req *restful.Request, rsp *restful.Response) {
+
+	var request rest.ExportJobsRequest
+	if err := req.ReadEntity(&request); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	ctx := req.Request.Context()
+	if !isAdmin(ctx) {
+		service.RestError403(req, rsp, errors.Forbidden(common.ServiceJobs, "only admins can export jobs"))
+		return
+	}
+	cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+	jj, err := bundle.Load(ctx, cli, request.JobIDs)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	format := request.Format
+	if format == "" {
+		format = bundle.FormatJSON
+	}
+	content, err := bundle.Export(jj, format)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	rsp.WriteEntity(&rest.ExportJobsResponse{Content: string(content), Format: format})
+
+}
+
+// ImportJobs loads jobs from a bundle, validates them and saves them, unless DryRun is set.
+func (s *JobsHandler) ImportJobs(req *restful.Request, rsp *restful.Response) {
+
+	var request rest.ImportJobsRequest
+	if err := req.ReadEntity(&request); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	ctx := req.Request.Context()
+	if !isAdmin(ctx) {
+		service.RestError403(req, rsp, errors.Forbidden(common.ServiceJobs, "only admins can import jobs"))
+		return
+	}
+	b, err := bundle.Parse([]byte(request.Content))
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	cli := jobs.NewJobServiceClient(common.ServiceGrpcNamespace_+common.ServiceJobs, defaults.NewClient())
+	results, err := bundle.Import(ctx, cli, b, bundle.ImportOptions{
+		OwnersMapping: request.OwnersMapping,
+		DryRun:        request.DryRun,
+	})
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	if !request.DryRun {
+		log.Auditer(ctx).Info(fmt.Sprintf("Imported jobs bundle containing %d job(s)", len(results)))
+	}
+	rsp.WriteEntity(&rest.ImportJobsResponse{Results: results})
+
+}
+
+func isAdmin(ctx context.Context) bool {
+	if c, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok {
+		return c.Profile == common.PydioProfileAdmin
+	}
+	return false
+}
+
 func (s *JobsHandler) UserCreateJob(req *restful.Request, rsp *restful.Response) {
 
 	var request rest.UserJobRequest
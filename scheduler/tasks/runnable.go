@@ -23,6 +23,7 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path"
 	"strings"
 	"time"
@@ -40,6 +41,9 @@ import (
 	"github.com/pydio/cells/scheduler/actions"
 )
 
+// attemptGracePeriod is the time given to an action implementation to stop once its attempt timed out
+var attemptGracePeriod = 10 * time.Second
+
 // Runnable represents the runnable instance of a given task
 type Runnable struct {
 	jobs.Action
@@ -181,9 +185,7 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 		log.TasksLogger(r.Context).Warn("Skipping action " + r.ID + " as it is flagged Bypass. Forwarding input to output.")
 		outputMessage = r.Message
 	} else {
-		runnableChannels, done := r.Task.GetRunnableChannels()
-		outputMessage, err = r.Implementation.Run(r.Context, runnableChannels, r.Message)
-		close(done)
+		outputMessage, err = r.runWithRetry()
 	}
 	r.Task.Done(1)
 
@@ -194,7 +196,9 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 		r.Task.Save()
 		return err
 	}
-	r.Task.AppendLog(r.Action, r.Message, outputMessage)
+	if r.Action.RetryPolicy == nil {
+		r.Task.AppendLog(r.Action, r.Message, outputMessage)
+	}
 
 	if !r.Action.BreakAfter {
 		r.Dispatch(r.ActionPath, outputMessage, r.ChainedActions, Queue)
@@ -210,3 +214,85 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 
 	return nil
 }
+
+// runWithRetry runs the concrete implementation, retrying it as long as the action RetryPolicy allows it.
+// When a RetryPolicy is set, each attempt is recorded in the task ActionsLogs.
+func (r *Runnable) runWithRetry() (jobs.ActionMessage, error) {
+	policy := r.Action.RetryPolicy
+	maxAttempts := policy.Attempts()
+	for attempt := 1; ; attempt++ {
+		output, timeout, running, err := r.runAttempt(r.Action.GetTimeoutDuration())
+		if policy != nil {
+			logged := output
+			if err != nil {
+				logged = r.Message.WithError(err)
+			}
+			r.Task.AppendLog(r.Action, r.Message, logged, int32(attempt))
+		}
+		if err == nil || attempt >= maxAttempts || !policy.IsRetryable(err, timeout) {
+			return output, err
+		}
+		if running {
+			// Never run two attempts concurrently
+			log.TasksLogger(r.Context).Error(fmt.Sprintf("Action %s did not stop after timing out, it will not be retried", r.ID))
+			return output, err
+		}
+		backoff := policy.Backoff(attempt)
+		log.TasksLogger(r.Context).Warn(fmt.Sprintf("Action %s failed (attempt %d/%d), retrying in %s", r.ID, attempt, maxAttempts, backoff), zap.Error(err))
+		select {
+		case <-time.After(backoff):
+		case <-r.Context.Done():
+			return output, r.Context.Err()
+		}
+	}
+}
+
+// runAttempt runs the concrete implementation once. If timeout is greater than 0, the context passed to the
+// implementation is cancelled after this duration and the attempt returns an error once the implementation
+// has stopped, or after attemptGracePeriod if it does not, in which case running is true.
+func (r *Runnable) runAttempt(timeout time.Duration) (output jobs.ActionMessage, timedOut bool, running bool, err error) {
+	runnableChannels, done := r.Task.GetRunnableChannels()
+	if timeout == 0 {
+		output, err = r.Implementation.Run(r.Context, runnableChannels, r.Message)
+		close(done)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context, timeout)
+	defer cancel()
+	type result struct {
+		output jobs.ActionMessage
+		err    error
+		panic  interface{}
+	}
+	results := make(chan result, 1)
+	go func() {
+		defer func() {
+			// Status channels are closed only once the implementation has really returned
+			close(done)
+			if re := recover(); re != nil {
+				results <- result{panic: re}
+			}
+		}()
+		o, e := r.Implementation.Run(ctx, runnableChannels, r.Message)
+		results <- result{output: o, err: e}
+	}()
+
+	select {
+	case res := <-results:
+		if res.panic != nil {
+			panic(res.panic)
+		}
+		return res.output, false, false, res.err
+	case <-ctx.Done():
+		select {
+		case <-done:
+		case <-time.After(attemptGracePeriod):
+			running = true
+		}
+		if r.Context.Err() != nil {
+			return r.Message, false, running, r.Context.Err()
+		}
+		return r.Message, true, running, errors.New(common.ServiceJobs, fmt.Sprintf("action %s timed out after %s", r.ID, timeout), http.StatusRequestTimeout)
+	}
+}
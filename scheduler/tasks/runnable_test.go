@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// flakyAction fails a given number of times before succeeding, blocks until its context is done, or
+// blocks until released whatever its context.
+type flakyAction struct {
+	failures int
+	err      error
+	block    bool
+	stuck    chan struct{}
+	runs     int32
+	running  int32
+	overlaps int32
+}
+
+func (f *flakyAction) GetName() string {
+	return "actions.test.flaky"
+}
+
+func (f *flakyAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	return nil
+}
+
+func (f *flakyAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	atomic.AddInt32(&f.runs, 1)
+	if atomic.AddInt32(&f.running, 1) > 1 {
+		atomic.AddInt32(&f.overlaps, 1)
+	}
+	defer atomic.AddInt32(&f.running, -1)
+	if f.stuck != nil {
+		<-f.stuck
+		return input, fmt.Errorf("released")
+	}
+	if f.block {
+		<-ctx.Done()
+		return input, ctx.Err()
+	}
+	if int(atomic.LoadInt32(&f.runs)) <= f.failures {
+		return input, f.err
+	}
+	return input.WithIgnore(), nil
+}
+
+func newFlakyRunnable(impl *flakyAction, action *jobs.Action) Runnable {
+	actions.GetActionsManager().Register(impl.GetName(), func() actions.ConcreteAction {
+		return impl
+	})
+	action.ID = impl.GetName()
+	task := NewTaskFromEvent(context.Background(), &jobs.Job{ID: "ajob"}, &jobs.JobTriggerEvent{JobID: "ajob"})
+	task.Add(1)
+	return NewRunnable(context.Background(), "ROOT", 0, nil, task, action, jobs.ActionMessage{})
+}
+
+func TestRunnableRetry(t *testing.T) {
+
+	Convey("Test action retry policy", t, func() {
+
+		impl := &flakyAction{failures: 2, err: errors.InternalServerError("test", "transient error")}
+		r := newFlakyRunnable(impl, &jobs.Action{RetryPolicy: &jobs.ActionRetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: "1ms",
+		}})
+		So(r.RunAction(make(chan Runnable, 10)), ShouldBeNil)
+		So(impl.runs, ShouldEqual, 3)
+		logs := r.Task.GetJobTaskClone().ActionsLogs
+		So(logs, ShouldHaveLength, 3)
+		for i, l := range logs {
+			So(l.Attempt, ShouldEqual, i+1)
+		}
+		So(logs[0].OutputMessage.GetLastOutput().ErrorString, ShouldContainSubstring, "transient error")
+		So(logs[2].OutputMessage.GetLastOutput().Ignored, ShouldBeTrue)
+		So(r.Task.GetJobTaskClone().Status, ShouldEqual, jobs.TaskStatus_Finished)
+
+	})
+
+	Convey("Test non-retryable errors", t, func() {
+
+		impl := &flakyAction{failures: 2, err: errors.NotFound("test", "not found")}
+		r := newFlakyRunnable(impl, &jobs.Action{RetryPolicy: &jobs.ActionRetryPolicy{
+			MaxAttempts:     3,
+			InitialBackoff:  "1ms",
+			RetryableCodes:  []int32{500, 503},
+			RetryableErrors: []string{"(?i)connection reset"},
+		}})
+		So(r.RunAction(make(chan Runnable, 10)), ShouldNotBeNil)
+		So(impl.runs, ShouldEqual, 1)
+		So(r.Task.GetJobTaskClone().ActionsLogs, ShouldHaveLength, 1)
+		So(r.Task.GetJobTaskClone().Status, ShouldEqual, jobs.TaskStatus_Error)
+
+		impl = &flakyAction{failures: 1, err: fmt.Errorf("read: Connection reset by peer")}
+		r = newFlakyRunnable(impl, &jobs.Action{RetryPolicy: &jobs.ActionRetryPolicy{
+			MaxAttempts:     3,
+			InitialBackoff:  "1ms",
+			RetryableErrors: []string{"(?i)connection reset"},
+		}})
+		So(r.RunAction(make(chan Runnable, 10)), ShouldBeNil)
+		So(impl.runs, ShouldEqual, 2)
+
+	})
+
+	Convey("Test action timeout", t, func() {
+
+		impl := &flakyAction{block: true}
+		r := newFlakyRunnable(impl, &jobs.Action{Timeout: "10ms"})
+		start := time.Now()
+		e := r.RunAction(make(chan Runnable, 10))
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 408)
+		So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+		So(r.Task.GetJobTaskClone().Status, ShouldEqual, jobs.TaskStatus_Error)
+
+		impl = &flakyAction{block: true}
+		r = newFlakyRunnable(impl, &jobs.Action{Timeout: "10ms", RetryPolicy: &jobs.ActionRetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: "1ms",
+			RetryOnTimeout: true,
+		}})
+		So(r.RunAction(make(chan Runnable, 10)), ShouldNotBeNil)
+		So(impl.runs, ShouldEqual, 2)
+		So(impl.overlaps, ShouldEqual, 0)
+		So(r.Task.GetJobTaskClone().ActionsLogs, ShouldHaveLength, 2)
+
+		// Implementation ignoring its context is not retried while still running
+		grace := attemptGracePeriod
+		attemptGracePeriod = 20 * time.Millisecond
+		defer func() {
+			attemptGracePeriod = grace
+		}()
+		impl = &flakyAction{stuck: make(chan struct{})}
+		r = newFlakyRunnable(impl, &jobs.Action{Timeout: "10ms", RetryPolicy: &jobs.ActionRetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: "1ms",
+			RetryOnTimeout: true,
+		}})
+		e = r.RunAction(make(chan Runnable, 10))
+		So(e, ShouldNotBeNil)
+		So(errors.Parse(e.Error()).Code, ShouldEqual, 408)
+		So(atomic.LoadInt32(&impl.runs), ShouldEqual, 1)
+		close(impl.stuck)
+
+	})
+
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+
+	Convey("Test retry backoff computation", t, func() {
+
+		var p *jobs.ActionRetryPolicy
+		So(p.Attempts(), ShouldEqual, 1)
+		p = &jobs.ActionRetryPolicy{MaxAttempts: 4, InitialBackoff: "1s", MaxBackoff: "3s"}
+		So(p.Attempts(), ShouldEqual, 4)
+		So(p.Backoff(1), ShouldEqual, time.Second)
+		So(p.Backoff(2), ShouldEqual, 2*time.Second)
+		So(p.Backoff(3), ShouldEqual, 3*time.Second)
+		p.BackoffMultiplier = 1.5
+		p.MaxBackoff = ""
+		So(p.Backoff(3), ShouldEqual, 2250*time.Millisecond)
+
+	})
+}
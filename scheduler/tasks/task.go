@@ -139,7 +139,9 @@ func (t *Task) SetHasProgress() {
 	t.lockedTask.HasProgress = true
 }
 
-func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMessage) {
+// AppendLog stores a cleaned version of the action input and output in the task ActionsLogs.
+// An optional attempt number can be passed for actions defining a RetryPolicy.
+func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMessage, attempt ...int32) {
 	t.lockTask()
 	defer t.unlockTask()
 	// Remove unnecessary fields
@@ -159,11 +161,15 @@ func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMe
 		cleanedOutput.OutputChain = append(cleanedOutput.OutputChain, lastMessage)
 	}
 
-	t.lockedTask.ActionsLogs = append(t.lockedTask.ActionsLogs, &jobs.ActionLog{
+	actionLog := &jobs.ActionLog{
 		Action:        &cleanedAction,
 		InputMessage:  &cleanedInput,
 		OutputMessage: &cleanedOutput,
-	})
+	}
+	if len(attempt) > 0 {
+		actionLog.Attempt = attempt[0]
+	}
+	t.lockedTask.ActionsLogs = append(t.lockedTask.ActionsLogs, actionLog)
 }
 
 func (t *Task) GlobalError(e error) {
@@ -127,8 +127,7 @@ func (e *EventProducer) StartOrUpdateJob(job *jobs.Job) {
 	jobId := job.ID
 	e.StopWaiter(jobId)
 
-	//schedule := job.Schedule
-	if s, err := schedule.NewTickerScheduleFromISO(job.Schedule.Iso8601Schedule); err == nil {
+	if s, err := job.Schedule.TickerSchedule(); err == nil {
 		w := schedule.NewTicker(s, func() error {
 			e.EventChan <- &jobs.JobTriggerEvent{
 				JobID:    jobId,